@@ -1,6 +1,86 @@
 package accounts
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/exchange"
+	"github.com/dalmarcogd/dock-test/internal/products"
+	"github.com/google/uuid"
+)
+
+// Restriction blocks a single kind of operation while the account stays active.
+type Restriction string
+
+const (
+	DebitsRestriction  Restriction = "DEBITS"
+	CreditsRestriction Restriction = "CREDITS"
+	P2POutRestriction  Restriction = "P2P_OUT"
+)
+
+var Restrictions = []Restriction{DebitsRestriction, CreditsRestriction, P2POutRestriction}
+
+func (r Restriction) Valid() bool {
+	for _, v := range Restrictions {
+		if r == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HolderRole is the relationship between a holder and an account.
+type HolderRole string
+
+const (
+	OwnerHolderRole    HolderRole = "OWNER"
+	CoOwnerHolderRole  HolderRole = "CO_OWNER"
+	OperatorHolderRole HolderRole = "AUTHORIZED_OPERATOR"
+)
+
+var HolderRoles = []HolderRole{OwnerHolderRole, CoOwnerHolderRole, OperatorHolderRole}
+
+func (r HolderRole) Valid() bool {
+	for _, v := range HolderRoles {
+		if r == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CanDebit reports whether holders with the role may move funds out of the account, authorized
+// operators follow the account but cannot debit it.
+func (r HolderRole) CanDebit() bool {
+	return r == OwnerHolderRole || r == CoOwnerHolderRole
+}
+
+// AccountHolder links a holder to an account with a role.
+type AccountHolder struct {
+	HolderID       uuid.UUID
+	Name           string
+	DocumentNumber string
+	Role           HolderRole
+	CreatedAt      time.Time
+}
+
+func newAccountHolder(model accountHolderModel) AccountHolder {
+	return AccountHolder{
+		HolderID:       model.HolderID,
+		Name:           model.HolderName,
+		DocumentNumber: model.HolderDocumentNumber,
+		Role:           model.Role,
+		CreatedAt:      model.CreatedAt,
+	}
+}
+
+type LegalHoldStatus string
+
+const (
+	ActiveLegalHoldStatus   LegalHoldStatus = "ACTIVE"
+	ReleasedLegalHoldStatus LegalHoldStatus = "RELEASED"
+)
 
 type Account struct {
 	ID             uuid.UUID
@@ -9,7 +89,118 @@ type Account struct {
 	Number         string
 	DocumentNumber string
 	HolderID       uuid.UUID
+	Type           products.Type
+	Currency       exchange.Currency
 	Status         Status
+	ClosureReason  string
+	ClosedAt       time.Time
+	Restrictions   []Restriction
+	// Holders lists who is linked to the account, the owner is the one in HolderID and DocumentNumber.
+	Holders []AccountHolder
+	// HolderRole is the role of the filtered holder when listing accounts by holder.
+	HolderRole HolderRole
+	// ParentID is set for pockets, the sub-accounts used to set money aside from the parent account.
+	ParentID uuid.NullUUID
+}
+
+// IsPocket reports whether the account is a pocket of another account.
+func (a Account) IsPocket() bool {
+	return a.ParentID.Valid
+}
+
+// Restricted reports whether the given restriction is in effect for the account.
+func (a Account) Restricted(restriction Restriction) bool {
+	for _, r := range a.Restrictions {
+		if r == restriction {
+			return true
+		}
+	}
+
+	return false
+}
+
+// StatusChange carries why and by whom an account status is being changed.
+type StatusChange struct {
+	Reason string
+	Actor  string
+}
+
+// Closure describes why an account is being closed and, when it still has
+// funds, the account that receives the remaining balance.
+type Closure struct {
+	Reason        string
+	Actor         string
+	DestinationID uuid.NullUUID
+}
+
+type StatusEvent struct {
+	ID         uuid.UUID
+	AccountID  uuid.UUID
+	FromStatus Status
+	ToStatus   Status
+	// Restrictions holds the restrictions in effect after a restriction change, it is empty for other events.
+	Restrictions []Restriction
+	Reason       string
+	Actor        string
+	CreatedAt    time.Time
+}
+
+func newStatusEvent(model statusEventModel) StatusEvent {
+	return StatusEvent{
+		ID:           model.ID,
+		AccountID:    model.AccountID,
+		FromStatus:   model.FromStatus,
+		ToStatus:     model.ToStatus,
+		Restrictions: newRestrictions(model.Restrictions),
+		Reason:       model.Reason,
+		Actor:        model.Actor,
+		CreatedAt:    model.CreatedAt,
+	}
+}
+
+// DormancyCandidate is an active account that becomes dormant at DormantAt unless it moves before.
+type DormancyCandidate struct {
+	Account        Account
+	LastMovementAt time.Time
+	DormantAt      time.Time
+}
+
+// DormancyFilter lists the accounts that become dormant within the given window.
+type DormancyFilter struct {
+	Within time.Duration
+	Page   int
+	Size   int
+}
+
+// LegalHold is a court-ordered amount that cannot leave the account until it is released.
+type LegalHold struct {
+	ID            uuid.UUID
+	AccountID     uuid.UUID
+	Amount        float64
+	CourtOrder    string
+	Reason        string
+	Actor         string
+	Status        LegalHoldStatus
+	CreatedAt     time.Time
+	ReleasedAt    time.Time
+	ReleaseReason string
+	ReleasedBy    string
+}
+
+func newLegalHold(model legalHoldModel) LegalHold {
+	return LegalHold{
+		ID:            model.ID,
+		AccountID:     model.AccountID,
+		Amount:        model.Amount,
+		CourtOrder:    model.CourtOrder,
+		Reason:        model.Reason,
+		Actor:         model.Actor,
+		Status:        model.Status,
+		CreatedAt:     model.CreatedAt,
+		ReleasedAt:    model.ReleasedAt,
+		ReleaseReason: model.ReleaseReason,
+		ReleasedBy:    model.ReleasedBy,
+	}
 }
 
 func newAccount(model accountModel) Account {
@@ -20,13 +211,36 @@ func newAccount(model accountModel) Account {
 		Number:         model.Number,
 		HolderID:       model.HolderID,
 		DocumentNumber: model.HolderDocumentNumber,
+		Type:           model.Type,
+		Currency:       model.Currency,
 		Status:         model.Status,
+		ClosureReason:  model.ClosureReason,
+		ClosedAt:       model.ClosedAt,
+		Restrictions:   newRestrictions(model.Restrictions),
+		HolderRole:     model.HolderRole,
+		ParentID:       model.ParentID,
 	}
 }
 
+func newRestrictions(values []string) []Restriction {
+	if values == nil {
+		return nil
+	}
+
+	restrictions := make([]Restriction, len(values))
+	for i, v := range values {
+		restrictions[i] = Restriction(v)
+	}
+
+	return restrictions
+}
+
 type ListFilter struct {
 	Sort           int
 	Page           int
 	Size           int
 	DocumentNumber string
+	HolderID       uuid.NullUUID
+	Agency         string
+	Number         string
 }
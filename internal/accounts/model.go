@@ -3,6 +3,8 @@ package accounts
 import (
 	"time"
 
+	"github.com/dalmarcogd/dock-test/internal/exchange"
+	"github.com/dalmarcogd/dock-test/internal/products"
 	"github.com/dalmarcogd/dock-test/pkg/database"
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
@@ -14,35 +16,165 @@ const (
 	ActiveStatus  Status = "ACTIVE"
 	BlockedStatus Status = "BLOCKED"
 	ClosedStatus  Status = "CLOSED"
+	// DormantStatus is applied to active accounts without movement for the
+	// configured inactivity period, the next credit reactivates them.
+	DormantStatus Status = "DORMANT"
 )
 
 const (
 	AccountNumberVariants = "0123456789"
 	AccountNumberSize     = 6
+	AccountAgency         = "0001"
+
+	// maxNumberGenerationAttempts bounds how many times Create retries a
+	// randomly generated number that collides with an existing account.
+	maxNumberGenerationAttempts = 5
+
+	// maxPocketsPerAccount bounds how many open pockets an account may have.
+	maxPocketsPerAccount = 20
+
+	// dormancyBatchSize bounds how many accounts MarkDormant reads at once.
+	dormancyBatchSize = 100
 )
 
 type accountModel struct {
 	bun.BaseModel `bun:"table:accounts"`
 
-	ID                   uuid.UUID `bun:"id,pk"`
-	Name                 string    `bun:"name"`
-	Agency               string    `bun:"agency"`
-	Number               string    `bun:"number"`
-	HolderID             uuid.UUID `bun:"holder_id"`
-	HolderDocumentNumber string    `bun:"holder_document_number,scanonly"`
-	Status               Status    `bun:"status"`
-	CreatedAt            time.Time `bun:"created_at,notnull"`
-	UpdatedAt            time.Time `bun:"updated_at,nullzero"`
+	ID                   uuid.UUID         `bun:"id,pk"`
+	Name                 string            `bun:"name"`
+	Agency               string            `bun:"agency"`
+	Number               string            `bun:"number"`
+	HolderID             uuid.UUID         `bun:"holder_id"`
+	HolderDocumentNumber string            `bun:"holder_document_number,scanonly"`
+	HolderRole           HolderRole        `bun:"holder_role,scanonly"`
+	Type                 products.Type     `bun:"type"`
+	Currency             exchange.Currency `bun:"currency"`
+	Status               Status            `bun:"status"`
+	ClosureReason        string            `bun:"closure_reason,nullzero"`
+	ClosedAt             time.Time         `bun:"closed_at,nullzero"`
+	Restrictions         []string          `bun:"restrictions,array,nullzero"`
+	ParentID             uuid.NullUUID     `bun:"parent_id"`
+	CreatedAt            time.Time         `bun:"created_at,notnull"`
+	UpdatedAt            time.Time         `bun:"updated_at,nullzero"`
 }
 
 func newAccountModel(acc Account) accountModel {
 	return accountModel{
-		ID:       acc.ID,
-		Name:     acc.Name,
-		Agency:   acc.Agency,
-		Number:   acc.Number,
-		HolderID: acc.HolderID,
-		Status:   acc.Status,
+		ID:            acc.ID,
+		Name:          acc.Name,
+		Agency:        acc.Agency,
+		Number:        acc.Number,
+		HolderID:      acc.HolderID,
+		Type:          acc.Type,
+		Currency:      acc.Currency,
+		Status:        acc.Status,
+		ClosureReason: acc.ClosureReason,
+		ClosedAt:      acc.ClosedAt,
+		Restrictions:  newRestrictionValues(acc.Restrictions),
+		ParentID:      acc.ParentID,
+	}
+}
+
+func newRestrictionValues(restrictions []Restriction) []string {
+	if restrictions == nil {
+		return nil
+	}
+
+	values := make([]string, len(restrictions))
+	for i, r := range restrictions {
+		values[i] = string(r)
+	}
+
+	return values
+}
+
+type accountHolderModel struct {
+	bun.BaseModel `bun:"table:account_holders"`
+
+	AccountID            uuid.UUID  `bun:"account_id,pk"`
+	HolderID             uuid.UUID  `bun:"holder_id,pk"`
+	HolderName           string     `bun:"holder_name,scanonly"`
+	HolderDocumentNumber string     `bun:"holder_document_number,scanonly"`
+	Role                 HolderRole `bun:"role"`
+	CreatedAt            time.Time  `bun:"created_at,notnull"`
+}
+
+// newAccountHolderModel links the holder to an account that is still being created, the
+// repository fills the account id when inserting both.
+func newAccountHolderModel(holder AccountHolder) accountHolderModel {
+	return accountHolderModel{
+		HolderID: holder.HolderID,
+		Role:     holder.Role,
+	}
+}
+
+// dormancyCandidateModel is an account read along with the date of its last movement,
+// which is the last transaction in or out of it or, without any, its creation.
+type dormancyCandidateModel struct {
+	accountModel `bun:",extend"`
+
+	LastMovementAt time.Time `bun:"last_movement_at,scanonly"`
+}
+
+// lastMovementFilter selects active accounts whose last movement happened before the given date.
+type lastMovementFilter struct {
+	Before time.Time
+	Page   int
+	Size   int
+}
+
+type statusEventModel struct {
+	bun.BaseModel `bun:"table:account_status_events"`
+
+	ID           uuid.UUID `bun:"id,pk"`
+	AccountID    uuid.UUID `bun:"account_id"`
+	FromStatus   Status    `bun:"from_status"`
+	ToStatus     Status    `bun:"to_status"`
+	Restrictions []string  `bun:"restrictions,array,nullzero"`
+	Reason       string    `bun:"reason"`
+	Actor        string    `bun:"actor,nullzero"`
+	CreatedAt    time.Time `bun:"created_at,notnull"`
+}
+
+func newStatusEventModel(accountID uuid.UUID, from, to Status, reason, actor string) statusEventModel {
+	return statusEventModel{
+		AccountID:  accountID,
+		FromStatus: from,
+		ToStatus:   to,
+		Reason:     reason,
+		Actor:      actor,
+	}
+}
+
+type legalHoldModel struct {
+	bun.BaseModel `bun:"table:account_legal_holds"`
+
+	ID            uuid.UUID       `bun:"id,pk"`
+	AccountID     uuid.UUID       `bun:"account_id"`
+	Amount        float64         `bun:"amount"`
+	CourtOrder    string          `bun:"court_order"`
+	Reason        string          `bun:"reason"`
+	Actor         string          `bun:"actor,nullzero"`
+	Status        LegalHoldStatus `bun:"status"`
+	CreatedAt     time.Time       `bun:"created_at,notnull"`
+	ReleasedAt    time.Time       `bun:"released_at,nullzero"`
+	ReleaseReason string          `bun:"release_reason,nullzero"`
+	ReleasedBy    string          `bun:"released_by,nullzero"`
+}
+
+func newLegalHoldModel(hold LegalHold) legalHoldModel {
+	return legalHoldModel{
+		ID:            hold.ID,
+		AccountID:     hold.AccountID,
+		Amount:        hold.Amount,
+		CourtOrder:    hold.CourtOrder,
+		Reason:        hold.Reason,
+		Actor:         hold.Actor,
+		Status:        hold.Status,
+		CreatedAt:     hold.CreatedAt,
+		ReleasedAt:    hold.ReleasedAt,
+		ReleaseReason: hold.ReleaseReason,
+		ReleasedBy:    hold.ReleasedBy,
 	}
 }
 
@@ -0,0 +1,72 @@
+package accounts
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/dalmarcogd/dock-test/pkg/stringer"
+)
+
+const numberCheckDigitSeparator = "-"
+
+// generateNumber returns a random account number followed by its mod-11
+// check digit, e.g. 123456-0.
+func generateNumber() string {
+	number := stringer.GenerateCode([]rune(AccountNumberVariants), AccountNumberSize)
+	return number + numberCheckDigitSeparator + numberCheckDigit(number)
+}
+
+// numberCheckDigit computes the mod-11 check digit of the given digits using
+// weights from 2 to 9 applied from the rightmost digit. Remainders resulting
+// in 10 or 11 are represented as 0.
+func numberCheckDigit(digits string) string {
+	var sum int
+	weight := 2
+	for i := len(digits) - 1; i >= 0; i-- {
+		sum += int(digits[i]-'0') * weight
+		weight++
+		if weight > 9 {
+			weight = 2
+		}
+	}
+
+	dv := 11 - (sum % 11)
+	if dv >= 10 {
+		dv = 0
+	}
+
+	return strconv.Itoa(dv)
+}
+
+// ValidNumber reports whether the number has the NNNNNN-D format and a valid
+// check digit.
+func ValidNumber(number string) bool {
+	digits, dv, found := strings.Cut(number, numberCheckDigitSeparator)
+	if !found || len(digits) != AccountNumberSize || len(dv) != 1 {
+		return false
+	}
+
+	for _, r := range digits + dv {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return numberCheckDigit(digits) == dv
+}
+
+// legacyNumber reports whether the number has the NNNNNN format the accounts had before the check digit, which
+// is still accepted to find them.
+func legacyNumber(number string) bool {
+	if len(number) != AccountNumberSize {
+		return false
+	}
+
+	for _, r := range number {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,42 @@
+//go:build unit
+
+package accounts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumberCheckDigit(t *testing.T) {
+	assert.Equal(t, "0", numberCheckDigit("000000"))
+	assert.Equal(t, "0", numberCheckDigit("123456"))
+	assert.Equal(t, "9", numberCheckDigit("654321"))
+	assert.Equal(t, "9", numberCheckDigit("000001"))
+	assert.Equal(t, "7", numberCheckDigit("000002"))
+}
+
+func TestGenerateNumber(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		number := generateNumber()
+		assert.Len(t, number, AccountNumberSize+2)
+		assert.True(t, ValidNumber(number), number)
+	}
+}
+
+func TestValidNumber(t *testing.T) {
+	assert.True(t, ValidNumber("123456-0"))
+	assert.True(t, ValidNumber("654321-9"))
+	assert.False(t, ValidNumber("654321-8"))
+	assert.False(t, ValidNumber("6543219"))
+	assert.False(t, ValidNumber("65432-9"))
+	assert.False(t, ValidNumber("65432a-9"))
+	assert.False(t, ValidNumber(""))
+}
+
+func TestLegacyNumber(t *testing.T) {
+	assert.True(t, legacyNumber("123456"))
+	assert.False(t, legacyNumber("123456-0"))
+	assert.False(t, legacyNumber("12345a"))
+	assert.False(t, legacyNumber("12345"))
+}
@@ -2,16 +2,57 @@ package accounts
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"time"
 
 	"github.com/dalmarcogd/dock-test/pkg/database"
 	"github.com/dalmarcogd/dock-test/pkg/tracer"
 	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+const (
+	accountsAgencyNumberConstraint = "accounts_agency_number"
+	accountsParentNameConstraint   = "accounts_parent_name"
+)
+
+// unsettledTransferStatuses are the statuses of the transfers still waiting for the bank, as kept by
+// the transfers package.
+var unsettledTransferStatuses = []string{"PENDING", "SENT"}
+
+var (
+	errDuplicatedAccountNumber    = errors.New("an account with this agency and number already exists")
+	errAccountBalanceNotZero      = errors.New("the account balance is not zero")
+	errAccountHasActiveHolds      = errors.New("the account has active legal holds")
+	errLegalHoldNotActive         = errors.New("the legal hold is not active")
+	errDuplicatedPocketName       = errors.New("a pocket with this name already exists in the account")
+	errAccountHasOpenPockets      = errors.New("the account has open pockets")
+	errAccountHasPendingTransfers = errors.New("the account has transfers not settled by the bank")
 )
 
 type Repository interface {
-	Create(ctx context.Context, model accountModel) (accountModel, error)
+	Create(ctx context.Context, model accountModel, holders []accountHolderModel) (accountModel, error)
 	Update(ctx context.Context, model accountModel) (accountModel, error)
+	UpdateStatus(ctx context.Context, model accountModel, event statusEventModel) (accountModel, error)
+	Close(ctx context.Context, model accountModel, event statusEventModel) (accountModel, error)
+	CheckClosable(ctx context.Context, id uuid.UUID) error
+	ListStatusEventsByAccountID(ctx context.Context, accountID uuid.UUID) ([]statusEventModel, error)
+	ListHolders(ctx context.Context, accountID uuid.UUID) ([]accountHolderModel, error)
+	ListPockets(ctx context.Context, parentID uuid.UUID) ([]accountModel, error)
+	UpdateRestrictions(ctx context.Context, model accountModel, event statusEventModel) (accountModel, error)
+	CreateLegalHold(ctx context.Context, model legalHoldModel) (legalHoldModel, error)
+	ReleaseLegalHold(ctx context.Context, model legalHoldModel) (legalHoldModel, error)
+	ListLegalHoldsByAccountID(ctx context.Context, accountID uuid.UUID) ([]legalHoldModel, error)
+	SumActiveLegalHolds(ctx context.Context, accountID uuid.UUID) (float64, error)
+	ListByLastMovement(ctx context.Context, filter lastMovementFilter) (int, []dormancyCandidateModel, error)
+	MarkDormant(
+		ctx context.Context,
+		model accountModel,
+		event statusEventModel,
+		lastMovementBefore time.Time,
+	) (bool, error)
 	GetByFilter(ctx context.Context, filter accountFilter) ([]accountModel, error)
 	ListByFilter(ctx context.Context, filter ListFilter) (int, []accountModel, error)
 }
@@ -28,20 +69,49 @@ func NewRepository(t tracer.Tracer, db database.Database) Repository {
 	}
 }
 
-func (r repository) Create(ctx context.Context, model accountModel) (accountModel, error) {
+// Create inserts the account along with its holders within the same database transaction.
+func (r repository) Create(
+	ctx context.Context,
+	model accountModel,
+	holders []accountHolderModel,
+) (accountModel, error) {
 	ctx, span := r.tracer.Span(ctx)
 	defer span.End()
 
 	model.ID = uuid.New()
 	model.CreatedAt = time.Now().UTC()
 
-	_, err := r.db.Master().
-		NewInsert().
-		Model(&model).
-		Returning("*").
-		Exec(ctx)
+	err := r.db.Master().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewInsert().
+			Model(&model).
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(holders) == 0 {
+			return nil
+		}
+
+		for i := range holders {
+			holders[i].AccountID = model.ID
+			holders[i].CreatedAt = model.CreatedAt
+		}
+
+		_, err = tx.NewInsert().
+			Model(&holders).
+			Exec(ctx)
+		return err
+	})
 	if err != nil {
 		span.RecordError(err)
+		if isConstraintViolation(err, accountsAgencyNumberConstraint) {
+			return accountModel{}, errDuplicatedAccountNumber
+		}
+		if isConstraintViolation(err, accountsParentNameConstraint) {
+			return accountModel{}, errDuplicatedPocketName
+		}
 		return accountModel{}, err
 	}
 
@@ -69,6 +139,457 @@ func (r repository) Update(ctx context.Context, model accountModel) (accountMode
 	return model, nil
 }
 
+// UpdateStatus changes the account status and records the event within the same database transaction.
+func (r repository) UpdateStatus(
+	ctx context.Context,
+	model accountModel,
+	event statusEventModel,
+) (accountModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	now := time.Now().UTC()
+	model.UpdatedAt = now
+
+	err := r.db.Master().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewUpdate().
+			Model(&model).
+			Column("status", "updated_at").
+			WherePK().
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		return insertStatusEvent(ctx, tx, event, now)
+	})
+	if err != nil {
+		span.RecordError(err)
+		return accountModel{}, err
+	}
+
+	return model, nil
+}
+
+// Close marks the account as closed, checking within the same database transaction that nothing
+// keeps it open and that its balance is zero, otherwise errAccountBalanceNotZero is returned.
+func (r repository) Close(ctx context.Context, model accountModel, event statusEventModel) (accountModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	now := time.Now().UTC()
+	model.Status = ClosedStatus
+	model.ClosedAt = now
+	model.UpdatedAt = now
+
+	err := r.db.Master().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// serializes concurrent closures of the same account.
+		_, err := tx.NewSelect().
+			Table("accounts").
+			Column("id").
+			Where("id = ?", model.ID).
+			For("UPDATE").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		var balance float64
+		err = tx.NewSelect().
+			ModelTableExpr("transactions_balances").
+			Column("balance").
+			Where("account_id = ?", model.ID.String()).
+			Scan(ctx, &balance)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		err = checkClosable(ctx, tx, model.ID)
+		if err != nil {
+			return err
+		}
+
+		if balance != 0 {
+			return errAccountBalanceNotZero
+		}
+
+		_, err = tx.NewUpdate().
+			Model(&model).
+			Column("status", "closure_reason", "closed_at", "updated_at").
+			WherePK().
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		return insertStatusEvent(ctx, tx, event, now)
+	})
+	if err != nil {
+		span.RecordError(err)
+		return accountModel{}, err
+	}
+
+	return model, nil
+}
+
+// CheckClosable tells whether anything but its balance keeps the account open, the same way Close
+// does.
+func (r repository) CheckClosable(ctx context.Context, id uuid.UUID) error {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	err := checkClosable(ctx, r.db.Master(), id)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+// checkClosable fails when the account has active legal holds, open pockets or transfers the bank
+// did not settle yet, whose reversal would credit the account back.
+func checkClosable(ctx context.Context, db bun.IDB, id uuid.UUID) error {
+	activeHolds, err := db.NewSelect().
+		Model((*legalHoldModel)(nil)).
+		Where("account_id = ?", id).
+		Where("status = ?", ActiveLegalHoldStatus).
+		Count(ctx)
+	if err != nil {
+		return err
+	}
+
+	if activeHolds > 0 {
+		return errAccountHasActiveHolds
+	}
+
+	openPockets, err := db.NewSelect().
+		Model((*accountModel)(nil)).
+		Where("parent_id = ?", id).
+		Where("status <> ?", ClosedStatus).
+		Count(ctx)
+	if err != nil {
+		return err
+	}
+
+	if openPockets > 0 {
+		return errAccountHasOpenPockets
+	}
+
+	pendingTransfers, err := db.NewSelect().
+		Table("transfers").
+		Where("account_id = ?", id).
+		Where("status IN (?)", bun.In(unsettledTransferStatuses)).
+		Count(ctx)
+	if err != nil {
+		return err
+	}
+
+	if pendingTransfers > 0 {
+		return errAccountHasPendingTransfers
+	}
+
+	return nil
+}
+
+func (r repository) ListStatusEventsByAccountID(
+	ctx context.Context,
+	accountID uuid.UUID,
+) ([]statusEventModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var events []statusEventModel
+	err := r.db.Replica().
+		NewSelect().
+		Model(&events).
+		Where("account_id = ?", accountID).
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r repository) ListHolders(ctx context.Context, accountID uuid.UUID) ([]accountHolderModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var holders []accountHolderModel
+	err := r.db.Replica().
+		NewSelect().
+		Model(&holders).
+		ColumnExpr("account_holder_model.*").
+		ColumnExpr("h.name AS holder_name, h.document_number AS holder_document_number").
+		Join("JOIN holders AS h ON h.id = account_holder_model.holder_id").
+		Where("account_holder_model.account_id = ?", accountID).
+		Order("account_holder_model.created_at ASC", "account_holder_model.role ASC").
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return holders, nil
+}
+
+// ListPockets lists the pockets of the account that are not closed, oldest first.
+func (r repository) ListPockets(ctx context.Context, parentID uuid.UUID) ([]accountModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var pockets []accountModel
+	err := r.db.Replica().
+		NewSelect().
+		ModelTableExpr("accounts AS a").
+		ColumnExpr("a.*, h.document_number AS holder_document_number").
+		Join("JOIN holders AS h ON h.id = a.holder_id").
+		Where("a.parent_id = ?", parentID).
+		Where("a.status <> ?", ClosedStatus).
+		Order("a.created_at ASC").
+		Scan(ctx, &pockets)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return pockets, nil
+}
+
+// UpdateRestrictions replaces the account restrictions and records the event within the same database transaction.
+func (r repository) UpdateRestrictions(
+	ctx context.Context,
+	model accountModel,
+	event statusEventModel,
+) (accountModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	now := time.Now().UTC()
+	model.UpdatedAt = now
+	if model.Restrictions == nil {
+		model.Restrictions = []string{}
+	}
+
+	err := r.db.Master().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewUpdate().
+			Model(&model).
+			Column("restrictions", "updated_at").
+			WherePK().
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		return insertStatusEvent(ctx, tx, event, now)
+	})
+	if err != nil {
+		span.RecordError(err)
+		return accountModel{}, err
+	}
+
+	return model, nil
+}
+
+func (r repository) CreateLegalHold(ctx context.Context, model legalHoldModel) (legalHoldModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	model.ID = uuid.New()
+	model.Status = ActiveLegalHoldStatus
+	model.CreatedAt = time.Now().UTC()
+
+	_, err := r.db.Master().
+		NewInsert().
+		Model(&model).
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return legalHoldModel{}, err
+	}
+
+	return model, nil
+}
+
+func (r repository) ReleaseLegalHold(ctx context.Context, model legalHoldModel) (legalHoldModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	model.Status = ReleasedLegalHoldStatus
+	model.ReleasedAt = time.Now().UTC()
+
+	res, err := r.db.Master().
+		NewUpdate().
+		Model(&model).
+		Column("status", "released_at", "release_reason", "released_by").
+		Where("id = ?", model.ID).
+		Where("account_id = ?", model.AccountID).
+		Where("status = ?", ActiveLegalHoldStatus).
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return legalHoldModel{}, err
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		span.RecordError(errLegalHoldNotActive)
+		return legalHoldModel{}, errLegalHoldNotActive
+	}
+
+	return model, nil
+}
+
+func (r repository) ListLegalHoldsByAccountID(ctx context.Context, accountID uuid.UUID) ([]legalHoldModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var holds []legalHoldModel
+	err := r.db.Replica().
+		NewSelect().
+		Model(&holds).
+		Where("account_id = ?", accountID).
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+func (r repository) SumActiveLegalHolds(ctx context.Context, accountID uuid.UUID) (float64, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var amount float64
+	err := r.db.Replica().
+		NewSelect().
+		Model((*legalHoldModel)(nil)).
+		ColumnExpr("COALESCE(SUM(amount), 0)").
+		Where("account_id = ?", accountID).
+		Where("status = ?", ActiveLegalHoldStatus).
+		Scan(ctx, &amount)
+	if err != nil {
+		span.RecordError(err)
+		return 0, err
+	}
+
+	return amount, nil
+}
+
+// lastMovementExpr computes when an account last moved, falling back to its creation. The fees charged
+// and the interest paid to the account are no activity of its holders, so they are not movements.
+const lastMovementExpr = `GREATEST(a.created_at, (
+	SELECT MAX(t.created_at)
+	FROM transactions AS t
+	WHERE (t.from_account_id = a.id OR t.to_account_id = a.id)
+		AND t.type <> 'FEE'
+		AND NOT EXISTS (SELECT 1 FROM interest_payouts AS p WHERE p.transaction_id = t.id)
+))`
+
+// ListByLastMovement lists the active accounts whose last movement is before the filter date, oldest first.
+func (r repository) ListByLastMovement(
+	ctx context.Context,
+	filter lastMovementFilter,
+) (int, []dormancyCandidateModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	page := filter.Page
+	if page == 0 {
+		page = 1
+	}
+
+	size := filter.Size
+	if size == 0 {
+		size = 20
+	}
+
+	selectQuery := r.db.Replica().
+		NewSelect().
+		TableExpr("(?) AS a", r.db.Replica().
+			NewSelect().
+			ModelTableExpr("accounts AS a").
+			ColumnExpr("a.*, h.document_number AS holder_document_number").
+			ColumnExpr(lastMovementExpr+" AS last_movement_at").
+			Join("JOIN holders AS h ON h.id = a.holder_id").
+			Where("a.status = ?", ActiveStatus),
+		).
+		ColumnExpr("a.*").
+		Where("a.last_movement_at < ?", filter.Before).
+		Order("a.last_movement_at ASC").
+		Limit(size).
+		Offset((page - 1) * size)
+
+	var candidates []dormancyCandidateModel
+	total, err := selectQuery.ScanAndCount(ctx, &candidates)
+	if err != nil {
+		span.RecordError(err)
+		return 0, nil, err
+	}
+
+	return total, candidates, nil
+}
+
+// MarkDormant moves the account to DORMANT and records the event within the same database
+// transaction. The account is left untouched, returning false, when it is no longer active or
+// moved since lastMovementBefore, which covers transactions created after it was listed.
+func (r repository) MarkDormant(
+	ctx context.Context,
+	model accountModel,
+	event statusEventModel,
+	lastMovementBefore time.Time,
+) (bool, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	now := time.Now().UTC()
+	model.Status = DormantStatus
+	model.UpdatedAt = now
+
+	var marked bool
+	err := r.db.Master().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewUpdate().
+			Model(&model).
+			ModelTableExpr("accounts AS a").
+			Column("status", "updated_at").
+			Where("a.id = ?", model.ID).
+			Where("a.status = ?", ActiveStatus).
+			Where(lastMovementExpr+" < ?", lastMovementBefore).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if rows == 0 {
+			return nil
+		}
+
+		marked = true
+		return insertStatusEvent(ctx, tx, event, now)
+	})
+	if err != nil {
+		span.RecordError(err)
+		return false, err
+	}
+
+	return marked, nil
+}
+
 func (r repository) GetByFilter(ctx context.Context, filter accountFilter) ([]accountModel, error) {
 	ctx, span := r.tracer.Span(ctx)
 	defer span.End()
@@ -143,6 +664,28 @@ func (r repository) ListByFilter(ctx context.Context, filter ListFilter) (int, [
 		selectQuery.Where("h.document_number = ?", filter.DocumentNumber)
 	}
 
+	// shared accounts are listed for every linked holder, not only the owner in a.holder_id.
+	if filter.HolderID.Valid {
+		selectQuery.
+			ColumnExpr("ah.role AS holder_role").
+			Join("JOIN account_holders AS ah ON ah.account_id = a.id AND ah.holder_id = ?", filter.HolderID.UUID)
+	}
+
+	if filter.Agency != "" {
+		selectQuery.Where("a.agency = ?", filter.Agency)
+	}
+
+	// accounts renumbered by the migration to agency and number unique are still found by their previous number.
+	if filter.Number != "" {
+		selectQuery.Where(
+			`(a.number = ? OR a.id IN (
+				SELECT c.account_id FROM account_number_changes AS c WHERE c.agency = a.agency AND c.old_number = ?
+			))`,
+			filter.Number,
+			filter.Number,
+		)
+	}
+
 	if filter.Sort == 0 {
 		selectQuery.Order("created_at ASC")
 	} else if filter.Sort > 0 {
@@ -158,3 +701,20 @@ func (r repository) ListByFilter(ctx context.Context, filter ListFilter) (int, [
 
 	return total, accs, nil
 }
+
+func insertStatusEvent(ctx context.Context, tx bun.Tx, event statusEventModel, now time.Time) error {
+	event.ID = uuid.New()
+	event.CreatedAt = now
+
+	_, err := tx.NewInsert().Model(&event).Exec(ctx)
+	return err
+}
+
+func isConstraintViolation(err error, constraint string) bool {
+	var pgErr pgdriver.Error
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.IntegrityViolation() && pgErr.Field('n') == constraint
+}
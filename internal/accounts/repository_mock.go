@@ -7,8 +7,10 @@ package accounts
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 )
 
 // MockRepository is a mock of Repository interface.
@@ -34,19 +36,63 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CheckClosable mocks base method.
+func (m *MockRepository) CheckClosable(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckClosable", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckClosable indicates an expected call of CheckClosable.
+func (mr *MockRepositoryMockRecorder) CheckClosable(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckClosable", reflect.TypeOf((*MockRepository)(nil).CheckClosable), ctx, id)
+}
+
+// Close mocks base method.
+func (m *MockRepository) Close(ctx context.Context, model accountModel, event statusEventModel) (accountModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx, model, event)
+	ret0, _ := ret[0].(accountModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockRepositoryMockRecorder) Close(ctx, model, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRepository)(nil).Close), ctx, model, event)
+}
+
 // Create mocks base method.
-func (m *MockRepository) Create(ctx context.Context, model accountModel) (accountModel, error) {
+func (m *MockRepository) Create(ctx context.Context, model accountModel, holders []accountHolderModel) (accountModel, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, model)
+	ret := m.ctrl.Call(m, "Create", ctx, model, holders)
 	ret0, _ := ret[0].(accountModel)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockRepositoryMockRecorder) Create(ctx, model interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Create(ctx, model, holders interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, model, holders)
+}
+
+// CreateLegalHold mocks base method.
+func (m *MockRepository) CreateLegalHold(ctx context.Context, model legalHoldModel) (legalHoldModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLegalHold", ctx, model)
+	ret0, _ := ret[0].(legalHoldModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLegalHold indicates an expected call of CreateLegalHold.
+func (mr *MockRepositoryMockRecorder) CreateLegalHold(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLegalHold", reflect.TypeOf((*MockRepository)(nil).CreateLegalHold), ctx, model)
 }
 
 // GetByFilter mocks base method.
@@ -80,6 +126,127 @@ func (mr *MockRepositoryMockRecorder) ListByFilter(ctx, filter interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByFilter", reflect.TypeOf((*MockRepository)(nil).ListByFilter), ctx, filter)
 }
 
+// ListByLastMovement mocks base method.
+func (m *MockRepository) ListByLastMovement(ctx context.Context, filter lastMovementFilter) (int, []dormancyCandidateModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByLastMovement", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]dormancyCandidateModel)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListByLastMovement indicates an expected call of ListByLastMovement.
+func (mr *MockRepositoryMockRecorder) ListByLastMovement(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByLastMovement", reflect.TypeOf((*MockRepository)(nil).ListByLastMovement), ctx, filter)
+}
+
+// ListHolders mocks base method.
+func (m *MockRepository) ListHolders(ctx context.Context, accountID uuid.UUID) ([]accountHolderModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHolders", ctx, accountID)
+	ret0, _ := ret[0].([]accountHolderModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHolders indicates an expected call of ListHolders.
+func (mr *MockRepositoryMockRecorder) ListHolders(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHolders", reflect.TypeOf((*MockRepository)(nil).ListHolders), ctx, accountID)
+}
+
+// ListLegalHoldsByAccountID mocks base method.
+func (m *MockRepository) ListLegalHoldsByAccountID(ctx context.Context, accountID uuid.UUID) ([]legalHoldModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLegalHoldsByAccountID", ctx, accountID)
+	ret0, _ := ret[0].([]legalHoldModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLegalHoldsByAccountID indicates an expected call of ListLegalHoldsByAccountID.
+func (mr *MockRepositoryMockRecorder) ListLegalHoldsByAccountID(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLegalHoldsByAccountID", reflect.TypeOf((*MockRepository)(nil).ListLegalHoldsByAccountID), ctx, accountID)
+}
+
+// ListPockets mocks base method.
+func (m *MockRepository) ListPockets(ctx context.Context, parentID uuid.UUID) ([]accountModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPockets", ctx, parentID)
+	ret0, _ := ret[0].([]accountModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPockets indicates an expected call of ListPockets.
+func (mr *MockRepositoryMockRecorder) ListPockets(ctx, parentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPockets", reflect.TypeOf((*MockRepository)(nil).ListPockets), ctx, parentID)
+}
+
+// ListStatusEventsByAccountID mocks base method.
+func (m *MockRepository) ListStatusEventsByAccountID(ctx context.Context, accountID uuid.UUID) ([]statusEventModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStatusEventsByAccountID", ctx, accountID)
+	ret0, _ := ret[0].([]statusEventModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStatusEventsByAccountID indicates an expected call of ListStatusEventsByAccountID.
+func (mr *MockRepositoryMockRecorder) ListStatusEventsByAccountID(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatusEventsByAccountID", reflect.TypeOf((*MockRepository)(nil).ListStatusEventsByAccountID), ctx, accountID)
+}
+
+// MarkDormant mocks base method.
+func (m *MockRepository) MarkDormant(ctx context.Context, model accountModel, event statusEventModel, lastMovementBefore time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDormant", ctx, model, event, lastMovementBefore)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkDormant indicates an expected call of MarkDormant.
+func (mr *MockRepositoryMockRecorder) MarkDormant(ctx, model, event, lastMovementBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDormant", reflect.TypeOf((*MockRepository)(nil).MarkDormant), ctx, model, event, lastMovementBefore)
+}
+
+// ReleaseLegalHold mocks base method.
+func (m *MockRepository) ReleaseLegalHold(ctx context.Context, model legalHoldModel) (legalHoldModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseLegalHold", ctx, model)
+	ret0, _ := ret[0].(legalHoldModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseLegalHold indicates an expected call of ReleaseLegalHold.
+func (mr *MockRepositoryMockRecorder) ReleaseLegalHold(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseLegalHold", reflect.TypeOf((*MockRepository)(nil).ReleaseLegalHold), ctx, model)
+}
+
+// SumActiveLegalHolds mocks base method.
+func (m *MockRepository) SumActiveLegalHolds(ctx context.Context, accountID uuid.UUID) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumActiveLegalHolds", ctx, accountID)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumActiveLegalHolds indicates an expected call of SumActiveLegalHolds.
+func (mr *MockRepositoryMockRecorder) SumActiveLegalHolds(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumActiveLegalHolds", reflect.TypeOf((*MockRepository)(nil).SumActiveLegalHolds), ctx, accountID)
+}
+
 // Update mocks base method.
 func (m *MockRepository) Update(ctx context.Context, model accountModel) (accountModel, error) {
 	m.ctrl.T.Helper()
@@ -94,3 +261,33 @@ func (mr *MockRepositoryMockRecorder) Update(ctx, model interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, model)
 }
+
+// UpdateRestrictions mocks base method.
+func (m *MockRepository) UpdateRestrictions(ctx context.Context, model accountModel, event statusEventModel) (accountModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRestrictions", ctx, model, event)
+	ret0, _ := ret[0].(accountModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRestrictions indicates an expected call of UpdateRestrictions.
+func (mr *MockRepositoryMockRecorder) UpdateRestrictions(ctx, model, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRestrictions", reflect.TypeOf((*MockRepository)(nil).UpdateRestrictions), ctx, model, event)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockRepository) UpdateStatus(ctx context.Context, model accountModel, event statusEventModel) (accountModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, model, event)
+	ret0, _ := ret[0].(accountModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockRepositoryMockRecorder) UpdateStatus(ctx, model, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockRepository)(nil).UpdateStatus), ctx, model, event)
+}
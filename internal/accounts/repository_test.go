@@ -60,6 +60,7 @@ func TestRepository(t *testing.T) {
 		created, err := repo.Create(
 			ctx,
 			newAccountModel(account),
+			nil,
 		)
 		assert.NoError(t, err)
 		assert.NotEmpty(t, created.ID)
@@ -81,6 +82,7 @@ func TestRepository(t *testing.T) {
 		created, err := repo.Create(
 			ctx,
 			newAccountModel(account),
+			nil,
 		)
 		assert.NoError(t, err)
 		assert.NotEmpty(t, created.ID)
@@ -115,6 +117,7 @@ func TestRepository(t *testing.T) {
 		created, err := repo.Create(
 			ctx,
 			newAccountModel(account),
+			nil,
 		)
 		assert.NoError(t, err)
 		assert.NotEmpty(t, created.ID)
@@ -165,6 +168,395 @@ func TestRepository(t *testing.T) {
 		assert.NotEqual(t, created, rst[0])
 	})
 
+	t.Run("fail create account, agency and number already used", func(t *testing.T) {
+		otherHolder, err := holdersRepo.Create(ctx, holders.HolderModel{
+			Name:           gofakeit.Name(),
+			DocumentNumber: gofakeit.SSN(),
+		})
+		assert.NoError(t, err)
+
+		account := Account{
+			Name:     gofakeit.Name(),
+			Agency:   "0001",
+			Number:   "123456",
+			HolderID: otherHolder.ID,
+			Status:   ActiveStatus,
+		}
+		created, err := repo.Create(ctx, newAccountModel(account), nil)
+		assert.ErrorIs(t, err, errDuplicatedAccountNumber)
+		assert.Empty(t, created)
+	})
+
+	t.Run("list accounts by agency and number", func(t *testing.T) {
+		total, rst, err := repo.ListByFilter(ctx, ListFilter{Agency: "0001", Number: "123457"})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, rst, 1)
+		assert.Equal(t, "123457", rst[0].Number)
+	})
+
+	t.Run("list accounts by legacy number", func(t *testing.T) {
+		total, rst, err := repo.ListByFilter(ctx, ListFilter{Agency: "0001", Number: "123458"})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, rst, 1)
+
+		_, err = db.Master().ExecContext(
+			ctx,
+			`INSERT INTO account_number_changes (account_id, agency, old_number, new_number, reason)
+			VALUES (?, '0001', '654321', '123458', 'CHECK_DIGIT')`,
+			rst[0].ID.String(),
+		)
+		assert.NoError(t, err)
+
+		total, legacy, err := repo.ListByFilter(ctx, ListFilter{Agency: "0001", Number: "654321"})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, legacy, 1)
+		assert.Equal(t, rst[0].ID, legacy[0].ID)
+	})
+
+	t.Run("close accounts checking the balance and the transfers", func(t *testing.T) {
+		source, err := repo.Create(ctx, newAccountModel(Account{
+			Name:     gofakeit.Name(),
+			Agency:   "0001",
+			Number:   "223456-4",
+			HolderID: holderModel.ID,
+			Status:   ActiveStatus,
+		}), nil)
+		assert.NoError(t, err)
+
+		destination, err := repo.Create(ctx, newAccountModel(Account{
+			Name:     gofakeit.Name(),
+			Agency:   "0001",
+			Number:   "223457-2",
+			HolderID: holderModel.ID,
+			Status:   ActiveStatus,
+		}), nil)
+		assert.NoError(t, err)
+
+		_, err = db.Master().ExecContext(
+			ctx,
+			"INSERT INTO transactions (id, to_account_id, type, amount, description) VALUES (?, ?, 'CREDIT', ?, ?)",
+			uuid.NewString(),
+			source.ID.String(),
+			150.0,
+			"deposit",
+		)
+		assert.NoError(t, err)
+
+		source.ClosureReason = "requested by the holder"
+		event := newStatusEventModel(source.ID, ActiveStatus, ClosedStatus, source.ClosureReason, "backoffice")
+		closed, err := repo.Close(ctx, source, event)
+		assert.ErrorIs(t, err, errAccountBalanceNotZero)
+		assert.Empty(t, closed)
+
+		_, err = db.Master().ExecContext(
+			ctx,
+			"INSERT INTO transactions (id, from_account_id, to_account_id, type, amount, description) "+
+				"VALUES (?, ?, ?, 'P2P', ?, ?)",
+			uuid.NewString(),
+			source.ID.String(),
+			destination.ID.String(),
+			150.0,
+			"account closure",
+		)
+		assert.NoError(t, err)
+
+		transferID := uuid.NewString()
+		_, err = db.Master().ExecContext(
+			ctx,
+			"INSERT INTO transfers (id, account_id, amount, bank_code, agency, account_number, document_number, "+
+				"name, reference, status) VALUES (?, ?, 10, '341', '1', '1-1', '52998224725', 'Supplier', ?, 'SENT')",
+			transferID,
+			source.ID.String(),
+			transferID[:20],
+		)
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, repo.CheckClosable(ctx, source.ID), errAccountHasPendingTransfers)
+
+		closed, err = repo.Close(ctx, source, event)
+		assert.ErrorIs(t, err, errAccountHasPendingTransfers)
+		assert.Empty(t, closed)
+
+		_, err = db.Master().ExecContext(ctx, "UPDATE transfers SET status = 'CONFIRMED' WHERE id = ?", transferID)
+		assert.NoError(t, err)
+
+		assert.NoError(t, repo.CheckClosable(ctx, source.ID))
+
+		closed, err = repo.Close(ctx, source, event)
+		assert.NoError(t, err)
+		assert.Equal(t, ClosedStatus, closed.Status)
+		assert.NotEmpty(t, closed.ClosedAt)
+
+		events, err := repo.ListStatusEventsByAccountID(ctx, source.ID)
+		assert.NoError(t, err)
+		assert.Len(t, events, 1)
+		assert.Equal(t, ClosedStatus, events[0].ToStatus)
+
+		events, err = repo.ListStatusEventsByAccountID(ctx, destination.ID)
+		assert.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("block and unblock account recording status events", func(t *testing.T) {
+		account, err := repo.Create(ctx, newAccountModel(Account{
+			Name:     gofakeit.Name(),
+			Agency:   "0001",
+			Number:   "323456-8",
+			HolderID: holderModel.ID,
+			Status:   ActiveStatus,
+		}), nil)
+		assert.NoError(t, err)
+
+		account.Status = BlockedStatus
+		blocked, err := repo.UpdateStatus(
+			ctx,
+			account,
+			newStatusEventModel(account.ID, ActiveStatus, BlockedStatus, "suspicious activity", "backoffice"),
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, BlockedStatus, blocked.Status)
+
+		blocked.Status = ActiveStatus
+		unblocked, err := repo.UpdateStatus(
+			ctx,
+			blocked,
+			newStatusEventModel(account.ID, BlockedStatus, ActiveStatus, "activity verified", ""),
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, ActiveStatus, unblocked.Status)
+
+		events, err := repo.ListStatusEventsByAccountID(ctx, account.ID)
+		assert.NoError(t, err)
+		assert.Len(t, events, 2)
+		assert.Equal(t, BlockedStatus, events[0].ToStatus)
+		assert.Equal(t, "backoffice", events[0].Actor)
+		assert.Equal(t, ActiveStatus, events[1].ToStatus)
+	})
+
+	t.Run("restrict account and hold funds blocking closure", func(t *testing.T) {
+		account, err := repo.Create(ctx, newAccountModel(Account{
+			Name:     gofakeit.Name(),
+			Agency:   "0001",
+			Number:   "423456-1",
+			HolderID: holderModel.ID,
+			Status:   ActiveStatus,
+		}), nil)
+		assert.NoError(t, err)
+
+		account.Restrictions = []string{string(DebitsRestriction)}
+		event := newStatusEventModel(account.ID, ActiveStatus, ActiveStatus, "court order", "backoffice")
+		event.Restrictions = account.Restrictions
+		restricted, err := repo.UpdateRestrictions(ctx, account, event)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{string(DebitsRestriction)}, restricted.Restrictions)
+
+		hold, err := repo.CreateLegalHold(ctx, newLegalHoldModel(LegalHold{
+			AccountID:  account.ID,
+			Amount:     100,
+			CourtOrder: "0001234-56.2024",
+			Reason:     "judicial seizure",
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, ActiveLegalHoldStatus, hold.Status)
+
+		held, err := repo.SumActiveLegalHolds(ctx, account.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 100.0, held)
+
+		closed, err := repo.Close(
+			ctx,
+			restricted,
+			newStatusEventModel(account.ID, ActiveStatus, ClosedStatus, "requested by the holder", ""),
+		)
+		assert.ErrorIs(t, err, errAccountHasActiveHolds)
+		assert.Empty(t, closed)
+
+		released, err := repo.ReleaseLegalHold(ctx, legalHoldModel{
+			ID:            hold.ID,
+			AccountID:     account.ID,
+			ReleaseReason: "court order revoked",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, ReleasedLegalHoldStatus, released.Status)
+
+		_, err = repo.ReleaseLegalHold(ctx, legalHoldModel{ID: hold.ID, AccountID: account.ID})
+		assert.ErrorIs(t, err, errLegalHoldNotActive)
+
+		held, err = repo.SumActiveLegalHolds(ctx, account.ID)
+		assert.NoError(t, err)
+		assert.Zero(t, held)
+	})
+
+	t.Run("mark inactive account as dormant", func(t *testing.T) {
+		account, err := repo.Create(ctx, newAccountModel(Account{
+			Name:     gofakeit.Name(),
+			Agency:   "0001",
+			Number:   "523456-5",
+			HolderID: holderModel.ID,
+			Status:   ActiveStatus,
+		}), nil)
+		assert.NoError(t, err)
+
+		_, err = db.Master().ExecContext(
+			ctx,
+			"UPDATE accounts SET created_at = NOW() - INTERVAL '40 days' WHERE id = ?",
+			account.ID.String(),
+		)
+		assert.NoError(t, err)
+
+		lastMovementBefore := time.Now().UTC().Add(-30 * 24 * time.Hour)
+		total, candidates, err := repo.ListByLastMovement(ctx, lastMovementFilter{Before: lastMovementBefore})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, candidates, 1)
+		assert.Equal(t, account.ID, candidates[0].ID)
+		assert.True(t, candidates[0].LastMovementAt.Before(lastMovementBefore))
+
+		event := newStatusEventModel(account.ID, ActiveStatus, DormantStatus, "no movement for 30 days", "")
+		marked, err := repo.MarkDormant(ctx, candidates[0].accountModel, event, lastMovementBefore)
+		assert.NoError(t, err)
+		assert.True(t, marked)
+
+		marked, err = repo.MarkDormant(ctx, candidates[0].accountModel, event, lastMovementBefore)
+		assert.NoError(t, err)
+		assert.False(t, marked)
+
+		total, candidates, err = repo.ListByLastMovement(ctx, lastMovementFilter{Before: lastMovementBefore})
+		assert.NoError(t, err)
+		assert.Zero(t, total)
+		assert.Empty(t, candidates)
+
+		events, err := repo.ListStatusEventsByAccountID(ctx, account.ID)
+		assert.NoError(t, err)
+		assert.Len(t, events, 1)
+		assert.Equal(t, DormantStatus, events[0].ToStatus)
+	})
+
+	t.Run("list account credited only with interest by last movement", func(t *testing.T) {
+		account, err := repo.Create(ctx, newAccountModel(Account{
+			Name:     gofakeit.Name(),
+			Agency:   "0001",
+			Number:   "523457-3",
+			HolderID: holderModel.ID,
+			Status:   ActiveStatus,
+		}), nil)
+		assert.NoError(t, err)
+
+		_, err = db.Master().ExecContext(
+			ctx,
+			"UPDATE accounts SET created_at = NOW() - INTERVAL '40 days' WHERE id = ?",
+			account.ID.String(),
+		)
+		assert.NoError(t, err)
+
+		transactionID := uuid.NewString()
+		_, err = db.Master().ExecContext(
+			ctx,
+			"INSERT INTO transactions (id, to_account_id, type, amount, description) VALUES (?, ?, 'CREDIT', 1.5, 'interest')",
+			transactionID,
+			account.ID.String(),
+		)
+		assert.NoError(t, err)
+		_, err = db.Master().ExecContext(
+			ctx,
+			`INSERT INTO interest_payouts (id, account_id, month, gross_amount, tax_amount, net_amount, transaction_id)
+			VALUES (?, ?, DATE_TRUNC('month', NOW()), 1.5, 0, 1.5, ?)`,
+			uuid.NewString(),
+			account.ID.String(),
+			transactionID,
+		)
+		assert.NoError(t, err)
+
+		lastMovementBefore := time.Now().UTC().Add(-30 * 24 * time.Hour)
+		_, candidates, err := repo.ListByLastMovement(ctx, lastMovementFilter{Before: lastMovementBefore, Size: 100})
+		assert.NoError(t, err)
+
+		var listed bool
+		for _, candidate := range candidates {
+			listed = listed || candidate.ID == account.ID
+		}
+		assert.True(t, listed)
+	})
+
+	t.Run("list account charged only with fees by last movement", func(t *testing.T) {
+		account, err := repo.Create(ctx, newAccountModel(Account{
+			Name:     gofakeit.Name(),
+			Agency:   "0001",
+			Number:   "523458-1",
+			HolderID: holderModel.ID,
+			Status:   ActiveStatus,
+		}), nil)
+		assert.NoError(t, err)
+
+		_, err = db.Master().ExecContext(
+			ctx,
+			"UPDATE accounts SET created_at = NOW() - INTERVAL '40 days' WHERE id = ?",
+			account.ID.String(),
+		)
+		assert.NoError(t, err)
+
+		_, err = db.Master().ExecContext(
+			ctx,
+			"INSERT INTO transactions (id, from_account_id, type, amount, description) VALUES (?, ?, 'FEE', 9.9, 'maintenance')",
+			uuid.NewString(),
+			account.ID.String(),
+		)
+		assert.NoError(t, err)
+
+		lastMovementBefore := time.Now().UTC().Add(-30 * 24 * time.Hour)
+		_, candidates, err := repo.ListByLastMovement(ctx, lastMovementFilter{Before: lastMovementBefore, Size: 100})
+		assert.NoError(t, err)
+
+		var listed bool
+		for _, candidate := range candidates {
+			listed = listed || candidate.ID == account.ID
+		}
+		assert.True(t, listed)
+	})
+
+	t.Run("create joint account listed for every holder", func(t *testing.T) {
+		coOwner, err := holdersRepo.Create(ctx, holders.HolderModel{
+			ID:             uuid.New(),
+			Name:           gofakeit.Name(),
+			DocumentNumber: gofakeit.SSN(),
+		})
+		assert.NoError(t, err)
+
+		account, err := repo.Create(
+			ctx,
+			newAccountModel(Account{
+				Name:     gofakeit.Name(),
+				Agency:   "0001",
+				Number:   "623456-9",
+				HolderID: holderModel.ID,
+				Status:   ActiveStatus,
+			}),
+			[]accountHolderModel{
+				newAccountHolderModel(AccountHolder{HolderID: holderModel.ID, Role: OwnerHolderRole}),
+				newAccountHolderModel(AccountHolder{HolderID: coOwner.ID, Role: CoOwnerHolderRole}),
+			},
+		)
+		assert.NoError(t, err)
+
+		accountHolders, err := repo.ListHolders(ctx, account.ID)
+		assert.NoError(t, err)
+		assert.Len(t, accountHolders, 2)
+		assert.Equal(t, holderModel.DocumentNumber, accountHolders[0].HolderDocumentNumber)
+		assert.Equal(t, OwnerHolderRole, accountHolders[0].Role)
+		assert.Equal(t, coOwner.DocumentNumber, accountHolders[1].HolderDocumentNumber)
+		assert.Equal(t, CoOwnerHolderRole, accountHolders[1].Role)
+
+		total, rst, err := repo.ListByFilter(ctx, ListFilter{HolderID: uuid.NullUUID{UUID: coOwner.ID, Valid: true}})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, rst, 1)
+		assert.Equal(t, account.ID, rst[0].ID)
+		assert.Equal(t, CoOwnerHolderRole, rst[0].HolderRole)
+	})
+
 	t.Run("account not found searching for these filters", func(t *testing.T) {
 		rst, err := repo.GetByFilter(ctx, accountFilter{
 			ID: uuid.NullUUID{
@@ -3,9 +3,14 @@ package accounts
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/dalmarcogd/dock-test/internal/exchange"
 	"github.com/dalmarcogd/dock-test/internal/holders"
-	"github.com/dalmarcogd/dock-test/pkg/stringer"
+	"github.com/dalmarcogd/dock-test/internal/products"
+	"github.com/dalmarcogd/dock-test/pkg/document"
 	"github.com/dalmarcogd/dock-test/pkg/tracer"
 	"github.com/dalmarcogd/dock-test/pkg/zapctx"
 	"github.com/google/uuid"
@@ -13,75 +18,392 @@ import (
 )
 
 var (
-	ErrAccountHolderNotFound = errors.New("no holders found with this document_number")
-	ErrAccountNotFound       = errors.New("no accounts found with these filters")
-	ErrMultpleAccountsFound  = errors.New("multiple accounts found with these filters")
-	ErrAccountInactive       = errors.New("account must be active for this operation")
-	ErrAccountUnblcked       = errors.New("account must be blocked for this operation")
+	ErrAccountHolderNotFound       = errors.New("no holders found with this document_number")
+	ErrAccountNotFound             = errors.New("no accounts found with these filters")
+	ErrMultpleAccountsFound        = errors.New("multiple accounts found with these filters")
+	ErrAccountInactive             = errors.New("account must be active for this operation")
+	ErrAccountUnblcked             = errors.New("account must be blocked for this operation")
+	ErrAccountHolderKYCNotApproved = errors.New("the holder kyc must be approved to open accounts")
+	ErrAccountNumberUnavailable    = errors.New("could not generate an unused account number")
+	ErrInvalidAccountNumber        = errors.New("invalid account number check digit")
+	ErrInvalidAccountType          = errors.New("invalid account type")
+	ErrAccountTypeNotAllowed       = errors.New("the account type is not allowed for this holder")
+	ErrStatusReasonRequired        = errors.New("a reason is required to change the account status")
+	ErrAccountBalanceNotZero       = errors.New("the account balance must be zero or a destination account informed")
+	ErrInvalidClosureDestination   = errors.New("the closure destination must be another active account")
+	ErrAccountHasActiveHolds       = errors.New("the account has active legal holds")
+	ErrAccountClosed               = errors.New("the account is closed")
+	ErrInvalidRestriction          = errors.New("invalid account restriction")
+	ErrInvalidLegalHoldAmount      = errors.New("the legal hold amount must be greater than zero")
+	ErrLegalHoldNotFound           = errors.New("no active legal hold found for this account")
+	ErrAccountNotDormant           = errors.New("account must be dormant for this operation")
+	ErrDormancyDisabled            = errors.New("account dormancy is disabled")
+	ErrInvalidDormancyWindow       = errors.New("the dormancy window must be positive and up to the inactivity period")
+	ErrInvalidHolderRole           = errors.New("co-holders must be CO_OWNER or AUTHORIZED_OPERATOR")
+	ErrDuplicatedAccountHolder     = errors.New("a holder can be linked to an account only once")
+	ErrAccountHolderNotLinked      = errors.New("the holder is not linked to the account")
+	ErrPocketNameRequired          = errors.New("a name is required to create a pocket")
+	ErrDuplicatedPocketName        = errors.New("a pocket with this name already exists in the account")
+	ErrPocketNesting               = errors.New("pockets cannot have pockets")
+	ErrPocketLimitReached          = errors.New("the account reached the maximum number of pockets")
+	ErrAccountHasOpenPockets       = errors.New("the account has open pockets")
+	ErrInvalidAccountCurrency      = errors.New("the account currency is not a valid ISO 4217 code")
+	ErrClosureCurrencyMismatch     = errors.New("the closure destination must hold the same currency as the account")
+	ErrAccountHasPendingTransfers  = errors.New("the account has transfers not settled by the bank")
 )
 
 type Service interface {
 	Create(ctx context.Context, account Account) (Account, error)
-	BlockByID(ctx context.Context, id uuid.UUID) (Account, error)
-	UnblockByID(ctx context.Context, id uuid.UUID) (Account, error)
-	CloseByID(ctx context.Context, id uuid.UUID) (Account, error)
+	BlockByID(ctx context.Context, id uuid.UUID, change StatusChange) (Account, error)
+	UnblockByID(ctx context.Context, id uuid.UUID, change StatusChange) (Account, error)
+	CloseByID(ctx context.Context, id uuid.UUID, closure Closure) (Account, error)
+	CheckClosable(ctx context.Context, id uuid.UUID) error
+	ListStatusHistory(ctx context.Context, id uuid.UUID) ([]StatusEvent, error)
+	ListHolders(ctx context.Context, id uuid.UUID) ([]AccountHolder, error)
+	CreatePocket(ctx context.Context, parentID uuid.UUID, name string) (Account, error)
+	ListPockets(ctx context.Context, parentID uuid.UUID) ([]Account, error)
+	GetHolderRole(ctx context.Context, id uuid.UUID, documentNumber string) (HolderRole, error)
+	SetRestrictions(ctx context.Context, id uuid.UUID, restrictions []Restriction, change StatusChange) (Account, error)
+	CreateLegalHold(ctx context.Context, hold LegalHold) (LegalHold, error)
+	ReleaseLegalHold(ctx context.Context, accountID, holdID uuid.UUID, change StatusChange) (LegalHold, error)
+	ListLegalHolds(ctx context.Context, accountID uuid.UUID) ([]LegalHold, error)
+	GetHeldAmount(ctx context.Context, accountID uuid.UUID) (float64, error)
+	ReactivateByID(ctx context.Context, id uuid.UUID, change StatusChange) (Account, error)
+	MarkDormant(ctx context.Context) (int, error)
+	ListDormancyCandidates(ctx context.Context, filter DormancyFilter) (int, []DormancyCandidate, error)
 	GetByID(ctx context.Context, id uuid.UUID) (Account, error)
 	List(ctx context.Context, filter ListFilter) (int, []Account, error)
 }
 
 type service struct {
-	tracer           tracer.Tracer
-	repository       Repository
-	holderRepository holders.Repository
+	tracer             tracer.Tracer
+	repository         Repository
+	holderRepository   holders.Repository
+	requireApprovedKYC bool
+	// dormancyPeriod is how long an active account may go without movement before
+	// becoming dormant, zero disables dormancy.
+	dormancyPeriod time.Duration
 }
 
-func NewService(t tracer.Tracer, r Repository, holderRepository holders.Repository) Service {
-	return service{tracer: t, repository: r, holderRepository: holderRepository}
+func NewService(
+	t tracer.Tracer,
+	r Repository,
+	holderRepository holders.Repository,
+	requireApprovedKYC bool,
+	dormancyPeriod time.Duration,
+) Service {
+	return service{
+		tracer:             t,
+		repository:         r,
+		holderRepository:   holderRepository,
+		requireApprovedKYC: requireApprovedKYC,
+		dormancyPeriod:     dormancyPeriod,
+	}
 }
 
 func (s service) Create(ctx context.Context, account Account) (Account, error) {
 	ctx, span := s.tracer.Span(ctx)
 	defer span.End()
 
-	if account.DocumentNumber == "" {
-		zapctx.L(ctx).Error("account_service_document_number_not_found_error", zap.Error(ErrAccountHolderNotFound))
-		span.RecordError(ErrAccountHolderNotFound)
-		return Account{}, ErrAccountHolderNotFound
+	if account.Type == "" {
+		account.Type = products.CheckingType
+	}
+
+	if !account.Type.Valid() {
+		zapctx.L(ctx).Error(
+			"account_service_invalid_type_error",
+			zap.String("type", string(account.Type)),
+			zap.Error(ErrInvalidAccountType),
+		)
+		span.RecordError(ErrInvalidAccountType)
+		return Account{}, ErrInvalidAccountType
 	}
 
-	hds, err := s.holderRepository.GetByFilter(ctx, holders.HolderFilter{DocumentNumber: account.DocumentNumber})
+	if account.Currency == "" {
+		account.Currency = exchange.DefaultCurrency
+	}
+
+	if !account.Currency.Valid() {
+		zapctx.L(ctx).Error(
+			"account_service_invalid_currency_error",
+			zap.String("currency", string(account.Currency)),
+			zap.Error(ErrInvalidAccountCurrency),
+		)
+		span.RecordError(ErrInvalidAccountCurrency)
+		return Account{}, ErrInvalidAccountCurrency
+	}
+
+	account.DocumentNumber = document.Normalize(account.DocumentNumber)
+	owner, err := s.getHolder(ctx, account.DocumentNumber)
 	if err != nil {
-		zapctx.L(ctx).Error("account_service_holder_repository_error", zap.Error(err))
 		span.RecordError(err)
 		return Account{}, err
 	}
 
-	if len(hds) != 1 {
-		zapctx.L(ctx).Error("account_service_document_number_not_found_error", zap.Error(ErrAccountHolderNotFound))
-		span.RecordError(ErrAccountHolderNotFound)
-		return Account{}, ErrAccountHolderNotFound
+	if account.Type == products.BusinessType && owner.Type != holders.CompanyType {
+		zapctx.L(ctx).Error(
+			"account_service_type_not_allowed_error",
+			zap.String("holder_id", owner.ID.String()),
+			zap.String("type", string(account.Type)),
+			zap.Error(ErrAccountTypeNotAllowed),
+		)
+		span.RecordError(ErrAccountTypeNotAllowed)
+		return Account{}, ErrAccountTypeNotAllowed
 	}
 
-	account.Agency = "0001"
-	account.Number = stringer.GenerateCode([]rune(AccountNumberVariants), AccountNumberSize)
-	account.HolderID = hds[0].ID
+	account.Holders, err = s.resolveHolders(ctx, owner, account.Holders)
+	if err != nil {
+		span.RecordError(err)
+		return Account{}, err
+	}
+
+	holderModels := make([]accountHolderModel, len(account.Holders))
+	for i, holder := range account.Holders {
+		holderModels[i] = newAccountHolderModel(holder)
+	}
+
+	account.Agency = AccountAgency
+	account.HolderID = owner.ID
 	account.Status = ActiveStatus
 
-	model, err := s.repository.Create(ctx, newAccountModel(account))
+	account, err = s.insert(ctx, account, holderModels)
 	if err != nil {
-		zapctx.L(ctx).Error("account_service_create_repository_error", zap.Error(err))
 		span.RecordError(err)
 		return Account{}, err
 	}
-	account.ID = model.ID
 
 	return account, nil
 }
 
-func (s service) BlockByID(ctx context.Context, id uuid.UUID) (Account, error) {
+// insert stores the account with a newly generated number, retrying when it collides with an existing one.
+func (s service) insert(ctx context.Context, account Account, holderModels []accountHolderModel) (Account, error) {
+	for attempt := 1; ; attempt++ {
+		account.Number = generateNumber()
+
+		model, err := s.repository.Create(ctx, newAccountModel(account), holderModels)
+		if err == nil {
+			account.ID = model.ID
+			for i := range account.Holders {
+				account.Holders[i].CreatedAt = model.CreatedAt
+			}
+			return account, nil
+		}
+
+		if errors.Is(err, errDuplicatedPocketName) {
+			return Account{}, ErrDuplicatedPocketName
+		}
+
+		if !errors.Is(err, errDuplicatedAccountNumber) {
+			zapctx.L(ctx).Error("account_service_create_repository_error", zap.Error(err))
+			return Account{}, err
+		}
+
+		if attempt == maxNumberGenerationAttempts {
+			zapctx.L(ctx).Error(
+				"account_service_create_number_unavailable_error",
+				zap.Int("attempts", attempt),
+				zap.Error(ErrAccountNumberUnavailable),
+			)
+			return Account{}, ErrAccountNumberUnavailable
+		}
+
+		zapctx.L(ctx).Warn(
+			"account_service_create_number_collision",
+			zap.String("agency", account.Agency),
+			zap.String("number", account.Number),
+			zap.Int("attempt", attempt),
+		)
+	}
+}
+
+// CreatePocket opens a pocket under the account, sharing its holders, type and roles.
+func (s service) CreatePocket(ctx context.Context, parentID uuid.UUID, name string) (Account, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		span.RecordError(ErrPocketNameRequired)
+		return Account{}, ErrPocketNameRequired
+	}
+
+	parent, err := s.GetByID(ctx, parentID)
+	if err != nil {
+		span.RecordError(err)
+		return Account{}, err
+	}
+
+	if parent.IsPocket() {
+		span.RecordError(ErrPocketNesting)
+		return Account{}, ErrPocketNesting
+	}
+
+	if parent.Status != ActiveStatus {
+		span.RecordError(ErrAccountInactive)
+		return Account{}, ErrAccountInactive
+	}
+
+	pockets, err := s.repository.ListPockets(ctx, parentID)
+	if err != nil {
+		zapctx.L(ctx).Error("account_service_list_pockets_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return Account{}, err
+	}
+
+	if len(pockets) >= maxPocketsPerAccount {
+		span.RecordError(ErrPocketLimitReached)
+		return Account{}, ErrPocketLimitReached
+	}
+
+	holderModels, err := s.repository.ListHolders(ctx, parentID)
+	if err != nil {
+		zapctx.L(ctx).Error("account_service_list_holders_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return Account{}, err
+	}
+
+	pocket := Account{
+		Name:           name,
+		Agency:         AccountAgency,
+		DocumentNumber: parent.DocumentNumber,
+		HolderID:       parent.HolderID,
+		Type:           parent.Type,
+		Currency:       parent.Currency,
+		Status:         ActiveStatus,
+		ParentID:       uuid.NullUUID{UUID: parentID, Valid: true},
+		Holders:        make([]AccountHolder, len(holderModels)),
+	}
+	for i, model := range holderModels {
+		pocket.Holders[i] = newAccountHolder(model)
+		holderModels[i] = newAccountHolderModel(pocket.Holders[i])
+	}
+
+	pocket, err = s.insert(ctx, pocket, holderModels)
+	if err != nil {
+		span.RecordError(err)
+		return Account{}, err
+	}
+
+	return pocket, nil
+}
+
+func (s service) ListPockets(ctx context.Context, parentID uuid.UUID) ([]Account, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if _, err := s.GetByID(ctx, parentID); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	models, err := s.repository.ListPockets(ctx, parentID)
+	if err != nil {
+		zapctx.L(ctx).Error("account_service_list_pockets_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	pockets := make([]Account, len(models))
+	for i, model := range models {
+		pockets[i] = newAccount(model)
+	}
+
+	return pockets, nil
+}
+
+// getHolder finds the holder with the document number, which must have its kyc approved when required.
+func (s service) getHolder(ctx context.Context, documentNumber string) (holders.HolderModel, error) {
+	documentNumber = document.Normalize(documentNumber)
+	if documentNumber == "" {
+		zapctx.L(ctx).Error("account_service_document_number_not_found_error", zap.Error(ErrAccountHolderNotFound))
+		return holders.HolderModel{}, ErrAccountHolderNotFound
+	}
+
+	hds, err := s.holderRepository.GetByFilter(ctx, holders.HolderFilter{DocumentNumber: documentNumber})
+	if err != nil {
+		zapctx.L(ctx).Error("account_service_holder_repository_error", zap.Error(err))
+		return holders.HolderModel{}, err
+	}
+
+	if len(hds) != 1 {
+		zapctx.L(ctx).Error("account_service_document_number_not_found_error", zap.Error(ErrAccountHolderNotFound))
+		return holders.HolderModel{}, ErrAccountHolderNotFound
+	}
+
+	if s.requireApprovedKYC && hds[0].KYCStatus != holders.ApprovedKYCStatus {
+		zapctx.L(ctx).Error(
+			"account_service_holder_kyc_not_approved_error",
+			zap.String("holder_id", hds[0].ID.String()),
+			zap.String("kyc_status", string(hds[0].KYCStatus)),
+			zap.Error(ErrAccountHolderKYCNotApproved),
+		)
+		return holders.HolderModel{}, ErrAccountHolderKYCNotApproved
+	}
+
+	return hds[0], nil
+}
+
+// resolveHolders returns the owner followed by the informed co-holders, each one found by its
+// document number and linked only once.
+func (s service) resolveHolders(
+	ctx context.Context,
+	owner holders.HolderModel,
+	coHolders []AccountHolder,
+) ([]AccountHolder, error) {
+	resolved := make([]AccountHolder, 0, len(coHolders)+1)
+	resolved = append(resolved, AccountHolder{
+		HolderID:       owner.ID,
+		Name:           owner.Name,
+		DocumentNumber: owner.DocumentNumber,
+		Role:           OwnerHolderRole,
+	})
+
+	linked := map[uuid.UUID]bool{owner.ID: true}
+	for _, coHolder := range coHolders {
+		if coHolder.Role != CoOwnerHolderRole && coHolder.Role != OperatorHolderRole {
+			zapctx.L(ctx).Error(
+				"account_service_invalid_holder_role_error",
+				zap.String("role", string(coHolder.Role)),
+				zap.Error(ErrInvalidHolderRole),
+			)
+			return nil, ErrInvalidHolderRole
+		}
+
+		holder, err := s.getHolder(ctx, coHolder.DocumentNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		if linked[holder.ID] {
+			zapctx.L(ctx).Error(
+				"account_service_duplicated_holder_error",
+				zap.String("holder_id", holder.ID.String()),
+				zap.Error(ErrDuplicatedAccountHolder),
+			)
+			return nil, ErrDuplicatedAccountHolder
+		}
+		linked[holder.ID] = true
+
+		resolved = append(resolved, AccountHolder{
+			HolderID:       holder.ID,
+			Name:           holder.Name,
+			DocumentNumber: holder.DocumentNumber,
+			Role:           coHolder.Role,
+		})
+	}
+
+	return resolved, nil
+}
+
+func (s service) BlockByID(ctx context.Context, id uuid.UUID, change StatusChange) (Account, error) {
 	ctx, span := s.tracer.Span(ctx)
 	defer span.End()
 
+	if strings.TrimSpace(change.Reason) == "" {
+		span.RecordError(ErrStatusReasonRequired)
+		return Account{}, ErrStatusReasonRequired
+	}
+
 	account, err := s.GetByID(ctx, id)
 	if err != nil {
 		zapctx.L(ctx).Error(
@@ -104,9 +426,10 @@ func (s service) BlockByID(ctx context.Context, id uuid.UUID) (Account, error) {
 		return Account{}, ErrAccountInactive
 	}
 
+	event := newStatusEventModel(id, account.Status, BlockedStatus, change.Reason, change.Actor)
 	account.Status = BlockedStatus
 
-	model, err := s.repository.Update(ctx, newAccountModel(account))
+	model, err := s.repository.UpdateStatus(ctx, newAccountModel(account), event)
 	if err != nil {
 		zapctx.L(ctx).Error("account_service_update_repository_error", zap.Error(err))
 		span.RecordError(err)
@@ -117,10 +440,15 @@ func (s service) BlockByID(ctx context.Context, id uuid.UUID) (Account, error) {
 	return account, nil
 }
 
-func (s service) UnblockByID(ctx context.Context, id uuid.UUID) (Account, error) {
+func (s service) UnblockByID(ctx context.Context, id uuid.UUID, change StatusChange) (Account, error) {
 	ctx, span := s.tracer.Span(ctx)
 	defer span.End()
 
+	if strings.TrimSpace(change.Reason) == "" {
+		span.RecordError(ErrStatusReasonRequired)
+		return Account{}, ErrStatusReasonRequired
+	}
+
 	account, err := s.GetByID(ctx, id)
 	if err != nil {
 		zapctx.L(ctx).Error(
@@ -143,9 +471,10 @@ func (s service) UnblockByID(ctx context.Context, id uuid.UUID) (Account, error)
 		return Account{}, ErrAccountUnblcked
 	}
 
+	event := newStatusEventModel(id, account.Status, ActiveStatus, change.Reason, change.Actor)
 	account.Status = ActiveStatus
 
-	model, err := s.repository.Update(ctx, newAccountModel(account))
+	model, err := s.repository.UpdateStatus(ctx, newAccountModel(account), event)
 	if err != nil {
 		zapctx.L(ctx).Error("account_service_unblock_update_repository_error", zap.Error(err))
 		span.RecordError(err)
@@ -156,10 +485,17 @@ func (s service) UnblockByID(ctx context.Context, id uuid.UUID) (Account, error)
 	return account, nil
 }
 
-func (s service) CloseByID(ctx context.Context, id uuid.UUID) (Account, error) {
+// CloseByID closes the account, whose balance must be zero. Accounts holding funds are closed
+// through transactions.Service CloseAccount, which moves the balance out first.
+func (s service) CloseByID(ctx context.Context, id uuid.UUID, closure Closure) (Account, error) {
 	ctx, span := s.tracer.Span(ctx)
 	defer span.End()
 
+	if strings.TrimSpace(closure.Reason) == "" {
+		span.RecordError(ErrStatusReasonRequired)
+		return Account{}, ErrStatusReasonRequired
+	}
+
 	account, err := s.GetByID(ctx, id)
 	if err != nil {
 		zapctx.L(ctx).Error(
@@ -175,11 +511,270 @@ func (s service) CloseByID(ctx context.Context, id uuid.UUID) (Account, error) {
 		return account, nil
 	}
 
-	account.Status = ClosedStatus
+	event := newStatusEventModel(id, account.Status, ClosedStatus, closure.Reason, closure.Actor)
+	account.ClosureReason = closure.Reason
+
+	model, err := s.repository.Close(ctx, newAccountModel(account), event)
+	if err != nil {
+		err = closureError(ctx, id, err)
+		span.RecordError(err)
+		return Account{}, err
+	}
+
+	account.Status = model.Status
+	account.ClosedAt = model.ClosedAt
+	return account, nil
+}
+
+// CheckClosable tells whether anything but its balance keeps the account open: active legal holds,
+// open pockets or transfers the bank did not settle yet.
+func (s service) CheckClosable(ctx context.Context, id uuid.UUID) error {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	err := s.repository.CheckClosable(ctx, id)
+	if err != nil {
+		err = closureError(ctx, id, err)
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+// closureError translates the repository error of closing the account.
+func closureError(ctx context.Context, id uuid.UUID, err error) error {
+	var serviceErr error
+	switch {
+	case errors.Is(err, errAccountBalanceNotZero):
+		serviceErr = ErrAccountBalanceNotZero
+	case errors.Is(err, errAccountHasActiveHolds):
+		serviceErr = ErrAccountHasActiveHolds
+	case errors.Is(err, errAccountHasOpenPockets):
+		serviceErr = ErrAccountHasOpenPockets
+	case errors.Is(err, errAccountHasPendingTransfers):
+		serviceErr = ErrAccountHasPendingTransfers
+	default:
+		zapctx.L(ctx).Error("account_service_close_repository_error", zap.Error(err))
+		return err
+	}
+
+	zapctx.L(ctx).Error(
+		"account_service_close_not_closable_error",
+		zap.String("id", id.String()),
+		zap.Error(serviceErr),
+	)
+	return serviceErr
+}
+
+func (s service) SetRestrictions(
+	ctx context.Context,
+	id uuid.UUID,
+	restrictions []Restriction,
+	change StatusChange,
+) (Account, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if strings.TrimSpace(change.Reason) == "" {
+		span.RecordError(ErrStatusReasonRequired)
+		return Account{}, ErrStatusReasonRequired
+	}
+
+	seen := make(map[Restriction]bool, len(restrictions))
+	unique := make([]Restriction, 0, len(restrictions))
+	for _, restriction := range restrictions {
+		if !restriction.Valid() {
+			span.RecordError(ErrInvalidRestriction)
+			return Account{}, ErrInvalidRestriction
+		}
+		if !seen[restriction] {
+			seen[restriction] = true
+			unique = append(unique, restriction)
+		}
+	}
+
+	account, err := s.GetByID(ctx, id)
+	if err != nil {
+		zapctx.L(ctx).Error(
+			"account_service_restrictions_get_error",
+			zap.String("id", id.String()),
+			zap.Error(err),
+		)
+		span.RecordError(err)
+		return Account{}, err
+	}
+
+	if account.Status == ClosedStatus {
+		span.RecordError(ErrAccountClosed)
+		return Account{}, ErrAccountClosed
+	}
+
+	account.Restrictions = unique
+	event := newStatusEventModel(id, account.Status, account.Status, change.Reason, change.Actor)
+	event.Restrictions = newRestrictionValues(unique)
+
+	model, err := s.repository.UpdateRestrictions(ctx, newAccountModel(account), event)
+	if err != nil {
+		zapctx.L(ctx).Error("account_service_restrictions_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return Account{}, err
+	}
+
+	account.Restrictions = newRestrictions(model.Restrictions)
+	return account, nil
+}
+
+func (s service) CreateLegalHold(ctx context.Context, hold LegalHold) (LegalHold, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if hold.Amount <= 0 {
+		span.RecordError(ErrInvalidLegalHoldAmount)
+		return LegalHold{}, ErrInvalidLegalHoldAmount
+	}
+
+	if strings.TrimSpace(hold.Reason) == "" {
+		span.RecordError(ErrStatusReasonRequired)
+		return LegalHold{}, ErrStatusReasonRequired
+	}
+
+	account, err := s.GetByID(ctx, hold.AccountID)
+	if err != nil {
+		span.RecordError(err)
+		return LegalHold{}, err
+	}
+
+	if account.Status == ClosedStatus {
+		span.RecordError(ErrAccountClosed)
+		return LegalHold{}, ErrAccountClosed
+	}
+
+	model, err := s.repository.CreateLegalHold(ctx, newLegalHoldModel(hold))
+	if err != nil {
+		zapctx.L(ctx).Error("account_service_create_legal_hold_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return LegalHold{}, err
+	}
+
+	zapctx.L(ctx).Info(
+		"account_service_legal_hold_created",
+		zap.String("account_id", hold.AccountID.String()),
+		zap.String("hold_id", model.ID.String()),
+		zap.Float64("amount", model.Amount),
+	)
+
+	return newLegalHold(model), nil
+}
+
+func (s service) ReleaseLegalHold(
+	ctx context.Context,
+	accountID,
+	holdID uuid.UUID,
+	change StatusChange,
+) (LegalHold, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if strings.TrimSpace(change.Reason) == "" {
+		span.RecordError(ErrStatusReasonRequired)
+		return LegalHold{}, ErrStatusReasonRequired
+	}
+
+	model, err := s.repository.ReleaseLegalHold(ctx, legalHoldModel{
+		ID:            holdID,
+		AccountID:     accountID,
+		ReleaseReason: change.Reason,
+		ReleasedBy:    change.Actor,
+	})
+	if err != nil {
+		if errors.Is(err, errLegalHoldNotActive) {
+			span.RecordError(ErrLegalHoldNotFound)
+			return LegalHold{}, ErrLegalHoldNotFound
+		}
+		zapctx.L(ctx).Error("account_service_release_legal_hold_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return LegalHold{}, err
+	}
+
+	return newLegalHold(model), nil
+}
+
+func (s service) ListLegalHolds(ctx context.Context, accountID uuid.UUID) ([]LegalHold, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if _, err := s.GetByID(ctx, accountID); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	models, err := s.repository.ListLegalHoldsByAccountID(ctx, accountID)
+	if err != nil {
+		zapctx.L(ctx).Error("account_service_list_legal_holds_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	holds := make([]LegalHold, len(models))
+	for i, model := range models {
+		holds[i] = newLegalHold(model)
+	}
+
+	return holds, nil
+}
+
+// GetHeldAmount returns the sum of the active legal holds, which must stay in the account.
+func (s service) GetHeldAmount(ctx context.Context, accountID uuid.UUID) (float64, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	amount, err := s.repository.SumActiveLegalHolds(ctx, accountID)
+	if err != nil {
+		zapctx.L(ctx).Error(
+			"account_service_held_amount_repository_error",
+			zap.String("account_id", accountID.String()),
+			zap.Error(err),
+		)
+		span.RecordError(err)
+		return 0, err
+	}
+
+	return amount, nil
+}
+
+// ReactivateByID moves a dormant account back to ACTIVE.
+func (s service) ReactivateByID(ctx context.Context, id uuid.UUID, change StatusChange) (Account, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if strings.TrimSpace(change.Reason) == "" {
+		span.RecordError(ErrStatusReasonRequired)
+		return Account{}, ErrStatusReasonRequired
+	}
+
+	account, err := s.GetByID(ctx, id)
+	if err != nil {
+		zapctx.L(ctx).Error(
+			"account_service_reactivate_get_error",
+			zap.String("id", id.String()),
+			zap.Error(err),
+		)
+		span.RecordError(err)
+		return Account{}, err
+	}
+
+	if account.Status != DormantStatus {
+		span.RecordError(ErrAccountNotDormant)
+		return Account{}, ErrAccountNotDormant
+	}
+
+	event := newStatusEventModel(id, account.Status, ActiveStatus, change.Reason, change.Actor)
+	account.Status = ActiveStatus
 
-	model, err := s.repository.Update(ctx, newAccountModel(account))
+	model, err := s.repository.UpdateStatus(ctx, newAccountModel(account), event)
 	if err != nil {
-		zapctx.L(ctx).Error("account_service_close_update_repository_error", zap.Error(err))
+		zapctx.L(ctx).Error("account_service_reactivate_update_repository_error", zap.Error(err))
 		span.RecordError(err)
 		return Account{}, err
 	}
@@ -188,6 +783,110 @@ func (s service) CloseByID(ctx context.Context, id uuid.UUID) (Account, error) {
 	return account, nil
 }
 
+// MarkDormant moves every active account without movement for the dormancy period to DORMANT,
+// returning how many accounts were changed.
+func (s service) MarkDormant(ctx context.Context) (int, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if s.dormancyPeriod <= 0 {
+		return 0, nil
+	}
+
+	lastMovementBefore := time.Now().UTC().Add(-s.dormancyPeriod)
+	reason := fmt.Sprintf("no movement for %d days", int(s.dormancyPeriod.Hours()/24))
+
+	var marked int
+	for {
+		_, models, err := s.repository.ListByLastMovement(ctx, lastMovementFilter{
+			Before: lastMovementBefore,
+			Size:   dormancyBatchSize,
+		})
+		if err != nil {
+			zapctx.L(ctx).Error("account_service_dormancy_list_repository_error", zap.Error(err))
+			span.RecordError(err)
+			return marked, err
+		}
+
+		var batchMarked int
+		for _, model := range models {
+			event := newStatusEventModel(model.ID, ActiveStatus, DormantStatus, reason, "")
+
+			ok, err := s.repository.MarkDormant(ctx, model.accountModel, event, lastMovementBefore)
+			if err != nil {
+				zapctx.L(ctx).Error(
+					"account_service_dormancy_mark_repository_error",
+					zap.String("id", model.ID.String()),
+					zap.Error(err),
+				)
+				span.RecordError(err)
+				return marked, err
+			}
+
+			if ok {
+				batchMarked++
+			}
+		}
+		marked += batchMarked
+
+		// a batch without changes means the remaining accounts are only being listed again
+		// because the replica did not catch up yet, the next run picks them up.
+		if len(models) < dormancyBatchSize || batchMarked == 0 {
+			break
+		}
+	}
+
+	zapctx.L(ctx).Info(
+		"account_service_dormancy_marked",
+		zap.Int("accounts", marked),
+		zap.Time("last_movement_before", lastMovementBefore),
+	)
+
+	return marked, nil
+}
+
+// ListDormancyCandidates lists the active accounts that become dormant within the filter window,
+// including the ones already past the inactivity period waiting for the next MarkDormant run.
+func (s service) ListDormancyCandidates(
+	ctx context.Context,
+	filter DormancyFilter,
+) (int, []DormancyCandidate, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if s.dormancyPeriod <= 0 {
+		span.RecordError(ErrDormancyDisabled)
+		return 0, nil, ErrDormancyDisabled
+	}
+
+	if filter.Within <= 0 || filter.Within > s.dormancyPeriod {
+		span.RecordError(ErrInvalidDormancyWindow)
+		return 0, nil, ErrInvalidDormancyWindow
+	}
+
+	total, models, err := s.repository.ListByLastMovement(ctx, lastMovementFilter{
+		Before: time.Now().UTC().Add(filter.Within - s.dormancyPeriod),
+		Page:   filter.Page,
+		Size:   filter.Size,
+	})
+	if err != nil {
+		zapctx.L(ctx).Error("account_service_dormancy_candidates_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return 0, nil, err
+	}
+
+	candidates := make([]DormancyCandidate, len(models))
+	for i, model := range models {
+		candidates[i] = DormancyCandidate{
+			Account:        newAccount(model.accountModel),
+			LastMovementAt: model.LastMovementAt,
+			DormantAt:      model.LastMovementAt.Add(s.dormancyPeriod),
+		}
+	}
+
+	return total, candidates, nil
+}
+
 func (s service) GetByID(ctx context.Context, id uuid.UUID) (Account, error) {
 	ctx, span := s.tracer.Span(ctx)
 	defer span.End()
@@ -214,10 +913,98 @@ func (s service) GetByID(ctx context.Context, id uuid.UUID) (Account, error) {
 	return newAccount(models[0]), nil
 }
 
+func (s service) ListHolders(ctx context.Context, id uuid.UUID) ([]AccountHolder, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if _, err := s.GetByID(ctx, id); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	models, err := s.repository.ListHolders(ctx, id)
+	if err != nil {
+		zapctx.L(ctx).Error("account_service_list_holders_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	accountHolders := make([]AccountHolder, len(models))
+	for i, model := range models {
+		accountHolders[i] = newAccountHolder(model)
+	}
+
+	return accountHolders, nil
+}
+
+// GetHolderRole returns the role of the holder with the document number in the account.
+func (s service) GetHolderRole(ctx context.Context, id uuid.UUID, documentNumber string) (HolderRole, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	accountHolders, err := s.ListHolders(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return "", err
+	}
+
+	documentNumber = document.Normalize(documentNumber)
+	for _, holder := range accountHolders {
+		if holder.DocumentNumber == documentNumber {
+			return holder.Role, nil
+		}
+	}
+
+	span.RecordError(ErrAccountHolderNotLinked)
+	return "", ErrAccountHolderNotLinked
+}
+
+func (s service) ListStatusHistory(ctx context.Context, id uuid.UUID) ([]StatusEvent, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if _, err := s.GetByID(ctx, id); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	models, err := s.repository.ListStatusEventsByAccountID(ctx, id)
+	if err != nil {
+		zapctx.L(ctx).Error(
+			"account_service_list_status_history_repository_error",
+			zap.String("id", id.String()),
+			zap.Error(err),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+
+	events := make([]StatusEvent, len(models))
+	for i, model := range models {
+		events[i] = newStatusEvent(model)
+	}
+
+	return events, nil
+}
+
 func (s service) List(ctx context.Context, filter ListFilter) (int, []Account, error) {
 	ctx, span := s.tracer.Span(ctx)
 	defer span.End()
 
+	if filter.DocumentNumber != "" {
+		filter.DocumentNumber = document.Normalize(filter.DocumentNumber)
+	}
+
+	if filter.Number != "" && !ValidNumber(filter.Number) && !legacyNumber(filter.Number) {
+		zapctx.L(ctx).Error(
+			"account_service_list_invalid_number_error",
+			zap.String("number", filter.Number),
+			zap.Error(ErrInvalidAccountNumber),
+		)
+		span.RecordError(ErrInvalidAccountNumber)
+		return 0, []Account{}, ErrInvalidAccountNumber
+	}
+
 	total, models, err := s.repository.ListByFilter(ctx, filter)
 	if err != nil {
 		zapctx.L(ctx).Error(
@@ -36,33 +36,47 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 }
 
 // BlockByID mocks base method.
-func (m *MockService) BlockByID(ctx context.Context, id uuid.UUID) (Account, error) {
+func (m *MockService) BlockByID(ctx context.Context, id uuid.UUID, change StatusChange) (Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BlockByID", ctx, id)
+	ret := m.ctrl.Call(m, "BlockByID", ctx, id, change)
 	ret0, _ := ret[0].(Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // BlockByID indicates an expected call of BlockByID.
-func (mr *MockServiceMockRecorder) BlockByID(ctx, id interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) BlockByID(ctx, id, change interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockByID", reflect.TypeOf((*MockService)(nil).BlockByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockByID", reflect.TypeOf((*MockService)(nil).BlockByID), ctx, id, change)
+}
+
+// CheckClosable mocks base method.
+func (m *MockService) CheckClosable(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckClosable", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckClosable indicates an expected call of CheckClosable.
+func (mr *MockServiceMockRecorder) CheckClosable(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckClosable", reflect.TypeOf((*MockService)(nil).CheckClosable), ctx, id)
 }
 
 // CloseByID mocks base method.
-func (m *MockService) CloseByID(ctx context.Context, id uuid.UUID) (Account, error) {
+func (m *MockService) CloseByID(ctx context.Context, id uuid.UUID, closure Closure) (Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CloseByID", ctx, id)
+	ret := m.ctrl.Call(m, "CloseByID", ctx, id, closure)
 	ret0, _ := ret[0].(Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CloseByID indicates an expected call of CloseByID.
-func (mr *MockServiceMockRecorder) CloseByID(ctx, id interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) CloseByID(ctx, id, closure interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseByID", reflect.TypeOf((*MockService)(nil).CloseByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseByID", reflect.TypeOf((*MockService)(nil).CloseByID), ctx, id, closure)
 }
 
 // Create mocks base method.
@@ -80,6 +94,36 @@ func (mr *MockServiceMockRecorder) Create(ctx, account interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), ctx, account)
 }
 
+// CreateLegalHold mocks base method.
+func (m *MockService) CreateLegalHold(ctx context.Context, hold LegalHold) (LegalHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLegalHold", ctx, hold)
+	ret0, _ := ret[0].(LegalHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLegalHold indicates an expected call of CreateLegalHold.
+func (mr *MockServiceMockRecorder) CreateLegalHold(ctx, hold interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLegalHold", reflect.TypeOf((*MockService)(nil).CreateLegalHold), ctx, hold)
+}
+
+// CreatePocket mocks base method.
+func (m *MockService) CreatePocket(ctx context.Context, parentID uuid.UUID, name string) (Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePocket", ctx, parentID, name)
+	ret0, _ := ret[0].(Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePocket indicates an expected call of CreatePocket.
+func (mr *MockServiceMockRecorder) CreatePocket(ctx, parentID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePocket", reflect.TypeOf((*MockService)(nil).CreatePocket), ctx, parentID, name)
+}
+
 // GetByID mocks base method.
 func (m *MockService) GetByID(ctx context.Context, id uuid.UUID) (Account, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +139,36 @@ func (mr *MockServiceMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockService)(nil).GetByID), ctx, id)
 }
 
+// GetHeldAmount mocks base method.
+func (m *MockService) GetHeldAmount(ctx context.Context, accountID uuid.UUID) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeldAmount", ctx, accountID)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeldAmount indicates an expected call of GetHeldAmount.
+func (mr *MockServiceMockRecorder) GetHeldAmount(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeldAmount", reflect.TypeOf((*MockService)(nil).GetHeldAmount), ctx, accountID)
+}
+
+// GetHolderRole mocks base method.
+func (m *MockService) GetHolderRole(ctx context.Context, id uuid.UUID, documentNumber string) (HolderRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHolderRole", ctx, id, documentNumber)
+	ret0, _ := ret[0].(HolderRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHolderRole indicates an expected call of GetHolderRole.
+func (mr *MockServiceMockRecorder) GetHolderRole(ctx, id, documentNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHolderRole", reflect.TypeOf((*MockService)(nil).GetHolderRole), ctx, id, documentNumber)
+}
+
 // List mocks base method.
 func (m *MockService) List(ctx context.Context, filter ListFilter) (int, []Account, error) {
 	m.ctrl.T.Helper()
@@ -111,17 +185,153 @@ func (mr *MockServiceMockRecorder) List(ctx, filter interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), ctx, filter)
 }
 
+// ListDormancyCandidates mocks base method.
+func (m *MockService) ListDormancyCandidates(ctx context.Context, filter DormancyFilter) (int, []DormancyCandidate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDormancyCandidates", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]DormancyCandidate)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDormancyCandidates indicates an expected call of ListDormancyCandidates.
+func (mr *MockServiceMockRecorder) ListDormancyCandidates(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDormancyCandidates", reflect.TypeOf((*MockService)(nil).ListDormancyCandidates), ctx, filter)
+}
+
+// ListHolders mocks base method.
+func (m *MockService) ListHolders(ctx context.Context, id uuid.UUID) ([]AccountHolder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHolders", ctx, id)
+	ret0, _ := ret[0].([]AccountHolder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHolders indicates an expected call of ListHolders.
+func (mr *MockServiceMockRecorder) ListHolders(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHolders", reflect.TypeOf((*MockService)(nil).ListHolders), ctx, id)
+}
+
+// ListLegalHolds mocks base method.
+func (m *MockService) ListLegalHolds(ctx context.Context, accountID uuid.UUID) ([]LegalHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLegalHolds", ctx, accountID)
+	ret0, _ := ret[0].([]LegalHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLegalHolds indicates an expected call of ListLegalHolds.
+func (mr *MockServiceMockRecorder) ListLegalHolds(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLegalHolds", reflect.TypeOf((*MockService)(nil).ListLegalHolds), ctx, accountID)
+}
+
+// ListPockets mocks base method.
+func (m *MockService) ListPockets(ctx context.Context, parentID uuid.UUID) ([]Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPockets", ctx, parentID)
+	ret0, _ := ret[0].([]Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPockets indicates an expected call of ListPockets.
+func (mr *MockServiceMockRecorder) ListPockets(ctx, parentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPockets", reflect.TypeOf((*MockService)(nil).ListPockets), ctx, parentID)
+}
+
+// ListStatusHistory mocks base method.
+func (m *MockService) ListStatusHistory(ctx context.Context, id uuid.UUID) ([]StatusEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStatusHistory", ctx, id)
+	ret0, _ := ret[0].([]StatusEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStatusHistory indicates an expected call of ListStatusHistory.
+func (mr *MockServiceMockRecorder) ListStatusHistory(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStatusHistory", reflect.TypeOf((*MockService)(nil).ListStatusHistory), ctx, id)
+}
+
+// MarkDormant mocks base method.
+func (m *MockService) MarkDormant(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDormant", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkDormant indicates an expected call of MarkDormant.
+func (mr *MockServiceMockRecorder) MarkDormant(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDormant", reflect.TypeOf((*MockService)(nil).MarkDormant), ctx)
+}
+
+// ReactivateByID mocks base method.
+func (m *MockService) ReactivateByID(ctx context.Context, id uuid.UUID, change StatusChange) (Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReactivateByID", ctx, id, change)
+	ret0, _ := ret[0].(Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReactivateByID indicates an expected call of ReactivateByID.
+func (mr *MockServiceMockRecorder) ReactivateByID(ctx, id, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactivateByID", reflect.TypeOf((*MockService)(nil).ReactivateByID), ctx, id, change)
+}
+
+// ReleaseLegalHold mocks base method.
+func (m *MockService) ReleaseLegalHold(ctx context.Context, accountID, holdID uuid.UUID, change StatusChange) (LegalHold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseLegalHold", ctx, accountID, holdID, change)
+	ret0, _ := ret[0].(LegalHold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseLegalHold indicates an expected call of ReleaseLegalHold.
+func (mr *MockServiceMockRecorder) ReleaseLegalHold(ctx, accountID, holdID, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseLegalHold", reflect.TypeOf((*MockService)(nil).ReleaseLegalHold), ctx, accountID, holdID, change)
+}
+
+// SetRestrictions mocks base method.
+func (m *MockService) SetRestrictions(ctx context.Context, id uuid.UUID, restrictions []Restriction, change StatusChange) (Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRestrictions", ctx, id, restrictions, change)
+	ret0, _ := ret[0].(Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRestrictions indicates an expected call of SetRestrictions.
+func (mr *MockServiceMockRecorder) SetRestrictions(ctx, id, restrictions, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRestrictions", reflect.TypeOf((*MockService)(nil).SetRestrictions), ctx, id, restrictions, change)
+}
+
 // UnblockByID mocks base method.
-func (m *MockService) UnblockByID(ctx context.Context, id uuid.UUID) (Account, error) {
+func (m *MockService) UnblockByID(ctx context.Context, id uuid.UUID, change StatusChange) (Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UnblockByID", ctx, id)
+	ret := m.ctrl.Call(m, "UnblockByID", ctx, id, change)
 	ret0, _ := ret[0].(Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UnblockByID indicates an expected call of UnblockByID.
-func (mr *MockServiceMockRecorder) UnblockByID(ctx, id interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) UnblockByID(ctx, id, change interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnblockByID", reflect.TypeOf((*MockService)(nil).UnblockByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnblockByID", reflect.TypeOf((*MockService)(nil).UnblockByID), ctx, id, change)
 }
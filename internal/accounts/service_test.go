@@ -6,9 +6,13 @@ import (
 	"context"
 	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/brianvoe/gofakeit/v6"
+	"github.com/dalmarcogd/dock-test/internal/exchange"
 	"github.com/dalmarcogd/dock-test/internal/holders"
+	"github.com/dalmarcogd/dock-test/internal/products"
+	"github.com/dalmarcogd/dock-test/pkg/gomockeq"
 	"github.com/dalmarcogd/dock-test/pkg/tracer"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -23,7 +27,7 @@ func TestService_Create(t *testing.T) {
 	repoMock := NewMockRepository(ctrl)
 	holderRepoMock := holders.NewMockRepository(ctrl)
 
-	svc := NewService(tracer.NewNoop(), repoMock, holderRepoMock)
+	svc := NewService(tracer.NewNoop(), repoMock, holderRepoMock, false, 0)
 
 	t.Run("fail create, holder not found", func(t *testing.T) {
 		account := Account{
@@ -58,7 +62,7 @@ func TestService_Create(t *testing.T) {
 				nil,
 			)
 		repoMock.EXPECT().
-			Create(ctx, gomock.Any()).
+			Create(ctx, gomock.Any(), gomock.Any()).
 			Return(
 				accountModel{
 					ID:       uuid.New(),
@@ -76,6 +80,229 @@ func TestService_Create(t *testing.T) {
 	})
 }
 
+func TestService_CreateType(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	holderRepoMock := holders.NewMockRepository(ctrl)
+
+	svc := NewService(tracer.NewNoop(), repoMock, holderRepoMock, false, 0)
+
+	t.Run("fail create, invalid type", func(t *testing.T) {
+		created, err := svc.Create(ctx, Account{
+			Name:           gofakeit.Name(),
+			DocumentNumber: gofakeit.SSN(),
+			Type:           "INVESTMENT",
+		})
+		assert.ErrorIs(t, err, ErrInvalidAccountType)
+		assert.Empty(t, created)
+	})
+
+	t.Run("fail create, invalid currency", func(t *testing.T) {
+		created, err := svc.Create(ctx, Account{
+			Name:           gofakeit.Name(),
+			DocumentNumber: gofakeit.SSN(),
+			Currency:       "XYZ",
+		})
+		assert.ErrorIs(t, err, ErrInvalidAccountCurrency)
+		assert.Empty(t, created)
+	})
+
+	t.Run("fail create, business account for individual holder", func(t *testing.T) {
+		account := Account{
+			Name:           gofakeit.Name(),
+			DocumentNumber: gofakeit.SSN(),
+			Type:           products.BusinessType,
+		}
+
+		holderRepoMock.EXPECT().
+			GetByFilter(ctx, holders.HolderFilter{DocumentNumber: account.DocumentNumber}).
+			Return([]holders.HolderModel{{ID: uuid.New(), Type: holders.IndividualType}}, nil)
+
+		created, err := svc.Create(ctx, account)
+		assert.ErrorIs(t, err, ErrAccountTypeNotAllowed)
+		assert.Empty(t, created)
+	})
+
+	t.Run("success create, default checking type", func(t *testing.T) {
+		account := Account{
+			Name:           gofakeit.Name(),
+			DocumentNumber: gofakeit.SSN(),
+		}
+
+		holderRepoMock.EXPECT().
+			GetByFilter(ctx, holders.HolderFilter{DocumentNumber: account.DocumentNumber}).
+			Return([]holders.HolderModel{{ID: uuid.New(), Type: holders.IndividualType}}, nil)
+		repoMock.EXPECT().
+			Create(ctx, gomockeq.Eq(
+				accountModel{
+					Type:     products.CheckingType,
+					Currency: exchange.DefaultCurrency,
+					Status:   ActiveStatus,
+					Agency:   AccountAgency,
+				},
+				gomockeq.IgnoreFields("Name", "Number", "HolderID"),
+			), gomock.Any()).
+			Return(accountModel{ID: uuid.New(), Type: products.CheckingType, Status: ActiveStatus}, nil)
+
+		created, err := svc.Create(ctx, account)
+		assert.NoError(t, err)
+		assert.Equal(t, products.CheckingType, created.Type)
+	})
+}
+
+func TestService_CreateNumberCollision(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	holderRepoMock := holders.NewMockRepository(ctrl)
+
+	svc := NewService(tracer.NewNoop(), repoMock, holderRepoMock, false, 0)
+
+	t.Run("success create, retry after number collision", func(t *testing.T) {
+		account := Account{
+			Name:           gofakeit.Name(),
+			DocumentNumber: gofakeit.SSN(),
+		}
+
+		holderRepoMock.EXPECT().
+			GetByFilter(ctx, holders.HolderFilter{DocumentNumber: account.DocumentNumber}).
+			Return([]holders.HolderModel{{ID: uuid.New(), DocumentNumber: account.DocumentNumber}}, nil)
+
+		id := uuid.New()
+		gomock.InOrder(
+			repoMock.EXPECT().
+				Create(ctx, gomock.Any(), gomock.Any()).
+				Return(accountModel{}, errDuplicatedAccountNumber),
+			repoMock.EXPECT().
+				Create(ctx, gomock.Any(), gomock.Any()).
+				Return(accountModel{ID: id, Status: ActiveStatus}, nil),
+		)
+
+		created, err := svc.Create(ctx, account)
+		assert.NoError(t, err)
+		assert.Equal(t, id, created.ID)
+		assert.Equal(t, AccountAgency, created.Agency)
+		assert.True(t, ValidNumber(created.Number))
+	})
+
+	t.Run("fail create, number unavailable", func(t *testing.T) {
+		account := Account{
+			Name:           gofakeit.Name(),
+			DocumentNumber: gofakeit.SSN(),
+		}
+
+		holderRepoMock.EXPECT().
+			GetByFilter(ctx, holders.HolderFilter{DocumentNumber: account.DocumentNumber}).
+			Return([]holders.HolderModel{{ID: uuid.New(), DocumentNumber: account.DocumentNumber}}, nil)
+
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any(), gomock.Any()).
+			Return(accountModel{}, errDuplicatedAccountNumber).
+			Times(maxNumberGenerationAttempts)
+
+		created, err := svc.Create(ctx, account)
+		assert.ErrorIs(t, err, ErrAccountNumberUnavailable)
+		assert.Empty(t, created)
+	})
+}
+
+func TestService_List(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	holderRepoMock := holders.NewMockRepository(ctrl)
+
+	svc := NewService(tracer.NewNoop(), repoMock, holderRepoMock, false, 0)
+
+	t.Run("fail list, invalid number check digit", func(t *testing.T) {
+		total, accs, err := svc.List(ctx, ListFilter{Agency: AccountAgency, Number: "654321-8"})
+		assert.ErrorIs(t, err, ErrInvalidAccountNumber)
+		assert.Zero(t, total)
+		assert.Empty(t, accs)
+	})
+
+	t.Run("success list by agency and number", func(t *testing.T) {
+		filter := ListFilter{Agency: AccountAgency, Number: "654321-9"}
+		repoMock.EXPECT().
+			ListByFilter(ctx, filter).
+			Return(1, []accountModel{{ID: uuid.New(), Agency: AccountAgency, Number: "654321-9"}}, nil)
+
+		total, accs, err := svc.List(ctx, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, accs, 1)
+	})
+}
+
+func TestService_CreateRequireApprovedKYC(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	holderRepoMock := holders.NewMockRepository(ctrl)
+
+	svc := NewService(tracer.NewNoop(), repoMock, holderRepoMock, true, 0)
+
+	t.Run("fail create, holder kyc not approved", func(t *testing.T) {
+		account := Account{
+			Name:           gofakeit.Name(),
+			DocumentNumber: gofakeit.SSN(),
+		}
+
+		holderRepoMock.EXPECT().
+			GetByFilter(ctx, holders.HolderFilter{DocumentNumber: account.DocumentNumber}).
+			Return(
+				[]holders.HolderModel{
+					{
+						ID:             uuid.New(),
+						DocumentNumber: account.DocumentNumber,
+						KYCStatus:      holders.PendingKYCStatus,
+					},
+				},
+				nil,
+			)
+
+		created, err := svc.Create(ctx, account)
+		assert.ErrorIs(t, err, ErrAccountHolderKYCNotApproved)
+		assert.Empty(t, created)
+	})
+
+	t.Run("success create, holder kyc approved", func(t *testing.T) {
+		account := Account{
+			Name:           gofakeit.Name(),
+			DocumentNumber: gofakeit.SSN(),
+		}
+
+		holderRepoMock.EXPECT().
+			GetByFilter(ctx, holders.HolderFilter{DocumentNumber: account.DocumentNumber}).
+			Return(
+				[]holders.HolderModel{
+					{
+						ID:             uuid.New(),
+						DocumentNumber: account.DocumentNumber,
+						KYCStatus:      holders.ApprovedKYCStatus,
+					},
+				},
+				nil,
+			)
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any(), gomock.Any()).
+			Return(accountModel{ID: uuid.New(), Status: ActiveStatus}, nil)
+
+		created, err := svc.Create(ctx, account)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, created)
+	})
+}
+
 func TestService_BlockByID(t *testing.T) {
 	ctx := context.Background()
 	ctrl := gomock.NewController(t)
@@ -83,16 +310,23 @@ func TestService_BlockByID(t *testing.T) {
 
 	repoMock := NewMockRepository(ctrl)
 
-	svc := NewService(tracer.NewNoop(), repoMock, holders.NewMockRepository(ctrl))
+	svc := NewService(tracer.NewNoop(), repoMock, holders.NewMockRepository(ctrl), false, 0)
 
 	accountID := uuid.New()
+	change := StatusChange{Reason: "suspicious activity", Actor: "backoffice"}
+
+	t.Run("fail block, reason required", func(t *testing.T) {
+		acc, err := svc.BlockByID(ctx, accountID, StatusChange{})
+		assert.ErrorIs(t, err, ErrStatusReasonRequired)
+		assert.Empty(t, acc)
+	})
 
 	t.Run("fail block, not found", func(t *testing.T) {
 		repoMock.EXPECT().
 			GetByFilter(ctx, accountFilter{ID: uuid.NullUUID{UUID: accountID, Valid: true}}).
 			Return([]accountModel{}, sql.ErrNoRows)
 
-		acc, err := svc.BlockByID(ctx, accountID)
+		acc, err := svc.BlockByID(ctx, accountID, change)
 		assert.EqualError(t, err, "sql: no rows in result set")
 		assert.Empty(t, acc)
 	})
@@ -104,7 +338,7 @@ func TestService_BlockByID(t *testing.T) {
 				{Status: ClosedStatus},
 			}, nil)
 
-		acc, err := svc.BlockByID(ctx, accountID)
+		acc, err := svc.BlockByID(ctx, accountID, change)
 		assert.EqualError(t, err, "account must be active for this operation")
 		assert.Empty(t, acc)
 	})
@@ -117,10 +351,14 @@ func TestService_BlockByID(t *testing.T) {
 			}, nil)
 
 		repoMock.EXPECT().
-			Update(ctx, accountModel{Status: BlockedStatus}).
+			UpdateStatus(
+				ctx,
+				accountModel{Status: BlockedStatus},
+				newStatusEventModel(accountID, ActiveStatus, BlockedStatus, change.Reason, change.Actor),
+			).
 			Return(accountModel{Status: BlockedStatus}, nil)
 
-		acc, err := svc.BlockByID(ctx, accountID)
+		acc, err := svc.BlockByID(ctx, accountID, change)
 		assert.NoError(t, err)
 		assert.Equal(t, BlockedStatus, acc.Status)
 	})
@@ -133,16 +371,23 @@ func TestService_UnblockByID(t *testing.T) {
 
 	repoMock := NewMockRepository(ctrl)
 
-	svc := NewService(tracer.NewNoop(), repoMock, holders.NewMockRepository(ctrl))
+	svc := NewService(tracer.NewNoop(), repoMock, holders.NewMockRepository(ctrl), false, 0)
 
 	accountID := uuid.New()
+	change := StatusChange{Reason: "activity verified"}
+
+	t.Run("fail unblock, reason required", func(t *testing.T) {
+		acc, err := svc.UnblockByID(ctx, accountID, StatusChange{})
+		assert.ErrorIs(t, err, ErrStatusReasonRequired)
+		assert.Empty(t, acc)
+	})
 
 	t.Run("fail unblock, not found", func(t *testing.T) {
 		repoMock.EXPECT().
 			GetByFilter(ctx, accountFilter{ID: uuid.NullUUID{UUID: accountID, Valid: true}}).
 			Return([]accountModel{}, sql.ErrNoRows)
 
-		acc, err := svc.UnblockByID(ctx, accountID)
+		acc, err := svc.UnblockByID(ctx, accountID, change)
 		assert.EqualError(t, err, "sql: no rows in result set")
 		assert.Empty(t, acc)
 	})
@@ -154,7 +399,7 @@ func TestService_UnblockByID(t *testing.T) {
 				{Status: ActiveStatus},
 			}, nil)
 
-		acc, err := svc.UnblockByID(ctx, accountID)
+		acc, err := svc.UnblockByID(ctx, accountID, change)
 		assert.EqualError(t, err, "account must be blocked for this operation")
 		assert.Empty(t, acc)
 	})
@@ -167,10 +412,14 @@ func TestService_UnblockByID(t *testing.T) {
 			}, nil)
 
 		repoMock.EXPECT().
-			Update(ctx, accountModel{Status: ActiveStatus}).
+			UpdateStatus(
+				ctx,
+				accountModel{Status: ActiveStatus},
+				newStatusEventModel(accountID, BlockedStatus, ActiveStatus, change.Reason, change.Actor),
+			).
 			Return(accountModel{Status: ActiveStatus}, nil)
 
-		acc, err := svc.UnblockByID(ctx, accountID)
+		acc, err := svc.UnblockByID(ctx, accountID, change)
 		assert.NoError(t, err)
 		assert.Equal(t, ActiveStatus, acc.Status)
 	})
@@ -183,20 +432,53 @@ func TestService_CloseByID(t *testing.T) {
 
 	repoMock := NewMockRepository(ctrl)
 
-	svc := NewService(tracer.NewNoop(), repoMock, holders.NewMockRepository(ctrl))
+	svc := NewService(tracer.NewNoop(), repoMock, holders.NewMockRepository(ctrl), false, 0)
 
 	accountID := uuid.New()
+	closure := Closure{Reason: "requested by the holder"}
+	closeEvent := newStatusEventModel(accountID, ActiveStatus, ClosedStatus, closure.Reason, "")
+
+	t.Run("fail close, reason required", func(t *testing.T) {
+		acc, err := svc.CloseByID(ctx, accountID, Closure{Reason: " "})
+		assert.ErrorIs(t, err, ErrStatusReasonRequired)
+		assert.Empty(t, acc)
+	})
 
 	t.Run("fail close, not found", func(t *testing.T) {
 		repoMock.EXPECT().
 			GetByFilter(ctx, accountFilter{ID: uuid.NullUUID{UUID: accountID, Valid: true}}).
 			Return([]accountModel{}, sql.ErrNoRows)
 
-		acc, err := svc.CloseByID(ctx, accountID)
+		acc, err := svc.CloseByID(ctx, accountID, closure)
 		assert.EqualError(t, err, "sql: no rows in result set")
 		assert.Empty(t, acc)
 	})
 
+	for _, tt := range []struct {
+		name    string
+		repoErr error
+		wantErr error
+	}{
+		{"fail close, balance not zero", errAccountBalanceNotZero, ErrAccountBalanceNotZero},
+		{"fail close, active legal holds", errAccountHasActiveHolds, ErrAccountHasActiveHolds},
+		{"fail close, open pockets", errAccountHasOpenPockets, ErrAccountHasOpenPockets},
+		{"fail close, pending transfers", errAccountHasPendingTransfers, ErrAccountHasPendingTransfers},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMock.EXPECT().
+				GetByFilter(ctx, accountFilter{ID: uuid.NullUUID{UUID: accountID, Valid: true}}).
+				Return([]accountModel{{ID: accountID, Status: ActiveStatus}}, nil)
+
+			repoMock.EXPECT().
+				Close(ctx, accountModel{ID: accountID, Status: ActiveStatus, ClosureReason: closure.Reason}, closeEvent).
+				Return(accountModel{}, tt.repoErr)
+
+			acc, err := svc.CloseByID(ctx, accountID, closure)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Empty(t, acc)
+		})
+	}
+
 	t.Run("success close", func(t *testing.T) {
 		repoMock.EXPECT().
 			GetByFilter(ctx, accountFilter{ID: uuid.NullUUID{UUID: accountID, Valid: true}}).
@@ -205,10 +487,10 @@ func TestService_CloseByID(t *testing.T) {
 			}, nil)
 
 		repoMock.EXPECT().
-			Update(ctx, accountModel{Status: ClosedStatus}).
+			Close(ctx, accountModel{Status: ActiveStatus, ClosureReason: closure.Reason}, closeEvent).
 			Return(accountModel{Status: ClosedStatus}, nil)
 
-		acc, err := svc.CloseByID(ctx, accountID)
+		acc, err := svc.CloseByID(ctx, accountID, closure)
 		assert.NoError(t, err)
 		assert.Equal(t, ClosedStatus, acc.Status)
 
@@ -218,8 +500,566 @@ func TestService_CloseByID(t *testing.T) {
 				{Status: ClosedStatus},
 			}, nil)
 
-		acc, err = svc.CloseByID(ctx, accountID)
+		acc, err = svc.CloseByID(ctx, accountID, closure)
 		assert.NoError(t, err)
 		assert.Equal(t, ClosedStatus, acc.Status)
 	})
 }
+
+func TestService_CheckClosable(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+
+	svc := NewService(tracer.NewNoop(), repoMock, holders.NewMockRepository(ctrl), false, 0)
+
+	accountID := uuid.New()
+
+	t.Run("fail check, pending transfers", func(t *testing.T) {
+		repoMock.EXPECT().CheckClosable(ctx, accountID).Return(errAccountHasPendingTransfers)
+
+		assert.ErrorIs(t, svc.CheckClosable(ctx, accountID), ErrAccountHasPendingTransfers)
+	})
+
+	t.Run("success check", func(t *testing.T) {
+		repoMock.EXPECT().CheckClosable(ctx, accountID).Return(nil)
+
+		assert.NoError(t, svc.CheckClosable(ctx, accountID))
+	})
+}
+
+func TestService_ListStatusHistory(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+
+	svc := NewService(tracer.NewNoop(), repoMock, holders.NewMockRepository(ctrl), false, 0)
+
+	accountID := uuid.New()
+
+	t.Run("fail list, account not found", func(t *testing.T) {
+		repoMock.EXPECT().
+			GetByFilter(ctx, accountFilter{ID: uuid.NullUUID{UUID: accountID, Valid: true}}).
+			Return([]accountModel{}, nil)
+
+		events, err := svc.ListStatusHistory(ctx, accountID)
+		assert.ErrorIs(t, err, ErrAccountNotFound)
+		assert.Empty(t, events)
+	})
+
+	t.Run("success list", func(t *testing.T) {
+		repoMock.EXPECT().
+			GetByFilter(ctx, accountFilter{ID: uuid.NullUUID{UUID: accountID, Valid: true}}).
+			Return([]accountModel{{ID: accountID, Status: ActiveStatus}}, nil)
+		repoMock.EXPECT().
+			ListStatusEventsByAccountID(ctx, accountID).
+			Return([]statusEventModel{
+				newStatusEventModel(accountID, ActiveStatus, BlockedStatus, "suspicious activity", "backoffice"),
+				newStatusEventModel(accountID, BlockedStatus, ActiveStatus, "activity verified", "backoffice"),
+			}, nil)
+
+		events, err := svc.ListStatusHistory(ctx, accountID)
+		assert.NoError(t, err)
+		assert.Len(t, events, 2)
+		assert.Equal(t, BlockedStatus, events[0].ToStatus)
+		assert.Equal(t, ActiveStatus, events[1].ToStatus)
+	})
+}
+
+func TestService_SetRestrictions(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+
+	svc := NewService(tracer.NewNoop(), repoMock, holders.NewMockRepository(ctrl), false, 0)
+
+	accountID := uuid.New()
+	change := StatusChange{Reason: "court order 123", Actor: "backoffice"}
+
+	t.Run("fail restrictions, reason required", func(t *testing.T) {
+		acc, err := svc.SetRestrictions(ctx, accountID, []Restriction{DebitsRestriction}, StatusChange{})
+		assert.ErrorIs(t, err, ErrStatusReasonRequired)
+		assert.Empty(t, acc)
+	})
+
+	t.Run("fail restrictions, invalid restriction", func(t *testing.T) {
+		acc, err := svc.SetRestrictions(ctx, accountID, []Restriction{"WITHDRAWALS"}, change)
+		assert.ErrorIs(t, err, ErrInvalidRestriction)
+		assert.Empty(t, acc)
+	})
+
+	t.Run("fail restrictions, account closed", func(t *testing.T) {
+		repoMock.EXPECT().
+			GetByFilter(ctx, accountFilter{ID: uuid.NullUUID{UUID: accountID, Valid: true}}).
+			Return([]accountModel{{ID: accountID, Status: ClosedStatus}}, nil)
+
+		acc, err := svc.SetRestrictions(ctx, accountID, []Restriction{DebitsRestriction}, change)
+		assert.ErrorIs(t, err, ErrAccountClosed)
+		assert.Empty(t, acc)
+	})
+
+	t.Run("success restrictions", func(t *testing.T) {
+		event := newStatusEventModel(accountID, ActiveStatus, ActiveStatus, change.Reason, change.Actor)
+		event.Restrictions = []string{"DEBITS", "P2P_OUT"}
+
+		repoMock.EXPECT().
+			GetByFilter(ctx, accountFilter{ID: uuid.NullUUID{UUID: accountID, Valid: true}}).
+			Return([]accountModel{{ID: accountID, Status: ActiveStatus}}, nil)
+		repoMock.EXPECT().
+			UpdateRestrictions(
+				ctx,
+				accountModel{ID: accountID, Status: ActiveStatus, Restrictions: []string{"DEBITS", "P2P_OUT"}},
+				event,
+			).
+			Return(accountModel{ID: accountID, Status: ActiveStatus, Restrictions: []string{"DEBITS", "P2P_OUT"}}, nil)
+
+		acc, err := svc.SetRestrictions(
+			ctx,
+			accountID,
+			[]Restriction{DebitsRestriction, P2POutRestriction, DebitsRestriction},
+			change,
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, ActiveStatus, acc.Status)
+		assert.True(t, acc.Restricted(DebitsRestriction))
+		assert.True(t, acc.Restricted(P2POutRestriction))
+		assert.False(t, acc.Restricted(CreditsRestriction))
+	})
+}
+
+func TestService_LegalHolds(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+
+	svc := NewService(tracer.NewNoop(), repoMock, holders.NewMockRepository(ctrl), false, 0)
+
+	accountID := uuid.New()
+	holdID := uuid.New()
+	hold := LegalHold{AccountID: accountID, Amount: 250, CourtOrder: "0001234-56.2024", Reason: "judicial seizure"}
+
+	t.Run("fail create, invalid amount", func(t *testing.T) {
+		created, err := svc.CreateLegalHold(ctx, LegalHold{AccountID: accountID, Reason: hold.Reason})
+		assert.ErrorIs(t, err, ErrInvalidLegalHoldAmount)
+		assert.Empty(t, created)
+	})
+
+	t.Run("fail create, account closed", func(t *testing.T) {
+		repoMock.EXPECT().
+			GetByFilter(ctx, accountFilter{ID: uuid.NullUUID{UUID: accountID, Valid: true}}).
+			Return([]accountModel{{ID: accountID, Status: ClosedStatus}}, nil)
+
+		created, err := svc.CreateLegalHold(ctx, hold)
+		assert.ErrorIs(t, err, ErrAccountClosed)
+		assert.Empty(t, created)
+	})
+
+	t.Run("success create", func(t *testing.T) {
+		repoMock.EXPECT().
+			GetByFilter(ctx, accountFilter{ID: uuid.NullUUID{UUID: accountID, Valid: true}}).
+			Return([]accountModel{{ID: accountID, Status: BlockedStatus}}, nil)
+		repoMock.EXPECT().
+			CreateLegalHold(ctx, newLegalHoldModel(hold)).
+			DoAndReturn(func(_ context.Context, model legalHoldModel) (legalHoldModel, error) {
+				model.ID = holdID
+				model.Status = ActiveLegalHoldStatus
+				return model, nil
+			})
+
+		created, err := svc.CreateLegalHold(ctx, hold)
+		assert.NoError(t, err)
+		assert.Equal(t, holdID, created.ID)
+		assert.Equal(t, ActiveLegalHoldStatus, created.Status)
+		assert.Equal(t, hold.Amount, created.Amount)
+	})
+
+	t.Run("fail release, hold not active", func(t *testing.T) {
+		repoMock.EXPECT().
+			ReleaseLegalHold(ctx, legalHoldModel{
+				ID:            holdID,
+				AccountID:     accountID,
+				ReleaseReason: "court order revoked",
+			}).
+			Return(legalHoldModel{}, errLegalHoldNotActive)
+
+		released, err := svc.ReleaseLegalHold(ctx, accountID, holdID, StatusChange{Reason: "court order revoked"})
+		assert.ErrorIs(t, err, ErrLegalHoldNotFound)
+		assert.Empty(t, released)
+	})
+
+	t.Run("success held amount", func(t *testing.T) {
+		repoMock.EXPECT().
+			SumActiveLegalHolds(ctx, accountID).
+			Return(float64(250), nil)
+
+		amount, err := svc.GetHeldAmount(ctx, accountID)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(250), amount)
+	})
+}
+
+func TestService_ReactivateByID(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+
+	svc := NewService(tracer.NewNoop(), repoMock, holders.NewMockRepository(ctrl), false, 0)
+
+	accountID := uuid.New()
+	change := StatusChange{Reason: "credit received"}
+
+	t.Run("fail reactivate, not dormant", func(t *testing.T) {
+		repoMock.EXPECT().
+			GetByFilter(ctx, accountFilter{ID: uuid.NullUUID{UUID: accountID, Valid: true}}).
+			Return([]accountModel{{ID: accountID, Status: ActiveStatus}}, nil)
+
+		acc, err := svc.ReactivateByID(ctx, accountID, change)
+		assert.ErrorIs(t, err, ErrAccountNotDormant)
+		assert.Empty(t, acc)
+	})
+
+	t.Run("success reactivate", func(t *testing.T) {
+		repoMock.EXPECT().
+			GetByFilter(ctx, accountFilter{ID: uuid.NullUUID{UUID: accountID, Valid: true}}).
+			Return([]accountModel{{ID: accountID, Status: DormantStatus}}, nil)
+		repoMock.EXPECT().
+			UpdateStatus(
+				ctx,
+				accountModel{ID: accountID, Status: ActiveStatus},
+				newStatusEventModel(accountID, DormantStatus, ActiveStatus, change.Reason, ""),
+			).
+			Return(accountModel{ID: accountID, Status: ActiveStatus}, nil)
+
+		acc, err := svc.ReactivateByID(ctx, accountID, change)
+		assert.NoError(t, err)
+		assert.Equal(t, ActiveStatus, acc.Status)
+	})
+}
+
+func TestService_MarkDormant(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+
+	t.Run("success mark, dormancy disabled", func(t *testing.T) {
+		svc := NewService(tracer.NewNoop(), repoMock, holders.NewMockRepository(ctrl), false, 0)
+
+		marked, err := svc.MarkDormant(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, marked)
+	})
+
+	t.Run("success mark", func(t *testing.T) {
+		svc := NewService(tracer.NewNoop(), repoMock, holders.NewMockRepository(ctrl), false, 30*24*time.Hour)
+
+		inactiveID := uuid.New()
+		movedID := uuid.New()
+
+		repoMock.EXPECT().
+			ListByLastMovement(ctx, gomockeq.Eq(lastMovementFilter{Size: dormancyBatchSize}, gomockeq.IgnoreFields("Before"))).
+			Return(2, []dormancyCandidateModel{
+				{accountModel: accountModel{ID: inactiveID, Status: ActiveStatus}},
+				{accountModel: accountModel{ID: movedID, Status: ActiveStatus}},
+			}, nil)
+		repoMock.EXPECT().
+			MarkDormant(
+				ctx,
+				accountModel{ID: inactiveID, Status: ActiveStatus},
+				newStatusEventModel(inactiveID, ActiveStatus, DormantStatus, "no movement for 30 days", ""),
+				gomock.Any(),
+			).
+			Return(true, nil)
+		repoMock.EXPECT().
+			MarkDormant(
+				ctx,
+				accountModel{ID: movedID, Status: ActiveStatus},
+				newStatusEventModel(movedID, ActiveStatus, DormantStatus, "no movement for 30 days", ""),
+				gomock.Any(),
+			).
+			Return(false, nil)
+
+		marked, err := svc.MarkDormant(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, marked)
+	})
+}
+
+func TestService_ListDormancyCandidates(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+
+	period := 30 * 24 * time.Hour
+	svc := NewService(tracer.NewNoop(), repoMock, holders.NewMockRepository(ctrl), false, period)
+
+	t.Run("fail list, dormancy disabled", func(t *testing.T) {
+		svc := NewService(tracer.NewNoop(), repoMock, holders.NewMockRepository(ctrl), false, 0)
+
+		total, candidates, err := svc.ListDormancyCandidates(ctx, DormancyFilter{Within: 24 * time.Hour})
+		assert.ErrorIs(t, err, ErrDormancyDisabled)
+		assert.Zero(t, total)
+		assert.Empty(t, candidates)
+	})
+
+	t.Run("fail list, window longer than the period", func(t *testing.T) {
+		total, candidates, err := svc.ListDormancyCandidates(ctx, DormancyFilter{Within: 2 * period})
+		assert.ErrorIs(t, err, ErrInvalidDormancyWindow)
+		assert.Zero(t, total)
+		assert.Empty(t, candidates)
+	})
+
+	t.Run("success list", func(t *testing.T) {
+		accountID := uuid.New()
+		lastMovementAt := time.Now().UTC().Add(-25 * 24 * time.Hour)
+
+		repoMock.EXPECT().
+			ListByLastMovement(ctx, gomockeq.Eq(lastMovementFilter{Page: 1, Size: 20}, gomockeq.IgnoreFields("Before"))).
+			Return(1, []dormancyCandidateModel{
+				{
+					accountModel:   accountModel{ID: accountID, Status: ActiveStatus},
+					LastMovementAt: lastMovementAt,
+				},
+			}, nil)
+
+		total, candidates, err := svc.ListDormancyCandidates(ctx, DormancyFilter{
+			Within: 7 * 24 * time.Hour,
+			Page:   1,
+			Size:   20,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, candidates, 1)
+		assert.Equal(t, accountID, candidates[0].Account.ID)
+		assert.Equal(t, lastMovementAt.Add(period), candidates[0].DormantAt)
+	})
+}
+
+func TestService_CreateJointAccount(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	holderRepoMock := holders.NewMockRepository(ctrl)
+
+	svc := NewService(tracer.NewNoop(), repoMock, holderRepoMock, false, 0)
+
+	owner := holders.HolderModel{ID: uuid.New(), Name: gofakeit.Name(), DocumentNumber: "52998224725"}
+	coOwner := holders.HolderModel{ID: uuid.New(), Name: gofakeit.Name(), DocumentNumber: "11144477735"}
+	operator := holders.HolderModel{ID: uuid.New(), Name: gofakeit.Name(), DocumentNumber: "39053344705"}
+
+	expectHolder := func(holder holders.HolderModel) {
+		holderRepoMock.EXPECT().
+			GetByFilter(ctx, holders.HolderFilter{DocumentNumber: holder.DocumentNumber}).
+			Return([]holders.HolderModel{holder}, nil)
+	}
+
+	t.Run("fail create, co-holder as owner", func(t *testing.T) {
+		expectHolder(owner)
+
+		created, err := svc.Create(ctx, Account{
+			Name:           gofakeit.Name(),
+			DocumentNumber: owner.DocumentNumber,
+			Holders:        []AccountHolder{{DocumentNumber: coOwner.DocumentNumber, Role: OwnerHolderRole}},
+		})
+		assert.ErrorIs(t, err, ErrInvalidHolderRole)
+		assert.Empty(t, created)
+	})
+
+	t.Run("fail create, holder linked twice", func(t *testing.T) {
+		expectHolder(owner)
+		expectHolder(owner)
+
+		created, err := svc.Create(ctx, Account{
+			Name:           gofakeit.Name(),
+			DocumentNumber: owner.DocumentNumber,
+			Holders:        []AccountHolder{{DocumentNumber: owner.DocumentNumber, Role: CoOwnerHolderRole}},
+		})
+		assert.ErrorIs(t, err, ErrDuplicatedAccountHolder)
+		assert.Empty(t, created)
+	})
+
+	t.Run("success create", func(t *testing.T) {
+		expectHolder(owner)
+		expectHolder(coOwner)
+		expectHolder(operator)
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any(), []accountHolderModel{
+				{HolderID: owner.ID, Role: OwnerHolderRole},
+				{HolderID: coOwner.ID, Role: CoOwnerHolderRole},
+				{HolderID: operator.ID, Role: OperatorHolderRole},
+			}).
+			Return(accountModel{ID: uuid.New(), HolderID: owner.ID, Status: ActiveStatus}, nil)
+
+		created, err := svc.Create(ctx, Account{
+			Name:           gofakeit.Name(),
+			DocumentNumber: owner.DocumentNumber,
+			Holders: []AccountHolder{
+				{DocumentNumber: coOwner.DocumentNumber, Role: CoOwnerHolderRole},
+				{DocumentNumber: operator.DocumentNumber, Role: OperatorHolderRole},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, owner.ID, created.HolderID)
+		assert.Len(t, created.Holders, 3)
+		assert.Equal(t, OwnerHolderRole, created.Holders[0].Role)
+		assert.Equal(t, coOwner.DocumentNumber, created.Holders[1].DocumentNumber)
+	})
+}
+
+func TestService_GetHolderRole(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+
+	svc := NewService(tracer.NewNoop(), repoMock, holders.NewMockRepository(ctrl), false, 0)
+
+	accountID := uuid.New()
+
+	repoMock.EXPECT().
+		GetByFilter(ctx, accountFilter{ID: uuid.NullUUID{UUID: accountID, Valid: true}}).
+		Return([]accountModel{{ID: accountID, Status: ActiveStatus}}, nil).
+		Times(2)
+	repoMock.EXPECT().
+		ListHolders(ctx, accountID).
+		Return([]accountHolderModel{
+			{AccountID: accountID, HolderDocumentNumber: "52998224725", Role: OwnerHolderRole},
+			{AccountID: accountID, HolderDocumentNumber: "39053344705", Role: OperatorHolderRole},
+		}, nil).
+		Times(2)
+
+	t.Run("success role, document number normalized", func(t *testing.T) {
+		role, err := svc.GetHolderRole(ctx, accountID, "390.533.447-05")
+		assert.NoError(t, err)
+		assert.Equal(t, OperatorHolderRole, role)
+		assert.False(t, role.CanDebit())
+	})
+
+	t.Run("fail role, holder not linked", func(t *testing.T) {
+		role, err := svc.GetHolderRole(ctx, accountID, "11144477735")
+		assert.ErrorIs(t, err, ErrAccountHolderNotLinked)
+		assert.Empty(t, role)
+	})
+}
+
+func TestService_Pockets(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	holderRepoMock := holders.NewMockRepository(ctrl)
+
+	svc := NewService(tracer.NewNoop(), repoMock, holderRepoMock, false, 0)
+
+	accountID := uuid.New()
+	pocketID := uuid.New()
+	holderID := uuid.New()
+	parent := uuid.NullUUID{UUID: accountID, Valid: true}
+
+	expectAccount := func(model accountModel) {
+		repoMock.EXPECT().
+			GetByFilter(ctx, accountFilter{ID: uuid.NullUUID{UUID: model.ID, Valid: true}}).
+			Return([]accountModel{model}, nil)
+	}
+
+	t.Run("fail create, name required", func(t *testing.T) {
+		created, err := svc.CreatePocket(ctx, accountID, "  ")
+		assert.ErrorIs(t, err, ErrPocketNameRequired)
+		assert.Empty(t, created)
+	})
+
+	t.Run("fail create, pocket under pocket", func(t *testing.T) {
+		expectAccount(accountModel{ID: pocketID, Status: ActiveStatus, ParentID: parent})
+
+		created, err := svc.CreatePocket(ctx, pocketID, "Travel")
+		assert.ErrorIs(t, err, ErrPocketNesting)
+		assert.Empty(t, created)
+	})
+
+	t.Run("fail create, blocked account", func(t *testing.T) {
+		expectAccount(accountModel{ID: accountID, Status: BlockedStatus})
+
+		created, err := svc.CreatePocket(ctx, accountID, "Travel")
+		assert.ErrorIs(t, err, ErrAccountInactive)
+		assert.Empty(t, created)
+	})
+
+	t.Run("fail create, limit reached", func(t *testing.T) {
+		expectAccount(accountModel{ID: accountID, Status: ActiveStatus})
+		repoMock.EXPECT().
+			ListPockets(ctx, accountID).
+			Return(make([]accountModel, maxPocketsPerAccount), nil)
+
+		created, err := svc.CreatePocket(ctx, accountID, "Travel")
+		assert.ErrorIs(t, err, ErrPocketLimitReached)
+		assert.Empty(t, created)
+	})
+
+	t.Run("fail create, duplicated name", func(t *testing.T) {
+		expectAccount(accountModel{ID: accountID, Status: ActiveStatus})
+		repoMock.EXPECT().ListPockets(ctx, accountID).Return(nil, nil)
+		repoMock.EXPECT().ListHolders(ctx, accountID).Return(nil, nil)
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any(), gomock.Any()).
+			Return(accountModel{}, errDuplicatedPocketName)
+
+		created, err := svc.CreatePocket(ctx, accountID, "Travel")
+		assert.ErrorIs(t, err, ErrDuplicatedPocketName)
+		assert.Empty(t, created)
+	})
+
+	t.Run("success create", func(t *testing.T) {
+		expectAccount(accountModel{
+			ID:                   accountID,
+			HolderID:             holderID,
+			HolderDocumentNumber: "52998224725",
+			Type:                 products.SavingsType,
+			Status:               ActiveStatus,
+		})
+		repoMock.EXPECT().ListPockets(ctx, accountID).Return(nil, nil)
+		repoMock.EXPECT().
+			ListHolders(ctx, accountID).
+			Return([]accountHolderModel{{AccountID: accountID, HolderID: holderID, Role: OwnerHolderRole}}, nil)
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any(), []accountHolderModel{{HolderID: holderID, Role: OwnerHolderRole}}).
+			Return(accountModel{ID: pocketID}, nil)
+
+		created, err := svc.CreatePocket(ctx, accountID, " Travel ")
+		assert.NoError(t, err)
+		assert.Equal(t, pocketID, created.ID)
+		assert.Equal(t, "Travel", created.Name)
+		assert.Equal(t, parent, created.ParentID)
+		assert.Equal(t, holderID, created.HolderID)
+		assert.True(t, created.IsPocket())
+		assert.Len(t, created.Holders, 1)
+	})
+
+	t.Run("success list", func(t *testing.T) {
+		expectAccount(accountModel{ID: accountID, Status: ActiveStatus})
+		repoMock.EXPECT().
+			ListPockets(ctx, accountID).
+			Return([]accountModel{{ID: pocketID, Name: "Travel", ParentID: parent}}, nil)
+
+		pockets, err := svc.ListPockets(ctx, accountID)
+		assert.NoError(t, err)
+		assert.Len(t, pockets, 1)
+		assert.Equal(t, pocketID, pockets[0].ID)
+	})
+}
@@ -6,30 +6,62 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/pprof"
+	"time"
 
 	"github.com/dalmarcogd/dock-test/internal/accounts"
 	"github.com/dalmarcogd/dock-test/internal/api/internal/environment"
 	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers"
 	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/accountsh"
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/apikeysh"
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/approvalsh"
 	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/balancesh"
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/batchesh"
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/boletosh"
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/chargesh"
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/feesh"
 	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/holdersh"
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/interesth"
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/keysh"
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/policiesh"
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/productsh"
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/reviewsh"
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/riskh"
 	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/statementsh"
 	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/transactionsh"
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/transfersh"
+	"github.com/dalmarcogd/dock-test/internal/apikeys"
+	"github.com/dalmarcogd/dock-test/internal/approvals"
 	"github.com/dalmarcogd/dock-test/internal/balances"
+	"github.com/dalmarcogd/dock-test/internal/batches"
+	"github.com/dalmarcogd/dock-test/internal/boletos"
+	"github.com/dalmarcogd/dock-test/internal/charges"
+	"github.com/dalmarcogd/dock-test/internal/exchange"
+	"github.com/dalmarcogd/dock-test/internal/fees"
 	"github.com/dalmarcogd/dock-test/internal/holders"
+	"github.com/dalmarcogd/dock-test/internal/interest"
+	"github.com/dalmarcogd/dock-test/internal/keys"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/internal/products"
+	"github.com/dalmarcogd/dock-test/internal/risk"
 	"github.com/dalmarcogd/dock-test/internal/statements"
 	"github.com/dalmarcogd/dock-test/internal/transactions"
+	"github.com/dalmarcogd/dock-test/internal/transfers"
 	"github.com/dalmarcogd/dock-test/pkg/database"
 	"github.com/dalmarcogd/dock-test/pkg/distlock"
 	"github.com/dalmarcogd/dock-test/pkg/healthcheck"
 	"github.com/dalmarcogd/dock-test/pkg/http/middlewares"
 	"github.com/dalmarcogd/dock-test/pkg/redis"
 	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 )
 
+// localEnvironment is the ENVIRONMENT of a developer machine, the only one allowed to run without
+// external providers.
+const localEnvironment = "local"
+
 var Module = fx.Options(
 	// Infra
 	fx.Provide(
@@ -59,13 +91,164 @@ var Module = fx.Options(
 		holders.NewRepository,
 		holders.NewService,
 		accounts.NewRepository,
-		accounts.NewService,
+		func(
+			t tracer.Tracer,
+			r accounts.Repository,
+			hr holders.Repository,
+			e environment.Environment,
+		) accounts.Service {
+			return accounts.NewService(
+				t,
+				r,
+				hr,
+				e.AccountsRequireApprovedKYC,
+				time.Duration(e.AccountsDormancyDays)*24*time.Hour,
+			)
+		},
+		func(t tracer.Tracer, db database.Database, e environment.Environment) (exchange.RateProvider, error) {
+			if e.ExchangeRatesFile != "" {
+				return exchange.NewFileProvider(e.ExchangeRatesFile)
+			}
+
+			return exchange.NewDatabaseProvider(t, db), nil
+		},
+		exchange.NewService,
+		fees.NewRepository,
+		func(t tracer.Tracer, r fees.Repository, e environment.Environment) (fees.Service, error) {
+			revenueAccountID, err := uuid.Parse(e.FeesRevenueAccountID)
+			if err != nil {
+				return nil, err
+			}
+
+			return fees.NewService(t, r, revenueAccountID), nil
+		},
+		risk.NewRepository,
+		risk.NewService,
 		transactions.NewRepository,
-		transactions.NewService,
+		func(
+			t tracer.Tracer,
+			r transactions.Repository,
+			l distlock.DistLock,
+			as accounts.Service,
+			bs balances.Service,
+			ps products.Service,
+			fs fees.Service,
+			es exchange.Service,
+			rs risk.Service,
+			pls policies.Service,
+			redisClient redis.Client,
+			e environment.Environment,
+		) transactions.Service {
+			return transactions.NewService(
+				t,
+				r,
+				l,
+				as,
+				bs,
+				ps,
+				fs,
+				es,
+				rs,
+				pls,
+				redisClient,
+				time.Duration(e.TransactionsReviewSLAMinutes)*time.Minute,
+				e.ApprovalsTransferThreshold,
+			)
+		},
 		statements.NewRepository,
 		statements.NewService,
 		balances.NewRepository,
 		balances.NewService,
+		products.NewRepository,
+		products.NewService,
+		interest.NewRepository,
+		func(
+			t tracer.Tracer,
+			r interest.Repository,
+			l distlock.DistLock,
+			trs transactions.Service,
+			e environment.Environment,
+		) (interest.Service, error) {
+			interestAccountID, err := uuid.Parse(e.InterestAccountID)
+			if err != nil {
+				return nil, err
+			}
+
+			return interest.NewService(t, r, l, trs, interestAccountID, e.InterestWithholdingTaxRate), nil
+		},
+		keys.NewRepository,
+		func(e environment.Environment) (keys.Notifier, error) {
+			if e.KeysNotifierURL != "" {
+				return keys.NewHTTPNotifier(e.KeysNotifierURL, &http.Client{Timeout: 10 * time.Second}), nil
+			}
+			if e.Environment != localEnvironment {
+				return nil, errors.New("KEYS_NOTIFIER_URL is required outside the local environment")
+			}
+
+			return keys.NewLogNotifier(), nil
+		},
+		keys.NewService,
+		charges.NewRepository,
+		charges.NewService,
+		boletos.NewRepository,
+		func(
+			t tracer.Tracer,
+			r boletos.Repository,
+			as accounts.Service,
+			ts transactions.Service,
+			e environment.Environment,
+		) boletos.Service {
+			return boletos.NewService(t, r, as, ts, e.BoletosBankCode)
+		},
+		transfers.NewRepository,
+		func(
+			t tracer.Tracer,
+			r transfers.Repository,
+			as accounts.Service,
+			ts transactions.Service,
+			e environment.Environment,
+		) (transfers.Service, error) {
+			clearingAccountID, err := uuid.Parse(e.TransfersClearingAccountID)
+			if err != nil {
+				return nil, err
+			}
+
+			return transfers.NewService(t, r, as, ts, clearingAccountID, transfers.Originator{
+				BankCode:       e.TransfersBankCode,
+				DocumentNumber: e.TransfersCompanyDocument,
+				Name:           e.TransfersCompanyName,
+			}), nil
+		},
+		batches.NewRepository,
+		batches.NewService,
+		approvals.NewRepository,
+		func(
+			t tracer.Tracer,
+			r approvals.Repository,
+			as accounts.Service,
+			trs transactions.Service,
+			ts transfers.Service,
+			is interest.Service,
+			pls policies.Service,
+			e environment.Environment,
+		) approvals.Service {
+			return approvals.NewService(
+				t,
+				r,
+				as,
+				trs,
+				ts,
+				is,
+				pls,
+				time.Duration(e.ApprovalsExpirationMinutes)*time.Minute,
+			)
+		},
+		apikeys.NewRepository,
+		func(t tracer.Tracer, r apikeys.Repository, e environment.Environment) apikeys.Service {
+			return apikeys.NewService(t, r, e.AuthBootstrapAPIKey)
+		},
+		policies.NewRepository,
+		policies.NewService,
 	),
 	// Endpoints
 	fx.Provide(
@@ -74,18 +257,76 @@ var Module = fx.Options(
 		holdersh.NewCreateHolderFunc,
 		holdersh.NewGetByIDHolderFunc,
 		holdersh.NewListHoldersFunc,
+		holdersh.NewUpdateHolderFunc,
+		holdersh.NewListHolderAccountsFunc,
+		holdersh.NewListHolderBalancesFunc,
+		holdersh.NewCreateKYCReviewFunc,
+		holdersh.NewListKYCReviewsFunc,
 		accountsh.NewCreateAccountFunc,
 		accountsh.NewBlockByIDFunc,
 		accountsh.NewUnblockByIDFunc,
 		accountsh.NewCloseByIDFunc,
 		accountsh.NewGetByIDFunc,
 		accountsh.NewListAccountsFunc,
+		accountsh.NewListStatusHistoryFunc,
+		accountsh.NewListAccountHoldersFunc,
+		accountsh.NewSetRestrictionsFunc,
+		accountsh.NewCreateLegalHoldFunc,
+		accountsh.NewListLegalHoldsFunc,
+		accountsh.NewReleaseLegalHoldFunc,
+		accountsh.NewListDormancyCandidatesFunc,
+		accountsh.NewCreatePocketFunc,
+		accountsh.NewListPocketsFunc,
 		statementsh.NewListAccountStatementFunc,
 		balancesh.NewGetBalanceByAccountIDFunc,
 		transactionsh.NewCreateCreditTransactionFunc,
 		transactionsh.NewCreateDebitTransactionFunc,
 		transactionsh.NewCreateP2PTransactionFunc,
+		transactionsh.NewCreateInternalTransactionFunc,
 		transactionsh.NewGetByIDTransactionFunc,
+		productsh.NewListProductsFunc,
+		interesth.NewSetRateFunc,
+		interesth.NewListRatesFunc,
+		interesth.NewListPayoutsFunc,
+		feesh.NewCreateScheduleFunc,
+		feesh.NewListSchedulesFunc,
+		feesh.NewDeleteScheduleFunc,
+		riskh.NewCreateRuleFunc,
+		riskh.NewListRulesFunc,
+		riskh.NewUpdateRuleFunc,
+		riskh.NewDeleteRuleFunc,
+		riskh.NewListEvaluationsFunc,
+		reviewsh.NewListReviewsFunc,
+		reviewsh.NewApproveReviewFunc,
+		reviewsh.NewRejectReviewFunc,
+		approvalsh.NewListApprovalsFunc,
+		approvalsh.NewGetApprovalFunc,
+		approvalsh.NewApproveFunc,
+		approvalsh.NewRejectFunc,
+		keysh.NewRegisterKeyFunc,
+		keysh.NewVerifyKeyFunc,
+		keysh.NewListKeysFunc,
+		keysh.NewDeleteKeyFunc,
+		keysh.NewLookupKeyFunc,
+		chargesh.NewCreateChargeFunc,
+		chargesh.NewGetByIDChargeFunc,
+		chargesh.NewGetQRCodeFunc,
+		chargesh.NewPayChargeFunc,
+		boletosh.NewCreateBoletoFunc,
+		boletosh.NewGetByIDBoletoFunc,
+		boletosh.NewImportReturnFileFunc,
+		transfersh.NewCreateTransferFunc,
+		transfersh.NewGetByIDTransferFunc,
+		transfersh.NewGenerateRemittanceFunc,
+		transfersh.NewGetRemittanceFileFunc,
+		transfersh.NewImportReturnFileFunc,
+		batchesh.NewSubmitPain001Func,
+		apikeysh.NewCreateAPIKeyFunc,
+		apikeysh.NewListAPIKeysFunc,
+		apikeysh.NewRevokeAPIKeyFunc,
+		policiesh.NewLinkHolderFunc,
+		policiesh.NewListLinksFunc,
+		policiesh.NewUnlinkHolderFunc,
 	),
 	// Startup applications
 	fx.Invoke(func(
@@ -98,6 +339,12 @@ var Module = fx.Options(
 		)
 	}),
 	fx.Invoke(runHTTPServer),
+	fx.Invoke(runDormancyJob),
+	fx.Invoke(runInterestJob),
+	fx.Invoke(runMaintenanceFeeJob),
+	fx.Invoke(runRemittanceJob),
+	fx.Invoke(runReviewExpirationJob),
+	fx.Invoke(runApprovalExpirationJob),
 )
 
 func setupLogger(service, version, env string) (*zap.Logger, error) {
@@ -121,39 +368,170 @@ func runHTTPServer(
 	createHolderFunc holdersh.CreateHolderFunc,
 	getByIDHolderFunc holdersh.GetByIDHolderFunc,
 	listHoldersFunc holdersh.ListHoldersFunc,
+	updateHolderFunc holdersh.UpdateHolderFunc,
+	listHolderAccountsFunc holdersh.ListHolderAccountsFunc,
+	listHolderBalancesFunc holdersh.ListHolderBalancesFunc,
+	createKYCReviewFunc holdersh.CreateKYCReviewFunc,
+	listKYCReviewsFunc holdersh.ListKYCReviewsFunc,
 	createAccountFunc accountsh.CreateAccountFunc,
 	closeByIDFunc accountsh.CloseByIDFunc,
 	blockByIDFunc accountsh.BlockByIDFunc,
 	unblockByIDFunc accountsh.UnblockByIDFunc,
 	getByIDAccountFunc accountsh.GetByIDFunc,
 	listAccountsFunc accountsh.ListAccountsFunc,
+	listStatusHistoryFunc accountsh.ListStatusHistoryFunc,
+	listAccountHoldersFunc accountsh.ListAccountHoldersFunc,
+	setRestrictionsFunc accountsh.SetRestrictionsFunc,
+	createLegalHoldFunc accountsh.CreateLegalHoldFunc,
+	listLegalHoldsFunc accountsh.ListLegalHoldsFunc,
+	releaseLegalHoldFunc accountsh.ReleaseLegalHoldFunc,
+	listDormancyCandidatesFunc accountsh.ListDormancyCandidatesFunc,
+	createPocketFunc accountsh.CreatePocketFunc,
+	listPocketsFunc accountsh.ListPocketsFunc,
 	createCreditTransactionFunc transactionsh.CreateCreditTransactionFunc,
 	createDebitTransactionFunc transactionsh.CreateDebitTransactionFunc,
 	createP2PTransactionFunc transactionsh.CreateP2PTransactionFunc,
+	createInternalTransactionFunc transactionsh.CreateInternalTransactionFunc,
 	getByIDTransactionFunc transactionsh.GetByIDTransactionFunc,
 	listAccountStatementFunc statementsh.ListAccountStatementFunc,
 	getBalanceByIDAccountFunc balancesh.GetBalanceByAccountIDFunc,
+	listProductsFunc productsh.ListProductsFunc,
+	setInterestRateFunc interesth.SetRateFunc,
+	listInterestRatesFunc interesth.ListRatesFunc,
+	listInterestPayoutsFunc interesth.ListPayoutsFunc,
+	createFeeScheduleFunc feesh.CreateScheduleFunc,
+	listFeeSchedulesFunc feesh.ListSchedulesFunc,
+	deleteFeeScheduleFunc feesh.DeleteScheduleFunc,
+	createRiskRuleFunc riskh.CreateRuleFunc,
+	listRiskRulesFunc riskh.ListRulesFunc,
+	updateRiskRuleFunc riskh.UpdateRuleFunc,
+	deleteRiskRuleFunc riskh.DeleteRuleFunc,
+	listRiskEvaluationsFunc riskh.ListEvaluationsFunc,
+	listReviewsFunc reviewsh.ListReviewsFunc,
+	approveReviewFunc reviewsh.ApproveReviewFunc,
+	rejectReviewFunc reviewsh.RejectReviewFunc,
+	listApprovalsFunc approvalsh.ListApprovalsFunc,
+	getApprovalFunc approvalsh.GetApprovalFunc,
+	approveFunc approvalsh.ApproveFunc,
+	rejectFunc approvalsh.RejectFunc,
+	registerKeyFunc keysh.RegisterKeyFunc,
+	verifyKeyFunc keysh.VerifyKeyFunc,
+	listKeysFunc keysh.ListKeysFunc,
+	deleteKeyFunc keysh.DeleteKeyFunc,
+	lookupKeyFunc keysh.LookupKeyFunc,
+	createChargeFunc chargesh.CreateChargeFunc,
+	getByIDChargeFunc chargesh.GetByIDChargeFunc,
+	getChargeQRCodeFunc chargesh.GetQRCodeFunc,
+	payChargeFunc chargesh.PayChargeFunc,
+	createBoletoFunc boletosh.CreateBoletoFunc,
+	getByIDBoletoFunc boletosh.GetByIDBoletoFunc,
+	importReturnFileFunc boletosh.ImportReturnFileFunc,
+	createTransferFunc transfersh.CreateTransferFunc,
+	getByIDTransferFunc transfersh.GetByIDTransferFunc,
+	generateRemittanceFunc transfersh.GenerateRemittanceFunc,
+	getRemittanceFileFunc transfersh.GetRemittanceFileFunc,
+	importTransfersReturnFileFunc transfersh.ImportReturnFileFunc,
+	submitPain001Func batchesh.SubmitPain001Func,
+	apiKeysSvc apikeys.Service,
+	createAPIKeyFunc apikeysh.CreateAPIKeyFunc,
+	listAPIKeysFunc apikeysh.ListAPIKeysFunc,
+	revokeAPIKeyFunc apikeysh.RevokeAPIKeyFunc,
+	linkHolderFunc policiesh.LinkHolderFunc,
+	listLinksFunc policiesh.ListLinksFunc,
+	unlinkHolderFunc policiesh.UnlinkHolderFunc,
 ) error {
+	var tokens middlewares.Authenticator
+	if env.AuthJWKSFile != "" {
+		var err error
+		tokens, err = middlewares.NewJWTAuthenticatorFromFile(env.AuthJWKSFile, env.AuthJWTIssuer, env.AuthJWTAudience)
+		if err != nil {
+			return err
+		}
+	}
+
+	requireAdmin := echo.WrapMiddleware(middlewares.NewScopeHTTPMiddleware(policies.AdminScope))
+	requireBackOffice := echo.WrapMiddleware(
+		middlewares.NewScopeHTTPMiddleware(policies.BackOfficeScope, policies.AdminScope),
+	)
+
 	e := echo.New()
 
 	e.GET("/readiness", echo.HandlerFunc(readinessFunc))
 	e.GET("/liveness", echo.HandlerFunc(livenessFunc))
 	v1 := e.Group("/v1")
-	v1.POST("/holders", echo.HandlerFunc(createHolderFunc))
+	v1.POST("/holders", echo.HandlerFunc(createHolderFunc), requireBackOffice)
 	v1.GET("/holders/:id", echo.HandlerFunc(getByIDHolderFunc))
-	v1.GET("/holders", echo.HandlerFunc(listHoldersFunc))
-	v1.POST("/accounts", echo.HandlerFunc(createAccountFunc))
-	v1.GET("/accounts", echo.HandlerFunc(listAccountsFunc))
+	v1.GET("/holders", echo.HandlerFunc(listHoldersFunc), requireBackOffice)
+	v1.PATCH("/holders/:id", echo.HandlerFunc(updateHolderFunc))
+	v1.GET("/holders/:id/accounts", echo.HandlerFunc(listHolderAccountsFunc))
+	v1.GET("/holders/:id/balances", echo.HandlerFunc(listHolderBalancesFunc))
+	v1.POST("/holders/:id/kyc-reviews", echo.HandlerFunc(createKYCReviewFunc), requireBackOffice)
+	v1.GET("/holders/:id/kyc-reviews", echo.HandlerFunc(listKYCReviewsFunc), requireBackOffice)
+	v1.POST("/accounts", echo.HandlerFunc(createAccountFunc), requireBackOffice)
+	v1.GET("/accounts", echo.HandlerFunc(listAccountsFunc), requireBackOffice)
+	v1.GET("/accounts/dormancy-candidates", echo.HandlerFunc(listDormancyCandidatesFunc), requireBackOffice)
 	v1.GET("/accounts/:id", echo.HandlerFunc(getByIDAccountFunc))
-	v1.PUT("/accounts/:id/blocks", echo.HandlerFunc(blockByIDFunc))
-	v1.PUT("/accounts/:id/unblocks", echo.HandlerFunc(unblockByIDFunc))
-	v1.PUT("/accounts/:id/closes", echo.HandlerFunc(closeByIDFunc))
+	v1.PUT("/accounts/:id/blocks", echo.HandlerFunc(blockByIDFunc), requireBackOffice)
+	v1.PUT("/accounts/:id/unblocks", echo.HandlerFunc(unblockByIDFunc), requireBackOffice)
+	v1.PUT("/accounts/:id/closes", echo.HandlerFunc(closeByIDFunc), requireBackOffice)
+	v1.GET("/accounts/:id/status-history", echo.HandlerFunc(listStatusHistoryFunc))
+	v1.GET("/accounts/:id/holders", echo.HandlerFunc(listAccountHoldersFunc))
+	v1.PUT("/accounts/:id/restrictions", echo.HandlerFunc(setRestrictionsFunc), requireBackOffice)
+	v1.POST("/accounts/:id/legal-holds", echo.HandlerFunc(createLegalHoldFunc), requireBackOffice)
+	v1.GET("/accounts/:id/legal-holds", echo.HandlerFunc(listLegalHoldsFunc), requireBackOffice)
+	v1.PUT("/accounts/:id/legal-holds/:holdID/releases", echo.HandlerFunc(releaseLegalHoldFunc), requireBackOffice)
+	v1.POST("/accounts/:id/pockets", echo.HandlerFunc(createPocketFunc))
+	v1.GET("/accounts/:id/pockets", echo.HandlerFunc(listPocketsFunc))
 	v1.GET("/accounts/:id/statements", echo.HandlerFunc(listAccountStatementFunc))
 	v1.GET("/accounts/:id/balances", echo.HandlerFunc(getBalanceByIDAccountFunc))
-	v1.POST("/transactions/credits", echo.HandlerFunc(createCreditTransactionFunc))
+	v1.GET("/accounts/:id/interest-payouts", echo.HandlerFunc(listInterestPayoutsFunc))
+	v1.POST("/accounts/:id/keys", echo.HandlerFunc(registerKeyFunc))
+	v1.GET("/accounts/:id/keys", echo.HandlerFunc(listKeysFunc))
+	v1.PUT("/accounts/:id/keys/:keyID/verifications", echo.HandlerFunc(verifyKeyFunc))
+	v1.DELETE("/accounts/:id/keys/:keyID", echo.HandlerFunc(deleteKeyFunc))
+	v1.GET("/keys/:key", echo.HandlerFunc(lookupKeyFunc))
+	v1.POST("/accounts/:id/charges", echo.HandlerFunc(createChargeFunc))
+	v1.GET("/charges/:id", echo.HandlerFunc(getByIDChargeFunc))
+	v1.GET("/charges/:id/qrcode", echo.HandlerFunc(getChargeQRCodeFunc))
+	v1.POST("/charges/:id/pay", echo.HandlerFunc(payChargeFunc))
+	v1.POST("/accounts/:id/boletos", echo.HandlerFunc(createBoletoFunc))
+	v1.POST("/boletos/returns", echo.HandlerFunc(importReturnFileFunc), requireBackOffice)
+	v1.GET("/boletos/:id", echo.HandlerFunc(getByIDBoletoFunc))
+	v1.POST("/transfers", echo.HandlerFunc(createTransferFunc))
+	v1.POST("/transfers/remittances", echo.HandlerFunc(generateRemittanceFunc), requireBackOffice)
+	v1.GET("/transfers/remittances/:id/file", echo.HandlerFunc(getRemittanceFileFunc), requireBackOffice)
+	v1.POST("/transfers/returns", echo.HandlerFunc(importTransfersReturnFileFunc), requireBackOffice)
+	v1.GET("/transfers/:id", echo.HandlerFunc(getByIDTransferFunc))
+	v1.POST("/transactions/credits", echo.HandlerFunc(createCreditTransactionFunc), requireBackOffice)
 	v1.POST("/transactions/debits", echo.HandlerFunc(createDebitTransactionFunc))
 	v1.POST("/transactions/p2p", echo.HandlerFunc(createP2PTransactionFunc))
+	v1.POST("/transactions/internal", echo.HandlerFunc(createInternalTransactionFunc))
+	v1.POST("/transactions/batches/iso20022", echo.HandlerFunc(submitPain001Func))
 	v1.GET("/transactions/:id", echo.HandlerFunc(getByIDTransactionFunc))
+	v1.GET("/products", echo.HandlerFunc(listProductsFunc))
+	v1.POST("/interest-rates", echo.HandlerFunc(setInterestRateFunc), requireBackOffice)
+	v1.GET("/interest-rates", echo.HandlerFunc(listInterestRatesFunc))
+	v1.POST("/fee-schedules", echo.HandlerFunc(createFeeScheduleFunc), requireBackOffice)
+	v1.GET("/fee-schedules", echo.HandlerFunc(listFeeSchedulesFunc))
+	v1.DELETE("/fee-schedules/:id", echo.HandlerFunc(deleteFeeScheduleFunc), requireBackOffice)
+	v1.POST("/risk-rules", echo.HandlerFunc(createRiskRuleFunc), requireBackOffice)
+	v1.GET("/risk-rules", echo.HandlerFunc(listRiskRulesFunc), requireBackOffice)
+	v1.PUT("/risk-rules/:id", echo.HandlerFunc(updateRiskRuleFunc), requireBackOffice)
+	v1.DELETE("/risk-rules/:id", echo.HandlerFunc(deleteRiskRuleFunc), requireBackOffice)
+	v1.GET("/risk-evaluations", echo.HandlerFunc(listRiskEvaluationsFunc), requireBackOffice)
+	v1.GET("/reviews", echo.HandlerFunc(listReviewsFunc), requireBackOffice)
+	v1.PUT("/reviews/:id/approvals", echo.HandlerFunc(approveReviewFunc), requireBackOffice)
+	v1.PUT("/reviews/:id/rejections", echo.HandlerFunc(rejectReviewFunc), requireBackOffice)
+	v1.GET("/approvals", echo.HandlerFunc(listApprovalsFunc))
+	v1.GET("/approvals/:id", echo.HandlerFunc(getApprovalFunc))
+	v1.PUT("/approvals/:id/approves", echo.HandlerFunc(approveFunc))
+	v1.PUT("/approvals/:id/rejects", echo.HandlerFunc(rejectFunc))
+	v1.POST("/api-keys", echo.HandlerFunc(createAPIKeyFunc), requireAdmin)
+	v1.GET("/api-keys", echo.HandlerFunc(listAPIKeysFunc), requireAdmin)
+	v1.DELETE("/api-keys/:id", echo.HandlerFunc(revokeAPIKeyFunc), requireAdmin)
+	v1.POST("/principals/:id/holders", echo.HandlerFunc(linkHolderFunc), requireAdmin)
+	v1.GET("/principals/:id/holders", echo.HandlerFunc(listLinksFunc), requireAdmin)
+	v1.DELETE("/principals/:id/holders/:holderID", echo.HandlerFunc(unlinkHolderFunc), requireAdmin)
 
 	hmux := http.NewServeMux()
 	hmux.Handle("/", e)
@@ -165,9 +543,15 @@ func runHTTPServer(
 		hmux.HandleFunc("/debug/pprof/trace", pprof.Trace)
 	}
 
-	apiMiddlewares := make([]middlewares.Middleware, 0, 3)
+	apiMiddlewares := make([]middlewares.Middleware, 0, 4)
 	apiMiddlewares = append(apiMiddlewares, middlewares.NewTracerHTTPMiddleware(t, "/", "/readiness", "/liveness"))
 	apiMiddlewares = append(apiMiddlewares, middlewares.NewRecoveryHTTPMiddleware())
+	apiMiddlewares = append(apiMiddlewares, middlewares.NewAuthenticationHTTPMiddleware(
+		apiKeysSvc,
+		tokens,
+		"/readiness",
+		"/liveness",
+	))
 	apiMiddlewares = append(apiMiddlewares, middlewares.NewDefaultContentTypeValidator())
 
 	httpServer := &http.Server{
@@ -196,3 +580,407 @@ func runHTTPServer(
 
 	return nil
 }
+
+const dormancyJobLockKey = "accounts-dormancy-job"
+
+// runDormancyJob periodically moves inactive accounts to DORMANT. The lock is kept for the whole
+// interval so only one instance runs it per interval.
+func runDormancyJob(
+	lc fx.Lifecycle,
+	env environment.Environment,
+	svc accounts.Service,
+	locker distlock.DistLock,
+) error {
+	if env.AccountsDormancyDays <= 0 || env.AccountsDormancyJobIntervalMinutes <= 0 {
+		zap.L().Info("dormancy_job_disabled")
+		return nil
+	}
+
+	interval := time.Duration(env.AccountsDormancyJobIntervalMinutes) * time.Minute
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			go func() {
+				defer close(done)
+
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+
+				zap.L().Info("dormancy_job_up", zap.Duration("interval", interval))
+				for {
+					if locker.Acquire(ctx, dormancyJobLockKey, interval, 1) {
+						if _, err := svc.MarkDormant(ctx); err != nil {
+							zap.L().Error("dormancy_job_error", zap.Error(err))
+						}
+					}
+
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+
+	return nil
+}
+
+const (
+	interestJobLockKey = "interest-job"
+	// interestCatchUpDays is how many past days each run accrues, covering the days missed while
+	// the job was not running.
+	interestCatchUpDays = 7
+)
+
+// runInterestJob periodically accrues the savings interest of the past days and capitalizes the
+// previous month. Both steps are idempotent, so each run retries what a failed one left behind.
+func runInterestJob(
+	lc fx.Lifecycle,
+	env environment.Environment,
+	svc interest.Service,
+	locker distlock.DistLock,
+) error {
+	if env.InterestJobIntervalMinutes <= 0 {
+		zap.L().Info("interest_job_disabled")
+		return nil
+	}
+
+	interval := time.Duration(env.InterestJobIntervalMinutes) * time.Minute
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			go func() {
+				defer close(done)
+
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+
+				zap.L().Info("interest_job_up", zap.Duration("interval", interval))
+				for {
+					if locker.Acquire(ctx, interestJobLockKey, interval, 1) {
+						today := time.Now().UTC()
+						for days := interestCatchUpDays; days > 0; days-- {
+							if _, err := svc.Accrue(ctx, today.AddDate(0, 0, -days)); err != nil {
+								zap.L().Error("interest_job_accrue_error", zap.Error(err))
+							}
+						}
+
+						previousMonth := time.Date(today.Year(), today.Month()-1, 1, 0, 0, 0, 0, time.UTC)
+						if _, err := svc.Capitalize(ctx, previousMonth); err != nil {
+							zap.L().Error("interest_job_capitalize_error", zap.Error(err))
+						}
+					}
+
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+
+	return nil
+}
+
+const maintenanceFeeJobLockKey = "fees-maintenance-job"
+
+// runMaintenanceFeeJob periodically charges the monthly maintenance fee of the current month. Fees
+// already charged are never charged again, and accounts without funds are retried on the next run.
+func runMaintenanceFeeJob(
+	lc fx.Lifecycle,
+	env environment.Environment,
+	feesSvc fees.Service,
+	transactionsSvc transactions.Service,
+	locker distlock.DistLock,
+) error {
+	if env.FeesMaintenanceJobIntervalMinutes <= 0 {
+		zap.L().Info("maintenance_fee_job_disabled")
+		return nil
+	}
+
+	interval := time.Duration(env.FeesMaintenanceJobIntervalMinutes) * time.Minute
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			go func() {
+				defer close(done)
+
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+
+				zap.L().Info("maintenance_fee_job_up", zap.Duration("interval", interval))
+				for {
+					if locker.Acquire(ctx, maintenanceFeeJobLockKey, interval, 1) {
+						chargeMaintenanceFees(ctx, feesSvc, transactionsSvc)
+					}
+
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+
+	return nil
+}
+
+func chargeMaintenanceFees(ctx context.Context, feesSvc fees.Service, transactionsSvc transactions.Service) {
+	due, err := feesSvc.ListMaintenanceDue(ctx, time.Now())
+	if err != nil {
+		zap.L().Error("maintenance_fee_job_error", zap.Error(err))
+		return
+	}
+
+	var charged int
+	for _, fee := range due {
+		_, err := transactionsSvc.ChargeFee(ctx, fee)
+		if errors.Is(err, transactions.ErrFeeAlreadyCharged) {
+			continue
+		}
+		if err != nil {
+			zap.L().Warn(
+				"maintenance_fee_job_not_charged",
+				zap.String("account_id", fee.AccountID.String()),
+				zap.Error(err),
+			)
+			continue
+		}
+		charged++
+	}
+
+	zap.L().Info("maintenance_fee_job_done", zap.Int("due", len(due)), zap.Int("charged", charged))
+}
+
+const remittanceJobLockKey = "transfers-remittance-job"
+
+// runRemittanceJob periodically generates the remittance of the previous day, so the transfers of a
+// day are sent to the bank the next morning. Each day has a single remittance, generated by whichever
+// run comes first.
+func runRemittanceJob(
+	lc fx.Lifecycle,
+	env environment.Environment,
+	transfersSvc transfers.Service,
+	locker distlock.DistLock,
+) error {
+	if env.TransfersRemittanceJobIntervalMinutes <= 0 {
+		zap.L().Info("remittance_job_disabled")
+		return nil
+	}
+
+	interval := time.Duration(env.TransfersRemittanceJobIntervalMinutes) * time.Minute
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			go func() {
+				defer close(done)
+
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+
+				zap.L().Info("remittance_job_up", zap.Duration("interval", interval))
+				for {
+					if locker.Acquire(ctx, remittanceJobLockKey, interval, 1) {
+						generateRemittance(ctx, transfersSvc)
+					}
+
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+
+	return nil
+}
+
+func generateRemittance(ctx context.Context, transfersSvc transfers.Service) {
+	date := time.Now().UTC().AddDate(0, 0, -1)
+
+	remittance, err := transfersSvc.GenerateRemittance(ctx, date)
+	if errors.Is(err, transfers.ErrRemittanceAlreadyGenerated) || errors.Is(err, transfers.ErrNoTransfersToRemit) {
+		return
+	}
+	if err != nil {
+		zap.L().Error("remittance_job_error", zap.Error(err))
+		return
+	}
+
+	zap.L().Info(
+		"remittance_job_done",
+		zap.String("remittance_id", remittance.ID.String()),
+		zap.Int("transfers", remittance.TransferCount),
+	)
+}
+
+const reviewExpirationJobLockKey = "transactions-review-expiration-job"
+
+// runReviewExpirationJob periodically rejects the P2Ps parked for a manual review that no analyst
+// decided within the SLA, releasing their funds.
+func runReviewExpirationJob(
+	lc fx.Lifecycle,
+	env environment.Environment,
+	transactionsSvc transactions.Service,
+	locker distlock.DistLock,
+) error {
+	if env.TransactionsReviewJobIntervalMinutes <= 0 {
+		zap.L().Info("review_expiration_job_disabled")
+		return nil
+	}
+
+	interval := time.Duration(env.TransactionsReviewJobIntervalMinutes) * time.Minute
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			go func() {
+				defer close(done)
+
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+
+				zap.L().Info("review_expiration_job_up", zap.Duration("interval", interval))
+				for {
+					if locker.Acquire(ctx, reviewExpirationJobLockKey, interval, 1) {
+						expired, err := transactionsSvc.ExpireReviews(ctx)
+						if err != nil {
+							zap.L().Error("review_expiration_job_error", zap.Error(err))
+						} else if expired > 0 {
+							zap.L().Info("review_expiration_job_done", zap.Int("expired", expired))
+						}
+					}
+
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+
+	return nil
+}
+
+const approvalExpirationJobLockKey = "approvals-expiration-job"
+
+// runApprovalExpirationJob periodically expires the operations under dual control not approved in time.
+func runApprovalExpirationJob(
+	lc fx.Lifecycle,
+	env environment.Environment,
+	approvalsSvc approvals.Service,
+	locker distlock.DistLock,
+) error {
+	if env.ApprovalsJobIntervalMinutes <= 0 {
+		zap.L().Info("approval_expiration_job_disabled")
+		return nil
+	}
+
+	interval := time.Duration(env.ApprovalsJobIntervalMinutes) * time.Minute
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			go func() {
+				defer close(done)
+
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+
+				zap.L().Info("approval_expiration_job_up", zap.Duration("interval", interval))
+				for {
+					if locker.Acquire(ctx, approvalExpirationJobLockKey, interval, 1) {
+						expired, err := approvalsSvc.ExpireApprovals(ctx)
+						if err != nil {
+							zap.L().Error("approval_expiration_job_error", zap.Error(err))
+						} else if expired > 0 {
+							zap.L().Info("approval_expiration_job_done", zap.Int("expired", expired))
+						}
+					}
+
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+
+	return nil
+}
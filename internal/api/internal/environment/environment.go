@@ -18,6 +18,68 @@ type Environment struct {
 	HTTPHost    string `cfg:"HTTP_HOST" cfgRequired:"true"`
 	HTTPPort    string `cfg:"PORT" cfgRequired:"true"`
 	DebugPprof  bool   `cfg:"DEBUG_PPROF"`
+	// Accounts
+	AccountsRequireApprovedKYC bool `cfg:"ACCOUNTS_REQUIRE_APPROVED_KYC"`
+	// AccountsDormancyDays is the inactivity period before an account becomes dormant, zero disables it.
+	AccountsDormancyDays               int `cfg:"ACCOUNTS_DORMANCY_DAYS" cfgDefault:"365"`
+	AccountsDormancyJobIntervalMinutes int `cfg:"ACCOUNTS_DORMANCY_JOB_INTERVAL_MINUTES" cfgDefault:"60"`
+	// Interest
+	// InterestAccountID is the system account that pays the savings interest.
+	InterestAccountID string `cfg:"INTEREST_ACCOUNT_ID" cfgDefault:"00000000-0000-0000-0000-000000000002"`
+	// InterestWithholdingTaxRate is the fraction of the gross interest withheld on payout.
+	InterestWithholdingTaxRate float64 `cfg:"INTEREST_WITHHOLDING_TAX_RATE" cfgDefault:"0.15"`
+	// InterestJobIntervalMinutes is how often interest is accrued and capitalized, zero disables it.
+	InterestJobIntervalMinutes int `cfg:"INTEREST_JOB_INTERVAL_MINUTES" cfgDefault:"60"`
+	// Fees
+	// FeesRevenueAccountID is the system account credited with every fee charged.
+	FeesRevenueAccountID string `cfg:"FEES_REVENUE_ACCOUNT_ID" cfgDefault:"00000000-0000-0000-0000-000000000003"`
+	// FeesMaintenanceJobIntervalMinutes is how often monthly maintenance fees are charged, zero disables it.
+	FeesMaintenanceJobIntervalMinutes int `cfg:"FEES_MAINTENANCE_JOB_INTERVAL_MINUTES" cfgDefault:"60"`
+	// Exchange
+	// ExchangeRatesFile is a JSON file quoting the exchange rates, when empty they are read from the database.
+	ExchangeRatesFile string `cfg:"EXCHANGE_RATES_FILE"`
+	// Boletos
+	// BoletosBankCode is the FEBRABAN code of the bank issuing the boletos, leading their barcodes.
+	BoletosBankCode string `cfg:"BOLETOS_BANK_CODE" cfgDefault:"999"`
+	// Transfers
+	// TransfersClearingAccountID is the system account holding the funds of TEDs until the bank confirms them.
+	TransfersClearingAccountID string `cfg:"TRANSFERS_CLEARING_ACCOUNT_ID" cfgDefault:"00000000-0000-0000-0000-000000000004"`
+	// TransfersBankCode is the FEBRABAN code of the bank receiving the remittance files.
+	TransfersBankCode string `cfg:"TRANSFERS_BANK_CODE" cfgDefault:"999"`
+	// TransfersCompanyName and TransfersCompanyDocument identify the company sending the transfers.
+	TransfersCompanyName     string `cfg:"TRANSFERS_COMPANY_NAME" cfgDefault:"Dock Test"`
+	TransfersCompanyDocument string `cfg:"TRANSFERS_COMPANY_DOCUMENT" cfgDefault:"11222333000181"`
+	// TransfersRemittanceJobIntervalMinutes is how often the remittance of the previous day is generated,
+	// zero disables it.
+	TransfersRemittanceJobIntervalMinutes int `cfg:"TRANSFERS_REMITTANCE_JOB_INTERVAL_MINUTES" cfgDefault:"60"`
+	// Transactions
+	// TransactionsReviewSLAMinutes is how long a P2P parked for a manual review waits for an analyst
+	// before it is rejected.
+	TransactionsReviewSLAMinutes int `cfg:"TRANSACTIONS_REVIEW_SLA_MINUTES" cfgDefault:"1440"`
+	// TransactionsReviewJobIntervalMinutes is how often the expired reviews are rejected, zero disables it.
+	TransactionsReviewJobIntervalMinutes int `cfg:"TRANSACTIONS_REVIEW_JOB_INTERVAL_MINUTES" cfgDefault:"5"`
+	// Approvals
+	// ApprovalsTransferThreshold is the amount above which debits, P2Ps and transfers from business accounts
+	// need a second approval, zero disables it.
+	ApprovalsTransferThreshold float64 `cfg:"APPROVALS_TRANSFER_THRESHOLD" cfgDefault:"50000"`
+	// ApprovalsExpirationMinutes is how long a submitted operation waits for its approval.
+	ApprovalsExpirationMinutes int `cfg:"APPROVALS_EXPIRATION_MINUTES" cfgDefault:"1440"`
+	// ApprovalsJobIntervalMinutes is how often the approvals not decided in time expire, zero disables it.
+	ApprovalsJobIntervalMinutes int `cfg:"APPROVALS_JOB_INTERVAL_MINUTES" cfgDefault:"5"`
+	// Auth
+	// AuthBootstrapAPIKey is an API key granted the admin scope without being stored, to create the first API
+	// keys, empty disables it.
+	AuthBootstrapAPIKey string `cfg:"AUTH_BOOTSTRAP_API_KEY"`
+	// AuthJWKSFile is a local JWKS file with the keys verifying the JWT bearer tokens, empty disables them.
+	AuthJWKSFile string `cfg:"AUTH_JWKS_FILE"`
+	// AuthJWTIssuer and AuthJWTAudience are the iss and aud claims the JWT bearer tokens must have, empty
+	// accepts any.
+	AuthJWTIssuer   string `cfg:"AUTH_JWT_ISSUER"`
+	AuthJWTAudience string `cfg:"AUTH_JWT_AUDIENCE"`
+	// Keys
+	// KeysNotifierURL is the provider receiving the verification codes of email and phone keys, it can only
+	// be empty in the local environment, where the codes are not delivered.
+	KeysNotifierURL string `cfg:"KEYS_NOTIFIER_URL"`
 }
 
 func NewEnvironment() (Environment, error) {
@@ -0,0 +1,30 @@
+package accountsh
+
+import (
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/approvals"
+)
+
+// submittedApproval is an operation on the account waiting for a second principal to approve it.
+type submittedApproval struct {
+	ID          string    `json:"approval_id"`
+	Operation   string    `json:"operation"`
+	AccountID   string    `json:"account_id"`
+	Status      string    `json:"status"`
+	SubmittedBy string    `json:"submitted_by"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func newSubmittedApproval(a approvals.Approval) submittedApproval {
+	return submittedApproval{
+		ID:          a.ID.String(),
+		Operation:   string(a.Operation),
+		AccountID:   a.AccountID.String(),
+		Status:      string(a.Status),
+		SubmittedBy: a.SubmittedBy,
+		ExpiresAt:   a.ExpiresAt,
+		CreatedAt:   a.CreatedAt,
+	}
+}
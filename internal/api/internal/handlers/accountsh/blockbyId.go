@@ -1,10 +1,14 @@
 package accountsh
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/approvals"
+	"github.com/dalmarcogd/dock-test/pkg/http/middlewares"
 	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
@@ -14,11 +18,20 @@ type (
 	BlockByIDFunc echo.HandlerFunc
 
 	blockByID struct {
-		ID string `param:"id"`
+		ID     string `param:"id"`
+		Reason string `json:"reason"`
 	}
 )
 
-func NewBlockByIDFunc(svc accounts.Service) BlockByIDFunc {
+func (b blockByID) Validate() error {
+	return validation.ValidateStruct(&b,
+		validation.Field(&b.Reason, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+// NewBlockByIDFunc submits the block of the account, made once a principal other than the one
+// requesting it approves it.
+func NewBlockByIDFunc(svc approvals.Service) BlockByIDFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
 
@@ -34,22 +47,28 @@ func NewBlockByIDFunc(svc accounts.Service) BlockByIDFunc {
 			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
 		}
 
-		account, err := svc.BlockByID(ctx, id)
+		if err := cls.Validate(); err != nil {
+			zapctx.L(ctx).Error("block_by_account_id_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		principal, _ := middlewares.PrincipalFromContext(ctx)
+
+		approval, err := svc.SubmitBlock(ctx, id, accounts.StatusChange{
+			Reason: cls.Reason,
+			Actor:  principal.ID,
+		})
 		if err != nil {
 			zapctx.L(ctx).Error("block_by_account_id_handler_service_error", zap.Error(err))
+			if errors.Is(err, accounts.ErrAccountNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			} else if errors.Is(err, accounts.ErrStatusReasonRequired) ||
+				errors.Is(err, approvals.ErrSubmitterRequired) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
 			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 		}
 
-		return c.JSON(
-			http.StatusOK,
-			createdAccount{
-				ID:             account.ID.String(),
-				Name:           account.Name,
-				Agency:         account.Agency,
-				Number:         account.Number,
-				DocumentNumber: account.DocumentNumber,
-				Status:         string(account.Status),
-			},
-		)
+		return c.JSON(http.StatusAccepted, newSubmittedApproval(approval))
 	}
 }
@@ -1,10 +1,14 @@
 package accountsh
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/approvals"
+	"github.com/dalmarcogd/dock-test/pkg/http/middlewares"
 	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
@@ -14,11 +18,21 @@ type (
 	CloseByIDFunc echo.HandlerFunc
 
 	closeByID struct {
-		ID string `param:"id"`
+		ID                   string `param:"id"`
+		Reason               string `json:"reason"`
+		DestinationAccountID string `json:"destination_account_id"`
 	}
 )
 
-func NewCloseByIDFunc(svc accounts.Service) CloseByIDFunc {
+func (c closeByID) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Reason, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+// NewCloseByIDFunc submits the closure of the account, made once a principal other than the one
+// requesting it approves it.
+func NewCloseByIDFunc(svc approvals.Service) CloseByIDFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
 
@@ -34,22 +48,39 @@ func NewCloseByIDFunc(svc accounts.Service) CloseByIDFunc {
 			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
 		}
 
-		account, err := svc.CloseByID(ctx, id)
+		if err := cls.Validate(); err != nil {
+			zapctx.L(ctx).Error("close_by_account_id_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		var destinationID uuid.NullUUID
+		if cls.DestinationAccountID != "" {
+			destinationID.UUID, err = uuid.Parse(cls.DestinationAccountID)
+			if err != nil {
+				zapctx.L(ctx).Error("close_by_account_id_handler_bind_error", zap.Error(err))
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid destination account id")
+			}
+			destinationID.Valid = true
+		}
+
+		principal, _ := middlewares.PrincipalFromContext(ctx)
+
+		approval, err := svc.SubmitClose(ctx, id, accounts.Closure{
+			Reason:        cls.Reason,
+			Actor:         principal.ID,
+			DestinationID: destinationID,
+		})
 		if err != nil {
-			zapctx.L(ctx).Error("blocse_by_account_id_handler_service_error", zap.Error(err))
+			zapctx.L(ctx).Error("close_by_account_id_handler_service_error", zap.Error(err))
+			if errors.Is(err, accounts.ErrAccountNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			} else if errors.Is(err, accounts.ErrStatusReasonRequired) ||
+				errors.Is(err, approvals.ErrSubmitterRequired) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
 			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 		}
 
-		return c.JSON(
-			http.StatusOK,
-			createdAccount{
-				ID:             account.ID.String(),
-				Name:           account.Name,
-				Agency:         account.Agency,
-				Number:         account.Number,
-				DocumentNumber: account.DocumentNumber,
-				Status:         string(account.Status),
-			},
-		)
+		return c.JSON(http.StatusAccepted, newSubmittedApproval(approval))
 	}
 }
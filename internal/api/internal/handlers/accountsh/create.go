@@ -1,9 +1,13 @@
 package accountsh
 
 import (
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/exchange"
+	"github.com/dalmarcogd/dock-test/internal/products"
 	"github.com/dalmarcogd/dock-test/pkg/zapctx"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/labstack/echo/v4"
@@ -14,23 +18,66 @@ type (
 	CreateAccountFunc echo.HandlerFunc
 
 	createAccount struct {
-		Name           string `json:"name"`
+		Name           string                `json:"name"`
+		DocumentNumber string                `json:"document_number"`
+		Type           string                `json:"type"`
+		Currency       string                `json:"currency"`
+		Holders        []createAccountHolder `json:"holders"`
+	}
+	createAccountHolder struct {
 		DocumentNumber string `json:"document_number"`
+		Role           string `json:"role"`
+	}
+	accountHolder struct {
+		HolderID       string    `json:"holder_id"`
+		Name           string    `json:"name"`
+		DocumentNumber string    `json:"document_number"`
+		Role           string    `json:"role"`
+		CreatedAt      time.Time `json:"created_at"`
 	}
 	createdAccount struct {
-		ID             string `json:"id"`
-		Name           string `json:"name"`
-		Agency         string `json:"agency"`
-		Number         string `json:"number"`
-		DocumentNumber string `json:"document_number"`
-		Status         string `json:"status"`
+		ID             string          `json:"id"`
+		Name           string          `json:"name"`
+		Agency         string          `json:"agency"`
+		Number         string          `json:"number"`
+		DocumentNumber string          `json:"document_number"`
+		Type           string          `json:"type"`
+		Currency       string          `json:"currency"`
+		Status         string          `json:"status"`
+		ClosureReason  string          `json:"closure_reason,omitempty"`
+		ClosedAt       *time.Time      `json:"closed_at,omitempty"`
+		Restrictions   []string        `json:"restrictions,omitempty"`
+		Holders        []accountHolder `json:"holders,omitempty"`
+		ParentID       string          `json:"parent_account_id,omitempty"`
 	}
 )
 
 func (c createAccount) Validate() error {
 	return validation.ValidateStruct(&c,
 		validation.Field(&c.Name, validation.Required, validation.Length(1, 100)),
-		validation.Field(&c.DocumentNumber, validation.Required, validation.Length(11, 14)),
+		validation.Field(&c.DocumentNumber, validation.Required, validation.Length(11, 18)),
+		validation.Field(
+			&c.Type,
+			validation.In(
+				string(products.CheckingType),
+				string(products.SavingsType),
+				string(products.PaymentType),
+				string(products.BusinessType),
+			),
+		),
+		validation.Field(&c.Currency, validation.Length(3, 3)),
+		validation.Field(&c.Holders),
+	)
+}
+
+func (c createAccountHolder) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.DocumentNumber, validation.Required, validation.Length(11, 18)),
+		validation.Field(
+			&c.Role,
+			validation.Required,
+			validation.In(string(accounts.CoOwnerHolderRole), string(accounts.OperatorHolderRole)),
+		),
 	)
 }
 
@@ -49,12 +96,36 @@ func NewCreateAccountFunc(svc accounts.Service) CreateAccountFunc {
 			return err
 		}
 
+		coHolders := make([]accounts.AccountHolder, len(acc.Holders))
+		for i, holder := range acc.Holders {
+			coHolders[i] = accounts.AccountHolder{
+				DocumentNumber: holder.DocumentNumber,
+				Role:           accounts.HolderRole(holder.Role),
+			}
+		}
+
 		account, err := svc.Create(ctx, accounts.Account{
 			Name:           acc.Name,
 			DocumentNumber: acc.DocumentNumber,
+			Type:           products.Type(acc.Type),
+			Currency:       exchange.Currency(acc.Currency),
+			Holders:        coHolders,
 		})
 		if err != nil {
 			zapctx.L(ctx).Error("create_account_handler_service_error", zap.Error(err))
+			if errors.Is(err, accounts.ErrAccountHolderNotFound) {
+				return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			} else if errors.Is(err, accounts.ErrAccountHolderKYCNotApproved) {
+				return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+			} else if errors.Is(err, accounts.ErrInvalidAccountType) ||
+				errors.Is(err, accounts.ErrAccountTypeNotAllowed) ||
+				errors.Is(err, accounts.ErrInvalidAccountCurrency) ||
+				errors.Is(err, accounts.ErrInvalidHolderRole) ||
+				errors.Is(err, accounts.ErrDuplicatedAccountHolder) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			} else if errors.Is(err, accounts.ErrAccountNumberUnavailable) {
+				return echo.NewHTTPError(http.StatusServiceUnavailable, err.Error())
+			}
 			return err
 		}
 
@@ -66,8 +137,40 @@ func NewCreateAccountFunc(svc accounts.Service) CreateAccountFunc {
 				Agency:         account.Agency,
 				Number:         account.Number,
 				DocumentNumber: account.DocumentNumber,
+				Type:           string(account.Type),
+				Currency:       string(account.Currency),
 				Status:         string(account.Status),
+				Holders:        accountHolderValues(account.Holders),
 			},
 		)
 	}
 }
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func restrictionValues(restrictions []accounts.Restriction) []string {
+	values := make([]string, len(restrictions))
+	for i, r := range restrictions {
+		values[i] = string(r)
+	}
+	return values
+}
+
+func accountHolderValues(accountHolders []accounts.AccountHolder) []accountHolder {
+	values := make([]accountHolder, len(accountHolders))
+	for i, h := range accountHolders {
+		values[i] = accountHolder{
+			HolderID:       h.HolderID.String(),
+			Name:           h.Name,
+			DocumentNumber: h.DocumentNumber,
+			Role:           string(h.Role),
+			CreatedAt:      h.CreatedAt,
+		}
+	}
+	return values
+}
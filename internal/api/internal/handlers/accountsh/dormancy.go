@@ -0,0 +1,116 @@
+package accountsh
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	ListDormancyCandidatesFunc echo.HandlerFunc
+
+	listDormancyCandidates struct {
+		WithinDays int `query:"within_days"`
+		Page       int `query:"page"`
+		Size       int `query:"size"`
+	}
+
+	dormancyCandidate struct {
+		createdAccount
+		LastMovementAt time.Time `json:"last_movement_at"`
+		DormantAt      time.Time `json:"dormant_at"`
+	}
+
+	listedDormancyCandidates struct {
+		Pagination pagination          `json:"pagination"`
+		Accounts   []dormancyCandidate `json:"accounts"`
+	}
+)
+
+func (l listDormancyCandidates) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(&l.WithinDays, validation.Required, validation.Min(1)),
+		validation.Field(&l.Size, validation.Max(100)),
+	)
+}
+
+func NewListDormancyCandidatesFunc(svc accounts.Service) ListDormancyCandidatesFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var ldc listDormancyCandidates
+		if err := c.Bind(&ldc); err != nil {
+			zapctx.L(ctx).Error("list_dormancy_candidates_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		if err := ldc.Validate(); err != nil {
+			zapctx.L(ctx).Error("list_dormancy_candidates_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		if ldc.Page == 0 {
+			ldc.Page = 1
+		}
+
+		if ldc.Size == 0 {
+			ldc.Size = 20
+		}
+
+		total, candidates, err := svc.ListDormancyCandidates(ctx, accounts.DormancyFilter{
+			Within: time.Duration(ldc.WithinDays) * 24 * time.Hour,
+			Page:   ldc.Page,
+			Size:   ldc.Size,
+		})
+		if err != nil {
+			zapctx.L(ctx).Error("list_dormancy_candidates_handler_service_error", zap.Error(err))
+			if errors.Is(err, accounts.ErrInvalidDormancyWindow) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			} else if errors.Is(err, accounts.ErrDormancyDisabled) {
+				return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+			}
+			return err
+		}
+
+		totalPages := total / ldc.Size
+		if (total % ldc.Size) != 0 {
+			totalPages++
+		}
+
+		listed := listedDormancyCandidates{
+			Pagination: pagination{
+				Page:        ldc.Page,
+				Size:        ldc.Size,
+				TotalItems:  total,
+				TotalPages:  totalPages,
+				TotalInPage: len(candidates),
+			},
+			Accounts: make([]dormancyCandidate, len(candidates)),
+		}
+		for i, candidate := range candidates {
+			account := candidate.Account
+			listed.Accounts[i] = dormancyCandidate{
+				createdAccount: createdAccount{
+					ID:             account.ID.String(),
+					Name:           account.Name,
+					Agency:         account.Agency,
+					Number:         account.Number,
+					DocumentNumber: account.DocumentNumber,
+					Type:           string(account.Type),
+					Currency:       string(account.Currency),
+					Status:         string(account.Status),
+				},
+				LastMovementAt: candidate.LastMovementAt,
+				DormantAt:      candidate.DormantAt,
+			}
+		}
+
+		return c.JSON(http.StatusOK, listed)
+	}
+}
@@ -1,9 +1,12 @@
 package accountsh
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/stringers"
+	"github.com/dalmarcogd/dock-test/internal/policies"
 	"github.com/dalmarcogd/dock-test/pkg/zapctx"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -18,7 +21,7 @@ type (
 	}
 )
 
-func NewGetByIDFunc(svc accounts.Service) GetByIDFunc {
+func NewGetByIDFunc(svc accounts.Service, ps policies.Service) GetByIDFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
 
@@ -34,6 +37,14 @@ func NewGetByIDFunc(svc accounts.Service) GetByIDFunc {
 			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
 		}
 
+		if err := ps.AuthorizeAccount(ctx, id, policies.HolderAccess); err != nil {
+			zapctx.L(ctx).Error("get_by_account_id_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
 		account, err := svc.GetByID(ctx, id)
 		if err != nil {
 			zapctx.L(ctx).Error("get_by_account_id_handler_service_error", zap.Error(err))
@@ -48,7 +59,13 @@ func NewGetByIDFunc(svc accounts.Service) GetByIDFunc {
 				Agency:         account.Agency,
 				Number:         account.Number,
 				DocumentNumber: account.DocumentNumber,
+				Type:           string(account.Type),
+				Currency:       string(account.Currency),
 				Status:         string(account.Status),
+				ClosureReason:  account.ClosureReason,
+				ClosedAt:       timeOrNil(account.ClosedAt),
+				Restrictions:   restrictionValues(account.Restrictions),
+				ParentID:       stringers.UUIDEmpty(account.ParentID.UUID),
 			},
 		)
 	}
@@ -0,0 +1,66 @@
+package accountsh
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	ListAccountHoldersFunc echo.HandlerFunc
+
+	listAccountHolders struct {
+		ID string `param:"id"`
+	}
+
+	listedAccountHolders struct {
+		AccountID string          `json:"account_id"`
+		Holders   []accountHolder `json:"holders"`
+	}
+)
+
+func NewListAccountHoldersFunc(svc accounts.Service, ps policies.Service) ListAccountHoldersFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var lah listAccountHolders
+		if err := c.Bind(&lah); err != nil {
+			zapctx.L(ctx).Error("list_account_holders_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(lah.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("list_account_holders_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		if err := ps.AuthorizeAccount(ctx, id, policies.HolderAccess); err != nil {
+			zapctx.L(ctx).Error("list_account_holders_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		accountHolders, err := svc.ListHolders(ctx, id)
+		if err != nil {
+			zapctx.L(ctx).Error("list_account_holders_handler_service_error", zap.Error(err))
+			if errors.Is(err, accounts.ErrAccountNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(http.StatusOK, listedAccountHolders{
+			AccountID: id.String(),
+			Holders:   accountHolderValues(accountHolders),
+		})
+	}
+}
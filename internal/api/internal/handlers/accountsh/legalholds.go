@@ -0,0 +1,216 @@
+package accountsh
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/pkg/http/middlewares"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	CreateLegalHoldFunc  echo.HandlerFunc
+	ListLegalHoldsFunc   echo.HandlerFunc
+	ReleaseLegalHoldFunc echo.HandlerFunc
+
+	createLegalHold struct {
+		AccountID  string  `param:"id"`
+		Amount     float64 `json:"amount"`
+		CourtOrder string  `json:"court_order"`
+		Reason     string  `json:"reason"`
+	}
+
+	listLegalHolds struct {
+		AccountID string `param:"id"`
+	}
+
+	releaseLegalHold struct {
+		AccountID string `param:"id"`
+		HoldID    string `param:"holdID"`
+		Reason    string `json:"reason"`
+	}
+
+	legalHold struct {
+		ID            string     `json:"id"`
+		AccountID     string     `json:"account_id"`
+		Amount        float64    `json:"amount"`
+		CourtOrder    string     `json:"court_order"`
+		Reason        string     `json:"reason"`
+		Actor         string     `json:"actor,omitempty"`
+		Status        string     `json:"status"`
+		CreatedAt     time.Time  `json:"created_at"`
+		ReleasedAt    *time.Time `json:"released_at,omitempty"`
+		ReleaseReason string     `json:"release_reason,omitempty"`
+		ReleasedBy    string     `json:"released_by,omitempty"`
+	}
+
+	listedLegalHolds struct {
+		AccountID string      `json:"account_id"`
+		Holds     []legalHold `json:"holds"`
+	}
+)
+
+func (c createLegalHold) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Amount, validation.Required, validation.Min(0.01)),
+		validation.Field(&c.CourtOrder, validation.Required, validation.Length(1, 100)),
+		validation.Field(&c.Reason, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+func (r releaseLegalHold) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Reason, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+func NewCreateLegalHoldFunc(svc accounts.Service) CreateLegalHoldFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var clh createLegalHold
+		if err := c.Bind(&clh); err != nil {
+			zapctx.L(ctx).Error("create_legal_hold_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(clh.AccountID)
+		if err != nil {
+			zapctx.L(ctx).Error("create_legal_hold_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		if err := clh.Validate(); err != nil {
+			zapctx.L(ctx).Error("create_legal_hold_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		principal, _ := middlewares.PrincipalFromContext(ctx)
+
+		hold, err := svc.CreateLegalHold(ctx, accounts.LegalHold{
+			AccountID:  id,
+			Amount:     clh.Amount,
+			CourtOrder: clh.CourtOrder,
+			Reason:     clh.Reason,
+			Actor:      principal.ID,
+		})
+		if err != nil {
+			zapctx.L(ctx).Error("create_legal_hold_handler_service_error", zap.Error(err))
+			if errors.Is(err, accounts.ErrAccountNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			} else if errors.Is(err, accounts.ErrAccountClosed) {
+				return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+			} else if errors.Is(err, accounts.ErrInvalidLegalHoldAmount) ||
+				errors.Is(err, accounts.ErrStatusReasonRequired) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(http.StatusCreated, newLegalHold(hold))
+	}
+}
+
+func NewListLegalHoldsFunc(svc accounts.Service) ListLegalHoldsFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var llh listLegalHolds
+		if err := c.Bind(&llh); err != nil {
+			zapctx.L(ctx).Error("list_legal_holds_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(llh.AccountID)
+		if err != nil {
+			zapctx.L(ctx).Error("list_legal_holds_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		holds, err := svc.ListLegalHolds(ctx, id)
+		if err != nil {
+			zapctx.L(ctx).Error("list_legal_holds_handler_service_error", zap.Error(err))
+			if errors.Is(err, accounts.ErrAccountNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		listed := listedLegalHolds{
+			AccountID: id.String(),
+			Holds:     make([]legalHold, len(holds)),
+		}
+		for i, hold := range holds {
+			listed.Holds[i] = newLegalHold(hold)
+		}
+
+		return c.JSON(http.StatusOK, listed)
+	}
+}
+
+func NewReleaseLegalHoldFunc(svc accounts.Service) ReleaseLegalHoldFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var rlh releaseLegalHold
+		if err := c.Bind(&rlh); err != nil {
+			zapctx.L(ctx).Error("release_legal_hold_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(rlh.AccountID)
+		if err != nil {
+			zapctx.L(ctx).Error("release_legal_hold_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		holdID, err := uuid.Parse(rlh.HoldID)
+		if err != nil {
+			zapctx.L(ctx).Error("release_legal_hold_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid hold id")
+		}
+
+		if err := rlh.Validate(); err != nil {
+			zapctx.L(ctx).Error("release_legal_hold_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		principal, _ := middlewares.PrincipalFromContext(ctx)
+
+		hold, err := svc.ReleaseLegalHold(ctx, id, holdID, accounts.StatusChange{
+			Reason: rlh.Reason,
+			Actor:  principal.ID,
+		})
+		if err != nil {
+			zapctx.L(ctx).Error("release_legal_hold_handler_service_error", zap.Error(err))
+			if errors.Is(err, accounts.ErrLegalHoldNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(http.StatusOK, newLegalHold(hold))
+	}
+}
+
+func newLegalHold(hold accounts.LegalHold) legalHold {
+	return legalHold{
+		ID:            hold.ID.String(),
+		AccountID:     hold.AccountID.String(),
+		Amount:        hold.Amount,
+		CourtOrder:    hold.CourtOrder,
+		Reason:        hold.Reason,
+		Actor:         hold.Actor,
+		Status:        string(hold.Status),
+		CreatedAt:     hold.CreatedAt,
+		ReleasedAt:    timeOrNil(hold.ReleasedAt),
+		ReleaseReason: hold.ReleaseReason,
+		ReleasedBy:    hold.ReleasedBy,
+	}
+}
@@ -1,10 +1,13 @@
 package accountsh
 
 import (
+	"errors"
 	"net/http"
+	"regexp"
 
 	"github.com/dalmarcogd/dock-test/internal/accounts"
 	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
 )
@@ -14,6 +17,8 @@ type (
 
 	listAccounts struct {
 		DocumentNumer string `query:"document_number"`
+		Agency        string `query:"agency"`
+		Number        string `query:"number"`
 		Sort          int    `query:"sort"`
 		Page          int    `query:"page"`
 		Size          int    `query:"size"`
@@ -34,6 +39,18 @@ type (
 	}
 )
 
+var (
+	agencyRegexp        = regexp.MustCompile(`^[0-9]{4}$`)
+	accountNumberRegexp = regexp.MustCompile(`^[0-9]{6}-[0-9]$`)
+)
+
+func (l listAccounts) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(&l.Agency, validation.Match(agencyRegexp)),
+		validation.Field(&l.Number, validation.Match(accountNumberRegexp)),
+	)
+}
+
 func NewListAccountsFunc(svc accounts.Service) ListAccountsFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
@@ -44,6 +61,11 @@ func NewListAccountsFunc(svc accounts.Service) ListAccountsFunc {
 			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
 		}
 
+		if err := lsa.Validate(); err != nil {
+			zapctx.L(ctx).Error("list_account_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
 		if lsa.Page == 0 {
 			lsa.Page = 1
 		}
@@ -57,9 +79,14 @@ func NewListAccountsFunc(svc accounts.Service) ListAccountsFunc {
 			Page:           lsa.Page,
 			Size:           lsa.Size,
 			DocumentNumber: lsa.DocumentNumer,
+			Agency:         lsa.Agency,
+			Number:         lsa.Number,
 		})
 		if err != nil {
 			zapctx.L(ctx).Error("list_account_handler_service_error", zap.Error(err))
+			if errors.Is(err, accounts.ErrInvalidAccountNumber) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
 			return err
 		}
 
@@ -76,6 +103,8 @@ func NewListAccountsFunc(svc accounts.Service) ListAccountsFunc {
 				Agency:         account.Agency,
 				Number:         account.Number,
 				DocumentNumber: account.DocumentNumber,
+				Type:           string(account.Type),
+				Currency:       string(account.Currency),
 				Status:         string(account.Status),
 			}
 		}
@@ -0,0 +1,168 @@
+package accountsh
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/stringers"
+	"github.com/dalmarcogd/dock-test/internal/balances"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	CreatePocketFunc echo.HandlerFunc
+	ListPocketsFunc  echo.HandlerFunc
+
+	createPocket struct {
+		ID   string `param:"id"`
+		Name string `json:"name"`
+	}
+
+	listPockets struct {
+		ID string `param:"id"`
+	}
+
+	pocket struct {
+		createdAccount
+		CurrentBalance float64 `json:"current_balance"`
+	}
+
+	listedPockets struct {
+		AccountID string   `json:"account_id"`
+		Pockets   []pocket `json:"pockets"`
+	}
+)
+
+func (c createPocket) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Name, validation.Required, validation.Length(1, 100)),
+	)
+}
+
+func NewCreatePocketFunc(svc accounts.Service, ps policies.Service) CreatePocketFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var cp createPocket
+		if err := c.Bind(&cp); err != nil {
+			zapctx.L(ctx).Error("create_pocket_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(cp.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("create_pocket_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		if err := ps.AuthorizeAccount(ctx, id, policies.DebitAccess); err != nil {
+			zapctx.L(ctx).Error("create_pocket_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		if err := cp.Validate(); err != nil {
+			zapctx.L(ctx).Error("create_pocket_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		account, err := svc.CreatePocket(ctx, id, cp.Name)
+		if err != nil {
+			zapctx.L(ctx).Error("create_pocket_handler_service_error", zap.Error(err))
+			if errors.Is(err, accounts.ErrAccountNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			} else if errors.Is(err, accounts.ErrPocketNesting) ||
+				errors.Is(err, accounts.ErrPocketLimitReached) ||
+				errors.Is(err, accounts.ErrAccountInactive) {
+				return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+			} else if errors.Is(err, accounts.ErrPocketNameRequired) ||
+				errors.Is(err, accounts.ErrDuplicatedPocketName) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(http.StatusCreated, pocketAccount(account))
+	}
+}
+
+func NewListPocketsFunc(as accounts.Service, bs balances.Service, ps policies.Service) ListPocketsFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var lp listPockets
+		if err := c.Bind(&lp); err != nil {
+			zapctx.L(ctx).Error("list_pockets_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(lp.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("list_pockets_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		if err := ps.AuthorizeAccount(ctx, id, policies.HolderAccess); err != nil {
+			zapctx.L(ctx).Error("list_pockets_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		pockets, err := as.ListPockets(ctx, id)
+		if err != nil {
+			zapctx.L(ctx).Error("list_pockets_handler_account_service_error", zap.Error(err))
+			if errors.Is(err, accounts.ErrAccountNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		pocketIDs := make([]uuid.UUID, len(pockets))
+		for i, p := range pockets {
+			pocketIDs[i] = p.ID
+		}
+
+		pbs, err := bs.ListByAccountIDs(ctx, pocketIDs)
+		if err != nil {
+			zapctx.L(ctx).Error("list_pockets_handler_balance_service_error", zap.Error(err))
+			return err
+		}
+
+		listed := listedPockets{
+			AccountID: id.String(),
+			Pockets:   make([]pocket, len(pockets)),
+		}
+		for i, p := range pockets {
+			listed.Pockets[i] = pocket{
+				createdAccount: pocketAccount(p),
+				CurrentBalance: pbs[i].CurrentBalance,
+			}
+		}
+
+		return c.JSON(http.StatusOK, listed)
+	}
+}
+
+func pocketAccount(account accounts.Account) createdAccount {
+	return createdAccount{
+		ID:             account.ID.String(),
+		Name:           account.Name,
+		Agency:         account.Agency,
+		Number:         account.Number,
+		DocumentNumber: account.DocumentNumber,
+		Type:           string(account.Type),
+		Currency:       string(account.Currency),
+		Status:         string(account.Status),
+		ParentID:       stringers.UUIDEmpty(account.ParentID.UUID),
+	}
+}
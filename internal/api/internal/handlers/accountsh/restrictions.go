@@ -0,0 +1,102 @@
+package accountsh
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/pkg/http/middlewares"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	SetRestrictionsFunc echo.HandlerFunc
+
+	setRestrictions struct {
+		ID           string   `param:"id"`
+		Restrictions []string `json:"restrictions"`
+		Reason       string   `json:"reason"`
+	}
+)
+
+func (s setRestrictions) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(
+			&s.Restrictions,
+			validation.Each(
+				validation.In(
+					string(accounts.DebitsRestriction),
+					string(accounts.CreditsRestriction),
+					string(accounts.P2POutRestriction),
+				),
+			),
+		),
+		validation.Field(&s.Reason, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+func NewSetRestrictionsFunc(svc accounts.Service) SetRestrictionsFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var str setRestrictions
+		if err := c.Bind(&str); err != nil {
+			zapctx.L(ctx).Error("set_restrictions_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(str.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("set_restrictions_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		if err := str.Validate(); err != nil {
+			zapctx.L(ctx).Error("set_restrictions_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		restrictions := make([]accounts.Restriction, len(str.Restrictions))
+		for i, r := range str.Restrictions {
+			restrictions[i] = accounts.Restriction(r)
+		}
+
+		principal, _ := middlewares.PrincipalFromContext(ctx)
+
+		account, err := svc.SetRestrictions(ctx, id, restrictions, accounts.StatusChange{
+			Reason: str.Reason,
+			Actor:  principal.ID,
+		})
+		if err != nil {
+			zapctx.L(ctx).Error("set_restrictions_handler_service_error", zap.Error(err))
+			if errors.Is(err, accounts.ErrAccountNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			} else if errors.Is(err, accounts.ErrAccountClosed) {
+				return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+			} else if errors.Is(err, accounts.ErrInvalidRestriction) ||
+				errors.Is(err, accounts.ErrStatusReasonRequired) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(
+			http.StatusOK,
+			createdAccount{
+				ID:             account.ID.String(),
+				Name:           account.Name,
+				Agency:         account.Agency,
+				Number:         account.Number,
+				DocumentNumber: account.DocumentNumber,
+				Type:           string(account.Type),
+				Currency:       string(account.Currency),
+				Status:         string(account.Status),
+				Restrictions:   restrictionValues(account.Restrictions),
+			},
+		)
+	}
+}
@@ -0,0 +1,88 @@
+package accountsh
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	ListStatusHistoryFunc echo.HandlerFunc
+
+	listStatusHistory struct {
+		ID string `param:"id"`
+	}
+
+	statusEvent struct {
+		ID         string    `json:"id"`
+		FromStatus string    `json:"from_status"`
+		ToStatus   string    `json:"to_status"`
+		Reason     string    `json:"reason"`
+		Actor      string    `json:"actor,omitempty"`
+		CreatedAt  time.Time `json:"created_at"`
+	}
+
+	listedStatusHistory struct {
+		AccountID string        `json:"account_id"`
+		Events    []statusEvent `json:"events"`
+	}
+)
+
+func NewListStatusHistoryFunc(svc accounts.Service, ps policies.Service) ListStatusHistoryFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var lsh listStatusHistory
+		if err := c.Bind(&lsh); err != nil {
+			zapctx.L(ctx).Error("list_status_history_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(lsh.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("list_status_history_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		if err := ps.AuthorizeAccount(ctx, id, policies.HolderAccess); err != nil {
+			zapctx.L(ctx).Error("list_status_history_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		events, err := svc.ListStatusHistory(ctx, id)
+		if err != nil {
+			zapctx.L(ctx).Error("list_status_history_handler_service_error", zap.Error(err))
+			if errors.Is(err, accounts.ErrAccountNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		listed := listedStatusHistory{
+			AccountID: id.String(),
+			Events:    make([]statusEvent, len(events)),
+		}
+		for i, event := range events {
+			listed.Events[i] = statusEvent{
+				ID:         event.ID.String(),
+				FromStatus: string(event.FromStatus),
+				ToStatus:   string(event.ToStatus),
+				Reason:     event.Reason,
+				Actor:      event.Actor,
+				CreatedAt:  event.CreatedAt,
+			}
+		}
+
+		return c.JSON(http.StatusOK, listed)
+	}
+}
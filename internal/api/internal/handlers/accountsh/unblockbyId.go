@@ -1,10 +1,13 @@
 package accountsh
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/pkg/http/middlewares"
 	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
@@ -14,10 +17,17 @@ type (
 	UnblockByIDFunc echo.HandlerFunc
 
 	unblockByID struct {
-		ID string `param:"id"`
+		ID     string `param:"id"`
+		Reason string `json:"reason"`
 	}
 )
 
+func (u unblockByID) Validate() error {
+	return validation.ValidateStruct(&u,
+		validation.Field(&u.Reason, validation.Required, validation.Length(1, 255)),
+	)
+}
+
 func NewUnblockByIDFunc(svc accounts.Service) UnblockByIDFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
@@ -34,9 +44,22 @@ func NewUnblockByIDFunc(svc accounts.Service) UnblockByIDFunc {
 			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
 		}
 
-		account, err := svc.UnblockByID(ctx, id)
+		if err := cls.Validate(); err != nil {
+			zapctx.L(ctx).Error("unblock_by_account_id_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		principal, _ := middlewares.PrincipalFromContext(ctx)
+
+		account, err := svc.UnblockByID(ctx, id, accounts.StatusChange{
+			Reason: cls.Reason,
+			Actor:  principal.ID,
+		})
 		if err != nil {
 			zapctx.L(ctx).Error("unblock_by_account_id_handler_service_error", zap.Error(err))
+			if errors.Is(err, accounts.ErrStatusReasonRequired) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
 			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 		}
 
@@ -48,6 +71,8 @@ func NewUnblockByIDFunc(svc accounts.Service) UnblockByIDFunc {
 				Agency:         account.Agency,
 				Number:         account.Number,
 				DocumentNumber: account.DocumentNumber,
+				Type:           string(account.Type),
+				Currency:       string(account.Currency),
 				Status:         string(account.Status),
 			},
 		)
@@ -0,0 +1,162 @@
+package apikeysh
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/apikeys"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/pkg/http/middlewares"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	CreateAPIKeyFunc echo.HandlerFunc
+	ListAPIKeysFunc  echo.HandlerFunc
+	RevokeAPIKeyFunc echo.HandlerFunc
+
+	createAPIKey struct {
+		Name   string   `json:"name"`
+		Scopes []string `json:"scopes"`
+	}
+
+	revokeAPIKey struct {
+		ID string `param:"id"`
+	}
+
+	apiKey struct {
+		ID        string     `json:"id"`
+		Name      string     `json:"name"`
+		Prefix    string     `json:"prefix"`
+		Scopes    []string   `json:"scopes"`
+		CreatedBy string     `json:"created_by"`
+		CreatedAt time.Time  `json:"created_at"`
+		RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	}
+
+	// createdAPIKey is the only response with the key, which is not stored.
+	createdAPIKey struct {
+		apiKey
+		Key string `json:"key"`
+	}
+
+	listedAPIKeys struct {
+		APIKeys []apiKey `json:"api_keys"`
+	}
+)
+
+func (c createAPIKey) Validate() error {
+	scopes := make([]interface{}, len(policies.Scopes))
+	for i, s := range policies.Scopes {
+		scopes[i] = s
+	}
+
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&c.Scopes, validation.Each(validation.In(scopes...))),
+	)
+}
+
+func NewCreateAPIKeyFunc(svc apikeys.Service) CreateAPIKeyFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var ca createAPIKey
+		if err := c.Bind(&ca); err != nil {
+			zapctx.L(ctx).Error("create_api_key_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		if err := ca.Validate(); err != nil {
+			zapctx.L(ctx).Error("create_api_key_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		principal, _ := middlewares.PrincipalFromContext(ctx)
+
+		created, err := svc.Create(ctx, apikeys.APIKey{
+			Name:      ca.Name,
+			Scopes:    ca.Scopes,
+			CreatedBy: principal.ID,
+		})
+		if err != nil {
+			zapctx.L(ctx).Error("create_api_key_handler_service_error", zap.Error(err))
+			if errors.Is(err, apikeys.ErrNameRequired) || errors.Is(err, apikeys.ErrInvalidScope) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(http.StatusCreated, createdAPIKey{apiKey: newAPIKey(created), Key: created.Key})
+	}
+}
+
+func NewListAPIKeysFunc(svc apikeys.Service) ListAPIKeysFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		keys, err := svc.List(ctx)
+		if err != nil {
+			zapctx.L(ctx).Error("list_api_keys_handler_service_error", zap.Error(err))
+			return err
+		}
+
+		listed := listedAPIKeys{APIKeys: make([]apiKey, len(keys))}
+		for i, k := range keys {
+			listed.APIKeys[i] = newAPIKey(k)
+		}
+
+		return c.JSON(http.StatusOK, listed)
+	}
+}
+
+func NewRevokeAPIKeyFunc(svc apikeys.Service) RevokeAPIKeyFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var ra revokeAPIKey
+		if err := c.Bind(&ra); err != nil {
+			zapctx.L(ctx).Error("revoke_api_key_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(ra.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("revoke_api_key_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		if err := svc.Revoke(ctx, id); err != nil {
+			zapctx.L(ctx).Error("revoke_api_key_handler_service_error", zap.Error(err))
+			if errors.Is(err, apikeys.ErrAPIKeyNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+func newAPIKey(k apikeys.APIKey) apiKey {
+	ak := apiKey{
+		ID:        k.ID.String(),
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Scopes:    k.Scopes,
+		CreatedBy: k.CreatedBy,
+		CreatedAt: k.CreatedAt,
+	}
+
+	if !k.RevokedAt.IsZero() {
+		revokedAt := k.RevokedAt
+		ak.RevokedAt = &revokedAt
+	}
+
+	return ak
+}
@@ -0,0 +1,150 @@
+package approvalsh
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/approvals"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/internal/transactions"
+	"github.com/dalmarcogd/dock-test/internal/transfers"
+	"github.com/dalmarcogd/dock-test/pkg/http/middlewares"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	ApproveFunc echo.HandlerFunc
+	RejectFunc  echo.HandlerFunc
+
+	decide struct {
+		ID     string `param:"id"`
+		Reason string `json:"reason"`
+	}
+)
+
+func (d decide) Validate() error {
+	return validation.ValidateStruct(&d,
+		validation.Field(&d.Reason, validation.Length(0, 255)),
+	)
+}
+
+// NewApproveFunc approves the operation and makes it. When it cannot be made the approval is
+// FAILED and the error of the operation is answered.
+func NewApproveFunc(svc approvals.Service) ApproveFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		id, decision, err := bindDecision(c, "approve")
+		if err != nil {
+			return err
+		}
+
+		approved, err := svc.Approve(ctx, id, decision)
+		if err != nil {
+			zapctx.L(ctx).Error("approve_handler_service_error", zap.Error(err))
+			if errors.Is(err, approvals.ErrApprovalNotFound) ||
+				errors.Is(err, accounts.ErrAccountNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			} else if errors.Is(err, approvals.ErrApprovalNotPending) ||
+				errors.Is(err, approvals.ErrApprovalExpired) {
+				return echo.NewHTTPError(http.StatusConflict, err.Error())
+			} else if errors.Is(err, approvals.ErrSameApprover) ||
+				errors.Is(err, approvals.ErrApproverNotAllowed) ||
+				errors.Is(err, policies.ErrForbidden) ||
+				errors.Is(err, transactions.ErrHolderNotAllowedToDebit) ||
+				errors.Is(err, transactions.ErrTransactionDenied) ||
+				errors.Is(err, transactions.ErrTransactionUnderReview) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			} else if errors.Is(err, transactions.ErrBalanceInsufficientFunds) ||
+				errors.Is(err, transactions.ErrInsufficientDailyLimit) ||
+				errors.Is(err, transactions.ErrAccountInactive) ||
+				errors.Is(err, transactions.ErrAccountDebitsBlocked) ||
+				errors.Is(err, transactions.ErrAccountCreditsBlocked) ||
+				errors.Is(err, transactions.ErrAccountP2POutBlocked) ||
+				errors.Is(err, transactions.ErrP2PNotAllowed) ||
+				errors.Is(err, transactions.ErrMaxBalanceExceeded) ||
+				errors.Is(err, transactions.ErrPocketExternalTransaction) ||
+				errors.Is(err, transactions.ErrAccountHasPendingReviews) ||
+				errors.Is(err, accounts.ErrAccountBalanceNotZero) ||
+				errors.Is(err, accounts.ErrAccountHasActiveHolds) ||
+				errors.Is(err, accounts.ErrAccountHasOpenPockets) ||
+				errors.Is(err, accounts.ErrAccountHasPendingTransfers) {
+				return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+			} else if errors.Is(err, approvals.ErrApproverRequired) ||
+				errors.Is(err, transfers.ErrInvalidTransferAmount) ||
+				errors.Is(err, transfers.ErrInvalidBeneficiary) ||
+				errors.Is(err, transactions.ErrFromAccountToAccountShouldBeDifferent) ||
+				errors.Is(err, transactions.ErrCurrencyMismatch) ||
+				errors.Is(err, transactions.ErrExchangeRateNotFound) ||
+				errors.Is(err, accounts.ErrInvalidClosureDestination) ||
+				errors.Is(err, accounts.ErrClosureCurrencyMismatch) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(http.StatusOK, newApproval(approved))
+	}
+}
+
+func NewRejectFunc(svc approvals.Service) RejectFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		id, decision, err := bindDecision(c, "reject")
+		if err != nil {
+			return err
+		}
+
+		rejected, err := svc.Reject(ctx, id, decision)
+		if err != nil {
+			zapctx.L(ctx).Error("reject_handler_service_error", zap.Error(err))
+			if errors.Is(err, approvals.ErrApprovalNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			} else if errors.Is(err, approvals.ErrApprovalNotPending) ||
+				errors.Is(err, approvals.ErrApprovalExpired) {
+				return echo.NewHTTPError(http.StatusConflict, err.Error())
+			} else if errors.Is(err, approvals.ErrApproverNotAllowed) ||
+				errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			} else if errors.Is(err, approvals.ErrApproverRequired) ||
+				errors.Is(err, approvals.ErrReasonRequired) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(http.StatusOK, newApproval(rejected))
+	}
+}
+
+// bindDecision reads the approval and the decision of the request, logging errors under the handler name.
+func bindDecision(c echo.Context, handler string) (uuid.UUID, approvals.Decision, error) {
+	ctx := c.Request().Context()
+
+	var d decide
+	if err := c.Bind(&d); err != nil {
+		zapctx.L(ctx).Error(handler+"_handler_bind_error", zap.Error(err))
+		return uuid.Nil, approvals.Decision{}, echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	id, err := uuid.Parse(d.ID)
+	if err != nil {
+		zapctx.L(ctx).Error(handler+"_handler_bind_error", zap.Error(err))
+		return uuid.Nil, approvals.Decision{}, echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+	}
+
+	if err := d.Validate(); err != nil {
+		zapctx.L(ctx).Error(handler+"_handler_validation_error", zap.Error(err))
+		return uuid.Nil, approvals.Decision{}, echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	principal, _ := middlewares.PrincipalFromContext(ctx)
+
+	return id, approvals.Decision{Reason: d.Reason, Actor: principal.ID}, nil
+}
@@ -0,0 +1,230 @@
+package approvalsh
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/stringers"
+	"github.com/dalmarcogd/dock-test/internal/approvals"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	ListApprovalsFunc echo.HandlerFunc
+	GetApprovalFunc   echo.HandlerFunc
+
+	listApprovals struct {
+		Status    string `query:"status"`
+		Operation string `query:"operation"`
+		AccountID string `query:"account_id"`
+		Page      int    `query:"page"`
+		Size      int    `query:"size"`
+	}
+
+	getApproval struct {
+		ID string `param:"id"`
+	}
+
+	event struct {
+		Action    string    `json:"action"`
+		Actor     string    `json:"actor"`
+		Reason    string    `json:"reason,omitempty"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	approval struct {
+		ID          string          `json:"id"`
+		Operation   string          `json:"operation"`
+		AccountID   string          `json:"account_id"`
+		Payload     json.RawMessage `json:"payload"`
+		Status      string          `json:"status"`
+		SubmittedBy string          `json:"submitted_by"`
+		ExpiresAt   time.Time       `json:"expires_at"`
+		DecidedBy   string          `json:"decided_by,omitempty"`
+		DecidedAt   *time.Time      `json:"decided_at,omitempty"`
+		ResultID    string          `json:"result_id,omitempty"`
+		Error       string          `json:"error,omitempty"`
+		CreatedAt   time.Time       `json:"created_at"`
+		Events      []event         `json:"events,omitempty"`
+	}
+
+	pagination struct {
+		Page        int `json:"page"`
+		Size        int `json:"size"`
+		TotalItems  int `json:"total_items"`
+		TotalPages  int `json:"total_pages"`
+		TotalInPage int `json:"total_in_page"`
+	}
+
+	listedApprovals struct {
+		Pagination pagination `json:"pagination"`
+		Approvals  []approval `json:"approvals"`
+	}
+)
+
+func (l listApprovals) Validate() error {
+	statuses := make([]interface{}, len(approvals.Statuses))
+	for i, s := range approvals.Statuses {
+		statuses[i] = string(s)
+	}
+
+	operations := make([]interface{}, len(approvals.Operations))
+	for i, o := range approvals.Operations {
+		operations[i] = string(o)
+	}
+
+	return validation.ValidateStruct(&l,
+		validation.Field(&l.Status, validation.In(statuses...)),
+		validation.Field(&l.Operation, validation.In(operations...)),
+		validation.Field(&l.Page, validation.Min(0)),
+		validation.Field(&l.Size, validation.Min(0), validation.Max(100)),
+	)
+}
+
+func NewListApprovalsFunc(svc approvals.Service) ListApprovalsFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var la listApprovals
+		if err := c.Bind(&la); err != nil {
+			zapctx.L(ctx).Error("list_approvals_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		if err := la.Validate(); err != nil {
+			zapctx.L(ctx).Error("list_approvals_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		var accountID uuid.NullUUID
+		if la.AccountID != "" {
+			id, err := uuid.Parse(la.AccountID)
+			if err != nil {
+				zapctx.L(ctx).Error("list_approvals_handler_bind_error", zap.Error(err))
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid account_id")
+			}
+			accountID = uuid.NullUUID{UUID: id, Valid: true}
+		}
+
+		if la.Page == 0 {
+			la.Page = 1
+		}
+
+		if la.Size == 0 {
+			la.Size = 20
+		}
+
+		var operations []approvals.Operation
+		if la.Operation != "" {
+			operations = []approvals.Operation{approvals.Operation(la.Operation)}
+		}
+
+		total, found, err := svc.List(ctx, approvals.Filter{
+			Status:     approvals.Status(la.Status),
+			Operations: operations,
+			AccountID:  accountID,
+			Page:       la.Page,
+			Size:       la.Size,
+		})
+		if err != nil {
+			zapctx.L(ctx).Error("list_approvals_handler_service_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		totalPages := total / la.Size
+		if (total % la.Size) != 0 {
+			totalPages++
+		}
+
+		listed := listedApprovals{
+			Pagination: pagination{
+				Page:        la.Page,
+				Size:        la.Size,
+				TotalItems:  total,
+				TotalPages:  totalPages,
+				TotalInPage: len(found),
+			},
+			Approvals: make([]approval, len(found)),
+		}
+		for i, a := range found {
+			listed.Approvals[i] = newApproval(a)
+		}
+
+		return c.JSON(http.StatusOK, listed)
+	}
+}
+
+func NewGetApprovalFunc(svc approvals.Service) GetApprovalFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var ga getApproval
+		if err := c.Bind(&ga); err != nil {
+			zapctx.L(ctx).Error("get_approval_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(ga.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("get_approval_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		found, err := svc.GetByID(ctx, id)
+		if err != nil {
+			zapctx.L(ctx).Error("get_approval_handler_service_error", zap.Error(err))
+			if errors.Is(err, approvals.ErrApprovalNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			} else if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(http.StatusOK, newApproval(found))
+	}
+}
+
+func newApproval(a approvals.Approval) approval {
+	var decidedAt *time.Time
+	if !a.DecidedAt.IsZero() {
+		decidedAt = &a.DecidedAt
+	}
+
+	response := approval{
+		ID:          a.ID.String(),
+		Operation:   string(a.Operation),
+		AccountID:   a.AccountID.String(),
+		Payload:     a.Payload,
+		Status:      string(a.Status),
+		SubmittedBy: a.SubmittedBy,
+		ExpiresAt:   a.ExpiresAt,
+		DecidedBy:   a.DecidedBy,
+		DecidedAt:   decidedAt,
+		ResultID:    stringers.UUIDEmpty(a.ResultID.UUID),
+		Error:       a.Error,
+		CreatedAt:   a.CreatedAt,
+		Events:      make([]event, len(a.Events)),
+	}
+
+	for i, e := range a.Events {
+		response.Events[i] = event{
+			Action:    string(e.Action),
+			Actor:     e.Actor,
+			Reason:    e.Reason,
+			CreatedAt: e.CreatedAt,
+		}
+	}
+
+	return response
+}
@@ -1,9 +1,11 @@
 package balancesh
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/dalmarcogd/dock-test/internal/balances"
+	"github.com/dalmarcogd/dock-test/internal/policies"
 	"github.com/dalmarcogd/dock-test/pkg/zapctx"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -17,12 +19,14 @@ type (
 		ID string `param:"id"`
 	}
 	accountBalance struct {
-		AccountID      string  `json:"account_id"`
-		CurrentBalance float64 `json:"current_balance"`
+		AccountID           string  `json:"account_id"`
+		Currency            string  `json:"currency"`
+		CurrentBalance      float64 `json:"current_balance"`
+		ConsolidatedBalance float64 `json:"consolidated_balance"`
 	}
 )
 
-func NewGetBalanceByAccountIDFunc(svc balances.Service) GetBalanceByAccountIDFunc {
+func NewGetBalanceByAccountIDFunc(svc balances.Service, ps policies.Service) GetBalanceByAccountIDFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
 
@@ -38,7 +42,15 @@ func NewGetBalanceByAccountIDFunc(svc balances.Service) GetBalanceByAccountIDFun
 			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
 		}
 
-		accb, err := svc.GetByAccountID(ctx, id)
+		if err := ps.AuthorizeAccount(ctx, id, policies.HolderAccess); err != nil {
+			zapctx.L(ctx).Error("get_balance_by_account_id_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		accb, err := svc.GetConsolidatedByAccountID(ctx, id)
 		if err != nil {
 			zapctx.L(ctx).Error("get_balance_by_account_id_handler_service_error", zap.Error(err))
 			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
@@ -47,8 +59,10 @@ func NewGetBalanceByAccountIDFunc(svc balances.Service) GetBalanceByAccountIDFun
 		return c.JSON(
 			http.StatusOK,
 			accountBalance{
-				AccountID:      accb.AccountID.String(),
-				CurrentBalance: accb.CurrentBalance,
+				AccountID:           accb.AccountID.String(),
+				Currency:            string(accb.Currency),
+				CurrentBalance:      accb.CurrentBalance,
+				ConsolidatedBalance: accb.ConsolidatedBalance,
 			},
 		)
 	}
@@ -0,0 +1,42 @@
+package batchesh
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dalmarcogd/dock-test/internal/batches"
+	"github.com/dalmarcogd/dock-test/pkg/http/middlewares"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// maxPain001Size bounds the pain.001 files read from request bodies.
+const maxPain001Size = 10 << 20
+
+type SubmitPain001Func echo.HandlerFunc
+
+// NewSubmitPain001Func creates a transfer for each payment of the ISO 20022 pain.001 message sent as
+// the XML request body, responding with the pain.002 status report of the payments. Each transfer is
+// requested by the principal of the request, which must be allowed to debit the debtor account.
+func NewSubmitPain001Func(svc batches.Service) SubmitPain001Func {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		principal, _ := middlewares.PrincipalFromContext(ctx)
+
+		body := http.MaxBytesReader(c.Response(), c.Request().Body, maxPain001Size)
+		report, err := svc.Submit(ctx, principal.ID, body)
+		if err != nil {
+			zapctx.L(ctx).Error("submit_pain001_handler_service_error", zap.Error(err))
+			if errors.Is(err, batches.ErrInvalidPain001) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			} else if errors.Is(err, batches.ErrBatchAlreadySubmitted) {
+				return echo.NewHTTPError(http.StatusConflict, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.Blob(http.StatusOK, echo.MIMEApplicationXMLCharsetUTF8, report)
+	}
+}
@@ -0,0 +1,162 @@
+package boletosh
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/stringers"
+	"github.com/dalmarcogd/dock-test/internal/boletos"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+const dateLayout = "2006-01-02"
+
+type (
+	CreateBoletoFunc echo.HandlerFunc
+
+	createBoleto struct {
+		ID                  string  `param:"id"`
+		Amount              float64 `json:"amount"`
+		DueDate             string  `json:"due_date"`
+		FineRate            float64 `json:"fine_rate"`
+		MonthlyInterestRate float64 `json:"monthly_interest_rate"`
+		PayerName           string  `json:"payer_name"`
+		PayerDocumentNumber string  `json:"payer_document_number"`
+		Description         string  `json:"description"`
+	}
+
+	boleto struct {
+		ID                  string    `json:"id"`
+		AccountID           string    `json:"account_id"`
+		OurNumber           string    `json:"our_number"`
+		Amount              float64   `json:"amount"`
+		AmountDue           float64   `json:"amount_due"`
+		DueDate             string    `json:"due_date"`
+		FineRate            float64   `json:"fine_rate"`
+		MonthlyInterestRate float64   `json:"monthly_interest_rate"`
+		PayerName           string    `json:"payer_name"`
+		PayerDocumentNumber string    `json:"payer_document_number"`
+		Description         string    `json:"description"`
+		Barcode             string    `json:"barcode"`
+		DigitableLine       string    `json:"digitable_line"`
+		Status              string    `json:"status"`
+		PaidAmount          float64   `json:"paid_amount,omitempty"`
+		PaidAt              string    `json:"paid_at,omitempty"`
+		TransactionID       string    `json:"transaction_id,omitempty"`
+		CreatedAt           time.Time `json:"created_at"`
+	}
+)
+
+func (c createBoleto) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Amount, validation.Required, validation.Min(0.01)),
+		validation.Field(&c.DueDate, validation.Required, validation.Date(dateLayout)),
+		validation.Field(&c.FineRate, validation.Min(0.0)),
+		validation.Field(&c.MonthlyInterestRate, validation.Min(0.0)),
+		validation.Field(&c.PayerName, validation.Required, validation.Length(1, 255)),
+		validation.Field(&c.PayerDocumentNumber, validation.Required),
+		validation.Field(&c.Description, validation.Length(0, 100)),
+	)
+}
+
+func NewCreateBoletoFunc(svc boletos.Service, ps policies.Service) CreateBoletoFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var cb createBoleto
+		if err := c.Bind(&cb); err != nil {
+			zapctx.L(ctx).Error("create_boleto_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(cb.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("create_boleto_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		if err := ps.AuthorizeAccount(ctx, id, policies.HolderAccess); err != nil {
+			zapctx.L(ctx).Error("create_boleto_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		if err := cb.Validate(); err != nil {
+			zapctx.L(ctx).Error("create_boleto_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		dueDate, err := time.Parse(dateLayout, cb.DueDate)
+		if err != nil {
+			zapctx.L(ctx).Error("create_boleto_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		created, err := svc.Create(ctx, boletos.Boleto{
+			AccountID:           id,
+			Amount:              cb.Amount,
+			DueDate:             dueDate,
+			FineRate:            cb.FineRate,
+			MonthlyInterestRate: cb.MonthlyInterestRate,
+			PayerName:           cb.PayerName,
+			PayerDocumentNumber: cb.PayerDocumentNumber,
+			Description:         cb.Description,
+		})
+		if err != nil {
+			zapctx.L(ctx).Error("create_boleto_handler_service_error", zap.Error(err))
+			if errors.Is(err, accounts.ErrAccountNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			} else if errors.Is(err, boletos.ErrBoletoAccountNotAllowed) ||
+				errors.Is(err, boletos.ErrBoletoCurrencyNotSupported) {
+				return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+			} else if errors.Is(err, boletos.ErrInvalidBoletoAmount) ||
+				errors.Is(err, boletos.ErrInvalidBoletoDueDate) ||
+				errors.Is(err, boletos.ErrInvalidBoletoFine) ||
+				errors.Is(err, boletos.ErrInvalidBoletoInterest) ||
+				errors.Is(err, boletos.ErrInvalidBoletoPayer) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(http.StatusCreated, newBoleto(created))
+	}
+}
+
+// newBoleto renders the boleto with the amount due if paid today.
+func newBoleto(b boletos.Boleto) boleto {
+	var paidAt string
+	if !b.PaidAt.IsZero() {
+		paidAt = b.PaidAt.Format(dateLayout)
+	}
+
+	return boleto{
+		ID:                  b.ID.String(),
+		AccountID:           b.AccountID.String(),
+		OurNumber:           b.OurNumber,
+		Amount:              b.Amount,
+		AmountDue:           b.AmountDue(time.Now().UTC()),
+		DueDate:             b.DueDate.Format(dateLayout),
+		FineRate:            b.FineRate,
+		MonthlyInterestRate: b.MonthlyInterestRate,
+		PayerName:           b.PayerName,
+		PayerDocumentNumber: b.PayerDocumentNumber,
+		Description:         b.Description,
+		Barcode:             b.Barcode,
+		DigitableLine:       b.DigitableLine,
+		Status:              string(b.Status),
+		PaidAmount:          b.PaidAmount,
+		PaidAt:              paidAt,
+		TransactionID:       stringers.UUIDEmpty(b.TransactionID.UUID),
+		CreatedAt:           b.CreatedAt,
+	}
+}
@@ -0,0 +1,58 @@
+package boletosh
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dalmarcogd/dock-test/internal/boletos"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	GetByIDBoletoFunc echo.HandlerFunc
+
+	getBoleto struct {
+		ID string `param:"id"`
+	}
+)
+
+func NewGetByIDBoletoFunc(svc boletos.Service, ps policies.Service) GetByIDBoletoFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var gb getBoleto
+		if err := c.Bind(&gb); err != nil {
+			zapctx.L(ctx).Error("get_boleto_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(gb.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("get_boleto_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		b, err := svc.GetByID(ctx, id)
+		if err != nil {
+			zapctx.L(ctx).Error("get_boleto_handler_service_error", zap.Error(err))
+			if errors.Is(err, boletos.ErrBoletoNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		if err := ps.AuthorizeAccount(ctx, b.AccountID, policies.HolderAccess); err != nil {
+			zapctx.L(ctx).Error("get_boleto_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		return c.JSON(http.StatusOK, newBoleto(b))
+	}
+}
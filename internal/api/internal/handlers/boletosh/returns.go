@@ -0,0 +1,80 @@
+package boletosh
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/stringers"
+	"github.com/dalmarcogd/dock-test/internal/boletos"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// maxReturnFileSize bounds the return files read from request bodies.
+const maxReturnFileSize = 10 << 20
+
+type (
+	ImportReturnFileFunc echo.HandlerFunc
+
+	returnRecord struct {
+		Line          int     `json:"line"`
+		OurNumber     string  `json:"our_number"`
+		Occurrence    string  `json:"occurrence"`
+		PaidAmount    float64 `json:"paid_amount"`
+		Outcome       string  `json:"outcome"`
+		BoletoID      string  `json:"boleto_id,omitempty"`
+		TransactionID string  `json:"transaction_id,omitempty"`
+		Reason        string  `json:"reason,omitempty"`
+	}
+
+	importResult struct {
+		Settled        int            `json:"settled"`
+		AlreadySettled int            `json:"already_settled"`
+		NotFound       int            `json:"not_found"`
+		Ignored        int            `json:"ignored"`
+		Failed         int            `json:"failed"`
+		Records        []returnRecord `json:"records"`
+	}
+)
+
+// NewImportReturnFileFunc settles the boletos paid in the CNAB 240 or CNAB 400 return file sent as
+// the plain text request body.
+func NewImportReturnFileFunc(svc boletos.Service) ImportReturnFileFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		body := http.MaxBytesReader(c.Response(), c.Request().Body, maxReturnFileSize)
+		result, err := svc.ImportReturnFile(ctx, body)
+		if err != nil {
+			zapctx.L(ctx).Error("import_return_file_handler_service_error", zap.Error(err))
+			if errors.Is(err, boletos.ErrInvalidReturnFile) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		response := importResult{
+			Settled:        result.Count(boletos.SettledOutcome),
+			AlreadySettled: result.Count(boletos.AlreadySettledOutcome),
+			NotFound:       result.Count(boletos.NotFoundOutcome),
+			Ignored:        result.Count(boletos.IgnoredOutcome),
+			Failed:         result.Count(boletos.FailedOutcome),
+			Records:        make([]returnRecord, 0, len(result.Records)),
+		}
+		for _, record := range result.Records {
+			response.Records = append(response.Records, returnRecord{
+				Line:          record.Line,
+				OurNumber:     record.OurNumber,
+				Occurrence:    record.Occurrence,
+				PaidAmount:    record.PaidAmount,
+				Outcome:       string(record.Outcome),
+				BoletoID:      stringers.UUIDEmpty(record.BoletoID.UUID),
+				TransactionID: stringers.UUIDEmpty(record.TransactionID.UUID),
+				Reason:        record.Reason,
+			})
+		}
+
+		return c.JSON(http.StatusOK, response)
+	}
+}
@@ -0,0 +1,152 @@
+package chargesh
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/stringers"
+	"github.com/dalmarcogd/dock-test/internal/charges"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// qrCodeSize is the width and height, in pixels, of the QR code images.
+const qrCodeSize = 256
+
+type (
+	CreateChargeFunc echo.HandlerFunc
+
+	createCharge struct {
+		ID          string     `param:"id"`
+		Amount      float64    `json:"amount"`
+		Description string     `json:"description"`
+		ExpiresAt   *time.Time `json:"expires_at"`
+	}
+
+	charge struct {
+		ID             string     `json:"id"`
+		AccountID      string     `json:"account_id"`
+		Amount         float64    `json:"amount"`
+		Currency       string     `json:"currency"`
+		Description    string     `json:"description"`
+		Status         string     `json:"status"`
+		ExpiresAt      time.Time  `json:"expires_at"`
+		Payload        string     `json:"payload"`
+		QRCode         string     `json:"qr_code,omitempty"`
+		PayerAccountID string     `json:"payer_account_id,omitempty"`
+		TransactionID  string     `json:"transaction_id,omitempty"`
+		PaidAt         *time.Time `json:"paid_at,omitempty"`
+		CreatedAt      time.Time  `json:"created_at"`
+	}
+)
+
+func (c createCharge) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Amount, validation.Required, validation.Min(0.01)),
+		validation.Field(&c.Description, validation.Length(0, 100)),
+	)
+}
+
+func NewCreateChargeFunc(svc charges.Service, ps policies.Service) CreateChargeFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var cc createCharge
+		if err := c.Bind(&cc); err != nil {
+			zapctx.L(ctx).Error("create_charge_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(cc.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("create_charge_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		if err := ps.AuthorizeAccount(ctx, id, policies.HolderAccess); err != nil {
+			zapctx.L(ctx).Error("create_charge_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		if err := cc.Validate(); err != nil {
+			zapctx.L(ctx).Error("create_charge_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		var expiresAt time.Time
+		if cc.ExpiresAt != nil {
+			expiresAt = *cc.ExpiresAt
+		}
+
+		created, err := svc.Create(ctx, charges.Charge{
+			AccountID:   id,
+			Amount:      cc.Amount,
+			Description: cc.Description,
+			ExpiresAt:   expiresAt,
+		})
+		if err != nil {
+			zapctx.L(ctx).Error("create_charge_handler_service_error", zap.Error(err))
+			if errors.Is(err, accounts.ErrAccountNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			} else if errors.Is(err, charges.ErrChargeAccountNotAllowed) {
+				return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+			} else if errors.Is(err, charges.ErrInvalidChargeAmount) ||
+				errors.Is(err, charges.ErrInvalidChargeExpiry) ||
+				errors.Is(err, charges.ErrChargeCurrencyNotSupported) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		response, err := newCharge(created, true)
+		if err != nil {
+			zapctx.L(ctx).Error("create_charge_handler_qr_code_error", zap.Error(err))
+			return err
+		}
+
+		return c.JSON(http.StatusCreated, response)
+	}
+}
+
+// newCharge renders the charge, with its QR code as a base64 PNG when withQRCode is set.
+func newCharge(ch charges.Charge, withQRCode bool) (charge, error) {
+	var paidAt *time.Time
+	if !ch.PaidAt.IsZero() {
+		paidAt = &ch.PaidAt
+	}
+
+	response := charge{
+		ID:             ch.ID.String(),
+		AccountID:      ch.AccountID.String(),
+		Amount:         ch.Amount,
+		Currency:       string(ch.Currency),
+		Description:    ch.Description,
+		Status:         string(ch.Status),
+		ExpiresAt:      ch.ExpiresAt,
+		Payload:        ch.Payload,
+		PayerAccountID: stringers.UUIDEmpty(ch.PayerAccountID.UUID),
+		TransactionID:  stringers.UUIDEmpty(ch.TransactionID.UUID),
+		PaidAt:         paidAt,
+		CreatedAt:      ch.CreatedAt,
+	}
+
+	if withQRCode {
+		png, err := ch.QRCode(qrCodeSize)
+		if err != nil {
+			return charge{}, err
+		}
+		response.QRCode = base64.StdEncoding.EncodeToString(png)
+	}
+
+	return response, nil
+}
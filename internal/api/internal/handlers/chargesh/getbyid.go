@@ -0,0 +1,87 @@
+package chargesh
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dalmarcogd/dock-test/internal/charges"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	GetByIDChargeFunc echo.HandlerFunc
+	GetQRCodeFunc     echo.HandlerFunc
+
+	getCharge struct {
+		ID string `param:"id"`
+	}
+)
+
+func NewGetByIDChargeFunc(svc charges.Service) GetByIDChargeFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		ch, err := getByID(c, svc, "get_charge_handler")
+		if err != nil {
+			return err
+		}
+
+		response, err := newCharge(ch, false)
+		if err != nil {
+			zapctx.L(ctx).Error("get_charge_handler_render_error", zap.Error(err))
+			return err
+		}
+
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+// NewGetQRCodeFunc serves the QR code of the charge as a PNG image.
+func NewGetQRCodeFunc(svc charges.Service) GetQRCodeFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		ch, err := getByID(c, svc, "get_charge_qr_code_handler")
+		if err != nil {
+			return err
+		}
+
+		png, err := ch.QRCode(qrCodeSize)
+		if err != nil {
+			zapctx.L(ctx).Error("get_charge_qr_code_handler_encode_error", zap.Error(err))
+			return err
+		}
+
+		return c.Blob(http.StatusOK, "image/png", png)
+	}
+}
+
+func getByID(c echo.Context, svc charges.Service, event string) (charges.Charge, error) {
+	ctx := c.Request().Context()
+
+	var gc getCharge
+	if err := c.Bind(&gc); err != nil {
+		zapctx.L(ctx).Error(event+"_bind_error", zap.Error(err))
+		return charges.Charge{}, echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	id, err := uuid.Parse(gc.ID)
+	if err != nil {
+		zapctx.L(ctx).Error(event+"_bind_error", zap.Error(err))
+		return charges.Charge{}, echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+	}
+
+	ch, err := svc.GetByID(ctx, id)
+	if err != nil {
+		zapctx.L(ctx).Error(event+"_service_error", zap.Error(err))
+		if errors.Is(err, charges.ErrChargeNotFound) {
+			return charges.Charge{}, echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return charges.Charge{}, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	return ch, nil
+}
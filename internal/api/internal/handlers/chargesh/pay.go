@@ -0,0 +1,112 @@
+package chargesh
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dalmarcogd/dock-test/internal/charges"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/internal/transactions"
+	"github.com/dalmarcogd/dock-test/pkg/http/middlewares"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	PayChargeFunc echo.HandlerFunc
+
+	payCharge struct {
+		ID             string `param:"id"`
+		PayerAccountID string `json:"payer_account_id"`
+	}
+)
+
+func (p payCharge) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.PayerAccountID, validation.Required),
+	)
+}
+
+func NewPayChargeFunc(svc charges.Service, ps policies.Service) PayChargeFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var pc payCharge
+		if err := c.Bind(&pc); err != nil {
+			zapctx.L(ctx).Error("pay_charge_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(pc.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("pay_charge_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		if err := pc.Validate(); err != nil {
+			zapctx.L(ctx).Error("pay_charge_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		payerID, err := uuid.Parse(pc.PayerAccountID)
+		if err != nil {
+			zapctx.L(ctx).Error("pay_charge_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid payer account id")
+		}
+
+		if err := ps.AuthorizeAccount(ctx, payerID, policies.DebitAccess); err != nil {
+			zapctx.L(ctx).Error("pay_charge_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		principal, _ := middlewares.PrincipalFromContext(ctx)
+
+		paid, err := svc.Pay(ctx, charges.Payment{
+			ChargeID:       id,
+			PayerAccountID: payerID,
+			RequestedBy:    principal.ID,
+		})
+		if err != nil {
+			zapctx.L(ctx).Error("pay_charge_handler_service_error", zap.Error(err))
+			if errors.Is(err, charges.ErrChargeNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			} else if errors.Is(err, charges.ErrChargeAlreadyPaid) {
+				return echo.NewHTTPError(http.StatusConflict, err.Error())
+			} else if errors.Is(err, charges.ErrChargeExpired) ||
+				errors.Is(err, transactions.ErrBalanceInsufficientFunds) ||
+				errors.Is(err, transactions.ErrP2PNotAllowed) ||
+				errors.Is(err, transactions.ErrMaxBalanceExceeded) ||
+				errors.Is(err, transactions.ErrInsufficientDailyLimit) ||
+				errors.Is(err, transactions.ErrAccountDebitsBlocked) ||
+				errors.Is(err, transactions.ErrAccountCreditsBlocked) ||
+				errors.Is(err, transactions.ErrAccountP2POutBlocked) ||
+				errors.Is(err, transactions.ErrPocketExternalTransaction) {
+				return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+			} else if errors.Is(err, transactions.ErrRequesterRequired) ||
+				errors.Is(err, transactions.ErrHolderNotAllowedToDebit) ||
+				errors.Is(err, transactions.ErrTransactionDenied) ||
+				errors.Is(err, transactions.ErrTransactionUnderReview) ||
+				errors.Is(err, transactions.ErrApprovalRequired) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			} else if errors.Is(err, transactions.ErrFromAccountToAccountShouldBeDifferent) ||
+				errors.Is(err, transactions.ErrCurrencyMismatch) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		response, err := newCharge(paid, false)
+		if err != nil {
+			zapctx.L(ctx).Error("pay_charge_handler_render_error", zap.Error(err))
+			return err
+		}
+
+		return c.JSON(http.StatusOK, response)
+	}
+}
@@ -0,0 +1,170 @@
+package feesh
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/fees"
+	"github.com/dalmarcogd/dock-test/internal/products"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	CreateScheduleFunc echo.HandlerFunc
+	ListSchedulesFunc  echo.HandlerFunc
+	DeleteScheduleFunc echo.HandlerFunc
+
+	createSchedule struct {
+		TransactionType string  `json:"transaction_type"`
+		ProductType     string  `json:"product_type"`
+		MinAmount       float64 `json:"min_amount"`
+		FixedAmount     float64 `json:"fixed_amount"`
+		Percentage      float64 `json:"percentage"`
+		FreePerMonth    int     `json:"free_per_month"`
+	}
+
+	deleteSchedule struct {
+		ID string `param:"id"`
+	}
+
+	schedule struct {
+		ID              string    `json:"id"`
+		TransactionType string    `json:"transaction_type"`
+		ProductType     string    `json:"product_type"`
+		MinAmount       float64   `json:"min_amount"`
+		FixedAmount     float64   `json:"fixed_amount"`
+		Percentage      float64   `json:"percentage"`
+		FreePerMonth    int       `json:"free_per_month"`
+		CreatedAt       time.Time `json:"created_at"`
+	}
+
+	listedSchedules struct {
+		Schedules []schedule `json:"schedules"`
+	}
+)
+
+func (c createSchedule) Validate() error {
+	transactionTypes := make([]interface{}, len(fees.TransactionTypes))
+	for i, t := range fees.TransactionTypes {
+		transactionTypes[i] = t
+	}
+
+	productTypes := make([]interface{}, len(products.Types))
+	for i, t := range products.Types {
+		productTypes[i] = string(t)
+	}
+
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.TransactionType, validation.Required, validation.In(transactionTypes...)),
+		validation.Field(&c.ProductType, validation.Required, validation.In(productTypes...)),
+		validation.Field(&c.MinAmount, validation.Min(0.0)),
+		validation.Field(&c.FixedAmount, validation.Min(0.0)),
+		validation.Field(&c.Percentage, validation.Min(0.0), validation.Max(1.0)),
+		validation.Field(&c.FreePerMonth, validation.Min(0)),
+	)
+}
+
+func NewCreateScheduleFunc(svc fees.Service) CreateScheduleFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var cs createSchedule
+		if err := c.Bind(&cs); err != nil {
+			zapctx.L(ctx).Error("create_fee_schedule_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		if err := cs.Validate(); err != nil {
+			zapctx.L(ctx).Error("create_fee_schedule_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		created, err := svc.CreateSchedule(ctx, fees.Schedule{
+			TransactionType: cs.TransactionType,
+			ProductType:     products.Type(cs.ProductType),
+			MinAmount:       cs.MinAmount,
+			FixedAmount:     cs.FixedAmount,
+			Percentage:      cs.Percentage,
+			FreePerMonth:    cs.FreePerMonth,
+		})
+		if err != nil {
+			zapctx.L(ctx).Error("create_fee_schedule_handler_service_error", zap.Error(err))
+			if errors.Is(err, fees.ErrInvalidTransactionType) ||
+				errors.Is(err, fees.ErrInvalidProductType) ||
+				errors.Is(err, fees.ErrInvalidFeeAmount) ||
+				errors.Is(err, fees.ErrEmptyFee) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			} else if errors.Is(err, fees.ErrDuplicatedScheduleTier) {
+				return echo.NewHTTPError(http.StatusConflict, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(http.StatusCreated, newSchedule(created))
+	}
+}
+
+func NewListSchedulesFunc(svc fees.Service) ListSchedulesFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		schedules, err := svc.ListSchedules(ctx)
+		if err != nil {
+			zapctx.L(ctx).Error("list_fee_schedules_handler_service_error", zap.Error(err))
+			return err
+		}
+
+		listed := listedSchedules{Schedules: make([]schedule, len(schedules))}
+		for i, s := range schedules {
+			listed.Schedules[i] = newSchedule(s)
+		}
+
+		return c.JSON(http.StatusOK, listed)
+	}
+}
+
+func NewDeleteScheduleFunc(svc fees.Service) DeleteScheduleFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var ds deleteSchedule
+		if err := c.Bind(&ds); err != nil {
+			zapctx.L(ctx).Error("delete_fee_schedule_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(ds.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("delete_fee_schedule_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		if err := svc.DeleteSchedule(ctx, id); err != nil {
+			zapctx.L(ctx).Error("delete_fee_schedule_handler_service_error", zap.Error(err))
+			if errors.Is(err, fees.ErrScheduleNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+func newSchedule(s fees.Schedule) schedule {
+	return schedule{
+		ID:              s.ID.String(),
+		TransactionType: s.TransactionType,
+		ProductType:     string(s.ProductType),
+		MinAmount:       s.MinAmount,
+		FixedAmount:     s.FixedAmount,
+		Percentage:      s.Percentage,
+		FreePerMonth:    s.FreePerMonth,
+		CreatedAt:       s.CreatedAt,
+	}
+}
@@ -0,0 +1,86 @@
+package holdersh
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dalmarcogd/dock-test/internal/balances"
+	"github.com/dalmarcogd/dock-test/internal/holders"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	ListHolderBalancesFunc echo.HandlerFunc
+
+	listHolderBalances struct {
+		ID string `param:"id"`
+	}
+
+	currencyBalance struct {
+		Currency string  `json:"currency"`
+		Balance  float64 `json:"balance"`
+	}
+
+	listedHolderBalances struct {
+		HolderID string            `json:"holder_id"`
+		Balances []currencyBalance `json:"balances"`
+	}
+)
+
+func NewListHolderBalancesFunc(hs holders.Service, bs balances.Service, ps policies.Service) ListHolderBalancesFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var lsb listHolderBalances
+		if err := c.Bind(&lsb); err != nil {
+			zapctx.L(ctx).Error("list_holder_balances_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(lsb.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("list_holder_balances_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		if err := ps.AuthorizeHolder(ctx, id); err != nil {
+			zapctx.L(ctx).Error("list_holder_balances_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		holder, err := hs.GetByID(ctx, id)
+		if err != nil {
+			zapctx.L(ctx).Error("list_holder_balances_handler_holder_service_error", zap.Error(err))
+			if errors.Is(err, holders.ErrHolderNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		cbs, err := bs.ListByHolderID(ctx, holder.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("list_holder_balances_handler_balance_service_error", zap.Error(err))
+			return err
+		}
+
+		listed := listedHolderBalances{
+			HolderID: holder.ID.String(),
+			Balances: make([]currencyBalance, len(cbs)),
+		}
+		for i, cb := range cbs {
+			listed.Balances[i] = currencyBalance{
+				Currency: string(cb.Currency),
+				Balance:  cb.Balance,
+			}
+		}
+
+		return c.JSON(http.StatusOK, listed)
+	}
+}
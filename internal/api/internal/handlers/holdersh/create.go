@@ -1,33 +1,91 @@
 package holdersh
 
 import (
+	"errors"
 	"net/http"
+	"regexp"
+	"time"
 
 	"github.com/dalmarcogd/dock-test/internal/holders"
+	"github.com/dalmarcogd/dock-test/pkg/document"
 	"github.com/dalmarcogd/dock-test/pkg/zapctx"
 	"github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
 )
 
+const dateLayout = "2006-01-02"
+
+var (
+	emailRegexp   = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+	phoneRegexp   = regexp.MustCompile(`^\+?[0-9]{10,15}$`)
+	zipCodeRegexp = regexp.MustCompile(`^[0-9]{8}$`)
+)
+
 type (
 	CreateHolderFunc echo.HandlerFunc
 
 	createHolder struct {
-		Name           string `json:"name"`
-		DocumentNumber string `json:"document_number"`
+		Name           string          `json:"name"`
+		DocumentNumber string          `json:"document_number"`
+		Email          string          `json:"email"`
+		Phone          string          `json:"phone"`
+		BirthDate      string          `json:"birth_date"`
+		Addresses      []holderAddress `json:"addresses"`
+	}
+	holderAddress struct {
+		Street       string `json:"street"`
+		Number       string `json:"number"`
+		Complement   string `json:"complement,omitempty"`
+		Neighborhood string `json:"neighborhood,omitempty"`
+		City         string `json:"city"`
+		State        string `json:"state"`
+		ZipCode      string `json:"zip_code"`
+		Country      string `json:"country,omitempty"`
 	}
 	createdHolder struct {
-		ID             string `json:"id"`
-		Name           string `json:"name"`
-		DocumentNumber string `json:"document_number"`
+		ID             string          `json:"id"`
+		Name           string          `json:"name"`
+		DocumentNumber string          `json:"document_number"`
+		Type           string          `json:"type"`
+		Email          string          `json:"email,omitempty"`
+		Phone          string          `json:"phone,omitempty"`
+		BirthDate      string          `json:"birth_date,omitempty"`
+		KYCStatus      string          `json:"kyc_status"`
+		Addresses      []holderAddress `json:"addresses,omitempty"`
 	}
 )
 
+// documentNumberRule accepts CPF and CNPJ, formatted or digits only.
+var documentNumberRule = validation.By(func(value interface{}) error {
+	number, _ := value.(string)
+	if number != "" && !document.IsValid(number) {
+		return holders.ErrInvalidDocumentNumber
+	}
+	return nil
+})
+
 func (c createHolder) Validate() error {
 	return validation.ValidateStruct(&c,
 		validation.Field(&c.Name, validation.Required, validation.Length(1, 100)),
-		validation.Field(&c.DocumentNumber, validation.Required, validation.Length(11, 14)),
+		validation.Field(&c.DocumentNumber, validation.Required, validation.Length(11, 18), documentNumberRule),
+		validation.Field(&c.Email, validation.Length(1, 254), validation.Match(emailRegexp)),
+		validation.Field(&c.Phone, validation.Match(phoneRegexp)),
+		validation.Field(&c.BirthDate, validation.Date(dateLayout).Max(time.Now().UTC())),
+		validation.Field(&c.Addresses),
+	)
+}
+
+func (a holderAddress) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.Street, validation.Required, validation.Length(1, 200)),
+		validation.Field(&a.Number, validation.Required, validation.Length(1, 20)),
+		validation.Field(&a.Complement, validation.Length(1, 100)),
+		validation.Field(&a.Neighborhood, validation.Length(1, 100)),
+		validation.Field(&a.City, validation.Required, validation.Length(1, 100)),
+		validation.Field(&a.State, validation.Required, validation.Length(2, 2)),
+		validation.Field(&a.ZipCode, validation.Required, validation.Match(zipCodeRegexp)),
+		validation.Field(&a.Country, validation.Length(2, 2)),
 	)
 }
 
@@ -46,22 +104,83 @@ func NewCreateHolderFunc(svc holders.Service) CreateHolderFunc {
 			return err
 		}
 
+		var birthDate time.Time
+		if acc.BirthDate != "" {
+			birthDate, _ = time.Parse(dateLayout, acc.BirthDate)
+		}
+
 		holder, err := svc.Create(ctx, holders.Holder{
 			Name:           acc.Name,
 			DocumentNumber: acc.DocumentNumber,
+			Email:          acc.Email,
+			Phone:          acc.Phone,
+			BirthDate:      birthDate,
+			Addresses:      newAddresses(acc.Addresses),
 		})
 		if err != nil {
 			zapctx.L(ctx).Error("create_holder_handler_service_error", zap.Error(err))
+			if errors.Is(err, holders.ErrInvalidDocumentNumber) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
 			return err
 		}
 
-		return c.JSON(
-			http.StatusCreated,
-			createdHolder{
-				ID:             holder.ID.String(),
-				Name:           holder.Name,
-				DocumentNumber: holder.DocumentNumber,
-			},
-		)
+		return c.JSON(http.StatusCreated, newCreatedHolder(holder))
+	}
+}
+
+func newAddresses(addresses []holderAddress) []holders.Address {
+	if addresses == nil {
+		return nil
 	}
+
+	hAddresses := make([]holders.Address, len(addresses))
+	for i, address := range addresses {
+		hAddresses[i] = holders.Address{
+			Street:       address.Street,
+			Number:       address.Number,
+			Complement:   address.Complement,
+			Neighborhood: address.Neighborhood,
+			City:         address.City,
+			State:        address.State,
+			ZipCode:      address.ZipCode,
+			Country:      address.Country,
+		}
+	}
+
+	return hAddresses
+}
+
+func newCreatedHolder(holder holders.Holder) createdHolder {
+	created := createdHolder{
+		ID:             holder.ID.String(),
+		Name:           holder.Name,
+		DocumentNumber: holder.DocumentNumber,
+		Type:           string(holder.Type),
+		Email:          holder.Email,
+		Phone:          holder.Phone,
+		KYCStatus:      string(holder.KYCStatus),
+	}
+
+	if !holder.BirthDate.IsZero() {
+		created.BirthDate = holder.BirthDate.Format(dateLayout)
+	}
+
+	if len(holder.Addresses) > 0 {
+		created.Addresses = make([]holderAddress, len(holder.Addresses))
+		for i, address := range holder.Addresses {
+			created.Addresses[i] = holderAddress{
+				Street:       address.Street,
+				Number:       address.Number,
+				Complement:   address.Complement,
+				Neighborhood: address.Neighborhood,
+				City:         address.City,
+				State:        address.State,
+				ZipCode:      address.ZipCode,
+				Country:      address.Country,
+			}
+		}
+	}
+
+	return created
 }
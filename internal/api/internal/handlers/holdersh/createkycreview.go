@@ -0,0 +1,101 @@
+package holdersh
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/holders"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	CreateKYCReviewFunc echo.HandlerFunc
+
+	createKYCReview struct {
+		HolderID string `param:"id"`
+		Status   string `json:"status"`
+		Reason   string `json:"reason"`
+		Reviewer string `json:"reviewer"`
+	}
+	createdKYCReview struct {
+		ID        string    `json:"id"`
+		HolderID  string    `json:"holder_id"`
+		Status    string    `json:"status"`
+		Reason    string    `json:"reason"`
+		Reviewer  string    `json:"reviewer"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+)
+
+func (c createKYCReview) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(
+			&c.Status,
+			validation.Required,
+			validation.In(
+				string(holders.PendingKYCStatus),
+				string(holders.ApprovedKYCStatus),
+				string(holders.RejectedKYCStatus),
+			),
+		),
+		validation.Field(&c.Reason, validation.Required, validation.Length(1, 200)),
+		validation.Field(&c.Reviewer, validation.Required, validation.Length(1, 100)),
+	)
+}
+
+func NewCreateKYCReviewFunc(svc holders.Service) CreateKYCReviewFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var rev createKYCReview
+		if err := c.Bind(&rev); err != nil {
+			zapctx.L(ctx).Error("create_kyc_review_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(rev.HolderID)
+		if err != nil {
+			zapctx.L(ctx).Error("create_kyc_review_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		if err := rev.Validate(); err != nil {
+			zapctx.L(ctx).Error("create_kyc_review_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		review, err := svc.ReviewKYC(ctx, holders.KYCReview{
+			HolderID: id,
+			Status:   holders.KYCStatus(rev.Status),
+			Reason:   rev.Reason,
+			Reviewer: rev.Reviewer,
+		})
+		if err != nil {
+			zapctx.L(ctx).Error("create_kyc_review_handler_service_error", zap.Error(err))
+			if errors.Is(err, holders.ErrHolderNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			} else if errors.Is(err, holders.ErrKYCStatusUnchanged) {
+				return echo.NewHTTPError(http.StatusConflict, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(http.StatusCreated, newCreatedKYCReview(review))
+	}
+}
+
+func newCreatedKYCReview(review holders.KYCReview) createdKYCReview {
+	return createdKYCReview{
+		ID:        review.ID.String(),
+		HolderID:  review.HolderID.String(),
+		Status:    string(review.Status),
+		Reason:    review.Reason,
+		Reviewer:  review.Reviewer,
+		CreatedAt: review.CreatedAt,
+	}
+}
@@ -1,9 +1,11 @@
 package holdersh
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/dalmarcogd/dock-test/internal/holders"
+	"github.com/dalmarcogd/dock-test/internal/policies"
 	"github.com/dalmarcogd/dock-test/pkg/zapctx"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -18,7 +20,7 @@ type (
 	}
 )
 
-func NewGetByIDHolderFunc(svc holders.Service) GetByIDHolderFunc {
+func NewGetByIDHolderFunc(svc holders.Service, ps policies.Service) GetByIDHolderFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
 
@@ -34,19 +36,20 @@ func NewGetByIDHolderFunc(svc holders.Service) GetByIDHolderFunc {
 			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
 		}
 
+		if err := ps.AuthorizeHolder(ctx, id); err != nil {
+			zapctx.L(ctx).Error("get_by_id_holder_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
 		holder, err := svc.GetByID(ctx, id)
 		if err != nil {
 			zapctx.L(ctx).Error("get_by_id_holder_handler_service_error", zap.Error(err))
 			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 		}
 
-		return c.JSON(
-			http.StatusOK,
-			createdHolder{
-				ID:             holder.ID.String(),
-				Name:           holder.Name,
-				DocumentNumber: holder.DocumentNumber,
-			},
-		)
+		return c.JSON(http.StatusOK, newCreatedHolder(holder))
 	}
 }
@@ -13,6 +13,7 @@ type (
 	ListHoldersFunc echo.HandlerFunc
 
 	listHolders struct {
+		Name          string `query:"name"`
 		DocumentNumer string `query:"document_number"`
 		Sort          int    `query:"sort"`
 		Page          int    `query:"page"`
@@ -56,6 +57,7 @@ func NewListHoldersFunc(svc holders.Service) ListHoldersFunc {
 			Sort:           lsa.Sort,
 			Page:           lsa.Page,
 			Size:           lsa.Size,
+			Name:           lsa.Name,
 			DocumentNumber: lsa.DocumentNumer,
 		})
 		if err != nil {
@@ -70,11 +72,7 @@ func NewListHoldersFunc(svc holders.Service) ListHoldersFunc {
 
 		cholders := make([]createdHolder, len(hdlrs))
 		for i, holder := range hdlrs {
-			cholders[i] = createdHolder{
-				ID:             holder.ID.String(),
-				Name:           holder.Name,
-				DocumentNumber: holder.DocumentNumber,
-			}
+			cholders[i] = newCreatedHolder(holder)
 		}
 
 		listed := listedHolder{
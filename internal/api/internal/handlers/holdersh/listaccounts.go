@@ -0,0 +1,149 @@
+package holdersh
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/balances"
+	"github.com/dalmarcogd/dock-test/internal/holders"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	ListHolderAccountsFunc echo.HandlerFunc
+
+	listHolderAccounts struct {
+		ID   string `param:"id"`
+		Sort int    `query:"sort"`
+		Page int    `query:"page"`
+		Size int    `query:"size"`
+	}
+
+	holderAccount struct {
+		ID             string  `json:"id"`
+		Name           string  `json:"name"`
+		Agency         string  `json:"agency"`
+		Number         string  `json:"number"`
+		Type           string  `json:"type"`
+		Currency       string  `json:"currency"`
+		Status         string  `json:"status"`
+		Role           string  `json:"role"`
+		CurrentBalance float64 `json:"current_balance"`
+	}
+
+	listedHolderAccounts struct {
+		Pagination pagination      `json:"pagination"`
+		HolderID   string          `json:"holder_id"`
+		Accounts   []holderAccount `json:"accounts"`
+	}
+)
+
+func NewListHolderAccountsFunc(
+	hs holders.Service,
+	as accounts.Service,
+	bs balances.Service,
+	ps policies.Service,
+) ListHolderAccountsFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var lsa listHolderAccounts
+		if err := c.Bind(&lsa); err != nil {
+			zapctx.L(ctx).Error("list_holder_accounts_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(lsa.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("list_holder_accounts_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		if err := ps.AuthorizeHolder(ctx, id); err != nil {
+			zapctx.L(ctx).Error("list_holder_accounts_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		if lsa.Page == 0 {
+			lsa.Page = 1
+		}
+
+		if lsa.Size == 0 {
+			lsa.Size = 20
+		}
+
+		holder, err := hs.GetByID(ctx, id)
+		if err != nil {
+			zapctx.L(ctx).Error("list_holder_accounts_handler_holder_service_error", zap.Error(err))
+			if errors.Is(err, holders.ErrHolderNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		total, accs, err := as.List(ctx, accounts.ListFilter{
+			Sort:     lsa.Sort,
+			Page:     lsa.Page,
+			Size:     lsa.Size,
+			HolderID: uuid.NullUUID{UUID: holder.ID, Valid: true},
+		})
+		if err != nil {
+			zapctx.L(ctx).Error("list_holder_accounts_handler_account_service_error", zap.Error(err))
+			return err
+		}
+
+		accountIDs := make([]uuid.UUID, len(accs))
+		for i, account := range accs {
+			accountIDs[i] = account.ID
+		}
+
+		accbs, err := bs.ListByAccountIDs(ctx, accountIDs)
+		if err != nil {
+			zapctx.L(ctx).Error("list_holder_accounts_handler_balance_service_error", zap.Error(err))
+			return err
+		}
+
+		totalPages := total / lsa.Size
+		if (total % lsa.Size) != 0 {
+			totalPages++
+		}
+
+		haccounts := make([]holderAccount, len(accs))
+		for i, account := range accs {
+			haccounts[i] = holderAccount{
+				ID:             account.ID.String(),
+				Name:           account.Name,
+				Agency:         account.Agency,
+				Number:         account.Number,
+				Type:           string(account.Type),
+				Currency:       string(account.Currency),
+				Status:         string(account.Status),
+				Role:           string(account.HolderRole),
+				CurrentBalance: accbs[i].CurrentBalance,
+			}
+		}
+
+		listed := listedHolderAccounts{
+			Pagination: pagination{
+				Sort:        lsa.Sort,
+				Page:        lsa.Page,
+				Size:        lsa.Size,
+				TotalItems:  total,
+				TotalPages:  totalPages,
+				TotalInPage: len(haccounts),
+			},
+			HolderID: holder.ID.String(),
+			Accounts: haccounts,
+		}
+
+		return c.JSON(http.StatusOK, listed)
+	}
+}
@@ -0,0 +1,62 @@
+package holdersh
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dalmarcogd/dock-test/internal/holders"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	ListKYCReviewsFunc echo.HandlerFunc
+
+	listKYCReviews struct {
+		HolderID string `param:"id"`
+	}
+
+	listedKYCReviews struct {
+		HolderID string             `json:"holder_id"`
+		Reviews  []createdKYCReview `json:"reviews"`
+	}
+)
+
+func NewListKYCReviewsFunc(svc holders.Service) ListKYCReviewsFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var lsr listKYCReviews
+		if err := c.Bind(&lsr); err != nil {
+			zapctx.L(ctx).Error("list_kyc_reviews_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(lsr.HolderID)
+		if err != nil {
+			zapctx.L(ctx).Error("list_kyc_reviews_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		reviews, err := svc.ListKYCReviews(ctx, id)
+		if err != nil {
+			zapctx.L(ctx).Error("list_kyc_reviews_handler_service_error", zap.Error(err))
+			if errors.Is(err, holders.ErrHolderNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		listed := listedKYCReviews{
+			HolderID: id.String(),
+			Reviews:  make([]createdKYCReview, len(reviews)),
+		}
+		for i, review := range reviews {
+			listed.Reviews[i] = newCreatedKYCReview(review)
+		}
+
+		return c.JSON(http.StatusOK, listed)
+	}
+}
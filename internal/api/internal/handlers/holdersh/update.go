@@ -0,0 +1,117 @@
+package holdersh
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/holders"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	UpdateHolderFunc echo.HandlerFunc
+
+	updateHolder struct {
+		ID             string `param:"id"`
+		Name           string `json:"name"`
+		DocumentNumber string `json:"document_number"`
+		Email          string `json:"email"`
+		Phone          string `json:"phone"`
+		BirthDate      string `json:"birth_date"`
+		// Addresses replaces all holder addresses when present.
+		Addresses *[]holderAddress `json:"addresses"`
+	}
+)
+
+func (u updateHolder) Validate() error {
+	empty := u.Name == "" &&
+		u.DocumentNumber == "" &&
+		u.Email == "" &&
+		u.Phone == "" &&
+		u.BirthDate == "" &&
+		u.Addresses == nil
+
+	return validation.ValidateStruct(&u,
+		validation.Field(
+			&u.Name,
+			validation.When(empty, validation.Required),
+			validation.Length(1, 100),
+		),
+		validation.Field(&u.DocumentNumber, validation.Length(11, 18), documentNumberRule),
+		validation.Field(&u.Email, validation.Length(1, 254), validation.Match(emailRegexp)),
+		validation.Field(&u.Phone, validation.Match(phoneRegexp)),
+		validation.Field(&u.BirthDate, validation.Date(dateLayout).Max(time.Now().UTC())),
+		validation.Field(&u.Addresses),
+	)
+}
+
+func NewUpdateHolderFunc(svc holders.Service, ps policies.Service) UpdateHolderFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var upd updateHolder
+		if err := c.Bind(&upd); err != nil {
+			zapctx.L(ctx).Error("update_holder_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(upd.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("update_holder_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		if err := ps.AuthorizeHolder(ctx, id); err != nil {
+			zapctx.L(ctx).Error("update_holder_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		if err := upd.Validate(); err != nil {
+			zapctx.L(ctx).Error("update_holder_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		var birthDate time.Time
+		if upd.BirthDate != "" {
+			birthDate, _ = time.Parse(dateLayout, upd.BirthDate)
+		}
+
+		var addresses []holders.Address
+		if upd.Addresses != nil {
+			addresses = newAddresses(*upd.Addresses)
+			if addresses == nil {
+				addresses = []holders.Address{}
+			}
+		}
+
+		holder, err := svc.Update(ctx, holders.Holder{
+			ID:             id,
+			Name:           upd.Name,
+			DocumentNumber: upd.DocumentNumber,
+			Email:          upd.Email,
+			Phone:          upd.Phone,
+			BirthDate:      birthDate,
+			Addresses:      addresses,
+		})
+		if err != nil {
+			zapctx.L(ctx).Error("update_holder_handler_service_error", zap.Error(err))
+			if errors.Is(err, holders.ErrHolderNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			} else if errors.Is(err, holders.ErrInvalidDocumentNumber) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(http.StatusOK, newCreatedHolder(holder))
+	}
+}
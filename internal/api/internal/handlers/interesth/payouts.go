@@ -0,0 +1,96 @@
+package interesth
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/interest"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	ListPayoutsFunc echo.HandlerFunc
+
+	listPayouts struct {
+		AccountID string `param:"id"`
+	}
+
+	payout struct {
+		ID            string    `json:"id"`
+		Month         string    `json:"month"`
+		GrossAmount   float64   `json:"gross_amount"`
+		TaxAmount     float64   `json:"tax_amount"`
+		NetAmount     float64   `json:"net_amount"`
+		TransactionID string    `json:"transaction_id"`
+		CreatedAt     time.Time `json:"created_at"`
+	}
+
+	listedPayouts struct {
+		AccountID string   `json:"account_id"`
+		Payouts   []payout `json:"payouts"`
+	}
+)
+
+func NewListPayoutsFunc(as accounts.Service, is interest.Service, ps policies.Service) ListPayoutsFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var lp listPayouts
+		if err := c.Bind(&lp); err != nil {
+			zapctx.L(ctx).Error("list_interest_payouts_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(lp.AccountID)
+		if err != nil {
+			zapctx.L(ctx).Error("list_interest_payouts_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		if err := ps.AuthorizeAccount(ctx, id, policies.HolderAccess); err != nil {
+			zapctx.L(ctx).Error("list_interest_payouts_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		if _, err := as.GetByID(ctx, id); err != nil {
+			zapctx.L(ctx).Error("list_interest_payouts_handler_account_service_error", zap.Error(err))
+			if errors.Is(err, accounts.ErrAccountNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		payouts, err := is.ListPayouts(ctx, id)
+		if err != nil {
+			zapctx.L(ctx).Error("list_interest_payouts_handler_interest_service_error", zap.Error(err))
+			return err
+		}
+
+		listed := listedPayouts{
+			AccountID: id.String(),
+			Payouts:   make([]payout, len(payouts)),
+		}
+		for i, p := range payouts {
+			listed.Payouts[i] = payout{
+				ID:            p.ID.String(),
+				Month:         p.Month.Format("2006-01"),
+				GrossAmount:   p.GrossAmount,
+				TaxAmount:     p.TaxAmount,
+				NetAmount:     p.NetAmount,
+				TransactionID: p.TransactionID.String(),
+				CreatedAt:     p.CreatedAt,
+			}
+		}
+
+		return c.JSON(http.StatusOK, listed)
+	}
+}
@@ -0,0 +1,104 @@
+package interesth
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/interest"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+const dateLayout = "2006-01-02"
+
+type (
+	SetRateFunc   echo.HandlerFunc
+	ListRatesFunc echo.HandlerFunc
+
+	setRate struct {
+		AnnualRate    float64 `json:"annual_rate"`
+		EffectiveFrom string  `json:"effective_from"`
+	}
+
+	rate struct {
+		AnnualRate    float64   `json:"annual_rate"`
+		EffectiveFrom string    `json:"effective_from"`
+		CreatedAt     time.Time `json:"created_at"`
+	}
+
+	listedRates struct {
+		Rates []rate `json:"rates"`
+	}
+)
+
+func (s setRate) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.AnnualRate, validation.Min(0.0), validation.Max(1.0)),
+		validation.Field(&s.EffectiveFrom, validation.Required, validation.Date(dateLayout)),
+	)
+}
+
+func NewSetRateFunc(svc interest.Service) SetRateFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var sr setRate
+		if err := c.Bind(&sr); err != nil {
+			zapctx.L(ctx).Error("set_interest_rate_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		if err := sr.Validate(); err != nil {
+			zapctx.L(ctx).Error("set_interest_rate_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		effectiveFrom, err := time.Parse(dateLayout, sr.EffectiveFrom)
+		if err != nil {
+			zapctx.L(ctx).Error("set_interest_rate_handler_parse_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid effective_from")
+		}
+
+		r, err := svc.SetRate(ctx, interest.Rate{AnnualRate: sr.AnnualRate, EffectiveFrom: effectiveFrom})
+		if err != nil {
+			zapctx.L(ctx).Error("set_interest_rate_handler_service_error", zap.Error(err))
+			if errors.Is(err, interest.ErrInvalidAnnualRate) ||
+				errors.Is(err, interest.ErrRateEffectiveDateRequired) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(http.StatusCreated, newRate(r))
+	}
+}
+
+func NewListRatesFunc(svc interest.Service) ListRatesFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		rates, err := svc.ListRates(ctx)
+		if err != nil {
+			zapctx.L(ctx).Error("list_interest_rates_handler_service_error", zap.Error(err))
+			return err
+		}
+
+		listed := listedRates{Rates: make([]rate, len(rates))}
+		for i, r := range rates {
+			listed.Rates[i] = newRate(r)
+		}
+
+		return c.JSON(http.StatusOK, listed)
+	}
+}
+
+func newRate(r interest.Rate) rate {
+	return rate{
+		AnnualRate:    r.AnnualRate,
+		EffectiveFrom: r.EffectiveFrom.Format(dateLayout),
+		CreatedAt:     r.CreatedAt,
+	}
+}
@@ -0,0 +1,298 @@
+package keysh
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/keys"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/pkg/http/middlewares"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	RegisterKeyFunc echo.HandlerFunc
+	VerifyKeyFunc   echo.HandlerFunc
+	ListKeysFunc    echo.HandlerFunc
+	DeleteKeyFunc   echo.HandlerFunc
+
+	registerKey struct {
+		ID    string `param:"id"`
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	}
+
+	verifyKey struct {
+		ID    string `param:"id"`
+		KeyID string `param:"keyID"`
+		Code  string `json:"code"`
+	}
+
+	listKeys struct {
+		ID string `param:"id"`
+	}
+
+	deleteKey struct {
+		ID    string `param:"id"`
+		KeyID string `param:"keyID"`
+	}
+
+	key struct {
+		ID         string     `json:"id"`
+		AccountID  string     `json:"account_id"`
+		Type       string     `json:"type"`
+		Value      string     `json:"value"`
+		Status     string     `json:"status"`
+		CreatedAt  time.Time  `json:"created_at"`
+		VerifiedAt *time.Time `json:"verified_at,omitempty"`
+	}
+
+	listedKeys struct {
+		AccountID string `json:"account_id"`
+		Keys      []key  `json:"keys"`
+	}
+)
+
+func (r registerKey) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Type, validation.Required),
+		validation.Field(&r.Value, validation.When(r.Type != string(keys.RandomType), validation.Required)),
+	)
+}
+
+func (v verifyKey) Validate() error {
+	return validation.ValidateStruct(&v,
+		validation.Field(&v.Code, validation.Required),
+	)
+}
+
+func NewRegisterKeyFunc(svc keys.Service, ps policies.Service) RegisterKeyFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var rk registerKey
+		if err := c.Bind(&rk); err != nil {
+			zapctx.L(ctx).Error("register_key_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(rk.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("register_key_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		if err := ps.AuthorizeAccount(ctx, id, policies.DebitAccess); err != nil {
+			zapctx.L(ctx).Error("register_key_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		if err := rk.Validate(); err != nil {
+			zapctx.L(ctx).Error("register_key_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		principal, _ := middlewares.PrincipalFromContext(ctx)
+
+		registered, err := svc.Register(ctx, keys.Registration{
+			AccountID:   id,
+			RequestedBy: principal.ID,
+			Type:        keys.Type(rk.Type),
+			Value:       rk.Value,
+		})
+		if err != nil {
+			zapctx.L(ctx).Error("register_key_handler_service_error", zap.Error(err))
+			if errors.Is(err, accounts.ErrAccountNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			} else if errors.Is(err, accounts.ErrAccountHolderNotLinked) ||
+				errors.Is(err, keys.ErrHolderNotAllowed) ||
+				errors.Is(err, keys.ErrDocumentKeyMismatch) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			} else if errors.Is(err, keys.ErrKeyInUse) ||
+				errors.Is(err, keys.ErrKeyAlreadyRegistered) {
+				return echo.NewHTTPError(http.StatusConflict, err.Error())
+			} else if errors.Is(err, keys.ErrKeyAccountNotAllowed) ||
+				errors.Is(err, keys.ErrKeyLimitReached) {
+				return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+			} else if errors.Is(err, keys.ErrInvalidKeyType) ||
+				errors.Is(err, keys.ErrInvalidKeyValue) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(http.StatusCreated, newKey(registered))
+	}
+}
+
+func NewVerifyKeyFunc(svc keys.Service, ps policies.Service) VerifyKeyFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var vk verifyKey
+		if err := c.Bind(&vk); err != nil {
+			zapctx.L(ctx).Error("verify_key_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(vk.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("verify_key_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		keyID, err := uuid.Parse(vk.KeyID)
+		if err != nil {
+			zapctx.L(ctx).Error("verify_key_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid key id")
+		}
+
+		if err := ps.AuthorizeAccount(ctx, id, policies.DebitAccess); err != nil {
+			zapctx.L(ctx).Error("verify_key_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		if err := vk.Validate(); err != nil {
+			zapctx.L(ctx).Error("verify_key_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		verified, err := svc.Verify(ctx, id, keyID, vk.Code)
+		if err != nil {
+			zapctx.L(ctx).Error("verify_key_handler_service_error", zap.Error(err))
+			if errors.Is(err, keys.ErrKeyNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			} else if errors.Is(err, keys.ErrKeyInUse) {
+				return echo.NewHTTPError(http.StatusConflict, err.Error())
+			} else if errors.Is(err, keys.ErrKeyAlreadyVerified) ||
+				errors.Is(err, keys.ErrVerificationExpired) ||
+				errors.Is(err, keys.ErrVerificationAttemptsExceeded) {
+				return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+			} else if errors.Is(err, keys.ErrInvalidVerificationCode) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(http.StatusOK, newKey(verified))
+	}
+}
+
+func NewListKeysFunc(svc keys.Service, ps policies.Service) ListKeysFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var lk listKeys
+		if err := c.Bind(&lk); err != nil {
+			zapctx.L(ctx).Error("list_keys_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(lk.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("list_keys_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		if err := ps.AuthorizeAccount(ctx, id, policies.HolderAccess); err != nil {
+			zapctx.L(ctx).Error("list_keys_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		accountKeys, err := svc.ListByAccountID(ctx, id)
+		if err != nil {
+			zapctx.L(ctx).Error("list_keys_handler_service_error", zap.Error(err))
+			if errors.Is(err, accounts.ErrAccountNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		listed := listedKeys{AccountID: id.String(), Keys: make([]key, len(accountKeys))}
+		for i, k := range accountKeys {
+			listed.Keys[i] = newKey(k)
+		}
+
+		return c.JSON(http.StatusOK, listed)
+	}
+}
+
+func NewDeleteKeyFunc(svc keys.Service, ps policies.Service) DeleteKeyFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var dk deleteKey
+		if err := c.Bind(&dk); err != nil {
+			zapctx.L(ctx).Error("delete_key_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(dk.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("delete_key_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		keyID, err := uuid.Parse(dk.KeyID)
+		if err != nil {
+			zapctx.L(ctx).Error("delete_key_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid key id")
+		}
+
+		if err := ps.AuthorizeAccount(ctx, id, policies.DebitAccess); err != nil {
+			zapctx.L(ctx).Error("delete_key_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		principal, _ := middlewares.PrincipalFromContext(ctx)
+
+		if err := svc.Delete(ctx, id, keyID, principal.ID); err != nil {
+			zapctx.L(ctx).Error("delete_key_handler_service_error", zap.Error(err))
+			if errors.Is(err, accounts.ErrAccountNotFound) ||
+				errors.Is(err, keys.ErrKeyNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			} else if errors.Is(err, accounts.ErrAccountHolderNotLinked) ||
+				errors.Is(err, keys.ErrHolderNotAllowed) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+func newKey(k keys.Key) key {
+	var verifiedAt *time.Time
+	if !k.VerifiedAt.IsZero() {
+		verifiedAt = &k.VerifiedAt
+	}
+
+	return key{
+		ID:         k.ID.String(),
+		AccountID:  k.AccountID.String(),
+		Type:       string(k.Type),
+		Value:      k.Value,
+		Status:     string(k.Status),
+		CreatedAt:  k.CreatedAt,
+		VerifiedAt: verifiedAt,
+	}
+}
@@ -0,0 +1,70 @@
+package keysh
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/dalmarcogd/dock-test/internal/keys"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	LookupKeyFunc echo.HandlerFunc
+
+	lookupKey struct {
+		Key string `param:"key"`
+	}
+
+	keyOwner struct {
+		Key            string `json:"key"`
+		Type           string `json:"type"`
+		AccountID      string `json:"account_id"`
+		Agency         string `json:"agency"`
+		Number         string `json:"number"`
+		Currency       string `json:"currency"`
+		HolderName     string `json:"holder_name"`
+		DocumentNumber string `json:"document_number"`
+	}
+)
+
+func NewLookupKeyFunc(svc keys.Service) LookupKeyFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var lk lookupKey
+		if err := c.Bind(&lk); err != nil {
+			zapctx.L(ctx).Error("lookup_key_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		// emails and phones arrive escaped, echo does not unescape path params.
+		value, err := url.PathUnescape(lk.Key)
+		if err != nil {
+			zapctx.L(ctx).Error("lookup_key_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid key")
+		}
+
+		owner, err := svc.Resolve(ctx, value)
+		if err != nil {
+			zapctx.L(ctx).Error("lookup_key_handler_service_error", zap.Error(err))
+			if errors.Is(err, keys.ErrKeyNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(http.StatusOK, keyOwner{
+			Key:            owner.Key.Value,
+			Type:           string(owner.Key.Type),
+			AccountID:      owner.AccountID.String(),
+			Agency:         owner.Agency,
+			Number:         owner.Number,
+			Currency:       owner.Currency,
+			HolderName:     owner.HolderName,
+			DocumentNumber: owner.DocumentNumber,
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package policiesh
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/pkg/http/middlewares"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	LinkHolderFunc   echo.HandlerFunc
+	ListLinksFunc    echo.HandlerFunc
+	UnlinkHolderFunc echo.HandlerFunc
+
+	linkHolder struct {
+		PrincipalID string `param:"id"`
+		HolderID    string `json:"holder_id"`
+	}
+
+	listLinks struct {
+		PrincipalID string `param:"id"`
+	}
+
+	unlinkHolder struct {
+		PrincipalID string `param:"id"`
+		HolderID    string `param:"holderID"`
+	}
+
+	link struct {
+		PrincipalID string    `json:"principal_id"`
+		HolderID    string    `json:"holder_id"`
+		CreatedBy   string    `json:"created_by"`
+		CreatedAt   time.Time `json:"created_at"`
+	}
+
+	listedLinks struct {
+		Links []link `json:"links"`
+	}
+)
+
+func (l linkHolder) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(&l.PrincipalID, validation.Required, validation.Length(1, 100)),
+		validation.Field(&l.HolderID, validation.Required),
+	)
+}
+
+func NewLinkHolderFunc(svc policies.Service) LinkHolderFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var lh linkHolder
+		if err := c.Bind(&lh); err != nil {
+			zapctx.L(ctx).Error("link_holder_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		if err := lh.Validate(); err != nil {
+			zapctx.L(ctx).Error("link_holder_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		holderID, err := uuid.Parse(lh.HolderID)
+		if err != nil {
+			zapctx.L(ctx).Error("link_holder_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid holder id")
+		}
+
+		principal, _ := middlewares.PrincipalFromContext(ctx)
+
+		created, err := svc.Link(ctx, policies.Link{
+			PrincipalID: lh.PrincipalID,
+			HolderID:    holderID,
+			CreatedBy:   principal.ID,
+		})
+		if err != nil {
+			zapctx.L(ctx).Error("link_holder_handler_service_error", zap.Error(err))
+			if errors.Is(err, policies.ErrPrincipalRequired) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			} else if errors.Is(err, policies.ErrHolderNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			} else if errors.Is(err, policies.ErrDuplicatedLink) {
+				return echo.NewHTTPError(http.StatusConflict, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(http.StatusCreated, newLink(created))
+	}
+}
+
+func NewListLinksFunc(svc policies.Service) ListLinksFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var ll listLinks
+		if err := c.Bind(&ll); err != nil {
+			zapctx.L(ctx).Error("list_links_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		links, err := svc.ListLinks(ctx, ll.PrincipalID)
+		if err != nil {
+			zapctx.L(ctx).Error("list_links_handler_service_error", zap.Error(err))
+			return err
+		}
+
+		listed := listedLinks{Links: make([]link, len(links))}
+		for i, l := range links {
+			listed.Links[i] = newLink(l)
+		}
+
+		return c.JSON(http.StatusOK, listed)
+	}
+}
+
+func NewUnlinkHolderFunc(svc policies.Service) UnlinkHolderFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var uh unlinkHolder
+		if err := c.Bind(&uh); err != nil {
+			zapctx.L(ctx).Error("unlink_holder_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		holderID, err := uuid.Parse(uh.HolderID)
+		if err != nil {
+			zapctx.L(ctx).Error("unlink_holder_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid holder id")
+		}
+
+		if err := svc.Unlink(ctx, uh.PrincipalID, holderID); err != nil {
+			zapctx.L(ctx).Error("unlink_holder_handler_service_error", zap.Error(err))
+			if errors.Is(err, policies.ErrLinkNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+func newLink(l policies.Link) link {
+	return link{
+		PrincipalID: l.PrincipalID,
+		HolderID:    l.HolderID.String(),
+		CreatedBy:   l.CreatedBy,
+		CreatedAt:   l.CreatedAt,
+	}
+}
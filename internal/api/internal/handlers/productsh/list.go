@@ -0,0 +1,53 @@
+package productsh
+
+import (
+	"net/http"
+
+	"github.com/dalmarcogd/dock-test/internal/products"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	ListProductsFunc echo.HandlerFunc
+
+	product struct {
+		Type            string  `json:"type"`
+		Name            string  `json:"name"`
+		DailyDebitLimit float64 `json:"daily_debit_limit"`
+		P2PAllowed      bool    `json:"p2p_allowed"`
+		MaxBalance      float64 `json:"max_balance"`
+		MonthlyFee      float64 `json:"monthly_fee"`
+	}
+
+	listedProducts struct {
+		Products []product `json:"products"`
+	}
+)
+
+func NewListProductsFunc(svc products.Service) ListProductsFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		prds, err := svc.List(ctx)
+		if err != nil {
+			zapctx.L(ctx).Error("list_products_handler_service_error", zap.Error(err))
+			return err
+		}
+
+		listed := listedProducts{Products: make([]product, len(prds))}
+		for i, p := range prds {
+			listed.Products[i] = product{
+				Type:            string(p.Type),
+				Name:            p.Name,
+				DailyDebitLimit: p.DailyDebitLimit,
+				P2PAllowed:      p.P2PAllowed,
+				MaxBalance:      p.MaxBalance,
+				MonthlyFee:      p.MonthlyFee,
+			}
+		}
+
+		return c.JSON(http.StatusOK, listed)
+	}
+}
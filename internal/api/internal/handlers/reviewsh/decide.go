@@ -0,0 +1,120 @@
+package reviewsh
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dalmarcogd/dock-test/internal/transactions"
+	"github.com/dalmarcogd/dock-test/pkg/http/middlewares"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	ApproveReviewFunc echo.HandlerFunc
+	RejectReviewFunc  echo.HandlerFunc
+
+	decideReview struct {
+		ID     string `param:"id"`
+		Reason string `json:"reason"`
+	}
+)
+
+func (d decideReview) Validate() error {
+	return validation.ValidateStruct(&d,
+		validation.Field(&d.Reason, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+func NewApproveReviewFunc(svc transactions.Service) ApproveReviewFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		id, decision, err := bindDecision(c, "approve_review")
+		if err != nil {
+			return err
+		}
+
+		approved, err := svc.ApproveReview(ctx, id, decision)
+		if err != nil {
+			zapctx.L(ctx).Error("approve_review_handler_service_error", zap.Error(err))
+			if errors.Is(err, transactions.ErrReviewNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			} else if errors.Is(err, transactions.ErrReviewNotPending) ||
+				errors.Is(err, transactions.ErrReviewExpired) {
+				return echo.NewHTTPError(http.StatusConflict, err.Error())
+			} else if errors.Is(err, transactions.ErrReviewReasonRequired) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			} else if errors.Is(err, transactions.ErrBalanceInsufficientFunds) ||
+				errors.Is(err, transactions.ErrInsufficientDailyLimit) ||
+				errors.Is(err, transactions.ErrP2PNotAllowed) ||
+				errors.Is(err, transactions.ErrMaxBalanceExceeded) ||
+				errors.Is(err, transactions.ErrAccountInactive) ||
+				errors.Is(err, transactions.ErrAccountDebitsBlocked) ||
+				errors.Is(err, transactions.ErrAccountCreditsBlocked) ||
+				errors.Is(err, transactions.ErrAccountP2POutBlocked) {
+				return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+			} else if errors.Is(err, transactions.ErrHolderNotAllowedToDebit) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(http.StatusOK, newReview(approved))
+	}
+}
+
+func NewRejectReviewFunc(svc transactions.Service) RejectReviewFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		id, decision, err := bindDecision(c, "reject_review")
+		if err != nil {
+			return err
+		}
+
+		rejected, err := svc.RejectReview(ctx, id, decision)
+		if err != nil {
+			zapctx.L(ctx).Error("reject_review_handler_service_error", zap.Error(err))
+			if errors.Is(err, transactions.ErrReviewNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			} else if errors.Is(err, transactions.ErrReviewNotPending) {
+				return echo.NewHTTPError(http.StatusConflict, err.Error())
+			} else if errors.Is(err, transactions.ErrReviewReasonRequired) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(http.StatusOK, newReview(rejected))
+	}
+}
+
+// bindDecision reads the review and the decision of the request, logging errors under the handler name.
+func bindDecision(c echo.Context, handler string) (uuid.UUID, transactions.ReviewDecision, error) {
+	ctx := c.Request().Context()
+
+	var dr decideReview
+	if err := c.Bind(&dr); err != nil {
+		zapctx.L(ctx).Error(handler+"_handler_bind_error", zap.Error(err))
+		return uuid.Nil, transactions.ReviewDecision{}, echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	id, err := uuid.Parse(dr.ID)
+	if err != nil {
+		zapctx.L(ctx).Error(handler+"_handler_bind_error", zap.Error(err))
+		return uuid.Nil, transactions.ReviewDecision{}, echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+	}
+
+	if err := dr.Validate(); err != nil {
+		zapctx.L(ctx).Error(handler+"_handler_validation_error", zap.Error(err))
+		return uuid.Nil, transactions.ReviewDecision{}, echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+	}
+
+	principal, _ := middlewares.PrincipalFromContext(ctx)
+
+	return id, transactions.ReviewDecision{Reason: dr.Reason, Actor: principal.ID}, nil
+}
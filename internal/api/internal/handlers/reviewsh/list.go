@@ -0,0 +1,232 @@
+package reviewsh
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/stringers"
+	"github.com/dalmarcogd/dock-test/internal/transactions"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	ListReviewsFunc echo.HandlerFunc
+
+	listReviews struct {
+		Status    string `query:"status"`
+		AccountID string `query:"account_id"`
+		Page      int    `query:"page"`
+		Size      int    `query:"size"`
+	}
+
+	hit struct {
+		RuleID   string `json:"rule_id"`
+		RuleType string `json:"rule_type"`
+		Outcome  string `json:"outcome"`
+		Reason   string `json:"reason"`
+	}
+
+	historyTransaction struct {
+		ID          string  `json:"id"`
+		From        string  `json:"from_account_id,omitempty"`
+		To          string  `json:"to_account_id,omitempty"`
+		Type        string  `json:"type"`
+		Amount      float64 `json:"amount"`
+		Currency    string  `json:"currency,omitempty"`
+		Description string  `json:"description"`
+	}
+
+	statusEvent struct {
+		FromStatus string    `json:"from_status"`
+		ToStatus   string    `json:"to_status"`
+		Reason     string    `json:"reason"`
+		Actor      string    `json:"actor,omitempty"`
+		CreatedAt  time.Time `json:"created_at"`
+	}
+
+	review struct {
+		ID             string               `json:"id"`
+		EvaluationID   string               `json:"evaluation_id"`
+		From           string               `json:"from_account_id"`
+		To             string               `json:"to_account_id"`
+		Amount         float64              `json:"amount"`
+		Currency       string               `json:"currency"`
+		Description    string               `json:"description"`
+		Status         string               `json:"status"`
+		TransactionID  string               `json:"transaction_id,omitempty"`
+		ExpiresAt      time.Time            `json:"expires_at"`
+		DecidedAt      *time.Time           `json:"decided_at,omitempty"`
+		DecidedBy      string               `json:"decided_by,omitempty"`
+		DecisionReason string               `json:"decision_reason,omitempty"`
+		CreatedAt      time.Time            `json:"created_at"`
+		Hits           []hit                `json:"hits,omitempty"`
+		History        []historyTransaction `json:"history,omitempty"`
+		StatusHistory  []statusEvent        `json:"status_history,omitempty"`
+	}
+
+	pagination struct {
+		Page        int `json:"page"`
+		Size        int `json:"size"`
+		TotalItems  int `json:"total_items"`
+		TotalPages  int `json:"total_pages"`
+		TotalInPage int `json:"total_in_page"`
+	}
+
+	listedReviews struct {
+		Pagination pagination `json:"pagination"`
+		Reviews    []review   `json:"reviews"`
+	}
+)
+
+func (l listReviews) Validate() error {
+	statuses := make([]interface{}, len(transactions.ReviewStatuses))
+	for i, s := range transactions.ReviewStatuses {
+		statuses[i] = string(s)
+	}
+
+	return validation.ValidateStruct(&l,
+		validation.Field(&l.Status, validation.In(statuses...)),
+		validation.Field(&l.Page, validation.Min(0)),
+		validation.Field(&l.Size, validation.Min(0), validation.Max(100)),
+	)
+}
+
+func NewListReviewsFunc(svc transactions.Service) ListReviewsFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var lr listReviews
+		if err := c.Bind(&lr); err != nil {
+			zapctx.L(ctx).Error("list_reviews_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		if err := lr.Validate(); err != nil {
+			zapctx.L(ctx).Error("list_reviews_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		var accountID uuid.NullUUID
+		if lr.AccountID != "" {
+			id, err := uuid.Parse(lr.AccountID)
+			if err != nil {
+				zapctx.L(ctx).Error("list_reviews_handler_bind_error", zap.Error(err))
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid account_id")
+			}
+			accountID = uuid.NullUUID{UUID: id, Valid: true}
+		}
+
+		if lr.Status == "" {
+			lr.Status = string(transactions.PendingReview)
+		}
+
+		if lr.Page == 0 {
+			lr.Page = 1
+		}
+
+		if lr.Size == 0 {
+			lr.Size = 20
+		}
+
+		total, reviews, err := svc.ListReviews(ctx, transactions.ReviewFilter{
+			Status:    transactions.ReviewStatus(lr.Status),
+			AccountID: accountID,
+			Page:      lr.Page,
+			Size:      lr.Size,
+		})
+		if err != nil {
+			zapctx.L(ctx).Error("list_reviews_handler_service_error", zap.Error(err))
+			return err
+		}
+
+		totalPages := total / lr.Size
+		if (total % lr.Size) != 0 {
+			totalPages++
+		}
+
+		listed := listedReviews{
+			Pagination: pagination{
+				Page:        lr.Page,
+				Size:        lr.Size,
+				TotalItems:  total,
+				TotalPages:  totalPages,
+				TotalInPage: len(reviews),
+			},
+			Reviews: make([]review, len(reviews)),
+		}
+		for i, r := range reviews {
+			listed.Reviews[i] = newReview(r)
+		}
+
+		return c.JSON(http.StatusOK, listed)
+	}
+}
+
+func newReview(r transactions.Review) review {
+	var transactionID string
+	if r.TransactionID.Valid {
+		transactionID = r.TransactionID.UUID.String()
+	}
+
+	var decidedAt *time.Time
+	if !r.DecidedAt.IsZero() {
+		decidedAt = &r.DecidedAt
+	}
+
+	response := review{
+		ID:             r.ID.String(),
+		EvaluationID:   r.EvaluationID.String(),
+		From:           r.Transaction.From.String(),
+		To:             r.Transaction.To.String(),
+		Amount:         r.Transaction.Amount,
+		Currency:       string(r.Transaction.Currency),
+		Description:    r.Transaction.Description,
+		Status:         string(r.Status),
+		TransactionID:  transactionID,
+		ExpiresAt:      r.ExpiresAt,
+		DecidedAt:      decidedAt,
+		DecidedBy:      r.DecidedBy,
+		DecisionReason: r.DecisionReason,
+		CreatedAt:      r.CreatedAt,
+		Hits:           make([]hit, len(r.Hits)),
+		History:        make([]historyTransaction, len(r.History)),
+		StatusHistory:  make([]statusEvent, len(r.StatusHistory)),
+	}
+
+	for i, h := range r.Hits {
+		response.Hits[i] = hit{
+			RuleID:   h.RuleID.String(),
+			RuleType: string(h.RuleType),
+			Outcome:  string(h.Outcome),
+			Reason:   h.Reason,
+		}
+	}
+
+	for i, t := range r.History {
+		response.History[i] = historyTransaction{
+			ID:          t.ID.String(),
+			From:        stringers.UUIDEmpty(t.From),
+			To:          stringers.UUIDEmpty(t.To),
+			Type:        string(t.Type),
+			Amount:      t.Amount,
+			Currency:    string(t.Currency),
+			Description: t.Description,
+		}
+	}
+
+	for i, e := range r.StatusHistory {
+		response.StatusHistory[i] = statusEvent{
+			FromStatus: string(e.FromStatus),
+			ToStatus:   string(e.ToStatus),
+			Reason:     e.Reason,
+			Actor:      e.Actor,
+			CreatedAt:  e.CreatedAt,
+		}
+	}
+
+	return response
+}
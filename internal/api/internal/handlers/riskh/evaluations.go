@@ -0,0 +1,161 @@
+package riskh
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/risk"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	ListEvaluationsFunc echo.HandlerFunc
+
+	listEvaluations struct {
+		AccountID string `query:"account_id"`
+		Outcome   string `query:"outcome"`
+		Page      int    `query:"page"`
+		Size      int    `query:"size"`
+	}
+
+	hit struct {
+		RuleID   string `json:"rule_id"`
+		RuleType string `json:"rule_type"`
+		Outcome  string `json:"outcome"`
+		Reason   string `json:"reason"`
+	}
+
+	evaluation struct {
+		ID              string    `json:"id"`
+		AccountID       string    `json:"account_id"`
+		CounterpartyID  string    `json:"counterparty_id,omitempty"`
+		TransactionType string    `json:"transaction_type"`
+		Amount          float64   `json:"amount"`
+		Outcome         string    `json:"outcome"`
+		Hits            []hit     `json:"hits"`
+		CreatedAt       time.Time `json:"created_at"`
+	}
+
+	pagination struct {
+		Page        int `json:"page"`
+		Size        int `json:"size"`
+		TotalItems  int `json:"total_items"`
+		TotalPages  int `json:"total_pages"`
+		TotalInPage int `json:"total_in_page"`
+	}
+
+	listedEvaluations struct {
+		Pagination  pagination   `json:"pagination"`
+		Evaluations []evaluation `json:"evaluations"`
+	}
+)
+
+func (l listEvaluations) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(
+			&l.Outcome,
+			validation.In(string(risk.AllowOutcome), string(risk.ReviewOutcome), string(risk.DenyOutcome)),
+		),
+		validation.Field(&l.Page, validation.Min(0)),
+		validation.Field(&l.Size, validation.Min(0), validation.Max(100)),
+	)
+}
+
+func NewListEvaluationsFunc(svc risk.Service) ListEvaluationsFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var le listEvaluations
+		if err := c.Bind(&le); err != nil {
+			zapctx.L(ctx).Error("list_risk_evaluations_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		if err := le.Validate(); err != nil {
+			zapctx.L(ctx).Error("list_risk_evaluations_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		var accountID uuid.NullUUID
+		if le.AccountID != "" {
+			id, err := uuid.Parse(le.AccountID)
+			if err != nil {
+				zapctx.L(ctx).Error("list_risk_evaluations_handler_bind_error", zap.Error(err))
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid account_id")
+			}
+			accountID = uuid.NullUUID{UUID: id, Valid: true}
+		}
+
+		if le.Page == 0 {
+			le.Page = 1
+		}
+
+		if le.Size == 0 {
+			le.Size = 20
+		}
+
+		total, evaluations, err := svc.ListEvaluations(ctx, risk.EvaluationFilter{
+			AccountID: accountID,
+			Outcome:   risk.Outcome(le.Outcome),
+			Page:      le.Page,
+			Size:      le.Size,
+		})
+		if err != nil {
+			zapctx.L(ctx).Error("list_risk_evaluations_handler_service_error", zap.Error(err))
+			return err
+		}
+
+		totalPages := total / le.Size
+		if (total % le.Size) != 0 {
+			totalPages++
+		}
+
+		listed := listedEvaluations{
+			Pagination: pagination{
+				Page:        le.Page,
+				Size:        le.Size,
+				TotalItems:  total,
+				TotalPages:  totalPages,
+				TotalInPage: len(evaluations),
+			},
+			Evaluations: make([]evaluation, len(evaluations)),
+		}
+		for i, e := range evaluations {
+			listed.Evaluations[i] = newEvaluation(e)
+		}
+
+		return c.JSON(http.StatusOK, listed)
+	}
+}
+
+func newEvaluation(e risk.Evaluation) evaluation {
+	var counterpartyID string
+	if e.CounterpartyID.Valid {
+		counterpartyID = e.CounterpartyID.UUID.String()
+	}
+
+	hits := make([]hit, len(e.Hits))
+	for i, h := range e.Hits {
+		hits[i] = hit{
+			RuleID:   h.RuleID.String(),
+			RuleType: string(h.RuleType),
+			Outcome:  string(h.Outcome),
+			Reason:   h.Reason,
+		}
+	}
+
+	return evaluation{
+		ID:              e.ID.String(),
+		AccountID:       e.AccountID.String(),
+		CounterpartyID:  counterpartyID,
+		TransactionType: e.TransactionType,
+		Amount:          e.Amount,
+		Outcome:         string(e.Outcome),
+		Hits:            hits,
+		CreatedAt:       e.CreatedAt,
+	}
+}
@@ -0,0 +1,223 @@
+package riskh
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/risk"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	CreateRuleFunc echo.HandlerFunc
+	ListRulesFunc  echo.HandlerFunc
+	UpdateRuleFunc echo.HandlerFunc
+	DeleteRuleFunc echo.HandlerFunc
+
+	ruleParameters struct {
+		Type          string  `json:"type"`
+		Outcome       string  `json:"outcome"`
+		Enabled       bool    `json:"enabled"`
+		Count         int     `json:"count"`
+		WindowMinutes int     `json:"window_minutes"`
+		Multiplier    float64 `json:"multiplier"`
+		MinAmount     float64 `json:"min_amount"`
+	}
+
+	updateRule struct {
+		ID string `param:"id"`
+		ruleParameters
+	}
+
+	deleteRule struct {
+		ID string `param:"id"`
+	}
+
+	rule struct {
+		ID            string    `json:"id"`
+		Type          string    `json:"type"`
+		Outcome       string    `json:"outcome"`
+		Enabled       bool      `json:"enabled"`
+		Count         int       `json:"count"`
+		WindowMinutes int       `json:"window_minutes"`
+		Multiplier    float64   `json:"multiplier"`
+		MinAmount     float64   `json:"min_amount"`
+		CreatedAt     time.Time `json:"created_at"`
+		UpdatedAt     time.Time `json:"updated_at"`
+	}
+
+	listedRules struct {
+		Rules []rule `json:"rules"`
+	}
+)
+
+func (r ruleParameters) Validate() error {
+	ruleTypes := make([]interface{}, len(risk.RuleTypes))
+	for i, t := range risk.RuleTypes {
+		ruleTypes[i] = string(t)
+	}
+
+	outcomes := make([]interface{}, len(risk.Outcomes))
+	for i, o := range risk.Outcomes {
+		outcomes[i] = string(o)
+	}
+
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Type, validation.Required, validation.In(ruleTypes...)),
+		validation.Field(&r.Outcome, validation.Required, validation.In(outcomes...)),
+		validation.Field(&r.Count, validation.Min(0)),
+		validation.Field(&r.WindowMinutes, validation.Min(0)),
+		validation.Field(&r.Multiplier, validation.Min(0.0)),
+		validation.Field(&r.MinAmount, validation.Min(0.0)),
+	)
+}
+
+func (r ruleParameters) rule(id uuid.UUID) risk.Rule {
+	return risk.Rule{
+		ID:            id,
+		Type:          risk.RuleType(r.Type),
+		Outcome:       risk.Outcome(r.Outcome),
+		Enabled:       r.Enabled,
+		Count:         r.Count,
+		WindowMinutes: r.WindowMinutes,
+		Multiplier:    r.Multiplier,
+		MinAmount:     r.MinAmount,
+	}
+}
+
+func NewCreateRuleFunc(svc risk.Service) CreateRuleFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var cr ruleParameters
+		if err := c.Bind(&cr); err != nil {
+			zapctx.L(ctx).Error("create_risk_rule_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		if err := cr.Validate(); err != nil {
+			zapctx.L(ctx).Error("create_risk_rule_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		created, err := svc.CreateRule(ctx, cr.rule(uuid.Nil))
+		if err != nil {
+			zapctx.L(ctx).Error("create_risk_rule_handler_service_error", zap.Error(err))
+			if invalidRule(err) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(http.StatusCreated, newRule(created))
+	}
+}
+
+func NewListRulesFunc(svc risk.Service) ListRulesFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		rules, err := svc.ListRules(ctx)
+		if err != nil {
+			zapctx.L(ctx).Error("list_risk_rules_handler_service_error", zap.Error(err))
+			return err
+		}
+
+		listed := listedRules{Rules: make([]rule, len(rules))}
+		for i, r := range rules {
+			listed.Rules[i] = newRule(r)
+		}
+
+		return c.JSON(http.StatusOK, listed)
+	}
+}
+
+func NewUpdateRuleFunc(svc risk.Service) UpdateRuleFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var ur updateRule
+		if err := c.Bind(&ur); err != nil {
+			zapctx.L(ctx).Error("update_risk_rule_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(ur.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("update_risk_rule_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		if err := ur.Validate(); err != nil {
+			zapctx.L(ctx).Error("update_risk_rule_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		updated, err := svc.UpdateRule(ctx, ur.rule(id))
+		if err != nil {
+			zapctx.L(ctx).Error("update_risk_rule_handler_service_error", zap.Error(err))
+			if invalidRule(err) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			} else if errors.Is(err, risk.ErrRuleNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(http.StatusOK, newRule(updated))
+	}
+}
+
+func NewDeleteRuleFunc(svc risk.Service) DeleteRuleFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var dr deleteRule
+		if err := c.Bind(&dr); err != nil {
+			zapctx.L(ctx).Error("delete_risk_rule_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(dr.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("delete_risk_rule_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		if err := svc.DeleteRule(ctx, id); err != nil {
+			zapctx.L(ctx).Error("delete_risk_rule_handler_service_error", zap.Error(err))
+			if errors.Is(err, risk.ErrRuleNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+func invalidRule(err error) bool {
+	return errors.Is(err, risk.ErrInvalidRuleType) ||
+		errors.Is(err, risk.ErrInvalidRuleOutcome) ||
+		errors.Is(err, risk.ErrInvalidRule)
+}
+
+func newRule(r risk.Rule) rule {
+	return rule{
+		ID:            r.ID.String(),
+		Type:          string(r.Type),
+		Outcome:       string(r.Outcome),
+		Enabled:       r.Enabled,
+		Count:         r.Count,
+		WindowMinutes: r.WindowMinutes,
+		Multiplier:    r.Multiplier,
+		MinAmount:     r.MinAmount,
+		CreatedAt:     r.CreatedAt,
+		UpdatedAt:     r.UpdatedAt,
+	}
+}
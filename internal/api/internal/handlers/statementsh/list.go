@@ -1,26 +1,41 @@
 package statementsh
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/stringers"
+	"github.com/dalmarcogd/dock-test/internal/policies"
 	"github.com/dalmarcogd/dock-test/internal/statements"
+	"github.com/dalmarcogd/dock-test/internal/transactions"
 	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
 )
 
+// camt053Format lists the statement as an ISO 20022 camt.053 document, for the whole period from
+// created_at_begin to created_at_end, both inclusive.
+const camt053Format = "camt.053"
+
 type (
 	ListAccountStatementFunc echo.HandlerFunc
 
 	listAccountStatement struct {
-		AccountID      string `param:"id"`
-		Sort           int    `query:"sort"`
-		Page           int    `query:"page"`
-		Size           int    `query:"size"`
-		CreatedAtBegin string `query:"created_at_begin"`
-		CreatedAtEnd   string `query:"created_at_end"`
+		AccountID      string  `param:"id"`
+		Sort           int     `query:"sort"`
+		Page           int     `query:"page"`
+		Size           int     `query:"size"`
+		CreatedAtBegin string  `query:"created_at_begin"`
+		CreatedAtEnd   string  `query:"created_at_end"`
+		Type           string  `query:"type"`
+		Direction      string  `query:"direction"`
+		AmountMin      float64 `query:"amount_min"`
+		AmountMax      float64 `query:"amount_max"`
+		Format         string  `query:"format"`
 	}
 
 	account struct {
@@ -29,11 +44,18 @@ type (
 	}
 
 	statement struct {
-		FromAccount *account  `json:"from_account,omitempty"`
-		ToAccount   *account  `json:"to_account,omitempty"`
-		Type        string    `json:"type"`
-		Amount      float64   `json:"amount"`
-		CreatedAt   time.Time `json:"created_at"`
+		ID                   string    `json:"id"`
+		RelatedTransactionID string    `json:"related_transaction_id,omitempty"`
+		FromAccount          *account  `json:"from_account,omitempty"`
+		ToAccount            *account  `json:"to_account,omitempty"`
+		Type                 string    `json:"type"`
+		Amount               float64   `json:"amount"`
+		Currency             string    `json:"currency"`
+		ToAmount             float64   `json:"to_amount,omitempty"`
+		ToCurrency           string    `json:"to_currency,omitempty"`
+		FxRate               float64   `json:"fx_rate,omitempty"`
+		Description          string    `json:"description"`
+		CreatedAt            time.Time `json:"created_at"`
 	}
 
 	pagination struct {
@@ -52,7 +74,39 @@ type (
 	}
 )
 
-func NewListAccountStatementFunc(svc statements.Service) ListAccountStatementFunc {
+func (l listAccountStatement) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(
+			&l.Type,
+			validation.In(
+				string(transactions.CreditTransaction),
+				string(transactions.DebitTransaction),
+				string(transactions.P2PTransaction),
+				string(transactions.InternalTransaction),
+				string(transactions.FeeTransaction),
+				string(transactions.TEDTransaction),
+				string(transactions.TEDReversalTransaction),
+			),
+		),
+		validation.Field(
+			&l.Direction,
+			validation.In(string(statements.InDirection), string(statements.OutDirection)),
+		),
+		validation.Field(&l.AmountMin, validation.Min(float64(0))),
+		validation.Field(&l.AmountMax, validation.Min(l.AmountMin)),
+		validation.Field(&l.Format, validation.In(camt053Format)),
+		validation.Field(
+			&l.CreatedAtBegin,
+			validation.When(l.Format == camt053Format, validation.Required),
+		),
+		validation.Field(
+			&l.CreatedAtEnd,
+			validation.When(l.Format == camt053Format, validation.Required),
+		),
+	)
+}
+
+func NewListAccountStatementFunc(svc statements.Service, ps policies.Service) ListAccountStatementFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
 
@@ -68,6 +122,19 @@ func NewListAccountStatementFunc(svc statements.Service) ListAccountStatementFun
 			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
 		}
 
+		if err := ps.AuthorizeAccount(ctx, id, policies.HolderAccess); err != nil {
+			zapctx.L(ctx).Error("list_account_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		if err := lsa.Validate(); err != nil {
+			zapctx.L(ctx).Error("list_account_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
 		if lsa.Page == 0 {
 			lsa.Page = 1
 		}
@@ -93,6 +160,21 @@ func NewListAccountStatementFunc(svc statements.Service) ListAccountStatementFun
 			}
 		}
 
+		if lsa.Format == camt053Format {
+			body, err := svc.GetCamt053(ctx, id, createdAtBegin, createdAtEnd.AddDate(0, 0, 1))
+			if err != nil {
+				zapctx.L(ctx).Error("list_account_handler_service_error", zap.Error(err))
+				if errors.Is(err, accounts.ErrAccountNotFound) {
+					return echo.NewHTTPError(http.StatusNotFound, err.Error())
+				} else if errors.Is(err, statements.ErrInvalidReportPeriod) {
+					return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+				}
+				return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			return c.Blob(http.StatusOK, echo.MIMEApplicationXMLCharsetUTF8, body)
+		}
+
 		total, stats, err := svc.List(ctx, statements.ListFilter{
 			Sort:           lsa.Sort,
 			Page:           lsa.Page,
@@ -100,6 +182,10 @@ func NewListAccountStatementFunc(svc statements.Service) ListAccountStatementFun
 			AccountID:      id,
 			CreatedAtBegin: createdAtBegin,
 			CreatedAtEnd:   createdAtEnd,
+			Type:           lsa.Type,
+			Direction:      statements.Direction(lsa.Direction),
+			AmountMin:      lsa.AmountMin,
+			AmountMax:      lsa.AmountMax,
 		})
 		if err != nil {
 			zapctx.L(ctx).Error("list_account_handler_service_error", zap.Error(err))
@@ -114,9 +200,16 @@ func NewListAccountStatementFunc(svc statements.Service) ListAccountStatementFun
 		accountStatements := make([]statement, len(stats))
 		for i, transaction := range stats {
 			accountStatements[i] = statement{
-				Type:      transaction.Type,
-				Amount:    transaction.Amount,
-				CreatedAt: transaction.CreatedAt,
+				ID:                   transaction.ID.String(),
+				RelatedTransactionID: stringers.UUIDEmpty(transaction.RelatedTransactionID.UUID),
+				Type:                 transaction.Type,
+				Amount:               transaction.Amount,
+				Currency:             string(transaction.Currency),
+				ToAmount:             transaction.ToAmount,
+				ToCurrency:           string(transaction.ToCurrency),
+				FxRate:               transaction.FxRate,
+				Description:          transaction.Description,
+				CreatedAt:            transaction.CreatedAt,
 			}
 			if transaction.FromAccount.ID != uuid.Nil {
 				accountStatements[i].FromAccount = &account{
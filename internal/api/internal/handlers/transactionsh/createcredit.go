@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/stringers"
+	"github.com/dalmarcogd/dock-test/internal/exchange"
 	"github.com/dalmarcogd/dock-test/internal/transactions"
 	"github.com/dalmarcogd/dock-test/pkg/zapctx"
 	"github.com/google/uuid"
@@ -18,6 +19,7 @@ type (
 	createCreditTransaction struct {
 		To          string  `json:"to_account_id"`
 		Amount      float64 `json:"amount"`
+		Currency    string  `json:"currency"`
 		Description string  `json:"description"`
 	}
 )
@@ -45,12 +47,19 @@ func NewCreateCreditTransactionFunc(svc transactions.Service) CreateCreditTransa
 		transaction, err := svc.CreateCredit(ctx, transactions.Transaction{
 			To:          toID,
 			Amount:      trx.Amount,
+			Currency:    exchange.Currency(trx.Currency),
 			Description: trx.Description,
 		})
 		if err != nil {
 			zapctx.L(ctx).Error("create_credit_transaction_handler_service_error", zap.Error(err))
 			if errors.Is(err, transactions.ErrAccountNotfound) {
 				return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			} else if errors.Is(err, transactions.ErrCurrencyMismatch) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			} else if errors.Is(err, transactions.ErrMaxBalanceExceeded) ||
+				errors.Is(err, transactions.ErrAccountCreditsBlocked) ||
+				errors.Is(err, transactions.ErrPocketExternalTransaction) {
+				return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
 			}
 			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 		}
@@ -62,6 +71,7 @@ func NewCreateCreditTransactionFunc(svc transactions.Service) CreateCreditTransa
 				To:          stringers.UUIDEmpty(transaction.To),
 				Type:        string(transaction.Type),
 				Amount:      transaction.Amount,
+				Currency:    string(transaction.Currency),
 				Description: transaction.Description,
 			},
 		)
@@ -5,7 +5,10 @@ import (
 	"net/http"
 
 	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/stringers"
+	"github.com/dalmarcogd/dock-test/internal/exchange"
+	"github.com/dalmarcogd/dock-test/internal/policies"
 	"github.com/dalmarcogd/dock-test/internal/transactions"
+	"github.com/dalmarcogd/dock-test/pkg/http/middlewares"
 	"github.com/dalmarcogd/dock-test/pkg/zapctx"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -18,11 +21,12 @@ type (
 	createDebitTransaction struct {
 		From        string  `json:"from_account_id"`
 		Amount      float64 `json:"amount"`
+		Currency    string  `json:"currency"`
 		Description string  `json:"description"`
 	}
 )
 
-func NewCreateDebitTransactionFunc(svc transactions.Service) CreateDebitTransactionFunc {
+func NewCreateDebitTransactionFunc(svc transactions.Service, ps policies.Service) CreateDebitTransactionFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
 
@@ -42,17 +46,39 @@ func NewCreateDebitTransactionFunc(svc transactions.Service) CreateDebitTransact
 			}
 		}
 
+		if err := ps.AuthorizeAccount(ctx, fromID, policies.DebitAccess); err != nil {
+			zapctx.L(ctx).Error("create_debit_transaction_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		principal, _ := middlewares.PrincipalFromContext(ctx)
+
 		transaction, err := svc.CreateDebit(ctx, transactions.Transaction{
 			From:        fromID,
 			Amount:      trx.Amount,
+			Currency:    exchange.Currency(trx.Currency),
 			Description: trx.Description,
+			RequestedBy: principal.ID,
 		})
 		if err != nil {
 			zapctx.L(ctx).Error("create_debit_transaction_handler_service_error", zap.Error(err))
-			if errors.Is(err, transactions.ErrBalanceInsufficientFunds) {
+			if errors.Is(err, transactions.ErrBalanceInsufficientFunds) ||
+				errors.Is(err, transactions.ErrAccountDebitsBlocked) ||
+				errors.Is(err, transactions.ErrPocketExternalTransaction) {
 				return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+			} else if errors.Is(err, transactions.ErrRequesterRequired) ||
+				errors.Is(err, transactions.ErrHolderNotAllowedToDebit) ||
+				errors.Is(err, transactions.ErrTransactionDenied) ||
+				errors.Is(err, transactions.ErrTransactionUnderReview) ||
+				errors.Is(err, transactions.ErrApprovalRequired) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
 			} else if errors.Is(err, transactions.ErrAccountNotfound) {
 				return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			} else if errors.Is(err, transactions.ErrCurrencyMismatch) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
 			}
 
 			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
@@ -65,7 +91,9 @@ func NewCreateDebitTransactionFunc(svc transactions.Service) CreateDebitTransact
 				From:        stringers.UUIDEmpty(transaction.From),
 				Type:        string(transaction.Type),
 				Amount:      transaction.Amount,
+				Currency:    string(transaction.Currency),
 				Description: transaction.Description,
+				Fee:         transaction.Fee,
 			},
 		)
 	}
@@ -0,0 +1,109 @@
+package transactionsh
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/stringers"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/internal/transactions"
+	"github.com/dalmarcogd/dock-test/pkg/http/middlewares"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	CreateInternalTransactionFunc echo.HandlerFunc
+
+	createInternalTransaction struct {
+		From        string  `json:"from_account_id"`
+		To          string  `json:"to_account_id"`
+		Amount      float64 `json:"amount"`
+		Description string  `json:"description"`
+	}
+)
+
+func NewCreateInternalTransactionFunc(
+	svc transactions.Service,
+	ps policies.Service,
+) CreateInternalTransactionFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var trx createInternalTransaction
+		err := c.Bind(&trx)
+		if err != nil {
+			zapctx.L(ctx).Error("create_internal_transaction_handler_bind_error", zap.Error(err))
+			return err
+		}
+
+		var fromID uuid.UUID
+		if trx.From != "" {
+			fromID, err = uuid.Parse(trx.From)
+			if err != nil {
+				zapctx.L(ctx).Error("create_internal_transaction_handler_parse_error", zap.Error(err))
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid from account id")
+			}
+		}
+
+		if err := ps.AuthorizeAccount(ctx, fromID, policies.DebitAccess); err != nil {
+			zapctx.L(ctx).Error("create_internal_transaction_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		var toID uuid.UUID
+		if trx.To != "" {
+			toID, err = uuid.Parse(trx.To)
+			if err != nil {
+				zapctx.L(ctx).Error("create_internal_transaction_handler_parse_error", zap.Error(err))
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid to account id")
+			}
+		}
+
+		principal, _ := middlewares.PrincipalFromContext(ctx)
+
+		transaction, err := svc.CreateInternal(ctx, transactions.Transaction{
+			From:        fromID,
+			To:          toID,
+			Amount:      trx.Amount,
+			Description: trx.Description,
+			RequestedBy: principal.ID,
+		})
+		if err != nil {
+			zapctx.L(ctx).Error("create_internal_transaction_handler_service_error", zap.Error(err))
+			if errors.Is(err, transactions.ErrBalanceInsufficientFunds) ||
+				errors.Is(err, transactions.ErrAccountDebitsBlocked) ||
+				errors.Is(err, transactions.ErrAccountCreditsBlocked) ||
+				errors.Is(err, transactions.ErrAccountsNotRelated) {
+				return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+			} else if errors.Is(err, transactions.ErrRequesterRequired) ||
+				errors.Is(err, transactions.ErrHolderNotAllowedToDebit) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			} else if errors.Is(err, transactions.ErrAccountNotfound) {
+				return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			} else if errors.Is(err, transactions.ErrFromAccountToAccountShouldBeDifferent) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
+
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(
+			http.StatusCreated,
+			createdTransaction{
+				ID:          stringers.UUIDEmpty(transaction.ID),
+				From:        stringers.UUIDEmpty(transaction.From),
+				To:          stringers.UUIDEmpty(transaction.To),
+				Type:        string(transaction.Type),
+				Amount:      transaction.Amount,
+				Currency:    string(transaction.Currency),
+				Description: transaction.Description,
+			},
+		)
+	}
+}
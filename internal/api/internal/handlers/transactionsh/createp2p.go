@@ -3,27 +3,57 @@ package transactionsh
 import (
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/stringers"
+	"github.com/dalmarcogd/dock-test/internal/approvals"
+	"github.com/dalmarcogd/dock-test/internal/exchange"
+	"github.com/dalmarcogd/dock-test/internal/keys"
+	"github.com/dalmarcogd/dock-test/internal/policies"
 	"github.com/dalmarcogd/dock-test/internal/transactions"
+	"github.com/dalmarcogd/dock-test/pkg/http/middlewares"
 	"github.com/dalmarcogd/dock-test/pkg/zapctx"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
 )
 
+// reviewStatus is the status of a P2P parked for a manual review, its funds held until decided.
+const reviewStatus = "REVIEW"
+
 type (
 	CreateP2PTransactionFunc echo.HandlerFunc
 
 	createP2PTransaction struct {
 		From        string  `json:"from_account_id"`
 		To          string  `json:"to_account_id"`
+		ToKey       string  `json:"to_key"`
 		Amount      float64 `json:"amount"`
+		Currency    string  `json:"currency"`
 		Description string  `json:"description"`
 	}
+
+	// submittedApproval is a P2P waiting for a second principal to approve it.
+	submittedApproval struct {
+		ID          string    `json:"approval_id"`
+		Operation   string    `json:"operation"`
+		AccountID   string    `json:"account_id"`
+		Amount      float64   `json:"amount"`
+		Status      string    `json:"status"`
+		SubmittedBy string    `json:"submitted_by"`
+		ExpiresAt   time.Time `json:"expires_at"`
+		CreatedAt   time.Time `json:"created_at"`
+	}
 )
 
-func NewCreateP2PTransactionFunc(svc transactions.Service) CreateP2PTransactionFunc {
+// NewCreateP2PTransactionFunc makes the P2P, or submits it when it needs the approval of a second
+// principal allowed to debit the account, answering 202 Accepted.
+func NewCreateP2PTransactionFunc(
+	svc transactions.Service,
+	ks keys.Service,
+	ps policies.Service,
+	as approvals.Service,
+) CreateP2PTransactionFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
 
@@ -43,6 +73,18 @@ func NewCreateP2PTransactionFunc(svc transactions.Service) CreateP2PTransactionF
 			}
 		}
 
+		if err := ps.AuthorizeAccount(ctx, fromID, policies.DebitAccess); err != nil {
+			zapctx.L(ctx).Error("create_p2p_transaction_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		if trx.To != "" && trx.ToKey != "" {
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "inform either to_account_id or to_key")
+		}
+
 		var toID uuid.UUID
 		if trx.To != "" {
 			toID, err = uuid.Parse(trx.To)
@@ -52,25 +94,94 @@ func NewCreateP2PTransactionFunc(svc transactions.Service) CreateP2PTransactionF
 			}
 		}
 
-		transaction, err := svc.CreateP2P(ctx, transactions.Transaction{
+		if trx.ToKey != "" {
+			owner, err := ks.Resolve(ctx, trx.ToKey)
+			if err != nil {
+				zapctx.L(ctx).Error("create_p2p_transaction_handler_key_service_error", zap.Error(err))
+				if errors.Is(err, keys.ErrKeyNotFound) {
+					return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+				}
+				return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			toID = owner.AccountID
+		}
+
+		principal, _ := middlewares.PrincipalFromContext(ctx)
+
+		p2p := transactions.Transaction{
 			From:        fromID,
 			To:          toID,
 			Amount:      trx.Amount,
+			Currency:    exchange.Currency(trx.Currency),
 			Description: trx.Description,
-		})
+			RequestedBy: principal.ID,
+			Reviewable:  true,
+		}
+
+		transaction, err := svc.CreateP2P(ctx, p2p)
+		if errors.Is(err, transactions.ErrApprovalRequired) {
+			approval, err := as.SubmitP2P(ctx, p2p)
+			if err != nil {
+				zapctx.L(ctx).Error("create_p2p_transaction_handler_approval_service_error", zap.Error(err))
+				if errors.Is(err, approvals.ErrSubmitterRequired) {
+					return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+				}
+				return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			return c.JSON(http.StatusAccepted, submittedApproval{
+				ID:          approval.ID.String(),
+				Operation:   string(approval.Operation),
+				AccountID:   approval.AccountID.String(),
+				Amount:      p2p.Amount,
+				Status:      string(approval.Status),
+				SubmittedBy: approval.SubmittedBy,
+				ExpiresAt:   approval.ExpiresAt,
+				CreatedAt:   approval.CreatedAt,
+			})
+		}
 		if err != nil {
 			zapctx.L(ctx).Error("create_p2p_transaction_handler_service_error", zap.Error(err))
-			if errors.Is(err, transactions.ErrBalanceInsufficientFunds) {
+			if errors.Is(err, transactions.ErrBalanceInsufficientFunds) ||
+				errors.Is(err, transactions.ErrP2PNotAllowed) ||
+				errors.Is(err, transactions.ErrMaxBalanceExceeded) ||
+				errors.Is(err, transactions.ErrAccountDebitsBlocked) ||
+				errors.Is(err, transactions.ErrAccountCreditsBlocked) ||
+				errors.Is(err, transactions.ErrAccountP2POutBlocked) ||
+				errors.Is(err, transactions.ErrPocketExternalTransaction) {
 				return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+			} else if errors.Is(err, transactions.ErrRequesterRequired) ||
+				errors.Is(err, transactions.ErrHolderNotAllowedToDebit) ||
+				errors.Is(err, transactions.ErrTransactionDenied) ||
+				errors.Is(err, transactions.ErrTransactionUnderReview) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
 			} else if errors.Is(err, transactions.ErrAccountNotfound) {
 				return echo.NewHTTPError(http.StatusBadRequest, err.Error())
-			} else if errors.Is(err, transactions.ErrFromAccountToAccountShouldBeDifferent) {
+			} else if errors.Is(err, transactions.ErrFromAccountToAccountShouldBeDifferent) ||
+				errors.Is(err, transactions.ErrCurrencyMismatch) ||
+				errors.Is(err, transactions.ErrExchangeRateNotFound) {
 				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
 			}
 
 			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 		}
 
+		if transaction.ReviewID.Valid {
+			return c.JSON(
+				http.StatusAccepted,
+				createdTransaction{
+					From:        stringers.UUIDEmpty(transaction.From),
+					To:          stringers.UUIDEmpty(transaction.To),
+					Type:        string(transaction.Type),
+					Amount:      transaction.Amount,
+					Currency:    string(transaction.Currency),
+					Description: transaction.Description,
+					Status:      reviewStatus,
+					ReviewID:    transaction.ReviewID.UUID.String(),
+				},
+			)
+		}
+
 		return c.JSON(
 			http.StatusCreated,
 			createdTransaction{
@@ -79,7 +190,12 @@ func NewCreateP2PTransactionFunc(svc transactions.Service) CreateP2PTransactionF
 				To:          stringers.UUIDEmpty(transaction.To),
 				Type:        string(transaction.Type),
 				Amount:      transaction.Amount,
+				Currency:    string(transaction.Currency),
+				ToAmount:    transaction.ToAmount,
+				ToCurrency:  string(transaction.ToCurrency),
+				FxRate:      transaction.FxRate,
 				Description: transaction.Description,
+				Fee:         transaction.Fee,
 			},
 		)
 	}
@@ -1,9 +1,11 @@
 package transactionsh
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/stringers"
+	"github.com/dalmarcogd/dock-test/internal/policies"
 	"github.com/dalmarcogd/dock-test/internal/transactions"
 	"github.com/dalmarcogd/dock-test/pkg/zapctx"
 	"github.com/google/uuid"
@@ -19,7 +21,7 @@ type (
 	}
 )
 
-func NewGetByIDTransactionFunc(svc transactions.Service) GetByIDTransactionFunc {
+func NewGetByIDTransactionFunc(svc transactions.Service, ps policies.Service) GetByIDTransactionFunc {
 	return func(c echo.Context) error {
 		ctx := c.Request().Context()
 
@@ -41,15 +43,33 @@ func NewGetByIDTransactionFunc(svc transactions.Service) GetByIDTransactionFunc
 			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 		}
 
+		// the transaction is seen by the holders of any of its accounts.
+		err = ps.AuthorizeAccount(ctx, transaction.From, policies.HolderAccess)
+		if errors.Is(err, policies.ErrForbidden) && transaction.To != uuid.Nil {
+			err = ps.AuthorizeAccount(ctx, transaction.To, policies.HolderAccess)
+		}
+		if err != nil {
+			zapctx.L(ctx).Error("get_by_id_transactions_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
 		return c.JSON(
 			http.StatusOK,
 			createdTransaction{
-				ID:          stringers.UUIDEmpty(transaction.ID),
-				From:        stringers.UUIDEmpty(transaction.From),
-				To:          stringers.UUIDEmpty(transaction.To),
-				Type:        string(transaction.Type),
-				Amount:      transaction.Amount,
-				Description: transaction.Description,
+				ID:                   stringers.UUIDEmpty(transaction.ID),
+				From:                 stringers.UUIDEmpty(transaction.From),
+				To:                   stringers.UUIDEmpty(transaction.To),
+				Type:                 string(transaction.Type),
+				Amount:               transaction.Amount,
+				Currency:             string(transaction.Currency),
+				ToAmount:             transaction.ToAmount,
+				ToCurrency:           string(transaction.ToCurrency),
+				FxRate:               transaction.FxRate,
+				Description:          transaction.Description,
+				RelatedTransactionID: stringers.UUIDEmpty(transaction.RelatedTransactionID.UUID),
 			},
 		)
 	}
@@ -1,10 +1,18 @@
 package transactionsh
 
 type createdTransaction struct {
-	ID          string  `json:"id"`
-	From        string  `json:"from_account_id,omitempty"`
-	To          string  `json:"to_account_id,omitempty"`
-	Type        string  `json:"type"`
-	Amount      float64 `json:"amount"`
-	Description string  `json:"description"`
+	ID                   string  `json:"id"`
+	From                 string  `json:"from_account_id,omitempty"`
+	To                   string  `json:"to_account_id,omitempty"`
+	Type                 string  `json:"type"`
+	Amount               float64 `json:"amount"`
+	Currency             string  `json:"currency,omitempty"`
+	ToAmount             float64 `json:"to_amount,omitempty"`
+	ToCurrency           string  `json:"to_currency,omitempty"`
+	FxRate               float64 `json:"fx_rate,omitempty"`
+	Description          string  `json:"description"`
+	Fee                  float64 `json:"fee,omitempty"`
+	RelatedTransactionID string  `json:"related_transaction_id,omitempty"`
+	Status               string  `json:"status,omitempty"`
+	ReviewID             string  `json:"review_id,omitempty"`
 }
@@ -0,0 +1,203 @@
+package transfersh
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/stringers"
+	"github.com/dalmarcogd/dock-test/internal/approvals"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/internal/transactions"
+	"github.com/dalmarcogd/dock-test/internal/transfers"
+	"github.com/dalmarcogd/dock-test/pkg/http/middlewares"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	CreateTransferFunc echo.HandlerFunc
+
+	createTransfer struct {
+		AccountID      string  `json:"account_id"`
+		Amount         float64 `json:"amount"`
+		BankCode       string  `json:"bank_code"`
+		Agency         string  `json:"agency"`
+		AccountNumber  string  `json:"account_number"`
+		DocumentNumber string  `json:"document_number"`
+		Name           string  `json:"name"`
+		Description    string  `json:"description"`
+	}
+
+	transfer struct {
+		ID                    string     `json:"id"`
+		AccountID             string     `json:"account_id"`
+		Amount                float64    `json:"amount"`
+		BankCode              string     `json:"bank_code"`
+		Agency                string     `json:"agency"`
+		AccountNumber         string     `json:"account_number"`
+		DocumentNumber        string     `json:"document_number"`
+		Name                  string     `json:"name"`
+		Description           string     `json:"description"`
+		Reference             string     `json:"reference"`
+		Status                string     `json:"status"`
+		DebitTransactionID    string     `json:"debit_transaction_id,omitempty"`
+		ReversalTransactionID string     `json:"reversal_transaction_id,omitempty"`
+		RemittanceID          string     `json:"remittance_id,omitempty"`
+		Occurrences           string     `json:"occurrences,omitempty"`
+		CreatedAt             time.Time  `json:"created_at"`
+		SettledAt             *time.Time `json:"settled_at,omitempty"`
+	}
+
+	// submittedApproval is a transfer waiting for a second principal to approve it.
+	submittedApproval struct {
+		ID          string    `json:"approval_id"`
+		Operation   string    `json:"operation"`
+		AccountID   string    `json:"account_id"`
+		Amount      float64   `json:"amount"`
+		Status      string    `json:"status"`
+		SubmittedBy string    `json:"submitted_by"`
+		ExpiresAt   time.Time `json:"expires_at"`
+		CreatedAt   time.Time `json:"created_at"`
+	}
+)
+
+func (c createTransfer) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.AccountID, validation.Required),
+		validation.Field(&c.Amount, validation.Required, validation.Min(0.01)),
+		validation.Field(&c.BankCode, validation.Required),
+		validation.Field(&c.Agency, validation.Required),
+		validation.Field(&c.AccountNumber, validation.Required),
+		validation.Field(&c.DocumentNumber, validation.Required),
+		validation.Field(&c.Name, validation.Required, validation.Length(1, 255)),
+		validation.Field(&c.Description, validation.Length(0, 100)),
+	)
+}
+
+// NewCreateTransferFunc sends the transfer, or submits it when it needs the approval of a second
+// principal allowed to debit the account, answering 202 Accepted.
+func NewCreateTransferFunc(svc transfers.Service, as approvals.Service, ps policies.Service) CreateTransferFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var ct createTransfer
+		if err := c.Bind(&ct); err != nil {
+			zapctx.L(ctx).Error("create_transfer_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		if err := ct.Validate(); err != nil {
+			zapctx.L(ctx).Error("create_transfer_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		accountID, err := uuid.Parse(ct.AccountID)
+		if err != nil {
+			zapctx.L(ctx).Error("create_transfer_handler_parse_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid account id")
+		}
+
+		if err := ps.AuthorizeAccount(ctx, accountID, policies.DebitAccess); err != nil {
+			zapctx.L(ctx).Error("create_transfer_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		principal, _ := middlewares.PrincipalFromContext(ctx)
+
+		transfer := transfers.Transfer{
+			AccountID: accountID,
+			Amount:    ct.Amount,
+			Beneficiary: transfers.Beneficiary{
+				BankCode:       ct.BankCode,
+				Agency:         ct.Agency,
+				AccountNumber:  ct.AccountNumber,
+				DocumentNumber: ct.DocumentNumber,
+				Name:           ct.Name,
+			},
+			Description: ct.Description,
+			RequestedBy: principal.ID,
+		}
+
+		created, err := svc.Create(ctx, transfer)
+		if errors.Is(err, transactions.ErrApprovalRequired) {
+			approval, err := as.SubmitTransfer(ctx, transfer)
+			if err != nil {
+				zapctx.L(ctx).Error("create_transfer_handler_approval_service_error", zap.Error(err))
+				if errors.Is(err, approvals.ErrSubmitterRequired) {
+					return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+				}
+				return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			return c.JSON(http.StatusAccepted, submittedApproval{
+				ID:          approval.ID.String(),
+				Operation:   string(approval.Operation),
+				AccountID:   approval.AccountID.String(),
+				Amount:      transfer.Amount,
+				Status:      string(approval.Status),
+				SubmittedBy: approval.SubmittedBy,
+				ExpiresAt:   approval.ExpiresAt,
+				CreatedAt:   approval.CreatedAt,
+			})
+		}
+		if err != nil {
+			zapctx.L(ctx).Error("create_transfer_handler_service_error", zap.Error(err))
+			if errors.Is(err, transactions.ErrBalanceInsufficientFunds) ||
+				errors.Is(err, transactions.ErrInsufficientDailyLimit) ||
+				errors.Is(err, transactions.ErrAccountInactive) ||
+				errors.Is(err, transactions.ErrAccountDebitsBlocked) ||
+				errors.Is(err, transactions.ErrPocketExternalTransaction) {
+				return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+			} else if errors.Is(err, transactions.ErrRequesterRequired) ||
+				errors.Is(err, transactions.ErrHolderNotAllowedToDebit) ||
+				errors.Is(err, transactions.ErrTransactionDenied) ||
+				errors.Is(err, transactions.ErrTransactionUnderReview) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			} else if errors.Is(err, transactions.ErrAccountNotfound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			} else if errors.Is(err, transfers.ErrInvalidTransferAmount) ||
+				errors.Is(err, transfers.ErrInvalidBeneficiary) ||
+				errors.Is(err, transactions.ErrFromAccountToAccountShouldBeDifferent) ||
+				errors.Is(err, transactions.ErrCurrencyMismatch) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(http.StatusCreated, newTransfer(created))
+	}
+}
+
+func newTransfer(t transfers.Transfer) transfer {
+	var settledAt *time.Time
+	if !t.SettledAt.IsZero() {
+		settledAt = &t.SettledAt
+	}
+
+	return transfer{
+		ID:                    t.ID.String(),
+		AccountID:             t.AccountID.String(),
+		Amount:                t.Amount,
+		BankCode:              t.Beneficiary.BankCode,
+		Agency:                t.Beneficiary.Agency,
+		AccountNumber:         t.Beneficiary.AccountNumber,
+		DocumentNumber:        t.Beneficiary.DocumentNumber,
+		Name:                  t.Beneficiary.Name,
+		Description:           t.Description,
+		Reference:             t.Reference,
+		Status:                string(t.Status),
+		DebitTransactionID:    stringers.UUIDEmpty(t.DebitTransactionID.UUID),
+		ReversalTransactionID: stringers.UUIDEmpty(t.ReversalTransactionID.UUID),
+		RemittanceID:          stringers.UUIDEmpty(t.RemittanceID.UUID),
+		Occurrences:           t.Occurrences,
+		CreatedAt:             t.CreatedAt,
+		SettledAt:             settledAt,
+	}
+}
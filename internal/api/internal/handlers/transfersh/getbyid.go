@@ -0,0 +1,58 @@
+package transfersh
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/internal/transfers"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+type (
+	GetByIDTransferFunc echo.HandlerFunc
+
+	getTransfer struct {
+		ID string `param:"id"`
+	}
+)
+
+func NewGetByIDTransferFunc(svc transfers.Service, ps policies.Service) GetByIDTransferFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var gt getTransfer
+		if err := c.Bind(&gt); err != nil {
+			zapctx.L(ctx).Error("get_transfer_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(gt.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("get_transfer_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		t, err := svc.GetByID(ctx, id)
+		if err != nil {
+			zapctx.L(ctx).Error("get_transfer_handler_service_error", zap.Error(err))
+			if errors.Is(err, transfers.ErrTransferNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		if err := ps.AuthorizeAccount(ctx, t.AccountID, policies.HolderAccess); err != nil {
+			zapctx.L(ctx).Error("get_transfer_handler_authorization_error", zap.Error(err))
+			if errors.Is(err, policies.ErrForbidden) {
+				return echo.NewHTTPError(http.StatusForbidden, err.Error())
+			}
+			return err
+		}
+
+		return c.JSON(http.StatusOK, newTransfer(t))
+	}
+}
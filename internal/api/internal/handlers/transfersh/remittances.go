@@ -0,0 +1,119 @@
+package transfersh
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/transfers"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+const dateLayout = "2006-01-02"
+
+type (
+	GenerateRemittanceFunc echo.HandlerFunc
+	GetRemittanceFileFunc  echo.HandlerFunc
+
+	generateRemittance struct {
+		Date string `json:"date"`
+	}
+
+	getRemittanceFile struct {
+		ID string `param:"id"`
+	}
+
+	remittance struct {
+		ID            string    `json:"id"`
+		Sequence      int64     `json:"sequence"`
+		Date          string    `json:"date"`
+		TransferCount int       `json:"transfer_count"`
+		TotalAmount   float64   `json:"total_amount"`
+		CreatedAt     time.Time `json:"created_at"`
+	}
+)
+
+func (g generateRemittance) Validate() error {
+	return validation.ValidateStruct(&g,
+		validation.Field(&g.Date, validation.Required, validation.Date(dateLayout)),
+	)
+}
+
+// NewGenerateRemittanceFunc sends the pending transfers created up to the end of the date in the
+// remittance of that date.
+func NewGenerateRemittanceFunc(svc transfers.Service) GenerateRemittanceFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var gr generateRemittance
+		if err := c.Bind(&gr); err != nil {
+			zapctx.L(ctx).Error("generate_remittance_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		if err := gr.Validate(); err != nil {
+			zapctx.L(ctx).Error("generate_remittance_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		date, err := time.Parse(dateLayout, gr.Date)
+		if err != nil {
+			zapctx.L(ctx).Error("generate_remittance_handler_validation_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		r, err := svc.GenerateRemittance(ctx, date)
+		if err != nil {
+			zapctx.L(ctx).Error("generate_remittance_handler_service_error", zap.Error(err))
+			if errors.Is(err, transfers.ErrRemittanceAlreadyGenerated) {
+				return echo.NewHTTPError(http.StatusConflict, err.Error())
+			} else if errors.Is(err, transfers.ErrNoTransfersToRemit) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.JSON(http.StatusCreated, remittance{
+			ID:            r.ID.String(),
+			Sequence:      r.Sequence,
+			Date:          r.Date.Format(dateLayout),
+			TransferCount: r.TransferCount,
+			TotalAmount:   r.TotalAmount,
+			CreatedAt:     r.CreatedAt,
+		})
+	}
+}
+
+// NewGetRemittanceFileFunc downloads the CNAB 240 file of the remittance to send to the bank.
+func NewGetRemittanceFileFunc(svc transfers.Service) GetRemittanceFileFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var gr getRemittanceFile
+		if err := c.Bind(&gr); err != nil {
+			zapctx.L(ctx).Error("get_remittance_file_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+		}
+
+		id, err := uuid.Parse(gr.ID)
+		if err != nil {
+			zapctx.L(ctx).Error("get_remittance_file_handler_bind_error", zap.Error(err))
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "invalid id")
+		}
+
+		file, err := svc.GetRemittanceFile(ctx, id)
+		if err != nil {
+			zapctx.L(ctx).Error("get_remittance_file_handler_service_error", zap.Error(err))
+			if errors.Is(err, transfers.ErrRemittanceNotFound) {
+				return echo.NewHTTPError(http.StatusNotFound, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		return c.String(http.StatusOK, file)
+	}
+}
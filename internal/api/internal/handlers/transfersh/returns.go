@@ -0,0 +1,80 @@
+package transfersh
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dalmarcogd/dock-test/internal/api/internal/handlers/stringers"
+	"github.com/dalmarcogd/dock-test/internal/transfers"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// maxReturnFileSize bounds the return files read from request bodies.
+const maxReturnFileSize = 10 << 20
+
+type (
+	ImportReturnFileFunc echo.HandlerFunc
+
+	returnRecord struct {
+		Line          int    `json:"line"`
+		Reference     string `json:"reference"`
+		Occurrences   string `json:"occurrences"`
+		Outcome       string `json:"outcome"`
+		TransferID    string `json:"transfer_id,omitempty"`
+		TransactionID string `json:"transaction_id,omitempty"`
+		Reason        string `json:"reason,omitempty"`
+	}
+
+	importResult struct {
+		Confirmed        int            `json:"confirmed"`
+		Reversed         int            `json:"reversed"`
+		Accepted         int            `json:"accepted"`
+		AlreadyProcessed int            `json:"already_processed"`
+		NotFound         int            `json:"not_found"`
+		Failed           int            `json:"failed"`
+		Records          []returnRecord `json:"records"`
+	}
+)
+
+// NewImportReturnFileFunc confirms or reverses the transfers in the CNAB 240 return file sent as the
+// plain text request body.
+func NewImportReturnFileFunc(svc transfers.Service) ImportReturnFileFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		body := http.MaxBytesReader(c.Response(), c.Request().Body, maxReturnFileSize)
+		result, err := svc.ImportReturnFile(ctx, body)
+		if err != nil {
+			zapctx.L(ctx).Error("import_transfers_return_file_handler_service_error", zap.Error(err))
+			if errors.Is(err, transfers.ErrInvalidReturnFile) {
+				return echo.NewHTTPError(http.StatusUnprocessableEntity, err.Error())
+			}
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		response := importResult{
+			Confirmed:        result.Count(transfers.ConfirmedOutcome),
+			Reversed:         result.Count(transfers.ReversedOutcome),
+			Accepted:         result.Count(transfers.AcceptedOutcome),
+			AlreadyProcessed: result.Count(transfers.AlreadyProcessedOutcome),
+			NotFound:         result.Count(transfers.NotFoundOutcome),
+			Failed:           result.Count(transfers.FailedOutcome),
+			Records:          make([]returnRecord, 0, len(result.Records)),
+		}
+		for _, record := range result.Records {
+			response.Records = append(response.Records, returnRecord{
+				Line:          record.Line,
+				Reference:     record.Reference,
+				Occurrences:   record.Occurrences,
+				Outcome:       string(record.Outcome),
+				TransferID:    stringers.UUIDEmpty(record.TransferID.UUID),
+				TransactionID: stringers.UUIDEmpty(record.TransactionID.UUID),
+				Reason:        record.Reason,
+			})
+		}
+
+		return c.JSON(http.StatusOK, response)
+	}
+}
@@ -0,0 +1,60 @@
+package apikeys
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// keyPrefix leads every key, so leaked keys are easy to spot in code and logs.
+	keyPrefix = "dk_"
+	// shownPrefixSize is how many characters of the key are kept in clear to tell the keys apart.
+	shownPrefixSize = len(keyPrefix) + 8
+	keyEntropyBytes = 32
+)
+
+// APIKey authenticates a client of the API. Only the hash of the key is stored, so Key is only set when the key
+// is created.
+type APIKey struct {
+	ID        uuid.UUID
+	Name      string
+	Prefix    string
+	Key       string
+	Scopes    []string
+	CreatedBy string
+	CreatedAt time.Time
+	RevokedAt time.Time
+}
+
+func newAPIKey(model apiKeyModel) APIKey {
+	return APIKey{
+		ID:        model.ID,
+		Name:      model.Name,
+		Prefix:    model.Prefix,
+		Scopes:    model.Scopes,
+		CreatedBy: model.CreatedBy,
+		CreatedAt: model.CreatedAt,
+		RevokedAt: model.RevokedAt,
+	}
+}
+
+// generateKey returns a random key and its hash.
+func generateKey() (string, string, error) {
+	entropy := make([]byte, keyEntropyBytes)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", "", err
+	}
+
+	key := keyPrefix + base64.RawURLEncoding.EncodeToString(entropy)
+	return key, hashKey(key), nil
+}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,38 @@
+package apikeys
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type apiKeyModel struct {
+	bun.BaseModel `bun:"table:api_keys"`
+
+	ID        uuid.UUID `bun:"id,pk"`
+	Name      string    `bun:"name"`
+	Prefix    string    `bun:"prefix"`
+	KeyHash   string    `bun:"key_hash"`
+	Scopes    []string  `bun:"scopes,array"`
+	CreatedBy string    `bun:"created_by"`
+	CreatedAt time.Time `bun:"created_at,notnull"`
+	RevokedAt time.Time `bun:"revoked_at,nullzero"`
+}
+
+func newAPIKeyModel(apiKey APIKey, keyHash string) apiKeyModel {
+	scopes := apiKey.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	return apiKeyModel{
+		ID:        uuid.New(),
+		Name:      apiKey.Name,
+		Prefix:    apiKey.Key[:shownPrefixSize],
+		KeyHash:   keyHash,
+		Scopes:    scopes,
+		CreatedBy: apiKey.CreatedBy,
+		CreatedAt: time.Now().UTC(),
+	}
+}
@@ -0,0 +1,109 @@
+package apikeys
+
+import (
+	"context"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, model apiKeyModel) (apiKeyModel, error)
+	GetByHash(ctx context.Context, keyHash string) (apiKeyModel, error)
+	List(ctx context.Context) ([]apiKeyModel, error)
+	Revoke(ctx context.Context, id uuid.UUID) (bool, error)
+}
+
+type repository struct {
+	tracer tracer.Tracer
+	db     database.Database
+}
+
+func NewRepository(t tracer.Tracer, db database.Database) Repository {
+	return repository{
+		tracer: t,
+		db:     db,
+	}
+}
+
+func (r repository) Create(ctx context.Context, model apiKeyModel) (apiKeyModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	_, err := r.db.Master().
+		NewInsert().
+		Model(&model).
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return apiKeyModel{}, err
+	}
+
+	return model, nil
+}
+
+// GetByHash finds the key with the hash, revoked or not, returning sql.ErrNoRows when there is none. It reads
+// from the master, so a key is revoked as soon as the revocation is answered.
+func (r repository) GetByHash(ctx context.Context, keyHash string) (apiKeyModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var model apiKeyModel
+	err := r.db.Master().
+		NewSelect().
+		Model(&model).
+		Where("key_hash = ?", keyHash).
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return apiKeyModel{}, err
+	}
+
+	return model, nil
+}
+
+func (r repository) List(ctx context.Context) ([]apiKeyModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var models []apiKeyModel
+	err := r.db.Replica().
+		NewSelect().
+		Model(&models).
+		Order("created_at DESC", "id ASC").
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return models, nil
+}
+
+// Revoke marks the key as revoked, returning false when it does not exist or was already revoked.
+func (r repository) Revoke(ctx context.Context, id uuid.UUID) (bool, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	res, err := r.db.Master().
+		NewUpdate().
+		Model((*apiKeyModel)(nil)).
+		Set("revoked_at = ?", time.Now().UTC()).
+		Where("id = ?", id).
+		Where("revoked_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return false, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		span.RecordError(err)
+		return false, err
+	}
+
+	return rows > 0, nil
+}
@@ -0,0 +1,79 @@
+//go:build integration
+
+package apikeys
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/testingcontainers"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+
+	url, closeFunc, err := testingcontainers.NewPostgresContainer()
+	assert.NoError(t, err)
+	defer closeFunc(ctx) //nolint:errcheck
+
+	_, callerPath, _, _ := runtime.Caller(0) //nolint:dogsled
+	err = testingcontainers.RunMigrateDatabase(
+		url,
+		fmt.Sprintf("file://%s/../../migrations/", filepath.Dir(callerPath)),
+	)
+	assert.NoError(t, err)
+
+	db, err := database.New(tracer.NewNoop(), url, url)
+	assert.NoError(t, err)
+
+	repo := NewRepository(tracer.NewNoop(), db)
+
+	t.Run("key created, found by hash and revoked", func(t *testing.T) {
+		key, keyHash, err := generateKey()
+		assert.NoError(t, err)
+
+		created, err := repo.Create(ctx, newAPIKeyModel(APIKey{
+			Name:      "back-office",
+			Key:       key,
+			Scopes:    []string{policies.AdminScope},
+			CreatedBy: "bootstrap",
+		}, keyHash))
+		assert.NoError(t, err)
+
+		found, err := repo.GetByHash(ctx, keyHash)
+		assert.NoError(t, err)
+		assert.Equal(t, created.ID, found.ID)
+		assert.Equal(t, []string{policies.AdminScope}, found.Scopes)
+		assert.True(t, found.RevokedAt.IsZero())
+
+		_, err = repo.GetByHash(ctx, hashKey(key+"x"))
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		models, err := repo.List(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, models, 1)
+
+		revoked, err := repo.Revoke(ctx, created.ID)
+		assert.NoError(t, err)
+		assert.True(t, revoked)
+		revoked, err = repo.Revoke(ctx, created.ID)
+		assert.NoError(t, err)
+		assert.False(t, revoked)
+		revoked, err = repo.Revoke(ctx, uuid.New())
+		assert.NoError(t, err)
+		assert.False(t, revoked)
+
+		found, err = repo.GetByHash(ctx, keyHash)
+		assert.NoError(t, err)
+		assert.False(t, found.RevokedAt.IsZero())
+	})
+}
@@ -0,0 +1,181 @@
+package apikeys
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/pkg/http/middlewares"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// bootstrapPrincipalID is the principal of the bootstrap key, which is not stored.
+const bootstrapPrincipalID = "bootstrap"
+
+var (
+	ErrAPIKeyNotFound = errors.New("no active API key found with this id")
+	ErrNameRequired   = errors.New("the API key must have a name")
+	ErrInvalidScope   = errors.New("the scope is not valid")
+)
+
+type Service interface {
+	Create(ctx context.Context, apiKey APIKey) (APIKey, error)
+	List(ctx context.Context) ([]APIKey, error)
+	Revoke(ctx context.Context, id uuid.UUID) error
+	Authenticate(ctx context.Context, key string) (middlewares.Principal, error)
+}
+
+type service struct {
+	tracer        tracer.Tracer
+	repository    Repository
+	bootstrapHash string
+}
+
+// NewService builds the API keys service. A non-empty bootstrapKey is authenticated with the admin scope
+// without being stored, so the first keys can be created.
+func NewService(t tracer.Tracer, r Repository, bootstrapKey string) Service {
+	var bootstrapHash string
+	if bootstrapKey != "" {
+		bootstrapHash = hashKey(bootstrapKey)
+	}
+
+	return service{
+		tracer:        t,
+		repository:    r,
+		bootstrapHash: bootstrapHash,
+	}
+}
+
+// Create generates a key, returned in Key of the created API key and never again.
+func (s service) Create(ctx context.Context, apiKey APIKey) (APIKey, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	apiKey.Name = strings.TrimSpace(apiKey.Name)
+	if apiKey.Name == "" {
+		span.RecordError(ErrNameRequired)
+		return APIKey{}, ErrNameRequired
+	}
+
+	for _, scope := range apiKey.Scopes {
+		if !policies.ValidScope(scope) {
+			span.RecordError(ErrInvalidScope)
+			return APIKey{}, ErrInvalidScope
+		}
+	}
+
+	key, keyHash, err := generateKey()
+	if err != nil {
+		zapctx.L(ctx).Error("apikeys_service_generate_key_error", zap.Error(err))
+		span.RecordError(err)
+		return APIKey{}, err
+	}
+	apiKey.Key = key
+
+	model, err := s.repository.Create(ctx, newAPIKeyModel(apiKey, keyHash))
+	if err != nil {
+		zapctx.L(ctx).Error("apikeys_service_create_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return APIKey{}, err
+	}
+
+	created := newAPIKey(model)
+	created.Key = key
+
+	zapctx.L(ctx).Info(
+		"apikeys_service_key_created",
+		zap.String("id", created.ID.String()),
+		zap.String("prefix", created.Prefix),
+		zap.Strings("scopes", created.Scopes),
+	)
+
+	return created, nil
+}
+
+func (s service) List(ctx context.Context) ([]APIKey, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	models, err := s.repository.List(ctx)
+	if err != nil {
+		zapctx.L(ctx).Error("apikeys_service_list_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	apiKeys := make([]APIKey, len(models))
+	for i, model := range models {
+		apiKeys[i] = newAPIKey(model)
+	}
+
+	return apiKeys, nil
+}
+
+func (s service) Revoke(ctx context.Context, id uuid.UUID) error {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	revoked, err := s.repository.Revoke(ctx, id)
+	if err != nil {
+		zapctx.L(ctx).Error(
+			"apikeys_service_revoke_repository_error",
+			zap.String("id", id.String()),
+			zap.Error(err),
+		)
+		span.RecordError(err)
+		return err
+	}
+
+	if !revoked {
+		span.RecordError(ErrAPIKeyNotFound)
+		return ErrAPIKeyNotFound
+	}
+
+	zapctx.L(ctx).Info("apikeys_service_key_revoked", zap.String("id", id.String()))
+
+	return nil
+}
+
+// Authenticate finds the principal of an active key, returning middlewares.ErrInvalidCredentials when the key
+// is unknown or revoked.
+func (s service) Authenticate(ctx context.Context, key string) (middlewares.Principal, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	keyHash := hashKey(key)
+
+	if s.bootstrapHash != "" && subtle.ConstantTimeCompare([]byte(keyHash), []byte(s.bootstrapHash)) == 1 {
+		return middlewares.Principal{
+			ID:     bootstrapPrincipalID,
+			Type:   middlewares.APIKeyPrincipal,
+			Scopes: []string{policies.AdminScope},
+		}, nil
+	}
+
+	model, err := s.repository.GetByHash(ctx, keyHash)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return middlewares.Principal{}, middlewares.ErrInvalidCredentials
+		}
+		zapctx.L(ctx).Error("apikeys_service_authenticate_repository_error", zap.Error(err))
+		return middlewares.Principal{}, err
+	}
+
+	if !model.RevokedAt.IsZero() {
+		span.RecordError(middlewares.ErrInvalidCredentials)
+		return middlewares.Principal{}, middlewares.ErrInvalidCredentials
+	}
+
+	return middlewares.Principal{
+		ID:     model.ID.String(),
+		Type:   middlewares.APIKeyPrincipal,
+		Scopes: model.Scopes,
+	}, nil
+}
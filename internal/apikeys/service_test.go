@@ -0,0 +1,146 @@
+//go:build unit
+
+package apikeys
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/pkg/http/middlewares"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_Create(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	svc := NewService(tracer.NewNoop(), repoMock, "")
+
+	t.Run("fail create, invalid keys", func(t *testing.T) {
+		for _, tt := range []struct {
+			apiKey  APIKey
+			wantErr error
+		}{
+			{APIKey{Name: "  "}, ErrNameRequired},
+			{APIKey{Name: "back-office", Scopes: []string{policies.AdminScope, "root"}}, ErrInvalidScope},
+		} {
+			created, err := svc.Create(ctx, tt.apiKey)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Empty(t, created)
+		}
+	})
+
+	t.Run("success create, only the hash stored", func(t *testing.T) {
+		var stored apiKeyModel
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, m apiKeyModel) (apiKeyModel, error) {
+				stored = m
+				return m, nil
+			})
+
+		created, err := svc.Create(ctx, APIKey{Name: " back-office ", Scopes: []string{policies.AdminScope}, CreatedBy: "bootstrap"})
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(created.Key, keyPrefix))
+		assert.Equal(t, created.Key[:shownPrefixSize], created.Prefix)
+		assert.Equal(t, "back-office", created.Name)
+		assert.Equal(t, "bootstrap", created.CreatedBy)
+		assert.Equal(t, hashKey(created.Key), stored.KeyHash)
+		assert.Equal(t, []string{policies.AdminScope}, stored.Scopes)
+	})
+
+	t.Run("fail create, repository error", func(t *testing.T) {
+		repoMock.EXPECT().Create(ctx, gomock.Any()).Return(apiKeyModel{}, sql.ErrConnDone)
+
+		created, err := svc.Create(ctx, APIKey{Name: "back-office"})
+		assert.ErrorIs(t, err, sql.ErrConnDone)
+		assert.Empty(t, created)
+	})
+}
+
+func TestService_Revoke(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	svc := NewService(tracer.NewNoop(), repoMock, "")
+
+	t.Run("fail revoke, key not found or already revoked", func(t *testing.T) {
+		id := uuid.New()
+		repoMock.EXPECT().Revoke(ctx, id).Return(false, nil)
+
+		assert.ErrorIs(t, svc.Revoke(ctx, id), ErrAPIKeyNotFound)
+	})
+
+	t.Run("success revoke", func(t *testing.T) {
+		id := uuid.New()
+		repoMock.EXPECT().Revoke(ctx, id).Return(true, nil)
+
+		assert.NoError(t, svc.Revoke(ctx, id))
+	})
+}
+
+func TestService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	svc := NewService(tracer.NewNoop(), repoMock, "dk_bootstrap")
+
+	t.Run("success authenticate, bootstrap key", func(t *testing.T) {
+		principal, err := svc.Authenticate(ctx, "dk_bootstrap")
+		assert.NoError(t, err)
+		assert.Equal(t, middlewares.Principal{
+			ID:     bootstrapPrincipalID,
+			Type:   middlewares.APIKeyPrincipal,
+			Scopes: []string{policies.AdminScope},
+		}, principal)
+	})
+
+	t.Run("success authenticate, stored key", func(t *testing.T) {
+		model := apiKeyModel{ID: uuid.New(), Scopes: []string{}}
+		repoMock.EXPECT().GetByHash(ctx, hashKey("dk_client")).Return(model, nil)
+
+		principal, err := svc.Authenticate(ctx, "dk_client")
+		assert.NoError(t, err)
+		assert.Equal(t, model.ID.String(), principal.ID)
+		assert.Equal(t, middlewares.APIKeyPrincipal, principal.Type)
+		assert.False(t, principal.HasScope(policies.AdminScope))
+	})
+
+	t.Run("fail authenticate, unknown key", func(t *testing.T) {
+		repoMock.EXPECT().GetByHash(ctx, hashKey("dk_unknown")).Return(apiKeyModel{}, sql.ErrNoRows)
+
+		principal, err := svc.Authenticate(ctx, "dk_unknown")
+		assert.ErrorIs(t, err, middlewares.ErrInvalidCredentials)
+		assert.Empty(t, principal)
+	})
+
+	t.Run("fail authenticate, revoked key", func(t *testing.T) {
+		repoMock.EXPECT().
+			GetByHash(ctx, hashKey("dk_revoked")).
+			Return(apiKeyModel{ID: uuid.New(), RevokedAt: time.Now().UTC()}, nil)
+
+		principal, err := svc.Authenticate(ctx, "dk_revoked")
+		assert.ErrorIs(t, err, middlewares.ErrInvalidCredentials)
+		assert.Empty(t, principal)
+	})
+
+	t.Run("fail authenticate, repository error", func(t *testing.T) {
+		repoMock.EXPECT().GetByHash(ctx, gomock.Any()).Return(apiKeyModel{}, sql.ErrConnDone)
+
+		_, err := svc.Authenticate(ctx, "dk_client")
+		assert.ErrorIs(t, err, sql.ErrConnDone)
+	})
+}
@@ -0,0 +1,172 @@
+package approvals
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Operation is an operation under dual control, made only after a second principal approves it.
+type Operation string
+
+const (
+	// TransferOperation is a TED above the threshold sent from a business account.
+	TransferOperation Operation = "TRANSFER"
+	// P2POperation is a P2P above the threshold made from a business account.
+	P2POperation Operation = "P2P"
+	// BlockAccountOperation blocks an account.
+	BlockAccountOperation Operation = "ACCOUNT_BLOCK"
+	// CloseAccountOperation closes an account.
+	CloseAccountOperation Operation = "ACCOUNT_CLOSE"
+)
+
+var Operations = []Operation{TransferOperation, P2POperation, BlockAccountOperation, CloseAccountOperation}
+
+// fundsOperations move the funds of the account, so they are decided by its holders.
+var fundsOperations = []Operation{TransferOperation, P2POperation}
+
+func (o Operation) movesFunds() bool {
+	for _, v := range fundsOperations {
+		if o == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+type Status string
+
+const (
+	// PendingStatus is an operation waiting for a second principal.
+	PendingStatus Status = "PENDING"
+	// ApprovedStatus is an operation approved and being made.
+	ApprovedStatus Status = "APPROVED"
+	// ExecutedStatus is an operation approved and made.
+	ExecutedStatus Status = "EXECUTED"
+	// FailedStatus is an operation approved that could not be made.
+	FailedStatus Status = "FAILED"
+	// RejectedStatus is an operation a principal refused.
+	RejectedStatus Status = "REJECTED"
+	// ExpiredStatus is an operation not approved before it expired.
+	ExpiredStatus Status = "EXPIRED"
+)
+
+var Statuses = []Status{PendingStatus, ApprovedStatus, ExecutedStatus, FailedStatus, RejectedStatus, ExpiredStatus}
+
+// Action is a step of the life of an approval, recorded in its audit trail.
+type Action string
+
+const (
+	SubmittedAction Action = "SUBMITTED"
+	ApprovedAction  Action = "APPROVED"
+	ExecutedAction  Action = "EXECUTED"
+	FailedAction    Action = "FAILED"
+	RejectedAction  Action = "REJECTED"
+	ExpiredAction   Action = "EXPIRED"
+)
+
+// Approval is an operation submitted by a principal, the maker, stored with its payload and made
+// once another principal, the checker, approves it.
+type Approval struct {
+	ID        uuid.UUID
+	Operation Operation
+	// AccountID is the account the operation acts on.
+	AccountID uuid.UUID
+	// Payload holds what the operation is made with, as submitted.
+	Payload     json.RawMessage
+	Status      Status
+	SubmittedBy string
+	ExpiresAt   time.Time
+	DecidedBy   string
+	DecidedAt   time.Time
+	// ResultID is what the operation made: the transfer sent, the P2P made or the account blocked or
+	// closed.
+	ResultID uuid.NullUUID
+	// Error tells why an approved operation failed.
+	Error     string
+	CreatedAt time.Time
+	Events    []Event
+}
+
+func newApproval(model approvalModel) Approval {
+	return Approval{
+		ID:          model.ID,
+		Operation:   model.Operation,
+		AccountID:   model.AccountID,
+		Payload:     json.RawMessage(model.Payload),
+		Status:      model.Status,
+		SubmittedBy: model.SubmittedBy,
+		ExpiresAt:   model.ExpiresAt,
+		DecidedBy:   model.DecidedBy,
+		DecidedAt:   model.DecidedAt,
+		ResultID:    model.ResultID,
+		Error:       model.Error,
+		CreatedAt:   model.CreatedAt,
+	}
+}
+
+// Event is an entry of the audit trail of an approval: who did what, when and why.
+type Event struct {
+	ID         uuid.UUID
+	ApprovalID uuid.UUID
+	Action     Action
+	Actor      string
+	Reason     string
+	CreatedAt  time.Time
+}
+
+func newEvent(model eventModel) Event {
+	return Event{
+		ID:         model.ID,
+		ApprovalID: model.ApprovalID,
+		Action:     model.Action,
+		Actor:      model.Actor,
+		Reason:     model.Reason,
+		CreatedAt:  model.CreatedAt,
+	}
+}
+
+// Decision is the principal approving or rejecting an operation, and why.
+type Decision struct {
+	Reason string
+	// Actor is the id of the principal deciding.
+	Actor string
+}
+
+// Filter selects a page of approvals with a status, an operation or of an account, newest first.
+type Filter struct {
+	Status Status
+	// Operations lists the approvals of any of the operations, all when empty.
+	Operations []Operation
+	AccountID  uuid.NullUUID
+	Page       int
+	Size       int
+}
+
+// transferPayload is the TED sent by a TransferOperation.
+type transferPayload struct {
+	Amount         float64 `json:"amount"`
+	BankCode       string  `json:"bank_code"`
+	Agency         string  `json:"agency"`
+	AccountNumber  string  `json:"account_number"`
+	DocumentNumber string  `json:"document_number"`
+	Name           string  `json:"name"`
+	Description    string  `json:"description"`
+}
+
+// p2pPayload is the P2P made by a P2POperation.
+type p2pPayload struct {
+	ToAccountID string  `json:"to_account_id"`
+	Amount      float64 `json:"amount"`
+	Currency    string  `json:"currency,omitempty"`
+	Description string  `json:"description"`
+}
+
+// statusPayload is why a BlockAccountOperation or a CloseAccountOperation changes the account status,
+// and the account receiving the balance of a closed one.
+type statusPayload struct {
+	Reason               string `json:"reason"`
+	DestinationAccountID string `json:"destination_account_id,omitempty"`
+}
@@ -0,0 +1,58 @@
+package approvals
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type approvalModel struct {
+	bun.BaseModel `bun:"table:approvals"`
+
+	ID          uuid.UUID     `bun:"id,pk"`
+	Operation   Operation     `bun:"operation"`
+	AccountID   uuid.UUID     `bun:"account_id"`
+	Payload     string        `bun:"payload,type:jsonb"`
+	Status      Status        `bun:"status"`
+	SubmittedBy string        `bun:"submitted_by"`
+	ExpiresAt   time.Time     `bun:"expires_at,notnull"`
+	DecidedBy   string        `bun:"decided_by,nullzero"`
+	DecidedAt   time.Time     `bun:"decided_at,nullzero"`
+	ResultID    uuid.NullUUID `bun:"result_id"`
+	Error       string        `bun:"error,nullzero"`
+	CreatedAt   time.Time     `bun:"created_at,notnull"`
+}
+
+func newApprovalModel(approval Approval) approvalModel {
+	return approvalModel{
+		ID:          uuid.New(),
+		Operation:   approval.Operation,
+		AccountID:   approval.AccountID,
+		Payload:     string(approval.Payload),
+		Status:      PendingStatus,
+		SubmittedBy: approval.SubmittedBy,
+		ExpiresAt:   approval.ExpiresAt,
+		CreatedAt:   time.Now().UTC(),
+	}
+}
+
+type eventModel struct {
+	bun.BaseModel `bun:"table:approval_events"`
+
+	ID         uuid.UUID `bun:"id,pk"`
+	ApprovalID uuid.UUID `bun:"approval_id"`
+	Action     Action    `bun:"action"`
+	Actor      string    `bun:"actor"`
+	Reason     string    `bun:"reason,nullzero"`
+	CreatedAt  time.Time `bun:"created_at,notnull"`
+}
+
+func newEventModel(action Action, actor, reason string) eventModel {
+	return eventModel{
+		ID:     uuid.New(),
+		Action: action,
+		Actor:  actor,
+		Reason: reason,
+	}
+}
@@ -0,0 +1,196 @@
+package approvals
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+var errStatusChanged = errors.New("the approval is no longer in the expected status")
+
+type Repository interface {
+	Create(ctx context.Context, model approvalModel, event eventModel) (approvalModel, error)
+	GetByID(ctx context.Context, id uuid.UUID) (approvalModel, []eventModel, error)
+	List(ctx context.Context, filter Filter) (int, []approvalModel, error)
+	ListExpired(ctx context.Context, now time.Time) ([]approvalModel, error)
+	Transition(ctx context.Context, model approvalModel, from Status, event eventModel) (approvalModel, error)
+}
+
+type repository struct {
+	tracer tracer.Tracer
+	db     database.Database
+}
+
+func NewRepository(t tracer.Tracer, db database.Database) Repository {
+	return repository{
+		tracer: t,
+		db:     db,
+	}
+}
+
+// Create inserts the approval along with the event of its submission within the same database
+// transaction.
+func (r repository) Create(ctx context.Context, model approvalModel, event eventModel) (approvalModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	event.ApprovalID = model.ID
+	event.CreatedAt = model.CreatedAt
+
+	err := r.db.Master().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewInsert().Model(&model).Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.NewInsert().Model(&event).Exec(ctx)
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		return approvalModel{}, err
+	}
+
+	return model, nil
+}
+
+// GetByID finds the approval along with its audit trail, oldest event first, returning sql.ErrNoRows
+// when it does not exist. It reads from the master, as approvals are decided right after being read.
+func (r repository) GetByID(ctx context.Context, id uuid.UUID) (approvalModel, []eventModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var model approvalModel
+	err := r.db.Master().
+		NewSelect().
+		Model(&model).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return approvalModel{}, nil, err
+	}
+
+	var events []eventModel
+	err = r.db.Master().
+		NewSelect().
+		Model(&events).
+		Where("approval_id = ?", id).
+		Order("created_at ASC", "id ASC").
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return approvalModel{}, nil, err
+	}
+
+	return model, events, nil
+}
+
+func (r repository) List(ctx context.Context, filter Filter) (int, []approvalModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var models []approvalModel
+	selectQuery := r.db.Replica().
+		NewSelect().
+		Model(&models).
+		Order("created_at DESC", "id ASC").
+		Limit(filter.Size).
+		Offset((filter.Page - 1) * filter.Size)
+
+	if filter.Status != "" {
+		selectQuery.Where("status = ?", filter.Status)
+	}
+
+	if len(filter.Operations) > 0 {
+		selectQuery.Where("operation IN (?)", bun.In(filter.Operations))
+	}
+
+	if filter.AccountID.Valid {
+		selectQuery.Where("account_id = ?", filter.AccountID.UUID)
+	}
+
+	total, err := selectQuery.ScanAndCount(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return 0, nil, err
+	}
+
+	return total, models, nil
+}
+
+// ListExpired lists the pending approvals expired by now.
+func (r repository) ListExpired(ctx context.Context, now time.Time) ([]approvalModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var models []approvalModel
+	err := r.db.Master().
+		NewSelect().
+		Model(&models).
+		Where("status = ?", PendingStatus).
+		Where("expires_at <= ?", now).
+		Order("expires_at ASC", "id ASC").
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return models, nil
+}
+
+// Transition moves the approval from a status to the one of the model and records the event within
+// the same database transaction. Leaving PENDING records who decided and when. It returns
+// errStatusChanged when the approval is no longer in the from status, so an operation is decided and
+// made once however many principals act on it.
+func (r repository) Transition(
+	ctx context.Context,
+	model approvalModel,
+	from Status,
+	event eventModel,
+) (approvalModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	now := time.Now().UTC()
+	columns := []string{"status", "result_id", "error"}
+	if from == PendingStatus {
+		model.DecidedAt = now
+		columns = append(columns, "decided_by", "decided_at")
+	}
+
+	event.ApprovalID = model.ID
+	event.CreatedAt = now
+
+	err := r.db.Master().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.NewUpdate().
+			Model(&model).
+			Column(columns...).
+			Where("id = ?", model.ID).
+			Where("status = ?", from).
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		if n, err := res.RowsAffected(); err == nil && n == 0 {
+			return errStatusChanged
+		}
+
+		_, err = tx.NewInsert().Model(&event).Exec(ctx)
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		return approvalModel{}, err
+	}
+
+	return model, nil
+}
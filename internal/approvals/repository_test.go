@@ -0,0 +1,140 @@
+//go:build integration
+
+package approvals
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/holders"
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/testingcontainers"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+
+	url, closeFunc, err := testingcontainers.NewPostgresContainer()
+	assert.NoError(t, err)
+	defer closeFunc(ctx) //nolint:errcheck
+
+	_, callerPath, _, _ := runtime.Caller(0) //nolint:dogsled
+	err = testingcontainers.RunMigrateDatabase(
+		url,
+		fmt.Sprintf("file://%s/../../migrations/", filepath.Dir(callerPath)),
+	)
+	assert.NoError(t, err)
+
+	db, err := database.New(tracer.NewNoop(), url, url)
+	assert.NoError(t, err)
+
+	holdersRepo := holders.NewRepository(tracer.NewNoop(), db)
+	holderModel, err := holdersRepo.Create(ctx, holders.HolderModel{
+		ID:             uuid.New(),
+		Name:           gofakeit.Name(),
+		DocumentNumber: gofakeit.SSN(),
+	})
+	assert.NoError(t, err)
+
+	accSvc := accounts.NewService(tracer.NewNoop(), accounts.NewRepository(tracer.NewNoop(), db), holdersRepo, false, 0)
+	account, err := accSvc.Create(ctx, accounts.Account{
+		Name:           gofakeit.Name(),
+		DocumentNumber: holderModel.DocumentNumber,
+	})
+	assert.NoError(t, err)
+
+	repo := NewRepository(tracer.NewNoop(), db)
+
+	submit := func(expiresAt time.Time) approvalModel {
+		model, err := repo.Create(
+			ctx,
+			newApprovalModel(Approval{
+				Operation:   BlockAccountOperation,
+				AccountID:   account.ID,
+				Payload:     []byte(`{"reason":"fraud suspicion"}`),
+				SubmittedBy: "maker",
+				ExpiresAt:   expiresAt,
+			}),
+			newEventModel(SubmittedAction, "maker", "fraud suspicion"),
+		)
+		assert.NoError(t, err)
+		return model
+	}
+
+	t.Run("approval submitted, approved and executed", func(t *testing.T) {
+		model := submit(time.Now().UTC().Add(time.Hour))
+
+		found, events, err := repo.GetByID(ctx, model.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, PendingStatus, found.Status)
+		assert.JSONEq(t, `{"reason":"fraud suspicion"}`, found.Payload)
+		assert.Len(t, events, 1)
+
+		approved, err := repo.Transition(ctx, approvalModel{
+			ID:        model.ID,
+			Status:    ApprovedStatus,
+			DecidedBy: "checker",
+		}, PendingStatus, newEventModel(ApprovedAction, "checker", "confirmed"))
+		assert.NoError(t, err)
+		assert.Equal(t, "maker", approved.SubmittedBy)
+		assert.NotEmpty(t, approved.DecidedAt)
+
+		_, err = repo.Transition(ctx, approvalModel{
+			ID:     model.ID,
+			Status: RejectedStatus,
+		}, PendingStatus, newEventModel(RejectedAction, "other", "too late"))
+		assert.ErrorIs(t, err, errStatusChanged)
+
+		approved.Status = ExecutedStatus
+		approved.ResultID = uuid.NullUUID{UUID: account.ID, Valid: true}
+		executed, err := repo.Transition(ctx, approved, ApprovedStatus, newEventModel(ExecutedAction, "checker", ""))
+		assert.NoError(t, err)
+		assert.Equal(t, "checker", executed.DecidedBy)
+
+		found, events, err = repo.GetByID(ctx, model.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, ExecutedStatus, found.Status)
+		assert.Equal(t, approved.ResultID, found.ResultID)
+		assert.Len(t, events, 3)
+		assert.Equal(t, ExecutedAction, events[2].Action)
+	})
+
+	t.Run("approval not found", func(t *testing.T) {
+		_, _, err := repo.GetByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("approvals listed and expired", func(t *testing.T) {
+		expired := submit(time.Now().UTC().Add(-time.Minute))
+
+		models, err := repo.ListExpired(ctx, time.Now().UTC())
+		assert.NoError(t, err)
+		assert.Len(t, models, 1)
+		assert.Equal(t, expired.ID, models[0].ID)
+
+		total, models, err := repo.List(ctx, Filter{
+			Status:     PendingStatus,
+			Operations: []Operation{BlockAccountOperation},
+			AccountID:  uuid.NullUUID{UUID: account.ID, Valid: true},
+			Page:       1,
+			Size:       10,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, models, 1)
+
+		total, _, err = repo.List(ctx, Filter{Page: 1, Size: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, total)
+	})
+}
@@ -0,0 +1,660 @@
+package approvals
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/exchange"
+	"github.com/dalmarcogd/dock-test/internal/interest"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/internal/transactions"
+	"github.com/dalmarcogd/dock-test/internal/transfers"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrApprovalNotFound   = errors.New("no approval found with this id")
+	ErrApprovalNotPending = errors.New("the approval was already decided")
+	ErrApprovalExpired    = errors.New("the approval expired")
+	ErrSubmitterRequired  = errors.New("the principal submitting the operation is required")
+	ErrApproverRequired   = errors.New("the principal deciding the operation is required")
+	ErrSameApprover       = errors.New("the operation must be approved by a principal other than its submitter")
+	ErrApproverNotAllowed = errors.New("the principal deciding the operation must be allowed to debit the account")
+	ErrReasonRequired     = errors.New("a reason is required to reject the operation")
+
+	errUnknownOperation = errors.New("the operation is not under dual control")
+)
+
+// expirationActor decides the approvals that expire.
+const expirationActor = "approval-expiration"
+
+type Service interface {
+	SubmitTransfer(ctx context.Context, transfer transfers.Transfer) (Approval, error)
+	SubmitP2P(ctx context.Context, transaction transactions.Transaction) (Approval, error)
+	SubmitBlock(ctx context.Context, accountID uuid.UUID, change accounts.StatusChange) (Approval, error)
+	SubmitClose(ctx context.Context, accountID uuid.UUID, closure accounts.Closure) (Approval, error)
+	GetByID(ctx context.Context, id uuid.UUID) (Approval, error)
+	List(ctx context.Context, filter Filter) (int, []Approval, error)
+	Approve(ctx context.Context, id uuid.UUID, decision Decision) (Approval, error)
+	Reject(ctx context.Context, id uuid.UUID, decision Decision) (Approval, error)
+	ExpireApprovals(ctx context.Context) (int, error)
+}
+
+type service struct {
+	tracer          tracer.Tracer
+	repository      Repository
+	accountsSvc     accounts.Service
+	transactionsSvc transactions.Service
+	transfersSvc    transfers.Service
+	interestSvc     interest.Service
+	policiesSvc     policies.Service
+	expiration      time.Duration
+}
+
+// NewService creates the approvals service. Approvals not decided within expiration expire.
+func NewService(
+	t tracer.Tracer,
+	r Repository,
+	accountsSvc accounts.Service,
+	transactionsSvc transactions.Service,
+	transfersSvc transfers.Service,
+	interestSvc interest.Service,
+	policiesSvc policies.Service,
+	expiration time.Duration,
+) Service {
+	return service{
+		tracer:          t,
+		repository:      r,
+		accountsSvc:     accountsSvc,
+		transactionsSvc: transactionsSvc,
+		transfersSvc:    transfersSvc,
+		interestSvc:     interestSvc,
+		policiesSvc:     policiesSvc,
+		expiration:      expiration,
+	}
+}
+
+func (s service) SubmitTransfer(ctx context.Context, transfer transfers.Transfer) (Approval, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	payload, err := json.Marshal(transferPayload{
+		Amount:         transfer.Amount,
+		BankCode:       transfer.Beneficiary.BankCode,
+		Agency:         transfer.Beneficiary.Agency,
+		AccountNumber:  transfer.Beneficiary.AccountNumber,
+		DocumentNumber: transfer.Beneficiary.DocumentNumber,
+		Name:           transfer.Beneficiary.Name,
+		Description:    transfer.Description,
+	})
+	if err != nil {
+		span.RecordError(err)
+		return Approval{}, err
+	}
+
+	approval, err := s.submit(ctx, Approval{
+		Operation:   TransferOperation,
+		AccountID:   transfer.AccountID,
+		Payload:     payload,
+		SubmittedBy: transfer.RequestedBy,
+	}, transfer.Description)
+	if err != nil {
+		span.RecordError(err)
+		return Approval{}, err
+	}
+
+	return approval, nil
+}
+
+func (s service) SubmitP2P(ctx context.Context, transaction transactions.Transaction) (Approval, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	payload, err := json.Marshal(p2pPayload{
+		ToAccountID: transaction.To.String(),
+		Amount:      transaction.Amount,
+		Currency:    string(transaction.Currency),
+		Description: transaction.Description,
+	})
+	if err != nil {
+		span.RecordError(err)
+		return Approval{}, err
+	}
+
+	approval, err := s.submit(ctx, Approval{
+		Operation:   P2POperation,
+		AccountID:   transaction.From,
+		Payload:     payload,
+		SubmittedBy: transaction.RequestedBy,
+	}, transaction.Description)
+	if err != nil {
+		span.RecordError(err)
+		return Approval{}, err
+	}
+
+	return approval, nil
+}
+
+func (s service) SubmitBlock(ctx context.Context, accountID uuid.UUID, change accounts.StatusChange) (Approval, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if strings.TrimSpace(change.Reason) == "" {
+		span.RecordError(accounts.ErrStatusReasonRequired)
+		return Approval{}, accounts.ErrStatusReasonRequired
+	}
+
+	payload, err := json.Marshal(statusPayload{Reason: change.Reason})
+	if err != nil {
+		span.RecordError(err)
+		return Approval{}, err
+	}
+
+	approval, err := s.submit(ctx, Approval{
+		Operation:   BlockAccountOperation,
+		AccountID:   accountID,
+		Payload:     payload,
+		SubmittedBy: change.Actor,
+	}, change.Reason)
+	if err != nil {
+		span.RecordError(err)
+		return Approval{}, err
+	}
+
+	return approval, nil
+}
+
+func (s service) SubmitClose(ctx context.Context, accountID uuid.UUID, closure accounts.Closure) (Approval, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if strings.TrimSpace(closure.Reason) == "" {
+		span.RecordError(accounts.ErrStatusReasonRequired)
+		return Approval{}, accounts.ErrStatusReasonRequired
+	}
+
+	p := statusPayload{Reason: closure.Reason}
+	if closure.DestinationID.Valid {
+		p.DestinationAccountID = closure.DestinationID.UUID.String()
+	}
+
+	payload, err := json.Marshal(p)
+	if err != nil {
+		span.RecordError(err)
+		return Approval{}, err
+	}
+
+	approval, err := s.submit(ctx, Approval{
+		Operation:   CloseAccountOperation,
+		AccountID:   accountID,
+		Payload:     payload,
+		SubmittedBy: closure.Actor,
+	}, closure.Reason)
+	if err != nil {
+		span.RecordError(err)
+		return Approval{}, err
+	}
+
+	return approval, nil
+}
+
+// submit stores the operation on an existing account, pending the approval of another principal
+// until it expires.
+func (s service) submit(ctx context.Context, approval Approval, reason string) (Approval, error) {
+	if strings.TrimSpace(approval.SubmittedBy) == "" {
+		return Approval{}, ErrSubmitterRequired
+	}
+
+	if _, err := s.accountsSvc.GetByID(ctx, approval.AccountID); err != nil {
+		return Approval{}, err
+	}
+
+	approval.ExpiresAt = time.Now().UTC().Add(s.expiration)
+
+	model, err := s.repository.Create(
+		ctx,
+		newApprovalModel(approval),
+		newEventModel(SubmittedAction, approval.SubmittedBy, reason),
+	)
+	if err != nil {
+		zapctx.L(ctx).Error("approval_service_create_repository_error", zap.Error(err))
+		return Approval{}, err
+	}
+
+	zapctx.L(ctx).Info(
+		"approval_submitted",
+		zap.String("approval_id", model.ID.String()),
+		zap.String("operation", string(model.Operation)),
+		zap.String("account_id", model.AccountID.String()),
+		zap.String("submitted_by", model.SubmittedBy),
+		zap.Time("expires_at", model.ExpiresAt),
+	)
+
+	return newApproval(model), nil
+}
+
+// GetByID finds the approval, returning policies.ErrForbidden when the principal of the request may not
+// decide it.
+func (s service) GetByID(ctx context.Context, id uuid.UUID) (Approval, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	approval, err := s.get(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return Approval{}, err
+	}
+
+	if err := s.authorize(ctx, approval); err != nil {
+		span.RecordError(err)
+		return Approval{}, err
+	}
+
+	return approval, nil
+}
+
+// get finds the approval along with its audit trail.
+func (s service) get(ctx context.Context, id uuid.UUID) (Approval, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	model, events, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return Approval{}, ErrApprovalNotFound
+		}
+		zapctx.L(ctx).Error("approval_service_get_repository_error", zap.Error(err))
+		return Approval{}, err
+	}
+
+	approval := newApproval(model)
+	approval.Events = make([]Event, len(events))
+	for i, event := range events {
+		approval.Events[i] = newEvent(event)
+	}
+
+	return approval, nil
+}
+
+// authorize returns policies.ErrForbidden unless the principal of the request may decide the approval:
+// the operations moving the funds of the account are decided by the principals allowed to debit it,
+// and seen by the back-office, while the others are decided by the back-office.
+func (s service) authorize(ctx context.Context, approval Approval) error {
+	if approval.Operation.movesFunds() {
+		return s.policiesSvc.AuthorizeAccount(ctx, approval.AccountID, policies.DebitAccess)
+	}
+
+	return s.policiesSvc.AuthorizeBackOffice(ctx)
+}
+
+// List lists the approvals of every account to the back-office. Other principals list only the
+// operations moving the funds of an account they may debit, returning policies.ErrForbidden otherwise.
+func (s service) List(ctx context.Context, filter Filter) (int, []Approval, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if err := s.policiesSvc.AuthorizeBackOffice(ctx); err != nil {
+		if !filter.AccountID.Valid {
+			span.RecordError(err)
+			return 0, nil, err
+		}
+
+		if err := s.policiesSvc.AuthorizeAccount(ctx, filter.AccountID.UUID, policies.DebitAccess); err != nil {
+			span.RecordError(err)
+			return 0, nil, err
+		}
+
+		for _, operation := range filter.Operations {
+			if !operation.movesFunds() {
+				span.RecordError(policies.ErrForbidden)
+				return 0, nil, policies.ErrForbidden
+			}
+		}
+
+		if len(filter.Operations) == 0 {
+			filter.Operations = fundsOperations
+		}
+	}
+
+	total, models, err := s.repository.List(ctx, filter)
+	if err != nil {
+		zapctx.L(ctx).Error("approval_service_list_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return 0, nil, err
+	}
+
+	approvals := make([]Approval, len(models))
+	for i, model := range models {
+		approvals[i] = newApproval(model)
+	}
+
+	return total, approvals, nil
+}
+
+// Approve makes the pending operation on behalf of a principal other than its submitter. Transfers
+// and P2Ps are approved by a principal allowed to debit the account, the other operations by the
+// back-office. An operation that cannot be made is FAILED, recording why, and the error of the
+// operation is returned.
+func (s service) Approve(ctx context.Context, id uuid.UUID, decision Decision) (Approval, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if strings.TrimSpace(decision.Actor) == "" {
+		span.RecordError(ErrApproverRequired)
+		return Approval{}, ErrApproverRequired
+	}
+
+	approval, err := s.pending(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return Approval{}, err
+	}
+
+	if decision.Actor == approval.SubmittedBy {
+		span.RecordError(ErrSameApprover)
+		return Approval{}, ErrSameApprover
+	}
+
+	if err := s.checkDecider(ctx, approval, decision); err != nil {
+		span.RecordError(err)
+		return Approval{}, err
+	}
+
+	model, err := s.repository.Transition(ctx, approvalModel{
+		ID:        id,
+		Status:    ApprovedStatus,
+		DecidedBy: decision.Actor,
+	}, PendingStatus, newEventModel(ApprovedAction, decision.Actor, decision.Reason))
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, errStatusChanged) {
+			return Approval{}, ErrApprovalNotPending
+		}
+		zapctx.L(ctx).Error("approval_service_approve_repository_error", zap.Error(err))
+		return Approval{}, err
+	}
+
+	zapctx.L(ctx).Info(
+		"approval_approved",
+		zap.String("approval_id", id.String()),
+		zap.String("operation", string(model.Operation)),
+		zap.String("submitted_by", model.SubmittedBy),
+		zap.String("decided_by", decision.Actor),
+	)
+
+	resultID, err := s.execute(ctx, newApproval(model))
+	if err != nil {
+		zapctx.L(ctx).Warn(
+			"approval_failed",
+			zap.String("approval_id", id.String()),
+			zap.String("operation", string(model.Operation)),
+			zap.Error(err),
+		)
+		model.Status = FailedStatus
+		model.Error = err.Error()
+		_, errFailed := s.repository.Transition(
+			ctx,
+			model,
+			ApprovedStatus,
+			newEventModel(FailedAction, decision.Actor, err.Error()),
+		)
+		if errFailed != nil {
+			zapctx.L(ctx).Error("approval_service_fail_repository_error", zap.Error(errFailed))
+		}
+		span.RecordError(err)
+		return Approval{}, err
+	}
+
+	model.Status = ExecutedStatus
+	model.ResultID = uuid.NullUUID{UUID: resultID, Valid: true}
+	_, err = s.repository.Transition(ctx, model, ApprovedStatus, newEventModel(ExecutedAction, decision.Actor, ""))
+	if err != nil {
+		zapctx.L(ctx).Error("approval_service_execute_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return Approval{}, err
+	}
+
+	zapctx.L(ctx).Info(
+		"approval_executed",
+		zap.String("approval_id", id.String()),
+		zap.String("operation", string(model.Operation)),
+		zap.String("result_id", resultID.String()),
+	)
+
+	return s.get(ctx, id)
+}
+
+// checkDecider checks the principal of the request may decide the approval. The operations moving the
+// funds of the account are decided only by the principals allowed to debit it, since the back-office,
+// while seeing them, may not move the funds of the holders.
+func (s service) checkDecider(ctx context.Context, approval Approval, decision Decision) error {
+	if err := s.authorize(ctx, approval); err != nil {
+		return err
+	}
+
+	if !approval.Operation.movesFunds() {
+		return nil
+	}
+
+	err := s.policiesSvc.AuthorizeDebit(ctx, decision.Actor, approval.AccountID)
+	if errors.Is(err, policies.ErrForbidden) {
+		return ErrApproverNotAllowed
+	}
+
+	return err
+}
+
+// execute makes the operation with its payload, on behalf of its submitter, returning what it made.
+func (s service) execute(ctx context.Context, approval Approval) (uuid.UUID, error) {
+	switch approval.Operation {
+	case TransferOperation:
+		var p transferPayload
+		if err := json.Unmarshal(approval.Payload, &p); err != nil {
+			return uuid.Nil, err
+		}
+
+		transfer, err := s.transfersSvc.Create(ctx, transfers.Transfer{
+			AccountID: approval.AccountID,
+			Amount:    p.Amount,
+			Beneficiary: transfers.Beneficiary{
+				BankCode:       p.BankCode,
+				Agency:         p.Agency,
+				AccountNumber:  p.AccountNumber,
+				DocumentNumber: p.DocumentNumber,
+				Name:           p.Name,
+			},
+			Description: p.Description,
+			RequestedBy: approval.SubmittedBy,
+			Approved:    true,
+		})
+		return transfer.ID, err
+	case P2POperation:
+		var p p2pPayload
+		if err := json.Unmarshal(approval.Payload, &p); err != nil {
+			return uuid.Nil, err
+		}
+
+		toID, err := uuid.Parse(p.ToAccountID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		transaction, err := s.transactionsSvc.CreateP2P(ctx, transactions.Transaction{
+			From:           approval.AccountID,
+			To:             toID,
+			Amount:         p.Amount,
+			Currency:       exchange.Currency(p.Currency),
+			Description:    p.Description,
+			RequestedBy:    approval.SubmittedBy,
+			IdempotencyKey: p2pKey(approval.ID),
+			Approved:       true,
+		})
+		return transaction.ID, err
+	case BlockAccountOperation, CloseAccountOperation:
+		var p statusPayload
+		if err := json.Unmarshal(approval.Payload, &p); err != nil {
+			return uuid.Nil, err
+		}
+
+		if approval.Operation == BlockAccountOperation {
+			account, err := s.accountsSvc.BlockByID(ctx, approval.AccountID, accounts.StatusChange{
+				Reason: p.Reason,
+				Actor:  approval.SubmittedBy,
+			})
+			return account.ID, err
+		}
+
+		closure := accounts.Closure{Reason: p.Reason, Actor: approval.SubmittedBy}
+		if p.DestinationAccountID != "" {
+			destinationID, err := uuid.Parse(p.DestinationAccountID)
+			if err != nil {
+				return uuid.Nil, err
+			}
+			closure.DestinationID = uuid.NullUUID{UUID: destinationID, Valid: true}
+		}
+
+		// the interest accrued until the closure is paid with the balance swept by it.
+		if err := s.interestSvc.Settle(ctx, approval.AccountID); err != nil {
+			return uuid.Nil, err
+		}
+
+		account, err := s.transactionsSvc.CloseAccount(ctx, approval.AccountID, closure)
+		return account.ID, err
+	}
+
+	return uuid.Nil, errUnknownOperation
+}
+
+// Reject refuses the pending operation, which is never made. It is rejected by whoever may approve it,
+// or by its submitter to withdraw it.
+func (s service) Reject(ctx context.Context, id uuid.UUID, decision Decision) (Approval, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if strings.TrimSpace(decision.Actor) == "" {
+		span.RecordError(ErrApproverRequired)
+		return Approval{}, ErrApproverRequired
+	}
+
+	if strings.TrimSpace(decision.Reason) == "" {
+		span.RecordError(ErrReasonRequired)
+		return Approval{}, ErrReasonRequired
+	}
+
+	approval, err := s.pending(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return Approval{}, err
+	}
+
+	if decision.Actor == approval.SubmittedBy {
+		err = s.authorize(ctx, approval)
+	} else {
+		err = s.checkDecider(ctx, approval, decision)
+	}
+	if err != nil {
+		span.RecordError(err)
+		return Approval{}, err
+	}
+
+	_, err = s.repository.Transition(ctx, approvalModel{
+		ID:        id,
+		Status:    RejectedStatus,
+		DecidedBy: decision.Actor,
+	}, PendingStatus, newEventModel(RejectedAction, decision.Actor, decision.Reason))
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, errStatusChanged) {
+			return Approval{}, ErrApprovalNotPending
+		}
+		zapctx.L(ctx).Error("approval_service_reject_repository_error", zap.Error(err))
+		return Approval{}, err
+	}
+
+	zapctx.L(ctx).Info(
+		"approval_rejected",
+		zap.String("approval_id", id.String()),
+		zap.String("decided_by", decision.Actor),
+		zap.String("reason", decision.Reason),
+	)
+
+	return s.get(ctx, id)
+}
+
+// pending finds the approval still waiting for a decision, expiring it when its time is up.
+func (s service) pending(ctx context.Context, id uuid.UUID) (Approval, error) {
+	approval, err := s.get(ctx, id)
+	if err != nil {
+		return Approval{}, err
+	}
+
+	if approval.Status != PendingStatus {
+		return Approval{}, ErrApprovalNotPending
+	}
+
+	if !time.Now().Before(approval.ExpiresAt) {
+		s.expire(ctx, approval.ID)
+		return Approval{}, ErrApprovalExpired
+	}
+
+	return approval, nil
+}
+
+// ExpireApprovals expires the pending approvals not decided in time, returning how many expired.
+func (s service) ExpireApprovals(ctx context.Context) (int, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	models, err := s.repository.ListExpired(ctx, time.Now().UTC())
+	if err != nil {
+		zapctx.L(ctx).Error("approval_service_list_expired_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return 0, err
+	}
+
+	var expired int
+	for _, model := range models {
+		if s.expire(ctx, model.ID) {
+			expired++
+		}
+	}
+
+	return expired, nil
+}
+
+// expire expires the pending approval, telling whether it did.
+func (s service) expire(ctx context.Context, id uuid.UUID) bool {
+	_, err := s.repository.Transition(ctx, approvalModel{
+		ID:        id,
+		Status:    ExpiredStatus,
+		DecidedBy: expirationActor,
+	}, PendingStatus, newEventModel(ExpiredAction, expirationActor, "the operation was not approved in time"))
+	if err != nil {
+		if !errors.Is(err, errStatusChanged) {
+			zapctx.L(ctx).Error(
+				"approval_service_expire_repository_error",
+				zap.String("approval_id", id.String()),
+				zap.Error(err),
+			)
+		}
+		return false
+	}
+
+	zapctx.L(ctx).Info("approval_expired", zap.String("approval_id", id.String()))
+
+	return true
+}
+
+// p2pKey is the idempotency key of the P2P made by the approval, so it is made once.
+func p2pKey(id uuid.UUID) string {
+	return "approval:" + id.String()
+}
@@ -0,0 +1,175 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/approvals/service.go
+
+// Package approvals is a generated GoMock package.
+package approvals
+
+import (
+	context "context"
+	reflect "reflect"
+
+	accounts "github.com/dalmarcogd/dock-test/internal/accounts"
+	transactions "github.com/dalmarcogd/dock-test/internal/transactions"
+	transfers "github.com/dalmarcogd/dock-test/internal/transfers"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockService) Approve(ctx context.Context, id uuid.UUID, decision Decision) (Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, id, decision)
+	ret0, _ := ret[0].(Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockServiceMockRecorder) Approve(ctx, id, decision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockService)(nil).Approve), ctx, id, decision)
+}
+
+// ExpireApprovals mocks base method.
+func (m *MockService) ExpireApprovals(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireApprovals", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireApprovals indicates an expected call of ExpireApprovals.
+func (mr *MockServiceMockRecorder) ExpireApprovals(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireApprovals", reflect.TypeOf((*MockService)(nil).ExpireApprovals), ctx)
+}
+
+// GetByID mocks base method.
+func (m *MockService) GetByID(ctx context.Context, id uuid.UUID) (Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockServiceMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockService)(nil).GetByID), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockService) List(ctx context.Context, filter Filter) (int, []Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]Approval)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockServiceMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), ctx, filter)
+}
+
+// Reject mocks base method.
+func (m *MockService) Reject(ctx context.Context, id uuid.UUID, decision Decision) (Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, id, decision)
+	ret0, _ := ret[0].(Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockServiceMockRecorder) Reject(ctx, id, decision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockService)(nil).Reject), ctx, id, decision)
+}
+
+// SubmitBlock mocks base method.
+func (m *MockService) SubmitBlock(ctx context.Context, accountID uuid.UUID, change accounts.StatusChange) (Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitBlock", ctx, accountID, change)
+	ret0, _ := ret[0].(Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitBlock indicates an expected call of SubmitBlock.
+func (mr *MockServiceMockRecorder) SubmitBlock(ctx, accountID, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitBlock", reflect.TypeOf((*MockService)(nil).SubmitBlock), ctx, accountID, change)
+}
+
+// SubmitClose mocks base method.
+func (m *MockService) SubmitClose(ctx context.Context, accountID uuid.UUID, closure accounts.Closure) (Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitClose", ctx, accountID, closure)
+	ret0, _ := ret[0].(Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitClose indicates an expected call of SubmitClose.
+func (mr *MockServiceMockRecorder) SubmitClose(ctx, accountID, closure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitClose", reflect.TypeOf((*MockService)(nil).SubmitClose), ctx, accountID, closure)
+}
+
+// SubmitP2P mocks base method.
+func (m *MockService) SubmitP2P(ctx context.Context, transaction transactions.Transaction) (Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitP2P", ctx, transaction)
+	ret0, _ := ret[0].(Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitP2P indicates an expected call of SubmitP2P.
+func (mr *MockServiceMockRecorder) SubmitP2P(ctx, transaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitP2P", reflect.TypeOf((*MockService)(nil).SubmitP2P), ctx, transaction)
+}
+
+// SubmitTransfer mocks base method.
+func (m *MockService) SubmitTransfer(ctx context.Context, transfer transfers.Transfer) (Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitTransfer", ctx, transfer)
+	ret0, _ := ret[0].(Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitTransfer indicates an expected call of SubmitTransfer.
+func (mr *MockServiceMockRecorder) SubmitTransfer(ctx, transfer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitTransfer", reflect.TypeOf((*MockService)(nil).SubmitTransfer), ctx, transfer)
+}
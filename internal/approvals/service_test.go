@@ -0,0 +1,607 @@
+//go:build unit
+
+package approvals
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/interest"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/internal/products"
+	"github.com/dalmarcogd/dock-test/internal/transactions"
+	"github.com/dalmarcogd/dock-test/internal/transfers"
+	"github.com/dalmarcogd/dock-test/pkg/gomockeq"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_Submit(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	accSvcMock := accounts.NewMockService(ctrl)
+	svc := NewService(
+		tracer.NewNoop(),
+		repoMock,
+		accSvcMock,
+		transactions.NewMockService(ctrl),
+		transfers.NewMockService(ctrl),
+		interest.NewMockService(ctrl),
+		policies.NewMockService(ctrl),
+		time.Hour,
+	)
+
+	businessID := uuid.New()
+	checkingID := uuid.New()
+	accSvcMock.EXPECT().
+		GetByID(ctx, businessID).
+		Return(accounts.Account{ID: businessID, Type: products.BusinessType}, nil).
+		AnyTimes()
+	accSvcMock.EXPECT().
+		GetByID(ctx, checkingID).
+		Return(accounts.Account{ID: checkingID, Type: products.CheckingType}, nil).
+		AnyTimes()
+
+	t.Run("fail submit, no submitter or reason", func(t *testing.T) {
+		approval, err := svc.SubmitTransfer(ctx, transfers.Transfer{AccountID: businessID, Amount: 5000})
+		assert.ErrorIs(t, err, ErrSubmitterRequired)
+		assert.Empty(t, approval)
+
+		approval, err = svc.SubmitBlock(ctx, businessID, accounts.StatusChange{Actor: "maker"})
+		assert.ErrorIs(t, err, accounts.ErrStatusReasonRequired)
+		assert.Empty(t, approval)
+	})
+
+	t.Run("fail submit, account not found", func(t *testing.T) {
+		id := uuid.New()
+		accSvcMock.EXPECT().GetByID(ctx, id).Return(accounts.Account{}, accounts.ErrAccountNotFound)
+
+		approval, err := svc.SubmitBlock(ctx, id, accounts.StatusChange{Reason: "fraud suspicion", Actor: "maker"})
+		assert.ErrorIs(t, err, accounts.ErrAccountNotFound)
+		assert.Empty(t, approval)
+	})
+
+	t.Run("submit transfer", func(t *testing.T) {
+		transfer := transfers.Transfer{
+			AccountID: businessID,
+			Amount:    5000,
+			Beneficiary: transfers.Beneficiary{
+				BankCode:       "341",
+				Agency:         "0001",
+				AccountNumber:  "12345-6",
+				DocumentNumber: "11222333000181",
+				Name:           "Supplier",
+			},
+			Description: "invoice 42",
+			RequestedBy: "maker-principal",
+		}
+
+		repoMock.EXPECT().
+			Create(
+				ctx,
+				gomockeq.Eq(
+					approvalModel{
+						Operation: TransferOperation,
+						AccountID: businessID,
+						Payload: `{"amount":5000,"bank_code":"341","agency":"0001","account_number":"12345-6",` +
+							`"document_number":"11222333000181","name":"Supplier","description":"invoice 42"}`,
+						Status:      PendingStatus,
+						SubmittedBy: transfer.RequestedBy,
+					},
+					gomockeq.IgnoreFields("ID", "ExpiresAt", "CreatedAt"),
+				),
+				gomockeq.Eq(
+					eventModel{Action: SubmittedAction, Actor: transfer.RequestedBy, Reason: transfer.Description},
+					gomockeq.IgnoreFields("ID"),
+				),
+			).
+			DoAndReturn(func(_ context.Context, m approvalModel, _ eventModel) (approvalModel, error) {
+				return m, nil
+			})
+
+		approval, err := svc.SubmitTransfer(ctx, transfer)
+		assert.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, approval.ID)
+		assert.Equal(t, PendingStatus, approval.Status)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), approval.ExpiresAt, time.Minute)
+	})
+
+	t.Run("submit p2p", func(t *testing.T) {
+		toID := uuid.New()
+		p2p := transactions.Transaction{
+			From:        businessID,
+			To:          toID,
+			Amount:      5000,
+			Description: "invoice 43",
+			RequestedBy: "maker-principal",
+		}
+
+		repoMock.EXPECT().
+			Create(
+				ctx,
+				gomockeq.Eq(
+					approvalModel{
+						Operation:   P2POperation,
+						AccountID:   businessID,
+						Payload:     `{"to_account_id":"` + toID.String() + `","amount":5000,"description":"invoice 43"}`,
+						Status:      PendingStatus,
+						SubmittedBy: p2p.RequestedBy,
+					},
+					gomockeq.IgnoreFields("ID", "ExpiresAt", "CreatedAt"),
+				),
+				gomockeq.Eq(
+					eventModel{Action: SubmittedAction, Actor: p2p.RequestedBy, Reason: p2p.Description},
+					gomockeq.IgnoreFields("ID"),
+				),
+			).
+			DoAndReturn(func(_ context.Context, m approvalModel, _ eventModel) (approvalModel, error) {
+				return m, nil
+			})
+
+		approval, err := svc.SubmitP2P(ctx, p2p)
+		assert.NoError(t, err)
+		assert.Equal(t, P2POperation, approval.Operation)
+		assert.Equal(t, PendingStatus, approval.Status)
+	})
+
+	t.Run("submit close with a destination", func(t *testing.T) {
+		destinationID := uuid.New()
+
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, m approvalModel, _ eventModel) (approvalModel, error) {
+				return m, nil
+			})
+
+		approval, err := svc.SubmitClose(ctx, checkingID, accounts.Closure{
+			Reason:        "customer request",
+			Actor:         "maker",
+			DestinationID: uuid.NullUUID{UUID: destinationID, Valid: true},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, CloseAccountOperation, approval.Operation)
+
+		var p statusPayload
+		assert.NoError(t, json.Unmarshal(approval.Payload, &p))
+		assert.Equal(t, statusPayload{Reason: "customer request", DestinationAccountID: destinationID.String()}, p)
+	})
+}
+
+func TestService_Decide(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	accSvcMock := accounts.NewMockService(ctrl)
+	trxSvcMock := transactions.NewMockService(ctrl)
+	trfSvcMock := transfers.NewMockService(ctrl)
+	intSvcMock := interest.NewMockService(ctrl)
+	plcSvcMock := policies.NewMockService(ctrl)
+	svc := NewService(
+		tracer.NewNoop(),
+		repoMock,
+		accSvcMock,
+		trxSvcMock,
+		trfSvcMock,
+		intSvcMock,
+		plcSvcMock,
+		time.Hour,
+	)
+
+	accountID := uuid.New()
+	pending := func(operation Operation, payload string) approvalModel {
+		return approvalModel{
+			ID:          uuid.New(),
+			Operation:   operation,
+			AccountID:   accountID,
+			Payload:     payload,
+			Status:      PendingStatus,
+			SubmittedBy: "maker",
+			ExpiresAt:   time.Now().UTC().Add(time.Hour),
+			CreatedAt:   time.Now().UTC(),
+		}
+	}
+	block := func() approvalModel {
+		return pending(BlockAccountOperation, `{"reason":"fraud suspicion"}`)
+	}
+	destinationID := uuid.New()
+	closure := func() approvalModel {
+		return pending(
+			CloseAccountOperation,
+			`{"reason":"requested by the holder","destination_account_id":"`+destinationID.String()+`"}`,
+		)
+	}
+	transfer := func() approvalModel {
+		model := pending(TransferOperation, `{"amount":5000,"bank_code":"341","name":"Supplier"}`)
+		model.SubmittedBy = "maker-principal"
+		return model
+	}
+	checker := Decision{Reason: "confirmed by phone", Actor: "checker"}
+
+	t.Run("fail approve, no approver", func(t *testing.T) {
+		approval, err := svc.Approve(ctx, uuid.New(), Decision{})
+		assert.ErrorIs(t, err, ErrApproverRequired)
+		assert.Empty(t, approval)
+	})
+
+	t.Run("fail approve, approval not found", func(t *testing.T) {
+		id := uuid.New()
+		repoMock.EXPECT().GetByID(ctx, id).Return(approvalModel{}, nil, sql.ErrNoRows)
+
+		approval, err := svc.Approve(ctx, id, checker)
+		assert.ErrorIs(t, err, ErrApprovalNotFound)
+		assert.Empty(t, approval)
+	})
+
+	t.Run("fail approve, approved by its submitter", func(t *testing.T) {
+		model := block()
+		repoMock.EXPECT().GetByID(ctx, model.ID).Return(model, nil, nil)
+
+		approval, err := svc.Approve(ctx, model.ID, Decision{Actor: model.SubmittedBy})
+		assert.ErrorIs(t, err, ErrSameApprover)
+		assert.Empty(t, approval)
+	})
+
+	t.Run("fail approve, already decided", func(t *testing.T) {
+		model := block()
+		model.Status = RejectedStatus
+		repoMock.EXPECT().GetByID(ctx, model.ID).Return(model, nil, nil)
+
+		approval, err := svc.Approve(ctx, model.ID, checker)
+		assert.ErrorIs(t, err, ErrApprovalNotPending)
+		assert.Empty(t, approval)
+	})
+
+	t.Run("fail approve, expired", func(t *testing.T) {
+		model := block()
+		model.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+		repoMock.EXPECT().GetByID(ctx, model.ID).Return(model, nil, nil)
+		repoMock.EXPECT().
+			Transition(
+				ctx,
+				approvalModel{ID: model.ID, Status: ExpiredStatus, DecidedBy: expirationActor},
+				PendingStatus,
+				gomockeq.Eq(eventModel{Action: ExpiredAction, Actor: expirationActor}, gomockeq.IgnoreFields("ID", "Reason")),
+			).
+			Return(approvalModel{}, nil)
+
+		approval, err := svc.Approve(ctx, model.ID, checker)
+		assert.ErrorIs(t, err, ErrApprovalExpired)
+		assert.Empty(t, approval)
+	})
+
+	t.Run("fail approve transfer, approver not allowed to debit", func(t *testing.T) {
+		model := transfer()
+		repoMock.EXPECT().GetByID(ctx, model.ID).Return(model, nil, nil)
+		plcSvcMock.EXPECT().AuthorizeAccount(ctx, accountID, policies.DebitAccess).Return(nil)
+		plcSvcMock.EXPECT().AuthorizeDebit(ctx, checker.Actor, accountID).Return(policies.ErrForbidden)
+
+		approval, err := svc.Approve(ctx, model.ID, checker)
+		assert.ErrorIs(t, err, ErrApproverNotAllowed)
+		assert.Empty(t, approval)
+	})
+
+	t.Run("approve block, executed", func(t *testing.T) {
+		model := block()
+		approved := model
+		approved.Status = ApprovedStatus
+		approved.DecidedBy = checker.Actor
+		executed := approved
+		executed.Status = ExecutedStatus
+		executed.ResultID = uuid.NullUUID{UUID: accountID, Valid: true}
+
+		gomock.InOrder(
+			repoMock.EXPECT().GetByID(ctx, model.ID).Return(model, nil, nil),
+			plcSvcMock.EXPECT().AuthorizeBackOffice(ctx).Return(nil),
+			repoMock.EXPECT().
+				Transition(
+					ctx,
+					approvalModel{ID: model.ID, Status: ApprovedStatus, DecidedBy: checker.Actor},
+					PendingStatus,
+					gomockeq.Eq(
+						eventModel{Action: ApprovedAction, Actor: checker.Actor, Reason: checker.Reason},
+						gomockeq.IgnoreFields("ID"),
+					),
+				).
+				Return(approved, nil),
+			accSvcMock.EXPECT().
+				BlockByID(ctx, accountID, accounts.StatusChange{Reason: "fraud suspicion", Actor: "maker"}).
+				Return(accounts.Account{ID: accountID, Status: accounts.BlockedStatus}, nil),
+			repoMock.EXPECT().
+				Transition(
+					ctx,
+					executed,
+					ApprovedStatus,
+					gomockeq.Eq(eventModel{Action: ExecutedAction, Actor: checker.Actor}, gomockeq.IgnoreFields("ID")),
+				).
+				Return(executed, nil),
+			repoMock.EXPECT().
+				GetByID(ctx, model.ID).
+				Return(executed, []eventModel{{Action: SubmittedAction}, {Action: ApprovedAction}, {Action: ExecutedAction}}, nil),
+		)
+
+		approval, err := svc.Approve(ctx, model.ID, checker)
+		assert.NoError(t, err)
+		assert.Equal(t, ExecutedStatus, approval.Status)
+		assert.Equal(t, executed.ResultID, approval.ResultID)
+		assert.Len(t, approval.Events, 3)
+	})
+
+	t.Run("approve closure, failed with pending reviews", func(t *testing.T) {
+		model := closure()
+		approved := model
+		approved.Status = ApprovedStatus
+		approved.DecidedBy = checker.Actor
+
+		gomock.InOrder(
+			repoMock.EXPECT().GetByID(ctx, model.ID).Return(model, nil, nil),
+			plcSvcMock.EXPECT().AuthorizeBackOffice(ctx).Return(nil),
+			repoMock.EXPECT().Transition(ctx, gomock.Any(), PendingStatus, gomock.Any()).Return(approved, nil),
+			intSvcMock.EXPECT().Settle(ctx, accountID).Return(nil),
+			trxSvcMock.EXPECT().
+				CloseAccount(ctx, accountID, accounts.Closure{
+					Reason:        "requested by the holder",
+					Actor:         "maker",
+					DestinationID: uuid.NullUUID{UUID: destinationID, Valid: true},
+				}).
+				Return(accounts.Account{}, transactions.ErrAccountHasPendingReviews),
+			repoMock.EXPECT().Transition(ctx, gomock.Any(), ApprovedStatus, gomock.Any()).Return(approvalModel{}, nil),
+		)
+
+		approval, err := svc.Approve(ctx, model.ID, checker)
+		assert.ErrorIs(t, err, transactions.ErrAccountHasPendingReviews)
+		assert.Empty(t, approval)
+	})
+
+	t.Run("approve closure, failed paying the interest", func(t *testing.T) {
+		model := closure()
+		approved := model
+		approved.Status = ApprovedStatus
+		approved.DecidedBy = checker.Actor
+
+		gomock.InOrder(
+			repoMock.EXPECT().GetByID(ctx, model.ID).Return(model, nil, nil),
+			plcSvcMock.EXPECT().AuthorizeBackOffice(ctx).Return(nil),
+			repoMock.EXPECT().Transition(ctx, gomock.Any(), PendingStatus, gomock.Any()).Return(approved, nil),
+			intSvcMock.EXPECT().Settle(ctx, accountID).Return(transactions.ErrAccountCreditsBlocked),
+			repoMock.EXPECT().Transition(ctx, gomock.Any(), ApprovedStatus, gomock.Any()).Return(approvalModel{}, nil),
+		)
+
+		approval, err := svc.Approve(ctx, model.ID, checker)
+		assert.ErrorIs(t, err, transactions.ErrAccountCreditsBlocked)
+		assert.Empty(t, approval)
+	})
+
+	t.Run("approve transfer, failed", func(t *testing.T) {
+		model := transfer()
+		approved := model
+		approved.Status = ApprovedStatus
+		approved.DecidedBy = checker.Actor
+		failed := approved
+		failed.Status = FailedStatus
+		failed.Error = transactions.ErrBalanceInsufficientFunds.Error()
+
+		gomock.InOrder(
+			repoMock.EXPECT().GetByID(ctx, model.ID).Return(model, nil, nil),
+			plcSvcMock.EXPECT().AuthorizeAccount(ctx, accountID, policies.DebitAccess).Return(nil),
+			plcSvcMock.EXPECT().AuthorizeDebit(ctx, checker.Actor, accountID).Return(nil),
+			repoMock.EXPECT().Transition(ctx, gomock.Any(), PendingStatus, gomock.Any()).Return(approved, nil),
+			trfSvcMock.EXPECT().
+				Create(ctx, transfers.Transfer{
+					AccountID:   accountID,
+					Amount:      5000,
+					Beneficiary: transfers.Beneficiary{BankCode: "341", Name: "Supplier"},
+					RequestedBy: "maker-principal",
+					Approved:    true,
+				}).
+				Return(transfers.Transfer{}, transactions.ErrBalanceInsufficientFunds),
+			repoMock.EXPECT().
+				Transition(
+					ctx,
+					failed,
+					ApprovedStatus,
+					gomockeq.Eq(
+						eventModel{Action: FailedAction, Actor: checker.Actor, Reason: failed.Error},
+						gomockeq.IgnoreFields("ID"),
+					),
+				).
+				Return(failed, nil),
+		)
+
+		approval, err := svc.Approve(ctx, model.ID, checker)
+		assert.ErrorIs(t, err, transactions.ErrBalanceInsufficientFunds)
+		assert.Empty(t, approval)
+	})
+
+	t.Run("approve p2p, made on behalf of the maker", func(t *testing.T) {
+		toID := uuid.New()
+		model := pending(P2POperation, `{"to_account_id":"`+toID.String()+`","amount":5000,"description":"invoice 43"}`)
+		approved := model
+		approved.Status = ApprovedStatus
+		approved.DecidedBy = checker.Actor
+		executed := approved
+		executed.Status = ExecutedStatus
+		transactionID := uuid.New()
+		executed.ResultID = uuid.NullUUID{UUID: transactionID, Valid: true}
+
+		gomock.InOrder(
+			repoMock.EXPECT().GetByID(ctx, model.ID).Return(model, nil, nil),
+			plcSvcMock.EXPECT().AuthorizeAccount(ctx, accountID, policies.DebitAccess).Return(nil),
+			plcSvcMock.EXPECT().AuthorizeDebit(ctx, checker.Actor, accountID).Return(nil),
+			repoMock.EXPECT().Transition(ctx, gomock.Any(), PendingStatus, gomock.Any()).Return(approved, nil),
+			trxSvcMock.EXPECT().
+				CreateP2P(ctx, transactions.Transaction{
+					From:           accountID,
+					To:             toID,
+					Amount:         5000,
+					Description:    "invoice 43",
+					RequestedBy:    "maker",
+					IdempotencyKey: "approval:" + model.ID.String(),
+					Approved:       true,
+				}).
+				Return(transactions.Transaction{ID: transactionID}, nil),
+			repoMock.EXPECT().Transition(ctx, executed, ApprovedStatus, gomock.Any()).Return(executed, nil),
+			repoMock.EXPECT().GetByID(ctx, model.ID).Return(executed, nil, nil),
+		)
+
+		approval, err := svc.Approve(ctx, model.ID, checker)
+		assert.NoError(t, err)
+		assert.Equal(t, ExecutedStatus, approval.Status)
+		assert.Equal(t, executed.ResultID, approval.ResultID)
+	})
+
+	t.Run("fail approve block, not back-office", func(t *testing.T) {
+		model := block()
+		repoMock.EXPECT().GetByID(ctx, model.ID).Return(model, nil, nil)
+		plcSvcMock.EXPECT().AuthorizeBackOffice(ctx).Return(policies.ErrForbidden)
+
+		approval, err := svc.Approve(ctx, model.ID, checker)
+		assert.ErrorIs(t, err, policies.ErrForbidden)
+		assert.Empty(t, approval)
+	})
+
+	t.Run("fail approve, decided meanwhile", func(t *testing.T) {
+		model := block()
+		repoMock.EXPECT().GetByID(ctx, model.ID).Return(model, nil, nil)
+		plcSvcMock.EXPECT().AuthorizeBackOffice(ctx).Return(nil)
+		repoMock.EXPECT().Transition(ctx, gomock.Any(), PendingStatus, gomock.Any()).Return(approvalModel{}, errStatusChanged)
+
+		approval, err := svc.Approve(ctx, model.ID, checker)
+		assert.ErrorIs(t, err, ErrApprovalNotPending)
+		assert.Empty(t, approval)
+	})
+
+	t.Run("fail reject, no reason", func(t *testing.T) {
+		approval, err := svc.Reject(ctx, uuid.New(), Decision{Actor: checker.Actor})
+		assert.ErrorIs(t, err, ErrReasonRequired)
+		assert.Empty(t, approval)
+	})
+
+	t.Run("reject by its submitter", func(t *testing.T) {
+		model := block()
+		rejected := model
+		rejected.Status = RejectedStatus
+		rejected.DecidedBy = model.SubmittedBy
+		withdraw := Decision{Reason: "submitted by mistake", Actor: model.SubmittedBy}
+
+		gomock.InOrder(
+			repoMock.EXPECT().GetByID(ctx, model.ID).Return(model, nil, nil),
+			plcSvcMock.EXPECT().AuthorizeBackOffice(ctx).Return(nil),
+			repoMock.EXPECT().
+				Transition(
+					ctx,
+					approvalModel{ID: model.ID, Status: RejectedStatus, DecidedBy: withdraw.Actor},
+					PendingStatus,
+					gomockeq.Eq(
+						eventModel{Action: RejectedAction, Actor: withdraw.Actor, Reason: withdraw.Reason},
+						gomockeq.IgnoreFields("ID"),
+					),
+				).
+				Return(rejected, nil),
+			repoMock.EXPECT().GetByID(ctx, model.ID).Return(rejected, nil, nil),
+		)
+
+		approval, err := svc.Reject(ctx, model.ID, withdraw)
+		assert.NoError(t, err)
+		assert.Equal(t, RejectedStatus, approval.Status)
+	})
+
+	t.Run("fail reject transfer, neither the submitter nor allowed to debit", func(t *testing.T) {
+		model := transfer()
+		gomock.InOrder(
+			repoMock.EXPECT().GetByID(ctx, model.ID).Return(model, nil, nil),
+			plcSvcMock.EXPECT().AuthorizeAccount(ctx, accountID, policies.DebitAccess).Return(nil),
+			plcSvcMock.EXPECT().AuthorizeDebit(ctx, checker.Actor, accountID).Return(policies.ErrForbidden),
+		)
+
+		approval, err := svc.Reject(ctx, model.ID, checker)
+		assert.ErrorIs(t, err, ErrApproverNotAllowed)
+		assert.Empty(t, approval)
+	})
+
+	t.Run("expire approvals not decided in time", func(t *testing.T) {
+		expired, decided := block(), block()
+
+		gomock.InOrder(
+			repoMock.EXPECT().ListExpired(ctx, gomock.Any()).Return([]approvalModel{expired, decided}, nil),
+			repoMock.EXPECT().Transition(ctx, gomock.Any(), PendingStatus, gomock.Any()).Return(expired, nil),
+			repoMock.EXPECT().
+				Transition(ctx, gomock.Any(), PendingStatus, gomock.Any()).
+				Return(approvalModel{}, errStatusChanged),
+		)
+
+		count, err := svc.ExpireApprovals(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+}
+
+func TestService_List(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	plcSvcMock := policies.NewMockService(ctrl)
+	svc := NewService(
+		tracer.NewNoop(),
+		repoMock,
+		accounts.NewMockService(ctrl),
+		transactions.NewMockService(ctrl),
+		transfers.NewMockService(ctrl),
+		interest.NewMockService(ctrl),
+		plcSvcMock,
+		time.Hour,
+	)
+
+	accountID := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+
+	t.Run("list every approval to the back-office", func(t *testing.T) {
+		filter := Filter{Page: 1, Size: 10}
+		plcSvcMock.EXPECT().AuthorizeBackOffice(ctx).Return(nil)
+		repoMock.EXPECT().List(ctx, filter).Return(1, []approvalModel{{Operation: BlockAccountOperation}}, nil)
+
+		total, approvals, err := svc.List(ctx, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, approvals, 1)
+	})
+
+	t.Run("fail list, not back-office without an account", func(t *testing.T) {
+		plcSvcMock.EXPECT().AuthorizeBackOffice(ctx).Return(policies.ErrForbidden)
+
+		_, _, err := svc.List(ctx, Filter{Page: 1, Size: 10})
+		assert.ErrorIs(t, err, policies.ErrForbidden)
+	})
+
+	t.Run("fail list, blocks of the account", func(t *testing.T) {
+		plcSvcMock.EXPECT().AuthorizeBackOffice(ctx).Return(policies.ErrForbidden)
+		plcSvcMock.EXPECT().AuthorizeAccount(ctx, accountID.UUID, policies.DebitAccess).Return(nil)
+
+		_, _, err := svc.List(ctx, Filter{Operations: []Operation{BlockAccountOperation}, AccountID: accountID})
+		assert.ErrorIs(t, err, policies.ErrForbidden)
+	})
+
+	t.Run("list the operations moving the funds of the account", func(t *testing.T) {
+		plcSvcMock.EXPECT().AuthorizeBackOffice(ctx).Return(policies.ErrForbidden)
+		plcSvcMock.EXPECT().AuthorizeAccount(ctx, accountID.UUID, policies.DebitAccess).Return(nil)
+		repoMock.EXPECT().
+			List(ctx, Filter{Operations: []Operation{TransferOperation, P2POperation}, AccountID: accountID, Page: 1, Size: 10}).
+			Return(0, nil, nil)
+
+		total, approvals, err := svc.List(ctx, Filter{AccountID: accountID, Page: 1, Size: 10})
+		assert.NoError(t, err)
+		assert.Zero(t, total)
+		assert.Empty(t, approvals)
+	})
+}
@@ -1,10 +1,20 @@
 package balances
 
 import (
+	"github.com/dalmarcogd/dock-test/internal/exchange"
 	"github.com/google/uuid"
 )
 
 type AccountBalance struct {
 	AccountID      uuid.UUID
+	Currency       exchange.Currency
 	CurrentBalance float64
+	// ConsolidatedBalance adds the balances of the account pockets to CurrentBalance.
+	ConsolidatedBalance float64
+}
+
+// CurrencyBalance sums the balances of several accounts held in the same currency.
+type CurrencyBalance struct {
+	Currency exchange.Currency
+	Balance  float64
 }
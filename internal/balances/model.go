@@ -1,6 +1,7 @@
 package balances
 
 import (
+	"github.com/dalmarcogd/dock-test/internal/exchange"
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 )
@@ -8,6 +9,18 @@ import (
 type accountBalanceModel struct {
 	bun.BaseModel `bun:"transactions_balances"`
 
-	AccountID uuid.UUID `bun:"account_id"`
-	Balance   float64   `bun:"balance"`
+	AccountID uuid.UUID         `bun:"account_id"`
+	Balance   float64           `bun:"balance"`
+	Currency  exchange.Currency `bun:"currency"`
+}
+
+type consolidatedBalanceModel struct {
+	Currency            exchange.Currency `bun:"currency"`
+	Balance             float64           `bun:"balance"`
+	ConsolidatedBalance float64           `bun:"consolidated_balance"`
+}
+
+type currencyBalanceModel struct {
+	Currency exchange.Currency `bun:"currency"`
+	Balance  float64           `bun:"balance"`
 }
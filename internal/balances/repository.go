@@ -3,13 +3,18 @@ package balances
 import (
 	"context"
 
+	"github.com/dalmarcogd/dock-test/internal/accounts"
 	"github.com/dalmarcogd/dock-test/pkg/database"
 	"github.com/dalmarcogd/dock-test/pkg/tracer"
 	"github.com/google/uuid"
+	"github.com/uptrace/bun"
 )
 
 type Repository interface {
 	GetByAccountID(ctx context.Context, accountID uuid.UUID) (accountBalanceModel, error)
+	ListByAccountIDs(ctx context.Context, accountIDs []uuid.UUID) ([]accountBalanceModel, error)
+	GetConsolidatedByAccountID(ctx context.Context, accountID uuid.UUID) (consolidatedBalanceModel, error)
+	ListByHolderID(ctx context.Context, holderID uuid.UUID) ([]currencyBalanceModel, error)
 }
 
 type repository struct {
@@ -42,3 +47,81 @@ func (r repository) GetByAccountID(ctx context.Context, accountID uuid.UUID) (ac
 
 	return acb, nil
 }
+
+func (r repository) ListByAccountIDs(ctx context.Context, accountIDs []uuid.UUID) ([]accountBalanceModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	ids := make([]string, len(accountIDs))
+	for i, id := range accountIDs {
+		ids[i] = id.String()
+	}
+
+	selectQuery := r.db.Replica().
+		NewSelect().
+		ModelTableExpr("transactions_balances").
+		Where("account_id IN (?)", bun.In(ids))
+
+	var acbs []accountBalanceModel
+	err := selectQuery.Scan(ctx, &acbs)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return acbs, nil
+}
+
+// GetConsolidatedByAccountID sums the balance of the account and of its pockets.
+func (r repository) GetConsolidatedByAccountID(
+	ctx context.Context,
+	accountID uuid.UUID,
+) (consolidatedBalanceModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	selectQuery := r.db.Replica().
+		NewSelect().
+		TableExpr("accounts AS a").
+		Join("LEFT JOIN transactions_balances AS tb ON tb.account_id = a.id").
+		ColumnExpr("MIN(a.currency) FILTER (WHERE a.id = ?) AS currency", accountID.String()).
+		ColumnExpr("COALESCE(SUM(tb.balance) FILTER (WHERE a.id = ?), 0) AS balance", accountID.String()).
+		ColumnExpr("COALESCE(SUM(tb.balance), 0) AS consolidated_balance").
+		Where("a.id = ? OR a.parent_id = ?", accountID.String(), accountID.String())
+
+	var cbm consolidatedBalanceModel
+	err := selectQuery.Scan(ctx, &cbm)
+	if err != nil {
+		span.RecordError(err)
+		return consolidatedBalanceModel{}, err
+	}
+
+	return cbm, nil
+}
+
+// ListByHolderID sums, per currency, the balances of the open accounts the holder is linked to.
+func (r repository) ListByHolderID(ctx context.Context, holderID uuid.UUID) ([]currencyBalanceModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	selectQuery := r.db.Replica().
+		NewSelect().
+		TableExpr("account_holders AS ah").
+		Join("JOIN accounts AS a ON a.id = ah.account_id").
+		Join("LEFT JOIN transactions_balances AS tb ON tb.account_id = a.id").
+		ColumnExpr("a.currency").
+		ColumnExpr("COALESCE(SUM(tb.balance), 0) AS balance").
+		Where("ah.holder_id = ?", holderID.String()).
+		Where("a.status <> ?", accounts.ClosedStatus).
+		Group("a.currency").
+		Order("a.currency ASC")
+
+	var cbms []currencyBalanceModel
+	err := selectQuery.Scan(ctx, &cbms)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return cbms, nil
+}
@@ -13,6 +13,9 @@ import (
 
 type Service interface {
 	GetByAccountID(ctx context.Context, accountID uuid.UUID) (AccountBalance, error)
+	ListByAccountIDs(ctx context.Context, accountIDs []uuid.UUID) ([]AccountBalance, error)
+	GetConsolidatedByAccountID(ctx context.Context, accountID uuid.UUID) (AccountBalance, error)
+	ListByHolderID(ctx context.Context, holderID uuid.UUID) ([]CurrencyBalance, error)
 }
 
 type service struct {
@@ -43,6 +46,84 @@ func (s service) GetByAccountID(ctx context.Context, accountID uuid.UUID) (Accou
 
 	return AccountBalance{
 		AccountID:      accountBalance.AccountID,
+		Currency:       accountBalance.Currency,
 		CurrentBalance: accountBalance.Balance,
 	}, nil
 }
+
+func (s service) ListByAccountIDs(ctx context.Context, accountIDs []uuid.UUID) ([]AccountBalance, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if len(accountIDs) == 0 {
+		return []AccountBalance{}, nil
+	}
+
+	models, err := s.repository.ListByAccountIDs(ctx, accountIDs)
+	if err != nil {
+		zapctx.L(ctx).Error("balances_service_list_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	balanceByAccount := make(map[uuid.UUID]accountBalanceModel, len(models))
+	for _, model := range models {
+		balanceByAccount[model.AccountID] = model
+	}
+
+	// accounts without transactions are not present in the view, so they are returned with zero balance.
+	accbs := make([]AccountBalance, len(accountIDs))
+	for i, accountID := range accountIDs {
+		accbs[i] = AccountBalance{
+			AccountID:      accountID,
+			Currency:       balanceByAccount[accountID].Currency,
+			CurrentBalance: balanceByAccount[accountID].Balance,
+		}
+	}
+
+	return accbs, nil
+}
+
+// GetConsolidatedByAccountID returns the account balance along with the sum of it and its pockets balances.
+func (s service) GetConsolidatedByAccountID(ctx context.Context, accountID uuid.UUID) (AccountBalance, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	cbm, err := s.repository.GetConsolidatedByAccountID(ctx, accountID)
+	if err != nil {
+		zapctx.L(ctx).Error("balances_service_consolidated_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return AccountBalance{}, err
+	}
+
+	return AccountBalance{
+		AccountID:           accountID,
+		Currency:            cbm.Currency,
+		CurrentBalance:      cbm.Balance,
+		ConsolidatedBalance: cbm.ConsolidatedBalance,
+	}, nil
+}
+
+// ListByHolderID reports the balances of the holder accounts per currency, as amounts in different
+// currencies are never added together.
+func (s service) ListByHolderID(ctx context.Context, holderID uuid.UUID) ([]CurrencyBalance, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	models, err := s.repository.ListByHolderID(ctx, holderID)
+	if err != nil {
+		zapctx.L(ctx).Error("balances_service_list_by_holder_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	cbs := make([]CurrencyBalance, len(models))
+	for i, model := range models {
+		cbs[i] = CurrencyBalance{
+			Currency: model.Currency,
+			Balance:  model.Balance,
+		}
+	}
+
+	return cbs, nil
+}
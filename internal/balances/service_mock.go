@@ -49,3 +49,48 @@ func (mr *MockServiceMockRecorder) GetByAccountID(ctx, accountID interface{}) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAccountID", reflect.TypeOf((*MockService)(nil).GetByAccountID), ctx, accountID)
 }
+
+// GetConsolidatedByAccountID mocks base method.
+func (m *MockService) GetConsolidatedByAccountID(ctx context.Context, accountID uuid.UUID) (AccountBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConsolidatedByAccountID", ctx, accountID)
+	ret0, _ := ret[0].(AccountBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConsolidatedByAccountID indicates an expected call of GetConsolidatedByAccountID.
+func (mr *MockServiceMockRecorder) GetConsolidatedByAccountID(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConsolidatedByAccountID", reflect.TypeOf((*MockService)(nil).GetConsolidatedByAccountID), ctx, accountID)
+}
+
+// ListByAccountIDs mocks base method.
+func (m *MockService) ListByAccountIDs(ctx context.Context, accountIDs []uuid.UUID) ([]AccountBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByAccountIDs", ctx, accountIDs)
+	ret0, _ := ret[0].([]AccountBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByAccountIDs indicates an expected call of ListByAccountIDs.
+func (mr *MockServiceMockRecorder) ListByAccountIDs(ctx, accountIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAccountIDs", reflect.TypeOf((*MockService)(nil).ListByAccountIDs), ctx, accountIDs)
+}
+
+// ListByHolderID mocks base method.
+func (m *MockService) ListByHolderID(ctx context.Context, holderID uuid.UUID) ([]CurrencyBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByHolderID", ctx, holderID)
+	ret0, _ := ret[0].([]CurrencyBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByHolderID indicates an expected call of ListByHolderID.
+func (mr *MockServiceMockRecorder) ListByHolderID(ctx, holderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByHolderID", reflect.TypeOf((*MockService)(nil).ListByHolderID), ctx, holderID)
+}
@@ -0,0 +1,116 @@
+package batches
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status is the ISO 20022 status of a batch, or of each of its payments, reported in pain.002.
+type Status string
+
+const (
+	// AcceptedStatus is a payment whose transfer was created, or a batch whose payments all were.
+	AcceptedStatus Status = "ACSP"
+	// PartiallyAcceptedStatus is a batch with accepted and rejected payments.
+	PartiallyAcceptedStatus Status = "PART"
+	// RejectedStatus is a payment whose transfer could not be created, or a batch with every payment rejected.
+	RejectedStatus Status = "RJCT"
+)
+
+// Batch is a pain.001 customer credit transfer initiation, with a TED transfer created for each of
+// its payments.
+type Batch struct {
+	ID uuid.UUID
+	// MessageID identifies the pain.001 message, a message is submitted once by each principal.
+	MessageID string
+	// SubmittedBy is the id of the principal submitting the message.
+	SubmittedBy string
+	// InitiatingParty is the name of the party that sent the message.
+	InitiatingParty  string
+	TransactionCount int
+	ControlSum       float64
+	Status           Status
+	// ReasonCode explains why the whole batch was rejected, when its totals do not match its payments.
+	ReasonCode string
+	Payments   []Payment
+	CreatedAt  time.Time
+}
+
+// Payment is a credit transfer transaction of a pain.001 message.
+type Payment struct {
+	// PaymentInformationID is the group of payments debited from the same account.
+	PaymentInformationID string
+	InstructionID        string
+	EndToEndID           string
+	AccountID            uuid.UUID
+	// RequestedBy is the id of the principal submitting the message, who must be allowed to debit the account.
+	RequestedBy      string
+	Amount           float64
+	Currency         string
+	BankCode         string
+	Agency           string
+	AccountNumber    string
+	CreditorName     string
+	CreditorDocument string
+	Description      string
+	Status           Status
+	// ReasonCode is the ISO 20022 code of the reason a payment was rejected.
+	ReasonCode string
+	Reason     string
+	TransferID uuid.NullUUID
+}
+
+// sum is the total of the payments, rounded to cents as the control sum of the message.
+func (b Batch) sum() float64 {
+	var total float64
+	for _, payment := range b.Payments {
+		total += payment.Amount
+	}
+	return math.Round(total*100) / 100
+}
+
+// reject rejects the whole batch and each of its payments with the reason code.
+func (b *Batch) reject(code string) {
+	b.Status = RejectedStatus
+	b.ReasonCode = code
+	for i := range b.Payments {
+		b.Payments[i].Status = RejectedStatus
+		b.Payments[i].ReasonCode = code
+	}
+}
+
+func newBatch(model batchModel, payments []paymentModel) Batch {
+	batch := Batch{
+		ID:               model.ID,
+		MessageID:        model.MessageID,
+		SubmittedBy:      model.SubmittedBy,
+		InitiatingParty:  model.InitiatingParty,
+		TransactionCount: model.TransactionCount,
+		ControlSum:       model.ControlSum,
+		Status:           model.Status,
+		ReasonCode:       model.ReasonCode,
+		Payments:         make([]Payment, 0, len(payments)),
+		CreatedAt:        model.CreatedAt,
+	}
+	for _, payment := range payments {
+		batch.Payments = append(batch.Payments, newPayment(payment))
+	}
+	return batch
+}
+
+func newPayment(model paymentModel) Payment {
+	return Payment{
+		PaymentInformationID: model.PaymentInformationID,
+		InstructionID:        model.InstructionID,
+		EndToEndID:           model.EndToEndID,
+		AccountID:            model.AccountID.UUID,
+		Amount:               model.Amount,
+		Currency:             model.Currency,
+		Status:               model.Status,
+		ReasonCode:           model.ReasonCode,
+		Reason:               model.Reason,
+		TransferID:           model.TransferID,
+	}
+}
@@ -0,0 +1,74 @@
+package batches
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type batchModel struct {
+	bun.BaseModel `bun:"table:payment_batches,alias:batch"`
+
+	ID               uuid.UUID `bun:"id,pk"`
+	MessageID        string    `bun:"message_id"`
+	SubmittedBy      string    `bun:"submitted_by"`
+	InitiatingParty  string    `bun:"initiating_party"`
+	TransactionCount int       `bun:"transaction_count"`
+	ControlSum       float64   `bun:"control_sum"`
+	Status           Status    `bun:"status,nullzero"`
+	ReasonCode       string    `bun:"reason_code"`
+	CreatedAt        time.Time `bun:"created_at,notnull"`
+}
+
+func newBatchModel(batch Batch) batchModel {
+	return batchModel{
+		ID:               batch.ID,
+		MessageID:        batch.MessageID,
+		SubmittedBy:      batch.SubmittedBy,
+		InitiatingParty:  batch.InitiatingParty,
+		TransactionCount: batch.TransactionCount,
+		ControlSum:       batch.ControlSum,
+		Status:           batch.Status,
+		ReasonCode:       batch.ReasonCode,
+		CreatedAt:        batch.CreatedAt,
+	}
+}
+
+type paymentModel struct {
+	bun.BaseModel `bun:"table:payment_batch_items,alias:payment"`
+
+	ID                   uuid.UUID     `bun:"id,pk"`
+	BatchID              uuid.UUID     `bun:"batch_id"`
+	Position             int           `bun:"position"`
+	PaymentInformationID string        `bun:"payment_information_id"`
+	InstructionID        string        `bun:"instruction_id"`
+	EndToEndID           string        `bun:"end_to_end_id"`
+	AccountID            uuid.NullUUID `bun:"account_id"`
+	Amount               float64       `bun:"amount"`
+	Currency             string        `bun:"currency"`
+	Status               Status        `bun:"status,nullzero"`
+	ReasonCode           string        `bun:"reason_code"`
+	Reason               string        `bun:"reason"`
+	TransferID           uuid.NullUUID `bun:"transfer_id"`
+	CreatedAt            time.Time     `bun:"created_at,notnull"`
+}
+
+func newPaymentModel(batchID uuid.UUID, position int, payment Payment, createdAt time.Time) paymentModel {
+	return paymentModel{
+		ID:                   uuid.New(),
+		BatchID:              batchID,
+		Position:             position,
+		PaymentInformationID: payment.PaymentInformationID,
+		InstructionID:        payment.InstructionID,
+		EndToEndID:           payment.EndToEndID,
+		AccountID:            uuid.NullUUID{UUID: payment.AccountID, Valid: payment.AccountID != uuid.Nil},
+		Amount:               payment.Amount,
+		Currency:             payment.Currency,
+		Status:               payment.Status,
+		ReasonCode:           payment.ReasonCode,
+		Reason:               payment.Reason,
+		TransferID:           payment.TransferID,
+		CreatedAt:            createdAt,
+	}
+}
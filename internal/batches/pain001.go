@@ -0,0 +1,142 @@
+package batches
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// pain001NamespacePrefix matches every version of pain.001, the elements read are the same in all of them.
+const pain001NamespacePrefix = "urn:iso:std:iso:20022:tech:xsd:pain.001.001."
+
+// maxIDLength is the size of the Max35Text identifications of the message and its payments.
+const maxIDLength = 35
+
+var ErrInvalidPain001 = errors.New("the file is not a valid ISO 20022 pain.001 customer credit transfer initiation")
+
+type (
+	pain001Document struct {
+		XMLName    xml.Name          `xml:"Document"`
+		Initiation pain001Initiation `xml:"CstmrCdtTrfInitn"`
+	}
+
+	pain001Initiation struct {
+		GroupHeader        pain001GroupHeader          `xml:"GrpHdr"`
+		PaymentInformation []pain001PaymentInformation `xml:"PmtInf"`
+	}
+
+	pain001GroupHeader struct {
+		MessageID        string `xml:"MsgId"`
+		TransactionCount string `xml:"NbOfTxs"`
+		ControlSum       string `xml:"CtrlSum"`
+		InitiatingParty  string `xml:"InitgPty>Nm"`
+	}
+
+	pain001PaymentInformation struct {
+		ID            string               `xml:"PmtInfId"`
+		Debtor        pain001Party         `xml:"Dbtr"`
+		DebtorAccount string               `xml:"DbtrAcct>Id>Othr>Id"`
+		Transactions  []pain001Transaction `xml:"CdtTrfTxInf"`
+	}
+
+	pain001Party struct {
+		Name           string `xml:"Nm"`
+		PrivateID      string `xml:"Id>PrvtId>Othr>Id"`
+		OrganisationID string `xml:"Id>OrgId>Othr>Id"`
+	}
+
+	pain001Transaction struct {
+		InstructionID string        `xml:"PmtId>InstrId"`
+		EndToEndID    string        `xml:"PmtId>EndToEndId"`
+		Amount        pain001Amount `xml:"Amt>InstdAmt"`
+		BankCode      string        `xml:"CdtrAgt>FinInstnId>ClrSysMmbId>MmbId"`
+		Agency        string        `xml:"CdtrAgt>BrnchId>Id"`
+		Creditor      pain001Party  `xml:"Cdtr"`
+		AccountNumber string        `xml:"CdtrAcct>Id>Othr>Id"`
+		Description   string        `xml:"RmtInf>Ustrd"`
+	}
+
+	pain001Amount struct {
+		Currency string `xml:"Ccy,attr"`
+		Value    string `xml:",chardata"`
+	}
+)
+
+func (p pain001Party) document() string {
+	if p.PrivateID != "" {
+		return p.PrivateID
+	}
+	return p.OrganisationID
+}
+
+// parsePain001 reads the payments of a pain.001 message. The debtor account of each payment
+// information is the id of one of our accounts. Payments keep a zero amount or account id when
+// theirs can not be read, to be rejected one by one.
+func parsePain001(file io.Reader) (Batch, error) {
+	var document pain001Document
+	if err := xml.NewDecoder(file).Decode(&document); err != nil {
+		return Batch{}, ErrInvalidPain001
+	}
+	if !strings.HasPrefix(document.XMLName.Space, pain001NamespacePrefix) {
+		return Batch{}, ErrInvalidPain001
+	}
+
+	header := document.Initiation.GroupHeader
+	batch := Batch{
+		MessageID:       strings.TrimSpace(header.MessageID),
+		InitiatingParty: strings.TrimSpace(header.InitiatingParty),
+	}
+	if batch.MessageID == "" || len(batch.MessageID) > maxIDLength {
+		return Batch{}, ErrInvalidPain001
+	}
+
+	var err error
+	batch.TransactionCount, err = strconv.Atoi(strings.TrimSpace(header.TransactionCount))
+	if err != nil {
+		return Batch{}, ErrInvalidPain001
+	}
+	if sum := strings.TrimSpace(header.ControlSum); sum != "" {
+		batch.ControlSum, err = strconv.ParseFloat(sum, 64)
+		if err != nil {
+			return Batch{}, ErrInvalidPain001
+		}
+	}
+
+	for _, info := range document.Initiation.PaymentInformation {
+		accountID, _ := uuid.Parse(strings.TrimSpace(info.DebtorAccount))
+		for _, tx := range info.Transactions {
+			amount, _ := strconv.ParseFloat(strings.TrimSpace(tx.Amount.Value), 64)
+			batch.Payments = append(batch.Payments, Payment{
+				PaymentInformationID: strings.TrimSpace(info.ID),
+				InstructionID:        strings.TrimSpace(tx.InstructionID),
+				EndToEndID:           strings.TrimSpace(tx.EndToEndID),
+				AccountID:            accountID,
+				Amount:               amount,
+				Currency:             strings.TrimSpace(tx.Amount.Currency),
+				BankCode:             strings.TrimSpace(tx.BankCode),
+				Agency:               strings.TrimSpace(tx.Agency),
+				AccountNumber:        strings.TrimSpace(tx.AccountNumber),
+				CreditorName:         strings.TrimSpace(tx.Creditor.Name),
+				CreditorDocument:     strings.TrimSpace(tx.Creditor.document()),
+				Description:          strings.TrimSpace(tx.Description),
+			})
+		}
+	}
+
+	if len(batch.Payments) == 0 {
+		return Batch{}, ErrInvalidPain001
+	}
+	for _, payment := range batch.Payments {
+		if len(payment.PaymentInformationID) > maxIDLength ||
+			len(payment.InstructionID) > maxIDLength ||
+			len(payment.EndToEndID) > maxIDLength {
+			return Batch{}, ErrInvalidPain001
+		}
+	}
+
+	return batch, nil
+}
@@ -0,0 +1,93 @@
+//go:build unit
+
+package batches
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePain001(t *testing.T) {
+	t.Run("customer credit transfer initiation", func(t *testing.T) {
+		file, err := os.Open("testdata/pain001.xml")
+		assert.NoError(t, err)
+		defer file.Close()
+
+		batch, err := parsePain001(file)
+		assert.NoError(t, err)
+		assert.Equal(t, "PAYROLL-2025-03-14", batch.MessageID)
+		assert.Equal(t, "Padaria Pao Quente Ltda", batch.InitiatingParty)
+		assert.Equal(t, 3, batch.TransactionCount)
+		assert.Equal(t, 1534.56, batch.ControlSum)
+		assert.Equal(t, 1534.56, batch.sum())
+
+		assert.Equal(t, []Payment{
+			{
+				PaymentInformationID: "PAYROLL-MARCH",
+				InstructionID:        "INSTR-1",
+				EndToEndID:           "E2E-1",
+				AccountID:            uuid.MustParse("6f1c2a9e-3b4d-4e5f-8a7b-9c0d1e2f3a4b"),
+				Amount:               150,
+				Currency:             "BRL",
+				BankCode:             "237",
+				Agency:               "1234",
+				AccountNumber:        "12345-6",
+				CreditorName:         "João da Silva",
+				CreditorDocument:     "52998224725",
+				Description:          "salary march",
+			},
+			{
+				PaymentInformationID: "PAYROLL-MARCH",
+				EndToEndID:           "E2E-2",
+				AccountID:            uuid.MustParse("6f1c2a9e-3b4d-4e5f-8a7b-9c0d1e2f3a4b"),
+				Amount:               1234.56,
+				Currency:             "BRL",
+				BankCode:             "341",
+				Agency:               "567",
+				AccountNumber:        "987654321-X",
+				CreditorName:         "Maria Souza",
+				CreditorDocument:     "39053344705",
+			},
+			{
+				PaymentInformationID: "SUPPLIERS",
+				EndToEndID:           "E2E-3",
+				Amount:               150,
+				Currency:             "USD",
+				BankCode:             "001",
+				Agency:               "1",
+				AccountNumber:        "4567-8",
+				CreditorName:         "Moinho Ltda",
+				CreditorDocument:     "11444777000161",
+			},
+		}, batch.Payments)
+	})
+
+	for name, file := range map[string]string{
+		"not xml":         "PAYROLL;150.00",
+		"other message":   `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.008.001.08"><CstmrDrctDbtInitn/></Document>`,
+		"no message id":   pain001(`<GrpHdr><NbOfTxs>1</NbOfTxs></GrpHdr>` + transaction),
+		"no transactions": pain001(`<GrpHdr><MsgId>1</MsgId><NbOfTxs>0</NbOfTxs></GrpHdr>`),
+		"invalid count":   pain001(`<GrpHdr><MsgId>1</MsgId><NbOfTxs>one</NbOfTxs></GrpHdr>` + transaction),
+		"invalid sum":     pain001(`<GrpHdr><MsgId>1</MsgId><NbOfTxs>1</NbOfTxs><CtrlSum>1,00</CtrlSum></GrpHdr>` + transaction),
+		"long message id": pain001(`<GrpHdr><MsgId>` + strings.Repeat("1", 36) + `</MsgId><NbOfTxs>1</NbOfTxs></GrpHdr>` + transaction),
+	} {
+		file := file
+		t.Run(name, func(t *testing.T) {
+			_, err := parsePain001(strings.NewReader(file))
+			assert.ErrorIs(t, err, ErrInvalidPain001)
+		})
+	}
+}
+
+const transaction = `<PmtInf><PmtInfId>1</PmtInfId><CdtTrfTxInf><PmtId><EndToEndId>1</EndToEndId></PmtId>` +
+	`<Amt><InstdAmt Ccy="BRL">1.00</InstdAmt></Amt></CdtTrfTxInf></PmtInf>`
+
+func pain001(initiation string) string {
+	return `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.09"><CstmrCdtTrfInitn>` +
+		initiation +
+		`</CstmrCdtTrfInitn></Document>`
+}
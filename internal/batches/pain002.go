@@ -0,0 +1,146 @@
+package batches
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	pain002Namespace   = "urn:iso:std:iso:20022:tech:xsd:pain.002.001.10"
+	pain001MessageName = "pain.001.001.09"
+
+	isoDateTimeLayout = "2006-01-02T15:04:05Z"
+)
+
+type (
+	pain002Document struct {
+		XMLName xml.Name      `xml:"Document"`
+		Xmlns   string        `xml:"xmlns,attr"`
+		Report  pain002Report `xml:"CstmrPmtStsRpt"`
+	}
+
+	pain002Report struct {
+		GroupHeader        pain002GroupHeader          `xml:"GrpHdr"`
+		OriginalGroup      pain002OriginalGroup        `xml:"OrgnlGrpInfAndSts"`
+		PaymentInformation []pain002PaymentInformation `xml:"OrgnlPmtInfAndSts"`
+	}
+
+	pain002GroupHeader struct {
+		MessageID string `xml:"MsgId"`
+		CreatedAt string `xml:"CreDtTm"`
+	}
+
+	pain002OriginalGroup struct {
+		MessageID        string         `xml:"OrgnlMsgId"`
+		MessageName      string         `xml:"OrgnlMsgNmId"`
+		TransactionCount int            `xml:"OrgnlNbOfTxs"`
+		ControlSum       string         `xml:"OrgnlCtrlSum"`
+		Status           Status         `xml:"GrpSts"`
+		Reason           *pain002Reason `xml:"StsRsnInf,omitempty"`
+	}
+
+	pain002PaymentInformation struct {
+		ID           string               `xml:"OrgnlPmtInfId"`
+		Status       Status               `xml:"PmtInfSts"`
+		Transactions []pain002Transaction `xml:"TxInfAndSts"`
+	}
+
+	pain002Transaction struct {
+		InstructionID string         `xml:"OrgnlInstrId,omitempty"`
+		EndToEndID    string         `xml:"OrgnlEndToEndId"`
+		Status        Status         `xml:"TxSts"`
+		Reason        *pain002Reason `xml:"StsRsnInf,omitempty"`
+		// ServicerReference is the id of the transfer created for the payment.
+		ServicerReference string `xml:"AcctSvcrRef,omitempty"`
+	}
+
+	pain002Reason struct {
+		Code       string `xml:"Rsn>Cd"`
+		Additional string `xml:"AddtlInf,omitempty"`
+	}
+)
+
+// encodePain002 writes the customer payment status report of the batch, with the status of each
+// payment grouped by its original payment information.
+func encodePain002(batch Batch, createdAt time.Time) ([]byte, error) {
+	group := pain002OriginalGroup{
+		MessageID:        batch.MessageID,
+		MessageName:      pain001MessageName,
+		TransactionCount: batch.TransactionCount,
+		ControlSum:       fmt.Sprintf("%.2f", batch.ControlSum),
+		Status:           batch.Status,
+	}
+	if batch.ReasonCode != "" {
+		group.Reason = &pain002Reason{Code: batch.ReasonCode}
+	}
+
+	var infos []pain002PaymentInformation
+	index := make(map[string]int)
+	for _, payment := range batch.Payments {
+		i, ok := index[payment.PaymentInformationID]
+		if !ok {
+			i = len(infos)
+			index[payment.PaymentInformationID] = i
+			infos = append(infos, pain002PaymentInformation{ID: payment.PaymentInformationID})
+		}
+
+		tx := pain002Transaction{
+			InstructionID: payment.InstructionID,
+			EndToEndID:    payment.EndToEndID,
+			Status:        payment.Status,
+		}
+		if payment.ReasonCode != "" {
+			tx.Reason = &pain002Reason{Code: payment.ReasonCode, Additional: payment.Reason}
+		}
+		if payment.TransferID.Valid {
+			tx.ServicerReference = payment.TransferID.UUID.String()
+		}
+		infos[i].Transactions = append(infos[i].Transactions, tx)
+	}
+
+	for i := range infos {
+		statuses := make([]Status, 0, len(infos[i].Transactions))
+		for _, tx := range infos[i].Transactions {
+			statuses = append(statuses, tx.Status)
+		}
+		infos[i].Status = groupStatus(statuses)
+	}
+
+	body, err := xml.MarshalIndent(pain002Document{
+		Xmlns: pain002Namespace,
+		Report: pain002Report{
+			GroupHeader: pain002GroupHeader{
+				MessageID: strings.ToUpper(strings.ReplaceAll(batch.ID.String(), "-", "")),
+				CreatedAt: createdAt.UTC().Format(isoDateTimeLayout),
+			},
+			OriginalGroup:      group,
+			PaymentInformation: infos,
+		},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
+
+// groupStatus is ACSP when every payment was accepted, RJCT when none was and PART otherwise.
+func groupStatus(statuses []Status) Status {
+	var accepted int
+	for _, status := range statuses {
+		if status == AcceptedStatus {
+			accepted++
+		}
+	}
+
+	switch accepted {
+	case len(statuses):
+		return AcceptedStatus
+	case 0:
+		return RejectedStatus
+	default:
+		return PartiallyAcceptedStatus
+	}
+}
@@ -0,0 +1,99 @@
+//go:build unit
+
+package batches
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodePain002(t *testing.T) {
+	transferID := uuid.New()
+	batch := Batch{
+		ID:               uuid.MustParse("0a1b2c3d-4e5f-6071-8293-a4b5c6d7e8f9"),
+		MessageID:        "PAYROLL-2025-03-14",
+		TransactionCount: 3,
+		ControlSum:       1534.56,
+		Status:           PartiallyAcceptedStatus,
+		Payments: []Payment{
+			{
+				PaymentInformationID: "PAYROLL-MARCH",
+				InstructionID:        "INSTR-1",
+				EndToEndID:           "E2E-1",
+				Status:               AcceptedStatus,
+				TransferID:           uuid.NullUUID{UUID: transferID, Valid: true},
+			},
+			{
+				PaymentInformationID: "SUPPLIERS",
+				EndToEndID:           "E2E-3",
+				Status:               RejectedStatus,
+				ReasonCode:           invalidCurrencyReason,
+				Reason:               "the transaction currency must be the account currency",
+			},
+			{
+				PaymentInformationID: "PAYROLL-MARCH",
+				EndToEndID:           "E2E-2",
+				Status:               RejectedStatus,
+				ReasonCode:           insufficientFundsReason,
+			},
+		},
+	}
+
+	body, err := encodePain002(batch, time.Date(2025, time.March, 14, 9, 31, 0, 0, time.UTC))
+	assert.NoError(t, err)
+
+	var document pain002Document
+	assert.NoError(t, xml.Unmarshal(body, &document))
+	assert.Equal(t, pain002Namespace, document.Xmlns)
+
+	report := document.Report
+	assert.Equal(t, pain002GroupHeader{
+		MessageID: "0A1B2C3D4E5F60718293A4B5C6D7E8F9",
+		CreatedAt: "2025-03-14T09:31:00Z",
+	}, report.GroupHeader)
+	assert.Equal(t, pain002OriginalGroup{
+		MessageID:        "PAYROLL-2025-03-14",
+		MessageName:      pain001MessageName,
+		TransactionCount: 3,
+		ControlSum:       "1534.56",
+		Status:           PartiallyAcceptedStatus,
+	}, report.OriginalGroup)
+
+	assert.Equal(t, []pain002PaymentInformation{
+		{
+			ID:     "PAYROLL-MARCH",
+			Status: PartiallyAcceptedStatus,
+			Transactions: []pain002Transaction{
+				{
+					InstructionID:     "INSTR-1",
+					EndToEndID:        "E2E-1",
+					Status:            AcceptedStatus,
+					ServicerReference: transferID.String(),
+				},
+				{
+					EndToEndID: "E2E-2",
+					Status:     RejectedStatus,
+					Reason:     &pain002Reason{Code: insufficientFundsReason},
+				},
+			},
+		},
+		{
+			ID:     "SUPPLIERS",
+			Status: RejectedStatus,
+			Transactions: []pain002Transaction{
+				{
+					EndToEndID: "E2E-3",
+					Status:     RejectedStatus,
+					Reason: &pain002Reason{
+						Code:       invalidCurrencyReason,
+						Additional: "the transaction currency must be the account currency",
+					},
+				},
+			},
+		},
+	}, report.PaymentInformation)
+}
@@ -0,0 +1,160 @@
+package batches
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+const paymentBatchesMessageIDConstraint = "payment_batches_submitted_by_message_id"
+
+var errBatchAlreadyExists = errors.New("a batch was already created with this message id")
+
+type Repository interface {
+	Create(ctx context.Context, model batchModel, payments []paymentModel) (batchModel, error)
+	RecordPayment(ctx context.Context, payment paymentModel) error
+	Complete(ctx context.Context, id uuid.UUID, status Status) error
+	GetByMessageID(ctx context.Context, submittedBy, messageID string) (batchModel, error)
+	ListPayments(ctx context.Context, batchID uuid.UUID) ([]paymentModel, error)
+}
+
+type repository struct {
+	tracer tracer.Tracer
+	db     database.Database
+}
+
+func NewRepository(t tracer.Tracer, db database.Database) Repository {
+	return repository{
+		tracer: t,
+		db:     db,
+	}
+}
+
+// Create records the batch together with its payments before their transfers are created, failing
+// with errBatchAlreadyExists when its submitter already submitted the message.
+func (r repository) Create(ctx context.Context, model batchModel, payments []paymentModel) (batchModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	if model.CreatedAt.IsZero() {
+		model.CreatedAt = time.Now().UTC()
+	}
+
+	err := r.db.Master().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewInsert().
+			Model(&model).
+			Returning("*").
+			Exec(ctx)
+		if err != nil || len(payments) == 0 {
+			return err
+		}
+
+		_, err = tx.NewInsert().
+			Model(&payments).
+			Exec(ctx)
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		if isConstraintViolation(err, paymentBatchesMessageIDConstraint) {
+			return batchModel{}, errBatchAlreadyExists
+		}
+		return batchModel{}, err
+	}
+
+	return model, nil
+}
+
+// RecordPayment records the status of the payment once its transfer is created or rejected.
+func (r repository) RecordPayment(ctx context.Context, payment paymentModel) error {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	_, err := r.db.Master().
+		NewUpdate().
+		Model((*paymentModel)(nil)).
+		Set("status = ?", payment.Status).
+		Set("reason_code = ?", payment.ReasonCode).
+		Set("reason = ?", payment.Reason).
+		Set("transfer_id = ?", payment.TransferID).
+		Where("id = ?", payment.ID).
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+// Complete records the status of the batch once every payment has its status.
+func (r repository) Complete(ctx context.Context, id uuid.UUID, status Status) error {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	_, err := r.db.Master().
+		NewUpdate().
+		Model((*batchModel)(nil)).
+		Set("status = ?", status).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r repository) GetByMessageID(ctx context.Context, submittedBy, messageID string) (batchModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var model batchModel
+	err := r.db.Master().
+		NewSelect().
+		Model(&model).
+		Where("submitted_by = ?", submittedBy).
+		Where("message_id = ?", messageID).
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return batchModel{}, err
+	}
+
+	return model, nil
+}
+
+func (r repository) ListPayments(ctx context.Context, batchID uuid.UUID) ([]paymentModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var models []paymentModel
+	err := r.db.Master().
+		NewSelect().
+		Model(&models).
+		Where("batch_id = ?", batchID).
+		Order("position").
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func isConstraintViolation(err error, constraint string) bool {
+	var pgErr pgdriver.Error
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.IntegrityViolation() && pgErr.Field('n') == constraint
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/batches/repository.go
+
+// Package batches is a generated GoMock package.
+package batches
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *MockRepository) Complete(ctx context.Context, id uuid.UUID, status Status) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockRepositoryMockRecorder) Complete(ctx, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockRepository)(nil).Complete), ctx, id, status)
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, model batchModel, payments []paymentModel) (batchModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, model, payments)
+	ret0, _ := ret[0].(batchModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, model, payments interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, model, payments)
+}
+
+// GetByMessageID mocks base method.
+func (m *MockRepository) GetByMessageID(ctx context.Context, submittedBy, messageID string) (batchModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByMessageID", ctx, submittedBy, messageID)
+	ret0, _ := ret[0].(batchModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByMessageID indicates an expected call of GetByMessageID.
+func (mr *MockRepositoryMockRecorder) GetByMessageID(ctx, submittedBy, messageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByMessageID", reflect.TypeOf((*MockRepository)(nil).GetByMessageID), ctx, submittedBy, messageID)
+}
+
+// ListPayments mocks base method.
+func (m *MockRepository) ListPayments(ctx context.Context, batchID uuid.UUID) ([]paymentModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPayments", ctx, batchID)
+	ret0, _ := ret[0].([]paymentModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPayments indicates an expected call of ListPayments.
+func (mr *MockRepositoryMockRecorder) ListPayments(ctx, batchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayments", reflect.TypeOf((*MockRepository)(nil).ListPayments), ctx, batchID)
+}
+
+// RecordPayment mocks base method.
+func (m *MockRepository) RecordPayment(ctx context.Context, payment paymentModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordPayment", ctx, payment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordPayment indicates an expected call of RecordPayment.
+func (mr *MockRepositoryMockRecorder) RecordPayment(ctx, payment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordPayment", reflect.TypeOf((*MockRepository)(nil).RecordPayment), ctx, payment)
+}
@@ -0,0 +1,113 @@
+//go:build integration
+
+package batches
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/testingcontainers"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+
+	url, closeFunc, err := testingcontainers.NewPostgresContainer()
+	assert.NoError(t, err)
+	defer closeFunc(ctx) //nolint:errcheck
+
+	_, callerPath, _, _ := runtime.Caller(0) //nolint:dogsled
+	err = testingcontainers.RunMigrateDatabase(
+		url,
+		fmt.Sprintf("file://%s/../../migrations/", filepath.Dir(callerPath)),
+	)
+	assert.NoError(t, err)
+
+	db, err := database.New(tracer.NewNoop(), url, url)
+	assert.NoError(t, err)
+
+	repo := NewRepository(tracer.NewNoop(), db)
+
+	t.Run("batch created once by each submitter and completed", func(t *testing.T) {
+		id := uuid.New()
+		model, err := repo.Create(
+			ctx,
+			batchModel{
+				ID:               id,
+				MessageID:        "PAYROLL-2025-03-14",
+				SubmittedBy:      "principal-id",
+				TransactionCount: 2,
+				ControlSum:       300,
+			},
+			[]paymentModel{
+				newPaymentModel(id, 1, Payment{EndToEndID: "E2E-2", Amount: 150}, time.Now().UTC()),
+				newPaymentModel(id, 0, Payment{EndToEndID: "E2E-1", Amount: 150}, time.Now().UTC()),
+			},
+		)
+		assert.NoError(t, err)
+
+		_, err = repo.Create(ctx, batchModel{
+			ID:               uuid.New(),
+			MessageID:        "PAYROLL-2025-03-14",
+			SubmittedBy:      "principal-id",
+			TransactionCount: 2,
+			ControlSum:       300,
+		}, nil)
+		assert.ErrorIs(t, err, errBatchAlreadyExists)
+
+		other, err := repo.Create(ctx, batchModel{
+			ID:               uuid.New(),
+			MessageID:        "PAYROLL-2025-03-14",
+			SubmittedBy:      "other-principal-id",
+			TransactionCount: 2,
+			ControlSum:       300,
+		}, nil)
+		assert.NoError(t, err)
+
+		found, err := repo.GetByMessageID(ctx, "principal-id", model.MessageID)
+		assert.NoError(t, err)
+		assert.Equal(t, model.ID, found.ID)
+		assert.Empty(t, found.Status)
+
+		found, err = repo.GetByMessageID(ctx, "other-principal-id", model.MessageID)
+		assert.NoError(t, err)
+		assert.Equal(t, other.ID, found.ID)
+
+		payments, err := repo.ListPayments(ctx, model.ID)
+		assert.NoError(t, err)
+		assert.Len(t, payments, 2)
+		assert.Empty(t, payments[0].Status)
+
+		rejected := payments[1]
+		rejected.Status = RejectedStatus
+		rejected.ReasonCode = "AM04"
+		assert.NoError(t, repo.RecordPayment(ctx, rejected))
+
+		err = repo.Complete(ctx, model.ID, RejectedStatus)
+		assert.NoError(t, err)
+
+		found, err = repo.GetByMessageID(ctx, "principal-id", model.MessageID)
+		assert.NoError(t, err)
+		assert.Equal(t, RejectedStatus, found.Status)
+
+		payments, err = repo.ListPayments(ctx, model.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "E2E-1", payments[0].EndToEndID)
+		assert.Equal(t, RejectedStatus, payments[1].Status)
+		assert.Equal(t, "AM04", payments[1].ReasonCode)
+	})
+
+	t.Run("batch not found", func(t *testing.T) {
+		_, err := repo.GetByMessageID(ctx, "principal-id", "UNKNOWN")
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
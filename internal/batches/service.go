@@ -0,0 +1,284 @@
+package batches
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/transactions"
+	"github.com/dalmarcogd/dock-test/internal/transfers"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// currency is the only currency of the TEDs created for the payments.
+	currency = "BRL"
+	// maxDescriptionLength is the size of transfer descriptions, smaller than the unstructured
+	// remittance information of a payment.
+	maxDescriptionLength = 100
+)
+
+// ISO 20022 external status reason codes reported in pain.002.
+const (
+	wrongTransactionCountReason  = "AM18"
+	wrongControlSumReason        = "AM10"
+	invalidAmountReason          = "AM12"
+	invalidCurrencyReason        = "AM03"
+	insufficientFundsReason      = "AM04"
+	dailyLimitExceededReason     = "AM02"
+	invalidDebtorAccountReason   = "AC02"
+	invalidCreditorAccountReason = "AC01"
+	blockedAccountReason         = "AC06"
+	transactionForbiddenReason   = "AG01"
+	fraudReason                  = "FRAD"
+	narrativeReason              = "NARR"
+)
+
+var (
+	ErrBatchAlreadySubmitted = errors.New("a batch with this message id is still being processed")
+	errUnknownDebtorAccount  = errors.New("the debtor account must be the id of an account")
+	errPaymentUnavailable    = errors.New("the transfer of the payment could not be created")
+)
+
+// reasons maps the errors of creating a transfer to the status reason codes of the payment.
+var reasons = []struct {
+	err  error
+	code string
+}{
+	{transfers.ErrInvalidTransferAmount, invalidAmountReason},
+	{transfers.ErrInvalidBeneficiary, invalidCreditorAccountReason},
+	{transactions.ErrBalanceInsufficientFunds, insufficientFundsReason},
+	{transactions.ErrInsufficientDailyLimit, dailyLimitExceededReason},
+	{transactions.ErrAccountNotfound, invalidDebtorAccountReason},
+	{accounts.ErrAccountNotFound, invalidDebtorAccountReason},
+	{transactions.ErrAccountInactive, blockedAccountReason},
+	{transactions.ErrAccountDebitsBlocked, blockedAccountReason},
+	{transactions.ErrRequesterRequired, transactionForbiddenReason},
+	{transactions.ErrHolderNotAllowedToDebit, transactionForbiddenReason},
+	{transactions.ErrPocketExternalTransaction, transactionForbiddenReason},
+	{transactions.ErrApprovalRequired, transactionForbiddenReason},
+	{transactions.ErrCurrencyMismatch, invalidCurrencyReason},
+	{transactions.ErrTransactionDenied, fraudReason},
+	{transactions.ErrTransactionUnderReview, fraudReason},
+}
+
+type Service interface {
+	Submit(ctx context.Context, submittedBy string, file io.Reader) ([]byte, error)
+}
+
+type service struct {
+	tracer       tracer.Tracer
+	repository   Repository
+	transfersSvc transfers.Service
+}
+
+func NewService(t tracer.Tracer, r Repository, ts transfers.Service) Service {
+	return service{
+		tracer:       t,
+		repository:   r,
+		transfersSvc: ts,
+	}
+}
+
+// Submit creates a TED transfer for each payment of the pain.001 message and returns the pain.002
+// report with the status of each one. Payments are independent, one rejected does not reject the
+// others, but the whole message is rejected when its number of transactions or control sum do not
+// match its payments. A message is processed once for each principal, submitting it again returns the
+// same report. The transfers are requested by the principal submitting the message, whatever debtor
+// it names.
+func (s service) Submit(ctx context.Context, submittedBy string, file io.Reader) ([]byte, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	batch, err := parsePain001(file)
+	if err != nil {
+		zapctx.L(ctx).Error("batch_service_parse_pain001_error", zap.Error(err))
+		span.RecordError(err)
+		return nil, err
+	}
+	batch.ID = uuid.New()
+	batch.SubmittedBy = submittedBy
+	batch.CreatedAt = time.Now().UTC()
+	for i := range batch.Payments {
+		batch.Payments[i].RequestedBy = submittedBy
+	}
+
+	switch {
+	case batch.TransactionCount != len(batch.Payments):
+		batch.reject(wrongTransactionCountReason)
+	case batch.ControlSum != 0 && math.Abs(batch.ControlSum-batch.sum()) >= 0.005:
+		batch.reject(wrongControlSumReason)
+	}
+
+	payments := make([]paymentModel, 0, len(batch.Payments))
+	for i, payment := range batch.Payments {
+		payments = append(payments, newPaymentModel(batch.ID, i, payment, batch.CreatedAt))
+	}
+
+	// a rejected batch is recorded complete, otherwise its payments are recorded without status and
+	// completed one by one, so a batch interrupted after creating them is recovered by resubmitting it.
+	_, err = s.repository.Create(ctx, newBatchModel(batch), payments)
+	if errors.Is(err, errBatchAlreadyExists) {
+		return s.resubmitted(ctx, submittedBy, batch.MessageID)
+	} else if err != nil {
+		zapctx.L(ctx).Error("batch_service_create_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if batch.Status == "" {
+		statuses := make([]Status, 0, len(batch.Payments))
+		for i := range batch.Payments {
+			batch.Payments[i] = s.pay(ctx, batch.Payments[i])
+			statuses = append(statuses, batch.Payments[i].Status)
+
+			record := newPaymentModel(batch.ID, i, batch.Payments[i], batch.CreatedAt)
+			record.ID = payments[i].ID
+			s.recordPayment(ctx, record)
+		}
+		batch.Status = groupStatus(statuses)
+
+		// the transfers are already created, so the report is still returned when recording it fails.
+		if err := s.repository.Complete(ctx, batch.ID, batch.Status); err != nil {
+			zapctx.L(ctx).Error(
+				"batch_service_complete_repository_error",
+				zap.String("batch_id", batch.ID.String()),
+				zap.Error(err),
+			)
+			span.RecordError(err)
+		}
+	}
+
+	zapctx.L(ctx).Info(
+		"batch_submitted",
+		zap.String("batch_id", batch.ID.String()),
+		zap.String("message_id", batch.MessageID),
+		zap.String("status", string(batch.Status)),
+		zap.Int("payments", len(batch.Payments)),
+	)
+
+	return encodePain002(batch, batch.CreatedAt)
+}
+
+// recordPayment records the status of the payment, whose transfer is already created, so a failure
+// is only logged and leaves the batch in progress.
+func (s service) recordPayment(ctx context.Context, payment paymentModel) {
+	if err := s.repository.RecordPayment(ctx, payment); err != nil {
+		zapctx.L(ctx).Error(
+			"batch_service_record_payment_repository_error",
+			zap.String("batch_id", payment.BatchID.String()),
+			zap.Int("position", payment.Position),
+			zap.Error(err),
+		)
+	}
+}
+
+// resubmitted returns the report of a message the principal already submitted. A batch whose payments
+// all have their status, but which was not completed, is completed with them.
+func (s service) resubmitted(ctx context.Context, submittedBy, messageID string) ([]byte, error) {
+	model, err := s.repository.GetByMessageID(ctx, submittedBy, messageID)
+	if err != nil {
+		zapctx.L(ctx).Error("batch_service_get_by_message_id_repository_error", zap.Error(err))
+		return nil, err
+	}
+
+	payments, err := s.repository.ListPayments(ctx, model.ID)
+	if err != nil {
+		zapctx.L(ctx).Error("batch_service_list_payments_repository_error", zap.Error(err))
+		return nil, err
+	}
+
+	if model.Status == "" {
+		statuses := make([]Status, 0, len(payments))
+		for _, payment := range payments {
+			if payment.Status == "" {
+				zapctx.L(ctx).Warn("batch_service_batch_in_progress", zap.String("batch_id", model.ID.String()))
+				return nil, ErrBatchAlreadySubmitted
+			}
+			statuses = append(statuses, payment.Status)
+		}
+
+		model.Status = groupStatus(statuses)
+		if err := s.repository.Complete(ctx, model.ID, model.Status); err != nil {
+			zapctx.L(ctx).Error("batch_service_complete_repository_error", zap.Error(err))
+			return nil, err
+		}
+
+		zapctx.L(ctx).Info("batch_recovered", zap.String("batch_id", model.ID.String()))
+	}
+
+	zapctx.L(ctx).Info("batch_resubmitted", zap.String("batch_id", model.ID.String()))
+
+	return encodePain002(newBatch(model, payments), time.Now().UTC())
+}
+
+// pay creates the transfer of the payment, rejecting it with the reason the transfer failed.
+func (s service) pay(ctx context.Context, payment Payment) Payment {
+	if payment.Currency != currency {
+		return rejected(payment, invalidCurrencyReason, transactions.ErrCurrencyMismatch)
+	}
+	if payment.AccountID == uuid.Nil {
+		return rejected(payment, invalidDebtorAccountReason, errUnknownDebtorAccount)
+	}
+
+	created, err := s.transfersSvc.Create(ctx, transfers.Transfer{
+		AccountID: payment.AccountID,
+		Amount:    payment.Amount,
+		Beneficiary: transfers.Beneficiary{
+			BankCode:       payment.BankCode,
+			Agency:         payment.Agency,
+			AccountNumber:  payment.AccountNumber,
+			DocumentNumber: payment.CreditorDocument,
+			Name:           payment.CreditorName,
+		},
+		Description: truncate(payment.Description, maxDescriptionLength),
+		RequestedBy: payment.RequestedBy,
+	})
+	if err != nil {
+		zapctx.L(ctx).Warn(
+			"batch_service_payment_rejected",
+			zap.String("end_to_end_id", payment.EndToEndID),
+			zap.Error(err),
+		)
+		code := reasonCode(err)
+		if code == narrativeReason {
+			err = errPaymentUnavailable
+		}
+		return rejected(payment, code, err)
+	}
+
+	payment.Status = AcceptedStatus
+	payment.TransferID = uuid.NullUUID{UUID: created.ID, Valid: true}
+
+	return payment
+}
+
+func rejected(payment Payment, code string, err error) Payment {
+	payment.Status = RejectedStatus
+	payment.ReasonCode = code
+	payment.Reason = err.Error()
+	return payment
+}
+
+func reasonCode(err error) string {
+	for _, reason := range reasons {
+		if errors.Is(err, reason.err) {
+			return reason.code
+		}
+	}
+	return narrativeReason
+}
+
+func truncate(text string, size int) string {
+	runes := []rune(text)
+	if len(runes) <= size {
+		return text
+	}
+	return string(runes[:size])
+}
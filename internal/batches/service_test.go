@@ -0,0 +1,223 @@
+//go:build unit
+
+package batches
+
+import (
+	"context"
+	"encoding/xml"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/dalmarcogd/dock-test/internal/transactions"
+	"github.com/dalmarcogd/dock-test/internal/transfers"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_Submit(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	transfersMock := transfers.NewMockService(ctrl)
+	svc := NewService(tracer.NewNoop(), repoMock, transfersMock)
+
+	fixture, err := os.ReadFile("testdata/pain001.xml")
+	assert.NoError(t, err)
+
+	report := func(t *testing.T, body []byte) pain002Report {
+		var document pain002Document
+		assert.NoError(t, xml.Unmarshal(body, &document))
+		return document.Report
+	}
+
+	t.Run("fail submit, invalid file", func(t *testing.T) {
+		body, err := svc.Submit(ctx, "principal-id", strings.NewReader("PAYROLL;150.00"))
+		assert.ErrorIs(t, err, ErrInvalidPain001)
+		assert.Nil(t, body)
+	})
+
+	t.Run("success submit, payments accepted and rejected", func(t *testing.T) {
+		var batchID uuid.UUID
+		transferID := uuid.New()
+
+		var recorded []paymentModel
+
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, m batchModel, payments []paymentModel) (batchModel, error) {
+				assert.Equal(t, "PAYROLL-2025-03-14", m.MessageID)
+				assert.Equal(t, "principal-id", m.SubmittedBy)
+				assert.Empty(t, m.Status)
+				assert.Len(t, payments, 3)
+				for i, payment := range payments {
+					assert.Equal(t, i, payment.Position)
+					assert.Empty(t, payment.Status)
+				}
+				assert.False(t, payments[2].AccountID.Valid)
+				batchID = m.ID
+				return m, nil
+			})
+		repoMock.EXPECT().
+			RecordPayment(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, payment paymentModel) error {
+				assert.Equal(t, batchID, payment.BatchID)
+				recorded = append(recorded, payment)
+				return nil
+			}).
+			Times(3)
+		gomock.InOrder(
+			transfersMock.EXPECT().
+				Create(ctx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, transfer transfers.Transfer) (transfers.Transfer, error) {
+					assert.Equal(t, uuid.MustParse("6f1c2a9e-3b4d-4e5f-8a7b-9c0d1e2f3a4b"), transfer.AccountID)
+					assert.Equal(t, 150.0, transfer.Amount)
+					assert.Equal(t, "principal-id", transfer.RequestedBy)
+					assert.Equal(t, "salary march", transfer.Description)
+					assert.Equal(t, transfers.Beneficiary{
+						BankCode:       "237",
+						Agency:         "1234",
+						AccountNumber:  "12345-6",
+						DocumentNumber: "52998224725",
+						Name:           "João da Silva",
+					}, transfer.Beneficiary)
+					transfer.ID = transferID
+					return transfer, nil
+				}),
+			transfersMock.EXPECT().
+				Create(ctx, gomock.Any()).
+				Return(transfers.Transfer{}, transactions.ErrBalanceInsufficientFunds),
+		)
+		repoMock.EXPECT().
+			Complete(ctx, gomock.Any(), PartiallyAcceptedStatus).
+			DoAndReturn(func(_ context.Context, id uuid.UUID, _ Status) error {
+				assert.Equal(t, batchID, id)
+				return nil
+			})
+
+		body, err := svc.Submit(ctx, "principal-id", strings.NewReader(string(fixture)))
+		assert.NoError(t, err)
+
+		assert.Len(t, recorded, 3)
+		assert.Equal(t, AcceptedStatus, recorded[0].Status)
+		assert.Equal(t, uuid.NullUUID{UUID: transferID, Valid: true}, recorded[0].TransferID)
+		assert.Equal(t, insufficientFundsReason, recorded[1].ReasonCode)
+
+		r := report(t, body)
+		assert.Equal(t, PartiallyAcceptedStatus, r.OriginalGroup.Status)
+		assert.Len(t, r.PaymentInformation, 2)
+
+		payroll := r.PaymentInformation[0]
+		assert.Equal(t, PartiallyAcceptedStatus, payroll.Status)
+		assert.Equal(t, AcceptedStatus, payroll.Transactions[0].Status)
+		assert.Equal(t, transferID.String(), payroll.Transactions[0].ServicerReference)
+		assert.Equal(t, RejectedStatus, payroll.Transactions[1].Status)
+		assert.Equal(t, insufficientFundsReason, payroll.Transactions[1].Reason.Code)
+
+		suppliers := r.PaymentInformation[1]
+		assert.Equal(t, RejectedStatus, suppliers.Status)
+		assert.Equal(t, invalidCurrencyReason, suppliers.Transactions[0].Reason.Code)
+	})
+
+	t.Run("success submit, control sum does not match", func(t *testing.T) {
+		file := strings.Replace(string(fixture), "<CtrlSum>1534.56</CtrlSum>", "<CtrlSum>1534.00</CtrlSum>", 1)
+
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, m batchModel, payments []paymentModel) (batchModel, error) {
+				assert.Equal(t, RejectedStatus, m.Status)
+				assert.Equal(t, wrongControlSumReason, m.ReasonCode)
+				for _, payment := range payments {
+					assert.Equal(t, RejectedStatus, payment.Status)
+				}
+				return m, nil
+			})
+
+		body, err := svc.Submit(ctx, "principal-id", strings.NewReader(file))
+		assert.NoError(t, err)
+
+		r := report(t, body)
+		assert.Equal(t, RejectedStatus, r.OriginalGroup.Status)
+		assert.Equal(t, wrongControlSumReason, r.OriginalGroup.Reason.Code)
+		for _, info := range r.PaymentInformation {
+			assert.Equal(t, RejectedStatus, info.Status)
+		}
+	})
+
+	t.Run("success submit, message already processed", func(t *testing.T) {
+		model := batchModel{
+			ID:               uuid.New(),
+			MessageID:        "PAYROLL-2025-03-14",
+			TransactionCount: 3,
+			ControlSum:       1534.56,
+			Status:           RejectedStatus,
+		}
+
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any(), gomock.Any()).
+			Return(batchModel{}, errBatchAlreadyExists)
+		repoMock.EXPECT().
+			GetByMessageID(ctx, "principal-id", "PAYROLL-2025-03-14").
+			Return(model, nil)
+		repoMock.EXPECT().
+			ListPayments(ctx, model.ID).
+			Return([]paymentModel{
+				{PaymentInformationID: "PAYROLL-MARCH", EndToEndID: "E2E-1", Status: RejectedStatus, ReasonCode: "AM04"},
+			}, nil)
+
+		body, err := svc.Submit(ctx, "principal-id", strings.NewReader(string(fixture)))
+		assert.NoError(t, err)
+
+		r := report(t, body)
+		assert.Equal(t, RejectedStatus, r.OriginalGroup.Status)
+		assert.Equal(t, "E2E-1", r.PaymentInformation[0].Transactions[0].EndToEndID)
+	})
+
+	t.Run("success submit, message interrupted before being completed", func(t *testing.T) {
+		model := batchModel{ID: uuid.New(), MessageID: "PAYROLL-2025-03-14", TransactionCount: 2}
+
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any(), gomock.Any()).
+			Return(batchModel{}, errBatchAlreadyExists)
+		repoMock.EXPECT().
+			GetByMessageID(ctx, "principal-id", "PAYROLL-2025-03-14").
+			Return(model, nil)
+		repoMock.EXPECT().
+			ListPayments(ctx, model.ID).
+			Return([]paymentModel{
+				{PaymentInformationID: "PAYROLL-MARCH", EndToEndID: "E2E-1", Status: AcceptedStatus},
+				{PaymentInformationID: "PAYROLL-MARCH", EndToEndID: "E2E-2", Status: RejectedStatus, ReasonCode: "AM04"},
+			}, nil)
+		repoMock.EXPECT().
+			Complete(ctx, model.ID, PartiallyAcceptedStatus).
+			Return(nil)
+
+		body, err := svc.Submit(ctx, "principal-id", strings.NewReader(string(fixture)))
+		assert.NoError(t, err)
+
+		r := report(t, body)
+		assert.Equal(t, PartiallyAcceptedStatus, r.OriginalGroup.Status)
+	})
+
+	t.Run("fail submit, message still processing", func(t *testing.T) {
+		model := batchModel{ID: uuid.New()}
+
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any(), gomock.Any()).
+			Return(batchModel{}, errBatchAlreadyExists)
+		repoMock.EXPECT().
+			GetByMessageID(ctx, "principal-id", "PAYROLL-2025-03-14").
+			Return(model, nil)
+		repoMock.EXPECT().
+			ListPayments(ctx, model.ID).
+			Return([]paymentModel{{EndToEndID: "E2E-1", Status: AcceptedStatus}, {EndToEndID: "E2E-2"}}, nil)
+
+		body, err := svc.Submit(ctx, "principal-id", strings.NewReader(string(fixture)))
+		assert.ErrorIs(t, err, ErrBatchAlreadySubmitted)
+		assert.Nil(t, body)
+	})
+}
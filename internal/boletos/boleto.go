@@ -0,0 +1,135 @@
+package boletos
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Status string
+
+const (
+	PendingStatus Status = "PENDING"
+	PaidStatus    Status = "PAID"
+)
+
+// Boleto is a bank slip issued by an account and paid by the payer at any bank, which settles it
+// through the return file the clearing bank sends back.
+type Boleto struct {
+	ID        uuid.UUID
+	AccountID uuid.UUID
+	// OurNumber is the "nosso número" identifying the boleto in barcodes and return files.
+	OurNumber string
+	Amount    float64
+	DueDate   time.Time
+	// FineRate is charged once on payments after the due date, 0.02 means 2% of the amount.
+	FineRate float64
+	// MonthlyInterestRate accrues pro rata per day late, 0.01 means 1% of the amount per month.
+	MonthlyInterestRate float64
+	PayerName           string
+	PayerDocumentNumber string
+	Description         string
+	Barcode             string
+	DigitableLine       string
+	Status              Status
+	PaidAmount          float64
+	PaidAt              time.Time
+	TransactionID       uuid.NullUUID
+	CreatedAt           time.Time
+}
+
+func newBoleto(model boletoModel) Boleto {
+	return Boleto{
+		ID:                  model.ID,
+		AccountID:           model.AccountID,
+		OurNumber:           model.OurNumber,
+		Amount:              model.Amount,
+		DueDate:             model.DueDate,
+		FineRate:            model.FineRate,
+		MonthlyInterestRate: model.MonthlyInterestRate,
+		PayerName:           model.PayerName,
+		PayerDocumentNumber: model.PayerDocumentNumber,
+		Description:         model.Description,
+		Barcode:             model.Barcode,
+		DigitableLine:       model.DigitableLine,
+		Status:              model.Status,
+		PaidAmount:          model.PaidAmount,
+		PaidAt:              model.PaidAt,
+		TransactionID:       model.TransactionID,
+		CreatedAt:           model.CreatedAt,
+	}
+}
+
+// AmountDue is the amount to pay on the given day, adding the fine and the interest of the days
+// late to payments after the due date. Due dates on weekends move to the following Monday.
+func (b Boleto) AmountDue(on time.Time) float64 {
+	daysLate := int(date(on).Sub(payableUntil(b.DueDate)).Hours() / 24)
+	if daysLate <= 0 {
+		return b.Amount
+	}
+
+	fine := b.Amount * b.FineRate
+	interest := b.Amount * b.MonthlyInterestRate / 30 * float64(daysLate)
+
+	return math.Round((b.Amount+fine+interest)*100) / 100
+}
+
+// payableUntil is the last day to pay without fines and interest.
+func payableUntil(dueDate time.Time) time.Time {
+	due := date(dueDate)
+	switch due.Weekday() {
+	case time.Saturday:
+		return due.AddDate(0, 0, 2)
+	case time.Sunday:
+		return due.AddDate(0, 0, 1)
+	default:
+		return due
+	}
+}
+
+func date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// ImportResult is the outcome of each record of an imported return file.
+type ImportResult struct {
+	Records []RecordResult
+}
+
+// Count is how many records had the given outcome.
+func (r ImportResult) Count(outcome Outcome) int {
+	var count int
+	for _, record := range r.Records {
+		if record.Outcome == outcome {
+			count++
+		}
+	}
+	return count
+}
+
+type Outcome string
+
+const (
+	// SettledOutcome is a payment credited to the account of the boleto.
+	SettledOutcome Outcome = "SETTLED"
+	// AlreadySettledOutcome is a payment already credited, from a file imported twice.
+	AlreadySettledOutcome Outcome = "ALREADY_SETTLED"
+	// NotFoundOutcome is a record whose our number does not match any boleto.
+	NotFoundOutcome Outcome = "NOT_FOUND"
+	// IgnoredOutcome is a record of an occurrence other than a payment.
+	IgnoredOutcome Outcome = "IGNORED"
+	// FailedOutcome is a payment that could not be credited, importing the file again retries it.
+	FailedOutcome Outcome = "FAILED"
+)
+
+type RecordResult struct {
+	Line          int
+	OurNumber     string
+	Occurrence    string
+	PaidAmount    float64
+	Outcome       Outcome
+	BoletoID      uuid.NullUUID
+	TransactionID uuid.NullUUID
+	Reason        string
+}
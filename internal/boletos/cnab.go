@@ -0,0 +1,185 @@
+package boletos
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	cnab240LineSize = 240
+	cnab400LineSize = 400
+)
+
+// Occurrence codes of the return files shared by the CNAB 240 and CNAB 400 layouts.
+const (
+	// paidOccurrence is the "liquidação" of a boleto.
+	paidOccurrence = "06"
+	// paidAfterWriteOffOccurrence is the "liquidação após baixa", a payment of a written off boleto.
+	paidAfterWriteOffOccurrence = "17"
+)
+
+var ErrInvalidReturnFile = errors.New("the file is not a CNAB 240 or CNAB 400 return file")
+
+// returnRecord is a boleto occurrence reported in a return file.
+type returnRecord struct {
+	// Line is where the record starts in the file, counting from 1.
+	Line       int
+	OurNumber  string
+	Occurrence string
+	PaidAmount float64
+	PaidAt     time.Time
+}
+
+func (r returnRecord) paid() bool {
+	return r.Occurrence == paidOccurrence || r.Occurrence == paidAfterWriteOffOccurrence
+}
+
+// parseReturnFile reads the records of a CNAB 240 or CNAB 400 return file, telling the layouts
+// apart by the size of the header line.
+func parseReturnFile(r io.Reader) ([]returnRecord, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimRight(scanner.Text(), "\r"))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return nil, ErrInvalidReturnFile
+	}
+
+	for i, line := range lines {
+		if len(line) != len(lines[0]) {
+			return nil, fmt.Errorf("%w: line %d has %d characters", ErrInvalidReturnFile, i+1, len(line))
+		}
+	}
+
+	switch len(lines[0]) {
+	case cnab240LineSize:
+		return parseCNAB240(lines)
+	case cnab400LineSize:
+		return parseCNAB400(lines)
+	default:
+		return nil, ErrInvalidReturnFile
+	}
+}
+
+// parseCNAB240 reads the FEBRABAN CNAB 240 layout, where each boleto takes a T segment, with the
+// our number and the occurrence, followed by a U segment, with the amount paid and its date.
+func parseCNAB240(lines []string) ([]returnRecord, error) {
+	// The file header has the record type 0 at position 8 and the return code 2 at position 143.
+	if lines[0][7] != '0' || lines[0][142] != '2' {
+		return nil, fmt.Errorf("%w: missing the CNAB 240 return header", ErrInvalidReturnFile)
+	}
+
+	var records []returnRecord
+	var pending *returnRecord
+	for i, line := range lines {
+		if line[7] != '3' {
+			continue
+		}
+
+		switch line[13] {
+		case 'T':
+			pending = &returnRecord{
+				Line:       i + 1,
+				OurNumber:  ourNumber(field(line, 38, 57)),
+				Occurrence: field(line, 16, 17),
+			}
+		case 'U':
+			if pending == nil {
+				return nil, fmt.Errorf("%w: line %d has a U segment without a T segment", ErrInvalidReturnFile, i+1)
+			}
+
+			var err error
+			pending.PaidAmount, err = amount(field(line, 78, 92))
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidReturnFile, i+1, err)
+			}
+
+			if pending.paid() {
+				pending.PaidAt, err = time.Parse("02012006", field(line, 138, 145))
+				if err != nil {
+					return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidReturnFile, i+1, err)
+				}
+			}
+
+			records = append(records, *pending)
+			pending = nil
+		}
+	}
+
+	if pending != nil {
+		return nil, fmt.Errorf("%w: line %d has a T segment without a U segment", ErrInvalidReturnFile, pending.Line)
+	}
+
+	return records, nil
+}
+
+// parseCNAB400 reads the CNAB 400 layout, with one detail record per boleto.
+func parseCNAB400(lines []string) ([]returnRecord, error) {
+	// The file header has the record type 0 at position 1 and the return code 2 at position 2.
+	if lines[0][0] != '0' || lines[0][1] != '2' {
+		return nil, fmt.Errorf("%w: missing the CNAB 400 return header", ErrInvalidReturnFile)
+	}
+
+	var records []returnRecord
+	for i, line := range lines {
+		if line[0] != '1' {
+			continue
+		}
+
+		record := returnRecord{
+			Line:       i + 1,
+			OurNumber:  ourNumber(field(line, 71, 82)),
+			Occurrence: field(line, 109, 110),
+		}
+
+		var err error
+		record.PaidAmount, err = amount(field(line, 254, 266))
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidReturnFile, i+1, err)
+		}
+
+		if record.paid() {
+			record.PaidAt, err = time.Parse("020106", field(line, 111, 116))
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidReturnFile, i+1, err)
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// field is the trimmed text between the positions, counting from 1 as the layouts do.
+func field(line string, from, to int) string {
+	return strings.TrimSpace(line[from-1 : to])
+}
+
+// ourNumber keeps the rightmost digits of the our number, which banks zero or space pad to the
+// size of the field.
+func ourNumber(value string) string {
+	return pad(strings.TrimLeft(digits(value), "0"), ourNumberSize)
+}
+
+// amount parses amounts written in cents.
+func amount(value string) (float64, error) {
+	cents, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	return float64(cents) / 100, nil
+}
@@ -0,0 +1,77 @@
+//go:build unit
+
+package boletos
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReturnFile(t *testing.T) {
+	paidAt := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("cnab 240", func(t *testing.T) {
+		file, err := os.Open("testdata/return_cnab240.ret")
+		assert.NoError(t, err)
+		defer file.Close()
+
+		records, err := parseReturnFile(file)
+		assert.NoError(t, err)
+		assert.Equal(t, []returnRecord{
+			{Line: 3, OurNumber: "00000000001", Occurrence: "06", PaidAmount: 150, PaidAt: paidAt},
+			{Line: 5, OurNumber: "00000000002", Occurrence: "02"},
+			{Line: 7, OurNumber: "00000000003", Occurrence: "06", PaidAmount: 204.47, PaidAt: paidAt},
+			{Line: 9, OurNumber: "00000099999", Occurrence: "06", PaidAmount: 50, PaidAt: paidAt},
+		}, records)
+	})
+
+	t.Run("cnab 400", func(t *testing.T) {
+		file, err := os.Open("testdata/return_cnab400.ret")
+		assert.NoError(t, err)
+		defer file.Close()
+
+		records, err := parseReturnFile(file)
+		assert.NoError(t, err)
+		assert.Equal(t, []returnRecord{
+			{Line: 2, OurNumber: "00000000001", Occurrence: "06", PaidAmount: 150, PaidAt: paidAt},
+			{Line: 3, OurNumber: "00000000002", Occurrence: "09"},
+			{Line: 4, OurNumber: "00000000003", Occurrence: "17", PaidAmount: 204.47, PaidAt: paidAt},
+		}, records)
+		assert.False(t, records[1].paid())
+		assert.True(t, records[2].paid())
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		_, err := parseReturnFile(strings.NewReader("\n\n"))
+		assert.ErrorIs(t, err, ErrInvalidReturnFile)
+	})
+
+	t.Run("unknown layout", func(t *testing.T) {
+		_, err := parseReturnFile(strings.NewReader(strings.Repeat("0", 150)))
+		assert.ErrorIs(t, err, ErrInvalidReturnFile)
+	})
+
+	t.Run("lines of different sizes", func(t *testing.T) {
+		_, err := parseReturnFile(strings.NewReader(strings.Repeat("0", 240) + "\n" + strings.Repeat("0", 239)))
+		assert.ErrorIs(t, err, ErrInvalidReturnFile)
+	})
+
+	t.Run("remittance instead of return file", func(t *testing.T) {
+		_, err := parseReturnFile(strings.NewReader("01" + strings.Repeat(" ", 398)))
+		assert.ErrorIs(t, err, ErrInvalidReturnFile)
+	})
+
+	t.Run("t segment without u segment", func(t *testing.T) {
+		header := []byte(strings.Repeat(" ", 240))
+		header[7], header[142] = '0', '2'
+		segment := []byte(strings.Repeat(" ", 240))
+		segment[7], segment[13] = '3', 'T'
+
+		_, err := parseReturnFile(strings.NewReader(string(header) + "\n" + string(segment)))
+		assert.ErrorIs(t, err, ErrInvalidReturnFile)
+	})
+}
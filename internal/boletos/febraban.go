@@ -0,0 +1,130 @@
+package boletos
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/exchange"
+)
+
+const (
+	// currency is the only one boletos are issued in, and currencyCode is its FEBRABAN code.
+	currency     exchange.Currency = "BRL"
+	currencyCode                   = "9"
+	// wallet is the "carteira" of the boletos in the free field.
+	wallet = "09"
+	// maxAmount is the largest amount the 10 digits of the barcode hold.
+	maxAmount = 99999999.99
+	// ourNumberSize is the number of digits of the our number in the free field.
+	ourNumberSize = 11
+)
+
+// factorBaseDate is the day the due date factor counts from. The factor reached 9999 on 2025-02-21
+// and restarted at 1000 on the following day.
+var factorBaseDate = time.Date(1997, time.October, 7, 0, 0, 0, 0, time.UTC)
+
+// dueDateFactor is the number of days between the base date and the due date, restarting at 1000
+// after 9999.
+func dueDateFactor(dueDate time.Time) int {
+	days := int(date(dueDate).Sub(factorBaseDate).Hours() / 24)
+	if days > 9999 {
+		days = (days-1000)%9000 + 1000
+	}
+	return days
+}
+
+// freeField lays out the bank reserved 25 digits of the barcode as agency (4), wallet (2), our
+// number (11), account number without separators (7) and a zero.
+func freeField(agency, ourNumber, accountNumber string) string {
+	return pad(digits(agency), 4) + wallet + pad(ourNumber, ourNumberSize) + pad(digits(accountNumber), 7) + "0"
+}
+
+// encodeBarcode builds the 44 digits of the barcode: bank (3), currency (1), general check digit
+// (1), due date factor (4), amount in cents (10) and the free field (25).
+func encodeBarcode(bankCode string, dueDate time.Time, amount float64, free string) string {
+	withoutCheckDigit := fmt.Sprintf(
+		"%s%s%04d%010d%s",
+		pad(bankCode, 3),
+		currencyCode,
+		dueDateFactor(dueDate),
+		int64(math.Round(amount*100)),
+		free,
+	)
+
+	return withoutCheckDigit[:4] + fmt.Sprint(mod11(withoutCheckDigit)) + withoutCheckDigit[4:]
+}
+
+// digitableLine is the typeable version of the barcode in five fields, the first three of them
+// followed by their mod 10 check digit.
+func digitableLine(barcode string) string {
+	free := barcode[19:]
+	first := barcode[:4] + free[:5]
+	second := free[5:15]
+	third := free[15:]
+
+	first += fmt.Sprint(mod10(first))
+	second += fmt.Sprint(mod10(second))
+	third += fmt.Sprint(mod10(third))
+
+	return fmt.Sprintf(
+		"%s.%s %s.%s %s.%s %s %s",
+		first[:5], first[5:],
+		second[:5], second[5:],
+		third[:5], third[5:],
+		barcode[4:5],
+		barcode[5:19],
+	)
+}
+
+// mod10 multiplies the digits by 2 and 1 alternately from the rightmost one, adding the digits of
+// each product.
+func mod10(digits string) int {
+	var sum int
+	weight := 2
+	for i := len(digits) - 1; i >= 0; i-- {
+		product := int(digits[i]-'0') * weight
+		sum += product/10 + product%10
+		weight = 3 - weight
+	}
+
+	return (10 - sum%10) % 10
+}
+
+// mod11 multiplies the digits by weights from 2 to 9 from the rightmost one. Results of 0, 10 and
+// 11 are represented as 1, since the general check digit is never zero.
+func mod11(digits string) int {
+	var sum int
+	weight := 2
+	for i := len(digits) - 1; i >= 0; i-- {
+		sum += int(digits[i]-'0') * weight
+		weight++
+		if weight > 9 {
+			weight = 2
+		}
+	}
+
+	digit := 11 - sum%11
+	if digit == 0 || digit >= 10 {
+		return 1
+	}
+	return digit
+}
+
+func digits(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r < '0' || r > '9' {
+			return -1
+		}
+		return r
+	}, value)
+}
+
+// pad left pads the digits with zeros up to size, keeping the rightmost size digits of longer ones.
+func pad(value string, size int) string {
+	if len(value) >= size {
+		return value[len(value)-size:]
+	}
+	return strings.Repeat("0", size-len(value)) + value
+}
@@ -0,0 +1,72 @@
+//go:build unit
+
+package boletos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDueDateFactor(t *testing.T) {
+	assert.Equal(t, 1000, dueDateFactor(time.Date(2000, time.July, 3, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 3737, dueDateFactor(time.Date(2007, time.December, 31, 15, 30, 0, 0, time.UTC)))
+	assert.Equal(t, 9999, dueDateFactor(time.Date(2025, time.February, 21, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 1000, dueDateFactor(time.Date(2025, time.February, 22, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 1001, dueDateFactor(time.Date(2025, time.February, 23, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestCheckDigits(t *testing.T) {
+	assert.Equal(t, 5, mod10("001905009"))
+	assert.Equal(t, 9, mod10("4014481606"))
+	assert.Equal(t, 4, mod10("0680935031"))
+	assert.Equal(t, 0, mod10("0"))
+	assert.Equal(t, 3, mod11("0019373700000001000500940144816060680935031"))
+	assert.Equal(t, 1, mod11("0"))
+}
+
+func TestEncodeBarcode(t *testing.T) {
+	barcode := encodeBarcode(
+		"001",
+		time.Date(2007, time.December, 31, 0, 0, 0, 0, time.UTC),
+		1,
+		"0500940144816060680935031",
+	)
+	assert.Equal(t, "00193373700000001000500940144816060680935031", barcode)
+	assert.Equal(t, "00190.50095 40144.816069 06809.350314 3 37370000000100", digitableLine(barcode))
+}
+
+func TestFreeField(t *testing.T) {
+	free := freeField("0001", "00000000042", "123456-7")
+	assert.Equal(t, "0001"+"09"+"00000000042"+"1234567"+"0", free)
+	assert.Len(t, free, 25)
+
+	barcode := encodeBarcode("999", time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC), 1234.56, free)
+	assert.Len(t, barcode, 44)
+	assert.Equal(t, "9999", barcode[:4])
+	assert.Equal(t, "1016", barcode[5:9])
+	assert.Equal(t, "0000123456", barcode[9:19])
+	assert.Equal(t, free, barcode[19:])
+	assert.Len(t, digitableLine(barcode), 54)
+}
+
+func TestBoleto_AmountDue(t *testing.T) {
+	boleto := Boleto{
+		Amount: 200,
+		// A Monday.
+		DueDate:             time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC),
+		FineRate:            0.02,
+		MonthlyInterestRate: 0.01,
+	}
+
+	assert.Equal(t, 200.0, boleto.AmountDue(time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 200.0, boleto.AmountDue(time.Date(2025, time.March, 3, 23, 59, 0, 0, time.UTC)))
+	assert.Equal(t, 204.07, boleto.AmountDue(time.Date(2025, time.March, 4, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 204.47, boleto.AmountDue(time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)))
+
+	// Due on a Saturday, payable without charges on the following Monday.
+	boleto.DueDate = time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, 200.0, boleto.AmountDue(time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 204.07, boleto.AmountDue(time.Date(2025, time.March, 4, 0, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,49 @@
+package boletos
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type boletoModel struct {
+	bun.BaseModel `bun:"table:boletos"`
+
+	ID                  uuid.UUID     `bun:"id,pk"`
+	AccountID           uuid.UUID     `bun:"account_id"`
+	OurNumber           string        `bun:"our_number"`
+	Amount              float64       `bun:"amount"`
+	DueDate             time.Time     `bun:"due_date"`
+	FineRate            float64       `bun:"fine_rate"`
+	MonthlyInterestRate float64       `bun:"monthly_interest_rate"`
+	PayerName           string        `bun:"payer_name"`
+	PayerDocumentNumber string        `bun:"payer_document_number"`
+	Description         string        `bun:"description"`
+	Barcode             string        `bun:"barcode"`
+	DigitableLine       string        `bun:"digitable_line"`
+	Status              Status        `bun:"status"`
+	PaidAmount          float64       `bun:"paid_amount,nullzero"`
+	PaidAt              time.Time     `bun:"paid_at,nullzero"`
+	TransactionID       uuid.NullUUID `bun:"transaction_id"`
+	CreatedAt           time.Time     `bun:"created_at,notnull"`
+}
+
+func newBoletoModel(boleto Boleto) boletoModel {
+	return boletoModel{
+		ID:                  boleto.ID,
+		AccountID:           boleto.AccountID,
+		OurNumber:           boleto.OurNumber,
+		Amount:              boleto.Amount,
+		DueDate:             boleto.DueDate,
+		FineRate:            boleto.FineRate,
+		MonthlyInterestRate: boleto.MonthlyInterestRate,
+		PayerName:           boleto.PayerName,
+		PayerDocumentNumber: boleto.PayerDocumentNumber,
+		Description:         boleto.Description,
+		Barcode:             boleto.Barcode,
+		DigitableLine:       boleto.DigitableLine,
+		Status:              boleto.Status,
+		CreatedAt:           boleto.CreatedAt,
+	}
+}
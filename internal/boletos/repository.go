@@ -0,0 +1,173 @@
+package boletos
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
+)
+
+var errBoletoNotPending = errors.New("the boleto is no longer pending")
+
+type Repository interface {
+	NextOurNumber(ctx context.Context) (int64, error)
+	Create(ctx context.Context, model boletoModel) (boletoModel, error)
+	GetByID(ctx context.Context, id uuid.UUID) (boletoModel, error)
+	GetByOurNumber(ctx context.Context, ourNumber string) (boletoModel, error)
+	MarkPaid(ctx context.Context, id uuid.UUID, paidAmount float64, paidAt time.Time, transactionID uuid.UUID) (boletoModel, error)
+	GetSettlementTransactionID(ctx context.Context, idempotencyKey string) (uuid.UUID, error)
+}
+
+type repository struct {
+	tracer tracer.Tracer
+	db     database.Database
+}
+
+func NewRepository(t tracer.Tracer, db database.Database) Repository {
+	return repository{
+		tracer: t,
+		db:     db,
+	}
+}
+
+// NextOurNumber draws the our number of a new boleto from a sequence, so none is ever reused.
+func (r repository) NextOurNumber(ctx context.Context) (int64, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var number int64
+	err := r.db.Master().
+		NewSelect().
+		ColumnExpr("nextval('boletos_our_number_seq')").
+		Scan(ctx, &number)
+	if err != nil {
+		span.RecordError(err)
+		return 0, err
+	}
+
+	return number, nil
+}
+
+func (r repository) Create(ctx context.Context, model boletoModel) (boletoModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	if model.CreatedAt.IsZero() {
+		model.CreatedAt = time.Now().UTC()
+	}
+
+	_, err := r.db.Master().
+		NewInsert().
+		Model(&model).
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return boletoModel{}, err
+	}
+
+	return model, nil
+}
+
+func (r repository) GetByID(ctx context.Context, id uuid.UUID) (boletoModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var model boletoModel
+	err := r.db.Master().
+		NewSelect().
+		Model(&model).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return boletoModel{}, err
+	}
+
+	return model, nil
+}
+
+func (r repository) GetByOurNumber(ctx context.Context, ourNumber string) (boletoModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var model boletoModel
+	err := r.db.Master().
+		NewSelect().
+		Model(&model).
+		Where("our_number = ?", ourNumber).
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return boletoModel{}, err
+	}
+
+	return model, nil
+}
+
+// MarkPaid records the payment of the boleto and the credit that settled it, failing with
+// errBoletoNotPending when it was already marked.
+func (r repository) MarkPaid(
+	ctx context.Context,
+	id uuid.UUID,
+	paidAmount float64,
+	paidAt time.Time,
+	transactionID uuid.UUID,
+) (boletoModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var model boletoModel
+	res, err := r.db.Master().
+		NewUpdate().
+		Model(&model).
+		Set("status = ?", PaidStatus).
+		Set("paid_amount = ?", paidAmount).
+		Set("paid_at = ?", paidAt).
+		Set("transaction_id = ?", transactionID).
+		Where("id = ?", id).
+		Where("status = ?", PendingStatus).
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return boletoModel{}, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		span.RecordError(err)
+		return boletoModel{}, err
+	}
+
+	if rows == 0 {
+		span.RecordError(errBoletoNotPending)
+		return boletoModel{}, errBoletoNotPending
+	}
+
+	return model, nil
+}
+
+// GetSettlementTransactionID returns the credit made with the idempotency key of a boleto
+// settlement, or sql.ErrNoRows when the boleto was not settled.
+func (r repository) GetSettlementTransactionID(ctx context.Context, idempotencyKey string) (uuid.UUID, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var id uuid.UUID
+	err := r.db.Master().
+		NewSelect().
+		TableExpr("transactions").
+		Column("id").
+		Where("idempotency_key = ?", idempotencyKey).
+		Scan(ctx, &id)
+	if err != nil {
+		span.RecordError(err)
+		return uuid.Nil, err
+	}
+
+	return id, nil
+}
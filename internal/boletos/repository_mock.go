@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/boletos/repository.go
+
+// Package boletos is a generated GoMock package.
+package boletos
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, model boletoModel) (boletoModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, model)
+	ret0, _ := ret[0].(boletoModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, model)
+}
+
+// GetByID mocks base method.
+func (m *MockRepository) GetByID(ctx context.Context, id uuid.UUID) (boletoModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(boletoModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByOurNumber mocks base method.
+func (m *MockRepository) GetByOurNumber(ctx context.Context, ourNumber string) (boletoModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByOurNumber", ctx, ourNumber)
+	ret0, _ := ret[0].(boletoModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByOurNumber indicates an expected call of GetByOurNumber.
+func (mr *MockRepositoryMockRecorder) GetByOurNumber(ctx, ourNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOurNumber", reflect.TypeOf((*MockRepository)(nil).GetByOurNumber), ctx, ourNumber)
+}
+
+// GetSettlementTransactionID mocks base method.
+func (m *MockRepository) GetSettlementTransactionID(ctx context.Context, idempotencyKey string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettlementTransactionID", ctx, idempotencyKey)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettlementTransactionID indicates an expected call of GetSettlementTransactionID.
+func (mr *MockRepositoryMockRecorder) GetSettlementTransactionID(ctx, idempotencyKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettlementTransactionID", reflect.TypeOf((*MockRepository)(nil).GetSettlementTransactionID), ctx, idempotencyKey)
+}
+
+// MarkPaid mocks base method.
+func (m *MockRepository) MarkPaid(ctx context.Context, id uuid.UUID, paidAmount float64, paidAt time.Time, transactionID uuid.UUID) (boletoModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPaid", ctx, id, paidAmount, paidAt, transactionID)
+	ret0, _ := ret[0].(boletoModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkPaid indicates an expected call of MarkPaid.
+func (mr *MockRepositoryMockRecorder) MarkPaid(ctx, id, paidAmount, paidAt, transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPaid", reflect.TypeOf((*MockRepository)(nil).MarkPaid), ctx, id, paidAmount, paidAt, transactionID)
+}
+
+// NextOurNumber mocks base method.
+func (m *MockRepository) NextOurNumber(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextOurNumber", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextOurNumber indicates an expected call of NextOurNumber.
+func (mr *MockRepositoryMockRecorder) NextOurNumber(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextOurNumber", reflect.TypeOf((*MockRepository)(nil).NextOurNumber), ctx)
+}
@@ -0,0 +1,128 @@
+//go:build integration
+
+package boletos
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/holders"
+	"github.com/dalmarcogd/dock-test/internal/products"
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/testingcontainers"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+
+	url, closeFunc, err := testingcontainers.NewPostgresContainer()
+	assert.NoError(t, err)
+	defer closeFunc(ctx) //nolint:errcheck
+
+	_, callerPath, _, _ := runtime.Caller(0) //nolint:dogsled
+	err = testingcontainers.RunMigrateDatabase(
+		url,
+		fmt.Sprintf("file://%s/../../migrations/", filepath.Dir(callerPath)),
+	)
+	assert.NoError(t, err)
+
+	db, err := database.New(tracer.NewNoop(), url, url)
+	assert.NoError(t, err)
+
+	holdersRepo := holders.NewRepository(tracer.NewNoop(), db)
+	holderModel, err := holdersRepo.Create(ctx, holders.HolderModel{
+		ID:             uuid.New(),
+		Name:           gofakeit.Name(),
+		DocumentNumber: gofakeit.SSN(),
+	})
+	assert.NoError(t, err)
+
+	accSvc := accounts.NewService(tracer.NewNoop(), accounts.NewRepository(tracer.NewNoop(), db), holdersRepo, false, 0)
+	account, err := accSvc.Create(ctx, accounts.Account{
+		Name:           gofakeit.Name(),
+		DocumentNumber: holderModel.DocumentNumber,
+		Type:           products.CheckingType,
+	})
+	assert.NoError(t, err)
+
+	repo := NewRepository(tracer.NewNoop(), db)
+
+	t.Run("our numbers are never reused", func(t *testing.T) {
+		first, err := repo.NextOurNumber(ctx)
+		assert.NoError(t, err)
+
+		second, err := repo.NextOurNumber(ctx)
+		assert.NoError(t, err)
+		assert.Greater(t, second, first)
+	})
+
+	t.Run("boleto paid once", func(t *testing.T) {
+		model, err := repo.Create(ctx, boletoModel{
+			ID:                  uuid.New(),
+			AccountID:           account.ID,
+			OurNumber:           "00000000042",
+			Amount:              150,
+			DueDate:             time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC),
+			FineRate:            0.02,
+			MonthlyInterestRate: 0.01,
+			PayerName:           gofakeit.Name(),
+			PayerDocumentNumber: "52998224725",
+			Barcode:             "99991101600000150000001090000000004212345670",
+			DigitableLine:       "99990.00104 90000.000004 21234.567009 1 10160000015000",
+			Status:              PendingStatus,
+		})
+		assert.NoError(t, err)
+
+		found, err := repo.GetByOurNumber(ctx, "00000000042")
+		assert.NoError(t, err)
+		assert.Equal(t, model.ID, found.ID)
+
+		_, err = repo.GetSettlementTransactionID(ctx, settlementKey(model.ID))
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		transactionID := uuid.New()
+		_, err = db.Master().ExecContext(
+			ctx,
+			"INSERT INTO transactions (id, to_account_id, type, amount, description, idempotency_key, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+			transactionID,
+			account.ID,
+			"CREDIT",
+			150,
+			"boleto 00000000042",
+			settlementKey(model.ID),
+			time.Now().UTC(),
+		)
+		assert.NoError(t, err)
+
+		settlementID, err := repo.GetSettlementTransactionID(ctx, settlementKey(model.ID))
+		assert.NoError(t, err)
+		assert.Equal(t, transactionID, settlementID)
+
+		paidAt := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+		paid, err := repo.MarkPaid(ctx, model.ID, 150, paidAt, transactionID)
+		assert.NoError(t, err)
+		assert.Equal(t, PaidStatus, paid.Status)
+		assert.Equal(t, transactionID, paid.TransactionID.UUID)
+
+		_, err = repo.MarkPaid(ctx, model.ID, 150, paidAt, transactionID)
+		assert.ErrorIs(t, err, errBoletoNotPending)
+	})
+
+	t.Run("boleto not found", func(t *testing.T) {
+		_, err := repo.GetByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		_, err = repo.GetByOurNumber(ctx, "99999999999")
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
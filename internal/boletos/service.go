@@ -0,0 +1,313 @@
+package boletos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/transactions"
+	"github.com/dalmarcogd/dock-test/pkg/document"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxDueDays is how far in the future boletos can be due.
+	maxDueDays = 365
+	// maxFineRate and maxMonthlyInterestRate are the legal limits of late payment charges in
+	// consumer relations.
+	maxFineRate            = 0.02
+	maxMonthlyInterestRate = 0.01
+)
+
+var (
+	ErrBoletoNotFound              = errors.New("no boleto found with this id")
+	ErrInvalidBoletoAmount         = errors.New("the boleto amount must be greater than zero and fit the barcode")
+	ErrInvalidBoletoDueDate        = errors.New("the boleto must be due from today and within 365 days")
+	ErrInvalidBoletoFine           = errors.New("the boleto fine must be between 0 and 2%")
+	ErrInvalidBoletoInterest       = errors.New("the boleto monthly interest must be between 0 and 1%")
+	ErrInvalidBoletoPayer          = errors.New("the boleto payer must have a name and a valid CPF or CNPJ")
+	ErrBoletoAccountNotAllowed     = errors.New("boletos can only be issued to active accounts that are not pockets")
+	ErrBoletoCurrencyNotSupported  = errors.New("boletos can only be issued to accounts in BRL")
+	errBoletoSettlementUnavailable = errors.New("the boleto can not be settled now")
+)
+
+type Service interface {
+	Create(ctx context.Context, boleto Boleto) (Boleto, error)
+	GetByID(ctx context.Context, id uuid.UUID) (Boleto, error)
+	ImportReturnFile(ctx context.Context, file io.Reader) (ImportResult, error)
+}
+
+type service struct {
+	tracer          tracer.Tracer
+	repository      Repository
+	accountsSvc     accounts.Service
+	transactionsSvc transactions.Service
+	bankCode        string
+}
+
+func NewService(
+	t tracer.Tracer,
+	r Repository,
+	as accounts.Service,
+	ts transactions.Service,
+	bankCode string,
+) Service {
+	return service{
+		tracer:          t,
+		repository:      r,
+		accountsSvc:     as,
+		transactionsSvc: ts,
+		bankCode:        bankCode,
+	}
+}
+
+// Create issues a boleto to the account, encoding its barcode and digitable line with a new our
+// number and the agency and number of the account.
+func (s service) Create(ctx context.Context, boleto Boleto) (Boleto, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	err := s.validate(boleto)
+	if err != nil {
+		span.RecordError(err)
+		return Boleto{}, err
+	}
+
+	account, err := s.accountsSvc.GetByID(ctx, boleto.AccountID)
+	if err != nil {
+		span.RecordError(err)
+		return Boleto{}, err
+	}
+
+	if account.Status != accounts.ActiveStatus || account.IsPocket() {
+		span.RecordError(ErrBoletoAccountNotAllowed)
+		return Boleto{}, ErrBoletoAccountNotAllowed
+	}
+
+	if account.Currency != currency {
+		span.RecordError(ErrBoletoCurrencyNotSupported)
+		return Boleto{}, ErrBoletoCurrencyNotSupported
+	}
+
+	number, err := s.repository.NextOurNumber(ctx)
+	if err != nil {
+		zapctx.L(ctx).Error("boleto_service_next_our_number_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return Boleto{}, err
+	}
+
+	boleto.ID = uuid.New()
+	boleto.OurNumber = pad(strconv.FormatInt(number, 10), ourNumberSize)
+	boleto.DueDate = date(boleto.DueDate)
+	boleto.PayerDocumentNumber = document.Normalize(boleto.PayerDocumentNumber)
+	boleto.Barcode = encodeBarcode(
+		s.bankCode,
+		boleto.DueDate,
+		boleto.Amount,
+		freeField(account.Agency, boleto.OurNumber, account.Number),
+	)
+	boleto.DigitableLine = digitableLine(boleto.Barcode)
+	boleto.Status = PendingStatus
+	boleto.CreatedAt = time.Now().UTC()
+
+	model, err := s.repository.Create(ctx, newBoletoModel(boleto))
+	if err != nil {
+		zapctx.L(ctx).Error("boleto_service_create_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return Boleto{}, err
+	}
+
+	zapctx.L(ctx).Info(
+		"boleto_created",
+		zap.String("boleto_id", model.ID.String()),
+		zap.String("account_id", model.AccountID.String()),
+		zap.String("our_number", model.OurNumber),
+		zap.Float64("amount", model.Amount),
+	)
+
+	return newBoleto(model), nil
+}
+
+func (s service) validate(boleto Boleto) error {
+	if boleto.Amount <= 0 || boleto.Amount > maxAmount {
+		return ErrInvalidBoletoAmount
+	}
+
+	today := date(time.Now().UTC())
+	due := date(boleto.DueDate)
+	if due.Before(today) || due.After(today.AddDate(0, 0, maxDueDays)) {
+		return ErrInvalidBoletoDueDate
+	}
+
+	if boleto.FineRate < 0 || boleto.FineRate > maxFineRate {
+		return ErrInvalidBoletoFine
+	}
+
+	if boleto.MonthlyInterestRate < 0 || boleto.MonthlyInterestRate > maxMonthlyInterestRate {
+		return ErrInvalidBoletoInterest
+	}
+
+	if boleto.PayerName == "" || !document.IsValid(boleto.PayerDocumentNumber) {
+		return ErrInvalidBoletoPayer
+	}
+
+	return nil
+}
+
+func (s service) GetByID(ctx context.Context, id uuid.UUID) (Boleto, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	model, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return Boleto{}, ErrBoletoNotFound
+		}
+		zapctx.L(ctx).Error("boleto_service_get_repository_error", zap.Error(err))
+		return Boleto{}, err
+	}
+
+	return newBoleto(model), nil
+}
+
+// ImportReturnFile settles the boletos paid in a CNAB 240 or CNAB 400 return file, crediting the
+// amount paid to their accounts. Each boleto is credited once, so the same file can be imported
+// again to retry the records that failed.
+func (s service) ImportReturnFile(ctx context.Context, file io.Reader) (ImportResult, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	records, err := parseReturnFile(file)
+	if err != nil {
+		zapctx.L(ctx).Error("boleto_service_parse_return_file_error", zap.Error(err))
+		span.RecordError(err)
+		return ImportResult{}, err
+	}
+
+	result := ImportResult{Records: make([]RecordResult, 0, len(records))}
+	for _, record := range records {
+		result.Records = append(result.Records, s.settle(ctx, record))
+	}
+
+	zapctx.L(ctx).Info(
+		"boleto_return_file_imported",
+		zap.Int("records", len(result.Records)),
+		zap.Int("settled", result.Count(SettledOutcome)),
+		zap.Int("already_settled", result.Count(AlreadySettledOutcome)),
+		zap.Int("not_found", result.Count(NotFoundOutcome)),
+		zap.Int("failed", result.Count(FailedOutcome)),
+	)
+
+	return result, nil
+}
+
+// settle credits the payment of a return record to the account of its boleto. The credit carries an
+// idempotency key of the boleto, so a payment reported twice is credited once.
+func (s service) settle(ctx context.Context, record returnRecord) RecordResult {
+	result := RecordResult{
+		Line:       record.Line,
+		OurNumber:  record.OurNumber,
+		Occurrence: record.Occurrence,
+		PaidAmount: record.PaidAmount,
+	}
+
+	if !record.paid() {
+		result.Outcome = IgnoredOutcome
+		return result
+	}
+
+	model, err := s.repository.GetByOurNumber(ctx, record.OurNumber)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			zapctx.L(ctx).Warn("boleto_service_settle_not_found", zap.String("our_number", record.OurNumber))
+			result.Outcome = NotFoundOutcome
+			return result
+		}
+		zapctx.L(ctx).Error("boleto_service_settle_get_repository_error", zap.Error(err))
+		return failed(result, errBoletoSettlementUnavailable)
+	}
+
+	boleto := newBoleto(model)
+	result.BoletoID = uuid.NullUUID{UUID: boleto.ID, Valid: true}
+
+	if boleto.Status == PaidStatus {
+		result.Outcome = AlreadySettledOutcome
+		result.TransactionID = boleto.TransactionID
+		return result
+	}
+
+	if due := boleto.AmountDue(record.PaidAt); record.PaidAmount != due {
+		zapctx.L(ctx).Warn(
+			"boleto_service_settle_amount_differs",
+			zap.String("boleto_id", boleto.ID.String()),
+			zap.Float64("amount_due", due),
+			zap.Float64("paid_amount", record.PaidAmount),
+		)
+	}
+
+	transaction, err := s.transactionsSvc.CreateCredit(ctx, transactions.Transaction{
+		To:             boleto.AccountID,
+		Amount:         record.PaidAmount,
+		Currency:       currency,
+		Description:    fmt.Sprintf("boleto %s", boleto.OurNumber),
+		IdempotencyKey: settlementKey(boleto.ID),
+	})
+	if errors.Is(err, transactions.ErrTransactionAlreadyMade) {
+		transaction.ID, err = s.repository.GetSettlementTransactionID(ctx, settlementKey(boleto.ID))
+		if err != nil {
+			zapctx.L(ctx).Error("boleto_service_get_settlement_transaction_error", zap.Error(err))
+			return failed(result, errBoletoSettlementUnavailable)
+		}
+		result.Outcome = AlreadySettledOutcome
+	} else if err != nil {
+		zapctx.L(ctx).Error(
+			"boleto_service_settle_credit_error",
+			zap.String("boleto_id", boleto.ID.String()),
+			zap.Error(err),
+		)
+		return failed(result, err)
+	}
+	result.TransactionID = uuid.NullUUID{UUID: transaction.ID, Valid: true}
+
+	_, err = s.repository.MarkPaid(ctx, boleto.ID, record.PaidAmount, record.PaidAt, transaction.ID)
+	if err != nil && !errors.Is(err, errBoletoNotPending) {
+		zapctx.L(ctx).Error(
+			"boleto_service_mark_paid_repository_error",
+			zap.String("boleto_id", boleto.ID.String()),
+			zap.String("transaction_id", transaction.ID.String()),
+			zap.Error(err),
+		)
+		return failed(result, errBoletoSettlementUnavailable)
+	}
+
+	if result.Outcome == "" {
+		result.Outcome = SettledOutcome
+		zapctx.L(ctx).Info(
+			"boleto_settled",
+			zap.String("boleto_id", boleto.ID.String()),
+			zap.String("transaction_id", transaction.ID.String()),
+			zap.Float64("paid_amount", record.PaidAmount),
+		)
+	}
+
+	return result
+}
+
+func failed(result RecordResult, err error) RecordResult {
+	result.Outcome = FailedOutcome
+	result.Reason = err.Error()
+	return result
+}
+
+func settlementKey(id uuid.UUID) string {
+	return "boleto:" + id.String()
+}
@@ -0,0 +1,275 @@
+//go:build unit
+
+package boletos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/transactions"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_Create(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	accountsMock := accounts.NewMockService(ctrl)
+	svc := NewService(tracer.NewNoop(), repoMock, accountsMock, transactions.NewMockService(ctrl), "999")
+
+	account := accounts.Account{
+		ID:       uuid.New(),
+		Agency:   "0001",
+		Number:   "123456-7",
+		Currency: "BRL",
+		Status:   accounts.ActiveStatus,
+	}
+	dueDate := time.Now().UTC().AddDate(0, 0, 10)
+	boleto := Boleto{
+		AccountID:           account.ID,
+		Amount:              150,
+		DueDate:             dueDate,
+		FineRate:            0.02,
+		MonthlyInterestRate: 0.01,
+		PayerName:           "Maria Silva",
+		PayerDocumentNumber: "529.982.247-25",
+	}
+
+	t.Run("fail create, invalid amount", func(t *testing.T) {
+		invalid := boleto
+		invalid.Amount = maxAmount + 1
+
+		created, err := svc.Create(ctx, invalid)
+		assert.ErrorIs(t, err, ErrInvalidBoletoAmount)
+		assert.Empty(t, created)
+	})
+
+	t.Run("fail create, due in the past", func(t *testing.T) {
+		invalid := boleto
+		invalid.DueDate = time.Now().UTC().AddDate(0, 0, -1)
+
+		created, err := svc.Create(ctx, invalid)
+		assert.ErrorIs(t, err, ErrInvalidBoletoDueDate)
+		assert.Empty(t, created)
+	})
+
+	t.Run("fail create, fine above the limit", func(t *testing.T) {
+		invalid := boleto
+		invalid.FineRate = 0.1
+
+		created, err := svc.Create(ctx, invalid)
+		assert.ErrorIs(t, err, ErrInvalidBoletoFine)
+		assert.Empty(t, created)
+	})
+
+	t.Run("fail create, interest above the limit", func(t *testing.T) {
+		invalid := boleto
+		invalid.MonthlyInterestRate = 0.05
+
+		created, err := svc.Create(ctx, invalid)
+		assert.ErrorIs(t, err, ErrInvalidBoletoInterest)
+		assert.Empty(t, created)
+	})
+
+	t.Run("fail create, invalid payer document", func(t *testing.T) {
+		invalid := boleto
+		invalid.PayerDocumentNumber = "12345678900"
+
+		created, err := svc.Create(ctx, invalid)
+		assert.ErrorIs(t, err, ErrInvalidBoletoPayer)
+		assert.Empty(t, created)
+	})
+
+	t.Run("fail create, pocket account", func(t *testing.T) {
+		pocket := account
+		pocket.ParentID = uuid.NullUUID{UUID: uuid.New(), Valid: true}
+		accountsMock.EXPECT().GetByID(ctx, account.ID).Return(pocket, nil)
+
+		created, err := svc.Create(ctx, boleto)
+		assert.ErrorIs(t, err, ErrBoletoAccountNotAllowed)
+		assert.Empty(t, created)
+	})
+
+	t.Run("fail create, account in another currency", func(t *testing.T) {
+		usd := account
+		usd.Currency = "USD"
+		accountsMock.EXPECT().GetByID(ctx, account.ID).Return(usd, nil)
+
+		created, err := svc.Create(ctx, boleto)
+		assert.ErrorIs(t, err, ErrBoletoCurrencyNotSupported)
+		assert.Empty(t, created)
+	})
+
+	t.Run("success create", func(t *testing.T) {
+		accountsMock.EXPECT().GetByID(ctx, account.ID).Return(account, nil)
+		repoMock.EXPECT().NextOurNumber(ctx).Return(int64(42), nil)
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, model boletoModel) (boletoModel, error) {
+				assert.NotEqual(t, uuid.Nil, model.ID)
+				assert.Equal(t, "00000000042", model.OurNumber)
+				assert.Equal(t, PendingStatus, model.Status)
+				assert.Equal(t, "52998224725", model.PayerDocumentNumber)
+				assert.Equal(t, date(dueDate), model.DueDate)
+				assert.Equal(t, "0001090000000004212345670", model.Barcode[19:])
+				assert.Equal(t, "0000015000", model.Barcode[9:19])
+				assert.Equal(t, digitableLine(model.Barcode), model.DigitableLine)
+				return model, nil
+			})
+
+		created, err := svc.Create(ctx, boleto)
+		assert.NoError(t, err)
+		assert.Equal(t, PendingStatus, created.Status)
+		assert.Equal(t, "9999", created.Barcode[:4])
+		assert.Equal(t, 150.0, created.AmountDue(dueDate))
+	})
+}
+
+func TestService_ImportReturnFile(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	transactionsMock := transactions.NewMockService(ctrl)
+	svc := NewService(tracer.NewNoop(), repoMock, accounts.NewMockService(ctrl), transactionsMock, "999")
+
+	onTime := boletoModel{
+		ID:        uuid.New(),
+		AccountID: uuid.New(),
+		OurNumber: "00000000001",
+		Amount:    150,
+		DueDate:   time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC),
+		Status:    PendingStatus,
+	}
+	late := boletoModel{
+		ID:                  uuid.New(),
+		AccountID:           uuid.New(),
+		OurNumber:           "00000000003",
+		Amount:              200,
+		DueDate:             time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC),
+		FineRate:            0.02,
+		MonthlyInterestRate: 0.01,
+		Status:              PendingStatus,
+	}
+	paidAt := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("fail import, invalid file", func(t *testing.T) {
+		file, err := os.Open("testdata/return_cnab240.ret")
+		assert.NoError(t, err)
+		defer file.Close()
+		_, _ = file.Seek(10, 0)
+
+		result, err := svc.ImportReturnFile(ctx, file)
+		assert.ErrorIs(t, err, ErrInvalidReturnFile)
+		assert.Empty(t, result)
+	})
+
+	t.Run("success import, cnab 240", func(t *testing.T) {
+		file, err := os.Open("testdata/return_cnab240.ret")
+		assert.NoError(t, err)
+		defer file.Close()
+
+		onTimeTransactionID := uuid.New()
+		lateTransactionID := uuid.New()
+
+		repoMock.EXPECT().GetByOurNumber(ctx, "00000000001").Return(onTime, nil)
+		transactionsMock.EXPECT().
+			CreateCredit(ctx, transactions.Transaction{
+				To:             onTime.AccountID,
+				Amount:         150,
+				Currency:       "BRL",
+				Description:    "boleto 00000000001",
+				IdempotencyKey: "boleto:" + onTime.ID.String(),
+			}).
+			Return(transactions.Transaction{ID: onTimeTransactionID}, nil)
+		repoMock.EXPECT().MarkPaid(ctx, onTime.ID, 150.0, paidAt, onTimeTransactionID).Return(onTime, nil)
+
+		repoMock.EXPECT().GetByOurNumber(ctx, "00000000003").Return(late, nil)
+		transactionsMock.EXPECT().
+			CreateCredit(ctx, transactions.Transaction{
+				To:             late.AccountID,
+				Amount:         204.47,
+				Currency:       "BRL",
+				Description:    "boleto 00000000003",
+				IdempotencyKey: "boleto:" + late.ID.String(),
+			}).
+			Return(transactions.Transaction{ID: lateTransactionID}, nil)
+		repoMock.EXPECT().MarkPaid(ctx, late.ID, 204.47, paidAt, lateTransactionID).Return(late, nil)
+
+		repoMock.EXPECT().GetByOurNumber(ctx, "00000099999").Return(boletoModel{}, sql.ErrNoRows)
+
+		result, err := svc.ImportReturnFile(ctx, file)
+		assert.NoError(t, err)
+		assert.Len(t, result.Records, 4)
+		assert.Equal(t, SettledOutcome, result.Records[0].Outcome)
+		assert.Equal(t, onTimeTransactionID, result.Records[0].TransactionID.UUID)
+		assert.Equal(t, IgnoredOutcome, result.Records[1].Outcome)
+		assert.Equal(t, SettledOutcome, result.Records[2].Outcome)
+		assert.Equal(t, late.ID, result.Records[2].BoletoID.UUID)
+		assert.Equal(t, NotFoundOutcome, result.Records[3].Outcome)
+		assert.Equal(t, 2, result.Count(SettledOutcome))
+	})
+
+	t.Run("success import, cnab 400 imported again", func(t *testing.T) {
+		file, err := os.Open("testdata/return_cnab400.ret")
+		assert.NoError(t, err)
+		defer file.Close()
+
+		paid := onTime
+		paid.Status = PaidStatus
+		paid.TransactionID = uuid.NullUUID{UUID: uuid.New(), Valid: true}
+		lateTransactionID := uuid.New()
+
+		repoMock.EXPECT().GetByOurNumber(ctx, "00000000001").Return(paid, nil)
+		repoMock.EXPECT().GetByOurNumber(ctx, "00000000003").Return(late, nil)
+		transactionsMock.EXPECT().
+			CreateCredit(ctx, gomock.Any()).
+			Return(transactions.Transaction{}, transactions.ErrTransactionAlreadyMade)
+		repoMock.EXPECT().
+			GetSettlementTransactionID(ctx, "boleto:"+late.ID.String()).
+			Return(lateTransactionID, nil)
+		repoMock.EXPECT().
+			MarkPaid(ctx, late.ID, 204.47, paidAt, lateTransactionID).
+			Return(boletoModel{}, errBoletoNotPending)
+
+		result, err := svc.ImportReturnFile(ctx, file)
+		assert.NoError(t, err)
+		assert.Len(t, result.Records, 3)
+		assert.Equal(t, AlreadySettledOutcome, result.Records[0].Outcome)
+		assert.Equal(t, paid.TransactionID, result.Records[0].TransactionID)
+		assert.Equal(t, IgnoredOutcome, result.Records[1].Outcome)
+		assert.Equal(t, AlreadySettledOutcome, result.Records[2].Outcome)
+		assert.Equal(t, lateTransactionID, result.Records[2].TransactionID.UUID)
+	})
+
+	t.Run("success import, failed credits are reported", func(t *testing.T) {
+		file, err := os.Open("testdata/return_cnab400.ret")
+		assert.NoError(t, err)
+		defer file.Close()
+
+		repoMock.EXPECT().GetByOurNumber(ctx, "00000000001").Return(boletoModel{}, errors.New("timeout"))
+		repoMock.EXPECT().GetByOurNumber(ctx, "00000000003").Return(late, nil)
+		transactionsMock.EXPECT().
+			CreateCredit(ctx, gomock.Any()).
+			Return(transactions.Transaction{}, transactions.ErrAccountCreditsBlocked)
+
+		result, err := svc.ImportReturnFile(ctx, file)
+		assert.NoError(t, err)
+		assert.Equal(t, FailedOutcome, result.Records[0].Outcome)
+		assert.Equal(t, errBoletoSettlementUnavailable.Error(), result.Records[0].Reason)
+		assert.Equal(t, FailedOutcome, result.Records[2].Outcome)
+		assert.Equal(t, transactions.ErrAccountCreditsBlocked.Error(), result.Records[2].Reason)
+		assert.Equal(t, 2, result.Count(FailedOutcome))
+	})
+}
@@ -0,0 +1,70 @@
+package charges
+
+import (
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/exchange"
+	"github.com/google/uuid"
+	"github.com/skip2/go-qrcode"
+)
+
+type Status string
+
+const (
+	PendingStatus Status = "PENDING"
+	PaidStatus    Status = "PAID"
+	// ExpiredStatus is never stored, pending charges read as expired once past their expiry.
+	ExpiredStatus Status = "EXPIRED"
+)
+
+// Charge is a payment request of a merchant, paid once by another account through a P2P transaction.
+type Charge struct {
+	ID          uuid.UUID
+	AccountID   uuid.UUID
+	Amount      float64
+	Currency    exchange.Currency
+	Description string
+	ExpiresAt   time.Time
+	Status      Status
+	// Payload is the EMV QR code payload payers scan to pay the charge.
+	Payload        string
+	PayerAccountID uuid.NullUUID
+	TransactionID  uuid.NullUUID
+	PaidAt         time.Time
+	CreatedAt      time.Time
+}
+
+func newCharge(model chargeModel) Charge {
+	status := model.Status
+	if status == PendingStatus && time.Now().After(model.ExpiresAt) {
+		status = ExpiredStatus
+	}
+
+	return Charge{
+		ID:             model.ID,
+		AccountID:      model.AccountID,
+		Amount:         model.Amount,
+		Currency:       model.Currency,
+		Description:    model.Description,
+		ExpiresAt:      model.ExpiresAt,
+		Status:         status,
+		Payload:        model.Payload,
+		PayerAccountID: model.PayerAccountID,
+		TransactionID:  model.TransactionID,
+		PaidAt:         model.PaidAt,
+		CreatedAt:      model.CreatedAt,
+	}
+}
+
+// QRCode renders the payload as a PNG QR code of size by size pixels.
+func (c Charge) QRCode(size int) ([]byte, error) {
+	return qrcode.Encode(c.Payload, qrcode.Medium, size)
+}
+
+// Payment pays the charge from the payer account, on behalf of the principal with the id in
+// RequestedBy.
+type Payment struct {
+	ChargeID       uuid.UUID
+	PayerAccountID uuid.UUID
+	RequestedBy    string
+}
@@ -0,0 +1,115 @@
+package charges
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dalmarcogd/dock-test/internal/exchange"
+	"github.com/dalmarcogd/dock-test/pkg/stringer"
+)
+
+// EMV QR code merchant presented mode field ids, in the order they appear in the payload, followed
+// by the ids of the fields nested in the merchant account information and additional data.
+const (
+	payloadFormatIndicatorID   = "00"
+	pointOfInitiationMethodID  = "01"
+	merchantAccountInfoID      = "26"
+	merchantCategoryCodeID     = "52"
+	transactionCurrencyID      = "53"
+	transactionAmountID        = "54"
+	countryCodeID              = "58"
+	merchantNameID             = "59"
+	merchantCityID             = "60"
+	additionalDataFieldID      = "62"
+	crcID                      = "63"
+	globallyUniqueIdentifierID = "00"
+	merchantAccountChargeID    = "01"
+	additionalDataReferenceID  = "05"
+)
+
+const (
+	payloadFormatIndicator = "01"
+	// dynamicInitiationMethod marks payloads that can be paid only once.
+	dynamicInitiationMethod     = "12"
+	merchantAccountGUI          = "br.com.dock-test.charges"
+	unspecifiedMerchantCategory = "0000"
+	countryCode                 = "BR"
+
+	maxMerchantNameLength = 25
+	maxMerchantCityLength = 15
+	maxReferenceLength    = 25
+)
+
+// numericCurrencies maps the currencies charges can be issued in to their ISO 4217 numeric codes,
+// the ones EMV payloads carry.
+var numericCurrencies = map[exchange.Currency]string{
+	"BRL": "986",
+	"USD": "840",
+	"EUR": "978",
+	"GBP": "826",
+}
+
+// merchant is who receives the charge, as displayed to payers when they scan it.
+type merchant struct {
+	Name string
+	City string
+}
+
+// encodePayload builds the EMV QR code payload of the charge. The merchant account information
+// carries the charge id, which is all a payer needs to pay it, and the payload is single use.
+func encodePayload(charge Charge, m merchant) (string, error) {
+	currency, ok := numericCurrencies[charge.Currency]
+	if !ok {
+		return "", ErrChargeCurrencyNotSupported
+	}
+
+	var sb strings.Builder
+	sb.WriteString(field(payloadFormatIndicatorID, payloadFormatIndicator))
+	sb.WriteString(field(pointOfInitiationMethodID, dynamicInitiationMethod))
+	sb.WriteString(field(merchantAccountInfoID,
+		field(globallyUniqueIdentifierID, merchantAccountGUI)+
+			field(merchantAccountChargeID, charge.ID.String()),
+	))
+	sb.WriteString(field(merchantCategoryCodeID, unspecifiedMerchantCategory))
+	sb.WriteString(field(transactionCurrencyID, currency))
+	sb.WriteString(field(transactionAmountID, strconv.FormatFloat(charge.Amount, 'f', 2, 64)))
+	sb.WriteString(field(countryCodeID, countryCode))
+	sb.WriteString(field(merchantNameID, stringer.Plain(m.Name, maxMerchantNameLength)))
+	sb.WriteString(field(merchantCityID, stringer.Plain(m.City, maxMerchantCityLength)))
+	sb.WriteString(field(additionalDataFieldID,
+		field(additionalDataReferenceID, reference(charge)),
+	))
+
+	// the checksum covers the whole payload up to its own id and length.
+	sb.WriteString(crcID + "04")
+	sb.WriteString(fmt.Sprintf("%04X", crc16(sb.String())))
+
+	return sb.String(), nil
+}
+
+func field(id, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, len(value), value)
+}
+
+// reference is the charge id without dashes, cut to the size of the reference label.
+func reference(charge Charge) string {
+	return strings.ReplaceAll(charge.ID.String(), "-", "")[:maxReferenceLength]
+}
+
+// crc16 is the CRC-16/CCITT-FALSE checksum, polynomial 0x1021 and initial value 0xFFFF.
+func crc16(data string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
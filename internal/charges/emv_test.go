@@ -0,0 +1,42 @@
+//go:build unit
+
+package charges
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCRC16(t *testing.T) {
+	assert.Equal(t, uint16(0x29B1), crc16("123456789"))
+}
+
+func TestEncodePayload(t *testing.T) {
+	charge := Charge{
+		ID:       uuid.MustParse("3f2a9d7e-1c4b-4e8f-9a6d-0b5c7e1f2a3d"),
+		Amount:   150.5,
+		Currency: "BRL",
+	}
+
+	payload, err := encodePayload(charge, merchant{Name: "Maria Conceição", City: "Brasília"})
+	assert.NoError(t, err)
+
+	body := "000201" +
+		"010212" +
+		"2668" + "0024br.com.dock-test.charges" + "01363f2a9d7e-1c4b-4e8f-9a6d-0b5c7e1f2a3d" +
+		"52040000" +
+		"5303986" +
+		"5406150.50" +
+		"5802BR" +
+		"5915MARIA CONCEICAO" +
+		"6008BRASILIA" +
+		"6229" + "05253f2a9d7e1c4b4e8f9a6d0b5c7" +
+		"6304"
+	assert.Equal(t, body+fmt.Sprintf("%04X", crc16(body)), payload)
+
+	_, err = encodePayload(Charge{ID: charge.ID, Amount: 1, Currency: "JPY"}, merchant{})
+	assert.ErrorIs(t, err, ErrChargeCurrencyNotSupported)
+}
@@ -0,0 +1,46 @@
+package charges
+
+import (
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/exchange"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type chargeModel struct {
+	bun.BaseModel `bun:"table:charges"`
+
+	ID             uuid.UUID         `bun:"id,pk"`
+	AccountID      uuid.UUID         `bun:"account_id"`
+	Amount         float64           `bun:"amount"`
+	Currency       exchange.Currency `bun:"currency"`
+	Description    string            `bun:"description"`
+	ExpiresAt      time.Time         `bun:"expires_at"`
+	Status         Status            `bun:"status"`
+	Payload        string            `bun:"payload"`
+	PayerAccountID uuid.NullUUID     `bun:"payer_account_id"`
+	TransactionID  uuid.NullUUID     `bun:"transaction_id"`
+	PaidAt         time.Time         `bun:"paid_at,nullzero"`
+	CreatedAt      time.Time         `bun:"created_at,notnull"`
+}
+
+func newChargeModel(charge Charge) chargeModel {
+	return chargeModel{
+		ID:          charge.ID,
+		AccountID:   charge.AccountID,
+		Amount:      charge.Amount,
+		Currency:    charge.Currency,
+		Description: charge.Description,
+		ExpiresAt:   charge.ExpiresAt,
+		Status:      charge.Status,
+		Payload:     charge.Payload,
+		CreatedAt:   charge.CreatedAt,
+	}
+}
+
+// paymentTransactionModel is the transaction that paid a charge.
+type paymentTransactionModel struct {
+	ID            uuid.UUID `bun:"id"`
+	FromAccountID uuid.UUID `bun:"from_account_id"`
+}
@@ -0,0 +1,129 @@
+package charges
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
+)
+
+var errChargeNotPending = errors.New("the charge is no longer pending")
+
+type Repository interface {
+	Create(ctx context.Context, model chargeModel) (chargeModel, error)
+	GetByID(ctx context.Context, id uuid.UUID) (chargeModel, error)
+	MarkPaid(ctx context.Context, id, payerAccountID, transactionID uuid.UUID) (chargeModel, error)
+	GetPaymentTransaction(ctx context.Context, idempotencyKey string) (paymentTransactionModel, error)
+}
+
+type repository struct {
+	tracer tracer.Tracer
+	db     database.Database
+}
+
+func NewRepository(t tracer.Tracer, db database.Database) Repository {
+	return repository{
+		tracer: t,
+		db:     db,
+	}
+}
+
+func (r repository) Create(ctx context.Context, model chargeModel) (chargeModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	if model.CreatedAt.IsZero() {
+		model.CreatedAt = time.Now().UTC()
+	}
+
+	_, err := r.db.Master().
+		NewInsert().
+		Model(&model).
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return chargeModel{}, err
+	}
+
+	return model, nil
+}
+
+func (r repository) GetByID(ctx context.Context, id uuid.UUID) (chargeModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var model chargeModel
+	err := r.db.Master().
+		NewSelect().
+		Model(&model).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return chargeModel{}, err
+	}
+
+	return model, nil
+}
+
+// MarkPaid records the transaction that paid the charge, failing with errChargeNotPending when it
+// was already marked.
+func (r repository) MarkPaid(ctx context.Context, id, payerAccountID, transactionID uuid.UUID) (chargeModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var model chargeModel
+	res, err := r.db.Master().
+		NewUpdate().
+		Model(&model).
+		Set("status = ?", PaidStatus).
+		Set("payer_account_id = ?", payerAccountID).
+		Set("transaction_id = ?", transactionID).
+		Set("paid_at = ?", time.Now().UTC()).
+		Where("id = ?", id).
+		Where("status = ?", PendingStatus).
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return chargeModel{}, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		span.RecordError(err)
+		return chargeModel{}, err
+	}
+
+	if rows == 0 {
+		span.RecordError(errChargeNotPending)
+		return chargeModel{}, errChargeNotPending
+	}
+
+	return model, nil
+}
+
+// GetPaymentTransaction returns the transaction made with the idempotency key of a charge payment,
+// or sql.ErrNoRows when the charge was not paid.
+func (r repository) GetPaymentTransaction(ctx context.Context, idempotencyKey string) (paymentTransactionModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var model paymentTransactionModel
+	err := r.db.Master().
+		NewSelect().
+		TableExpr("transactions").
+		Column("id", "from_account_id").
+		Where("idempotency_key = ?", idempotencyKey).
+		Scan(ctx, &model)
+	if err != nil {
+		span.RecordError(err)
+		return paymentTransactionModel{}, err
+	}
+
+	return model, nil
+}
@@ -0,0 +1,118 @@
+//go:build integration
+
+package charges
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/holders"
+	"github.com/dalmarcogd/dock-test/internal/products"
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/testingcontainers"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+
+	url, closeFunc, err := testingcontainers.NewPostgresContainer()
+	assert.NoError(t, err)
+	defer closeFunc(ctx) //nolint:errcheck
+
+	_, callerPath, _, _ := runtime.Caller(0) //nolint:dogsled
+	err = testingcontainers.RunMigrateDatabase(
+		url,
+		fmt.Sprintf("file://%s/../../migrations/", filepath.Dir(callerPath)),
+	)
+	assert.NoError(t, err)
+
+	db, err := database.New(tracer.NewNoop(), url, url)
+	assert.NoError(t, err)
+
+	holdersRepo := holders.NewRepository(tracer.NewNoop(), db)
+	holderModel, err := holdersRepo.Create(ctx, holders.HolderModel{
+		ID:             uuid.New(),
+		Name:           gofakeit.Name(),
+		DocumentNumber: gofakeit.SSN(),
+	})
+	assert.NoError(t, err)
+
+	accSvc := accounts.NewService(tracer.NewNoop(), accounts.NewRepository(tracer.NewNoop(), db), holdersRepo, false, 0)
+	newAccount := func() accounts.Account {
+		account, err := accSvc.Create(ctx, accounts.Account{
+			Name:           gofakeit.Name(),
+			DocumentNumber: holderModel.DocumentNumber,
+			Type:           products.CheckingType,
+		})
+		assert.NoError(t, err)
+		return account
+	}
+	merchantAccount := newAccount()
+	payerAccount := newAccount()
+
+	repo := NewRepository(tracer.NewNoop(), db)
+
+	t.Run("charge paid once", func(t *testing.T) {
+		model, err := repo.Create(ctx, chargeModel{
+			ID:          uuid.New(),
+			AccountID:   merchantAccount.ID,
+			Amount:      42.5,
+			Currency:    "BRL",
+			Description: gofakeit.BeerName(),
+			ExpiresAt:   time.Now().UTC().Add(time.Hour),
+			Status:      PendingStatus,
+			Payload:     gofakeit.UUID(),
+		})
+		assert.NoError(t, err)
+
+		_, err = repo.GetPaymentTransaction(ctx, paymentKey(model.ID))
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		transactionID := uuid.New()
+		_, err = db.Master().ExecContext(
+			ctx,
+			"INSERT INTO transactions (id, from_account_id, to_account_id, type, amount, description, idempotency_key, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+			transactionID,
+			payerAccount.ID,
+			merchantAccount.ID,
+			"P2P",
+			42.5,
+			model.Description,
+			paymentKey(model.ID),
+			time.Now().UTC(),
+		)
+		assert.NoError(t, err)
+
+		transaction, err := repo.GetPaymentTransaction(ctx, paymentKey(model.ID))
+		assert.NoError(t, err)
+		assert.Equal(t, paymentTransactionModel{ID: transactionID, FromAccountID: payerAccount.ID}, transaction)
+
+		paid, err := repo.MarkPaid(ctx, model.ID, payerAccount.ID, transactionID)
+		assert.NoError(t, err)
+		assert.Equal(t, PaidStatus, paid.Status)
+		assert.Equal(t, transactionID, paid.TransactionID.UUID)
+		assert.False(t, paid.PaidAt.IsZero())
+
+		_, err = repo.MarkPaid(ctx, model.ID, payerAccount.ID, transactionID)
+		assert.ErrorIs(t, err, errChargeNotPending)
+
+		found, err := repo.GetByID(ctx, model.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, payerAccount.ID, found.PayerAccountID.UUID)
+	})
+
+	t.Run("charge not found", func(t *testing.T) {
+		_, err := repo.GetByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
@@ -0,0 +1,238 @@
+package charges
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/holders"
+	"github.com/dalmarcogd/dock-test/internal/transactions"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultChargeTTL = 24 * time.Hour
+	maxChargeTTL     = 30 * 24 * time.Hour
+	// defaultMerchantCity is displayed for merchants without an address.
+	defaultMerchantCity = "BRASIL"
+)
+
+var (
+	ErrChargeNotFound             = errors.New("no charge found with this id")
+	ErrInvalidChargeAmount        = errors.New("the charge amount must be greater than zero")
+	ErrInvalidChargeExpiry        = errors.New("the charge must expire in the future and within 30 days")
+	ErrChargeAccountNotAllowed    = errors.New("charges can only be issued to active accounts that are not pockets")
+	ErrChargeCurrencyNotSupported = errors.New("charges can not be issued in the account currency")
+	ErrChargeAlreadyPaid          = errors.New("the charge was already paid")
+	ErrChargeExpired              = errors.New("the charge expired")
+)
+
+type Service interface {
+	Create(ctx context.Context, charge Charge) (Charge, error)
+	GetByID(ctx context.Context, id uuid.UUID) (Charge, error)
+	Pay(ctx context.Context, payment Payment) (Charge, error)
+}
+
+type service struct {
+	tracer          tracer.Tracer
+	repository      Repository
+	accountsSvc     accounts.Service
+	holdersSvc      holders.Service
+	transactionsSvc transactions.Service
+}
+
+func NewService(
+	t tracer.Tracer,
+	r Repository,
+	as accounts.Service,
+	hs holders.Service,
+	ts transactions.Service,
+) Service {
+	return service{
+		tracer:          t,
+		repository:      r,
+		accountsSvc:     as,
+		holdersSvc:      hs,
+		transactionsSvc: ts,
+	}
+}
+
+// Create issues a charge to the account in its currency, encoding it as an EMV QR code payload
+// with the account owner as the merchant. Charges without an expiry expire in defaultChargeTTL.
+func (s service) Create(ctx context.Context, charge Charge) (Charge, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if charge.Amount <= 0 {
+		span.RecordError(ErrInvalidChargeAmount)
+		return Charge{}, ErrInvalidChargeAmount
+	}
+
+	now := time.Now().UTC()
+	if charge.ExpiresAt.IsZero() {
+		charge.ExpiresAt = now.Add(defaultChargeTTL)
+	}
+
+	if !charge.ExpiresAt.After(now) || charge.ExpiresAt.After(now.Add(maxChargeTTL)) {
+		span.RecordError(ErrInvalidChargeExpiry)
+		return Charge{}, ErrInvalidChargeExpiry
+	}
+
+	account, err := s.accountsSvc.GetByID(ctx, charge.AccountID)
+	if err != nil {
+		span.RecordError(err)
+		return Charge{}, err
+	}
+
+	if account.Status != accounts.ActiveStatus || account.IsPocket() {
+		span.RecordError(ErrChargeAccountNotAllowed)
+		return Charge{}, ErrChargeAccountNotAllowed
+	}
+
+	holder, err := s.holdersSvc.GetByID(ctx, account.HolderID)
+	if err != nil {
+		zapctx.L(ctx).Error("charge_service_get_holder_error", zap.Error(err))
+		span.RecordError(err)
+		return Charge{}, err
+	}
+
+	m := merchant{Name: holder.Name, City: defaultMerchantCity}
+	if len(holder.Addresses) > 0 && holder.Addresses[0].City != "" {
+		m.City = holder.Addresses[0].City
+	}
+
+	charge.ID = uuid.New()
+	charge.Currency = account.Currency
+	charge.Status = PendingStatus
+	charge.CreatedAt = now
+	charge.ExpiresAt = charge.ExpiresAt.UTC()
+
+	charge.Payload, err = encodePayload(charge, m)
+	if err != nil {
+		span.RecordError(err)
+		return Charge{}, err
+	}
+
+	model, err := s.repository.Create(ctx, newChargeModel(charge))
+	if err != nil {
+		zapctx.L(ctx).Error("charge_service_create_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return Charge{}, err
+	}
+
+	zapctx.L(ctx).Info(
+		"charge_created",
+		zap.String("charge_id", model.ID.String()),
+		zap.String("account_id", model.AccountID.String()),
+		zap.Float64("amount", model.Amount),
+	)
+
+	return newCharge(model), nil
+}
+
+func (s service) GetByID(ctx context.Context, id uuid.UUID) (Charge, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	model, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return Charge{}, ErrChargeNotFound
+		}
+		zapctx.L(ctx).Error("charge_service_get_repository_error", zap.Error(err))
+		return Charge{}, err
+	}
+
+	return newCharge(model), nil
+}
+
+// Pay settles the charge with a P2P transaction from the payer account in the charge currency. The
+// transaction carries an idempotency key of the charge, so concurrent payments make a single one.
+func (s service) Pay(ctx context.Context, payment Payment) (Charge, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	charge, err := s.GetByID(ctx, payment.ChargeID)
+	if err != nil {
+		span.RecordError(err)
+		return Charge{}, err
+	}
+
+	switch charge.Status {
+	case PaidStatus:
+		span.RecordError(ErrChargeAlreadyPaid)
+		return Charge{}, ErrChargeAlreadyPaid
+	case ExpiredStatus:
+		span.RecordError(ErrChargeExpired)
+		return Charge{}, ErrChargeExpired
+	}
+
+	description := charge.Description
+	if description == "" {
+		description = fmt.Sprintf("charge %s", charge.ID)
+	}
+
+	transaction, err := s.transactionsSvc.CreateP2P(ctx, transactions.Transaction{
+		From:           payment.PayerAccountID,
+		To:             charge.AccountID,
+		Amount:         charge.Amount,
+		Currency:       charge.Currency,
+		Description:    description,
+		RequestedBy:    payment.RequestedBy,
+		IdempotencyKey: paymentKey(charge.ID),
+	})
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, transactions.ErrTransactionAlreadyMade) {
+			s.settle(ctx, charge.ID)
+			return Charge{}, ErrChargeAlreadyPaid
+		}
+		return Charge{}, err
+	}
+
+	model, err := s.repository.MarkPaid(ctx, charge.ID, payment.PayerAccountID, transaction.ID)
+	if err != nil {
+		zapctx.L(ctx).Error(
+			"charge_service_mark_paid_repository_error",
+			zap.String("charge_id", charge.ID.String()),
+			zap.String("transaction_id", transaction.ID.String()),
+			zap.Error(err),
+		)
+		span.RecordError(err)
+		return Charge{}, err
+	}
+
+	zapctx.L(ctx).Info(
+		"charge_paid",
+		zap.String("charge_id", model.ID.String()),
+		zap.String("transaction_id", transaction.ID.String()),
+	)
+
+	return newCharge(model), nil
+}
+
+// settle marks the charge paid by the transaction already made for it, in case the payment that
+// made it could not mark the charge.
+func (s service) settle(ctx context.Context, id uuid.UUID) {
+	transaction, err := s.repository.GetPaymentTransaction(ctx, paymentKey(id))
+	if err != nil {
+		zapctx.L(ctx).Error("charge_service_get_payment_transaction_error", zap.Error(err))
+		return
+	}
+
+	_, err = s.repository.MarkPaid(ctx, id, transaction.FromAccountID, transaction.ID)
+	if err != nil && !errors.Is(err, errChargeNotPending) {
+		zapctx.L(ctx).Error("charge_service_settle_mark_paid_error", zap.Error(err))
+	}
+}
+
+func paymentKey(id uuid.UUID) string {
+	return "charge:" + id.String()
+}
@@ -0,0 +1,229 @@
+//go:build unit
+
+package charges
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/holders"
+	"github.com/dalmarcogd/dock-test/internal/transactions"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_Create(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	accountsMock := accounts.NewMockService(ctrl)
+	holdersMock := holders.NewMockService(ctrl)
+	svc := NewService(tracer.NewNoop(), repoMock, accountsMock, holdersMock, transactions.NewMockService(ctrl))
+
+	account := accounts.Account{
+		ID:       uuid.New(),
+		HolderID: uuid.New(),
+		Currency: "BRL",
+		Status:   accounts.ActiveStatus,
+	}
+
+	t.Run("fail create, invalid amount", func(t *testing.T) {
+		charge, err := svc.Create(ctx, Charge{AccountID: account.ID, Amount: 0})
+		assert.ErrorIs(t, err, ErrInvalidChargeAmount)
+		assert.Empty(t, charge)
+	})
+
+	t.Run("fail create, expiry in the past", func(t *testing.T) {
+		charge, err := svc.Create(ctx, Charge{AccountID: account.ID, Amount: 10, ExpiresAt: time.Now().Add(-time.Minute)})
+		assert.ErrorIs(t, err, ErrInvalidChargeExpiry)
+		assert.Empty(t, charge)
+	})
+
+	t.Run("fail create, expiry too far", func(t *testing.T) {
+		charge, err := svc.Create(ctx, Charge{AccountID: account.ID, Amount: 10, ExpiresAt: time.Now().AddDate(0, 2, 0)})
+		assert.ErrorIs(t, err, ErrInvalidChargeExpiry)
+		assert.Empty(t, charge)
+	})
+
+	t.Run("fail create, blocked account", func(t *testing.T) {
+		blocked := account
+		blocked.Status = accounts.BlockedStatus
+		accountsMock.EXPECT().GetByID(ctx, account.ID).Return(blocked, nil)
+
+		charge, err := svc.Create(ctx, Charge{AccountID: account.ID, Amount: 10})
+		assert.ErrorIs(t, err, ErrChargeAccountNotAllowed)
+		assert.Empty(t, charge)
+	})
+
+	t.Run("fail create, currency without numeric code", func(t *testing.T) {
+		yen := account
+		yen.Currency = "JPY"
+		accountsMock.EXPECT().GetByID(ctx, account.ID).Return(yen, nil)
+		holdersMock.EXPECT().GetByID(ctx, account.HolderID).Return(holders.Holder{Name: "Maria"}, nil)
+
+		charge, err := svc.Create(ctx, Charge{AccountID: account.ID, Amount: 10})
+		assert.ErrorIs(t, err, ErrChargeCurrencyNotSupported)
+		assert.Empty(t, charge)
+	})
+
+	t.Run("success create", func(t *testing.T) {
+		accountsMock.EXPECT().GetByID(ctx, account.ID).Return(account, nil)
+		holdersMock.EXPECT().
+			GetByID(ctx, account.HolderID).
+			Return(holders.Holder{Name: "Maria Silva", Addresses: []holders.Address{{City: "Recife"}}}, nil)
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, model chargeModel) (chargeModel, error) {
+				assert.NotEqual(t, uuid.Nil, model.ID)
+				assert.Equal(t, PendingStatus, model.Status)
+				assert.Equal(t, account.Currency, model.Currency)
+				assert.WithinDuration(t, time.Now().Add(defaultChargeTTL), model.ExpiresAt, time.Minute)
+				assert.Contains(t, model.Payload, model.ID.String())
+				assert.Contains(t, model.Payload, "5911MARIA SILVA6006RECIFE")
+				return model, nil
+			})
+
+		charge, err := svc.Create(ctx, Charge{AccountID: account.ID, Amount: 99.9, Description: "order 42"})
+		assert.NoError(t, err)
+		assert.Equal(t, PendingStatus, charge.Status)
+		assert.True(t, strings.HasPrefix(charge.Payload, "000201010212"))
+
+		png, err := charge.QRCode(128)
+		assert.NoError(t, err)
+		assert.Equal(t, "\x89PNG", string(png[:4]))
+	})
+}
+
+func TestService_Pay(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	transactionsMock := transactions.NewMockService(ctrl)
+	svc := NewService(
+		tracer.NewNoop(),
+		repoMock,
+		accounts.NewMockService(ctrl),
+		holders.NewMockService(ctrl),
+		transactionsMock,
+	)
+
+	payerID := uuid.New()
+	pending := chargeModel{
+		ID:          uuid.New(),
+		AccountID:   uuid.New(),
+		Amount:      25,
+		Currency:    "BRL",
+		Description: "order 42",
+		Status:      PendingStatus,
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+	payment := Payment{ChargeID: pending.ID, PayerAccountID: payerID, RequestedBy: "52998224725"}
+
+	t.Run("fail pay, charge not found", func(t *testing.T) {
+		repoMock.EXPECT().GetByID(ctx, pending.ID).Return(chargeModel{}, sql.ErrNoRows)
+
+		charge, err := svc.Pay(ctx, payment)
+		assert.ErrorIs(t, err, ErrChargeNotFound)
+		assert.Empty(t, charge)
+	})
+
+	t.Run("fail pay, charge already paid", func(t *testing.T) {
+		paid := pending
+		paid.Status = PaidStatus
+		repoMock.EXPECT().GetByID(ctx, pending.ID).Return(paid, nil)
+
+		charge, err := svc.Pay(ctx, payment)
+		assert.ErrorIs(t, err, ErrChargeAlreadyPaid)
+		assert.Empty(t, charge)
+	})
+
+	t.Run("fail pay, charge expired", func(t *testing.T) {
+		expired := pending
+		expired.ExpiresAt = time.Now().Add(-time.Second)
+		repoMock.EXPECT().GetByID(ctx, pending.ID).Return(expired, nil)
+
+		charge, err := svc.Pay(ctx, payment)
+		assert.ErrorIs(t, err, ErrChargeExpired)
+		assert.Empty(t, charge)
+	})
+
+	t.Run("fail pay, insufficient funds", func(t *testing.T) {
+		repoMock.EXPECT().GetByID(ctx, pending.ID).Return(pending, nil)
+		transactionsMock.EXPECT().
+			CreateP2P(ctx, gomock.Any()).
+			Return(transactions.Transaction{}, transactions.ErrBalanceInsufficientFunds)
+
+		charge, err := svc.Pay(ctx, payment)
+		assert.ErrorIs(t, err, transactions.ErrBalanceInsufficientFunds)
+		assert.Empty(t, charge)
+	})
+
+	t.Run("fail pay, paid concurrently settles the charge", func(t *testing.T) {
+		transactionID := uuid.New()
+		otherPayerID := uuid.New()
+		repoMock.EXPECT().GetByID(ctx, pending.ID).Return(pending, nil)
+		transactionsMock.EXPECT().
+			CreateP2P(ctx, gomock.Any()).
+			Return(transactions.Transaction{}, transactions.ErrTransactionAlreadyMade)
+		repoMock.EXPECT().
+			GetPaymentTransaction(ctx, "charge:"+pending.ID.String()).
+			Return(paymentTransactionModel{ID: transactionID, FromAccountID: otherPayerID}, nil)
+		repoMock.EXPECT().
+			MarkPaid(ctx, pending.ID, otherPayerID, transactionID).
+			Return(chargeModel{}, errChargeNotPending)
+
+		charge, err := svc.Pay(ctx, payment)
+		assert.ErrorIs(t, err, ErrChargeAlreadyPaid)
+		assert.Empty(t, charge)
+	})
+
+	t.Run("fail pay, marking the charge", func(t *testing.T) {
+		transactionID := uuid.New()
+		repoMock.EXPECT().GetByID(ctx, pending.ID).Return(pending, nil)
+		transactionsMock.EXPECT().CreateP2P(ctx, gomock.Any()).Return(transactions.Transaction{ID: transactionID}, nil)
+		repoMock.EXPECT().MarkPaid(ctx, pending.ID, payerID, transactionID).Return(chargeModel{}, errors.New("timeout"))
+
+		charge, err := svc.Pay(ctx, payment)
+		assert.Error(t, err)
+		assert.Empty(t, charge)
+	})
+
+	t.Run("success pay", func(t *testing.T) {
+		transactionID := uuid.New()
+		paid := pending
+		paid.Status = PaidStatus
+		paid.PayerAccountID = uuid.NullUUID{UUID: payerID, Valid: true}
+		paid.TransactionID = uuid.NullUUID{UUID: transactionID, Valid: true}
+		paid.PaidAt = time.Now()
+
+		repoMock.EXPECT().GetByID(ctx, pending.ID).Return(pending, nil)
+		transactionsMock.EXPECT().
+			CreateP2P(ctx, transactions.Transaction{
+				From:           payerID,
+				To:             pending.AccountID,
+				Amount:         25,
+				Currency:       "BRL",
+				Description:    "order 42",
+				RequestedBy:    "52998224725",
+				IdempotencyKey: "charge:" + pending.ID.String(),
+			}).
+			Return(transactions.Transaction{ID: transactionID}, nil)
+		repoMock.EXPECT().MarkPaid(ctx, pending.ID, payerID, transactionID).Return(paid, nil)
+
+		charge, err := svc.Pay(ctx, payment)
+		assert.NoError(t, err)
+		assert.Equal(t, PaidStatus, charge.Status)
+		assert.Equal(t, transactionID, charge.TransactionID.UUID)
+	})
+}
@@ -0,0 +1,25 @@
+package exchange
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type rateModel struct {
+	bun.BaseModel `bun:"table:fx_rates"`
+
+	BaseCurrency  Currency  `bun:"base_currency,pk"`
+	QuoteCurrency Currency  `bun:"quote_currency,pk"`
+	Rate          float64   `bun:"rate"`
+	UpdatedAt     time.Time `bun:"updated_at,notnull"`
+}
+
+func newRate(model rateModel) Rate {
+	return Rate{
+		Base:      model.BaseCurrency,
+		Quote:     model.QuoteCurrency,
+		Rate:      model.Rate,
+		UpdatedAt: model.UpdatedAt,
+	}
+}
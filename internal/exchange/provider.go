@@ -0,0 +1,110 @@
+package exchange
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+)
+
+// ErrRateNotFound is returned by providers that do not quote the pair of currencies.
+var ErrRateNotFound = errors.New("no exchange rate found for these currencies")
+
+// RateProvider quotes the exchange rate between two currencies. Providers only need to quote each
+// pair in one direction, the service inverts the rate when asked for the other.
+type RateProvider interface {
+	GetRate(ctx context.Context, base, quote Currency) (Rate, error)
+}
+
+type databaseProvider struct {
+	tracer tracer.Tracer
+	db     database.Database
+}
+
+// NewDatabaseProvider quotes the rates kept in the fx_rates table.
+func NewDatabaseProvider(t tracer.Tracer, db database.Database) RateProvider {
+	return databaseProvider{
+		tracer: t,
+		db:     db,
+	}
+}
+
+func (p databaseProvider) GetRate(ctx context.Context, base, quote Currency) (Rate, error) {
+	ctx, span := p.tracer.Span(ctx)
+	defer span.End()
+
+	var model rateModel
+	err := p.db.Replica().
+		NewSelect().
+		Model(&model).
+		Where("base_currency = ?", base).
+		Where("quote_currency = ?", quote).
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return Rate{}, ErrRateNotFound
+		}
+		return Rate{}, err
+	}
+
+	return newRate(model), nil
+}
+
+type fileRate struct {
+	Base  Currency `json:"base"`
+	Quote Currency `json:"quote"`
+	Rate  float64  `json:"rate"`
+}
+
+type fileProvider struct {
+	rates map[[2]Currency]Rate
+}
+
+// NewFileProvider quotes the rates of a JSON file, a list of objects with base, quote and rate, read
+// once when the provider is built.
+func NewFileProvider(path string) (RateProvider, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var frs []fileRate
+	if err := json.Unmarshal(content, &frs); err != nil {
+		return nil, fmt.Errorf("invalid exchange rates file %s: %w", path, err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make(map[[2]Currency]Rate, len(frs))
+	for _, fr := range frs {
+		if !fr.Base.Valid() || !fr.Quote.Valid() || fr.Rate <= 0 {
+			return nil, fmt.Errorf("invalid exchange rate %s/%s in %s", fr.Base, fr.Quote, path)
+		}
+		rates[[2]Currency{fr.Base, fr.Quote}] = Rate{
+			Base:      fr.Base,
+			Quote:     fr.Quote,
+			Rate:      fr.Rate,
+			UpdatedAt: info.ModTime().UTC(),
+		}
+	}
+
+	return fileProvider{rates: rates}, nil
+}
+
+func (p fileProvider) GetRate(_ context.Context, base, quote Currency) (Rate, error) {
+	rate, ok := p.rates[[2]Currency{base, quote}]
+	if !ok {
+		return Rate{}, ErrRateNotFound
+	}
+
+	return rate, nil
+}
@@ -0,0 +1,38 @@
+package exchange
+
+import (
+	"math"
+	"time"
+)
+
+// Rate is how many units of Quote one unit of Base buys.
+type Rate struct {
+	Base      Currency
+	Quote     Currency
+	Rate      float64
+	UpdatedAt time.Time
+}
+
+// inverse is the rate from Quote back to Base.
+func (r Rate) inverse() Rate {
+	return Rate{
+		Base:      r.Quote,
+		Quote:     r.Base,
+		Rate:      1 / r.Rate,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+// Conversion is an amount converted from one currency into another at the applied rate.
+type Conversion struct {
+	From            Currency
+	To              Currency
+	Rate            float64
+	Amount          float64
+	ConvertedAmount float64
+}
+
+// roundCents rounds the amount to two decimal places.
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,106 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrInvalidCurrency = errors.New("the currency is not a valid ISO 4217 code")
+	ErrInvalidAmount   = errors.New("the amount to convert must be greater than zero")
+	ErrInvalidRate     = errors.New("the exchange rate must be greater than zero")
+)
+
+type Service interface {
+	Convert(ctx context.Context, amount float64, from, to Currency) (Conversion, error)
+}
+
+type service struct {
+	tracer   tracer.Tracer
+	provider RateProvider
+}
+
+func NewService(t tracer.Tracer, p RateProvider) Service {
+	return service{
+		tracer:   t,
+		provider: p,
+	}
+}
+
+// Convert converts the amount into the currency to, rounding the converted amount to cents. Amounts
+// in the same currency are kept at a rate of one.
+func (s service) Convert(ctx context.Context, amount float64, from, to Currency) (Conversion, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if !from.Valid() || !to.Valid() {
+		span.RecordError(ErrInvalidCurrency)
+		return Conversion{}, ErrInvalidCurrency
+	}
+
+	if amount <= 0 {
+		span.RecordError(ErrInvalidAmount)
+		return Conversion{}, ErrInvalidAmount
+	}
+
+	if from == to {
+		return Conversion{From: from, To: to, Rate: 1, Amount: amount, ConvertedAmount: amount}, nil
+	}
+
+	rate, err := s.getRate(ctx, from, to)
+	if err != nil {
+		span.RecordError(err)
+		return Conversion{}, err
+	}
+
+	if rate.Rate <= 0 {
+		zapctx.L(ctx).Error(
+			"exchange_service_invalid_rate_error",
+			zap.String("base", string(rate.Base)),
+			zap.String("quote", string(rate.Quote)),
+			zap.Float64("rate", rate.Rate),
+			zap.Error(ErrInvalidRate),
+		)
+		span.RecordError(ErrInvalidRate)
+		return Conversion{}, ErrInvalidRate
+	}
+
+	return Conversion{
+		From:            from,
+		To:              to,
+		Rate:            rate.Rate,
+		Amount:          amount,
+		ConvertedAmount: roundCents(amount * rate.Rate),
+	}, nil
+}
+
+// getRate quotes the pair, falling back to the inverse of the rate quoted the other way around.
+func (s service) getRate(ctx context.Context, from, to Currency) (Rate, error) {
+	rate, err := s.provider.GetRate(ctx, from, to)
+	if err == nil {
+		return rate, nil
+	}
+
+	if !errors.Is(err, ErrRateNotFound) {
+		zapctx.L(ctx).Error("exchange_service_rate_provider_error", zap.Error(err))
+		return Rate{}, err
+	}
+
+	rate, err = s.provider.GetRate(ctx, to, from)
+	if err != nil {
+		if !errors.Is(err, ErrRateNotFound) {
+			zapctx.L(ctx).Error("exchange_service_rate_provider_error", zap.Error(err))
+		}
+		return Rate{}, err
+	}
+
+	if rate.Rate <= 0 {
+		return rate, nil
+	}
+
+	return rate.inverse(), nil
+}
@@ -0,0 +1,112 @@
+//go:build unit
+
+package exchange
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_Convert(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	providerMock := NewMockRateProvider(ctrl)
+	svc := NewService(tracer.NewNoop(), providerMock)
+
+	t.Run("fail convert, invalid currency", func(t *testing.T) {
+		conversion, err := svc.Convert(ctx, 10, "USD", "XYZ")
+		assert.ErrorIs(t, err, ErrInvalidCurrency)
+		assert.Empty(t, conversion)
+	})
+
+	t.Run("fail convert, invalid amount", func(t *testing.T) {
+		conversion, err := svc.Convert(ctx, 0, "USD", "BRL")
+		assert.ErrorIs(t, err, ErrInvalidAmount)
+		assert.Empty(t, conversion)
+	})
+
+	t.Run("fail convert, rate not found", func(t *testing.T) {
+		providerMock.EXPECT().GetRate(ctx, Currency("JPY"), Currency("BRL")).Return(Rate{}, ErrRateNotFound)
+		providerMock.EXPECT().GetRate(ctx, Currency("BRL"), Currency("JPY")).Return(Rate{}, ErrRateNotFound)
+
+		conversion, err := svc.Convert(ctx, 10, "JPY", "BRL")
+		assert.ErrorIs(t, err, ErrRateNotFound)
+		assert.Empty(t, conversion)
+	})
+
+	t.Run("fail convert, provider error", func(t *testing.T) {
+		providerErr := errors.New("provider unavailable")
+		providerMock.EXPECT().GetRate(ctx, Currency("USD"), Currency("BRL")).Return(Rate{}, providerErr)
+
+		conversion, err := svc.Convert(ctx, 10, "USD", "BRL")
+		assert.ErrorIs(t, err, providerErr)
+		assert.Empty(t, conversion)
+	})
+
+	t.Run("success convert, same currency", func(t *testing.T) {
+		conversion, err := svc.Convert(ctx, 10, "BRL", "BRL")
+		assert.NoError(t, err)
+		assert.Equal(t, Conversion{From: "BRL", To: "BRL", Rate: 1, Amount: 10, ConvertedAmount: 10}, conversion)
+	})
+
+	t.Run("success convert, quoted rate", func(t *testing.T) {
+		providerMock.EXPECT().
+			GetRate(ctx, Currency("USD"), Currency("BRL")).
+			Return(Rate{Base: "USD", Quote: "BRL", Rate: 5.0123}, nil)
+
+		conversion, err := svc.Convert(ctx, 10.5, "USD", "BRL")
+		assert.NoError(t, err)
+		assert.Equal(t, 5.0123, conversion.Rate)
+		assert.Equal(t, 52.63, conversion.ConvertedAmount)
+	})
+
+	t.Run("success convert, inverse rate", func(t *testing.T) {
+		providerMock.EXPECT().GetRate(ctx, Currency("BRL"), Currency("USD")).Return(Rate{}, ErrRateNotFound)
+		providerMock.EXPECT().
+			GetRate(ctx, Currency("USD"), Currency("BRL")).
+			Return(Rate{Base: "USD", Quote: "BRL", Rate: 5}, nil)
+
+		conversion, err := svc.Convert(ctx, 100, "BRL", "USD")
+		assert.NoError(t, err)
+		assert.Equal(t, 0.2, conversion.Rate)
+		assert.Equal(t, float64(20), conversion.ConvertedAmount)
+	})
+}
+
+func TestFileProvider(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	t.Run("fail build, invalid rate", func(t *testing.T) {
+		path := filepath.Join(dir, "invalid.json")
+		assert.NoError(t, os.WriteFile(path, []byte(`[{"base":"USD","quote":"BRL","rate":0}]`), 0o600))
+
+		provider, err := NewFileProvider(path)
+		assert.Error(t, err)
+		assert.Nil(t, provider)
+	})
+
+	t.Run("success get rate", func(t *testing.T) {
+		path := filepath.Join(dir, "rates.json")
+		assert.NoError(t, os.WriteFile(path, []byte(`[{"base":"USD","quote":"BRL","rate":5.2}]`), 0o600))
+
+		provider, err := NewFileProvider(path)
+		assert.NoError(t, err)
+
+		rate, err := provider.GetRate(ctx, "USD", "BRL")
+		assert.NoError(t, err)
+		assert.Equal(t, 5.2, rate.Rate)
+
+		_, err = provider.GetRate(ctx, "BRL", "USD")
+		assert.ErrorIs(t, err, ErrRateNotFound)
+	})
+}
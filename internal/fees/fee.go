@@ -0,0 +1,69 @@
+package fees
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/products"
+	"github.com/google/uuid"
+)
+
+// TransactionTypes are the transaction types fee schedules may charge for.
+var TransactionTypes = []string{"DEBIT", "P2P", "TED"}
+
+// Schedule is a fee tier applied to transactions of a type made from accounts of a product. The
+// schedule with the highest MinAmount not above the transaction amount applies.
+type Schedule struct {
+	ID              uuid.UUID
+	TransactionType string
+	ProductType     products.Type
+	MinAmount       float64
+	FixedAmount     float64
+	// Percentage is a fraction of the transaction amount, 0.01 means 1%.
+	Percentage float64
+	// FreePerMonth is how many transactions of the type the account makes free of charge each month.
+	FreePerMonth int
+	CreatedAt    time.Time
+}
+
+func newSchedule(model scheduleModel) Schedule {
+	return Schedule{
+		ID:              model.ID,
+		TransactionType: model.TransactionType,
+		ProductType:     model.ProductType,
+		MinAmount:       model.MinAmount,
+		FixedAmount:     model.FixedAmount,
+		Percentage:      model.Percentage,
+		FreePerMonth:    model.FreePerMonth,
+		CreatedAt:       model.CreatedAt,
+	}
+}
+
+// Amount is the fee charged over a transaction amount, rounded to cents.
+func (s Schedule) Amount(amount float64) float64 {
+	return math.Round((s.FixedAmount+amount*s.Percentage)*100) / 100
+}
+
+// Quote describes the transaction a fee is evaluated for.
+type Quote struct {
+	AccountID       uuid.UUID
+	TransactionType string
+	ProductType     products.Type
+	Amount          float64
+}
+
+// Fee is an amount debited from AccountID and credited to RevenueAccountID.
+type Fee struct {
+	AccountID        uuid.UUID
+	RevenueAccountID uuid.UUID
+	Amount           float64
+	Description      string
+	// IdempotencyKey is set for fees charged once per period, so they are never charged twice.
+	IdempotencyKey string
+}
+
+// maintenanceKey identifies the maintenance fee of an account in a month.
+func maintenanceKey(accountID uuid.UUID, month time.Time) string {
+	return fmt.Sprintf("maintenance-fee:%s:%s", accountID, month.Format("2006-01"))
+}
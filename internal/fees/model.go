@@ -0,0 +1,41 @@
+package fees
+
+import (
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/products"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type scheduleModel struct {
+	bun.BaseModel `bun:"table:fee_schedules"`
+
+	ID              uuid.UUID     `bun:"id,pk"`
+	TransactionType string        `bun:"transaction_type"`
+	ProductType     products.Type `bun:"product_type"`
+	MinAmount       float64       `bun:"min_amount"`
+	FixedAmount     float64       `bun:"fixed_amount"`
+	Percentage      float64       `bun:"percentage"`
+	FreePerMonth    int           `bun:"free_per_month"`
+	CreatedAt       time.Time     `bun:"created_at,notnull"`
+}
+
+func newScheduleModel(schedule Schedule) scheduleModel {
+	return scheduleModel{
+		ID:              schedule.ID,
+		TransactionType: schedule.TransactionType,
+		ProductType:     schedule.ProductType,
+		MinAmount:       schedule.MinAmount,
+		FixedAmount:     schedule.FixedAmount,
+		Percentage:      schedule.Percentage,
+		FreePerMonth:    schedule.FreePerMonth,
+		CreatedAt:       schedule.CreatedAt,
+	}
+}
+
+// maintenanceDueModel is an open account whose product charges a monthly fee not charged yet.
+type maintenanceDueModel struct {
+	AccountID  uuid.UUID `bun:"account_id"`
+	MonthlyFee float64   `bun:"monthly_fee"`
+}
@@ -0,0 +1,208 @@
+package fees
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/products"
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+const feeSchedulesTierConstraint = "fee_schedules_tier"
+
+var errDuplicatedScheduleTier = errors.New("a schedule with this minimum amount already exists")
+
+type Repository interface {
+	CreateSchedule(ctx context.Context, model scheduleModel) (scheduleModel, error)
+	DeleteSchedule(ctx context.Context, id uuid.UUID) (bool, error)
+	ListSchedules(ctx context.Context) ([]scheduleModel, error)
+	GetTier(
+		ctx context.Context,
+		transactionType string,
+		productType products.Type,
+		amount float64,
+	) (scheduleModel, error)
+	CountTransactionsSince(
+		ctx context.Context,
+		accountID uuid.UUID,
+		transactionType string,
+		since time.Time,
+	) (int, error)
+	ListMaintenanceDue(ctx context.Context, month time.Time) ([]maintenanceDueModel, error)
+}
+
+type repository struct {
+	tracer tracer.Tracer
+	db     database.Database
+}
+
+func NewRepository(t tracer.Tracer, db database.Database) Repository {
+	return repository{
+		tracer: t,
+		db:     db,
+	}
+}
+
+func (r repository) CreateSchedule(ctx context.Context, model scheduleModel) (scheduleModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	model.ID = uuid.New()
+	model.CreatedAt = time.Now().UTC()
+
+	_, err := r.db.Master().
+		NewInsert().
+		Model(&model).
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		if isConstraintViolation(err, feeSchedulesTierConstraint) {
+			return scheduleModel{}, errDuplicatedScheduleTier
+		}
+		return scheduleModel{}, err
+	}
+
+	return model, nil
+}
+
+// DeleteSchedule removes the schedule, returning false when it does not exist.
+func (r repository) DeleteSchedule(ctx context.Context, id uuid.UUID) (bool, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	res, err := r.db.Master().
+		NewDelete().
+		Model((*scheduleModel)(nil)).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return false, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		span.RecordError(err)
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func (r repository) ListSchedules(ctx context.Context) ([]scheduleModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var models []scheduleModel
+	err := r.db.Replica().
+		NewSelect().
+		Model(&models).
+		Order("transaction_type ASC", "product_type ASC", "min_amount ASC").
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return models, nil
+}
+
+// GetTier returns the schedule with the highest minimum amount not above the amount, or
+// sql.ErrNoRows when the transaction type is free for the product.
+func (r repository) GetTier(
+	ctx context.Context,
+	transactionType string,
+	productType products.Type,
+	amount float64,
+) (scheduleModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var model scheduleModel
+	err := r.db.Replica().
+		NewSelect().
+		Model(&model).
+		Where("transaction_type = ?", transactionType).
+		Where("product_type = ?", productType).
+		Where("min_amount <= ?", amount).
+		Order("min_amount DESC").
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return scheduleModel{}, err
+	}
+
+	return model, nil
+}
+
+// CountTransactionsSince counts the transactions of the type made from the account since the date.
+func (r repository) CountTransactionsSince(
+	ctx context.Context,
+	accountID uuid.UUID,
+	transactionType string,
+	since time.Time,
+) (int, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	count, err := r.db.Replica().
+		NewSelect().
+		TableExpr("transactions").
+		Where("from_account_id = ?", accountID).
+		Where("type = ?", transactionType).
+		Where("created_at >= ?", since).
+		Count(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// ListMaintenanceDue lists the open accounts, pockets aside, whose product charges a monthly fee
+// not charged for the month yet.
+func (r repository) ListMaintenanceDue(ctx context.Context, month time.Time) ([]maintenanceDueModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var models []maintenanceDueModel
+	err := r.db.Replica().
+		NewSelect().
+		ModelTableExpr("accounts AS a").
+		ColumnExpr("a.id AS account_id, p.monthly_fee").
+		Join("JOIN products AS p ON p.type = a.type").
+		Where("p.monthly_fee > 0").
+		Where("a.status <> ?", accounts.ClosedStatus).
+		Where("a.parent_id IS NULL").
+		Where("a.created_at < ?", month.AddDate(0, 1, 0)).
+		Where(
+			"NOT EXISTS (SELECT 1 FROM transactions AS t WHERE t.idempotency_key = ? || a.id || ?)",
+			"maintenance-fee:",
+			":"+month.Format("2006-01"),
+		).
+		Order("a.id ASC").
+		Scan(ctx, &models)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func isConstraintViolation(err error, constraint string) bool {
+	var pgErr pgdriver.Error
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.IntegrityViolation() && pgErr.Field('n') == constraint
+}
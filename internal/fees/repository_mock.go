@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/fees/repository.go
+
+// Package fees is a generated GoMock package.
+package fees
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	products "github.com/dalmarcogd/dock-test/internal/products"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountTransactionsSince mocks base method.
+func (m *MockRepository) CountTransactionsSince(ctx context.Context, accountID uuid.UUID, transactionType string, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTransactionsSince", ctx, accountID, transactionType, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTransactionsSince indicates an expected call of CountTransactionsSince.
+func (mr *MockRepositoryMockRecorder) CountTransactionsSince(ctx, accountID, transactionType, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTransactionsSince", reflect.TypeOf((*MockRepository)(nil).CountTransactionsSince), ctx, accountID, transactionType, since)
+}
+
+// CreateSchedule mocks base method.
+func (m *MockRepository) CreateSchedule(ctx context.Context, model scheduleModel) (scheduleModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSchedule", ctx, model)
+	ret0, _ := ret[0].(scheduleModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSchedule indicates an expected call of CreateSchedule.
+func (mr *MockRepositoryMockRecorder) CreateSchedule(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSchedule", reflect.TypeOf((*MockRepository)(nil).CreateSchedule), ctx, model)
+}
+
+// DeleteSchedule mocks base method.
+func (m *MockRepository) DeleteSchedule(ctx context.Context, id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSchedule", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSchedule indicates an expected call of DeleteSchedule.
+func (mr *MockRepositoryMockRecorder) DeleteSchedule(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSchedule", reflect.TypeOf((*MockRepository)(nil).DeleteSchedule), ctx, id)
+}
+
+// GetTier mocks base method.
+func (m *MockRepository) GetTier(ctx context.Context, transactionType string, productType products.Type, amount float64) (scheduleModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTier", ctx, transactionType, productType, amount)
+	ret0, _ := ret[0].(scheduleModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTier indicates an expected call of GetTier.
+func (mr *MockRepositoryMockRecorder) GetTier(ctx, transactionType, productType, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTier", reflect.TypeOf((*MockRepository)(nil).GetTier), ctx, transactionType, productType, amount)
+}
+
+// ListMaintenanceDue mocks base method.
+func (m *MockRepository) ListMaintenanceDue(ctx context.Context, month time.Time) ([]maintenanceDueModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMaintenanceDue", ctx, month)
+	ret0, _ := ret[0].([]maintenanceDueModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMaintenanceDue indicates an expected call of ListMaintenanceDue.
+func (mr *MockRepositoryMockRecorder) ListMaintenanceDue(ctx, month interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMaintenanceDue", reflect.TypeOf((*MockRepository)(nil).ListMaintenanceDue), ctx, month)
+}
+
+// ListSchedules mocks base method.
+func (m *MockRepository) ListSchedules(ctx context.Context) ([]scheduleModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSchedules", ctx)
+	ret0, _ := ret[0].([]scheduleModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSchedules indicates an expected call of ListSchedules.
+func (mr *MockRepositoryMockRecorder) ListSchedules(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSchedules", reflect.TypeOf((*MockRepository)(nil).ListSchedules), ctx)
+}
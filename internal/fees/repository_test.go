@@ -0,0 +1,152 @@
+//go:build integration
+
+package fees
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/holders"
+	"github.com/dalmarcogd/dock-test/internal/products"
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/testingcontainers"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+
+	url, closeFunc, err := testingcontainers.NewPostgresContainer()
+	assert.NoError(t, err)
+	defer closeFunc(ctx) //nolint:errcheck
+
+	_, callerPath, _, _ := runtime.Caller(0) //nolint:dogsled
+	err = testingcontainers.RunMigrateDatabase(
+		url,
+		fmt.Sprintf("file://%s/../../migrations/", filepath.Dir(callerPath)),
+	)
+	assert.NoError(t, err)
+
+	db, err := database.New(tracer.NewNoop(), url, url)
+	assert.NoError(t, err)
+
+	holdersRepo := holders.NewRepository(tracer.NewNoop(), db)
+	holderModel, err := holdersRepo.Create(ctx, holders.HolderModel{
+		ID:             uuid.New(),
+		Name:           gofakeit.Name(),
+		DocumentNumber: gofakeit.SSN(),
+	})
+	assert.NoError(t, err)
+
+	accSvc := accounts.NewService(tracer.NewNoop(), accounts.NewRepository(tracer.NewNoop(), db), holdersRepo, false, 0)
+	account, err := accSvc.Create(ctx, accounts.Account{
+		Name:           gofakeit.Name(),
+		DocumentNumber: holderModel.DocumentNumber,
+		Type:           products.BusinessType,
+	})
+	assert.NoError(t, err)
+
+	repo := NewRepository(tracer.NewNoop(), db)
+	thisMonth := month(time.Now())
+
+	t.Run("schedules by tier", func(t *testing.T) {
+		_, err := repo.GetTier(ctx, "P2P", products.BusinessType, 100)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		_, err = repo.CreateSchedule(ctx, scheduleModel{
+			TransactionType: "P2P",
+			ProductType:     products.BusinessType,
+			FixedAmount:     1,
+		})
+		assert.NoError(t, err)
+		high, err := repo.CreateSchedule(ctx, scheduleModel{
+			TransactionType: "P2P",
+			ProductType:     products.BusinessType,
+			MinAmount:       1000,
+			Percentage:      0.01,
+		})
+		assert.NoError(t, err)
+
+		_, err = repo.CreateSchedule(ctx, scheduleModel{
+			TransactionType: "P2P",
+			ProductType:     products.BusinessType,
+			MinAmount:       1000,
+			FixedAmount:     2,
+		})
+		assert.ErrorIs(t, err, errDuplicatedScheduleTier)
+
+		tier, err := repo.GetTier(ctx, "P2P", products.BusinessType, 100)
+		assert.NoError(t, err)
+		assert.Equal(t, 1.0, tier.FixedAmount)
+
+		tier, err = repo.GetTier(ctx, "P2P", products.BusinessType, 1500)
+		assert.NoError(t, err)
+		assert.Equal(t, high.ID, tier.ID)
+
+		schedules, err := repo.ListSchedules(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, schedules, 2)
+
+		deleted, err := repo.DeleteSchedule(ctx, high.ID)
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+		deleted, err = repo.DeleteSchedule(ctx, high.ID)
+		assert.NoError(t, err)
+		assert.False(t, deleted)
+	})
+
+	t.Run("count transactions since", func(t *testing.T) {
+		_, err := db.Master().ExecContext(
+			ctx,
+			"INSERT INTO transactions (id, from_account_id, type, amount, description, created_at) VALUES (?, ?, ?, ?, ?, ?)",
+			uuid.New(),
+			account.ID,
+			"P2P",
+			10,
+			gofakeit.BeerName(),
+			time.Now().UTC(),
+		)
+		assert.NoError(t, err)
+
+		count, err := repo.CountTransactionsSince(ctx, account.ID, "P2P", thisMonth)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		count, err = repo.CountTransactionsSince(ctx, account.ID, "DEBIT", thisMonth)
+		assert.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("maintenance due until charged", func(t *testing.T) {
+		due, err := repo.ListMaintenanceDue(ctx, thisMonth)
+		assert.NoError(t, err)
+		assert.Equal(t, []maintenanceDueModel{{AccountID: account.ID, MonthlyFee: 29.9}}, due)
+
+		_, err = db.Master().ExecContext(
+			ctx,
+			"INSERT INTO transactions (id, from_account_id, to_account_id, type, amount, description, idempotency_key, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+			uuid.New(),
+			account.ID,
+			uuid.MustParse("00000000-0000-0000-0000-000000000003"),
+			"FEE",
+			29.9,
+			gofakeit.BeerName(),
+			maintenanceKey(account.ID, thisMonth),
+			time.Now().UTC(),
+		)
+		assert.NoError(t, err)
+
+		due, err = repo.ListMaintenanceDue(ctx, thisMonth)
+		assert.NoError(t, err)
+		assert.Empty(t, due)
+	})
+}
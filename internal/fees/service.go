@@ -0,0 +1,232 @@
+package fees
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrScheduleNotFound         = errors.New("no fee schedule found with this id")
+	ErrInvalidTransactionType   = errors.New("fees can not be charged for this transaction type")
+	ErrInvalidProductType       = errors.New("the product type is not valid")
+	ErrInvalidFeeAmount         = errors.New("fee amounts must not be negative and the percentage at most 1")
+	ErrEmptyFee                 = errors.New("the schedule must charge a fixed amount or a percentage")
+	ErrDuplicatedScheduleTier   = errors.New("a schedule with this minimum amount already exists")
+	ErrRevenueAccountNotDefined = errors.New("the fee revenue account is not defined")
+	ErrMaintenanceMonthNotBegun = errors.New("maintenance fees can not be charged for future months")
+)
+
+type Service interface {
+	CreateSchedule(ctx context.Context, schedule Schedule) (Schedule, error)
+	DeleteSchedule(ctx context.Context, id uuid.UUID) error
+	ListSchedules(ctx context.Context) ([]Schedule, error)
+	Quote(ctx context.Context, quote Quote) (Fee, error)
+	ListMaintenanceDue(ctx context.Context, month time.Time) ([]Fee, error)
+}
+
+type service struct {
+	tracer           tracer.Tracer
+	repository       Repository
+	revenueAccountID uuid.UUID
+}
+
+// NewService builds the fee service. Every fee charged is credited to revenueAccountID.
+func NewService(t tracer.Tracer, r Repository, revenueAccountID uuid.UUID) Service {
+	return service{
+		tracer:           t,
+		repository:       r,
+		revenueAccountID: revenueAccountID,
+	}
+}
+
+func (s service) CreateSchedule(ctx context.Context, schedule Schedule) (Schedule, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if !charged(schedule.TransactionType) {
+		span.RecordError(ErrInvalidTransactionType)
+		return Schedule{}, ErrInvalidTransactionType
+	}
+
+	if !schedule.ProductType.Valid() {
+		span.RecordError(ErrInvalidProductType)
+		return Schedule{}, ErrInvalidProductType
+	}
+
+	if schedule.MinAmount < 0 || schedule.FixedAmount < 0 || schedule.FreePerMonth < 0 ||
+		schedule.Percentage < 0 || schedule.Percentage > 1 {
+		span.RecordError(ErrInvalidFeeAmount)
+		return Schedule{}, ErrInvalidFeeAmount
+	}
+
+	if schedule.FixedAmount == 0 && schedule.Percentage == 0 {
+		span.RecordError(ErrEmptyFee)
+		return Schedule{}, ErrEmptyFee
+	}
+
+	model, err := s.repository.CreateSchedule(ctx, newScheduleModel(schedule))
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, errDuplicatedScheduleTier) {
+			return Schedule{}, ErrDuplicatedScheduleTier
+		}
+		zapctx.L(ctx).Error("fee_service_create_schedule_repository_error", zap.Error(err))
+		return Schedule{}, err
+	}
+
+	return newSchedule(model), nil
+}
+
+func (s service) DeleteSchedule(ctx context.Context, id uuid.UUID) error {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	deleted, err := s.repository.DeleteSchedule(ctx, id)
+	if err != nil {
+		zapctx.L(ctx).Error(
+			"fee_service_delete_schedule_repository_error",
+			zap.String("id", id.String()),
+			zap.Error(err),
+		)
+		span.RecordError(err)
+		return err
+	}
+
+	if !deleted {
+		span.RecordError(ErrScheduleNotFound)
+		return ErrScheduleNotFound
+	}
+
+	return nil
+}
+
+func (s service) ListSchedules(ctx context.Context) ([]Schedule, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	models, err := s.repository.ListSchedules(ctx)
+	if err != nil {
+		zapctx.L(ctx).Error("fee_service_list_schedules_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	schedules := make([]Schedule, len(models))
+	for i, model := range models {
+		schedules[i] = newSchedule(model)
+	}
+
+	return schedules, nil
+}
+
+// Quote evaluates the fee of a transaction. The fee is zero when no schedule applies or the
+// transaction is within the free monthly quota of the account.
+func (s service) Quote(ctx context.Context, quote Quote) (Fee, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	fee := Fee{AccountID: quote.AccountID, RevenueAccountID: s.revenueAccountID}
+
+	if !charged(quote.TransactionType) {
+		return fee, nil
+	}
+
+	model, err := s.repository.GetTier(ctx, quote.TransactionType, quote.ProductType, quote.Amount)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fee, nil
+		}
+		zapctx.L(ctx).Error("fee_service_get_tier_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return Fee{}, err
+	}
+
+	if model.FreePerMonth > 0 {
+		made, err := s.repository.CountTransactionsSince(
+			ctx,
+			quote.AccountID,
+			quote.TransactionType,
+			month(time.Now()),
+		)
+		if err != nil {
+			zapctx.L(ctx).Error("fee_service_count_transactions_repository_error", zap.Error(err))
+			span.RecordError(err)
+			return Fee{}, err
+		}
+
+		if made < model.FreePerMonth {
+			return fee, nil
+		}
+	}
+
+	if s.revenueAccountID == uuid.Nil {
+		span.RecordError(ErrRevenueAccountNotDefined)
+		return Fee{}, ErrRevenueAccountNotDefined
+	}
+
+	fee.Amount = newSchedule(model).Amount(quote.Amount)
+	fee.Description = fmt.Sprintf("%s fee", quote.TransactionType)
+
+	return fee, nil
+}
+
+// ListMaintenanceDue lists the monthly maintenance fees of the month not charged yet.
+func (s service) ListMaintenanceDue(ctx context.Context, maintenanceMonth time.Time) ([]Fee, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if s.revenueAccountID == uuid.Nil {
+		span.RecordError(ErrRevenueAccountNotDefined)
+		return nil, ErrRevenueAccountNotDefined
+	}
+
+	maintenanceMonth = month(maintenanceMonth)
+	if maintenanceMonth.After(month(time.Now())) {
+		span.RecordError(ErrMaintenanceMonthNotBegun)
+		return nil, ErrMaintenanceMonthNotBegun
+	}
+
+	models, err := s.repository.ListMaintenanceDue(ctx, maintenanceMonth)
+	if err != nil {
+		zapctx.L(ctx).Error("fee_service_list_maintenance_due_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	due := make([]Fee, len(models))
+	for i, model := range models {
+		due[i] = Fee{
+			AccountID:        model.AccountID,
+			RevenueAccountID: s.revenueAccountID,
+			Amount:           model.MonthlyFee,
+			Description:      fmt.Sprintf("monthly maintenance fee %s", maintenanceMonth.Format("2006-01")),
+			IdempotencyKey:   maintenanceKey(model.AccountID, maintenanceMonth),
+		}
+	}
+
+	return due, nil
+}
+
+func charged(transactionType string) bool {
+	for _, t := range TransactionTypes {
+		if t == transactionType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// month truncates the time to the first day of its month in UTC.
+func month(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,178 @@
+//go:build unit
+
+package fees
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/products"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedule_Amount(t *testing.T) {
+	assert.Equal(t, 2.0, Schedule{FixedAmount: 2}.Amount(500))
+	assert.Equal(t, 5.0, Schedule{Percentage: 0.01}.Amount(500))
+	assert.Equal(t, 1.63, Schedule{FixedAmount: 0.5, Percentage: 0.0075}.Amount(150.5))
+}
+
+func TestService_CreateSchedule(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	svc := NewService(tracer.NewNoop(), repoMock, uuid.New())
+
+	t.Run("fail create, transaction type not charged", func(t *testing.T) {
+		schedule, err := svc.CreateSchedule(ctx, Schedule{
+			TransactionType: "CREDIT",
+			ProductType:     products.CheckingType,
+			FixedAmount:     1,
+		})
+		assert.ErrorIs(t, err, ErrInvalidTransactionType)
+		assert.Empty(t, schedule)
+	})
+
+	t.Run("fail create, invalid percentage", func(t *testing.T) {
+		schedule, err := svc.CreateSchedule(ctx, Schedule{
+			TransactionType: "P2P",
+			ProductType:     products.CheckingType,
+			Percentage:      1.5,
+		})
+		assert.ErrorIs(t, err, ErrInvalidFeeAmount)
+		assert.Empty(t, schedule)
+	})
+
+	t.Run("fail create, nothing charged", func(t *testing.T) {
+		schedule, err := svc.CreateSchedule(ctx, Schedule{
+			TransactionType: "P2P",
+			ProductType:     products.CheckingType,
+			FreePerMonth:    5,
+		})
+		assert.ErrorIs(t, err, ErrEmptyFee)
+		assert.Empty(t, schedule)
+	})
+
+	t.Run("fail create, duplicated tier", func(t *testing.T) {
+		repoMock.EXPECT().CreateSchedule(ctx, gomock.Any()).Return(scheduleModel{}, errDuplicatedScheduleTier)
+
+		schedule, err := svc.CreateSchedule(ctx, Schedule{
+			TransactionType: "P2P",
+			ProductType:     products.CheckingType,
+			FixedAmount:     1,
+		})
+		assert.ErrorIs(t, err, ErrDuplicatedScheduleTier)
+		assert.Empty(t, schedule)
+	})
+
+	t.Run("success create", func(t *testing.T) {
+		model := scheduleModel{
+			TransactionType: "DEBIT",
+			ProductType:     products.PaymentType,
+			MinAmount:       1000,
+			Percentage:      0.005,
+			FreePerMonth:    4,
+		}
+		repoMock.EXPECT().CreateSchedule(ctx, model).Return(model, nil)
+
+		schedule, err := svc.CreateSchedule(ctx, newSchedule(model))
+		assert.NoError(t, err)
+		assert.Equal(t, 4, schedule.FreePerMonth)
+	})
+}
+
+func TestService_Quote(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	revenueAccountID := uuid.New()
+	svc := NewService(tracer.NewNoop(), repoMock, revenueAccountID)
+
+	accountID := uuid.New()
+	quote := Quote{AccountID: accountID, TransactionType: "P2P", ProductType: products.CheckingType, Amount: 200}
+
+	t.Run("free, transaction type not charged", func(t *testing.T) {
+		fee, err := svc.Quote(ctx, Quote{AccountID: accountID, TransactionType: "CREDIT", Amount: 200})
+		assert.NoError(t, err)
+		assert.Zero(t, fee.Amount)
+	})
+
+	t.Run("free, no schedule", func(t *testing.T) {
+		repoMock.EXPECT().GetTier(ctx, "P2P", products.CheckingType, 200.0).Return(scheduleModel{}, sql.ErrNoRows)
+
+		fee, err := svc.Quote(ctx, quote)
+		assert.NoError(t, err)
+		assert.Zero(t, fee.Amount)
+	})
+
+	t.Run("free, within the monthly quota", func(t *testing.T) {
+		repoMock.EXPECT().
+			GetTier(ctx, "P2P", products.CheckingType, 200.0).
+			Return(scheduleModel{FixedAmount: 1, FreePerMonth: 3}, nil)
+		repoMock.EXPECT().
+			CountTransactionsSince(ctx, accountID, "P2P", month(time.Now())).
+			Return(2, nil)
+
+		fee, err := svc.Quote(ctx, quote)
+		assert.NoError(t, err)
+		assert.Zero(t, fee.Amount)
+	})
+
+	t.Run("charged, quota used", func(t *testing.T) {
+		repoMock.EXPECT().
+			GetTier(ctx, "P2P", products.CheckingType, 200.0).
+			Return(scheduleModel{FixedAmount: 1, Percentage: 0.01, FreePerMonth: 3}, nil)
+		repoMock.EXPECT().
+			CountTransactionsSince(ctx, accountID, "P2P", gomock.Any()).
+			Return(3, nil)
+
+		fee, err := svc.Quote(ctx, quote)
+		assert.NoError(t, err)
+		assert.Equal(t, 3.0, fee.Amount)
+		assert.Equal(t, accountID, fee.AccountID)
+		assert.Equal(t, revenueAccountID, fee.RevenueAccountID)
+		assert.Empty(t, fee.IdempotencyKey)
+	})
+}
+
+func TestService_ListMaintenanceDue(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	revenueAccountID := uuid.New()
+	svc := NewService(tracer.NewNoop(), repoMock, revenueAccountID)
+
+	t.Run("fail list, future month", func(t *testing.T) {
+		due, err := svc.ListMaintenanceDue(ctx, time.Now().AddDate(0, 2, 0))
+		assert.ErrorIs(t, err, ErrMaintenanceMonthNotBegun)
+		assert.Empty(t, due)
+	})
+
+	t.Run("success list", func(t *testing.T) {
+		accountID := uuid.New()
+		maintenanceMonth := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+		repoMock.EXPECT().
+			ListMaintenanceDue(ctx, maintenanceMonth).
+			Return([]maintenanceDueModel{{AccountID: accountID, MonthlyFee: 29.9}}, nil)
+
+		due, err := svc.ListMaintenanceDue(ctx, maintenanceMonth.AddDate(0, 0, 17))
+		assert.NoError(t, err)
+		assert.Equal(t, []Fee{{
+			AccountID:        accountID,
+			RevenueAccountID: revenueAccountID,
+			Amount:           29.9,
+			Description:      "monthly maintenance fee 2026-09",
+			IdempotencyKey:   "maintenance-fee:" + accountID.String() + ":2026-09",
+		}}, due)
+	})
+}
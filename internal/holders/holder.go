@@ -1,17 +1,123 @@
 package holders
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/dalmarcogd/dock-test/pkg/document"
+	"github.com/google/uuid"
+)
+
+type Type string
+
+const (
+	IndividualType Type = "INDIVIDUAL"
+	CompanyType    Type = "COMPANY"
+)
+
+type KYCStatus string
+
+const (
+	PendingKYCStatus  KYCStatus = "PENDING"
+	ApprovedKYCStatus KYCStatus = "APPROVED"
+	RejectedKYCStatus KYCStatus = "REJECTED"
+)
+
+// the kyc reviews made by the service itself, and not by a reviewer, are recorded with these.
+const (
+	systemReviewer        = "system"
+	documentChangedReason = "document number changed"
+)
 
 type Holder struct {
 	ID             uuid.UUID
 	Name           string
 	DocumentNumber string
+	Type           Type
+	Email          string
+	Phone          string
+	// BirthDate is the incorporation date when the holder is a company.
+	BirthDate time.Time
+	KYCStatus KYCStatus
+	Addresses []Address
+}
+
+type Address struct {
+	ID           uuid.UUID
+	Street       string
+	Number       string
+	Complement   string
+	Neighborhood string
+	City         string
+	State        string
+	ZipCode      string
+	Country      string
+}
+
+type KYCReview struct {
+	ID        uuid.UUID
+	HolderID  uuid.UUID
+	Status    KYCStatus
+	Reason    string
+	Reviewer  string
+	CreatedAt time.Time
 }
 
 func newHolder(model HolderModel) Holder {
+	var addresses []Address
+	if model.Addresses != nil {
+		addresses = make([]Address, len(model.Addresses))
+		for i, address := range model.Addresses {
+			addresses[i] = newAddress(address)
+		}
+	}
+
 	return Holder{
 		ID:             model.ID,
 		Name:           model.Name,
 		DocumentNumber: model.DocumentNumber,
+		Type:           model.Type,
+		Email:          model.Email,
+		Phone:          model.Phone,
+		BirthDate:      model.BirthDate,
+		KYCStatus:      model.KYCStatus,
+		Addresses:      addresses,
+	}
+}
+
+func newAddress(model addressModel) Address {
+	return Address{
+		ID:           model.ID,
+		Street:       model.Street,
+		Number:       model.Number,
+		Complement:   model.Complement,
+		Neighborhood: model.Neighborhood,
+		City:         model.City,
+		State:        model.State,
+		ZipCode:      model.ZipCode,
+		Country:      model.Country,
+	}
+}
+
+func newKYCReview(model kycReviewModel) KYCReview {
+	return KYCReview{
+		ID:        model.ID,
+		HolderID:  model.HolderID,
+		Status:    model.Status,
+		Reason:    model.Reason,
+		Reviewer:  model.Reviewer,
+		CreatedAt: model.CreatedAt,
+	}
+}
+
+// typeFromDocument returns the holder type according to the document number, CPF for individuals and CNPJ for
+// companies.
+func typeFromDocument(documentNumber string) (Type, error) {
+	switch {
+	case document.IsCPF(documentNumber):
+		return IndividualType, nil
+	case document.IsCNPJ(documentNumber):
+		return CompanyType, nil
+	default:
+		return "", ErrInvalidDocumentNumber
 	}
 }
@@ -14,15 +14,89 @@ type HolderModel struct {
 	ID             uuid.UUID `bun:"id,pk"`
 	Name           string    `bun:"name"`
 	DocumentNumber string    `bun:"document_number"`
+	Type           Type      `bun:"type"`
+	Email          string    `bun:"email,nullzero"`
+	Phone          string    `bun:"phone,nullzero"`
+	BirthDate      time.Time `bun:"birth_date,nullzero"`
+	KYCStatus      KYCStatus `bun:"kyc_status,nullzero"`
 	CreatedAt      time.Time `bun:"created_at,notnull"`
 	UpdatedAt      time.Time `bun:"updated_at,nullzero"`
+
+	// Addresses are persisted in holder_addresses, a nil value means that the addresses must not be touched.
+	Addresses []addressModel `bun:"-"`
 }
 
 func newHolderModel(h Holder) HolderModel {
+	var addresses []addressModel
+	if h.Addresses != nil {
+		addresses = make([]addressModel, len(h.Addresses))
+		for i, address := range h.Addresses {
+			addresses[i] = newAddressModel(h.ID, address)
+		}
+	}
+
 	return HolderModel{
 		ID:             h.ID,
 		Name:           h.Name,
 		DocumentNumber: h.DocumentNumber,
+		Type:           h.Type,
+		Email:          h.Email,
+		Phone:          h.Phone,
+		BirthDate:      h.BirthDate,
+		KYCStatus:      h.KYCStatus,
+		Addresses:      addresses,
+	}
+}
+
+type addressModel struct {
+	bun.BaseModel `bun:"table:holder_addresses"`
+
+	ID           uuid.UUID `bun:"id,pk"`
+	HolderID     uuid.UUID `bun:"holder_id"`
+	Street       string    `bun:"street"`
+	Number       string    `bun:"number"`
+	Complement   string    `bun:"complement,nullzero"`
+	Neighborhood string    `bun:"neighborhood,nullzero"`
+	City         string    `bun:"city"`
+	State        string    `bun:"state"`
+	ZipCode      string    `bun:"zip_code"`
+	Country      string    `bun:"country,nullzero"`
+	CreatedAt    time.Time `bun:"created_at,notnull"`
+}
+
+func newAddressModel(holderID uuid.UUID, a Address) addressModel {
+	return addressModel{
+		ID:           a.ID,
+		HolderID:     holderID,
+		Street:       a.Street,
+		Number:       a.Number,
+		Complement:   a.Complement,
+		Neighborhood: a.Neighborhood,
+		City:         a.City,
+		State:        a.State,
+		ZipCode:      a.ZipCode,
+		Country:      a.Country,
+	}
+}
+
+type kycReviewModel struct {
+	bun.BaseModel `bun:"table:holder_kyc_reviews"`
+
+	ID        uuid.UUID `bun:"id,pk"`
+	HolderID  uuid.UUID `bun:"holder_id"`
+	Status    KYCStatus `bun:"status"`
+	Reason    string    `bun:"reason"`
+	Reviewer  string    `bun:"reviewer"`
+	CreatedAt time.Time `bun:"created_at,notnull"`
+}
+
+func newKYCReviewModel(r KYCReview) kycReviewModel {
+	return kycReviewModel{
+		ID:       r.ID,
+		HolderID: r.HolderID,
+		Status:   r.Status,
+		Reason:   r.Reason,
+		Reviewer: r.Reviewer,
 	}
 }
 
@@ -40,5 +114,6 @@ type ListFilter struct {
 	Sort           int
 	Page           int
 	Size           int
+	Name           string
 	DocumentNumber string
 }
@@ -2,18 +2,22 @@ package holders
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/dalmarcogd/dock-test/pkg/database"
 	"github.com/dalmarcogd/dock-test/pkg/tracer"
 	"github.com/google/uuid"
+	"github.com/uptrace/bun"
 )
 
 type Repository interface {
 	Create(ctx context.Context, model HolderModel) (HolderModel, error)
-	Update(ctx context.Context, model HolderModel) (HolderModel, error)
+	Update(ctx context.Context, model HolderModel, reviews ...kycReviewModel) (HolderModel, error)
 	GetByFilter(ctx context.Context, filter HolderFilter) ([]HolderModel, error)
 	ListByFilter(ctx context.Context, filter ListFilter) (int, []HolderModel, error)
+	CreateKYCReview(ctx context.Context, model kycReviewModel) (kycReviewModel, error)
+	ListKYCReviewsByHolderID(ctx context.Context, holderID uuid.UUID) ([]kycReviewModel, error)
 }
 
 type repository struct {
@@ -35,11 +39,17 @@ func (r repository) Create(ctx context.Context, model HolderModel) (HolderModel,
 	model.ID = uuid.New()
 	model.CreatedAt = time.Now().UTC()
 
-	_, err := r.db.Master().
-		NewInsert().
-		Model(&model).
-		Returning("*").
-		Exec(ctx)
+	err := r.db.Master().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewInsert().
+			Model(&model).
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		return r.insertAddresses(ctx, tx, model.ID, model.Addresses)
+	})
 	if err != nil {
 		span.RecordError(err)
 		return HolderModel{}, err
@@ -48,19 +58,54 @@ func (r repository) Create(ctx context.Context, model HolderModel) (HolderModel,
 	return model, nil
 }
 
-func (r repository) Update(ctx context.Context, model HolderModel) (HolderModel, error) {
+// Update updates the non zero fields of the holder, recording the kyc reviews in the same transaction, so a kyc
+// status changed by the update keeps its history.
+func (r repository) Update(ctx context.Context, model HolderModel, reviews ...kycReviewModel) (HolderModel, error) {
 	ctx, span := r.tracer.Span(ctx)
 	defer span.End()
 
 	model.UpdatedAt = time.Now().UTC()
 
-	_, err := r.db.Master().
-		NewUpdate().
-		Model(&model).
-		WherePK().
-		Returning("*").
-		OmitZero().
-		Exec(ctx)
+	err := r.db.Master().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewUpdate().
+			Model(&model).
+			WherePK().
+			Returning("*").
+			OmitZero().
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		for i := range reviews {
+			reviews[i].ID = uuid.New()
+			reviews[i].HolderID = model.ID
+			reviews[i].CreatedAt = model.UpdatedAt
+		}
+		if len(reviews) > 0 {
+			_, err = tx.NewInsert().
+				Model(&reviews).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		if model.Addresses == nil {
+			return nil
+		}
+
+		// addresses are replaced as a whole, the holder profile always sends its current addresses.
+		_, err = tx.NewDelete().
+			Model((*addressModel)(nil)).
+			Where("holder_id = ?", model.ID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		return r.insertAddresses(ctx, tx, model.ID, model.Addresses)
+	})
 	if err != nil {
 		span.RecordError(err)
 		return HolderModel{}, err
@@ -69,6 +114,63 @@ func (r repository) Update(ctx context.Context, model HolderModel) (HolderModel,
 	return model, nil
 }
 
+func (r repository) insertAddresses(
+	ctx context.Context,
+	tx bun.Tx,
+	holderID uuid.UUID,
+	addresses []addressModel,
+) error {
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	for i := range addresses {
+		addresses[i].ID = uuid.New()
+		addresses[i].HolderID = holderID
+		addresses[i].CreatedAt = now
+	}
+
+	_, err := tx.NewInsert().
+		Model(&addresses).
+		Returning("*").
+		Exec(ctx)
+	return err
+}
+
+func (r repository) attachAddresses(ctx context.Context, models []HolderModel) error {
+	if len(models) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(models))
+	for i, model := range models {
+		ids[i] = model.ID.String()
+	}
+
+	var addresses []addressModel
+	err := r.db.Replica().
+		NewSelect().
+		Model(&addresses).
+		Where("holder_id IN (?)", bun.In(ids)).
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return err
+	}
+
+	addressesByHolder := make(map[uuid.UUID][]addressModel, len(models))
+	for _, address := range addresses {
+		addressesByHolder[address.HolderID] = append(addressesByHolder[address.HolderID], address)
+	}
+
+	for i := range models {
+		models[i].Addresses = addressesByHolder[models[i].ID]
+	}
+
+	return nil
+}
+
 func (r repository) GetByFilter(ctx context.Context, filter HolderFilter) ([]HolderModel, error) {
 	ctx, span := r.tracer.Span(ctx)
 	defer span.End()
@@ -109,6 +211,12 @@ func (r repository) GetByFilter(ctx context.Context, filter HolderFilter) ([]Hol
 		return nil, err
 	}
 
+	err = r.attachAddresses(ctx, accs)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
 	return accs, nil
 }
 
@@ -136,6 +244,12 @@ func (r repository) ListByFilter(ctx context.Context, filter ListFilter) (int, [
 		selectQuery.Where("document_number = ?", filter.DocumentNumber)
 	}
 
+	if filter.Name != "" {
+		selectQuery.
+			Where("(name ILIKE ? OR name % ?)", escapeLike(filter.Name)+"%", filter.Name).
+			OrderExpr("similarity(name, ?) DESC", filter.Name)
+	}
+
 	if filter.Sort == 0 {
 		selectQuery.Order("created_at ASC")
 	} else if filter.Sort > 0 {
@@ -149,5 +263,70 @@ func (r repository) ListByFilter(ctx context.Context, filter ListFilter) (int, [
 		return 0, nil, err
 	}
 
+	err = r.attachAddresses(ctx, accs)
+	if err != nil {
+		span.RecordError(err)
+		return 0, nil, err
+	}
+
 	return total, accs, nil
 }
+
+func (r repository) CreateKYCReview(ctx context.Context, model kycReviewModel) (kycReviewModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	model.ID = uuid.New()
+	model.CreatedAt = time.Now().UTC()
+
+	err := r.db.Master().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewInsert().
+			Model(&model).
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.NewUpdate().
+			Model((*HolderModel)(nil)).
+			Set("kyc_status = ?", model.Status).
+			Set("updated_at = ?", model.CreatedAt).
+			Where("id = ?", model.HolderID).
+			Exec(ctx)
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		return kycReviewModel{}, err
+	}
+
+	return model, nil
+}
+
+func (r repository) ListKYCReviewsByHolderID(ctx context.Context, holderID uuid.UUID) ([]kycReviewModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var reviews []kycReviewModel
+	err := r.db.Replica().
+		NewSelect().
+		Model(&reviews).
+		Where("holder_id = ?", holderID).
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+// likeEscaper escapes the wildcards of LIKE patterns with the default escape character, so a name with % or _
+// is searched literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 )
 
 // MockRepository is a mock of Repository interface.
@@ -49,6 +50,21 @@ func (mr *MockRepositoryMockRecorder) Create(ctx, model interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, model)
 }
 
+// CreateKYCReview mocks base method.
+func (m *MockRepository) CreateKYCReview(ctx context.Context, model kycReviewModel) (kycReviewModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKYCReview", ctx, model)
+	ret0, _ := ret[0].(kycReviewModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateKYCReview indicates an expected call of CreateKYCReview.
+func (mr *MockRepositoryMockRecorder) CreateKYCReview(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKYCReview", reflect.TypeOf((*MockRepository)(nil).CreateKYCReview), ctx, model)
+}
+
 // GetByFilter mocks base method.
 func (m *MockRepository) GetByFilter(ctx context.Context, filter HolderFilter) ([]HolderModel, error) {
 	m.ctrl.T.Helper()
@@ -80,17 +96,37 @@ func (mr *MockRepositoryMockRecorder) ListByFilter(ctx, filter interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByFilter", reflect.TypeOf((*MockRepository)(nil).ListByFilter), ctx, filter)
 }
 
+// ListKYCReviewsByHolderID mocks base method.
+func (m *MockRepository) ListKYCReviewsByHolderID(ctx context.Context, holderID uuid.UUID) ([]kycReviewModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKYCReviewsByHolderID", ctx, holderID)
+	ret0, _ := ret[0].([]kycReviewModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKYCReviewsByHolderID indicates an expected call of ListKYCReviewsByHolderID.
+func (mr *MockRepositoryMockRecorder) ListKYCReviewsByHolderID(ctx, holderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKYCReviewsByHolderID", reflect.TypeOf((*MockRepository)(nil).ListKYCReviewsByHolderID), ctx, holderID)
+}
+
 // Update mocks base method.
-func (m *MockRepository) Update(ctx context.Context, model HolderModel) (HolderModel, error) {
+func (m *MockRepository) Update(ctx context.Context, model HolderModel, reviews ...kycReviewModel) (HolderModel, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, model)
+	varargs := []interface{}{ctx, model}
+	for _, a := range reviews {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Update", varargs...)
 	ret0, _ := ret[0].(HolderModel)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockRepositoryMockRecorder) Update(ctx, model interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Update(ctx, model interface{}, reviews ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, model)
+	varargs := append([]interface{}{ctx, model}, reviews...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), varargs...)
 }
@@ -139,6 +139,98 @@ func TestRepository(t *testing.T) {
 		assert.NotEqual(t, created, rst[0])
 	})
 
+	t.Run("create and list by name", func(t *testing.T) {
+		holder := Holder{Name: "Zacarias " + gofakeit.LastName(), DocumentNumber: gofakeit.SSN()}
+		created, err := repo.Create(
+			ctx,
+			newHolderModel(holder),
+		)
+		assert.NoError(t, err)
+
+		total, rst, err := repo.ListByFilter(ctx, ListFilter{Name: "Zaca"})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, rst, 1)
+		assert.Equal(t, created.ID, rst[0].ID)
+
+		total, rst, err = repo.ListByFilter(ctx, ListFilter{Name: "Zacaryas"})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, rst, 1)
+		assert.Equal(t, created.ID, rst[0].ID)
+	})
+
+	t.Run("list by name with like wildcards", func(t *testing.T) {
+		for _, name := range []string{"%", "_", "Zac%"} {
+			total, rst, err := repo.ListByFilter(ctx, ListFilter{Name: name})
+			assert.NoError(t, err)
+			assert.Equal(t, 0, total, name)
+			assert.Empty(t, rst, name)
+		}
+	})
+
+	t.Run("create holder with addresses and review kyc", func(t *testing.T) {
+		holder := Holder{
+			Name:           gofakeit.Name(),
+			DocumentNumber: gofakeit.SSN(),
+			Email:          gofakeit.Email(),
+			BirthDate:      time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC),
+			Addresses: []Address{
+				{
+					Street:  gofakeit.Street(),
+					Number:  "10",
+					City:    gofakeit.City(),
+					State:   "SP",
+					ZipCode: "01001000",
+				},
+			},
+		}
+		created, err := repo.Create(
+			ctx,
+			newHolderModel(holder),
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, PendingKYCStatus, created.KYCStatus)
+		assert.Len(t, created.Addresses, 1)
+		assert.NotEmpty(t, created.Addresses[0].ID)
+
+		rst, err := repo.GetByFilter(ctx, HolderFilter{ID: uuid.NullUUID{UUID: created.ID, Valid: true}})
+		assert.NoError(t, err)
+		assert.Len(t, rst, 1)
+		assert.Len(t, rst[0].Addresses, 1)
+		assert.Equal(t, "BR", rst[0].Addresses[0].Country)
+
+		review, err := repo.CreateKYCReview(ctx, kycReviewModel{
+			HolderID: created.ID,
+			Status:   ApprovedKYCStatus,
+			Reason:   "documents verified",
+			Reviewer: gofakeit.Username(),
+		})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, review.ID)
+
+		rst, err = repo.GetByFilter(ctx, HolderFilter{ID: uuid.NullUUID{UUID: created.ID, Valid: true}})
+		assert.NoError(t, err)
+		assert.Equal(t, ApprovedKYCStatus, rst[0].KYCStatus)
+
+		reviews, err := repo.ListKYCReviewsByHolderID(ctx, created.ID)
+		assert.NoError(t, err)
+		assert.Len(t, reviews, 1)
+
+		updated, err := repo.Update(
+			ctx,
+			HolderModel{ID: created.ID, DocumentNumber: gofakeit.SSN(), KYCStatus: PendingKYCStatus},
+			kycReviewModel{Status: PendingKYCStatus, Reason: documentChangedReason, Reviewer: systemReviewer},
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, PendingKYCStatus, updated.KYCStatus)
+
+		reviews, err = repo.ListKYCReviewsByHolderID(ctx, created.ID)
+		assert.NoError(t, err)
+		assert.Len(t, reviews, 2)
+		assert.Equal(t, systemReviewer, reviews[1].Reviewer)
+	})
+
 	t.Run("holder not found searching for these filters", func(t *testing.T) {
 		rst, err := repo.GetByFilter(ctx, HolderFilter{
 			ID: uuid.NullUUID{
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/dalmarcogd/dock-test/pkg/document"
 	"github.com/dalmarcogd/dock-test/pkg/tracer"
 	"github.com/dalmarcogd/dock-test/pkg/zapctx"
 	"github.com/google/uuid"
@@ -11,8 +12,11 @@ import (
 )
 
 var (
-	ErrHolderNotFound      = errors.New("no holders found with these filters")
-	ErrMultpleHoldersFound = errors.New("multiple holders found with these filters")
+	ErrHolderNotFound        = errors.New("no holders found with these filters")
+	ErrMultpleHoldersFound   = errors.New("multiple holders found with these filters")
+	ErrInvalidDocumentNumber = errors.New("document_number must be a valid cpf or cnpj")
+	ErrInvalidKYCStatus      = errors.New("kyc status must be one of PENDING, APPROVED or REJECTED")
+	ErrKYCStatusUnchanged    = errors.New("holder already has this kyc status")
 )
 
 type Service interface {
@@ -20,6 +24,8 @@ type Service interface {
 	Update(ctx context.Context, holder Holder) (Holder, error)
 	GetByID(ctx context.Context, id uuid.UUID) (Holder, error)
 	List(ctx context.Context, filter ListFilter) (int, []Holder, error)
+	ReviewKYC(ctx context.Context, review KYCReview) (KYCReview, error)
+	ListKYCReviews(ctx context.Context, holderID uuid.UUID) ([]KYCReview, error)
 }
 
 type service struct {
@@ -35,29 +41,87 @@ func (s service) Create(ctx context.Context, holder Holder) (Holder, error) {
 	ctx, span := s.tracer.Span(ctx)
 	defer span.End()
 
+	holder.DocumentNumber = document.Normalize(holder.DocumentNumber)
+	holderType, err := typeFromDocument(holder.DocumentNumber)
+	if err != nil {
+		zapctx.L(ctx).Error("holder_service_create_document_number_error", zap.Error(err))
+		span.RecordError(err)
+		return Holder{}, err
+	}
+	holder.Type = holderType
+	holder.KYCStatus = PendingKYCStatus
+
 	model, err := s.repository.Create(ctx, newHolderModel(holder))
 	if err != nil {
 		zapctx.L(ctx).Error("holder_service_create_repository_error", zap.Error(err))
 		span.RecordError(err)
 		return Holder{}, err
 	}
-	holder.ID = model.ID
-
-	return holder, nil
+	return newHolder(model), nil
 }
 
 func (s service) Update(ctx context.Context, holder Holder) (Holder, error) {
 	ctx, span := s.tracer.Span(ctx)
 	defer span.End()
 
-	_, err := s.repository.Update(ctx, newHolderModel(holder))
+	if holder.DocumentNumber != "" {
+		holder.DocumentNumber = document.Normalize(holder.DocumentNumber)
+		holderType, err := typeFromDocument(holder.DocumentNumber)
+		if err != nil {
+			zapctx.L(ctx).Error("holder_service_update_document_number_error", zap.Error(err))
+			span.RecordError(err)
+			return Holder{}, err
+		}
+		holder.Type = holderType
+	}
+
+	// kyc status is only changed by ReviewKYC, keeping the review history consistent.
+	holder.KYCStatus = ""
+
+	current, err := s.GetByID(ctx, holder.ID)
+	if err != nil {
+		zapctx.L(ctx).Error(
+			"holder_service_update_get_error",
+			zap.String("id", holder.ID.String()),
+			zap.Error(err),
+		)
+		span.RecordError(err)
+		return Holder{}, err
+	}
+
+	// the kyc was made for the previous document, a new one must be verified again.
+	var reviews []kycReviewModel
+	documentChanged := holder.DocumentNumber != "" && holder.DocumentNumber != current.DocumentNumber
+	if documentChanged && current.KYCStatus != PendingKYCStatus {
+		holder.KYCStatus = PendingKYCStatus
+		reviews = append(reviews, kycReviewModel{
+			Status:   PendingKYCStatus,
+			Reason:   documentChangedReason,
+			Reviewer: systemReviewer,
+		})
+	}
+
+	model, err := s.repository.Update(ctx, newHolderModel(holder), reviews...)
 	if err != nil {
 		zapctx.L(ctx).Error("holder_service_update_repository_error", zap.Error(err))
 		span.RecordError(err)
 		return Holder{}, err
 	}
 
-	return holder, nil
+	updated := newHolder(model)
+	if holder.Addresses == nil {
+		updated.Addresses = current.Addresses
+	}
+
+	if len(reviews) > 0 {
+		zapctx.L(ctx).Info(
+			"holder_service_kyc_reset",
+			zap.String("id", holder.ID.String()),
+			zap.String("from_status", string(current.KYCStatus)),
+		)
+	}
+
+	return updated, nil
 }
 
 func (s service) GetByID(ctx context.Context, id uuid.UUID) (Holder, error) {
@@ -98,6 +162,10 @@ func (s service) List(ctx context.Context, filter ListFilter) (int, []Holder, er
 	ctx, span := s.tracer.Span(ctx)
 	defer span.End()
 
+	if filter.DocumentNumber != "" {
+		filter.DocumentNumber = document.Normalize(filter.DocumentNumber)
+	}
+
 	total, models, err := s.repository.ListByFilter(ctx, filter)
 	if err != nil {
 		zapctx.L(ctx).Error(
@@ -115,3 +183,77 @@ func (s service) List(ctx context.Context, filter ListFilter) (int, []Holder, er
 
 	return total, hdrs, nil
 }
+
+func (s service) ReviewKYC(ctx context.Context, review KYCReview) (KYCReview, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	switch review.Status {
+	case PendingKYCStatus, ApprovedKYCStatus, RejectedKYCStatus:
+	default:
+		span.RecordError(ErrInvalidKYCStatus)
+		return KYCReview{}, ErrInvalidKYCStatus
+	}
+
+	holder, err := s.GetByID(ctx, review.HolderID)
+	if err != nil {
+		zapctx.L(ctx).Error(
+			"holder_service_review_kyc_get_error",
+			zap.String("id", review.HolderID.String()),
+			zap.Error(err),
+		)
+		span.RecordError(err)
+		return KYCReview{}, err
+	}
+
+	if holder.KYCStatus == review.Status {
+		span.RecordError(ErrKYCStatusUnchanged)
+		return KYCReview{}, ErrKYCStatusUnchanged
+	}
+
+	model, err := s.repository.CreateKYCReview(ctx, newKYCReviewModel(review))
+	if err != nil {
+		zapctx.L(ctx).Error("holder_service_review_kyc_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return KYCReview{}, err
+	}
+
+	zapctx.L(ctx).Info(
+		"holder_service_kyc_reviewed",
+		zap.String("id", holder.ID.String()),
+		zap.String("from_status", string(holder.KYCStatus)),
+		zap.String("to_status", string(model.Status)),
+		zap.String("reviewer", model.Reviewer),
+	)
+
+	return newKYCReview(model), nil
+}
+
+func (s service) ListKYCReviews(ctx context.Context, holderID uuid.UUID) ([]KYCReview, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	_, err := s.GetByID(ctx, holderID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	models, err := s.repository.ListKYCReviewsByHolderID(ctx, holderID)
+	if err != nil {
+		zapctx.L(ctx).Error(
+			"holder_service_list_kyc_reviews_repository_error",
+			zap.String("id", holderID.String()),
+			zap.Error(err),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+
+	reviews := make([]KYCReview, len(models))
+	for i, model := range models {
+		reviews[i] = newKYCReview(model)
+	}
+
+	return reviews, nil
+}
@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/holders/service.go
+
+// Package holders is a generated GoMock package.
+package holders
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockService) Create(ctx context.Context, holder Holder) (Holder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, holder)
+	ret0, _ := ret[0].(Holder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockServiceMockRecorder) Create(ctx, holder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), ctx, holder)
+}
+
+// GetByID mocks base method.
+func (m *MockService) GetByID(ctx context.Context, id uuid.UUID) (Holder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(Holder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockServiceMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockService)(nil).GetByID), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockService) List(ctx context.Context, filter ListFilter) (int, []Holder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]Holder)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockServiceMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockService)(nil).List), ctx, filter)
+}
+
+// ListKYCReviews mocks base method.
+func (m *MockService) ListKYCReviews(ctx context.Context, holderID uuid.UUID) ([]KYCReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKYCReviews", ctx, holderID)
+	ret0, _ := ret[0].([]KYCReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKYCReviews indicates an expected call of ListKYCReviews.
+func (mr *MockServiceMockRecorder) ListKYCReviews(ctx, holderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKYCReviews", reflect.TypeOf((*MockService)(nil).ListKYCReviews), ctx, holderID)
+}
+
+// ReviewKYC mocks base method.
+func (m *MockService) ReviewKYC(ctx context.Context, review KYCReview) (KYCReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewKYC", ctx, review)
+	ret0, _ := ret[0].(KYCReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReviewKYC indicates an expected call of ReviewKYC.
+func (mr *MockServiceMockRecorder) ReviewKYC(ctx, review interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewKYC", reflect.TypeOf((*MockService)(nil).ReviewKYC), ctx, review)
+}
+
+// Update mocks base method.
+func (m *MockService) Update(ctx context.Context, holder Holder) (Holder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, holder)
+	ret0, _ := ret[0].(Holder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockServiceMockRecorder) Update(ctx, holder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockService)(nil).Update), ctx, holder)
+}
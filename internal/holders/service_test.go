@@ -0,0 +1,230 @@
+//go:build unit
+
+package holders
+
+import (
+	"context"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_Create(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+
+	svc := NewService(tracer.NewNoop(), repoMock)
+
+	t.Run("fail create, invalid document number", func(t *testing.T) {
+		created, err := svc.Create(ctx, Holder{
+			Name:           gofakeit.Name(),
+			DocumentNumber: "123.456.789-00",
+		})
+		assert.ErrorIs(t, err, ErrInvalidDocumentNumber)
+		assert.Empty(t, created)
+	})
+
+	t.Run("success create individual", func(t *testing.T) {
+		name := gofakeit.Name()
+		id := uuid.New()
+
+		repoMock.EXPECT().
+			Create(ctx, HolderModel{
+				Name:           name,
+				DocumentNumber: "52998224725",
+				Type:           IndividualType,
+				KYCStatus:      PendingKYCStatus,
+			}).
+			Return(HolderModel{
+				ID:             id,
+				Name:           name,
+				DocumentNumber: "52998224725",
+				Type:           IndividualType,
+				KYCStatus:      PendingKYCStatus,
+			}, nil)
+
+		created, err := svc.Create(ctx, Holder{Name: name, DocumentNumber: "529.982.247-25"})
+		assert.NoError(t, err)
+		assert.Equal(t, id, created.ID)
+		assert.Equal(t, "52998224725", created.DocumentNumber)
+		assert.Equal(t, IndividualType, created.Type)
+		assert.Equal(t, PendingKYCStatus, created.KYCStatus)
+	})
+
+	t.Run("success create company", func(t *testing.T) {
+		name := gofakeit.Company()
+		id := uuid.New()
+
+		repoMock.EXPECT().
+			Create(ctx, HolderModel{
+				Name:           name,
+				DocumentNumber: "11222333000181",
+				Type:           CompanyType,
+				KYCStatus:      PendingKYCStatus,
+			}).
+			Return(HolderModel{ID: id, Name: name, DocumentNumber: "11222333000181", Type: CompanyType}, nil)
+
+		created, err := svc.Create(ctx, Holder{Name: name, DocumentNumber: "11.222.333/0001-81"})
+		assert.NoError(t, err)
+		assert.Equal(t, id, created.ID)
+		assert.Equal(t, CompanyType, created.Type)
+	})
+}
+
+func TestService_Update(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+
+	svc := NewService(tracer.NewNoop(), repoMock)
+
+	holderID := uuid.New()
+
+	t.Run("fail update, invalid document number", func(t *testing.T) {
+		updated, err := svc.Update(ctx, Holder{ID: holderID, DocumentNumber: "11.222.333/0001-00"})
+		assert.ErrorIs(t, err, ErrInvalidDocumentNumber)
+		assert.Empty(t, updated)
+	})
+
+	t.Run("fail update, not found", func(t *testing.T) {
+		repoMock.EXPECT().
+			GetByFilter(ctx, HolderFilter{ID: uuid.NullUUID{UUID: holderID, Valid: true}}).
+			Return([]HolderModel{}, nil)
+
+		updated, err := svc.Update(ctx, Holder{ID: holderID, Name: gofakeit.Name()})
+		assert.ErrorIs(t, err, ErrHolderNotFound)
+		assert.Empty(t, updated)
+	})
+
+	t.Run("success update name", func(t *testing.T) {
+		name := gofakeit.Name()
+
+		repoMock.EXPECT().
+			GetByFilter(ctx, HolderFilter{ID: uuid.NullUUID{UUID: holderID, Valid: true}}).
+			Return([]HolderModel{{ID: holderID, DocumentNumber: "52998224725", Type: IndividualType}}, nil)
+
+		repoMock.EXPECT().
+			Update(ctx, HolderModel{ID: holderID, Name: name}).
+			Return(HolderModel{ID: holderID, Name: name, DocumentNumber: "52998224725", Type: IndividualType}, nil)
+
+		updated, err := svc.Update(ctx, Holder{ID: holderID, Name: name})
+		assert.NoError(t, err)
+		assert.Equal(t, name, updated.Name)
+		assert.Equal(t, "52998224725", updated.DocumentNumber)
+	})
+
+	t.Run("success update name, current addresses kept", func(t *testing.T) {
+		name := gofakeit.Name()
+		address := addressModel{ID: uuid.New(), HolderID: holderID, Street: gofakeit.Street(), City: gofakeit.City()}
+
+		repoMock.EXPECT().
+			GetByFilter(ctx, HolderFilter{ID: uuid.NullUUID{UUID: holderID, Valid: true}}).
+			Return([]HolderModel{{ID: holderID, DocumentNumber: "52998224725", Addresses: []addressModel{address}}}, nil)
+
+		repoMock.EXPECT().
+			Update(ctx, HolderModel{ID: holderID, Name: name}).
+			Return(HolderModel{ID: holderID, Name: name, DocumentNumber: "52998224725"}, nil)
+
+		updated, err := svc.Update(ctx, Holder{ID: holderID, Name: name})
+		assert.NoError(t, err)
+		assert.Equal(t, []Address{newAddress(address)}, updated.Addresses)
+	})
+
+	t.Run("success update document number, kyc reset to pending", func(t *testing.T) {
+		repoMock.EXPECT().
+			GetByFilter(ctx, HolderFilter{ID: uuid.NullUUID{UUID: holderID, Valid: true}}).
+			Return([]HolderModel{{ID: holderID, DocumentNumber: "52998224725", KYCStatus: ApprovedKYCStatus}}, nil)
+
+		repoMock.EXPECT().
+			Update(
+				ctx,
+				HolderModel{ID: holderID, DocumentNumber: "12345678909", Type: IndividualType, KYCStatus: PendingKYCStatus},
+				kycReviewModel{Status: PendingKYCStatus, Reason: documentChangedReason, Reviewer: systemReviewer},
+			).
+			Return(HolderModel{ID: holderID, DocumentNumber: "12345678909", KYCStatus: PendingKYCStatus}, nil)
+
+		updated, err := svc.Update(ctx, Holder{ID: holderID, DocumentNumber: "123.456.789-09"})
+		assert.NoError(t, err)
+		assert.Equal(t, PendingKYCStatus, updated.KYCStatus)
+	})
+
+	t.Run("success update same document number, kyc kept", func(t *testing.T) {
+		repoMock.EXPECT().
+			GetByFilter(ctx, HolderFilter{ID: uuid.NullUUID{UUID: holderID, Valid: true}}).
+			Return([]HolderModel{{ID: holderID, DocumentNumber: "52998224725", KYCStatus: ApprovedKYCStatus}}, nil)
+
+		repoMock.EXPECT().
+			Update(ctx, HolderModel{ID: holderID, DocumentNumber: "52998224725", Type: IndividualType}).
+			Return(HolderModel{ID: holderID, DocumentNumber: "52998224725", KYCStatus: ApprovedKYCStatus}, nil)
+
+		updated, err := svc.Update(ctx, Holder{ID: holderID, DocumentNumber: "529.982.247-25"})
+		assert.NoError(t, err)
+		assert.Equal(t, ApprovedKYCStatus, updated.KYCStatus)
+	})
+}
+
+func TestService_ReviewKYC(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+
+	svc := NewService(tracer.NewNoop(), repoMock)
+
+	holderID := uuid.New()
+
+	t.Run("fail review, invalid status", func(t *testing.T) {
+		review, err := svc.ReviewKYC(ctx, KYCReview{HolderID: holderID, Status: "UNKNOWN"})
+		assert.ErrorIs(t, err, ErrInvalidKYCStatus)
+		assert.Empty(t, review)
+	})
+
+	t.Run("fail review, status unchanged", func(t *testing.T) {
+		repoMock.EXPECT().
+			GetByFilter(ctx, HolderFilter{ID: uuid.NullUUID{UUID: holderID, Valid: true}}).
+			Return([]HolderModel{{ID: holderID, KYCStatus: ApprovedKYCStatus}}, nil)
+
+		review, err := svc.ReviewKYC(ctx, KYCReview{HolderID: holderID, Status: ApprovedKYCStatus})
+		assert.ErrorIs(t, err, ErrKYCStatusUnchanged)
+		assert.Empty(t, review)
+	})
+
+	t.Run("success review", func(t *testing.T) {
+		reviewID := uuid.New()
+		review := KYCReview{
+			HolderID: holderID,
+			Status:   ApprovedKYCStatus,
+			Reason:   "documents verified",
+			Reviewer: gofakeit.Username(),
+		}
+
+		repoMock.EXPECT().
+			GetByFilter(ctx, HolderFilter{ID: uuid.NullUUID{UUID: holderID, Valid: true}}).
+			Return([]HolderModel{{ID: holderID, KYCStatus: PendingKYCStatus}}, nil)
+
+		repoMock.EXPECT().
+			CreateKYCReview(ctx, newKYCReviewModel(review)).
+			Return(kycReviewModel{
+				ID:       reviewID,
+				HolderID: holderID,
+				Status:   review.Status,
+				Reason:   review.Reason,
+				Reviewer: review.Reviewer,
+			}, nil)
+
+		created, err := svc.ReviewKYC(ctx, review)
+		assert.NoError(t, err)
+		assert.Equal(t, reviewID, created.ID)
+		assert.Equal(t, ApprovedKYCStatus, created.Status)
+	})
+}
@@ -0,0 +1,73 @@
+package interest
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// daysInYear is the day count used to turn the annual rate into a daily rate.
+const daysInYear = 365
+
+// Rate is the savings annual rate, applied from EffectiveFrom until the next rate takes effect.
+type Rate struct {
+	AnnualRate    float64
+	EffectiveFrom time.Time
+	CreatedAt     time.Time
+}
+
+func newRate(model rateModel) Rate {
+	return Rate{
+		AnnualRate:    model.AnnualRate,
+		EffectiveFrom: model.EffectiveFrom,
+		CreatedAt:     model.CreatedAt,
+	}
+}
+
+// DailyRate is the daily rate that compounds to the annual rate over a year.
+func (r Rate) DailyRate() float64 {
+	return math.Pow(1+r.AnnualRate, 1.0/daysInYear) - 1
+}
+
+// Payout is the capitalization of the interest accrued by an account, monthly or at its closure,
+// credited net of the withheld tax.
+type Payout struct {
+	ID            uuid.UUID
+	AccountID     uuid.UUID
+	Month         time.Time
+	GrossAmount   float64
+	TaxAmount     float64
+	NetAmount     float64
+	TransactionID uuid.UUID
+	CreatedAt     time.Time
+}
+
+func newPayout(model payoutModel) Payout {
+	return Payout{
+		ID:            model.ID,
+		AccountID:     model.AccountID,
+		Month:         model.Month,
+		GrossAmount:   model.GrossAmount,
+		TaxAmount:     model.TaxAmount,
+		NetAmount:     model.NetAmount,
+		TransactionID: model.TransactionID,
+		CreatedAt:     model.CreatedAt,
+	}
+}
+
+// day truncates the time to the start of its day in UTC.
+func day(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// month truncates the time to the first day of its month in UTC.
+func month(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
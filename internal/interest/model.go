@@ -0,0 +1,56 @@
+package interest
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type rateModel struct {
+	bun.BaseModel `bun:"table:interest_rates"`
+
+	EffectiveFrom time.Time `bun:"effective_from,pk"`
+	AnnualRate    float64   `bun:"annual_rate"`
+	CreatedAt     time.Time `bun:"created_at,notnull"`
+}
+
+func newRateModel(rate Rate) rateModel {
+	return rateModel{
+		EffectiveFrom: rate.EffectiveFrom,
+		AnnualRate:    rate.AnnualRate,
+		CreatedAt:     rate.CreatedAt,
+	}
+}
+
+type accrualModel struct {
+	bun.BaseModel `bun:"table:interest_accruals"`
+
+	AccountID  uuid.UUID     `bun:"account_id,pk"`
+	AccruedOn  time.Time     `bun:"accrued_on,pk"`
+	Balance    float64       `bun:"balance"`
+	AnnualRate float64       `bun:"annual_rate"`
+	Amount     float64       `bun:"amount"`
+	PayoutID   uuid.NullUUID `bun:"payout_id"`
+	CreatedAt  time.Time     `bun:"created_at,notnull"`
+}
+
+type payoutModel struct {
+	bun.BaseModel `bun:"table:interest_payouts"`
+
+	ID            uuid.UUID `bun:"id,pk"`
+	AccountID     uuid.UUID `bun:"account_id"`
+	Month         time.Time `bun:"month"`
+	GrossAmount   float64   `bun:"gross_amount"`
+	TaxAmount     float64   `bun:"tax_amount"`
+	NetAmount     float64   `bun:"net_amount"`
+	TransactionID uuid.UUID `bun:"transaction_id"`
+	CreatedAt     time.Time `bun:"created_at,notnull"`
+}
+
+// pendingInterestModel sums the accruals of an account not capitalized yet.
+type pendingInterestModel struct {
+	AccountID     uuid.UUID `bun:"account_id"`
+	Amount        float64   `bun:"amount"`
+	LastAccruedOn time.Time `bun:"last_accrued_on"`
+}
@@ -0,0 +1,292 @@
+package interest
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/exchange"
+	"github.com/dalmarcogd/dock-test/internal/products"
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+const interestPayoutsTransactionConstraint = "interest_payouts_transaction"
+
+var errPayoutAlreadyExists = errors.New("the interest credited by this transaction was already paid out")
+
+type Repository interface {
+	SaveRate(ctx context.Context, model rateModel) (rateModel, error)
+	ListRates(ctx context.Context) ([]rateModel, error)
+	GetRateAt(ctx context.Context, date time.Time) (rateModel, error)
+	Accrue(ctx context.Context, date time.Time, rate rateModel, dailyRate float64) (int, error)
+	ListPending(ctx context.Context, payoutMonth time.Time) ([]pendingInterestModel, error)
+	GetPending(ctx context.Context, accountID uuid.UUID, until time.Time) (pendingInterestModel, error)
+	CreatePayout(
+		ctx context.Context,
+		model payoutModel,
+		transactionKey string,
+		lastAccruedOn time.Time,
+	) (payoutModel, error)
+	ListPayoutsByAccountID(ctx context.Context, accountID uuid.UUID) ([]payoutModel, error)
+}
+
+type repository struct {
+	tracer tracer.Tracer
+	db     database.Database
+}
+
+func NewRepository(t tracer.Tracer, db database.Database) Repository {
+	return repository{
+		tracer: t,
+		db:     db,
+	}
+}
+
+// SaveRate stores the rate, replacing the one already effective from the same date.
+func (r repository) SaveRate(ctx context.Context, model rateModel) (rateModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	model.CreatedAt = time.Now().UTC()
+
+	_, err := r.db.Master().
+		NewInsert().
+		Model(&model).
+		On("CONFLICT (effective_from) DO UPDATE").
+		Set("annual_rate = EXCLUDED.annual_rate").
+		Set("created_at = EXCLUDED.created_at").
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return rateModel{}, err
+	}
+
+	return model, nil
+}
+
+func (r repository) ListRates(ctx context.Context) ([]rateModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var models []rateModel
+	err := r.db.Replica().
+		NewSelect().
+		Model(&models).
+		Order("effective_from ASC").
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return models, nil
+}
+
+// GetRateAt returns the rate in effect at the date, or sql.ErrNoRows when there is none.
+func (r repository) GetRateAt(ctx context.Context, date time.Time) (rateModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var model rateModel
+	err := r.db.Replica().
+		NewSelect().
+		Model(&model).
+		Where("effective_from <= ?", date).
+		Order("effective_from DESC").
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return rateModel{}, err
+	}
+
+	return model, nil
+}
+
+// accrueQuery accrues the daily interest over the end-of-day balance of every open savings
+// account with a positive balance. Rates are set for the default currency, so only accounts held in
+// it accrue. Accounts already accrued on the date are skipped.
+const accrueQuery = `
+INSERT INTO interest_accruals (account_id, accrued_on, balance, annual_rate, amount)
+SELECT b.account_id, ?, b.balance, ?, ROUND(b.balance * ?, 8)
+FROM (
+	SELECT a.id AS account_id,
+	       COALESCE((SELECT SUM(COALESCE(t.to_amount, t.amount)) FROM transactions AS t
+	                 WHERE t.to_account_id = a.id AND t.created_at < ?), 0) -
+	       COALESCE((SELECT SUM(t.amount) FROM transactions AS t
+	                 WHERE t.from_account_id = a.id AND t.created_at < ?), 0) AS balance
+	FROM accounts AS a
+	WHERE a.type = ? AND a.currency = ? AND a.status <> ? AND a.created_at < ?
+) AS b
+WHERE b.balance > 0
+ON CONFLICT (account_id, accrued_on) DO NOTHING`
+
+func (r repository) Accrue(ctx context.Context, date time.Time, rate rateModel, dailyRate float64) (int, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	endOfDay := date.AddDate(0, 0, 1)
+
+	res, err := r.db.Master().ExecContext(
+		ctx,
+		accrueQuery,
+		date,
+		rate.AnnualRate,
+		dailyRate,
+		endOfDay,
+		endOfDay,
+		products.SavingsType,
+		exchange.DefaultCurrency,
+		accounts.ClosedStatus,
+		endOfDay,
+	)
+	if err != nil {
+		span.RecordError(err)
+		return 0, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		span.RecordError(err)
+		return 0, err
+	}
+
+	return int(rows), nil
+}
+
+// ListPending sums, per open account, the accruals before the end of payoutMonth that were not
+// capitalized yet. The sums are read again under the lock of each account when paid.
+func (r repository) ListPending(ctx context.Context, payoutMonth time.Time) ([]pendingInterestModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var models []pendingInterestModel
+	err := r.db.Replica().
+		NewSelect().
+		ModelTableExpr("interest_accruals AS ia").
+		ColumnExpr("ia.account_id, SUM(ia.amount) AS amount, MAX(ia.accrued_on) AS last_accrued_on").
+		Join("JOIN accounts AS a ON a.id = ia.account_id").
+		Where("ia.payout_id IS NULL").
+		Where("ia.accrued_on < ?", payoutMonth.AddDate(0, 1, 0)).
+		Where("a.status <> ?", accounts.ClosedStatus).
+		Group("ia.account_id").
+		Order("ia.account_id ASC").
+		Scan(ctx, &models)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return models, nil
+}
+
+// GetPending sums the accruals of the account before until that were not capitalized yet, or returns
+// sql.ErrNoRows when there are none.
+func (r repository) GetPending(
+	ctx context.Context,
+	accountID uuid.UUID,
+	until time.Time,
+) (pendingInterestModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var model pendingInterestModel
+	err := r.db.Master().
+		NewSelect().
+		ModelTableExpr("interest_accruals AS ia").
+		ColumnExpr("ia.account_id, SUM(ia.amount) AS amount, MAX(ia.accrued_on) AS last_accrued_on").
+		Where("ia.account_id = ?", accountID).
+		Where("ia.payout_id IS NULL").
+		Where("ia.accrued_on < ?", until).
+		Group("ia.account_id").
+		Scan(ctx, &model)
+	if err != nil {
+		span.RecordError(err)
+		return pendingInterestModel{}, err
+	}
+
+	return model, nil
+}
+
+// CreatePayout records the payout of the interest credited by the transaction with the idempotency key,
+// linking the accruals up to lastAccruedOn not capitalized yet within the same database transaction.
+func (r repository) CreatePayout(
+	ctx context.Context,
+	model payoutModel,
+	transactionKey string,
+	lastAccruedOn time.Time,
+) (payoutModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	model.ID = uuid.New()
+	model.CreatedAt = time.Now().UTC()
+
+	err := r.db.Master().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		err := tx.NewSelect().
+			Table("transactions").
+			Column("id").
+			Where("idempotency_key = ?", transactionKey).
+			Scan(ctx, &model.TransactionID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.NewInsert().Model(&model).Returning("*").Exec(ctx)
+		if err != nil {
+			if isConstraintViolation(err, interestPayoutsTransactionConstraint) {
+				return errPayoutAlreadyExists
+			}
+			return err
+		}
+
+		_, err = tx.NewUpdate().
+			Model((*accrualModel)(nil)).
+			Set("payout_id = ?", model.ID).
+			Where("account_id = ?", model.AccountID).
+			Where("payout_id IS NULL").
+			Where("accrued_on <= ?", lastAccruedOn).
+			Exec(ctx)
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		return payoutModel{}, err
+	}
+
+	return model, nil
+}
+
+func (r repository) ListPayoutsByAccountID(ctx context.Context, accountID uuid.UUID) ([]payoutModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var models []payoutModel
+	err := r.db.Replica().
+		NewSelect().
+		Model(&models).
+		Where("account_id = ?", accountID).
+		Order("month ASC").
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func isConstraintViolation(err error, constraint string) bool {
+	var pgErr pgdriver.Error
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.IntegrityViolation() && pgErr.Field('n') == constraint
+}
@@ -0,0 +1,157 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/interest/repository.go
+
+// Package interest is a generated GoMock package.
+package interest
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Accrue mocks base method.
+func (m *MockRepository) Accrue(ctx context.Context, date time.Time, rate rateModel, dailyRate float64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accrue", ctx, date, rate, dailyRate)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Accrue indicates an expected call of Accrue.
+func (mr *MockRepositoryMockRecorder) Accrue(ctx, date, rate, dailyRate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accrue", reflect.TypeOf((*MockRepository)(nil).Accrue), ctx, date, rate, dailyRate)
+}
+
+// CreatePayout mocks base method.
+func (m *MockRepository) CreatePayout(ctx context.Context, model payoutModel, transactionKey string, lastAccruedOn time.Time) (payoutModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayout", ctx, model, transactionKey, lastAccruedOn)
+	ret0, _ := ret[0].(payoutModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePayout indicates an expected call of CreatePayout.
+func (mr *MockRepositoryMockRecorder) CreatePayout(ctx, model, transactionKey, lastAccruedOn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayout", reflect.TypeOf((*MockRepository)(nil).CreatePayout), ctx, model, transactionKey, lastAccruedOn)
+}
+
+// GetPending mocks base method.
+func (m *MockRepository) GetPending(ctx context.Context, accountID uuid.UUID, until time.Time) (pendingInterestModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPending", ctx, accountID, until)
+	ret0, _ := ret[0].(pendingInterestModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPending indicates an expected call of GetPending.
+func (mr *MockRepositoryMockRecorder) GetPending(ctx, accountID, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPending", reflect.TypeOf((*MockRepository)(nil).GetPending), ctx, accountID, until)
+}
+
+// GetRateAt mocks base method.
+func (m *MockRepository) GetRateAt(ctx context.Context, date time.Time) (rateModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRateAt", ctx, date)
+	ret0, _ := ret[0].(rateModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRateAt indicates an expected call of GetRateAt.
+func (mr *MockRepositoryMockRecorder) GetRateAt(ctx, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRateAt", reflect.TypeOf((*MockRepository)(nil).GetRateAt), ctx, date)
+}
+
+// ListPayoutsByAccountID mocks base method.
+func (m *MockRepository) ListPayoutsByAccountID(ctx context.Context, accountID uuid.UUID) ([]payoutModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPayoutsByAccountID", ctx, accountID)
+	ret0, _ := ret[0].([]payoutModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPayoutsByAccountID indicates an expected call of ListPayoutsByAccountID.
+func (mr *MockRepositoryMockRecorder) ListPayoutsByAccountID(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayoutsByAccountID", reflect.TypeOf((*MockRepository)(nil).ListPayoutsByAccountID), ctx, accountID)
+}
+
+// ListPending mocks base method.
+func (m *MockRepository) ListPending(ctx context.Context, payoutMonth time.Time) ([]pendingInterestModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPending", ctx, payoutMonth)
+	ret0, _ := ret[0].([]pendingInterestModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPending indicates an expected call of ListPending.
+func (mr *MockRepositoryMockRecorder) ListPending(ctx, payoutMonth interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPending", reflect.TypeOf((*MockRepository)(nil).ListPending), ctx, payoutMonth)
+}
+
+// ListRates mocks base method.
+func (m *MockRepository) ListRates(ctx context.Context) ([]rateModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRates", ctx)
+	ret0, _ := ret[0].([]rateModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRates indicates an expected call of ListRates.
+func (mr *MockRepositoryMockRecorder) ListRates(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRates", reflect.TypeOf((*MockRepository)(nil).ListRates), ctx)
+}
+
+// SaveRate mocks base method.
+func (m *MockRepository) SaveRate(ctx context.Context, model rateModel) (rateModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRate", ctx, model)
+	ret0, _ := ret[0].(rateModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveRate indicates an expected call of SaveRate.
+func (mr *MockRepositoryMockRecorder) SaveRate(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRate", reflect.TypeOf((*MockRepository)(nil).SaveRate), ctx, model)
+}
@@ -0,0 +1,166 @@
+//go:build integration
+
+package interest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/holders"
+	"github.com/dalmarcogd/dock-test/internal/products"
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/testingcontainers"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+
+	url, closeFunc, err := testingcontainers.NewPostgresContainer()
+	assert.NoError(t, err)
+	defer closeFunc(ctx) //nolint:errcheck
+
+	_, callerPath, _, _ := runtime.Caller(0) //nolint:dogsled
+	err = testingcontainers.RunMigrateDatabase(
+		url,
+		fmt.Sprintf("file://%s/../../migrations/", filepath.Dir(callerPath)),
+	)
+	assert.NoError(t, err)
+
+	db, err := database.New(tracer.NewNoop(), url, url)
+	assert.NoError(t, err)
+
+	holdersRepo := holders.NewRepository(tracer.NewNoop(), db)
+	holderModel, err := holdersRepo.Create(ctx, holders.HolderModel{
+		ID:             uuid.New(),
+		Name:           gofakeit.Name(),
+		DocumentNumber: gofakeit.SSN(),
+	})
+	assert.NoError(t, err)
+
+	accSvc := accounts.NewService(tracer.NewNoop(), accounts.NewRepository(tracer.NewNoop(), db), holdersRepo, false, 0)
+	account, err := accSvc.Create(ctx, accounts.Account{
+		Name:           gofakeit.Name(),
+		DocumentNumber: holderModel.DocumentNumber,
+		Type:           products.SavingsType,
+	})
+	assert.NoError(t, err)
+
+	interestAccountID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	credit := func(from interface{}, to uuid.UUID, amount float64, idempotencyKey interface{}) {
+		_, err := db.Master().ExecContext(
+			ctx,
+			`INSERT INTO transactions
+			 (id, from_account_id, to_account_id, type, amount, description, idempotency_key, created_at)
+			 VALUES (?, ?, ?, 'CREDIT', ?, ?, ?, ?)`,
+			uuid.New().String(),
+			from,
+			to.String(),
+			amount,
+			gofakeit.BeerName(),
+			idempotencyKey,
+			time.Now().UTC(),
+		)
+		assert.NoError(t, err)
+	}
+
+	credit(nil, account.ID, 1000, nil)
+
+	repo := NewRepository(tracer.NewNoop(), db)
+	today := day(time.Now())
+
+	t.Run("rates effective by date", func(t *testing.T) {
+		_, err := repo.GetRateAt(ctx, today)
+		assert.Error(t, err)
+
+		_, err = repo.SaveRate(ctx, rateModel{EffectiveFrom: today.AddDate(0, 0, -10), AnnualRate: 0.05})
+		assert.NoError(t, err)
+		_, err = repo.SaveRate(ctx, rateModel{EffectiveFrom: today.AddDate(0, 0, -1), AnnualRate: 0.1})
+		assert.NoError(t, err)
+		_, err = repo.SaveRate(ctx, rateModel{EffectiveFrom: today.AddDate(0, 0, 5), AnnualRate: 0.2})
+		assert.NoError(t, err)
+
+		rate, err := repo.GetRateAt(ctx, today)
+		assert.NoError(t, err)
+		assert.Equal(t, 0.1, rate.AnnualRate)
+
+		rate, err = repo.GetRateAt(ctx, today.AddDate(0, 0, -5))
+		assert.NoError(t, err)
+		assert.Equal(t, 0.05, rate.AnnualRate)
+
+		rates, err := repo.ListRates(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, rates, 3)
+	})
+
+	t.Run("accrue once per day over end-of-day balances", func(t *testing.T) {
+		rate, err := repo.GetRateAt(ctx, today)
+		assert.NoError(t, err)
+
+		accrued, err := repo.Accrue(ctx, today.AddDate(0, 0, -1), rate, newRate(rate).DailyRate())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, accrued)
+
+		accrued, err = repo.Accrue(ctx, today, rate, newRate(rate).DailyRate())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, accrued)
+
+		accrued, err = repo.Accrue(ctx, today, rate, newRate(rate).DailyRate())
+		assert.NoError(t, err)
+		assert.Equal(t, 0, accrued)
+	})
+
+	t.Run("pay pending interest once", func(t *testing.T) {
+		payoutMonth := month(today)
+		until := today.AddDate(0, 0, 1)
+
+		pending, err := repo.ListPending(ctx, payoutMonth)
+		assert.NoError(t, err)
+		assert.Len(t, pending, 1)
+		assert.Equal(t, account.ID, pending[0].AccountID)
+
+		accrued, err := repo.GetPending(ctx, account.ID, until)
+		assert.NoError(t, err)
+		assert.InDelta(t, 1000*(math.Pow(1.1, 1.0/daysInYear)-1), accrued.Amount, 0.00001)
+		assert.True(t, today.Equal(accrued.LastAccruedOn))
+
+		key := payoutKey(account.ID, accrued.LastAccruedOn)
+		payout := payoutModel{AccountID: account.ID, Month: payoutMonth, GrossAmount: 0.26, TaxAmount: 0.04, NetAmount: 0.22}
+
+		_, err = repo.CreatePayout(ctx, payout, key, accrued.LastAccruedOn)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		credit(interestAccountID.String(), account.ID, 0.22, key)
+
+		created, err := repo.CreatePayout(ctx, payout, key, accrued.LastAccruedOn)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, created.ID)
+		assert.NotEmpty(t, created.TransactionID)
+
+		pending, err = repo.ListPending(ctx, payoutMonth)
+		assert.NoError(t, err)
+		assert.Empty(t, pending)
+
+		_, err = repo.GetPending(ctx, account.ID, until)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		_, err = repo.CreatePayout(ctx, payout, key, accrued.LastAccruedOn)
+		assert.ErrorIs(t, err, errPayoutAlreadyExists)
+
+		payouts, err := repo.ListPayoutsByAccountID(ctx, account.ID)
+		assert.NoError(t, err)
+		assert.Len(t, payouts, 1)
+		assert.Equal(t, 0.22, payouts[0].NetAmount)
+	})
+}
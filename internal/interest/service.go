@@ -0,0 +1,349 @@
+package interest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/transactions"
+	"github.com/dalmarcogd/dock-test/pkg/distlock"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrInvalidAnnualRate          = errors.New("the annual rate must be between 0 and 1")
+	ErrRateEffectiveDateRequired  = errors.New("the date the rate becomes effective is required")
+	ErrInterestAccountNotDefined  = errors.New("the interest account is not defined")
+	ErrInvalidWithholdingTaxRate  = errors.New("the withholding tax rate must be between 0 and 1")
+	ErrCapitalizationMonthNotDone = errors.New("only past months can be capitalized")
+	ErrFailLockAccount            = errors.New("was not possible to lock account to pay its interest")
+)
+
+type Service interface {
+	SetRate(ctx context.Context, rate Rate) (Rate, error)
+	ListRates(ctx context.Context) ([]Rate, error)
+	Accrue(ctx context.Context, date time.Time) (int, error)
+	Capitalize(ctx context.Context, payoutMonth time.Time) (int, error)
+	Settle(ctx context.Context, accountID uuid.UUID) error
+	ListPayouts(ctx context.Context, accountID uuid.UUID) ([]Payout, error)
+}
+
+type service struct {
+	tracer            tracer.Tracer
+	repository        Repository
+	locker            distlock.DistLock
+	transactionsSvc   transactions.Service
+	interestAccountID uuid.UUID
+	taxRate           float64
+}
+
+// NewService builds the interest service. Capitalizations are paid from interestAccountID and
+// taxRate, a fraction of the gross interest, is withheld from each payout.
+func NewService(
+	t tracer.Tracer,
+	r Repository,
+	l distlock.DistLock,
+	ts transactions.Service,
+	interestAccountID uuid.UUID,
+	taxRate float64,
+) Service {
+	return service{
+		tracer:            t,
+		repository:        r,
+		locker:            l,
+		transactionsSvc:   ts,
+		interestAccountID: interestAccountID,
+		taxRate:           taxRate,
+	}
+}
+
+func (s service) SetRate(ctx context.Context, rate Rate) (Rate, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if rate.EffectiveFrom.IsZero() {
+		span.RecordError(ErrRateEffectiveDateRequired)
+		return Rate{}, ErrRateEffectiveDateRequired
+	}
+
+	if rate.AnnualRate < 0 || rate.AnnualRate > 1 {
+		span.RecordError(ErrInvalidAnnualRate)
+		return Rate{}, ErrInvalidAnnualRate
+	}
+
+	rate.EffectiveFrom = day(rate.EffectiveFrom)
+
+	model, err := s.repository.SaveRate(ctx, newRateModel(rate))
+	if err != nil {
+		zapctx.L(ctx).Error("interest_service_save_rate_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return Rate{}, err
+	}
+
+	zapctx.L(ctx).Info(
+		"interest_rate_set",
+		zap.Float64("annual_rate", model.AnnualRate),
+		zap.Time("effective_from", model.EffectiveFrom),
+	)
+
+	return newRate(model), nil
+}
+
+func (s service) ListRates(ctx context.Context) ([]Rate, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	models, err := s.repository.ListRates(ctx)
+	if err != nil {
+		zapctx.L(ctx).Error("interest_service_list_rates_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	rates := make([]Rate, len(models))
+	for i, model := range models {
+		rates[i] = newRate(model)
+	}
+
+	return rates, nil
+}
+
+// Accrue records the interest of the date over the end-of-day balance of the savings accounts,
+// using the rate in effect at that date. Accruing the same date again only covers the accounts
+// missing it, and nothing accrues while no rate is effective.
+func (s service) Accrue(ctx context.Context, date time.Time) (int, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	date = day(date)
+
+	model, err := s.repository.GetRateAt(ctx, date)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			zapctx.L(ctx).Info("interest_service_no_rate_effective", zap.Time("date", date))
+			return 0, nil
+		}
+		zapctx.L(ctx).Error("interest_service_get_rate_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return 0, err
+	}
+
+	accrued, err := s.repository.Accrue(ctx, date, model, newRate(model).DailyRate())
+	if err != nil {
+		zapctx.L(ctx).Error(
+			"interest_service_accrue_repository_error",
+			zap.Time("date", date),
+			zap.Error(err),
+		)
+		span.RecordError(err)
+		return 0, err
+	}
+
+	zapctx.L(ctx).Info(
+		"interest_accrued",
+		zap.Time("date", date),
+		zap.Float64("annual_rate", model.AnnualRate),
+		zap.Int("accounts", accrued),
+	)
+
+	return accrued, nil
+}
+
+// Capitalize credits every account with the interest accrued up to the end of payoutMonth, net of
+// the withheld tax. Accrued interest already paid is skipped, and amounts below one cent stay pending
+// until the next capitalization.
+func (s service) Capitalize(ctx context.Context, payoutMonth time.Time) (int, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if s.interestAccountID == uuid.Nil {
+		span.RecordError(ErrInterestAccountNotDefined)
+		return 0, ErrInterestAccountNotDefined
+	}
+
+	if s.taxRate < 0 || s.taxRate > 1 {
+		span.RecordError(ErrInvalidWithholdingTaxRate)
+		return 0, ErrInvalidWithholdingTaxRate
+	}
+
+	payoutMonth = month(payoutMonth)
+	if !payoutMonth.Before(month(time.Now())) {
+		span.RecordError(ErrCapitalizationMonthNotDone)
+		return 0, ErrCapitalizationMonthNotDone
+	}
+
+	pending, err := s.repository.ListPending(ctx, payoutMonth)
+	if err != nil {
+		zapctx.L(ctx).Error("interest_service_list_pending_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return 0, err
+	}
+
+	var paid int
+	for _, p := range pending {
+		ok, err := s.pay(ctx, p.AccountID, payoutMonth.AddDate(0, 1, 0))
+		if err != nil {
+			zapctx.L(ctx).Warn(
+				"interest_service_payout_error",
+				zap.String("account_id", p.AccountID.String()),
+				zap.Error(err),
+			)
+			span.RecordError(err)
+			continue
+		}
+		if ok {
+			paid++
+		}
+	}
+
+	zapctx.L(ctx).Info(
+		"interest_capitalized",
+		zap.String("month", payoutMonth.Format("2006-01")),
+		zap.Int("accounts", paid),
+	)
+
+	return paid, nil
+}
+
+// Settle credits the account with all the interest it accrued and was not paid yet, net of the withheld
+// tax, so the account is closed with its interest paid. Less than one cent is not paid.
+func (s service) Settle(ctx context.Context, accountID uuid.UUID) error {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if s.taxRate < 0 || s.taxRate > 1 {
+		span.RecordError(ErrInvalidWithholdingTaxRate)
+		return ErrInvalidWithholdingTaxRate
+	}
+
+	_, err := s.pay(ctx, accountID, day(time.Now()).AddDate(0, 0, 1))
+	if err != nil {
+		zapctx.L(ctx).Error(
+			"interest_service_settle_error",
+			zap.String("account_id", accountID.String()),
+			zap.Error(err),
+		)
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+// pay credits the account with the interest accrued before until and not paid yet, holding the lock of
+// the account so its accruals are paid once. The credit is made with an idempotency key of the last
+// accrual paid, so a payout not recorded is recorded when paying again instead of credited twice.
+func (s service) pay(ctx context.Context, accountID uuid.UUID, until time.Time) (bool, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	interestAccountLockerKey := fmt.Sprintf("interest-account-%s", accountID.String())
+	defer s.locker.Release(ctx, interestAccountLockerKey)
+
+	if !s.locker.Acquire(ctx, interestAccountLockerKey, 50*time.Millisecond, 3) {
+		span.RecordError(ErrFailLockAccount)
+		return false, ErrFailLockAccount
+	}
+
+	pending, err := s.repository.GetPending(ctx, accountID, until)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	} else if err != nil {
+		zapctx.L(ctx).Error("interest_service_get_pending_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return false, err
+	}
+
+	gross := roundCents(pending.Amount)
+	tax := roundCents(gross * s.taxRate)
+	net := roundCents(gross - tax)
+	if net <= 0 {
+		return false, nil
+	}
+
+	if s.interestAccountID == uuid.Nil {
+		span.RecordError(ErrInterestAccountNotDefined)
+		return false, ErrInterestAccountNotDefined
+	}
+
+	payoutMonth := month(pending.LastAccruedOn)
+	key := payoutKey(accountID, pending.LastAccruedOn)
+
+	_, err = s.transactionsSvc.CreditInterest(ctx, transactions.Transaction{
+		From:   s.interestAccountID,
+		To:     accountID,
+		Amount: net,
+		Description: fmt.Sprintf(
+			"savings interest %s: gross %.2f, tax withheld %.2f",
+			payoutMonth.Format("2006-01"),
+			gross,
+			tax,
+		),
+		IdempotencyKey: key,
+	})
+	if err != nil && !errors.Is(err, transactions.ErrTransactionAlreadyMade) {
+		span.RecordError(err)
+		return false, err
+	}
+
+	_, err = s.repository.CreatePayout(
+		ctx,
+		payoutModel{
+			AccountID:   accountID,
+			Month:       payoutMonth,
+			GrossAmount: gross,
+			TaxAmount:   tax,
+			NetAmount:   net,
+		},
+		key,
+		pending.LastAccruedOn,
+	)
+	if errors.Is(err, errPayoutAlreadyExists) {
+		return false, nil
+	} else if err != nil {
+		zapctx.L(ctx).Error("interest_service_create_payout_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return false, err
+	}
+
+	zapctx.L(ctx).Info(
+		"interest_paid",
+		zap.String("account_id", accountID.String()),
+		zap.Float64("net_amount", net),
+	)
+
+	return true, nil
+}
+
+func (s service) ListPayouts(ctx context.Context, accountID uuid.UUID) ([]Payout, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	models, err := s.repository.ListPayoutsByAccountID(ctx, accountID)
+	if err != nil {
+		zapctx.L(ctx).Error(
+			"interest_service_list_payouts_repository_error",
+			zap.String("account_id", accountID.String()),
+			zap.Error(err),
+		)
+		span.RecordError(err)
+		return nil, err
+	}
+
+	payouts := make([]Payout, len(models))
+	for i, model := range models {
+		payouts[i] = newPayout(model)
+	}
+
+	return payouts, nil
+}
+
+// payoutKey is the idempotency key of the credit paying the interest accrued by the account up to the date.
+func payoutKey(accountID uuid.UUID, lastAccruedOn time.Time) string {
+	return fmt.Sprintf("interest:%s:%s", accountID.String(), lastAccruedOn.Format("2006-01-02"))
+}
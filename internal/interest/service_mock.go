@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/interest/service.go
+
+// Package interest is a generated GoMock package.
+package interest
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Accrue mocks base method.
+func (m *MockService) Accrue(ctx context.Context, date time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accrue", ctx, date)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Accrue indicates an expected call of Accrue.
+func (mr *MockServiceMockRecorder) Accrue(ctx, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accrue", reflect.TypeOf((*MockService)(nil).Accrue), ctx, date)
+}
+
+// Capitalize mocks base method.
+func (m *MockService) Capitalize(ctx context.Context, payoutMonth time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capitalize", ctx, payoutMonth)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Capitalize indicates an expected call of Capitalize.
+func (mr *MockServiceMockRecorder) Capitalize(ctx, payoutMonth interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capitalize", reflect.TypeOf((*MockService)(nil).Capitalize), ctx, payoutMonth)
+}
+
+// ListPayouts mocks base method.
+func (m *MockService) ListPayouts(ctx context.Context, accountID uuid.UUID) ([]Payout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPayouts", ctx, accountID)
+	ret0, _ := ret[0].([]Payout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPayouts indicates an expected call of ListPayouts.
+func (mr *MockServiceMockRecorder) ListPayouts(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayouts", reflect.TypeOf((*MockService)(nil).ListPayouts), ctx, accountID)
+}
+
+// ListRates mocks base method.
+func (m *MockService) ListRates(ctx context.Context) ([]Rate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRates", ctx)
+	ret0, _ := ret[0].([]Rate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRates indicates an expected call of ListRates.
+func (mr *MockServiceMockRecorder) ListRates(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRates", reflect.TypeOf((*MockService)(nil).ListRates), ctx)
+}
+
+// SetRate mocks base method.
+func (m *MockService) SetRate(ctx context.Context, rate Rate) (Rate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRate", ctx, rate)
+	ret0, _ := ret[0].(Rate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRate indicates an expected call of SetRate.
+func (mr *MockServiceMockRecorder) SetRate(ctx, rate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRate", reflect.TypeOf((*MockService)(nil).SetRate), ctx, rate)
+}
+
+// Settle mocks base method.
+func (m *MockService) Settle(ctx context.Context, accountID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Settle", ctx, accountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Settle indicates an expected call of Settle.
+func (mr *MockServiceMockRecorder) Settle(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Settle", reflect.TypeOf((*MockService)(nil).Settle), ctx, accountID)
+}
@@ -0,0 +1,276 @@
+//go:build unit
+
+package interest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/transactions"
+	"github.com/dalmarcogd/dock-test/pkg/distlock"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRate_DailyRate(t *testing.T) {
+	rate := Rate{AnnualRate: 0.1}
+
+	compounded := 1.0
+	for i := 0; i < daysInYear; i++ {
+		compounded *= 1 + rate.DailyRate()
+	}
+
+	assert.InDelta(t, 1.1, compounded, 0.0000001)
+	assert.Zero(t, Rate{}.DailyRate())
+}
+
+func TestService_SetRate(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	svc := NewService(
+		tracer.NewNoop(),
+		repoMock,
+		distlock.NewDistlockNoop(),
+		transactions.NewMockService(ctrl),
+		uuid.New(),
+		0.15,
+	)
+
+	t.Run("fail set, effective date required", func(t *testing.T) {
+		rate, err := svc.SetRate(ctx, Rate{AnnualRate: 0.1})
+		assert.ErrorIs(t, err, ErrRateEffectiveDateRequired)
+		assert.Empty(t, rate)
+	})
+
+	t.Run("fail set, invalid rate", func(t *testing.T) {
+		rate, err := svc.SetRate(ctx, Rate{AnnualRate: 1.5, EffectiveFrom: time.Now()})
+		assert.ErrorIs(t, err, ErrInvalidAnnualRate)
+		assert.Empty(t, rate)
+	})
+
+	t.Run("success set, effective from the start of the day", func(t *testing.T) {
+		effectiveFrom := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+		repoMock.EXPECT().
+			SaveRate(ctx, rateModel{EffectiveFrom: effectiveFrom, AnnualRate: 0.1}).
+			Return(rateModel{EffectiveFrom: effectiveFrom, AnnualRate: 0.1, CreatedAt: time.Now()}, nil)
+
+		rate, err := svc.SetRate(ctx, Rate{AnnualRate: 0.1, EffectiveFrom: effectiveFrom.Add(15 * time.Hour)})
+		assert.NoError(t, err)
+		assert.Equal(t, effectiveFrom, rate.EffectiveFrom)
+	})
+}
+
+func TestService_Accrue(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	svc := NewService(
+		tracer.NewNoop(),
+		repoMock,
+		distlock.NewDistlockNoop(),
+		transactions.NewMockService(ctrl),
+		uuid.New(),
+		0.15,
+	)
+
+	date := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("success accrue, no rate effective", func(t *testing.T) {
+		repoMock.EXPECT().GetRateAt(ctx, date).Return(rateModel{}, sql.ErrNoRows)
+
+		accrued, err := svc.Accrue(ctx, date.Add(10*time.Hour))
+		assert.NoError(t, err)
+		assert.Zero(t, accrued)
+	})
+
+	t.Run("fail accrue, repository error", func(t *testing.T) {
+		rate := rateModel{EffectiveFrom: date, AnnualRate: 0.1}
+		repoMock.EXPECT().GetRateAt(ctx, date).Return(rate, nil)
+		repoMock.EXPECT().Accrue(ctx, date, rate, gomock.Any()).Return(0, errors.New("unexpected"))
+
+		accrued, err := svc.Accrue(ctx, date)
+		assert.Error(t, err)
+		assert.Zero(t, accrued)
+	})
+
+	t.Run("success accrue with the rate effective at the date", func(t *testing.T) {
+		rate := rateModel{EffectiveFrom: date.AddDate(0, -1, 0), AnnualRate: 0.1}
+		repoMock.EXPECT().GetRateAt(ctx, date).Return(rate, nil)
+		repoMock.EXPECT().Accrue(ctx, date, rate, Rate{AnnualRate: 0.1}.DailyRate()).Return(3, nil)
+
+		accrued, err := svc.Accrue(ctx, date)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, accrued)
+	})
+}
+
+func TestService_Capitalize(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	trxSvcMock := transactions.NewMockService(ctrl)
+	interestAccountID := uuid.New()
+	svc := NewService(tracer.NewNoop(), repoMock, distlock.NewDistlockNoop(), trxSvcMock, interestAccountID, 0.15)
+
+	payoutMonth := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	until := payoutMonth.AddDate(0, 1, 0)
+
+	t.Run("fail capitalize, current month", func(t *testing.T) {
+		paid, err := svc.Capitalize(ctx, time.Now())
+		assert.ErrorIs(t, err, ErrCapitalizationMonthNotDone)
+		assert.Zero(t, paid)
+	})
+
+	t.Run("fail capitalize, interest account not defined", func(t *testing.T) {
+		paid, err := NewService(tracer.NewNoop(), repoMock, distlock.NewDistlockNoop(), trxSvcMock, uuid.Nil, 0.15).
+			Capitalize(ctx, payoutMonth)
+		assert.ErrorIs(t, err, ErrInterestAccountNotDefined)
+		assert.Zero(t, paid)
+	})
+
+	t.Run("success capitalize, withholding tax", func(t *testing.T) {
+		accountID := uuid.New()
+		dustAccountID := uuid.New()
+		paidAccountID := uuid.New()
+		closedAccountID := uuid.New()
+		lastAccruedOn := time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+		key := "interest:" + accountID.String() + ":2026-02-28"
+
+		repoMock.EXPECT().
+			ListPending(ctx, payoutMonth).
+			Return([]pendingInterestModel{
+				{AccountID: accountID},
+				{AccountID: dustAccountID},
+				{AccountID: paidAccountID},
+				{AccountID: closedAccountID},
+			}, nil)
+
+		repoMock.EXPECT().
+			GetPending(ctx, accountID, until).
+			Return(pendingInterestModel{AccountID: accountID, Amount: 12.3456789, LastAccruedOn: lastAccruedOn}, nil)
+		trxSvcMock.EXPECT().
+			CreditInterest(ctx, transactions.Transaction{
+				From:           interestAccountID,
+				To:             accountID,
+				Amount:         10.5,
+				Description:    "savings interest 2026-02: gross 12.35, tax withheld 1.85",
+				IdempotencyKey: key,
+			}).
+			Return(transactions.Transaction{ID: uuid.New()}, nil)
+		repoMock.EXPECT().
+			CreatePayout(
+				ctx,
+				payoutModel{
+					AccountID:   accountID,
+					Month:       payoutMonth,
+					GrossAmount: 12.35,
+					TaxAmount:   1.85,
+					NetAmount:   10.5,
+				},
+				key,
+				lastAccruedOn,
+			).
+			Return(payoutModel{ID: uuid.New()}, nil)
+
+		repoMock.EXPECT().
+			GetPending(ctx, dustAccountID, until).
+			Return(pendingInterestModel{AccountID: dustAccountID, Amount: 0.004, LastAccruedOn: lastAccruedOn}, nil)
+
+		repoMock.EXPECT().
+			GetPending(ctx, paidAccountID, until).
+			Return(pendingInterestModel{}, sql.ErrNoRows)
+
+		repoMock.EXPECT().
+			GetPending(ctx, closedAccountID, until).
+			Return(pendingInterestModel{AccountID: closedAccountID, Amount: 1, LastAccruedOn: lastAccruedOn}, nil)
+		trxSvcMock.EXPECT().
+			CreditInterest(ctx, gomock.Any()).
+			Return(transactions.Transaction{}, transactions.ErrAccountInactive)
+
+		paid, err := svc.Capitalize(ctx, payoutMonth.AddDate(0, 0, 20))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, paid)
+	})
+
+	t.Run("success capitalize, credited payout recorded again", func(t *testing.T) {
+		accountID := uuid.New()
+		lastAccruedOn := time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)
+
+		repoMock.EXPECT().ListPending(ctx, payoutMonth).Return([]pendingInterestModel{{AccountID: accountID}}, nil)
+		repoMock.EXPECT().
+			GetPending(ctx, accountID, until).
+			Return(pendingInterestModel{AccountID: accountID, Amount: 1, LastAccruedOn: lastAccruedOn}, nil)
+		trxSvcMock.EXPECT().
+			CreditInterest(ctx, gomock.Any()).
+			Return(transactions.Transaction{}, transactions.ErrTransactionAlreadyMade)
+		repoMock.EXPECT().
+			CreatePayout(ctx, gomock.Any(), "interest:"+accountID.String()+":2026-02-28", lastAccruedOn).
+			Return(payoutModel{ID: uuid.New()}, nil)
+
+		paid, err := svc.Capitalize(ctx, payoutMonth)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, paid)
+	})
+}
+
+func TestService_Settle(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	trxSvcMock := transactions.NewMockService(ctrl)
+	interestAccountID := uuid.New()
+	svc := NewService(tracer.NewNoop(), repoMock, distlock.NewDistlockNoop(), trxSvcMock, interestAccountID, 0.15)
+
+	accountID := uuid.New()
+	until := day(time.Now()).AddDate(0, 0, 1)
+
+	t.Run("success settle, nothing pending", func(t *testing.T) {
+		repoMock.EXPECT().GetPending(ctx, accountID, until).Return(pendingInterestModel{}, sql.ErrNoRows)
+
+		assert.NoError(t, svc.Settle(ctx, accountID))
+	})
+
+	t.Run("fail settle, credit not made", func(t *testing.T) {
+		repoMock.EXPECT().
+			GetPending(ctx, accountID, until).
+			Return(pendingInterestModel{AccountID: accountID, Amount: 1, LastAccruedOn: day(time.Now())}, nil)
+		trxSvcMock.EXPECT().
+			CreditInterest(ctx, gomock.Any()).
+			Return(transactions.Transaction{}, transactions.ErrAccountCreditsBlocked)
+
+		assert.ErrorIs(t, svc.Settle(ctx, accountID), transactions.ErrAccountCreditsBlocked)
+	})
+
+	t.Run("success settle, accrued today", func(t *testing.T) {
+		today := day(time.Now())
+		repoMock.EXPECT().
+			GetPending(ctx, accountID, until).
+			Return(pendingInterestModel{AccountID: accountID, Amount: 2, LastAccruedOn: today}, nil)
+		trxSvcMock.EXPECT().
+			CreditInterest(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, transaction transactions.Transaction) (transactions.Transaction, error) {
+				assert.Equal(t, 1.7, transaction.Amount)
+				assert.Equal(t, payoutKey(accountID, today), transaction.IdempotencyKey)
+				return transactions.Transaction{ID: uuid.New()}, nil
+			})
+		repoMock.EXPECT().
+			CreatePayout(ctx, gomock.Any(), payoutKey(accountID, today), today).
+			Return(payoutModel{ID: uuid.New()}, nil)
+
+		assert.NoError(t, svc.Settle(ctx, accountID))
+	})
+}
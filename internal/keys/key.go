@@ -0,0 +1,181 @@
+package keys
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/pkg/document"
+	"github.com/google/uuid"
+)
+
+// Type is the kind of value a key is made of.
+type Type string
+
+const (
+	DocumentType Type = "DOCUMENT"
+	EmailType    Type = "EMAIL"
+	PhoneType    Type = "PHONE"
+	RandomType   Type = "RANDOM"
+)
+
+var Types = []Type{DocumentType, EmailType, PhoneType, RandomType}
+
+func (t Type) Valid() bool {
+	for _, v := range Types {
+		if t == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// verified reports whether keys of the type must have their ownership verified before they resolve,
+// document keys are checked against the holder and random keys are generated by us.
+func (t Type) verified() bool {
+	return t == EmailType || t == PhoneType
+}
+
+type Status string
+
+const (
+	// PendingStatus keys wait for the verification code sent to the email or phone.
+	PendingStatus Status = "PENDING"
+	ActiveStatus  Status = "ACTIVE"
+)
+
+// Key is an alias that resolves to an account in payments.
+type Key struct {
+	ID         uuid.UUID
+	AccountID  uuid.UUID
+	HolderID   uuid.UUID
+	Type       Type
+	Value      string
+	Status     Status
+	CreatedAt  time.Time
+	VerifiedAt time.Time
+}
+
+func newKey(model keyModel) Key {
+	return Key{
+		ID:         model.ID,
+		AccountID:  model.AccountID,
+		HolderID:   model.HolderID,
+		Type:       model.Type,
+		Value:      model.Value,
+		Status:     model.Status,
+		CreatedAt:  model.CreatedAt,
+		VerifiedAt: model.VerifiedAt,
+	}
+}
+
+// Registration asks for a key pointing at the account on behalf of one of its holders. Value is
+// ignored for random keys.
+type Registration struct {
+	AccountID uuid.UUID
+	// RequestedBy is the id of the principal registering the key, linked to the holder it belongs to.
+	RequestedBy string
+	Type        Type
+	Value       string
+}
+
+// Owner is what a lookup discloses about the account a key points at, the holder data is masked.
+type Owner struct {
+	Key            Key
+	AccountID      uuid.UUID
+	Agency         string
+	Number         string
+	Currency       string
+	HolderName     string
+	DocumentNumber string
+}
+
+func newOwner(model keyModel) Owner {
+	return Owner{
+		Key:            newKey(model),
+		AccountID:      model.AccountID,
+		Agency:         model.AccountAgency,
+		Number:         model.AccountNumber,
+		Currency:       model.AccountCurrency,
+		HolderName:     maskName(model.HolderName),
+		DocumentNumber: maskDocument(model.HolderDocumentNumber),
+	}
+}
+
+var (
+	emailRegexp = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+	phoneRegexp = regexp.MustCompile(`^\+[1-9][0-9]{9,14}$`)
+)
+
+// normalize returns the canonical form of the value for the key type, and false when it is not
+// valid for the type. Emails are lowercased and phones kept in E.164 without separators.
+func normalize(t Type, value string) (string, bool) {
+	value = strings.TrimSpace(value)
+
+	switch t {
+	case DocumentType:
+		value = document.Normalize(value)
+		return value, document.IsValid(value)
+	case EmailType:
+		value = strings.ToLower(value)
+		return value, emailRegexp.MatchString(value)
+	case PhoneType:
+		value = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "").Replace(value)
+		return value, phoneRegexp.MatchString(value)
+	case RandomType:
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return "", false
+		}
+		return id.String(), true
+	}
+
+	return "", false
+}
+
+// detect infers the type of a key from its value, as payers only inform the key itself.
+func detect(value string) Type {
+	value = strings.TrimSpace(value)
+
+	switch {
+	case isUUID(value):
+		return RandomType
+	case strings.Contains(value, "@"):
+		return EmailType
+	case strings.HasPrefix(value, "+"):
+		return PhoneType
+	}
+
+	return DocumentType
+}
+
+func isUUID(value string) bool {
+	_, err := uuid.Parse(value)
+	return err == nil && len(value) == 36
+}
+
+// maskName keeps the first name and the initials of the other names.
+func maskName(name string) string {
+	names := strings.Fields(name)
+	for i := 1; i < len(names); i++ {
+		names[i] = string([]rune(names[i])[0]) + "."
+	}
+
+	return strings.Join(names, " ")
+}
+
+// maskDocument shows only the middle digits of the document, the way they are usually disclosed
+// on receipts: ***.456.789-** for CPFs and **.345.678/0001-** for CNPJs.
+func maskDocument(documentNumber string) string {
+	documentNumber = document.Normalize(documentNumber)
+
+	switch len(documentNumber) {
+	case 11:
+		return "***." + documentNumber[3:6] + "." + documentNumber[6:9] + "-**"
+	case 14:
+		return "**." + documentNumber[2:5] + "." + documentNumber[5:8] + "/" + documentNumber[8:12] + "-**"
+	}
+
+	return strings.Repeat("*", len(documentNumber))
+}
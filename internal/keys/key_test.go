@@ -0,0 +1,55 @@
+//go:build unit
+
+package keys
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	value, valid := normalize(DocumentType, "529.982.247-25")
+	assert.True(t, valid)
+	assert.Equal(t, "52998224725", value)
+
+	_, valid = normalize(DocumentType, "529.982.247-26")
+	assert.False(t, valid)
+
+	value, valid = normalize(EmailType, " Maria@Example.com ")
+	assert.True(t, valid)
+	assert.Equal(t, "maria@example.com", value)
+
+	_, valid = normalize(EmailType, "maria@example")
+	assert.False(t, valid)
+
+	value, valid = normalize(PhoneType, "+55 (11) 98765-4321")
+	assert.True(t, valid)
+	assert.Equal(t, "+5511987654321", value)
+
+	_, valid = normalize(PhoneType, "11987654321")
+	assert.False(t, valid)
+
+	value, valid = normalize(RandomType, "6F9619FF-8B86-D011-B42D-00C04FC964FF")
+	assert.True(t, valid)
+	assert.Equal(t, "6f9619ff-8b86-d011-b42d-00c04fc964ff", value)
+
+	_, valid = normalize(Type("IBAN"), "anything")
+	assert.False(t, valid)
+}
+
+func TestDetect(t *testing.T) {
+	assert.Equal(t, RandomType, detect("6f9619ff-8b86-d011-b42d-00c04fc964ff"))
+	assert.Equal(t, EmailType, detect("maria@example.com"))
+	assert.Equal(t, PhoneType, detect("+5511987654321"))
+	assert.Equal(t, DocumentType, detect("529.982.247-25"))
+	assert.Equal(t, DocumentType, detect("11444777000161"))
+}
+
+func TestMask(t *testing.T) {
+	assert.Equal(t, "Maria S. d. O.", maskName("Maria  Silva de Oliveira"))
+	assert.Equal(t, "Maria", maskName("Maria"))
+	assert.Equal(t, "***.982.247-**", maskDocument("52998224725"))
+	assert.Equal(t, "**.444.777/0001-**", maskDocument("11.444.777/0001-61"))
+	assert.Equal(t, "*****", maskDocument("12345"))
+}
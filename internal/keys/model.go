@@ -0,0 +1,31 @@
+package keys
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type keyModel struct {
+	bun.BaseModel `bun:"table:account_keys"`
+
+	ID                    uuid.UUID `bun:"id,pk"`
+	AccountID             uuid.UUID `bun:"account_id"`
+	HolderID              uuid.UUID `bun:"holder_id"`
+	Type                  Type      `bun:"type"`
+	Value                 string    `bun:"value"`
+	Status                Status    `bun:"status"`
+	VerificationCodeHash  string    `bun:"verification_code_hash,nullzero"`
+	VerificationExpiresAt time.Time `bun:"verification_expires_at,nullzero"`
+	VerificationAttempts  int       `bun:"verification_attempts"`
+	CreatedAt             time.Time `bun:"created_at,notnull"`
+	VerifiedAt            time.Time `bun:"verified_at,nullzero"`
+
+	// the account and owner the key points at, only filled by lookups.
+	AccountAgency        string `bun:"account_agency,scanonly"`
+	AccountNumber        string `bun:"account_number,scanonly"`
+	AccountCurrency      string `bun:"account_currency,scanonly"`
+	HolderName           string `bun:"holder_name,scanonly"`
+	HolderDocumentNumber string `bun:"holder_document_number,scanonly"`
+}
@@ -0,0 +1,90 @@
+package keys
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"go.uber.org/zap"
+)
+
+// Notifier delivers the verification code to the email or phone being registered as a key, proving
+// that the holder owns it.
+type Notifier interface {
+	SendVerificationCode(ctx context.Context, key Key, code string) error
+}
+
+type logNotifier struct{}
+
+// NewLogNotifier builds a notifier that delivers nothing, meant for local environments without an email
+// or SMS provider. The code is never logged, it would let anyone reading the logs verify any key.
+func NewLogNotifier() Notifier {
+	return logNotifier{}
+}
+
+func (logNotifier) SendVerificationCode(ctx context.Context, key Key, _ string) error {
+	zapctx.L(ctx).Info(
+		"key_verification_code_not_delivered",
+		zap.String("key_id", key.ID.String()),
+		zap.String("type", string(key.Type)),
+	)
+
+	return nil
+}
+
+type httpNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPNotifier builds a notifier posting the codes to the url of the provider that sends the emails
+// and SMSs.
+func NewHTTPNotifier(url string, client *http.Client) Notifier {
+	return httpNotifier{url: url, client: client}
+}
+
+type verificationCodeRequest struct {
+	KeyID string `json:"key_id"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	Code  string `json:"code"`
+}
+
+func (n httpNotifier) SendVerificationCode(ctx context.Context, key Key, code string) error {
+	body, err := json.Marshal(verificationCodeRequest{
+		KeyID: key.ID.String(),
+		Type:  string(key.Type),
+		Value: key.Value,
+		Code:  code,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("the notifier answered with status %d", resp.StatusCode)
+	}
+
+	zapctx.L(ctx).Info(
+		"key_verification_code_sent",
+		zap.String("key_id", key.ID.String()),
+		zap.String("type", string(key.Type)),
+	)
+
+	return nil
+}
@@ -0,0 +1,47 @@
+//go:build unit
+
+package keys
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPNotifier_SendVerificationCode(t *testing.T) {
+	key := Key{ID: uuid.New(), Type: EmailType, Value: "maria@example.com"}
+
+	t.Run("delivered", func(t *testing.T) {
+		var received verificationCodeRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		err := NewHTTPNotifier(server.URL, server.Client()).SendVerificationCode(context.Background(), key, "123456")
+		assert.NoError(t, err)
+		assert.Equal(t, verificationCodeRequest{
+			KeyID: key.ID.String(),
+			Type:  string(EmailType),
+			Value: "maria@example.com",
+			Code:  "123456",
+		}, received)
+	})
+
+	t.Run("rejected by the provider", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		err := NewHTTPNotifier(server.URL, server.Client()).SendVerificationCode(context.Background(), key, "123456")
+		assert.Error(t, err)
+	})
+}
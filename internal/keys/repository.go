@@ -0,0 +1,308 @@
+package keys
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+const accountKeysActiveValueConstraint = "account_keys_active_value"
+
+var (
+	errKeyInUse             = errors.New("the key is already active in another account")
+	errKeyAlreadyRegistered = errors.New("the key is already registered to this account")
+)
+
+type Repository interface {
+	Create(ctx context.Context, model keyModel) (keyModel, error)
+	GetByID(ctx context.Context, accountID, id uuid.UUID) (keyModel, error)
+	ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]keyModel, error)
+	CountByAccountID(ctx context.Context, accountID uuid.UUID) (int, error)
+	ClaimAttempt(ctx context.Context, accountID, id uuid.UUID, maxAttempts int) (keyModel, error)
+	Activate(ctx context.Context, claimed keyModel) (keyModel, error)
+	Delete(ctx context.Context, accountID, id uuid.UUID) (bool, error)
+	GetActiveByValue(ctx context.Context, value string) (keyModel, error)
+}
+
+type repository struct {
+	tracer tracer.Tracer
+	db     database.Database
+}
+
+func NewRepository(t tracer.Tracer, db database.Database) Repository {
+	return repository{
+		tracer: t,
+		db:     db,
+	}
+}
+
+// Create stores the key. Registering again a key still pending in the same account replaces its
+// verification code, while a key already active there fails with errKeyAlreadyRegistered.
+func (r repository) Create(ctx context.Context, model keyModel) (keyModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	model.ID = uuid.New()
+	model.CreatedAt = time.Now().UTC()
+
+	err := r.db.Master().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if model.Status == ActiveStatus {
+			if err := releaseClosed(ctx, tx, model.Value); err != nil {
+				return err
+			}
+		}
+
+		res, err := tx.NewInsert().
+			Model(&model).
+			On("CONFLICT (account_id, value) DO UPDATE").
+			Set("verification_code_hash = EXCLUDED.verification_code_hash").
+			Set("verification_expires_at = EXCLUDED.verification_expires_at").
+			Set("verification_attempts = 0").
+			Where("key_model.status = ?", PendingStatus).
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if rows == 0 {
+			return errKeyAlreadyRegistered
+		}
+
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		if isConstraintViolation(err, accountKeysActiveValueConstraint) {
+			return keyModel{}, errKeyInUse
+		}
+		return keyModel{}, err
+	}
+
+	return model, nil
+}
+
+func (r repository) GetByID(ctx context.Context, accountID, id uuid.UUID) (keyModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var model keyModel
+	err := r.db.Master().
+		NewSelect().
+		Model(&model).
+		Where("id = ?", id).
+		Where("account_id = ?", accountID).
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return keyModel{}, err
+	}
+
+	return model, nil
+}
+
+func (r repository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]keyModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var models []keyModel
+	err := r.db.Replica().
+		NewSelect().
+		Model(&models).
+		Where("account_id = ?", accountID).
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return models, nil
+}
+
+// CountByAccountID counts the keys of the account, pending ones included.
+func (r repository) CountByAccountID(ctx context.Context, accountID uuid.UUID) (int, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	count, err := r.db.Master().
+		NewSelect().
+		Model((*keyModel)(nil)).
+		Where("account_id = ?", accountID).
+		Count(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// ClaimAttempt counts a verification attempt of the pending key and returns it, or sql.ErrNoRows when
+// the key is not pending, has no attempts left or its code expired. The check and the count are a
+// single statement, so concurrent verifications never make more attempts than allowed.
+func (r repository) ClaimAttempt(ctx context.Context, accountID, id uuid.UUID, maxAttempts int) (keyModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var model keyModel
+	res, err := r.db.Master().
+		NewUpdate().
+		Model(&model).
+		Set("verification_attempts = verification_attempts + 1").
+		Where("id = ?", id).
+		Where("account_id = ?", accountID).
+		Where("status = ?", PendingStatus).
+		Where("verification_attempts < ?", maxAttempts).
+		Where("verification_expires_at > ?", time.Now().UTC()).
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return keyModel{}, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		span.RecordError(err)
+		return keyModel{}, err
+	}
+	if rows == 0 {
+		return keyModel{}, sql.ErrNoRows
+	}
+
+	return model, nil
+}
+
+// Activate marks the key claimed by ClaimAttempt as verified while it is still pending with the same
+// code, which did not expire, returning sql.ErrNoRows otherwise. It fails with errKeyInUse when
+// another account activated the same value first.
+func (r repository) Activate(ctx context.Context, claimed keyModel) (keyModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var model keyModel
+	err := r.db.Master().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		err := tx.NewSelect().
+			Model(&model).
+			Where("id = ?", claimed.ID).
+			Where("status = ?", PendingStatus).
+			Where("verification_code_hash = ?", claimed.VerificationCodeHash).
+			Where("verification_expires_at > ?", time.Now().UTC()).
+			For("UPDATE").
+			Scan(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err := releaseClosed(ctx, tx, model.Value); err != nil {
+			return err
+		}
+
+		_, err = tx.NewUpdate().
+			Model(&model).
+			Set("status = ?", ActiveStatus).
+			Set("verified_at = ?", time.Now().UTC()).
+			Set("verification_code_hash = NULL").
+			Set("verification_expires_at = NULL").
+			Where("id = ?", model.ID).
+			Returning("*").
+			Exec(ctx)
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		if isConstraintViolation(err, accountKeysActiveValueConstraint) {
+			return keyModel{}, errKeyInUse
+		}
+		return keyModel{}, err
+	}
+
+	return model, nil
+}
+
+// Delete removes the key, returning false when it does not exist in the account.
+func (r repository) Delete(ctx context.Context, accountID, id uuid.UUID) (bool, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	res, err := r.db.Master().
+		NewDelete().
+		Model((*keyModel)(nil)).
+		Where("id = ?", id).
+		Where("account_id = ?", accountID).
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return false, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		span.RecordError(err)
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+// GetActiveByValue returns the active key with the value together with the account it points at
+// and the account owner, or sql.ErrNoRows when no open account has it.
+func (r repository) GetActiveByValue(ctx context.Context, value string) (keyModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var model keyModel
+	err := r.db.Replica().
+		NewSelect().
+		Model(&model).
+		ColumnExpr("key_model.*").
+		ColumnExpr("a.agency AS account_agency, a.number AS account_number, a.currency AS account_currency").
+		ColumnExpr("h.name AS holder_name, h.document_number AS holder_document_number").
+		Join("JOIN accounts AS a ON a.id = key_model.account_id").
+		Join("JOIN holders AS h ON h.id = a.holder_id").
+		Where("key_model.value = ?", value).
+		Where("key_model.status = ?", ActiveStatus).
+		Where("a.status <> ?", accounts.ClosedStatus).
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return keyModel{}, err
+	}
+
+	return model, nil
+}
+
+// releaseClosed removes the active keys with the value left in closed accounts, so that the value
+// can be registered again.
+func releaseClosed(ctx context.Context, tx bun.Tx, value string) error {
+	_, err := tx.NewDelete().
+		Model((*keyModel)(nil)).
+		Where("value = ?", value).
+		Where("status = ?", ActiveStatus).
+		Where("account_id IN (SELECT id FROM accounts WHERE status = ?)", accounts.ClosedStatus).
+		Exec(ctx)
+	return err
+}
+
+func isConstraintViolation(err error, constraint string) bool {
+	var pgErr pgdriver.Error
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.IntegrityViolation() && pgErr.Field('n') == constraint
+}
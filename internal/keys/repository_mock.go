@@ -0,0 +1,156 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/keys/repository.go
+
+// Package keys is a generated GoMock package.
+package keys
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Activate mocks base method.
+func (m *MockRepository) Activate(ctx context.Context, claimed keyModel) (keyModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Activate", ctx, claimed)
+	ret0, _ := ret[0].(keyModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Activate indicates an expected call of Activate.
+func (mr *MockRepositoryMockRecorder) Activate(ctx, claimed interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Activate", reflect.TypeOf((*MockRepository)(nil).Activate), ctx, claimed)
+}
+
+// ClaimAttempt mocks base method.
+func (m *MockRepository) ClaimAttempt(ctx context.Context, accountID, id uuid.UUID, maxAttempts int) (keyModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimAttempt", ctx, accountID, id, maxAttempts)
+	ret0, _ := ret[0].(keyModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimAttempt indicates an expected call of ClaimAttempt.
+func (mr *MockRepositoryMockRecorder) ClaimAttempt(ctx, accountID, id, maxAttempts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimAttempt", reflect.TypeOf((*MockRepository)(nil).ClaimAttempt), ctx, accountID, id, maxAttempts)
+}
+
+// CountByAccountID mocks base method.
+func (m *MockRepository) CountByAccountID(ctx context.Context, accountID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByAccountID", ctx, accountID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByAccountID indicates an expected call of CountByAccountID.
+func (mr *MockRepositoryMockRecorder) CountByAccountID(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByAccountID", reflect.TypeOf((*MockRepository)(nil).CountByAccountID), ctx, accountID)
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, model keyModel) (keyModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, model)
+	ret0, _ := ret[0].(keyModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, model)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, accountID, id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, accountID, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, accountID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, accountID, id)
+}
+
+// GetActiveByValue mocks base method.
+func (m *MockRepository) GetActiveByValue(ctx context.Context, value string) (keyModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveByValue", ctx, value)
+	ret0, _ := ret[0].(keyModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveByValue indicates an expected call of GetActiveByValue.
+func (mr *MockRepositoryMockRecorder) GetActiveByValue(ctx, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveByValue", reflect.TypeOf((*MockRepository)(nil).GetActiveByValue), ctx, value)
+}
+
+// GetByID mocks base method.
+func (m *MockRepository) GetByID(ctx context.Context, accountID, id uuid.UUID) (keyModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, accountID, id)
+	ret0, _ := ret[0].(keyModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRepositoryMockRecorder) GetByID(ctx, accountID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRepository)(nil).GetByID), ctx, accountID, id)
+}
+
+// ListByAccountID mocks base method.
+func (m *MockRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]keyModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByAccountID", ctx, accountID)
+	ret0, _ := ret[0].([]keyModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByAccountID indicates an expected call of ListByAccountID.
+func (mr *MockRepositoryMockRecorder) ListByAccountID(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAccountID", reflect.TypeOf((*MockRepository)(nil).ListByAccountID), ctx, accountID)
+}
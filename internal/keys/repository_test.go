@@ -0,0 +1,208 @@
+//go:build integration
+
+package keys
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/holders"
+	"github.com/dalmarcogd/dock-test/internal/products"
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/testingcontainers"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+
+	url, closeFunc, err := testingcontainers.NewPostgresContainer()
+	assert.NoError(t, err)
+	defer closeFunc(ctx) //nolint:errcheck
+
+	_, callerPath, _, _ := runtime.Caller(0) //nolint:dogsled
+	err = testingcontainers.RunMigrateDatabase(
+		url,
+		fmt.Sprintf("file://%s/../../migrations/", filepath.Dir(callerPath)),
+	)
+	assert.NoError(t, err)
+
+	db, err := database.New(tracer.NewNoop(), url, url)
+	assert.NoError(t, err)
+
+	holdersRepo := holders.NewRepository(tracer.NewNoop(), db)
+	holderModel, err := holdersRepo.Create(ctx, holders.HolderModel{
+		ID:             uuid.New(),
+		Name:           gofakeit.Name(),
+		DocumentNumber: gofakeit.SSN(),
+	})
+	assert.NoError(t, err)
+
+	accSvc := accounts.NewService(tracer.NewNoop(), accounts.NewRepository(tracer.NewNoop(), db), holdersRepo, false, 0)
+	newAccount := func() accounts.Account {
+		account, err := accSvc.Create(ctx, accounts.Account{
+			Name:           gofakeit.Name(),
+			DocumentNumber: holderModel.DocumentNumber,
+			Type:           products.CheckingType,
+		})
+		assert.NoError(t, err)
+		return account
+	}
+	first := newAccount()
+	second := newAccount()
+
+	repo := NewRepository(tracer.NewNoop(), db)
+	pending := func(accountID uuid.UUID, value, code string) keyModel {
+		return keyModel{
+			AccountID:             accountID,
+			HolderID:              holderModel.ID,
+			Type:                  EmailType,
+			Value:                 value,
+			Status:                PendingStatus,
+			VerificationCodeHash:  hashCode(accountID, value, code),
+			VerificationExpiresAt: time.Now().UTC().Add(verificationTTL),
+		}
+	}
+
+	t.Run("active value in a single account", func(t *testing.T) {
+		value := gofakeit.UUID()
+		model, err := repo.Create(ctx, keyModel{
+			AccountID:  first.ID,
+			HolderID:   holderModel.ID,
+			Type:       RandomType,
+			Value:      value,
+			Status:     ActiveStatus,
+			VerifiedAt: time.Now().UTC(),
+		})
+		assert.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, model.ID)
+
+		_, err = repo.Create(ctx, keyModel{
+			AccountID: second.ID,
+			HolderID:  holderModel.ID,
+			Type:      RandomType,
+			Value:     value,
+			Status:    ActiveStatus,
+		})
+		assert.ErrorIs(t, err, errKeyInUse)
+
+		_, err = repo.Create(ctx, keyModel{
+			AccountID: first.ID,
+			HolderID:  holderModel.ID,
+			Type:      RandomType,
+			Value:     value,
+			Status:    ActiveStatus,
+		})
+		assert.ErrorIs(t, err, errKeyAlreadyRegistered)
+
+		found, err := repo.GetActiveByValue(ctx, value)
+		assert.NoError(t, err)
+		assert.Equal(t, model.ID, found.ID)
+		assert.Equal(t, first.Number, found.AccountNumber)
+		assert.Equal(t, holderModel.Name, found.HolderName)
+		assert.Equal(t, holderModel.DocumentNumber, found.HolderDocumentNumber)
+	})
+
+	t.Run("pending in both accounts until one verifies", func(t *testing.T) {
+		value := gofakeit.Email()
+		firstKey, err := repo.Create(ctx, pending(first.ID, value, "111111"))
+		assert.NoError(t, err)
+		secondKey, err := repo.Create(ctx, pending(second.ID, value, "222222"))
+		assert.NoError(t, err)
+
+		_, err = repo.GetActiveByValue(ctx, value)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		claimed, err := repo.ClaimAttempt(ctx, first.ID, firstKey.ID, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, claimed.VerificationAttempts)
+		_, err = repo.ClaimAttempt(ctx, first.ID, firstKey.ID, 1)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		refreshed, err := repo.Create(ctx, pending(first.ID, value, "333333"))
+		assert.NoError(t, err)
+		assert.Equal(t, firstKey.ID, refreshed.ID)
+		assert.Zero(t, refreshed.VerificationAttempts)
+		assert.Equal(t, hashCode(first.ID, value, "333333"), refreshed.VerificationCodeHash)
+
+		_, err = repo.Activate(ctx, claimed)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		claimed, err = repo.ClaimAttempt(ctx, second.ID, secondKey.ID, maxVerificationAttempts)
+		assert.NoError(t, err)
+		active, err := repo.Activate(ctx, claimed)
+		assert.NoError(t, err)
+		assert.Equal(t, ActiveStatus, active.Status)
+		assert.False(t, active.VerifiedAt.IsZero())
+
+		_, err = repo.ClaimAttempt(ctx, second.ID, secondKey.ID, maxVerificationAttempts)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		_, err = repo.Activate(ctx, refreshed)
+		assert.ErrorIs(t, err, errKeyInUse)
+
+		found, err := repo.GetActiveByValue(ctx, value)
+		assert.NoError(t, err)
+		assert.Equal(t, second.ID, found.AccountID)
+	})
+
+	t.Run("closed accounts release their keys", func(t *testing.T) {
+		value := gofakeit.UUID()
+		_, err := repo.Create(ctx, keyModel{
+			AccountID: second.ID,
+			HolderID:  holderModel.ID,
+			Type:      RandomType,
+			Value:     value,
+			Status:    ActiveStatus,
+		})
+		assert.NoError(t, err)
+
+		_, err = db.Master().ExecContext(ctx, "UPDATE accounts SET status = ? WHERE id = ?", accounts.ClosedStatus, second.ID)
+		assert.NoError(t, err)
+
+		_, err = repo.GetActiveByValue(ctx, value)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		model, err := repo.Create(ctx, keyModel{
+			AccountID: first.ID,
+			HolderID:  holderModel.ID,
+			Type:      RandomType,
+			Value:     value,
+			Status:    ActiveStatus,
+		})
+		assert.NoError(t, err)
+
+		found, err := repo.GetActiveByValue(ctx, value)
+		assert.NoError(t, err)
+		assert.Equal(t, model.ID, found.ID)
+	})
+
+	t.Run("list count and delete", func(t *testing.T) {
+		models, err := repo.ListByAccountID(ctx, first.ID)
+		assert.NoError(t, err)
+		count, err := repo.CountByAccountID(ctx, first.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, len(models), count)
+		assert.Equal(t, 3, count)
+
+		deleted, err := repo.Delete(ctx, second.ID, models[0].ID)
+		assert.NoError(t, err)
+		assert.False(t, deleted)
+
+		deleted, err = repo.Delete(ctx, first.ID, models[0].ID)
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+
+		_, err = repo.GetByID(ctx, first.ID, models[0].ID)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
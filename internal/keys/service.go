@@ -0,0 +1,396 @@
+package keys
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/pkg/document"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// individuals may register up to 5 keys per account and companies up to 20.
+	maxIndividualKeys = 5
+	maxCompanyKeys    = 20
+
+	verificationTTL         = 10 * time.Minute
+	maxVerificationAttempts = 3
+)
+
+var (
+	ErrInvalidKeyType               = errors.New("invalid key type")
+	ErrInvalidKeyValue              = errors.New("the key value is not valid for its type")
+	ErrDocumentKeyMismatch          = errors.New("document keys must be the document number of the holder")
+	ErrHolderNotAllowed             = errors.New("only owners and co-owners can manage the keys of the account")
+	ErrKeyAccountNotAllowed         = errors.New("keys can only point at active accounts that are not pockets")
+	ErrKeyLimitReached              = errors.New("the account reached the maximum number of keys")
+	ErrKeyInUse                     = errors.New("the key is already registered to another account")
+	ErrKeyAlreadyRegistered         = errors.New("the key is already registered to this account")
+	ErrKeyNotFound                  = errors.New("no key found")
+	ErrKeyAlreadyVerified           = errors.New("the key is already verified")
+	ErrInvalidVerificationCode      = errors.New("the verification code is not valid")
+	ErrVerificationExpired          = errors.New("the verification code expired, register the key again for a new one")
+	ErrVerificationAttemptsExceeded = errors.New("too many verification attempts, register the key again for a new code")
+)
+
+type Service interface {
+	Register(ctx context.Context, registration Registration) (Key, error)
+	Verify(ctx context.Context, accountID, id uuid.UUID, code string) (Key, error)
+	ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]Key, error)
+	Delete(ctx context.Context, accountID, id uuid.UUID, principalID string) error
+	Resolve(ctx context.Context, key string) (Owner, error)
+}
+
+type service struct {
+	tracer      tracer.Tracer
+	repository  Repository
+	notifier    Notifier
+	accountsSvc accounts.Service
+	policiesSvc policies.Service
+}
+
+func NewService(t tracer.Tracer, r Repository, n Notifier, as accounts.Service, ps policies.Service) Service {
+	return service{
+		tracer:      t,
+		repository:  r,
+		notifier:    n,
+		accountsSvc: as,
+		policiesSvc: ps,
+	}
+}
+
+// Register creates a key pointing at the account. Document and random keys are active right away,
+// email and phone keys stay pending until the code sent to them is verified.
+func (s service) Register(ctx context.Context, registration Registration) (Key, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if !registration.Type.Valid() {
+		span.RecordError(ErrInvalidKeyType)
+		return Key{}, ErrInvalidKeyType
+	}
+
+	account, holder, err := s.authorize(ctx, registration.AccountID, registration.RequestedBy)
+	if err != nil {
+		span.RecordError(err)
+		return Key{}, err
+	}
+
+	if account.Status != accounts.ActiveStatus || account.IsPocket() {
+		span.RecordError(ErrKeyAccountNotAllowed)
+		return Key{}, ErrKeyAccountNotAllowed
+	}
+
+	value := registration.Value
+	if registration.Type == RandomType {
+		value = uuid.NewString()
+	}
+
+	value, valid := normalize(registration.Type, value)
+	if !valid {
+		span.RecordError(ErrInvalidKeyValue)
+		return Key{}, ErrInvalidKeyValue
+	}
+
+	if registration.Type == DocumentType && value != holder.DocumentNumber {
+		span.RecordError(ErrDocumentKeyMismatch)
+		return Key{}, ErrDocumentKeyMismatch
+	}
+
+	count, err := s.repository.CountByAccountID(ctx, account.ID)
+	if err != nil {
+		zapctx.L(ctx).Error("key_service_count_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return Key{}, err
+	}
+
+	if count >= keysLimit(account) {
+		span.RecordError(ErrKeyLimitReached)
+		return Key{}, ErrKeyLimitReached
+	}
+
+	model := keyModel{
+		AccountID: account.ID,
+		HolderID:  holder.HolderID,
+		Type:      registration.Type,
+		Value:     value,
+		Status:    ActiveStatus,
+	}
+
+	var code string
+	if registration.Type.verified() {
+		code, err = verificationCode()
+		if err != nil {
+			span.RecordError(err)
+			return Key{}, err
+		}
+
+		model.Status = PendingStatus
+		model.VerificationCodeHash = hashCode(account.ID, value, code)
+		model.VerificationExpiresAt = time.Now().UTC().Add(verificationTTL)
+	} else {
+		model.VerifiedAt = time.Now().UTC()
+	}
+
+	model, err = s.repository.Create(ctx, model)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, errKeyInUse) {
+			return Key{}, ErrKeyInUse
+		}
+		if errors.Is(err, errKeyAlreadyRegistered) {
+			return Key{}, ErrKeyAlreadyRegistered
+		}
+		zapctx.L(ctx).Error("key_service_create_repository_error", zap.Error(err))
+		return Key{}, err
+	}
+
+	key := newKey(model)
+
+	if code != "" {
+		if err := s.notifier.SendVerificationCode(ctx, key, code); err != nil {
+			zapctx.L(ctx).Error(
+				"key_service_send_verification_code_error",
+				zap.String("key_id", key.ID.String()),
+				zap.Error(err),
+			)
+			span.RecordError(err)
+			return Key{}, err
+		}
+	}
+
+	zapctx.L(ctx).Info(
+		"key_registered",
+		zap.String("key_id", key.ID.String()),
+		zap.String("account_id", key.AccountID.String()),
+		zap.String("type", string(key.Type)),
+		zap.String("status", string(key.Status)),
+	)
+
+	return key, nil
+}
+
+// Verify activates the pending key when the code is the one sent to it. Every attempt counts, the
+// code is lost after too many attempts or once it expires, and the key must be registered again.
+func (s service) Verify(ctx context.Context, accountID, id uuid.UUID, code string) (Key, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	model, err := s.repository.ClaimAttempt(ctx, accountID, id, maxVerificationAttempts)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = s.unverifiable(ctx, accountID, id)
+		span.RecordError(err)
+		return Key{}, err
+	} else if err != nil {
+		zapctx.L(ctx).Error("key_service_claim_attempt_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return Key{}, err
+	}
+
+	expected := []byte(model.VerificationCodeHash)
+	if subtle.ConstantTimeCompare(expected, []byte(hashCode(model.AccountID, model.Value, code))) != 1 {
+		span.RecordError(ErrInvalidVerificationCode)
+		return Key{}, ErrInvalidVerificationCode
+	}
+
+	model, err = s.repository.Activate(ctx, model)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = s.unverifiable(ctx, accountID, id)
+		} else if errors.Is(err, errKeyInUse) {
+			err = ErrKeyInUse
+		} else {
+			zapctx.L(ctx).Error("key_service_activate_repository_error", zap.Error(err))
+		}
+		span.RecordError(err)
+		return Key{}, err
+	}
+
+	zapctx.L(ctx).Info(
+		"key_verified",
+		zap.String("key_id", model.ID.String()),
+		zap.String("account_id", model.AccountID.String()),
+	)
+
+	return newKey(model), nil
+}
+
+// unverifiable returns why the key can no longer be verified with the code of the attempt, which was
+// replaced when the key is still verifiable.
+func (s service) unverifiable(ctx context.Context, accountID, id uuid.UUID) error {
+	model, err := s.repository.GetByID(ctx, accountID, id)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return ErrKeyNotFound
+	case err != nil:
+		zapctx.L(ctx).Error("key_service_get_repository_error", zap.Error(err))
+		return err
+	case model.Status == ActiveStatus:
+		return ErrKeyAlreadyVerified
+	case model.VerificationAttempts >= maxVerificationAttempts:
+		return ErrVerificationAttemptsExceeded
+	case !time.Now().Before(model.VerificationExpiresAt):
+		return ErrVerificationExpired
+	default:
+		return ErrInvalidVerificationCode
+	}
+}
+
+func (s service) ListByAccountID(ctx context.Context, accountID uuid.UUID) ([]Key, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if _, err := s.accountsSvc.GetByID(ctx, accountID); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	models, err := s.repository.ListByAccountID(ctx, accountID)
+	if err != nil {
+		zapctx.L(ctx).Error("key_service_list_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	keys := make([]Key, len(models))
+	for i, model := range models {
+		keys[i] = newKey(model)
+	}
+
+	return keys, nil
+}
+
+// Delete removes the key from the account on behalf of the principal linked to one of its owners,
+// freeing the value to be registered elsewhere.
+func (s service) Delete(ctx context.Context, accountID, id uuid.UUID, principalID string) error {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if _, _, err := s.authorize(ctx, accountID, principalID); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	deleted, err := s.repository.Delete(ctx, accountID, id)
+	if err != nil {
+		zapctx.L(ctx).Error("key_service_delete_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return err
+	}
+
+	if !deleted {
+		span.RecordError(ErrKeyNotFound)
+		return ErrKeyNotFound
+	}
+
+	zapctx.L(ctx).Info(
+		"key_deleted",
+		zap.String("key_id", id.String()),
+		zap.String("account_id", accountID.String()),
+	)
+
+	return nil
+}
+
+// Resolve finds the open account an active key points at. The key type is inferred from the value.
+func (s service) Resolve(ctx context.Context, key string) (Owner, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	value, valid := normalize(detect(key), key)
+	if !valid {
+		span.RecordError(ErrKeyNotFound)
+		return Owner{}, ErrKeyNotFound
+	}
+
+	model, err := s.repository.GetActiveByValue(ctx, value)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return Owner{}, ErrKeyNotFound
+		}
+		zapctx.L(ctx).Error("key_service_get_active_repository_error", zap.Error(err))
+		return Owner{}, err
+	}
+
+	return newOwner(model), nil
+}
+
+// authorize returns the account and the holder of the account the principal is linked to, who must
+// be allowed to move its funds to manage its keys.
+func (s service) authorize(
+	ctx context.Context,
+	accountID uuid.UUID,
+	principalID string,
+) (accounts.Account, accounts.AccountHolder, error) {
+	account, err := s.accountsSvc.GetByID(ctx, accountID)
+	if err != nil {
+		return accounts.Account{}, accounts.AccountHolder{}, err
+	}
+
+	accountHolders, err := s.accountsSvc.ListHolders(ctx, accountID)
+	if err != nil {
+		return accounts.Account{}, accounts.AccountHolder{}, err
+	}
+
+	links, err := s.policiesSvc.ListLinks(ctx, principalID)
+	if err != nil {
+		return accounts.Account{}, accounts.AccountHolder{}, err
+	}
+
+	linked := make(map[uuid.UUID]bool, len(links))
+	for _, link := range links {
+		linked[link.HolderID] = true
+	}
+
+	err = accounts.ErrAccountHolderNotLinked
+	for _, holder := range accountHolders {
+		if !linked[holder.HolderID] {
+			continue
+		}
+
+		if holder.Role.CanDebit() {
+			return account, holder, nil
+		}
+		err = ErrHolderNotAllowed
+	}
+
+	return accounts.Account{}, accounts.AccountHolder{}, err
+}
+
+func keysLimit(account accounts.Account) int {
+	if document.IsCNPJ(account.DocumentNumber) {
+		return maxCompanyKeys
+	}
+
+	return maxIndividualKeys
+}
+
+// verificationCode draws a random 6 digit code.
+func verificationCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// hashCode binds the code to the account and value it was sent for, only the hash is stored.
+func hashCode(accountID uuid.UUID, value, code string) string {
+	sum := sha256.Sum256([]byte(accountID.String() + ":" + value + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,470 @@
+//go:build unit
+
+package keys
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	ownerDocument    = "52998224725"
+	operatorDocument = "11144477735"
+
+	ownerPrincipal    = "owner-principal"
+	operatorPrincipal = "operator-principal"
+)
+
+func TestService_Register(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	notifierMock := NewMockNotifier(ctrl)
+	accountsMock := accounts.NewMockService(ctrl)
+	policiesMock := policies.NewMockService(ctrl)
+	svc := NewService(tracer.NewNoop(), repoMock, notifierMock, accountsMock, policiesMock)
+
+	account := accounts.Account{
+		ID:             uuid.New(),
+		DocumentNumber: ownerDocument,
+		HolderID:       uuid.New(),
+		Status:         accounts.ActiveStatus,
+	}
+	operatorID := uuid.New()
+	accountHolders := []accounts.AccountHolder{
+		{HolderID: account.HolderID, DocumentNumber: ownerDocument, Role: accounts.OwnerHolderRole},
+		{HolderID: operatorID, DocumentNumber: operatorDocument, Role: accounts.OperatorHolderRole},
+	}
+	policiesMock.EXPECT().
+		ListLinks(ctx, ownerPrincipal).
+		Return([]policies.Link{{PrincipalID: ownerPrincipal, HolderID: account.HolderID}}, nil).
+		AnyTimes()
+	policiesMock.EXPECT().
+		ListLinks(ctx, operatorPrincipal).
+		Return([]policies.Link{{PrincipalID: operatorPrincipal, HolderID: operatorID}}, nil).
+		AnyTimes()
+	policiesMock.EXPECT().ListLinks(ctx, "unlinked-principal").Return(nil, nil).AnyTimes()
+
+	t.Run("fail register, invalid type", func(t *testing.T) {
+		key, err := svc.Register(ctx, Registration{AccountID: account.ID, Type: "IBAN", Value: "x"})
+		assert.ErrorIs(t, err, ErrInvalidKeyType)
+		assert.Empty(t, key)
+	})
+
+	t.Run("fail register, holder not linked", func(t *testing.T) {
+		accountsMock.EXPECT().GetByID(ctx, account.ID).Return(account, nil)
+		accountsMock.EXPECT().ListHolders(ctx, account.ID).Return(accountHolders, nil)
+
+		key, err := svc.Register(ctx, Registration{
+			AccountID:   account.ID,
+			RequestedBy: "unlinked-principal",
+			Type:        RandomType,
+		})
+		assert.ErrorIs(t, err, accounts.ErrAccountHolderNotLinked)
+		assert.Empty(t, key)
+	})
+
+	t.Run("fail register, authorized operator", func(t *testing.T) {
+		accountsMock.EXPECT().GetByID(ctx, account.ID).Return(account, nil)
+		accountsMock.EXPECT().ListHolders(ctx, account.ID).Return(accountHolders, nil)
+
+		key, err := svc.Register(ctx, Registration{
+			AccountID:   account.ID,
+			RequestedBy: operatorPrincipal,
+			Type:        RandomType,
+		})
+		assert.ErrorIs(t, err, ErrHolderNotAllowed)
+		assert.Empty(t, key)
+	})
+
+	t.Run("fail register, pocket", func(t *testing.T) {
+		pocket := account
+		pocket.ParentID = uuid.NullUUID{UUID: uuid.New(), Valid: true}
+		accountsMock.EXPECT().GetByID(ctx, account.ID).Return(pocket, nil)
+		accountsMock.EXPECT().ListHolders(ctx, account.ID).Return(accountHolders, nil)
+
+		key, err := svc.Register(ctx, Registration{
+			AccountID:   account.ID,
+			RequestedBy: ownerPrincipal,
+			Type:        RandomType,
+		})
+		assert.ErrorIs(t, err, ErrKeyAccountNotAllowed)
+		assert.Empty(t, key)
+	})
+
+	t.Run("fail register, document of another holder", func(t *testing.T) {
+		accountsMock.EXPECT().GetByID(ctx, account.ID).Return(account, nil)
+		accountsMock.EXPECT().ListHolders(ctx, account.ID).Return(accountHolders, nil)
+
+		key, err := svc.Register(ctx, Registration{
+			AccountID:   account.ID,
+			RequestedBy: ownerPrincipal,
+			Type:        DocumentType,
+			Value:       operatorDocument,
+		})
+		assert.ErrorIs(t, err, ErrDocumentKeyMismatch)
+		assert.Empty(t, key)
+	})
+
+	t.Run("fail register, invalid email", func(t *testing.T) {
+		accountsMock.EXPECT().GetByID(ctx, account.ID).Return(account, nil)
+		accountsMock.EXPECT().ListHolders(ctx, account.ID).Return(accountHolders, nil)
+
+		key, err := svc.Register(ctx, Registration{
+			AccountID:   account.ID,
+			RequestedBy: ownerPrincipal,
+			Type:        EmailType,
+			Value:       "maria.example.com",
+		})
+		assert.ErrorIs(t, err, ErrInvalidKeyValue)
+		assert.Empty(t, key)
+	})
+
+	t.Run("fail register, limit reached", func(t *testing.T) {
+		accountsMock.EXPECT().GetByID(ctx, account.ID).Return(account, nil)
+		accountsMock.EXPECT().ListHolders(ctx, account.ID).Return(accountHolders, nil)
+		repoMock.EXPECT().CountByAccountID(ctx, account.ID).Return(maxIndividualKeys, nil)
+
+		key, err := svc.Register(ctx, Registration{
+			AccountID:   account.ID,
+			RequestedBy: ownerPrincipal,
+			Type:        RandomType,
+		})
+		assert.ErrorIs(t, err, ErrKeyLimitReached)
+		assert.Empty(t, key)
+	})
+
+	t.Run("fail register, key active in another account", func(t *testing.T) {
+		accountsMock.EXPECT().GetByID(ctx, account.ID).Return(account, nil)
+		accountsMock.EXPECT().ListHolders(ctx, account.ID).Return(accountHolders, nil)
+		repoMock.EXPECT().CountByAccountID(ctx, account.ID).Return(0, nil)
+		repoMock.EXPECT().Create(ctx, gomock.Any()).Return(keyModel{}, errKeyInUse)
+
+		key, err := svc.Register(ctx, Registration{
+			AccountID:   account.ID,
+			RequestedBy: ownerPrincipal,
+			Type:        DocumentType,
+			Value:       "529.982.247-25",
+		})
+		assert.ErrorIs(t, err, ErrKeyInUse)
+		assert.Empty(t, key)
+	})
+
+	t.Run("success register document key active right away", func(t *testing.T) {
+		accountsMock.EXPECT().GetByID(ctx, account.ID).Return(account, nil)
+		accountsMock.EXPECT().ListHolders(ctx, account.ID).Return(accountHolders, nil)
+		repoMock.EXPECT().CountByAccountID(ctx, account.ID).Return(2, nil)
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, model keyModel) (keyModel, error) {
+				assert.Equal(t, account.ID, model.AccountID)
+				assert.Equal(t, account.HolderID, model.HolderID)
+				assert.Equal(t, ownerDocument, model.Value)
+				assert.Equal(t, ActiveStatus, model.Status)
+				assert.Empty(t, model.VerificationCodeHash)
+				assert.False(t, model.VerifiedAt.IsZero())
+				model.ID = uuid.New()
+				return model, nil
+			})
+
+		key, err := svc.Register(ctx, Registration{
+			AccountID:   account.ID,
+			RequestedBy: ownerPrincipal,
+			Type:        DocumentType,
+			Value:       "529.982.247-25",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, ActiveStatus, key.Status)
+		assert.Equal(t, DocumentType, key.Type)
+	})
+
+	t.Run("success register random key", func(t *testing.T) {
+		accountsMock.EXPECT().GetByID(ctx, account.ID).Return(account, nil)
+		accountsMock.EXPECT().ListHolders(ctx, account.ID).Return(accountHolders, nil)
+		repoMock.EXPECT().CountByAccountID(ctx, account.ID).Return(0, nil)
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, model keyModel) (keyModel, error) {
+				model.ID = uuid.New()
+				return model, nil
+			})
+
+		key, err := svc.Register(ctx, Registration{
+			AccountID:   account.ID,
+			RequestedBy: ownerPrincipal,
+			Type:        RandomType,
+			Value:       "ignored",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, ActiveStatus, key.Status)
+		assert.True(t, isUUID(key.Value))
+	})
+
+	t.Run("success register email key pending verification", func(t *testing.T) {
+		var hash string
+		accountsMock.EXPECT().GetByID(ctx, account.ID).Return(account, nil)
+		accountsMock.EXPECT().ListHolders(ctx, account.ID).Return(accountHolders, nil)
+		repoMock.EXPECT().CountByAccountID(ctx, account.ID).Return(0, nil)
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, model keyModel) (keyModel, error) {
+				assert.Equal(t, "maria@example.com", model.Value)
+				assert.Equal(t, PendingStatus, model.Status)
+				assert.True(t, model.VerifiedAt.IsZero())
+				assert.WithinDuration(t, time.Now().Add(verificationTTL), model.VerificationExpiresAt, time.Minute)
+				hash = model.VerificationCodeHash
+				model.ID = uuid.New()
+				return model, nil
+			})
+		notifierMock.EXPECT().
+			SendVerificationCode(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, key Key, code string) error {
+				assert.Len(t, code, 6)
+				assert.Equal(t, hash, hashCode(account.ID, key.Value, code))
+				return nil
+			})
+
+		key, err := svc.Register(ctx, Registration{
+			AccountID:   account.ID,
+			RequestedBy: ownerPrincipal,
+			Type:        EmailType,
+			Value:       "Maria@Example.com",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, PendingStatus, key.Status)
+	})
+}
+
+func TestService_Verify(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	svc := NewService(
+		tracer.NewNoop(),
+		repoMock,
+		NewMockNotifier(ctrl),
+		accounts.NewMockService(ctrl),
+		policies.NewMockService(ctrl),
+	)
+
+	accountID := uuid.New()
+	pending := keyModel{
+		ID:                    uuid.New(),
+		AccountID:             accountID,
+		Type:                  PhoneType,
+		Value:                 "+5511987654321",
+		Status:                PendingStatus,
+		VerificationCodeHash:  hashCode(accountID, "+5511987654321", "123456"),
+		VerificationExpiresAt: time.Now().Add(verificationTTL),
+	}
+
+	t.Run("fail verify, key not found", func(t *testing.T) {
+		repoMock.EXPECT().
+			ClaimAttempt(ctx, accountID, pending.ID, maxVerificationAttempts).
+			Return(keyModel{}, sql.ErrNoRows)
+		repoMock.EXPECT().GetByID(ctx, accountID, pending.ID).Return(keyModel{}, sql.ErrNoRows)
+
+		key, err := svc.Verify(ctx, accountID, pending.ID, "123456")
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+		assert.Empty(t, key)
+	})
+
+	t.Run("fail verify, already active", func(t *testing.T) {
+		active := pending
+		active.Status = ActiveStatus
+		repoMock.EXPECT().
+			ClaimAttempt(ctx, accountID, pending.ID, maxVerificationAttempts).
+			Return(keyModel{}, sql.ErrNoRows)
+		repoMock.EXPECT().GetByID(ctx, accountID, pending.ID).Return(active, nil)
+
+		key, err := svc.Verify(ctx, accountID, pending.ID, "123456")
+		assert.ErrorIs(t, err, ErrKeyAlreadyVerified)
+		assert.Empty(t, key)
+	})
+
+	t.Run("fail verify, code expired", func(t *testing.T) {
+		expired := pending
+		expired.VerificationExpiresAt = time.Now().Add(-time.Second)
+		repoMock.EXPECT().
+			ClaimAttempt(ctx, accountID, pending.ID, maxVerificationAttempts).
+			Return(keyModel{}, sql.ErrNoRows)
+		repoMock.EXPECT().GetByID(ctx, accountID, pending.ID).Return(expired, nil)
+
+		key, err := svc.Verify(ctx, accountID, pending.ID, "123456")
+		assert.ErrorIs(t, err, ErrVerificationExpired)
+		assert.Empty(t, key)
+	})
+
+	t.Run("fail verify, too many attempts", func(t *testing.T) {
+		exhausted := pending
+		exhausted.VerificationAttempts = maxVerificationAttempts
+		repoMock.EXPECT().
+			ClaimAttempt(ctx, accountID, pending.ID, maxVerificationAttempts).
+			Return(keyModel{}, sql.ErrNoRows)
+		repoMock.EXPECT().GetByID(ctx, accountID, pending.ID).Return(exhausted, nil)
+
+		key, err := svc.Verify(ctx, accountID, pending.ID, "123456")
+		assert.ErrorIs(t, err, ErrVerificationAttemptsExceeded)
+		assert.Empty(t, key)
+	})
+
+	t.Run("fail verify, wrong code counts the attempt", func(t *testing.T) {
+		repoMock.EXPECT().ClaimAttempt(ctx, accountID, pending.ID, maxVerificationAttempts).Return(pending, nil)
+
+		key, err := svc.Verify(ctx, accountID, pending.ID, "654321")
+		assert.ErrorIs(t, err, ErrInvalidVerificationCode)
+		assert.Empty(t, key)
+	})
+
+	t.Run("fail verify, code replaced after the attempt", func(t *testing.T) {
+		replaced := pending
+		replaced.VerificationCodeHash = hashCode(accountID, pending.Value, "999999")
+		repoMock.EXPECT().ClaimAttempt(ctx, accountID, pending.ID, maxVerificationAttempts).Return(pending, nil)
+		repoMock.EXPECT().Activate(ctx, pending).Return(keyModel{}, sql.ErrNoRows)
+		repoMock.EXPECT().GetByID(ctx, accountID, pending.ID).Return(replaced, nil)
+
+		key, err := svc.Verify(ctx, accountID, pending.ID, "123456")
+		assert.ErrorIs(t, err, ErrInvalidVerificationCode)
+		assert.Empty(t, key)
+	})
+
+	t.Run("fail verify, activated in another account first", func(t *testing.T) {
+		repoMock.EXPECT().ClaimAttempt(ctx, accountID, pending.ID, maxVerificationAttempts).Return(pending, nil)
+		repoMock.EXPECT().Activate(ctx, pending).Return(keyModel{}, errKeyInUse)
+
+		key, err := svc.Verify(ctx, accountID, pending.ID, "123456")
+		assert.ErrorIs(t, err, ErrKeyInUse)
+		assert.Empty(t, key)
+	})
+
+	t.Run("success verify", func(t *testing.T) {
+		active := pending
+		active.Status = ActiveStatus
+		active.VerifiedAt = time.Now()
+		repoMock.EXPECT().ClaimAttempt(ctx, accountID, pending.ID, maxVerificationAttempts).Return(pending, nil)
+		repoMock.EXPECT().Activate(ctx, pending).Return(active, nil)
+
+		key, err := svc.Verify(ctx, accountID, pending.ID, "123456")
+		assert.NoError(t, err)
+		assert.Equal(t, ActiveStatus, key.Status)
+	})
+}
+
+func TestService_Delete(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	accountsMock := accounts.NewMockService(ctrl)
+	policiesMock := policies.NewMockService(ctrl)
+	svc := NewService(tracer.NewNoop(), repoMock, NewMockNotifier(ctrl), accountsMock, policiesMock)
+
+	account := accounts.Account{ID: uuid.New(), Status: accounts.ActiveStatus}
+	coOwnerID := uuid.New()
+	accountHolders := []accounts.AccountHolder{
+		{HolderID: coOwnerID, DocumentNumber: ownerDocument, Role: accounts.CoOwnerHolderRole},
+	}
+	keyID := uuid.New()
+
+	t.Run("fail delete, principal not linked to a holder", func(t *testing.T) {
+		accountsMock.EXPECT().GetByID(ctx, account.ID).Return(account, nil)
+		accountsMock.EXPECT().ListHolders(ctx, account.ID).Return(accountHolders, nil)
+		policiesMock.EXPECT().
+			ListLinks(ctx, operatorPrincipal).
+			Return([]policies.Link{{PrincipalID: operatorPrincipal, HolderID: uuid.New()}}, nil)
+
+		err := svc.Delete(ctx, account.ID, keyID, operatorPrincipal)
+		assert.ErrorIs(t, err, accounts.ErrAccountHolderNotLinked)
+	})
+
+	t.Run("fail delete, key not found", func(t *testing.T) {
+		accountsMock.EXPECT().GetByID(ctx, account.ID).Return(account, nil)
+		accountsMock.EXPECT().ListHolders(ctx, account.ID).Return(accountHolders, nil)
+		policiesMock.EXPECT().
+			ListLinks(ctx, ownerPrincipal).
+			Return([]policies.Link{{PrincipalID: ownerPrincipal, HolderID: coOwnerID}}, nil)
+		repoMock.EXPECT().Delete(ctx, account.ID, keyID).Return(false, nil)
+
+		err := svc.Delete(ctx, account.ID, keyID, ownerPrincipal)
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+	})
+
+	t.Run("success delete by co-owner", func(t *testing.T) {
+		accountsMock.EXPECT().GetByID(ctx, account.ID).Return(account, nil)
+		accountsMock.EXPECT().ListHolders(ctx, account.ID).Return(accountHolders, nil)
+		policiesMock.EXPECT().
+			ListLinks(ctx, ownerPrincipal).
+			Return([]policies.Link{{PrincipalID: ownerPrincipal, HolderID: coOwnerID}}, nil)
+		repoMock.EXPECT().Delete(ctx, account.ID, keyID).Return(true, nil)
+
+		err := svc.Delete(ctx, account.ID, keyID, ownerPrincipal)
+		assert.NoError(t, err)
+	})
+}
+
+func TestService_Resolve(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	svc := NewService(
+		tracer.NewNoop(),
+		repoMock,
+		NewMockNotifier(ctrl),
+		accounts.NewMockService(ctrl),
+		policies.NewMockService(ctrl),
+	)
+
+	t.Run("fail resolve, malformed key", func(t *testing.T) {
+		owner, err := svc.Resolve(ctx, "not-a-key")
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+		assert.Empty(t, owner)
+	})
+
+	t.Run("fail resolve, key not active", func(t *testing.T) {
+		repoMock.EXPECT().GetActiveByValue(ctx, "maria@example.com").Return(keyModel{}, sql.ErrNoRows)
+
+		owner, err := svc.Resolve(ctx, "MARIA@example.com")
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+		assert.Empty(t, owner)
+	})
+
+	t.Run("success resolve masks the holder", func(t *testing.T) {
+		model := keyModel{
+			ID:                   uuid.New(),
+			AccountID:            uuid.New(),
+			Type:                 DocumentType,
+			Value:                ownerDocument,
+			Status:               ActiveStatus,
+			AccountAgency:        "0001",
+			AccountNumber:        "000123-4",
+			AccountCurrency:      "BRL",
+			HolderName:           "Maria Silva",
+			HolderDocumentNumber: ownerDocument,
+		}
+		repoMock.EXPECT().GetActiveByValue(ctx, ownerDocument).Return(model, nil)
+
+		owner, err := svc.Resolve(ctx, "529.982.247-25")
+		assert.NoError(t, err)
+		assert.Equal(t, model.AccountID, owner.AccountID)
+		assert.Equal(t, "0001", owner.Agency)
+		assert.Equal(t, "000123-4", owner.Number)
+		assert.Equal(t, "Maria S.", owner.HolderName)
+		assert.Equal(t, "***.982.247-**", owner.DocumentNumber)
+	})
+}
@@ -0,0 +1,26 @@
+package policies
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type linkModel struct {
+	bun.BaseModel `bun:"table:principal_holders"`
+
+	PrincipalID string    `bun:"principal_id,pk"`
+	HolderID    uuid.UUID `bun:"holder_id,pk"`
+	CreatedBy   string    `bun:"created_by"`
+	CreatedAt   time.Time `bun:"created_at,notnull"`
+}
+
+func newLinkModel(link Link) linkModel {
+	return linkModel{
+		PrincipalID: link.PrincipalID,
+		HolderID:    link.HolderID,
+		CreatedBy:   link.CreatedBy,
+		CreatedAt:   time.Now().UTC(),
+	}
+}
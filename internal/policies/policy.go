@@ -0,0 +1,57 @@
+package policies
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// AdminScope grants the management of the API keys and of the links between principals and holders,
+	// along with everything BackOfficeScope grants.
+	AdminScope = "admin"
+	// BackOfficeScope grants the operations run by the bank staff and access to the resources of every holder.
+	BackOfficeScope = "back-office"
+)
+
+// Scopes are the scopes a principal may be granted.
+var Scopes = []string{AdminScope, BackOfficeScope}
+
+// Access is what a principal does with an account.
+type Access string
+
+const (
+	// HolderAccess is granted to the principals linked to any holder of the account.
+	HolderAccess Access = "HOLDER"
+	// DebitAccess is granted to the principals linked to a holder of the account whose role can debit it.
+	DebitAccess Access = "DEBIT"
+)
+
+// Link ties a principal, the id of an API key or the subject of a JWT, to a holder, so it accesses the
+// accounts of the holder.
+type Link struct {
+	PrincipalID string
+	HolderID    uuid.UUID
+	CreatedBy   string
+	CreatedAt   time.Time
+}
+
+func newLink(model linkModel) Link {
+	return Link{
+		PrincipalID: model.PrincipalID,
+		HolderID:    model.HolderID,
+		CreatedBy:   model.CreatedBy,
+		CreatedAt:   model.CreatedAt,
+	}
+}
+
+// ValidScope tells whether the scope is one of Scopes.
+func ValidScope(scope string) bool {
+	for _, s := range Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,150 @@
+package policies
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+const (
+	principalHoldersPrimaryKeyConstraint = "principal_holders_pkey"
+	principalHoldersHolderConstraint     = "principal_holders_holder_id_fkey"
+)
+
+var (
+	errDuplicatedLink = errors.New("the principal is already linked to this holder")
+	errHolderNotFound = errors.New("no holder found with this id")
+)
+
+type Repository interface {
+	CreateLink(ctx context.Context, model linkModel) (linkModel, error)
+	DeleteLink(ctx context.Context, principalID string, holderID uuid.UUID) (bool, error)
+	ListLinks(ctx context.Context, principalID string) ([]linkModel, error)
+	ListAccountRoles(ctx context.Context, principalID string, accountID uuid.UUID) ([]accounts.HolderRole, error)
+}
+
+type repository struct {
+	tracer tracer.Tracer
+	db     database.Database
+}
+
+func NewRepository(t tracer.Tracer, db database.Database) Repository {
+	return repository{
+		tracer: t,
+		db:     db,
+	}
+}
+
+func (r repository) CreateLink(ctx context.Context, model linkModel) (linkModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	_, err := r.db.Master().
+		NewInsert().
+		Model(&model).
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		if isConstraintViolation(err, principalHoldersPrimaryKeyConstraint) {
+			return linkModel{}, errDuplicatedLink
+		}
+		if isConstraintViolation(err, principalHoldersHolderConstraint) {
+			return linkModel{}, errHolderNotFound
+		}
+		return linkModel{}, err
+	}
+
+	return model, nil
+}
+
+// DeleteLink removes the link, returning false when it does not exist.
+func (r repository) DeleteLink(ctx context.Context, principalID string, holderID uuid.UUID) (bool, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	res, err := r.db.Master().
+		NewDelete().
+		Model((*linkModel)(nil)).
+		Where("principal_id = ?", principalID).
+		Where("holder_id = ?", holderID).
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return false, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		span.RecordError(err)
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+// ListLinks lists the holders the principal is linked to, oldest first. It reads from the master, so an unlinked
+// principal loses its access as soon as the unlink is answered.
+func (r repository) ListLinks(ctx context.Context, principalID string) ([]linkModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var models []linkModel
+	err := r.db.Master().
+		NewSelect().
+		Model(&models).
+		Where("principal_id = ?", principalID).
+		Order("created_at ASC", "holder_id ASC").
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return models, nil
+}
+
+// ListAccountRoles lists the roles in the account of the holders the principal is linked to, empty when it is
+// linked to none of them or the account does not exist.
+func (r repository) ListAccountRoles(
+	ctx context.Context,
+	principalID string,
+	accountID uuid.UUID,
+) ([]accounts.HolderRole, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var values []string
+	err := r.db.Master().
+		NewSelect().
+		TableExpr("account_holders AS ah").
+		ColumnExpr("ah.role").
+		Join("JOIN principal_holders AS ph ON ph.holder_id = ah.holder_id").
+		Where("ph.principal_id = ?", principalID).
+		Where("ah.account_id = ?", accountID).
+		Scan(ctx, &values)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	roles := make([]accounts.HolderRole, len(values))
+	for i, value := range values {
+		roles[i] = accounts.HolderRole(value)
+	}
+
+	return roles, nil
+}
+
+func isConstraintViolation(err error, constraint string) bool {
+	var pgErr pgdriver.Error
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.IntegrityViolation() && pgErr.Field('n') == constraint
+}
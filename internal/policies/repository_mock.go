@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/policies/repository.go
+
+// Package policies is a generated GoMock package.
+package policies
+
+import (
+	context "context"
+	reflect "reflect"
+
+	accounts "github.com/dalmarcogd/dock-test/internal/accounts"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateLink mocks base method.
+func (m *MockRepository) CreateLink(ctx context.Context, model linkModel) (linkModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLink", ctx, model)
+	ret0, _ := ret[0].(linkModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLink indicates an expected call of CreateLink.
+func (mr *MockRepositoryMockRecorder) CreateLink(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLink", reflect.TypeOf((*MockRepository)(nil).CreateLink), ctx, model)
+}
+
+// DeleteLink mocks base method.
+func (m *MockRepository) DeleteLink(ctx context.Context, principalID string, holderID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLink", ctx, principalID, holderID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteLink indicates an expected call of DeleteLink.
+func (mr *MockRepositoryMockRecorder) DeleteLink(ctx, principalID, holderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLink", reflect.TypeOf((*MockRepository)(nil).DeleteLink), ctx, principalID, holderID)
+}
+
+// ListAccountRoles mocks base method.
+func (m *MockRepository) ListAccountRoles(ctx context.Context, principalID string, accountID uuid.UUID) ([]accounts.HolderRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountRoles", ctx, principalID, accountID)
+	ret0, _ := ret[0].([]accounts.HolderRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountRoles indicates an expected call of ListAccountRoles.
+func (mr *MockRepositoryMockRecorder) ListAccountRoles(ctx, principalID, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountRoles", reflect.TypeOf((*MockRepository)(nil).ListAccountRoles), ctx, principalID, accountID)
+}
+
+// ListLinks mocks base method.
+func (m *MockRepository) ListLinks(ctx context.Context, principalID string) ([]linkModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLinks", ctx, principalID)
+	ret0, _ := ret[0].([]linkModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLinks indicates an expected call of ListLinks.
+func (mr *MockRepositoryMockRecorder) ListLinks(ctx, principalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLinks", reflect.TypeOf((*MockRepository)(nil).ListLinks), ctx, principalID)
+}
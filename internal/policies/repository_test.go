@@ -0,0 +1,115 @@
+//go:build integration
+
+package policies
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/holders"
+	"github.com/dalmarcogd/dock-test/internal/products"
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/testingcontainers"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+
+	url, closeFunc, err := testingcontainers.NewPostgresContainer()
+	assert.NoError(t, err)
+	defer closeFunc(ctx) //nolint:errcheck
+
+	_, callerPath, _, _ := runtime.Caller(0) //nolint:dogsled
+	err = testingcontainers.RunMigrateDatabase(
+		url,
+		fmt.Sprintf("file://%s/../../migrations/", filepath.Dir(callerPath)),
+	)
+	assert.NoError(t, err)
+
+	db, err := database.New(tracer.NewNoop(), url, url)
+	assert.NoError(t, err)
+
+	holdersRepo := holders.NewRepository(tracer.NewNoop(), db)
+	holderModel, err := holdersRepo.Create(ctx, holders.HolderModel{
+		ID:             uuid.New(),
+		Name:           gofakeit.Name(),
+		DocumentNumber: gofakeit.SSN(),
+	})
+	assert.NoError(t, err)
+
+	accSvc := accounts.NewService(tracer.NewNoop(), accounts.NewRepository(tracer.NewNoop(), db), holdersRepo, false, 0)
+	account, err := accSvc.Create(ctx, accounts.Account{
+		Name:           gofakeit.Name(),
+		DocumentNumber: holderModel.DocumentNumber,
+		Type:           products.BusinessType,
+	})
+	assert.NoError(t, err)
+
+	repo := NewRepository(tracer.NewNoop(), db)
+
+	t.Run("link created, listed and deleted", func(t *testing.T) {
+		principalID := uuid.NewString()
+
+		roles, err := repo.ListAccountRoles(ctx, principalID, account.ID)
+		assert.NoError(t, err)
+		assert.Empty(t, roles)
+
+		created, err := repo.CreateLink(ctx, linkModel{
+			PrincipalID: principalID,
+			HolderID:    holderModel.ID,
+			CreatedBy:   "bootstrap",
+			CreatedAt:   time.Now().UTC(),
+		})
+		assert.NoError(t, err)
+
+		links, err := repo.ListLinks(ctx, principalID)
+		assert.NoError(t, err)
+		assert.Len(t, links, 1)
+		assert.Equal(t, created.HolderID, links[0].HolderID)
+		assert.Equal(t, "bootstrap", links[0].CreatedBy)
+
+		roles, err = repo.ListAccountRoles(ctx, principalID, account.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, []accounts.HolderRole{accounts.OwnerHolderRole}, roles)
+
+		roles, err = repo.ListAccountRoles(ctx, principalID, uuid.New())
+		assert.NoError(t, err)
+		assert.Empty(t, roles)
+
+		deleted, err := repo.DeleteLink(ctx, principalID, holderModel.ID)
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+
+		deleted, err = repo.DeleteLink(ctx, principalID, holderModel.ID)
+		assert.NoError(t, err)
+		assert.False(t, deleted)
+
+		links, err = repo.ListLinks(ctx, principalID)
+		assert.NoError(t, err)
+		assert.Empty(t, links)
+	})
+
+	t.Run("link not created, duplicated or unknown holder", func(t *testing.T) {
+		principalID := uuid.NewString()
+		model := linkModel{PrincipalID: principalID, HolderID: holderModel.ID, CreatedAt: time.Now().UTC()}
+
+		_, err := repo.CreateLink(ctx, model)
+		assert.NoError(t, err)
+
+		_, err = repo.CreateLink(ctx, model)
+		assert.ErrorIs(t, err, errDuplicatedLink)
+
+		model.HolderID = uuid.New()
+		_, err = repo.CreateLink(ctx, model)
+		assert.ErrorIs(t, err, errHolderNotFound)
+	})
+}
@@ -0,0 +1,243 @@
+package policies
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/dalmarcogd/dock-test/pkg/http/middlewares"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrForbidden         = errors.New("the principal is not allowed to access this resource")
+	ErrPrincipalRequired = errors.New("the link must have a principal")
+	ErrHolderNotFound    = errors.New("no holder found with this id")
+	ErrDuplicatedLink    = errors.New("the principal is already linked to this holder")
+	ErrLinkNotFound      = errors.New("the principal is not linked to this holder")
+)
+
+type Service interface {
+	Link(ctx context.Context, link Link) (Link, error)
+	Unlink(ctx context.Context, principalID string, holderID uuid.UUID) error
+	ListLinks(ctx context.Context, principalID string) ([]Link, error)
+	AuthorizeBackOffice(ctx context.Context) error
+	AuthorizeHolder(ctx context.Context, holderID uuid.UUID) error
+	AuthorizeAccount(ctx context.Context, accountID uuid.UUID, access Access) error
+	AuthorizeDebit(ctx context.Context, principalID string, accountID uuid.UUID) error
+}
+
+type service struct {
+	tracer     tracer.Tracer
+	repository Repository
+}
+
+func NewService(t tracer.Tracer, r Repository) Service {
+	return service{
+		tracer:     t,
+		repository: r,
+	}
+}
+
+func (s service) Link(ctx context.Context, link Link) (Link, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	link.PrincipalID = strings.TrimSpace(link.PrincipalID)
+	if link.PrincipalID == "" {
+		span.RecordError(ErrPrincipalRequired)
+		return Link{}, ErrPrincipalRequired
+	}
+
+	model, err := s.repository.CreateLink(ctx, newLinkModel(link))
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, errDuplicatedLink) {
+			return Link{}, ErrDuplicatedLink
+		} else if errors.Is(err, errHolderNotFound) {
+			return Link{}, ErrHolderNotFound
+		}
+		zapctx.L(ctx).Error("policy_service_link_repository_error", zap.Error(err))
+		return Link{}, err
+	}
+
+	zapctx.L(ctx).Info(
+		"policy_service_principal_linked",
+		zap.String("linked_principal_id", model.PrincipalID),
+		zap.String("holder_id", model.HolderID.String()),
+	)
+
+	return newLink(model), nil
+}
+
+func (s service) Unlink(ctx context.Context, principalID string, holderID uuid.UUID) error {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	deleted, err := s.repository.DeleteLink(ctx, principalID, holderID)
+	if err != nil {
+		zapctx.L(ctx).Error(
+			"policy_service_unlink_repository_error",
+			zap.String("linked_principal_id", principalID),
+			zap.String("holder_id", holderID.String()),
+			zap.Error(err),
+		)
+		span.RecordError(err)
+		return err
+	}
+
+	if !deleted {
+		span.RecordError(ErrLinkNotFound)
+		return ErrLinkNotFound
+	}
+
+	zapctx.L(ctx).Info(
+		"policy_service_principal_unlinked",
+		zap.String("linked_principal_id", principalID),
+		zap.String("holder_id", holderID.String()),
+	)
+
+	return nil
+}
+
+func (s service) ListLinks(ctx context.Context, principalID string) ([]Link, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	models, err := s.repository.ListLinks(ctx, principalID)
+	if err != nil {
+		zapctx.L(ctx).Error("policy_service_list_links_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	links := make([]Link, len(models))
+	for i, model := range models {
+		links[i] = newLink(model)
+	}
+
+	return links, nil
+}
+
+// AuthorizeBackOffice returns ErrForbidden unless the principal of the request is from the back-office.
+func (s service) AuthorizeBackOffice(ctx context.Context) error {
+	_, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	principal, ok := middlewares.PrincipalFromContext(ctx)
+	if !ok || !backOffice(principal) {
+		span.RecordError(ErrForbidden)
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// AuthorizeHolder returns ErrForbidden unless the principal of the request is from the back-office or linked
+// to the holder.
+func (s service) AuthorizeHolder(ctx context.Context, holderID uuid.UUID) error {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	principal, ok := middlewares.PrincipalFromContext(ctx)
+	if !ok {
+		span.RecordError(ErrForbidden)
+		return ErrForbidden
+	}
+
+	if backOffice(principal) {
+		return nil
+	}
+
+	models, err := s.repository.ListLinks(ctx, principal.ID)
+	if err != nil {
+		zapctx.L(ctx).Error("policy_service_authorize_holder_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return err
+	}
+
+	for _, model := range models {
+		if model.HolderID == holderID {
+			return nil
+		}
+	}
+
+	zapctx.L(ctx).Warn("policy_service_holder_access_denied", zap.String("holder_id", holderID.String()))
+	span.RecordError(ErrForbidden)
+	return ErrForbidden
+}
+
+// AuthorizeAccount returns ErrForbidden unless the principal of the request is from the back-office or linked
+// to a holder of the account with the access. It is also returned when the account does not exist, so its
+// existence is not told to whoever has no access to it.
+func (s service) AuthorizeAccount(ctx context.Context, accountID uuid.UUID, access Access) error {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	principal, ok := middlewares.PrincipalFromContext(ctx)
+	if !ok {
+		span.RecordError(ErrForbidden)
+		return ErrForbidden
+	}
+
+	if backOffice(principal) {
+		return nil
+	}
+
+	roles, err := s.repository.ListAccountRoles(ctx, principal.ID, accountID)
+	if err != nil {
+		zapctx.L(ctx).Error("policy_service_authorize_account_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return err
+	}
+
+	for _, role := range roles {
+		if access == HolderAccess || (access == DebitAccess && role.CanDebit()) {
+			return nil
+		}
+	}
+
+	zapctx.L(ctx).Warn(
+		"policy_service_account_access_denied",
+		zap.String("account_id", accountID.String()),
+		zap.String("access", string(access)),
+	)
+	span.RecordError(ErrForbidden)
+	return ErrForbidden
+}
+
+// AuthorizeDebit returns ErrForbidden unless the principal is linked to a holder of the account whose role
+// allows debits. Unlike the other authorizations the scopes of the principal grant nothing: only holders move
+// the funds of their accounts.
+func (s service) AuthorizeDebit(ctx context.Context, principalID string, accountID uuid.UUID) error {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	roles, err := s.repository.ListAccountRoles(ctx, principalID, accountID)
+	if err != nil {
+		zapctx.L(ctx).Error("policy_service_authorize_debit_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return err
+	}
+
+	for _, role := range roles {
+		if role.CanDebit() {
+			return nil
+		}
+	}
+
+	zapctx.L(ctx).Warn(
+		"policy_service_debit_denied",
+		zap.String("linked_principal_id", principalID),
+		zap.String("account_id", accountID.String()),
+	)
+	span.RecordError(ErrForbidden)
+	return ErrForbidden
+}
+
+func backOffice(principal middlewares.Principal) bool {
+	return principal.HasScope(BackOfficeScope) || principal.HasScope(AdminScope)
+}
@@ -0,0 +1,136 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/policies/service.go
+
+// Package policies is a generated GoMock package.
+package policies
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// AuthorizeAccount mocks base method.
+func (m *MockService) AuthorizeAccount(ctx context.Context, accountID uuid.UUID, access Access) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeAccount", ctx, accountID, access)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthorizeAccount indicates an expected call of AuthorizeAccount.
+func (mr *MockServiceMockRecorder) AuthorizeAccount(ctx, accountID, access interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeAccount", reflect.TypeOf((*MockService)(nil).AuthorizeAccount), ctx, accountID, access)
+}
+
+// AuthorizeBackOffice mocks base method.
+func (m *MockService) AuthorizeBackOffice(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeBackOffice", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthorizeBackOffice indicates an expected call of AuthorizeBackOffice.
+func (mr *MockServiceMockRecorder) AuthorizeBackOffice(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeBackOffice", reflect.TypeOf((*MockService)(nil).AuthorizeBackOffice), ctx)
+}
+
+// AuthorizeDebit mocks base method.
+func (m *MockService) AuthorizeDebit(ctx context.Context, principalID string, accountID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeDebit", ctx, principalID, accountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthorizeDebit indicates an expected call of AuthorizeDebit.
+func (mr *MockServiceMockRecorder) AuthorizeDebit(ctx, principalID, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeDebit", reflect.TypeOf((*MockService)(nil).AuthorizeDebit), ctx, principalID, accountID)
+}
+
+// AuthorizeHolder mocks base method.
+func (m *MockService) AuthorizeHolder(ctx context.Context, holderID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeHolder", ctx, holderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthorizeHolder indicates an expected call of AuthorizeHolder.
+func (mr *MockServiceMockRecorder) AuthorizeHolder(ctx, holderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeHolder", reflect.TypeOf((*MockService)(nil).AuthorizeHolder), ctx, holderID)
+}
+
+// Link mocks base method.
+func (m *MockService) Link(ctx context.Context, link Link) (Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Link", ctx, link)
+	ret0, _ := ret[0].(Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Link indicates an expected call of Link.
+func (mr *MockServiceMockRecorder) Link(ctx, link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Link", reflect.TypeOf((*MockService)(nil).Link), ctx, link)
+}
+
+// ListLinks mocks base method.
+func (m *MockService) ListLinks(ctx context.Context, principalID string) ([]Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLinks", ctx, principalID)
+	ret0, _ := ret[0].([]Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLinks indicates an expected call of ListLinks.
+func (mr *MockServiceMockRecorder) ListLinks(ctx, principalID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLinks", reflect.TypeOf((*MockService)(nil).ListLinks), ctx, principalID)
+}
+
+// Unlink mocks base method.
+func (m *MockService) Unlink(ctx context.Context, principalID string, holderID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unlink", ctx, principalID, holderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unlink indicates an expected call of Unlink.
+func (mr *MockServiceMockRecorder) Unlink(ctx, principalID, holderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlink", reflect.TypeOf((*MockService)(nil).Unlink), ctx, principalID, holderID)
+}
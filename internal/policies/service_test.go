@@ -0,0 +1,254 @@
+//go:build unit
+
+package policies
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/pkg/http/middlewares"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_Link(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	svc := NewService(tracer.NewNoop(), repoMock)
+
+	t.Run("fail link, principal required", func(t *testing.T) {
+		link, err := svc.Link(ctx, Link{PrincipalID: "  ", HolderID: uuid.New()})
+		assert.ErrorIs(t, err, ErrPrincipalRequired)
+		assert.Empty(t, link)
+	})
+
+	t.Run("fail link, repository errors", func(t *testing.T) {
+		for _, tt := range []struct {
+			repoErr error
+			wantErr error
+		}{
+			{errDuplicatedLink, ErrDuplicatedLink},
+			{errHolderNotFound, ErrHolderNotFound},
+			{sql.ErrConnDone, sql.ErrConnDone},
+		} {
+			repoMock.EXPECT().CreateLink(ctx, gomock.Any()).Return(linkModel{}, tt.repoErr)
+
+			link, err := svc.Link(ctx, Link{PrincipalID: "subject", HolderID: uuid.New()})
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Empty(t, link)
+		}
+	})
+
+	t.Run("success link", func(t *testing.T) {
+		holderID := uuid.New()
+		repoMock.EXPECT().
+			CreateLink(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, m linkModel) (linkModel, error) {
+				return m, nil
+			})
+
+		link, err := svc.Link(ctx, Link{PrincipalID: " subject ", HolderID: holderID, CreatedBy: "bootstrap"})
+		assert.NoError(t, err)
+		assert.Equal(t, "subject", link.PrincipalID)
+		assert.Equal(t, holderID, link.HolderID)
+		assert.Equal(t, "bootstrap", link.CreatedBy)
+		assert.False(t, link.CreatedAt.IsZero())
+	})
+}
+
+func TestService_Unlink(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	svc := NewService(tracer.NewNoop(), repoMock)
+
+	t.Run("fail unlink, link not found", func(t *testing.T) {
+		holderID := uuid.New()
+		repoMock.EXPECT().DeleteLink(ctx, "subject", holderID).Return(false, nil)
+
+		assert.ErrorIs(t, svc.Unlink(ctx, "subject", holderID), ErrLinkNotFound)
+	})
+
+	t.Run("success unlink", func(t *testing.T) {
+		holderID := uuid.New()
+		repoMock.EXPECT().DeleteLink(ctx, "subject", holderID).Return(true, nil)
+
+		assert.NoError(t, svc.Unlink(ctx, "subject", holderID))
+	})
+}
+
+func TestService_AuthorizeBackOffice(t *testing.T) {
+	svc := NewService(tracer.NewNoop(), NewMockRepository(gomock.NewController(t)))
+
+	for _, tt := range []struct {
+		name      string
+		principal middlewares.Principal
+		wantErr   error
+	}{
+		{"success authorize, back-office principal", middlewares.Principal{Scopes: []string{BackOfficeScope}}, nil},
+		{"success authorize, admin principal", middlewares.Principal{Scopes: []string{AdminScope}}, nil},
+		{"fail authorize, customer principal", middlewares.Principal{ID: "subject"}, ErrForbidden},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := middlewares.WithPrincipal(context.Background(), tt.principal)
+			assert.ErrorIs(t, svc.AuthorizeBackOffice(ctx), tt.wantErr)
+		})
+	}
+
+	t.Run("fail authorize, no principal", func(t *testing.T) {
+		assert.ErrorIs(t, svc.AuthorizeBackOffice(context.Background()), ErrForbidden)
+	})
+}
+
+func TestService_AuthorizeHolder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	svc := NewService(tracer.NewNoop(), repoMock)
+
+	holderID := uuid.New()
+	customer := middlewares.WithPrincipal(context.Background(), middlewares.Principal{ID: "subject"})
+
+	t.Run("fail authorize, no principal", func(t *testing.T) {
+		assert.ErrorIs(t, svc.AuthorizeHolder(context.Background(), holderID), ErrForbidden)
+	})
+
+	t.Run("success authorize, back-office principal", func(t *testing.T) {
+		ctx := middlewares.WithPrincipal(
+			context.Background(),
+			middlewares.Principal{ID: "operator", Scopes: []string{BackOfficeScope}},
+		)
+
+		assert.NoError(t, svc.AuthorizeHolder(ctx, holderID))
+	})
+
+	t.Run("success authorize, linked principal", func(t *testing.T) {
+		repoMock.EXPECT().
+			ListLinks(customer, "subject").
+			Return([]linkModel{
+				{PrincipalID: "subject", HolderID: uuid.New()},
+				{PrincipalID: "subject", HolderID: holderID},
+			}, nil)
+
+		assert.NoError(t, svc.AuthorizeHolder(customer, holderID))
+	})
+
+	t.Run("fail authorize, principal linked to another holder", func(t *testing.T) {
+		repoMock.EXPECT().
+			ListLinks(customer, "subject").
+			Return([]linkModel{{PrincipalID: "subject", HolderID: uuid.New()}}, nil)
+
+		assert.ErrorIs(t, svc.AuthorizeHolder(customer, holderID), ErrForbidden)
+	})
+
+	t.Run("fail authorize, repository error", func(t *testing.T) {
+		repoMock.EXPECT().ListLinks(customer, "subject").Return(nil, sql.ErrConnDone)
+
+		assert.ErrorIs(t, svc.AuthorizeHolder(customer, holderID), sql.ErrConnDone)
+	})
+}
+
+func TestService_AuthorizeAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	svc := NewService(tracer.NewNoop(), repoMock)
+
+	accountID := uuid.New()
+	customer := middlewares.WithPrincipal(context.Background(), middlewares.Principal{ID: "subject"})
+
+	t.Run("fail authorize, no principal", func(t *testing.T) {
+		assert.ErrorIs(t, svc.AuthorizeAccount(context.Background(), accountID, HolderAccess), ErrForbidden)
+	})
+
+	t.Run("success authorize, admin principal", func(t *testing.T) {
+		ctx := middlewares.WithPrincipal(
+			context.Background(),
+			middlewares.Principal{ID: "bootstrap", Scopes: []string{AdminScope}},
+		)
+
+		assert.NoError(t, svc.AuthorizeAccount(ctx, accountID, DebitAccess))
+	})
+
+	for _, tt := range []struct {
+		name    string
+		roles   []accounts.HolderRole
+		access  Access
+		wantErr error
+	}{
+		{"success authorize, operator reads", []accounts.HolderRole{accounts.OperatorHolderRole}, HolderAccess, nil},
+		{"fail authorize, operator debits", []accounts.HolderRole{accounts.OperatorHolderRole}, DebitAccess, ErrForbidden},
+		{"success authorize, owner debits", []accounts.HolderRole{accounts.OwnerHolderRole}, DebitAccess, nil},
+		{"fail authorize, not a holder", nil, HolderAccess, ErrForbidden},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMock.EXPECT().ListAccountRoles(customer, "subject", accountID).Return(tt.roles, nil)
+
+			err := svc.AuthorizeAccount(customer, accountID, tt.access)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("fail authorize, repository error", func(t *testing.T) {
+		repoMock.EXPECT().ListAccountRoles(customer, "subject", accountID).Return(nil, sql.ErrConnDone)
+
+		assert.ErrorIs(t, svc.AuthorizeAccount(customer, accountID, HolderAccess), sql.ErrConnDone)
+	})
+}
+
+func TestService_AuthorizeDebit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	svc := NewService(tracer.NewNoop(), repoMock)
+
+	accountID := uuid.New()
+	admin := middlewares.WithPrincipal(
+		context.Background(),
+		middlewares.Principal{ID: "bootstrap", Scopes: []string{AdminScope}},
+	)
+
+	for _, tt := range []struct {
+		name    string
+		roles   []accounts.HolderRole
+		wantErr error
+	}{
+		{"fail authorize, admin not a holder", nil, ErrForbidden},
+		{"fail authorize, operator", []accounts.HolderRole{accounts.OperatorHolderRole}, ErrForbidden},
+		{"success authorize, co-owner", []accounts.HolderRole{accounts.CoOwnerHolderRole}, nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMock.EXPECT().ListAccountRoles(admin, "bootstrap", accountID).Return(tt.roles, nil)
+
+			err := svc.AuthorizeDebit(admin, "bootstrap", accountID)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("fail authorize, repository error", func(t *testing.T) {
+		repoMock.EXPECT().ListAccountRoles(admin, "bootstrap", accountID).Return(nil, sql.ErrConnDone)
+
+		assert.ErrorIs(t, svc.AuthorizeDebit(admin, "bootstrap", accountID), sql.ErrConnDone)
+	})
+}
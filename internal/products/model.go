@@ -0,0 +1,20 @@
+package products
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type productModel struct {
+	bun.BaseModel `bun:"table:products"`
+
+	Type            Type      `bun:"type,pk"`
+	Name            string    `bun:"name"`
+	DailyDebitLimit float64   `bun:"daily_debit_limit"`
+	P2PAllowed      bool      `bun:"p2p_allowed"`
+	MaxBalance      float64   `bun:"max_balance"`
+	MonthlyFee      float64   `bun:"monthly_fee"`
+	CreatedAt       time.Time `bun:"created_at,notnull"`
+	UpdatedAt       time.Time `bun:"updated_at,nullzero"`
+}
@@ -0,0 +1,48 @@
+package products
+
+type Type string
+
+const (
+	CheckingType Type = "CHECKING"
+	SavingsType  Type = "SAVINGS"
+	PaymentType  Type = "PAYMENT"
+	BusinessType Type = "BUSINESS"
+)
+
+var Types = []Type{CheckingType, SavingsType, PaymentType, BusinessType}
+
+func (t Type) Valid() bool {
+	for _, v := range Types {
+		if t == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+type Product struct {
+	Type            Type
+	Name            string
+	DailyDebitLimit float64
+	P2PAllowed      bool
+	// MaxBalance is the highest balance an account may hold, zero means unlimited.
+	MaxBalance float64
+	MonthlyFee float64
+}
+
+func newProduct(model productModel) Product {
+	return Product{
+		Type:            model.Type,
+		Name:            model.Name,
+		DailyDebitLimit: model.DailyDebitLimit,
+		P2PAllowed:      model.P2PAllowed,
+		MaxBalance:      model.MaxBalance,
+		MonthlyFee:      model.MonthlyFee,
+	}
+}
+
+// HasMaxBalance reports whether the product restricts the account balance.
+func (p Product) HasMaxBalance() bool {
+	return p.MaxBalance > 0
+}
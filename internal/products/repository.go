@@ -0,0 +1,61 @@
+package products
+
+import (
+	"context"
+
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+)
+
+type Repository interface {
+	GetByType(ctx context.Context, productType Type) (productModel, error)
+	List(ctx context.Context) ([]productModel, error)
+}
+
+type repository struct {
+	tracer tracer.Tracer
+	db     database.Database
+}
+
+func NewRepository(t tracer.Tracer, db database.Database) Repository {
+	return repository{
+		tracer: t,
+		db:     db,
+	}
+}
+
+func (r repository) GetByType(ctx context.Context, productType Type) (productModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var model productModel
+	err := r.db.Replica().
+		NewSelect().
+		Model(&model).
+		Where("type = ?", productType).
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return productModel{}, err
+	}
+
+	return model, nil
+}
+
+func (r repository) List(ctx context.Context) ([]productModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var models []productModel
+	err := r.db.Replica().
+		NewSelect().
+		Model(&models).
+		Order("type ASC").
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return models, nil
+}
@@ -0,0 +1,68 @@
+package products
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"go.uber.org/zap"
+)
+
+var ErrProductNotFound = errors.New("no product found with this type")
+
+type Service interface {
+	GetByType(ctx context.Context, productType Type) (Product, error)
+	List(ctx context.Context) ([]Product, error)
+}
+
+type service struct {
+	tracer     tracer.Tracer
+	repository Repository
+}
+
+func NewService(t tracer.Tracer, r Repository) Service {
+	return service{tracer: t, repository: r}
+}
+
+func (s service) GetByType(ctx context.Context, productType Type) (Product, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	model, err := s.repository.GetByType(ctx, productType)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			span.RecordError(ErrProductNotFound)
+			return Product{}, ErrProductNotFound
+		}
+		zapctx.L(ctx).Error(
+			"products_service_get_repository_error",
+			zap.String("type", string(productType)),
+			zap.Error(err),
+		)
+		span.RecordError(err)
+		return Product{}, err
+	}
+
+	return newProduct(model), nil
+}
+
+func (s service) List(ctx context.Context) ([]Product, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	models, err := s.repository.List(ctx)
+	if err != nil {
+		zapctx.L(ctx).Error("products_service_list_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	prds := make([]Product, len(models))
+	for i, model := range models {
+		prds[i] = newProduct(model)
+	}
+
+	return prds, nil
+}
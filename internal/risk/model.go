@@ -0,0 +1,85 @@
+package risk
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type ruleModel struct {
+	bun.BaseModel `bun:"table:risk_rules,alias:rule"`
+
+	ID            uuid.UUID `bun:"id,pk"`
+	Type          RuleType  `bun:"type"`
+	Outcome       Outcome   `bun:"outcome"`
+	Enabled       bool      `bun:"enabled"`
+	Count         int       `bun:"count"`
+	WindowMinutes int       `bun:"window_minutes"`
+	Multiplier    float64   `bun:"multiplier"`
+	MinAmount     float64   `bun:"min_amount"`
+	CreatedAt     time.Time `bun:"created_at,notnull"`
+	UpdatedAt     time.Time `bun:"updated_at,notnull"`
+}
+
+func newRuleModel(rule Rule) ruleModel {
+	return ruleModel{
+		ID:            rule.ID,
+		Type:          rule.Type,
+		Outcome:       rule.Outcome,
+		Enabled:       rule.Enabled,
+		Count:         rule.Count,
+		WindowMinutes: rule.WindowMinutes,
+		Multiplier:    rule.Multiplier,
+		MinAmount:     rule.MinAmount,
+		CreatedAt:     rule.CreatedAt,
+		UpdatedAt:     rule.UpdatedAt,
+	}
+}
+
+type evaluationModel struct {
+	bun.BaseModel `bun:"table:risk_evaluations,alias:evaluation"`
+
+	ID              uuid.UUID     `bun:"id,pk"`
+	AccountID       uuid.UUID     `bun:"account_id"`
+	CounterpartyID  uuid.NullUUID `bun:"counterparty_id"`
+	TransactionType string        `bun:"transaction_type"`
+	Amount          float64       `bun:"amount"`
+	Outcome         Outcome       `bun:"outcome"`
+	CreatedAt       time.Time     `bun:"created_at,notnull"`
+}
+
+type hitModel struct {
+	bun.BaseModel `bun:"table:risk_rule_hits,alias:hit"`
+
+	ID           uuid.UUID `bun:"id,pk"`
+	EvaluationID uuid.UUID `bun:"evaluation_id"`
+	RuleID       uuid.UUID `bun:"rule_id"`
+	RuleType     RuleType  `bun:"rule_type"`
+	Outcome      Outcome   `bun:"outcome"`
+	Reason       string    `bun:"reason"`
+}
+
+func newEvaluationModels(evaluation Evaluation) (evaluationModel, []hitModel) {
+	hits := make([]hitModel, 0, len(evaluation.Hits))
+	for _, hit := range evaluation.Hits {
+		hits = append(hits, hitModel{
+			ID:           uuid.New(),
+			EvaluationID: evaluation.ID,
+			RuleID:       hit.RuleID,
+			RuleType:     hit.RuleType,
+			Outcome:      hit.Outcome,
+			Reason:       hit.Reason,
+		})
+	}
+
+	return evaluationModel{
+		ID:              evaluation.ID,
+		AccountID:       evaluation.AccountID,
+		CounterpartyID:  evaluation.CounterpartyID,
+		TransactionType: evaluation.TransactionType,
+		Amount:          evaluation.Amount,
+		Outcome:         evaluation.Outcome,
+		CreatedAt:       evaluation.CreatedAt,
+	}, hits
+}
@@ -0,0 +1,321 @@
+package risk
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// debitTypes are the transactions that take funds out of an account by the will of its holders.
+var debitTypes = []string{"DEBIT", "P2P", "TED"}
+
+type Repository interface {
+	CreateRule(ctx context.Context, model ruleModel) (ruleModel, error)
+	UpdateRule(ctx context.Context, model ruleModel) (ruleModel, error)
+	DeleteRule(ctx context.Context, id uuid.UUID) (bool, error)
+	ListRules(ctx context.Context, enabledOnly bool) ([]ruleModel, error)
+	CreateEvaluation(ctx context.Context, model evaluationModel, hits []hitModel) error
+	ListEvaluations(ctx context.Context, filter EvaluationFilter) (int, []evaluationModel, []hitModel, error)
+	CountDebitsSince(ctx context.Context, accountID uuid.UUID, since time.Time) (int, error)
+	AverageDebitSince(ctx context.Context, accountID uuid.UUID, since time.Time) (float64, error)
+	HasPaid(ctx context.Context, accountID, counterpartyID uuid.UUID) (bool, error)
+	LastUnblockedAt(ctx context.Context, accountID uuid.UUID) (time.Time, error)
+}
+
+type repository struct {
+	tracer tracer.Tracer
+	db     database.Database
+}
+
+func NewRepository(t tracer.Tracer, db database.Database) Repository {
+	return repository{
+		tracer: t,
+		db:     db,
+	}
+}
+
+func (r repository) CreateRule(ctx context.Context, model ruleModel) (ruleModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	model.ID = uuid.New()
+	model.CreatedAt = time.Now().UTC()
+	model.UpdatedAt = model.CreatedAt
+
+	_, err := r.db.Master().
+		NewInsert().
+		Model(&model).
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return ruleModel{}, err
+	}
+
+	return model, nil
+}
+
+// UpdateRule replaces the parameters of the rule, returning sql.ErrNoRows when it does not exist.
+func (r repository) UpdateRule(ctx context.Context, model ruleModel) (ruleModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	model.UpdatedAt = time.Now().UTC()
+
+	res, err := r.db.Master().
+		NewUpdate().
+		Model(&model).
+		Column("type", "outcome", "enabled", "count", "window_minutes", "multiplier", "min_amount", "updated_at").
+		WherePK().
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return ruleModel{}, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		span.RecordError(err)
+		return ruleModel{}, err
+	}
+
+	if rows == 0 {
+		span.RecordError(sql.ErrNoRows)
+		return ruleModel{}, sql.ErrNoRows
+	}
+
+	return model, nil
+}
+
+// DeleteRule removes the rule, returning false when it does not exist. The hits of the rule are kept
+// with its id and type.
+func (r repository) DeleteRule(ctx context.Context, id uuid.UUID) (bool, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	res, err := r.db.Master().
+		NewDelete().
+		Model((*ruleModel)(nil)).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return false, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		span.RecordError(err)
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+// ListRules reads the rules from the master, so a rule changed applies to the very next debit.
+func (r repository) ListRules(ctx context.Context, enabledOnly bool) ([]ruleModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var models []ruleModel
+	selectQuery := r.db.Master().
+		NewSelect().
+		Model(&models).
+		Order("created_at ASC", "id ASC")
+
+	if enabledOnly {
+		selectQuery.Where("enabled")
+	}
+
+	if err := selectQuery.Scan(ctx); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return models, nil
+}
+
+// CreateEvaluation records the evaluation along with its hits.
+func (r repository) CreateEvaluation(ctx context.Context, model evaluationModel, hits []hitModel) error {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	err := r.db.Master().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewInsert().
+			Model(&model).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(hits) == 0 {
+			return nil
+		}
+
+		_, err = tx.NewInsert().
+			Model(&hits).
+			Exec(ctx)
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+// ListEvaluations lists a page of the evaluations, newest first, and the hits of each of them.
+func (r repository) ListEvaluations(
+	ctx context.Context,
+	filter EvaluationFilter,
+) (int, []evaluationModel, []hitModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var models []evaluationModel
+	selectQuery := r.db.Replica().
+		NewSelect().
+		Model(&models).
+		Order("created_at DESC", "id ASC").
+		Limit(filter.Size).
+		Offset((filter.Page - 1) * filter.Size)
+
+	if len(filter.IDs) > 0 {
+		selectQuery.Where("id IN (?)", bun.In(filter.IDs))
+	}
+
+	if filter.AccountID.Valid {
+		selectQuery.Where("account_id = ?", filter.AccountID.UUID)
+	}
+
+	if filter.Outcome != "" {
+		selectQuery.Where("outcome = ?", filter.Outcome)
+	}
+
+	total, err := selectQuery.ScanAndCount(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return 0, nil, nil, err
+	}
+
+	if len(models) == 0 {
+		return total, models, nil, nil
+	}
+
+	ids := make([]uuid.UUID, len(models))
+	for i, model := range models {
+		ids[i] = model.ID
+	}
+
+	var hits []hitModel
+	err = r.db.Replica().
+		NewSelect().
+		Model(&hits).
+		Where("evaluation_id IN (?)", bun.In(ids)).
+		Order("id ASC").
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return 0, nil, nil, err
+	}
+
+	return total, models, hits, nil
+}
+
+// CountDebitsSince counts the debits made from the account since the time.
+func (r repository) CountDebitsSince(ctx context.Context, accountID uuid.UUID, since time.Time) (int, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	count, err := r.db.Master().
+		NewSelect().
+		TableExpr("transactions").
+		Where("from_account_id = ?", accountID).
+		Where("type IN (?)", bun.In(debitTypes)).
+		Where("created_at >= ?", since).
+		Count(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// AverageDebitSince is the average amount of the debits made from the account since the time, zero
+// when there are none.
+func (r repository) AverageDebitSince(ctx context.Context, accountID uuid.UUID, since time.Time) (float64, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var average float64
+	err := r.db.Replica().
+		NewSelect().
+		TableExpr("transactions").
+		ColumnExpr("COALESCE(AVG(amount), 0)").
+		Where("from_account_id = ?", accountID).
+		Where("type IN (?)", bun.In(debitTypes)).
+		Where("created_at >= ?", since).
+		Scan(ctx, &average)
+	if err != nil {
+		span.RecordError(err)
+		return 0, err
+	}
+
+	return average, nil
+}
+
+// HasPaid tells whether the account ever made a P2P to the counterparty.
+func (r repository) HasPaid(ctx context.Context, accountID, counterpartyID uuid.UUID) (bool, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	exists, err := r.db.Replica().
+		NewSelect().
+		TableExpr("transactions").
+		Where("from_account_id = ?", accountID).
+		Where("to_account_id = ?", counterpartyID).
+		Where("type = ?", "P2P").
+		Exists(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// LastUnblockedAt is when the account last went from blocked to active, zero when it never did.
+func (r repository) LastUnblockedAt(ctx context.Context, accountID uuid.UUID) (time.Time, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var unblockedAt time.Time
+	err := r.db.Master().
+		NewSelect().
+		TableExpr("account_status_events").
+		ColumnExpr("created_at").
+		Where("account_id = ?", accountID).
+		Where("from_status = ?", "BLOCKED").
+		Where("to_status = ?", "ACTIVE").
+		Order("created_at DESC").
+		Limit(1).
+		Scan(ctx, &unblockedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, nil
+		}
+		span.RecordError(err)
+		return time.Time{}, err
+	}
+
+	return unblockedAt, nil
+}
@@ -0,0 +1,188 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/risk/repository.go
+
+// Package risk is a generated GoMock package.
+package risk
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AverageDebitSince mocks base method.
+func (m *MockRepository) AverageDebitSince(ctx context.Context, accountID uuid.UUID, since time.Time) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AverageDebitSince", ctx, accountID, since)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AverageDebitSince indicates an expected call of AverageDebitSince.
+func (mr *MockRepositoryMockRecorder) AverageDebitSince(ctx, accountID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AverageDebitSince", reflect.TypeOf((*MockRepository)(nil).AverageDebitSince), ctx, accountID, since)
+}
+
+// CountDebitsSince mocks base method.
+func (m *MockRepository) CountDebitsSince(ctx context.Context, accountID uuid.UUID, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDebitsSince", ctx, accountID, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDebitsSince indicates an expected call of CountDebitsSince.
+func (mr *MockRepositoryMockRecorder) CountDebitsSince(ctx, accountID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDebitsSince", reflect.TypeOf((*MockRepository)(nil).CountDebitsSince), ctx, accountID, since)
+}
+
+// CreateEvaluation mocks base method.
+func (m *MockRepository) CreateEvaluation(ctx context.Context, model evaluationModel, hits []hitModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEvaluation", ctx, model, hits)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEvaluation indicates an expected call of CreateEvaluation.
+func (mr *MockRepositoryMockRecorder) CreateEvaluation(ctx, model, hits interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvaluation", reflect.TypeOf((*MockRepository)(nil).CreateEvaluation), ctx, model, hits)
+}
+
+// CreateRule mocks base method.
+func (m *MockRepository) CreateRule(ctx context.Context, model ruleModel) (ruleModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRule", ctx, model)
+	ret0, _ := ret[0].(ruleModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRule indicates an expected call of CreateRule.
+func (mr *MockRepositoryMockRecorder) CreateRule(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockRepository)(nil).CreateRule), ctx, model)
+}
+
+// DeleteRule mocks base method.
+func (m *MockRepository) DeleteRule(ctx context.Context, id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockRepositoryMockRecorder) DeleteRule(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockRepository)(nil).DeleteRule), ctx, id)
+}
+
+// HasPaid mocks base method.
+func (m *MockRepository) HasPaid(ctx context.Context, accountID, counterpartyID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPaid", ctx, accountID, counterpartyID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPaid indicates an expected call of HasPaid.
+func (mr *MockRepositoryMockRecorder) HasPaid(ctx, accountID, counterpartyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPaid", reflect.TypeOf((*MockRepository)(nil).HasPaid), ctx, accountID, counterpartyID)
+}
+
+// LastUnblockedAt mocks base method.
+func (m *MockRepository) LastUnblockedAt(ctx context.Context, accountID uuid.UUID) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastUnblockedAt", ctx, accountID)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastUnblockedAt indicates an expected call of LastUnblockedAt.
+func (mr *MockRepositoryMockRecorder) LastUnblockedAt(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastUnblockedAt", reflect.TypeOf((*MockRepository)(nil).LastUnblockedAt), ctx, accountID)
+}
+
+// ListEvaluations mocks base method.
+func (m *MockRepository) ListEvaluations(ctx context.Context, filter EvaluationFilter) (int, []evaluationModel, []hitModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvaluations", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]evaluationModel)
+	ret2, _ := ret[2].([]hitModel)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// ListEvaluations indicates an expected call of ListEvaluations.
+func (mr *MockRepositoryMockRecorder) ListEvaluations(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvaluations", reflect.TypeOf((*MockRepository)(nil).ListEvaluations), ctx, filter)
+}
+
+// ListRules mocks base method.
+func (m *MockRepository) ListRules(ctx context.Context, enabledOnly bool) ([]ruleModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRules", ctx, enabledOnly)
+	ret0, _ := ret[0].([]ruleModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRules indicates an expected call of ListRules.
+func (mr *MockRepositoryMockRecorder) ListRules(ctx, enabledOnly interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRules", reflect.TypeOf((*MockRepository)(nil).ListRules), ctx, enabledOnly)
+}
+
+// UpdateRule mocks base method.
+func (m *MockRepository) UpdateRule(ctx context.Context, model ruleModel) (ruleModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRule", ctx, model)
+	ret0, _ := ret[0].(ruleModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRule indicates an expected call of UpdateRule.
+func (mr *MockRepositoryMockRecorder) UpdateRule(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRule", reflect.TypeOf((*MockRepository)(nil).UpdateRule), ctx, model)
+}
@@ -0,0 +1,207 @@
+//go:build integration
+
+package risk
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/holders"
+	"github.com/dalmarcogd/dock-test/internal/products"
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/testingcontainers"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+
+	url, closeFunc, err := testingcontainers.NewPostgresContainer()
+	assert.NoError(t, err)
+	defer closeFunc(ctx) //nolint:errcheck
+
+	_, callerPath, _, _ := runtime.Caller(0) //nolint:dogsled
+	err = testingcontainers.RunMigrateDatabase(
+		url,
+		fmt.Sprintf("file://%s/../../migrations/", filepath.Dir(callerPath)),
+	)
+	assert.NoError(t, err)
+
+	db, err := database.New(tracer.NewNoop(), url, url)
+	assert.NoError(t, err)
+
+	holdersRepo := holders.NewRepository(tracer.NewNoop(), db)
+	holderModel, err := holdersRepo.Create(ctx, holders.HolderModel{
+		ID:             uuid.New(),
+		Name:           gofakeit.Name(),
+		DocumentNumber: gofakeit.SSN(),
+	})
+	assert.NoError(t, err)
+
+	accSvc := accounts.NewService(tracer.NewNoop(), accounts.NewRepository(tracer.NewNoop(), db), holdersRepo, false, 0)
+	account, err := accSvc.Create(ctx, accounts.Account{
+		Name:           gofakeit.Name(),
+		DocumentNumber: holderModel.DocumentNumber,
+		Type:           products.BusinessType,
+	})
+	assert.NoError(t, err)
+
+	repo := NewRepository(tracer.NewNoop(), db)
+
+	t.Run("rules created, updated and deleted", func(t *testing.T) {
+		velocity, err := repo.CreateRule(ctx, ruleModel{
+			Type:          VelocityRule,
+			Outcome:       DenyOutcome,
+			Enabled:       true,
+			Count:         5,
+			WindowMinutes: 10,
+		})
+		assert.NoError(t, err)
+
+		unblock, err := repo.CreateRule(ctx, ruleModel{
+			Type:          AfterUnblockRule,
+			Outcome:       ReviewOutcome,
+			WindowMinutes: 60,
+		})
+		assert.NoError(t, err)
+
+		rules, err := repo.ListRules(ctx, true)
+		assert.NoError(t, err)
+		assert.Len(t, rules, 1)
+		assert.Equal(t, velocity.ID, rules[0].ID)
+
+		unblock.Enabled = true
+		unblock.MinAmount = 100
+		updated, err := repo.UpdateRule(ctx, unblock)
+		assert.NoError(t, err)
+		assert.Equal(t, 100.0, updated.MinAmount)
+
+		rules, err = repo.ListRules(ctx, true)
+		assert.NoError(t, err)
+		assert.Len(t, rules, 2)
+
+		_, err = repo.UpdateRule(ctx, ruleModel{ID: uuid.New(), Type: VelocityRule, Outcome: DenyOutcome})
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		deleted, err := repo.DeleteRule(ctx, velocity.ID)
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+		deleted, err = repo.DeleteRule(ctx, velocity.ID)
+		assert.NoError(t, err)
+		assert.False(t, deleted)
+	})
+
+	t.Run("evaluations listed with their hits", func(t *testing.T) {
+		allowed, _ := newEvaluationModels(Evaluation{
+			ID:              uuid.New(),
+			AccountID:       account.ID,
+			TransactionType: "DEBIT",
+			Amount:          10,
+			Outcome:         AllowOutcome,
+			CreatedAt:       time.Now().UTC().Add(-time.Minute),
+		})
+		assert.NoError(t, repo.CreateEvaluation(ctx, allowed, nil))
+
+		reviewed, hits := newEvaluationModels(Evaluation{
+			ID:              uuid.New(),
+			AccountID:       account.ID,
+			CounterpartyID:  uuid.NullUUID{UUID: uuid.New(), Valid: true},
+			TransactionType: "P2P",
+			Amount:          5000,
+			Outcome:         ReviewOutcome,
+			Hits: []Hit{
+				{RuleID: uuid.New(), RuleType: NewCounterpartyRule, Outcome: ReviewOutcome, Reason: "first payment"},
+			},
+			CreatedAt: time.Now().UTC(),
+		})
+		assert.NoError(t, repo.CreateEvaluation(ctx, reviewed, hits))
+
+		total, models, hitModels, err := repo.ListEvaluations(ctx, EvaluationFilter{
+			AccountID: uuid.NullUUID{UUID: account.ID, Valid: true},
+			Page:      1,
+			Size:      10,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Equal(t, reviewed.ID, models[0].ID)
+		assert.Len(t, hitModels, 1)
+
+		total, models, _, err = repo.ListEvaluations(ctx, EvaluationFilter{Outcome: AllowOutcome, Page: 1, Size: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, allowed.ID, models[0].ID)
+
+		total, models, hitModels, err = repo.ListEvaluations(ctx, EvaluationFilter{
+			IDs:  []uuid.UUID{reviewed.ID},
+			Page: 1,
+			Size: 10,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, reviewed.ID, models[0].ID)
+		assert.Len(t, hitModels, 1)
+	})
+
+	t.Run("debits of the account", func(t *testing.T) {
+		counterpartyID := uuid.New()
+		for _, amount := range []float64{100, 300} {
+			_, err := db.Master().ExecContext(
+				ctx,
+				"INSERT INTO transactions (id, from_account_id, to_account_id, type, amount, description, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+				uuid.New(),
+				account.ID,
+				counterpartyID,
+				"P2P",
+				amount,
+				gofakeit.BeerName(),
+				time.Now().UTC(),
+			)
+			assert.NoError(t, err)
+		}
+
+		since := time.Now().UTC().Add(-time.Hour)
+		count, err := repo.CountDebitsSince(ctx, account.ID, since)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		average, err := repo.AverageDebitSince(ctx, account.ID, since)
+		assert.NoError(t, err)
+		assert.Equal(t, 200.0, average)
+
+		average, err = repo.AverageDebitSince(ctx, uuid.New(), since)
+		assert.NoError(t, err)
+		assert.Zero(t, average)
+
+		paid, err := repo.HasPaid(ctx, account.ID, counterpartyID)
+		assert.NoError(t, err)
+		assert.True(t, paid)
+
+		paid, err = repo.HasPaid(ctx, account.ID, uuid.New())
+		assert.NoError(t, err)
+		assert.False(t, paid)
+	})
+
+	t.Run("last unblocked at", func(t *testing.T) {
+		unblockedAt, err := repo.LastUnblockedAt(ctx, account.ID)
+		assert.NoError(t, err)
+		assert.True(t, unblockedAt.IsZero())
+
+		_, err = accSvc.BlockByID(ctx, account.ID, accounts.StatusChange{Reason: "chargeback dispute", Actor: "back-office"})
+		assert.NoError(t, err)
+		_, err = accSvc.UnblockByID(ctx, account.ID, accounts.StatusChange{Reason: "dispute settled", Actor: "back-office"})
+		assert.NoError(t, err)
+
+		unblockedAt, err = repo.LastUnblockedAt(ctx, account.ID)
+		assert.NoError(t, err)
+		assert.WithinDuration(t, time.Now(), unblockedAt, time.Minute)
+	})
+}
@@ -0,0 +1,143 @@
+package risk
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RuleType string
+
+const (
+	// VelocityRule hits when the account made Count debits or more in the last WindowMinutes.
+	VelocityRule RuleType = "VELOCITY"
+	// AmountAboveAverageRule hits when the amount, from MinAmount, is more than Multiplier times the
+	// average of the debits of the last WindowMinutes, once the account made at least Count of them.
+	AmountAboveAverageRule RuleType = "AMOUNT_ABOVE_AVERAGE"
+	// NewCounterpartyRule hits on the first P2P to an account above MinAmount.
+	NewCounterpartyRule RuleType = "NEW_COUNTERPARTY"
+	// AfterUnblockRule hits on debits from MinAmount in the WindowMinutes after the account was unblocked.
+	AfterUnblockRule RuleType = "AFTER_UNBLOCK"
+)
+
+// RuleTypes are the rules debits can be evaluated with.
+var RuleTypes = []RuleType{VelocityRule, AmountAboveAverageRule, NewCounterpartyRule, AfterUnblockRule}
+
+type Outcome string
+
+const (
+	AllowOutcome Outcome = "ALLOW"
+	// ReviewOutcome is a debit an analyst must review before it is made.
+	ReviewOutcome Outcome = "REVIEW"
+	DenyOutcome   Outcome = "DENY"
+)
+
+// Outcomes are the outcomes of rules, a debit no rule hits is allowed.
+var Outcomes = []Outcome{ReviewOutcome, DenyOutcome}
+
+// severity orders outcomes, the evaluation outcome is the most severe of the rules hit.
+func (o Outcome) severity() int {
+	switch o {
+	case DenyOutcome:
+		return 2
+	case ReviewOutcome:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Rule is a check debits go through before being made. Each type of rule reads only its own
+// parameters.
+type Rule struct {
+	ID            uuid.UUID
+	Type          RuleType
+	Outcome       Outcome
+	Enabled       bool
+	Count         int
+	WindowMinutes int
+	Multiplier    float64
+	MinAmount     float64
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (r Rule) window() time.Duration {
+	return time.Duration(r.WindowMinutes) * time.Minute
+}
+
+func newRule(model ruleModel) Rule {
+	return Rule{
+		ID:            model.ID,
+		Type:          model.Type,
+		Outcome:       model.Outcome,
+		Enabled:       model.Enabled,
+		Count:         model.Count,
+		WindowMinutes: model.WindowMinutes,
+		Multiplier:    model.Multiplier,
+		MinAmount:     model.MinAmount,
+		CreatedAt:     model.CreatedAt,
+		UpdatedAt:     model.UpdatedAt,
+	}
+}
+
+// Operation is the debit evaluated.
+type Operation struct {
+	AccountID uuid.UUID
+	// CounterpartyID is the account credited by P2P debits.
+	CounterpartyID  uuid.NullUUID
+	TransactionType string
+	Amount          float64
+}
+
+// Hit is a rule that matched the operation.
+type Hit struct {
+	RuleID   uuid.UUID
+	RuleType RuleType
+	Outcome  Outcome
+	// Reason explains with the values of the account why the rule matched.
+	Reason string
+}
+
+// Evaluation is the outcome of the rules for an operation, recorded for analysts whatever its outcome.
+type Evaluation struct {
+	ID              uuid.UUID
+	AccountID       uuid.UUID
+	CounterpartyID  uuid.NullUUID
+	TransactionType string
+	Amount          float64
+	Outcome         Outcome
+	Hits            []Hit
+	CreatedAt       time.Time
+}
+
+func newEvaluation(model evaluationModel, hits []hitModel) Evaluation {
+	evaluation := Evaluation{
+		ID:              model.ID,
+		AccountID:       model.AccountID,
+		CounterpartyID:  model.CounterpartyID,
+		TransactionType: model.TransactionType,
+		Amount:          model.Amount,
+		Outcome:         model.Outcome,
+		Hits:            make([]Hit, 0, len(hits)),
+		CreatedAt:       model.CreatedAt,
+	}
+	for _, hit := range hits {
+		evaluation.Hits = append(evaluation.Hits, Hit{
+			RuleID:   hit.RuleID,
+			RuleType: hit.RuleType,
+			Outcome:  hit.Outcome,
+			Reason:   hit.Reason,
+		})
+	}
+	return evaluation
+}
+
+// EvaluationFilter lists the evaluations of an account, with an outcome or among the IDs, newest first.
+type EvaluationFilter struct {
+	IDs       []uuid.UUID
+	AccountID uuid.NullUUID
+	Outcome   Outcome
+	Page      int
+	Size      int
+}
@@ -0,0 +1,344 @@
+package risk
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrRuleNotFound       = errors.New("no risk rule found with this id")
+	ErrInvalidRuleType    = errors.New("the risk rule type is not valid")
+	ErrInvalidRuleOutcome = errors.New("the risk rule outcome must be REVIEW or DENY")
+	ErrInvalidRule        = errors.New("the risk rule parameters are not valid for its type")
+)
+
+type Service interface {
+	CreateRule(ctx context.Context, rule Rule) (Rule, error)
+	UpdateRule(ctx context.Context, rule Rule) (Rule, error)
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+	ListRules(ctx context.Context) ([]Rule, error)
+	Evaluate(ctx context.Context, operation Operation) (Evaluation, error)
+	ListEvaluations(ctx context.Context, filter EvaluationFilter) (int, []Evaluation, error)
+}
+
+type service struct {
+	tracer     tracer.Tracer
+	repository Repository
+}
+
+func NewService(t tracer.Tracer, r Repository) Service {
+	return service{
+		tracer:     t,
+		repository: r,
+	}
+}
+
+func (s service) CreateRule(ctx context.Context, rule Rule) (Rule, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if err := validate(rule); err != nil {
+		span.RecordError(err)
+		return Rule{}, err
+	}
+
+	model, err := s.repository.CreateRule(ctx, newRuleModel(rule))
+	if err != nil {
+		zapctx.L(ctx).Error("risk_service_create_rule_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return Rule{}, err
+	}
+
+	zapctx.L(ctx).Info(
+		"risk_rule_created",
+		zap.String("rule_id", model.ID.String()),
+		zap.String("type", string(model.Type)),
+		zap.String("outcome", string(model.Outcome)),
+		zap.Bool("enabled", model.Enabled),
+	)
+
+	return newRule(model), nil
+}
+
+func (s service) UpdateRule(ctx context.Context, rule Rule) (Rule, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if err := validate(rule); err != nil {
+		span.RecordError(err)
+		return Rule{}, err
+	}
+
+	model, err := s.repository.UpdateRule(ctx, newRuleModel(rule))
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return Rule{}, ErrRuleNotFound
+		}
+		zapctx.L(ctx).Error("risk_service_update_rule_repository_error", zap.Error(err))
+		return Rule{}, err
+	}
+
+	zapctx.L(ctx).Info(
+		"risk_rule_updated",
+		zap.String("rule_id", model.ID.String()),
+		zap.String("outcome", string(model.Outcome)),
+		zap.Bool("enabled", model.Enabled),
+	)
+
+	return newRule(model), nil
+}
+
+func (s service) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	deleted, err := s.repository.DeleteRule(ctx, id)
+	if err != nil {
+		zapctx.L(ctx).Error(
+			"risk_service_delete_rule_repository_error",
+			zap.String("rule_id", id.String()),
+			zap.Error(err),
+		)
+		span.RecordError(err)
+		return err
+	}
+
+	if !deleted {
+		span.RecordError(ErrRuleNotFound)
+		return ErrRuleNotFound
+	}
+
+	zapctx.L(ctx).Info("risk_rule_deleted", zap.String("rule_id", id.String()))
+
+	return nil
+}
+
+func (s service) ListRules(ctx context.Context) ([]Rule, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	models, err := s.repository.ListRules(ctx, false)
+	if err != nil {
+		zapctx.L(ctx).Error("risk_service_list_rules_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	rules := make([]Rule, len(models))
+	for i, model := range models {
+		rules[i] = newRule(model)
+	}
+
+	return rules, nil
+}
+
+func validate(rule Rule) error {
+	if !rule.Type.valid() {
+		return ErrInvalidRuleType
+	}
+
+	if rule.Outcome != ReviewOutcome && rule.Outcome != DenyOutcome {
+		return ErrInvalidRuleOutcome
+	}
+
+	if rule.MinAmount < 0 || rule.Count < 0 || rule.WindowMinutes < 0 || rule.Multiplier < 0 {
+		return ErrInvalidRule
+	}
+
+	var valid bool
+	switch rule.Type {
+	case VelocityRule:
+		valid = rule.Count > 0 && rule.WindowMinutes > 0
+	case AmountAboveAverageRule:
+		valid = rule.Count > 0 && rule.WindowMinutes > 0 && rule.Multiplier > 1
+	case NewCounterpartyRule:
+		valid = rule.MinAmount > 0
+	case AfterUnblockRule:
+		valid = rule.WindowMinutes > 0
+	}
+
+	if !valid {
+		return ErrInvalidRule
+	}
+
+	return nil
+}
+
+func (t RuleType) valid() bool {
+	for _, ruleType := range RuleTypes {
+		if t == ruleType {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate checks the operation against the enabled rules. The outcome is the most severe of the
+// rules hit, ALLOW when none is. Every evaluation is recorded, so analysts see the debits allowed
+// too.
+func (s service) Evaluate(ctx context.Context, operation Operation) (Evaluation, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	models, err := s.repository.ListRules(ctx, true)
+	if err != nil {
+		zapctx.L(ctx).Error("risk_service_evaluate_list_rules_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return Evaluation{}, err
+	}
+
+	evaluation := Evaluation{
+		ID:              uuid.New(),
+		AccountID:       operation.AccountID,
+		CounterpartyID:  operation.CounterpartyID,
+		TransactionType: operation.TransactionType,
+		Amount:          operation.Amount,
+		Outcome:         AllowOutcome,
+		Hits:            []Hit{},
+		CreatedAt:       time.Now().UTC(),
+	}
+
+	for _, model := range models {
+		rule := newRule(model)
+
+		reason, hit, err := s.check(ctx, rule, operation, evaluation.CreatedAt)
+		if err != nil {
+			zapctx.L(ctx).Error(
+				"risk_service_evaluate_rule_error",
+				zap.String("rule_id", rule.ID.String()),
+				zap.Error(err),
+			)
+			span.RecordError(err)
+			return Evaluation{}, err
+		}
+
+		if !hit {
+			continue
+		}
+
+		evaluation.Hits = append(evaluation.Hits, Hit{
+			RuleID:   rule.ID,
+			RuleType: rule.Type,
+			Outcome:  rule.Outcome,
+			Reason:   reason,
+		})
+		if rule.Outcome.severity() > evaluation.Outcome.severity() {
+			evaluation.Outcome = rule.Outcome
+		}
+	}
+
+	model, hits := newEvaluationModels(evaluation)
+	if err := s.repository.CreateEvaluation(ctx, model, hits); err != nil {
+		zapctx.L(ctx).Error("risk_service_create_evaluation_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return Evaluation{}, err
+	}
+
+	ruleTypes := make([]string, len(evaluation.Hits))
+	for i, hit := range evaluation.Hits {
+		ruleTypes[i] = string(hit.RuleType)
+	}
+
+	zapctx.L(ctx).Info(
+		"risk_evaluated",
+		zap.String("evaluation_id", evaluation.ID.String()),
+		zap.String("account_id", evaluation.AccountID.String()),
+		zap.String("transaction_type", evaluation.TransactionType),
+		zap.Float64("amount", evaluation.Amount),
+		zap.String("outcome", string(evaluation.Outcome)),
+		zap.Strings("hits", ruleTypes),
+	)
+
+	return evaluation, nil
+}
+
+// check tells whether the rule matches the operation and why.
+func (s service) check(ctx context.Context, rule Rule, operation Operation, now time.Time) (string, bool, error) {
+	switch rule.Type {
+	case VelocityRule:
+		count, err := s.repository.CountDebitsSince(ctx, operation.AccountID, now.Add(-rule.window()))
+		if err != nil {
+			return "", false, err
+		}
+
+		reason := fmt.Sprintf("%d debits in the last %d minutes", count, rule.WindowMinutes)
+		return reason, count >= rule.Count, nil
+	case AmountAboveAverageRule:
+		if operation.Amount < rule.MinAmount {
+			return "", false, nil
+		}
+
+		since := now.Add(-rule.window())
+		count, err := s.repository.CountDebitsSince(ctx, operation.AccountID, since)
+		if err != nil || count < rule.Count {
+			return "", false, err
+		}
+
+		average, err := s.repository.AverageDebitSince(ctx, operation.AccountID, since)
+		if err != nil {
+			return "", false, err
+		}
+
+		reason := fmt.Sprintf("amount %.2f over %.2f times the average of %.2f", operation.Amount, rule.Multiplier, average)
+		return reason, operation.Amount > average*rule.Multiplier, nil
+	case NewCounterpartyRule:
+		if !operation.CounterpartyID.Valid || operation.Amount <= rule.MinAmount {
+			return "", false, nil
+		}
+
+		paid, err := s.repository.HasPaid(ctx, operation.AccountID, operation.CounterpartyID.UUID)
+		if err != nil {
+			return "", false, err
+		}
+
+		reason := fmt.Sprintf("first payment to account %s above %.2f", operation.CounterpartyID.UUID, rule.MinAmount)
+		return reason, !paid, nil
+	case AfterUnblockRule:
+		if operation.Amount < rule.MinAmount {
+			return "", false, nil
+		}
+
+		unblockedAt, err := s.repository.LastUnblockedAt(ctx, operation.AccountID)
+		if err != nil || unblockedAt.IsZero() {
+			return "", false, err
+		}
+
+		reason := fmt.Sprintf("account unblocked at %s", unblockedAt.UTC().Format(time.RFC3339))
+		return reason, now.Sub(unblockedAt) < rule.window(), nil
+	}
+
+	return "", false, nil
+}
+
+func (s service) ListEvaluations(ctx context.Context, filter EvaluationFilter) (int, []Evaluation, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	total, models, hits, err := s.repository.ListEvaluations(ctx, filter)
+	if err != nil {
+		zapctx.L(ctx).Error("risk_service_list_evaluations_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return 0, nil, err
+	}
+
+	byEvaluation := make(map[uuid.UUID][]hitModel)
+	for _, hit := range hits {
+		byEvaluation[hit.EvaluationID] = append(byEvaluation[hit.EvaluationID], hit)
+	}
+
+	evaluations := make([]Evaluation, len(models))
+	for i, model := range models {
+		evaluations[i] = newEvaluation(model, byEvaluation[model.ID])
+	}
+
+	return total, evaluations, nil
+}
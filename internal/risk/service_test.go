@@ -0,0 +1,209 @@
+//go:build unit
+
+package risk
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_Rules(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	svc := NewService(tracer.NewNoop(), repoMock)
+
+	t.Run("fail create, invalid rules", func(t *testing.T) {
+		for _, tt := range []struct {
+			rule    Rule
+			wantErr error
+		}{
+			{Rule{Type: "GEOLOCATION", Outcome: DenyOutcome}, ErrInvalidRuleType},
+			{Rule{Type: VelocityRule, Outcome: AllowOutcome, Count: 5, WindowMinutes: 10}, ErrInvalidRuleOutcome},
+			{Rule{Type: VelocityRule, Outcome: DenyOutcome, WindowMinutes: 10}, ErrInvalidRule},
+			{Rule{Type: AmountAboveAverageRule, Outcome: ReviewOutcome, Count: 3, WindowMinutes: 60, Multiplier: 1}, ErrInvalidRule},
+			{Rule{Type: NewCounterpartyRule, Outcome: ReviewOutcome}, ErrInvalidRule},
+			{Rule{Type: AfterUnblockRule, Outcome: ReviewOutcome, MinAmount: -1, WindowMinutes: 60}, ErrInvalidRule},
+		} {
+			created, err := svc.CreateRule(ctx, tt.rule)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Empty(t, created)
+		}
+	})
+
+	t.Run("success create", func(t *testing.T) {
+		rule := Rule{Type: VelocityRule, Outcome: DenyOutcome, Enabled: true, Count: 5, WindowMinutes: 10}
+
+		repoMock.EXPECT().
+			CreateRule(ctx, newRuleModel(rule)).
+			DoAndReturn(func(_ context.Context, m ruleModel) (ruleModel, error) {
+				m.ID = uuid.New()
+				return m, nil
+			})
+
+		created, err := svc.CreateRule(ctx, rule)
+		assert.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, created.ID)
+		assert.Equal(t, VelocityRule, created.Type)
+	})
+
+	t.Run("fail update, rule not found", func(t *testing.T) {
+		repoMock.EXPECT().
+			UpdateRule(ctx, gomock.Any()).
+			Return(ruleModel{}, sql.ErrNoRows)
+
+		updated, err := svc.UpdateRule(ctx, Rule{ID: uuid.New(), Type: NewCounterpartyRule, Outcome: ReviewOutcome, MinAmount: 500})
+		assert.ErrorIs(t, err, ErrRuleNotFound)
+		assert.Empty(t, updated)
+	})
+
+	t.Run("fail delete, rule not found", func(t *testing.T) {
+		id := uuid.New()
+		repoMock.EXPECT().
+			DeleteRule(ctx, id).
+			Return(false, nil)
+
+		assert.ErrorIs(t, svc.DeleteRule(ctx, id), ErrRuleNotFound)
+	})
+}
+
+func TestService_Evaluate(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	svc := NewService(tracer.NewNoop(), repoMock)
+
+	accountID := uuid.New()
+	counterpartyID := uuid.New()
+	velocity := ruleModel{ID: uuid.New(), Type: VelocityRule, Outcome: DenyOutcome, Count: 5, WindowMinutes: 10}
+	average := ruleModel{
+		ID:            uuid.New(),
+		Type:          AmountAboveAverageRule,
+		Outcome:       ReviewOutcome,
+		Count:         3,
+		WindowMinutes: 43200,
+		Multiplier:    5,
+		MinAmount:     100,
+	}
+	counterparty := ruleModel{ID: uuid.New(), Type: NewCounterpartyRule, Outcome: ReviewOutcome, MinAmount: 1000}
+	unblock := ruleModel{ID: uuid.New(), Type: AfterUnblockRule, Outcome: ReviewOutcome, WindowMinutes: 60}
+
+	operation := Operation{
+		AccountID:       accountID,
+		CounterpartyID:  uuid.NullUUID{UUID: counterpartyID, Valid: true},
+		TransactionType: "P2P",
+		Amount:          1500,
+	}
+
+	t.Run("allow, no rule hit", func(t *testing.T) {
+		repoMock.EXPECT().ListRules(ctx, true).Return([]ruleModel{velocity, average, counterparty, unblock}, nil)
+		repoMock.EXPECT().CountDebitsSince(ctx, accountID, gomock.Any()).Return(1, nil)
+		repoMock.EXPECT().CountDebitsSince(ctx, accountID, gomock.Any()).Return(10, nil)
+		repoMock.EXPECT().AverageDebitSince(ctx, accountID, gomock.Any()).Return(1000.0, nil)
+		repoMock.EXPECT().HasPaid(ctx, accountID, counterpartyID).Return(true, nil)
+		repoMock.EXPECT().LastUnblockedAt(ctx, accountID).Return(time.Now().Add(-2*time.Hour), nil)
+		repoMock.EXPECT().
+			CreateEvaluation(ctx, gomock.Any(), gomock.Len(0)).
+			DoAndReturn(func(_ context.Context, m evaluationModel, _ []hitModel) error {
+				assert.Equal(t, AllowOutcome, m.Outcome)
+				assert.Equal(t, operation.CounterpartyID, m.CounterpartyID)
+				return nil
+			})
+
+		evaluation, err := svc.Evaluate(ctx, operation)
+		assert.NoError(t, err)
+		assert.Equal(t, AllowOutcome, evaluation.Outcome)
+		assert.Empty(t, evaluation.Hits)
+	})
+
+	t.Run("review, amount above average, new counterparty and right after unblock", func(t *testing.T) {
+		repoMock.EXPECT().ListRules(ctx, true).Return([]ruleModel{average, counterparty, unblock}, nil)
+		repoMock.EXPECT().CountDebitsSince(ctx, accountID, gomock.Any()).Return(10, nil)
+		repoMock.EXPECT().AverageDebitSince(ctx, accountID, gomock.Any()).Return(100.0, nil)
+		repoMock.EXPECT().HasPaid(ctx, accountID, counterpartyID).Return(false, nil)
+		repoMock.EXPECT().LastUnblockedAt(ctx, accountID).Return(time.Now().Add(-10*time.Minute), nil)
+		repoMock.EXPECT().
+			CreateEvaluation(ctx, gomock.Any(), gomock.Len(3)).
+			Return(nil)
+
+		evaluation, err := svc.Evaluate(ctx, operation)
+		assert.NoError(t, err)
+		assert.Equal(t, ReviewOutcome, evaluation.Outcome)
+		assert.Len(t, evaluation.Hits, 3)
+		assert.Equal(t, AmountAboveAverageRule, evaluation.Hits[0].RuleType)
+		assert.Equal(t, "amount 1500.00 over 5.00 times the average of 100.00", evaluation.Hits[0].Reason)
+		assert.Equal(t, counterparty.ID, evaluation.Hits[1].RuleID)
+		assert.Equal(t, AfterUnblockRule, evaluation.Hits[2].RuleType)
+	})
+
+	t.Run("deny, the most severe rule hit", func(t *testing.T) {
+		repoMock.EXPECT().ListRules(ctx, true).Return([]ruleModel{counterparty, velocity}, nil)
+		repoMock.EXPECT().HasPaid(ctx, accountID, counterpartyID).Return(false, nil)
+		repoMock.EXPECT().CountDebitsSince(ctx, accountID, gomock.Any()).Return(5, nil)
+		repoMock.EXPECT().CreateEvaluation(ctx, gomock.Any(), gomock.Len(2)).Return(nil)
+
+		evaluation, err := svc.Evaluate(ctx, operation)
+		assert.NoError(t, err)
+		assert.Equal(t, DenyOutcome, evaluation.Outcome)
+		assert.Equal(t, "5 debits in the last 10 minutes", evaluation.Hits[1].Reason)
+	})
+
+	t.Run("allow, rules not applicable to the operation", func(t *testing.T) {
+		debit := Operation{AccountID: accountID, TransactionType: "DEBIT", Amount: 50}
+
+		repoMock.EXPECT().ListRules(ctx, true).Return([]ruleModel{average, counterparty}, nil)
+		repoMock.EXPECT().CreateEvaluation(ctx, gomock.Any(), gomock.Len(0)).Return(nil)
+
+		evaluation, err := svc.Evaluate(ctx, debit)
+		assert.NoError(t, err)
+		assert.Equal(t, AllowOutcome, evaluation.Outcome)
+	})
+
+	t.Run("fail evaluate, evaluation not recorded", func(t *testing.T) {
+		repoMock.EXPECT().ListRules(ctx, true).Return(nil, nil)
+		repoMock.EXPECT().CreateEvaluation(ctx, gomock.Any(), gomock.Any()).Return(sql.ErrConnDone)
+
+		evaluation, err := svc.Evaluate(ctx, operation)
+		assert.ErrorIs(t, err, sql.ErrConnDone)
+		assert.Empty(t, evaluation)
+	})
+}
+
+func TestService_ListEvaluations(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	svc := NewService(tracer.NewNoop(), repoMock)
+
+	filter := EvaluationFilter{Outcome: ReviewOutcome, Page: 1, Size: 10}
+	first := evaluationModel{ID: uuid.New(), Outcome: ReviewOutcome}
+	second := evaluationModel{ID: uuid.New(), Outcome: ReviewOutcome}
+
+	repoMock.EXPECT().
+		ListEvaluations(ctx, filter).
+		Return(2, []evaluationModel{first, second}, []hitModel{
+			{EvaluationID: second.ID, RuleType: VelocityRule},
+			{EvaluationID: first.ID, RuleType: NewCounterpartyRule},
+			{EvaluationID: second.ID, RuleType: AfterUnblockRule},
+		}, nil)
+
+	total, evaluations, err := svc.ListEvaluations(ctx, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Len(t, evaluations[0].Hits, 1)
+	assert.Equal(t, NewCounterpartyRule, evaluations[0].Hits[0].RuleType)
+	assert.Len(t, evaluations[1].Hits, 2)
+}
@@ -0,0 +1,276 @@
+package statements
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	camt053Namespace = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.08"
+
+	creditIndicator = "CRDT"
+	debitIndicator  = "DBIT"
+	// openingBalance and closingBalance are the booked balances at the start and end of the period.
+	openingBalance = "OPBD"
+	closingBalance = "CLBD"
+	bookedStatus   = "BOOK"
+
+	isoDateLayout     = "2006-01-02"
+	isoDateTimeLayout = "2006-01-02T15:04:05Z"
+)
+
+type (
+	camt053Document struct {
+		XMLName xml.Name         `xml:"Document"`
+		Xmlns   string           `xml:"xmlns,attr"`
+		Report  camt053BkToCstmr `xml:"BkToCstmrStmt"`
+	}
+
+	camt053BkToCstmr struct {
+		GroupHeader camt053GroupHeader `xml:"GrpHdr"`
+		Statement   camt053Statement   `xml:"Stmt"`
+	}
+
+	camt053GroupHeader struct {
+		MessageID string `xml:"MsgId"`
+		CreatedAt string `xml:"CreDtTm"`
+	}
+
+	camt053Statement struct {
+		ID        string           `xml:"Id"`
+		CreatedAt string           `xml:"CreDtTm"`
+		Period    camt053Period    `xml:"FrToDt"`
+		Account   camt053Account   `xml:"Acct"`
+		Balances  []camt053Balance `xml:"Bal"`
+		Summary   camt053Summary   `xml:"TxsSummry"`
+		Entries   []camt053Entry   `xml:"Ntry"`
+	}
+
+	camt053Period struct {
+		From string `xml:"FrDtTm"`
+		To   string `xml:"ToDtTm"`
+	}
+
+	camt053Account struct {
+		ID       camt053OtherID `xml:"Id"`
+		Currency string         `xml:"Ccy"`
+		Name     string         `xml:"Nm,omitempty"`
+		Owner    *camt053Party  `xml:"Ownr,omitempty"`
+		Servicer *camt053Agent  `xml:"Svcr,omitempty"`
+	}
+
+	camt053OtherID struct {
+		ID string `xml:"Othr>Id"`
+	}
+
+	camt053Party struct {
+		Name string `xml:"Nm,omitempty"`
+		ID   string `xml:"Id>PrvtId>Othr>Id,omitempty"`
+	}
+
+	camt053Agent struct {
+		BranchID string `xml:"BrnchId>Id"`
+	}
+
+	camt053Amount struct {
+		Currency string `xml:"Ccy,attr"`
+		Value    string `xml:",chardata"`
+	}
+
+	camt053Balance struct {
+		Type      string        `xml:"Tp>CdOrPrtry>Cd"`
+		Amount    camt053Amount `xml:"Amt"`
+		Indicator string        `xml:"CdtDbtInd"`
+		Date      string        `xml:"Dt>Dt"`
+	}
+
+	camt053Summary struct {
+		Total   camt053SummaryTotal `xml:"TtlNtries"`
+		Credits camt053SummarySide  `xml:"TtlCdtNtries"`
+		Debits  camt053SummarySide  `xml:"TtlDbtNtries"`
+	}
+
+	camt053SummaryTotal struct {
+		Count     int    `xml:"NbOfNtries"`
+		Sum       string `xml:"Sum"`
+		NetAmount string `xml:"TtlNetNtry>Amt"`
+		Indicator string `xml:"TtlNetNtry>CdtDbtInd"`
+	}
+
+	camt053SummarySide struct {
+		Count int    `xml:"NbOfNtries"`
+		Sum   string `xml:"Sum"`
+	}
+
+	camt053Entry struct {
+		Reference   string             `xml:"NtryRef"`
+		Amount      camt053Amount      `xml:"Amt"`
+		Indicator   string             `xml:"CdtDbtInd"`
+		Status      string             `xml:"Sts>Cd"`
+		BookingDate string             `xml:"BookgDt>DtTm"`
+		ValueDate   string             `xml:"ValDt>Dt"`
+		ServicerRef string             `xml:"AcctSvcrRef"`
+		Code        string             `xml:"BkTxCd>Prtry>Cd"`
+		Details     camt053EntryDetail `xml:"NtryDtls>TxDtls"`
+	}
+
+	camt053EntryDetail struct {
+		TransactionID string             `xml:"Refs>TxId"`
+		Parties       *camt053Parties    `xml:"RltdPties,omitempty"`
+		Remittance    string             `xml:"RmtInf>Ustrd,omitempty"`
+		AmountDetails *camt053AmountDtls `xml:"AmtDtls,omitempty"`
+	}
+
+	camt053Parties struct {
+		Debtor          *camt053PartyName `xml:"Dbtr,omitempty"`
+		DebtorAccount   *camt053OtherID   `xml:"DbtrAcct>Id,omitempty"`
+		Creditor        *camt053PartyName `xml:"Cdtr,omitempty"`
+		CreditorAccount *camt053OtherID   `xml:"CdtrAcct>Id,omitempty"`
+	}
+
+	camt053PartyName struct {
+		Name string `xml:"Pty>Nm"`
+	}
+
+	camt053AmountDtls struct {
+		Instructed camt053Amount `xml:"InstdAmt>Amt"`
+		Rate       string        `xml:"InstdAmt>CcyXchg>XchgRate"`
+	}
+)
+
+// encodeCamt053 writes the report as an ISO 20022 camt.053 bank to customer statement, with the
+// booked balances of the period and an entry per transaction, credited or debited from the
+// account.
+func encodeCamt053(report Report) ([]byte, error) {
+	account := report.Account
+	messageID := strings.ToUpper(strings.ReplaceAll(report.ID.String(), "-", ""))
+	createdAt := report.CreatedAt.UTC().Format(isoDateTimeLayout)
+
+	statement := camt053Statement{
+		ID:        messageID,
+		CreatedAt: createdAt,
+		Period: camt053Period{
+			From: report.From.UTC().Format(isoDateTimeLayout),
+			To:   report.To.UTC().Add(-time.Second).Format(isoDateTimeLayout),
+		},
+		Account: camt053Account{
+			ID:       camt053OtherID{ID: account.Number},
+			Currency: string(account.Currency),
+			Name:     account.Name,
+			Owner:    &camt053Party{Name: account.Name, ID: account.DocumentNumber},
+			Servicer: &camt053Agent{BranchID: account.Agency},
+		},
+		Balances: []camt053Balance{
+			balance(openingBalance, report.OpeningBalance, string(account.Currency), report.From),
+			balance(closingBalance, report.ClosingBalance, string(account.Currency), report.To.Add(-time.Second)),
+		},
+		Entries: make([]camt053Entry, 0, len(report.Entries)),
+	}
+
+	var credits, debits float64
+	for _, stmt := range report.Entries {
+		amount, currency := stmt.Amount, stmt.Currency
+		side := debitIndicator
+		if stmt.ToAccount.ID == account.ID {
+			side = creditIndicator
+			if stmt.ToCurrency != "" {
+				amount, currency = stmt.ToAmount, stmt.ToCurrency
+			}
+			credits += amount
+			statement.Summary.Credits.Count++
+		} else {
+			debits += amount
+			statement.Summary.Debits.Count++
+		}
+
+		entry := camt053Entry{
+			Reference:   stmt.ID.String(),
+			Amount:      camt053Amount{Currency: string(currency), Value: decimal(amount)},
+			Indicator:   side,
+			Status:      bookedStatus,
+			BookingDate: stmt.CreatedAt.UTC().Format(isoDateTimeLayout),
+			ValueDate:   stmt.CreatedAt.UTC().Format(isoDateLayout),
+			ServicerRef: stmt.ID.String(),
+			Code:        stmt.Type,
+			Details: camt053EntryDetail{
+				TransactionID: stmt.ID.String(),
+				Parties:       parties(stmt),
+				Remittance:    stmt.Description,
+			},
+		}
+		if stmt.ToCurrency != "" && stmt.ToCurrency != stmt.Currency {
+			entry.Details.AmountDetails = &camt053AmountDtls{
+				Instructed: camt053Amount{Currency: string(stmt.Currency), Value: decimal(stmt.Amount)},
+				Rate:       fmt.Sprint(stmt.FxRate),
+			}
+		}
+		statement.Entries = append(statement.Entries, entry)
+	}
+
+	net := credits - debits
+	statement.Summary.Total = camt053SummaryTotal{
+		Count:     len(report.Entries),
+		Sum:       decimal(credits + debits),
+		NetAmount: decimal(math.Abs(net)),
+		Indicator: indicator(net),
+	}
+	statement.Summary.Credits.Sum = decimal(credits)
+	statement.Summary.Debits.Sum = decimal(debits)
+
+	body, err := xml.MarshalIndent(camt053Document{
+		Xmlns: camt053Namespace,
+		Report: camt053BkToCstmr{
+			GroupHeader: camt053GroupHeader{MessageID: messageID, CreatedAt: createdAt},
+			Statement:   statement,
+		},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
+
+func balance(kind string, amount float64, currency string, on time.Time) camt053Balance {
+	return camt053Balance{
+		Type:      kind,
+		Amount:    camt053Amount{Currency: currency, Value: decimal(math.Abs(amount))},
+		Indicator: indicator(amount),
+		Date:      on.UTC().Format(isoDateLayout),
+	}
+}
+
+func parties(stmt Statement) *camt053Parties {
+	var p camt053Parties
+	if stmt.FromAccount.ID != uuid.Nil {
+		p.Debtor = &camt053PartyName{Name: stmt.FromAccount.Name}
+		p.DebtorAccount = &camt053OtherID{ID: stmt.FromAccount.ID.String()}
+	}
+	if stmt.ToAccount.ID != uuid.Nil {
+		p.Creditor = &camt053PartyName{Name: stmt.ToAccount.Name}
+		p.CreditorAccount = &camt053OtherID{ID: stmt.ToAccount.ID.String()}
+	}
+
+	if p.Debtor == nil && p.Creditor == nil {
+		return nil
+	}
+
+	return &p
+}
+
+// indicator is CRDT for positive amounts, when the balance is in favor of the account holder.
+func indicator(amount float64) string {
+	if amount < 0 {
+		return debitIndicator
+	}
+	return creditIndicator
+}
+
+func decimal(amount float64) string {
+	return fmt.Sprintf("%.2f", amount)
+}
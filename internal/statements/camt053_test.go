@@ -0,0 +1,97 @@
+//go:build unit
+
+package statements
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeCamt053(t *testing.T) {
+	account := accounts.Account{
+		ID:             uuid.New(),
+		Name:           "Maria Silva",
+		DocumentNumber: "52998224725",
+		Agency:         "0001",
+		Number:         "123456-7",
+		Currency:       "BRL",
+	}
+	other := accounts.Account{ID: uuid.New(), Name: "John Smith"}
+	from := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	credit := Statement{
+		ID:          uuid.New(),
+		FromAccount: other,
+		ToAccount:   account,
+		Type:        "P2P",
+		Amount:      20,
+		Currency:    "USD",
+		ToAmount:    100,
+		ToCurrency:  "BRL",
+		FxRate:      5,
+		Description: "dinner",
+		CreatedAt:   time.Date(2025, time.March, 5, 12, 30, 0, 0, time.UTC),
+	}
+	debit := Statement{
+		ID:          uuid.New(),
+		FromAccount: account,
+		Type:        "DEBIT",
+		Amount:      250.5,
+		Currency:    "BRL",
+		CreatedAt:   time.Date(2025, time.March, 20, 9, 0, 0, 0, time.UTC),
+	}
+
+	body, err := encodeCamt053(Report{
+		ID:             uuid.MustParse("0a1b2c3d-4e5f-6071-8293-a4b5c6d7e8f9"),
+		Account:        account,
+		From:           from,
+		To:             to,
+		OpeningBalance: 200,
+		ClosingBalance: 49.5,
+		Entries:        []Statement{credit, debit},
+		CreatedAt:      to,
+	})
+	assert.NoError(t, err)
+
+	var document camt053Document
+	assert.NoError(t, xml.Unmarshal(body, &document))
+	assert.Equal(t, camt053Namespace, document.Xmlns)
+
+	statement := document.Report.Statement
+	assert.Equal(t, "0A1B2C3D4E5F60718293A4B5C6D7E8F9", document.Report.GroupHeader.MessageID)
+	assert.Equal(t, "2025-03-01T00:00:00Z", statement.Period.From)
+	assert.Equal(t, "2025-03-31T23:59:59Z", statement.Period.To)
+	assert.Equal(t, "123456-7", statement.Account.ID.ID)
+	assert.Equal(t, "0001", statement.Account.Servicer.BranchID)
+	assert.Equal(t, "52998224725", statement.Account.Owner.ID)
+
+	assert.Equal(t, []camt053Balance{
+		{Type: "OPBD", Amount: camt053Amount{Currency: "BRL", Value: "200.00"}, Indicator: "CRDT", Date: "2025-03-01"},
+		{Type: "CLBD", Amount: camt053Amount{Currency: "BRL", Value: "49.50"}, Indicator: "CRDT", Date: "2025-03-31"},
+	}, statement.Balances)
+
+	assert.Equal(t, 2, statement.Summary.Total.Count)
+	assert.Equal(t, "350.50", statement.Summary.Total.Sum)
+	assert.Equal(t, "150.50", statement.Summary.Total.NetAmount)
+	assert.Equal(t, "DBIT", statement.Summary.Total.Indicator)
+	assert.Equal(t, 1, statement.Summary.Credits.Count)
+	assert.Equal(t, "100.00", statement.Summary.Credits.Sum)
+
+	assert.Len(t, statement.Entries, 2)
+	assert.Equal(t, "CRDT", statement.Entries[0].Indicator)
+	assert.Equal(t, camt053Amount{Currency: "BRL", Value: "100.00"}, statement.Entries[0].Amount)
+	assert.Equal(t, "2025-03-05T12:30:00Z", statement.Entries[0].BookingDate)
+	assert.Equal(t, "John Smith", statement.Entries[0].Details.Parties.Debtor.Name)
+	assert.Equal(t, "dinner", statement.Entries[0].Details.Remittance)
+	assert.Equal(t, "20.00", statement.Entries[0].Details.AmountDetails.Instructed.Value)
+	assert.Equal(t, "DBIT", statement.Entries[1].Indicator)
+	assert.Equal(t, "250.50", statement.Entries[1].Amount.Value)
+	assert.Equal(t, "DEBIT", statement.Entries[1].Code)
+	assert.Nil(t, statement.Entries[1].Details.Parties.Creditor)
+}
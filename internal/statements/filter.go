@@ -6,6 +6,13 @@ import (
 	"github.com/google/uuid"
 )
 
+type Direction string
+
+const (
+	InDirection  Direction = "in"
+	OutDirection Direction = "out"
+)
+
 type ListFilter struct {
 	Sort           int
 	Page           int
@@ -13,4 +20,8 @@ type ListFilter struct {
 	AccountID      uuid.UUID
 	CreatedAtBegin time.Time
 	CreatedAtEnd   time.Time
+	Type           string
+	Direction      Direction
+	AmountMin      float64
+	AmountMax      float64
 }
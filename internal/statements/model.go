@@ -3,6 +3,7 @@ package statements
 import (
 	"time"
 
+	"github.com/dalmarcogd/dock-test/internal/exchange"
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 )
@@ -11,15 +12,20 @@ type (
 	statementModel struct {
 		bun.BaseModel `bun:"table:transactions,alias:trx"`
 
-		ID              uuid.UUID `bun:"id,pk"`
-		FromAccountID   uuid.UUID `bun:"from_account_id"`
-		FromAccountName string    `bun:"from_account_name"`
-		ToAccountID     uuid.UUID `bun:"to_account_id"`
-		ToAccountName   string    `bun:"to_account_name"`
-		Type            string    `bun:"type"`
-		Amount          float64   `bun:"amount"`
-		Description     string    `bun:"description"`
-		CreatedAt       time.Time `bun:"created_at"`
+		ID                   uuid.UUID         `bun:"id,pk"`
+		RelatedTransactionID uuid.NullUUID     `bun:"related_transaction_id"`
+		FromAccountID        uuid.UUID         `bun:"from_account_id"`
+		FromAccountName      string            `bun:"from_account_name"`
+		ToAccountID          uuid.UUID         `bun:"to_account_id"`
+		ToAccountName        string            `bun:"to_account_name"`
+		Type                 string            `bun:"type"`
+		Amount               float64           `bun:"amount"`
+		Currency             exchange.Currency `bun:"currency"`
+		ToAmount             float64           `bun:"to_amount"`
+		ToCurrency           exchange.Currency `bun:"to_currency"`
+		FxRate               float64           `bun:"fx_rate"`
+		Description          string            `bun:"description"`
+		CreatedAt            time.Time         `bun:"created_at"`
 	}
 
 	StatementFilter struct {
@@ -29,5 +35,17 @@ type (
 		AccountID      uuid.UUID
 		CreatedAtBegin time.Time
 		CreatedAtEnd   time.Time
+		Type           string
+		Direction      Direction
+		AmountMin      float64
+		AmountMax      float64
 	}
 )
+
+// credited is the amount credited to the to account, converted when the currencies differ.
+func (m statementModel) credited() float64 {
+	if m.ToCurrency != "" {
+		return m.ToAmount
+	}
+	return m.Amount
+}
@@ -2,13 +2,17 @@ package statements
 
 import (
 	"context"
+	"time"
 
 	"github.com/dalmarcogd/dock-test/pkg/database"
 	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
 )
 
 type Repository interface {
 	ListByFilter(ctx context.Context, filter StatementFilter) (int, []statementModel, error)
+	ListByPeriod(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]statementModel, error)
+	GetBalanceAt(ctx context.Context, accountID uuid.UUID, at time.Time) (float64, error)
 }
 
 type repository struct {
@@ -67,6 +71,24 @@ func (r repository) ListByFilter(ctx context.Context, filter StatementFilter) (i
 		selectQuery.Where("trx.created_at <= ?", filter.CreatedAtEnd)
 	}
 
+	if filter.Type != "" {
+		selectQuery.Where("trx.type = ?", filter.Type)
+	}
+
+	switch filter.Direction {
+	case InDirection:
+		selectQuery.Where("trx.to_account_id = ?", filter.AccountID.String())
+	case OutDirection:
+		selectQuery.Where("trx.from_account_id = ?", filter.AccountID.String())
+	}
+
+	if filter.AmountMin > 0 {
+		selectQuery.Where("trx.amount >= ?", filter.AmountMin)
+	}
+	if filter.AmountMax > 0 {
+		selectQuery.Where("trx.amount <= ?", filter.AmountMax)
+	}
+
 	var stms []statementModel
 	total, err := selectQuery.ScanAndCount(ctx, &stms)
 	if err != nil {
@@ -76,3 +98,69 @@ func (r repository) ListByFilter(ctx context.Context, filter StatementFilter) (i
 
 	return total, stms, nil
 }
+
+// ListByPeriod returns every transaction of the account made from the start of the period and before
+// its end, in the order they were made.
+func (r repository) ListByPeriod(
+	ctx context.Context,
+	accountID uuid.UUID,
+	from, to time.Time,
+) ([]statementModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var stms []statementModel
+	err := r.db.Replica().
+		NewSelect().
+		Model(&stms).
+		ColumnExpr("trx.*").
+		ColumnExpr("from_acc.name AS from_account_name, to_acc.name AS to_account_name").
+		Where(
+			"(from_account_id = ? OR to_account_id = ?)",
+			accountID.String(),
+			accountID.String(),
+		).
+		Where("trx.created_at >= ?", from).
+		Where("trx.created_at < ?", to).
+		Join("LEFT JOIN accounts AS from_acc").
+		JoinOn("from_acc.id = from_account_id").
+		Join("LEFT JOIN accounts AS to_acc").
+		JoinOn("to_acc.id = to_account_id").
+		Order("trx.created_at ASC", "trx.id ASC").
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return []statementModel{}, err
+	}
+
+	return stms, nil
+}
+
+// GetBalanceAt sums the transactions of the account made before the given time, the same way the
+// transactions_balances view does.
+func (r repository) GetBalanceAt(ctx context.Context, accountID uuid.UUID, at time.Time) (float64, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var balance float64
+	err := r.db.Replica().
+		NewSelect().
+		Model((*statementModel)(nil)).
+		ColumnExpr(
+			"COALESCE(SUM(CASE WHEN trx.to_account_id = ? THEN COALESCE(trx.to_amount, trx.amount) ELSE -trx.amount END), 0)",
+			accountID.String(),
+		).
+		Where(
+			"(trx.from_account_id = ? OR trx.to_account_id = ?)",
+			accountID.String(),
+			accountID.String(),
+		).
+		Where("trx.created_at < ?", at).
+		Scan(ctx, &balance)
+	if err != nil {
+		span.RecordError(err)
+		return 0, err
+	}
+
+	return balance, nil
+}
@@ -2,24 +2,34 @@ package statements
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/dalmarcogd/dock-test/internal/accounts"
 	"github.com/dalmarcogd/dock-test/pkg/tracer"
 	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+// maxReportDays is the longest period of a statement report.
+const maxReportDays = 366
+
+var ErrInvalidReportPeriod = errors.New("the statement period must end after it starts and span at most 366 days")
+
 type Service interface {
 	List(ctx context.Context, filter ListFilter) (int, []Statement, error)
+	GetCamt053(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]byte, error)
 }
 
 type service struct {
-	tracer     tracer.Tracer
-	repository Repository
+	tracer      tracer.Tracer
+	repository  Repository
+	accountsSvc accounts.Service
 }
 
-func NewService(t tracer.Tracer, r Repository) Service {
-	return service{tracer: t, repository: r}
+func NewService(t tracer.Tracer, r Repository, as accounts.Service) Service {
+	return service{tracer: t, repository: r, accountsSvc: as}
 }
 
 func (s service) List(ctx context.Context, filter ListFilter) (int, []Statement, error) {
@@ -33,6 +43,10 @@ func (s service) List(ctx context.Context, filter ListFilter) (int, []Statement,
 		AccountID:      filter.AccountID,
 		CreatedAtBegin: filter.CreatedAtBegin,
 		CreatedAtEnd:   filter.CreatedAtEnd,
+		Type:           filter.Type,
+		Direction:      filter.Direction,
+		AmountMin:      filter.AmountMin,
+		AmountMax:      filter.AmountMax,
 	})
 	if err != nil {
 		zapctx.L(ctx).Error("statements_service_repository_error", zap.Error(err))
@@ -40,9 +54,74 @@ func (s service) List(ctx context.Context, filter ListFilter) (int, []Statement,
 		return 0, []Statement{}, err
 	}
 
+	return total, newStatements(statementModels), nil
+}
+
+// GetCamt053 writes the ISO 20022 camt.053 statement of the account from the start of the period and
+// before its end.
+func (s service) GetCamt053(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]byte, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if !to.After(from) || to.Sub(from) > maxReportDays*24*time.Hour {
+		span.RecordError(ErrInvalidReportPeriod)
+		return nil, ErrInvalidReportPeriod
+	}
+
+	account, err := s.accountsSvc.GetByID(ctx, accountID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	opening, err := s.repository.GetBalanceAt(ctx, accountID, from)
+	if err != nil {
+		zapctx.L(ctx).Error("statements_service_balance_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	statementModels, err := s.repository.ListByPeriod(ctx, accountID, from, to)
+	if err != nil {
+		zapctx.L(ctx).Error("statements_service_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	report := Report{
+		ID:             uuid.New(),
+		Account:        account,
+		From:           from,
+		To:             to,
+		OpeningBalance: opening,
+		ClosingBalance: opening,
+		Entries:        newStatements(statementModels),
+		CreatedAt:      time.Now().UTC(),
+	}
+	for _, model := range statementModels {
+		if model.ToAccountID == accountID {
+			report.ClosingBalance += model.credited()
+		} else {
+			report.ClosingBalance -= model.Amount
+		}
+	}
+
+	body, err := encodeCamt053(report)
+	if err != nil {
+		zapctx.L(ctx).Error("statements_service_encode_camt053_error", zap.Error(err))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return body, nil
+}
+
+func newStatements(statementModels []statementModel) []Statement {
 	stmts := make([]Statement, len(statementModels))
 	for i, model := range statementModels {
 		stmts[i] = Statement{
+			ID:                   model.ID,
+			RelatedTransactionID: model.RelatedTransactionID,
 			FromAccount: accounts.Account{
 				ID:   model.FromAccountID,
 				Name: model.FromAccountName,
@@ -53,10 +132,14 @@ func (s service) List(ctx context.Context, filter ListFilter) (int, []Statement,
 			},
 			Type:        model.Type,
 			Amount:      model.Amount,
+			Currency:    model.Currency,
+			ToAmount:    model.ToAmount,
+			ToCurrency:  model.ToCurrency,
+			FxRate:      model.FxRate,
 			Description: model.Description,
 			CreatedAt:   model.CreatedAt,
 		}
 	}
 
-	return total, stmts, nil
+	return stmts
 }
@@ -4,13 +4,35 @@ import (
 	"time"
 
 	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/exchange"
+	"github.com/google/uuid"
 )
 
 type Statement struct {
-	FromAccount accounts.Account
-	ToAccount   accounts.Account
-	Type        string
-	Amount      float64
+	ID                   uuid.UUID
+	RelatedTransactionID uuid.NullUUID
+	FromAccount          accounts.Account
+	ToAccount            accounts.Account
+	Type                 string
+	Amount               float64
+	Currency             exchange.Currency
+	// ToAmount is the amount credited in ToCurrency when the transaction was converted.
+	ToAmount    float64
+	ToCurrency  exchange.Currency
+	FxRate      float64
 	Description string
 	CreatedAt   time.Time
 }
+
+// Report is the statement of an account over a period, from its start and up to its end, with the
+// balances before and after the period.
+type Report struct {
+	ID             uuid.UUID
+	Account        accounts.Account
+	From           time.Time
+	To             time.Time
+	OpeningBalance float64
+	ClosingBalance float64
+	Entries        []Statement
+	CreatedAt      time.Time
+}
@@ -3,6 +3,7 @@ package transactions
 import (
 	"time"
 
+	"github.com/dalmarcogd/dock-test/internal/exchange"
 	"github.com/dalmarcogd/dock-test/pkg/database"
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
@@ -11,24 +12,36 @@ import (
 type transactionModel struct {
 	bun.BaseModel `bun:"table:transactions"`
 
-	ID            uuid.UUID       `bun:"id,pk"`
-	FromAccountID uuid.UUID       `bun:"from_account_id,nullzero"`
-	ToAccountID   uuid.UUID       `bun:"to_account_id,nullzero"`
-	Type          TransactionType `bun:"type"`
-	Amount        float64         `bun:"amount"`
-	Description   string          `bun:"description"`
-	CreatedAt     time.Time       `bun:"created_at,notnull"`
+	ID                   uuid.UUID         `bun:"id,pk"`
+	FromAccountID        uuid.UUID         `bun:"from_account_id,nullzero"`
+	ToAccountID          uuid.UUID         `bun:"to_account_id,nullzero"`
+	Type                 TransactionType   `bun:"type"`
+	Amount               float64           `bun:"amount"`
+	Currency             exchange.Currency `bun:"currency,nullzero"`
+	ToAmount             float64           `bun:"to_amount,nullzero"`
+	ToCurrency           exchange.Currency `bun:"to_currency,nullzero"`
+	FxRate               float64           `bun:"fx_rate,nullzero"`
+	Description          string            `bun:"description"`
+	CreatedAt            time.Time         `bun:"created_at,notnull"`
+	RelatedTransactionID uuid.NullUUID     `bun:"related_transaction_id"`
+	IdempotencyKey       string            `bun:"idempotency_key,nullzero"`
 }
 
 func newTransactionModel(tx Transaction) transactionModel {
 	return transactionModel{
-		ID:            uuid.New(),
-		FromAccountID: tx.From,
-		ToAccountID:   tx.To,
-		Type:          tx.Type,
-		Amount:        tx.Amount,
-		Description:   tx.Description,
-		CreatedAt:     time.Now().UTC(),
+		ID:                   uuid.New(),
+		FromAccountID:        tx.From,
+		ToAccountID:          tx.To,
+		Type:                 tx.Type,
+		Amount:               tx.Amount,
+		Currency:             tx.Currency,
+		ToAmount:             tx.ToAmount,
+		ToCurrency:           tx.ToCurrency,
+		FxRate:               tx.FxRate,
+		Description:          tx.Description,
+		CreatedAt:            time.Now().UTC(),
+		RelatedTransactionID: tx.RelatedTransactionID,
+		IdempotencyKey:       tx.IdempotencyKey,
 	}
 }
 
@@ -39,3 +52,40 @@ type transactionFilter struct {
 	CreatedAtBegin database.NullTime
 	CreatedAtEnd   database.NullTime
 }
+
+type reviewModel struct {
+	bun.BaseModel `bun:"table:transaction_reviews,alias:review"`
+
+	ID             uuid.UUID         `bun:"id,pk"`
+	EvaluationID   uuid.UUID         `bun:"evaluation_id"`
+	FromAccountID  uuid.UUID         `bun:"from_account_id"`
+	ToAccountID    uuid.UUID         `bun:"to_account_id"`
+	Amount         float64           `bun:"amount"`
+	Currency       exchange.Currency `bun:"currency"`
+	Description    string            `bun:"description"`
+	RequestedBy    string            `bun:"requested_by,nullzero"`
+	Status         ReviewStatus      `bun:"status"`
+	ExpiresAt      time.Time         `bun:"expires_at,notnull"`
+	DecidedAt      time.Time         `bun:"decided_at,nullzero"`
+	DecidedBy      string            `bun:"decided_by,nullzero"`
+	DecisionReason string            `bun:"decision_reason,nullzero"`
+	CreatedAt      time.Time         `bun:"created_at,notnull"`
+	// TransactionID is the P2P made with the idempotency key of the review.
+	TransactionID uuid.NullUUID `bun:"transaction_id,scanonly"`
+}
+
+func newReviewModel(transaction Transaction, evaluationID uuid.UUID, expiresAt time.Time) reviewModel {
+	return reviewModel{
+		ID:            uuid.New(),
+		EvaluationID:  evaluationID,
+		FromAccountID: transaction.From,
+		ToAccountID:   transaction.To,
+		Amount:        transaction.Amount,
+		Currency:      transaction.Currency,
+		Description:   transaction.Description,
+		RequestedBy:   transaction.RequestedBy,
+		Status:        PendingReview,
+		ExpiresAt:     expiresAt,
+		CreatedAt:     time.Now().UTC(),
+	}
+}
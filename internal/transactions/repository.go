@@ -2,14 +2,43 @@ package transactions
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/dalmarcogd/dock-test/pkg/database"
 	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+const (
+	transactionsIdempotencyKeyConstraint = "transactions_idempotency_key"
+	// reviewTransactionIDExpr resolves the P2P made with the idempotency key of the review, so an
+	// approved review is known to be made even when the P2P was made right before a failure.
+	reviewTransactionIDExpr = "(SELECT t.id FROM transactions AS t WHERE t.idempotency_key = 'review:' || review.id)"
+)
+
+var (
+	errDuplicatedIdempotencyKey = errors.New("a transaction with this idempotency key already exists")
+	errReviewStatusChanged      = errors.New("the review is no longer in the expected status")
 )
 
 type Repository interface {
 	Create(ctx context.Context, model transactionModel) (transactionModel, error)
+	CreateWithFee(
+		ctx context.Context,
+		model transactionModel,
+		fee transactionModel,
+	) (transactionModel, transactionModel, error)
 	GetByFilter(ctx context.Context, filter transactionFilter) ([]transactionModel, error)
+	ListLatestByAccountID(ctx context.Context, accountID uuid.UUID, limit int) ([]transactionModel, error)
+	CreateReview(ctx context.Context, model reviewModel) (reviewModel, error)
+	GetReview(ctx context.Context, id uuid.UUID) (reviewModel, error)
+	ListReviews(ctx context.Context, filter ReviewFilter) (int, []reviewModel, error)
+	ListExpiredReviews(ctx context.Context, now time.Time) ([]reviewModel, error)
+	DecideReview(ctx context.Context, model reviewModel, from ReviewStatus) (reviewModel, error)
+	SumPendingReviews(ctx context.Context, accountID uuid.UUID) (float64, error)
 }
 
 type repository struct {
@@ -35,12 +64,47 @@ func (r repository) Create(ctx context.Context, model transactionModel) (transac
 		Exec(ctx)
 	if err != nil {
 		span.RecordError(err)
+		if isConstraintViolation(err, transactionsIdempotencyKeyConstraint) {
+			return transactionModel{}, errDuplicatedIdempotencyKey
+		}
 		return transactionModel{}, err
 	}
 
 	return model, nil
 }
 
+// CreateWithFee inserts the transaction and the fee charged for it, linked to it, within the same
+// database transaction.
+func (r repository) CreateWithFee(
+	ctx context.Context,
+	model transactionModel,
+	fee transactionModel,
+) (transactionModel, transactionModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	fee.RelatedTransactionID = uuid.NullUUID{UUID: model.ID, Valid: true}
+
+	err := r.db.Master().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewInsert().Model(&model).Returning("*").Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.NewInsert().Model(&fee).Returning("*").Exec(ctx)
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		if isConstraintViolation(err, transactionsIdempotencyKeyConstraint) {
+			return transactionModel{}, transactionModel{}, errDuplicatedIdempotencyKey
+		}
+		return transactionModel{}, transactionModel{}, err
+	}
+
+	return model, fee, nil
+}
+
 func (r repository) GetByFilter(ctx context.Context, filter transactionFilter) ([]transactionModel, error) {
 	ctx, span := r.tracer.Span(ctx)
 	defer span.End()
@@ -75,3 +139,181 @@ func (r repository) GetByFilter(ctx context.Context, filter transactionFilter) (
 
 	return trxs, nil
 }
+
+// ListLatestByAccountID lists the latest transactions moving funds in or out of the account, newest first.
+func (r repository) ListLatestByAccountID(
+	ctx context.Context,
+	accountID uuid.UUID,
+	limit int,
+) ([]transactionModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var models []transactionModel
+	err := r.db.Replica().
+		NewSelect().
+		Model(&models).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("from_account_id = ?", accountID).WhereOr("to_account_id = ?", accountID)
+		}).
+		Order("created_at DESC", "id ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func (r repository) CreateReview(ctx context.Context, model reviewModel) (reviewModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	_, err := r.db.Master().
+		NewInsert().
+		Model(&model).
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return reviewModel{}, err
+	}
+
+	return model, nil
+}
+
+// GetReview finds the review, returning sql.ErrNoRows when it does not exist. It reads from the
+// master, as reviews are decided right after being read.
+func (r repository) GetReview(ctx context.Context, id uuid.UUID) (reviewModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var model reviewModel
+	err := r.db.Master().
+		NewSelect().
+		Model(&model).
+		ColumnExpr("review.*").
+		ColumnExpr(reviewTransactionIDExpr+" AS transaction_id").
+		Where("review.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return reviewModel{}, err
+	}
+
+	return model, nil
+}
+
+// ListReviews lists a page of the reviews with the status, oldest first, so the closest to expire
+// come first.
+func (r repository) ListReviews(ctx context.Context, filter ReviewFilter) (int, []reviewModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var models []reviewModel
+	selectQuery := r.db.Replica().
+		NewSelect().
+		Model(&models).
+		ColumnExpr("review.*").
+		ColumnExpr(reviewTransactionIDExpr+" AS transaction_id").
+		Where("review.status = ?", filter.Status).
+		Order("review.created_at ASC", "review.id ASC").
+		Limit(filter.Size).
+		Offset((filter.Page - 1) * filter.Size)
+
+	if filter.AccountID.Valid {
+		selectQuery.Where("review.from_account_id = ?", filter.AccountID.UUID)
+	}
+
+	total, err := selectQuery.ScanAndCount(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return 0, nil, err
+	}
+
+	return total, models, nil
+}
+
+// ListExpiredReviews lists the pending reviews whose SLA expired by now.
+func (r repository) ListExpiredReviews(ctx context.Context, now time.Time) ([]reviewModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var models []reviewModel
+	err := r.db.Master().
+		NewSelect().
+		Model(&models).
+		Where("status = ?", PendingReview).
+		Where("expires_at <= ?", now).
+		Order("expires_at ASC", "id ASC").
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return models, nil
+}
+
+// DecideReview moves the review from a status to the one of the model, recording who decided and
+// why. It returns errReviewStatusChanged when the review is no longer in the from status, so a review
+// is decided once however many analysts act on it.
+func (r repository) DecideReview(ctx context.Context, model reviewModel, from ReviewStatus) (reviewModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	if model.Status != PendingReview {
+		model.DecidedAt = time.Now().UTC()
+	}
+
+	res, err := r.db.Master().
+		NewUpdate().
+		Model(&model).
+		Column("status", "decided_at", "decided_by", "decision_reason").
+		Where("id = ?", model.ID).
+		Where("status = ?", from).
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return reviewModel{}, err
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		span.RecordError(errReviewStatusChanged)
+		return reviewModel{}, errReviewStatusChanged
+	}
+
+	return model, nil
+}
+
+// SumPendingReviews is the amount of the pending reviews of the account, held until they are decided.
+func (r repository) SumPendingReviews(ctx context.Context, accountID uuid.UUID) (float64, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var amount float64
+	err := r.db.Master().
+		NewSelect().
+		Model((*reviewModel)(nil)).
+		ColumnExpr("COALESCE(SUM(amount), 0)").
+		Where("from_account_id = ?", accountID).
+		Where("status = ?", PendingReview).
+		Scan(ctx, &amount)
+	if err != nil {
+		span.RecordError(err)
+		return 0, err
+	}
+
+	return amount, nil
+}
+
+func isConstraintViolation(err error, constraint string) bool {
+	var pgErr pgdriver.Error
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.IntegrityViolation() && pgErr.Field('n') == constraint
+}
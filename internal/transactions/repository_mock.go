@@ -7,8 +7,10 @@ package transactions
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 )
 
 // MockRepository is a mock of Repository interface.
@@ -49,6 +51,52 @@ func (mr *MockRepositoryMockRecorder) Create(ctx, model interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, model)
 }
 
+// CreateReview mocks base method.
+func (m *MockRepository) CreateReview(ctx context.Context, model reviewModel) (reviewModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReview", ctx, model)
+	ret0, _ := ret[0].(reviewModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReview indicates an expected call of CreateReview.
+func (mr *MockRepositoryMockRecorder) CreateReview(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockRepository)(nil).CreateReview), ctx, model)
+}
+
+// CreateWithFee mocks base method.
+func (m *MockRepository) CreateWithFee(ctx context.Context, model, fee transactionModel) (transactionModel, transactionModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithFee", ctx, model, fee)
+	ret0, _ := ret[0].(transactionModel)
+	ret1, _ := ret[1].(transactionModel)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateWithFee indicates an expected call of CreateWithFee.
+func (mr *MockRepositoryMockRecorder) CreateWithFee(ctx, model, fee interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithFee", reflect.TypeOf((*MockRepository)(nil).CreateWithFee), ctx, model, fee)
+}
+
+// DecideReview mocks base method.
+func (m *MockRepository) DecideReview(ctx context.Context, model reviewModel, from ReviewStatus) (reviewModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecideReview", ctx, model, from)
+	ret0, _ := ret[0].(reviewModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecideReview indicates an expected call of DecideReview.
+func (mr *MockRepositoryMockRecorder) DecideReview(ctx, model, from interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecideReview", reflect.TypeOf((*MockRepository)(nil).DecideReview), ctx, model, from)
+}
+
 // GetByFilter mocks base method.
 func (m *MockRepository) GetByFilter(ctx context.Context, filter transactionFilter) ([]transactionModel, error) {
 	m.ctrl.T.Helper()
@@ -63,3 +111,79 @@ func (mr *MockRepositoryMockRecorder) GetByFilter(ctx, filter interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFilter", reflect.TypeOf((*MockRepository)(nil).GetByFilter), ctx, filter)
 }
+
+// GetReview mocks base method.
+func (m *MockRepository) GetReview(ctx context.Context, id uuid.UUID) (reviewModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReview", ctx, id)
+	ret0, _ := ret[0].(reviewModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReview indicates an expected call of GetReview.
+func (mr *MockRepositoryMockRecorder) GetReview(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReview", reflect.TypeOf((*MockRepository)(nil).GetReview), ctx, id)
+}
+
+// ListExpiredReviews mocks base method.
+func (m *MockRepository) ListExpiredReviews(ctx context.Context, now time.Time) ([]reviewModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredReviews", ctx, now)
+	ret0, _ := ret[0].([]reviewModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredReviews indicates an expected call of ListExpiredReviews.
+func (mr *MockRepositoryMockRecorder) ListExpiredReviews(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredReviews", reflect.TypeOf((*MockRepository)(nil).ListExpiredReviews), ctx, now)
+}
+
+// ListLatestByAccountID mocks base method.
+func (m *MockRepository) ListLatestByAccountID(ctx context.Context, accountID uuid.UUID, limit int) ([]transactionModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLatestByAccountID", ctx, accountID, limit)
+	ret0, _ := ret[0].([]transactionModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLatestByAccountID indicates an expected call of ListLatestByAccountID.
+func (mr *MockRepositoryMockRecorder) ListLatestByAccountID(ctx, accountID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLatestByAccountID", reflect.TypeOf((*MockRepository)(nil).ListLatestByAccountID), ctx, accountID, limit)
+}
+
+// ListReviews mocks base method.
+func (m *MockRepository) ListReviews(ctx context.Context, filter ReviewFilter) (int, []reviewModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReviews", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]reviewModel)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListReviews indicates an expected call of ListReviews.
+func (mr *MockRepositoryMockRecorder) ListReviews(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviews", reflect.TypeOf((*MockRepository)(nil).ListReviews), ctx, filter)
+}
+
+// SumPendingReviews mocks base method.
+func (m *MockRepository) SumPendingReviews(ctx context.Context, accountID uuid.UUID) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumPendingReviews", ctx, accountID)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumPendingReviews indicates an expected call of SumPendingReviews.
+func (mr *MockRepositoryMockRecorder) SumPendingReviews(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumPendingReviews", reflect.TypeOf((*MockRepository)(nil).SumPendingReviews), ctx, accountID)
+}
@@ -4,6 +4,7 @@ package transactions
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"path/filepath"
 	"runtime"
@@ -13,7 +14,9 @@ import (
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/dalmarcogd/dock-test/internal/accounts"
 	"github.com/dalmarcogd/dock-test/internal/balances"
+	"github.com/dalmarcogd/dock-test/internal/exchange"
 	"github.com/dalmarcogd/dock-test/internal/holders"
+	"github.com/dalmarcogd/dock-test/internal/risk"
 	"github.com/dalmarcogd/dock-test/internal/statements"
 	"github.com/dalmarcogd/dock-test/pkg/database"
 	"github.com/dalmarcogd/dock-test/pkg/testingcontainers"
@@ -52,7 +55,7 @@ func TestRepository(t *testing.T) {
 	holderModel, err = holdersRepo.Create(ctx, holderModel)
 	assert.NoError(t, err)
 
-	accSvc := accounts.NewService(tracer.NewNoop(), accounts.NewRepository(tracer.NewNoop(), db), holdersRepo)
+	accSvc := accounts.NewService(tracer.NewNoop(), accounts.NewRepository(tracer.NewNoop(), db), holdersRepo, false, 0)
 
 	account1, err := accSvc.Create(ctx, accounts.Account{
 		ID:             uuid.New(),
@@ -223,6 +226,10 @@ func TestRepository(t *testing.T) {
 		accountBalance2, err := balanceRepo.GetByAccountID(ctx, account2.ID)
 		assert.NoError(t, err)
 		assert.Equal(t, float64(120), accountBalance2.Balance)
+
+		accountBalances, err := balanceRepo.ListByAccountIDs(ctx, []uuid.UUID{account1.ID, account2.ID})
+		assert.NoError(t, err)
+		assert.Len(t, accountBalances, 2)
 	})
 
 	t.Run("check accounts statement", func(t *testing.T) {
@@ -246,4 +253,160 @@ func TestRepository(t *testing.T) {
 		assert.Equal(t, 3, total)
 		assert.Len(t, stats, 3)
 	})
+
+	t.Run("check accounts statement with filters", func(t *testing.T) {
+		total, stats, err := statementRepo.ListByFilter(ctx, statements.StatementFilter{
+			AccountID: account1.ID,
+			Direction: statements.InDirection,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, stats, 1)
+		assert.Equal(t, float64(100), stats[0].Amount)
+
+		total, stats, err = statementRepo.ListByFilter(ctx, statements.StatementFilter{
+			AccountID: account1.ID,
+			Direction: statements.OutDirection,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Len(t, stats, 2)
+
+		total, stats, err = statementRepo.ListByFilter(ctx, statements.StatementFilter{
+			AccountID: account1.ID,
+			Type:      string(P2PTransaction),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, stats, 1)
+		assert.NotEmpty(t, stats[0].Description)
+
+		total, stats, err = statementRepo.ListByFilter(ctx, statements.StatementFilter{
+			AccountID: account1.ID,
+			AmountMin: 30,
+			AmountMax: 60,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, stats, 1)
+		assert.Equal(t, float64(50), stats[0].Amount)
+	})
+
+	t.Run("converted p2p transaction balances per currency", func(t *testing.T) {
+		account3, err := accSvc.Create(ctx, accounts.Account{
+			Name:           gofakeit.Name(),
+			DocumentNumber: holderModel.DocumentNumber,
+			Currency:       "USD",
+		})
+		assert.NoError(t, err)
+
+		created, err := repo.Create(ctx, newTransactionModel(Transaction{
+			From:        account1.ID,
+			To:          account3.ID,
+			Type:        P2PTransaction,
+			Amount:      10,
+			Currency:    "BRL",
+			ToAmount:    2,
+			ToCurrency:  "USD",
+			FxRate:      0.2,
+			Description: gofakeit.BeerName(),
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, exchange.Currency("USD"), created.ToCurrency)
+
+		accountBalance1, err := balanceRepo.GetByAccountID(ctx, account1.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(20), accountBalance1.Balance)
+		assert.Equal(t, exchange.Currency("BRL"), accountBalance1.Currency)
+
+		accountBalance3, err := balanceRepo.GetByAccountID(ctx, account3.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(2), accountBalance3.Balance)
+		assert.Equal(t, exchange.Currency("USD"), accountBalance3.Currency)
+
+		holderBalances, err := balanceRepo.ListByHolderID(ctx, holderModel.ID)
+		assert.NoError(t, err)
+		assert.Len(t, holderBalances, 2)
+	})
+
+	t.Run("review lifecycle", func(t *testing.T) {
+		evaluation, err := risk.NewService(tracer.NewNoop(), risk.NewRepository(tracer.NewNoop(), db)).
+			Evaluate(ctx, risk.Operation{
+				AccountID:       account1.ID,
+				TransactionType: string(P2PTransaction),
+				Amount:          5,
+			})
+		assert.NoError(t, err)
+
+		transaction := Transaction{
+			From:        account1.ID,
+			To:          account2.ID,
+			Type:        P2PTransaction,
+			Amount:      5,
+			Currency:    "BRL",
+			Description: gofakeit.BeerName(),
+		}
+		review, err := repo.CreateReview(ctx, newReviewModel(transaction, evaluation.ID, time.Now().UTC().Add(time.Hour)))
+		assert.NoError(t, err)
+
+		held, err := repo.SumPendingReviews(ctx, account1.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, float64(5), held)
+
+		got, err := repo.GetReview(ctx, review.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, PendingReview, got.Status)
+		assert.False(t, got.TransactionID.Valid)
+
+		_, err = repo.GetReview(ctx, uuid.New())
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		total, reviews, err := repo.ListReviews(ctx, ReviewFilter{
+			Status:    PendingReview,
+			AccountID: uuid.NullUUID{UUID: account1.ID, Valid: true},
+			Page:      1,
+			Size:      10,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, reviews, 1)
+
+		expired, err := repo.ListExpiredReviews(ctx, time.Now().UTC())
+		assert.NoError(t, err)
+		assert.Empty(t, expired)
+
+		expired, err = repo.ListExpiredReviews(ctx, time.Now().UTC().Add(2*time.Hour))
+		assert.NoError(t, err)
+		assert.Len(t, expired, 1)
+
+		decided, err := repo.DecideReview(ctx, reviewModel{
+			ID:             review.ID,
+			Status:         ApprovedReview,
+			DecidedBy:      "analyst",
+			DecisionReason: gofakeit.Sentence(5),
+		}, PendingReview)
+		assert.NoError(t, err)
+		assert.Equal(t, ApprovedReview, decided.Status)
+		assert.NotEmpty(t, decided.DecidedAt)
+
+		_, err = repo.DecideReview(ctx, reviewModel{ID: review.ID, Status: RejectedReview}, PendingReview)
+		assert.ErrorIs(t, err, errReviewStatusChanged)
+
+		held, err = repo.SumPendingReviews(ctx, account1.ID)
+		assert.NoError(t, err)
+		assert.Zero(t, held)
+
+		transaction.IdempotencyKey = reviewKey(review.ID)
+		created, err := repo.Create(ctx, newTransactionModel(transaction))
+		assert.NoError(t, err)
+
+		got, err = repo.GetReview(ctx, review.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, uuid.NullUUID{UUID: created.ID, Valid: true}, got.TransactionID)
+
+		latest, err := repo.ListLatestByAccountID(ctx, account1.ID, 2)
+		assert.NoError(t, err)
+		assert.Len(t, latest, 2)
+		assert.Equal(t, created.ID, latest[0].ID)
+	})
 }
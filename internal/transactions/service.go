@@ -6,10 +6,16 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dalmarcogd/dock-test/internal/accounts"
 	"github.com/dalmarcogd/dock-test/internal/balances"
+	"github.com/dalmarcogd/dock-test/internal/exchange"
+	"github.com/dalmarcogd/dock-test/internal/fees"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/internal/products"
+	"github.com/dalmarcogd/dock-test/internal/risk"
 	"github.com/dalmarcogd/dock-test/pkg/distlock"
 	"github.com/dalmarcogd/dock-test/pkg/redis"
 	"github.com/dalmarcogd/dock-test/pkg/tracer"
@@ -29,13 +35,73 @@ var (
 	ErrBalanceInsufficientFunds              = errors.New("insufficient funds to complete the transaction")
 	ErrAccountInactive                       = errors.New("the account related to the transaction must be active")
 	ErrInsufficientDailyLimit                = errors.New("the account has insufficient daily limit")
+	ErrP2PNotAllowed                         = errors.New("the account product does not allow p2p transactions")
+	ErrMaxBalanceExceeded                    = errors.New("the transaction exceeds the account product maximum balance")
+	ErrAccountDebitsBlocked                  = errors.New("the account is restricted from debits")
+	ErrAccountCreditsBlocked                 = errors.New("the account is restricted from credits")
+	ErrAccountP2POutBlocked                  = errors.New("the account is restricted from outgoing p2p transactions")
+	ErrGetAccountHeldAmount                  = errors.New("received error when get the account held amount")
+	ErrHolderNotAllowedToDebit               = errors.New("the holder is not allowed to debit the account")
+	ErrRequesterRequired                     = errors.New("the transaction must have the principal requesting it")
+	ErrPocketExternalTransaction             = errors.New("pockets only move funds internally with their account")
+	ErrAccountsNotRelated                    = errors.New("internal transactions must be between an account and its pockets")
+	ErrFeeAlreadyCharged                     = errors.New("the fee was already charged")
+	ErrTransactionAlreadyMade                = errors.New("a transaction with this idempotency key was already made")
+	ErrInvalidFeeAmount                      = errors.New("the fee amount must be greater than zero")
+	ErrCurrencyMismatch                      = errors.New("the transaction currency must be the account currency")
+	ErrExchangeRateNotFound                  = errors.New("no exchange rate available between the accounts currencies")
+	ErrMissingIdempotencyKey                 = errors.New("the transaction must have an idempotency key")
+	ErrTransactionDenied                     = errors.New("the transaction was denied by the risk rules")
+	ErrTransactionUnderReview                = errors.New("the transaction requires a manual review by the risk team")
+	ErrApprovalRequired                      = errors.New("the transaction must be approved by a second principal")
+	ErrReviewNotFound                        = errors.New("no review found with this id")
+	ErrReviewNotPending                      = errors.New("the review was already decided")
+	ErrReviewExpired                         = errors.New("the review expired and its transaction was rejected")
+	ErrReviewReasonRequired                  = errors.New("the review decision must have a reason")
+	ErrAccountHasPendingReviews              = errors.New("the account has p2ps pending a review")
 )
 
+const (
+	// debitLockTTL is how long the account lock of a debit lasts unless released, well beyond the risk
+	// evaluation and the round trips of the debit, so it never expires while the debit is made. The
+	// other debits of the account do not wait for it, they fail once their retries are over, so it only
+	// delays them when the process making the debit dies.
+	debitLockTTL = 5 * time.Second
+	// reviewHistorySize is how many of the latest transactions of the account come with each review.
+	reviewHistorySize = 10
+	// reviewSLAActor decides the reviews that expire.
+	reviewSLAActor = "review-sla"
+)
+
+var (
+	// dormant accounts still receive credits, which reactivate them.
+	creditableStatuses = []accounts.Status{accounts.ActiveStatus, accounts.DormantStatus}
+	debitableStatuses  = []accounts.Status{accounts.ActiveStatus}
+	// the balance of a closed account only moves to an active account.
+	closureDestinationStatuses = []accounts.Status{accounts.ActiveStatus}
+)
+
+var restrictionErrors = map[accounts.Restriction]error{
+	accounts.DebitsRestriction:  ErrAccountDebitsBlocked,
+	accounts.CreditsRestriction: ErrAccountCreditsBlocked,
+	accounts.P2POutRestriction:  ErrAccountP2POutBlocked,
+}
+
 type Service interface {
 	CreateCredit(ctx context.Context, transaction Transaction) (Transaction, error)
+	CreditInterest(ctx context.Context, transaction Transaction) (Transaction, error)
 	CreateDebit(ctx context.Context, transaction Transaction) (Transaction, error)
 	CreateP2P(ctx context.Context, transaction Transaction) (Transaction, error)
+	CreateInternal(ctx context.Context, transaction Transaction) (Transaction, error)
+	CreateTED(ctx context.Context, transaction Transaction) (Transaction, error)
+	ReverseTED(ctx context.Context, transaction Transaction) (Transaction, error)
+	ChargeFee(ctx context.Context, fee fees.Fee) (Transaction, error)
+	CloseAccount(ctx context.Context, id uuid.UUID, closure accounts.Closure) (accounts.Account, error)
 	GetByID(ctx context.Context, id uuid.UUID) (Transaction, error)
+	ListReviews(ctx context.Context, filter ReviewFilter) (int, []Review, error)
+	ApproveReview(ctx context.Context, id uuid.UUID, decision ReviewDecision) (Review, error)
+	RejectReview(ctx context.Context, id uuid.UUID, decision ReviewDecision) (Review, error)
+	ExpireReviews(ctx context.Context) (int, error)
 }
 
 type service struct {
@@ -44,7 +110,15 @@ type service struct {
 	locker      distlock.DistLock
 	accountsSvs accounts.Service
 	balancesSvs balances.Service
+	productsSvs products.Service
+	feesSvs     fees.Service
+	exchangeSvs exchange.Service
+	riskSvs     risk.Service
+	policiesSvs policies.Service
 	redis       redis.Client
+	reviewSLA   time.Duration
+	// approvalThreshold is the amount above which debits from business accounts need an approval.
+	approvalThreshold float64
 }
 
 func NewService(
@@ -53,15 +127,29 @@ func NewService(
 	l distlock.DistLock,
 	as accounts.Service,
 	bs balances.Service,
+	ps products.Service,
+	fs fees.Service,
+	es exchange.Service,
+	rs risk.Service,
+	pls policies.Service,
 	redis redis.Client,
+	reviewSLA time.Duration,
+	approvalThreshold float64,
 ) Service {
 	return service{
-		tracer:      t,
-		repository:  r,
-		locker:      l,
-		accountsSvs: as,
-		balancesSvs: bs,
-		redis:       redis,
+		tracer:            t,
+		repository:        r,
+		locker:            l,
+		accountsSvs:       as,
+		balancesSvs:       bs,
+		productsSvs:       ps,
+		feesSvs:           fs,
+		exchangeSvs:       es,
+		riskSvs:           rs,
+		policiesSvs:       pls,
+		redis:             redis,
+		reviewSLA:         reviewSLA,
+		approvalThreshold: approvalThreshold,
 	}
 }
 
@@ -69,148 +157,1133 @@ func (s service) CreateCredit(ctx context.Context, transaction Transaction) (Tra
 	ctx, span := s.tracer.Span(ctx)
 	defer span.End()
 
-	transaction.Type = CreditTransaction
+	transaction.Type = CreditTransaction
+
+	to, toProduct, err := s.checkExternalAccount(ctx, transaction.To, creditableStatuses, accounts.CreditsRestriction)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	transaction.Currency, err = s.checkCurrency(ctx, transaction, to)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	err = s.checkMaxBalance(ctx, transaction.To, toProduct, transaction.Amount)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	transaction, err = s.createCredit(ctx, transaction)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	s.reactivateDormant(ctx, to)
+
+	return transaction, nil
+}
+
+// CreditInterest credits the interest paid from the From account holding the lock of the To account, so it
+// is not credited along with the closure of the account. Pockets earn interest like their accounts, and as
+// interest is no activity of the holders it does not reactivate dormant accounts.
+func (s service) CreditInterest(ctx context.Context, transaction Transaction) (Transaction, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	transaction.Type = CreditTransaction
+
+	transactionAccountLockerKey := fmt.Sprintf("transaction-account-from-%s", transaction.To.String())
+	defer s.locker.Release(ctx, transactionAccountLockerKey)
+
+	if !s.locker.Acquire(ctx, transactionAccountLockerKey, 50*time.Millisecond, 3) {
+		span.RecordError(ErrFailLockAccount)
+		return Transaction{}, ErrFailLockAccount
+	}
+
+	to, toProduct, err := s.checkAccount(ctx, transaction.To, creditableStatuses, accounts.CreditsRestriction)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	transaction.Currency, err = s.checkCurrency(ctx, transaction, to)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	err = s.checkMaxBalance(ctx, transaction.To, toProduct, transaction.Amount)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	transaction, err = s.createCredit(ctx, transaction)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	return transaction, nil
+}
+
+func (s service) CreateDebit(ctx context.Context, transaction Transaction) (Transaction, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	transaction.Type = DebitTransaction
+
+	from, fromProduct, err := s.checkExternalAccount(
+		ctx,
+		transaction.From,
+		debitableStatuses,
+		accounts.DebitsRestriction,
+	)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	transaction.Currency, err = s.checkCurrency(ctx, transaction, from)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	err = s.checkDebitRole(ctx, transaction)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	return s.createDebit(ctx, transaction, fromProduct)
+}
+
+// CreateP2P moves funds between two accounts. A Reviewable P2P the risk rules send to a manual review
+// is parked instead, returned with its ReviewID.
+func (s service) CreateP2P(ctx context.Context, transaction Transaction) (Transaction, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	transaction.Type = P2PTransaction
+
+	transaction, fromProduct, to, err := s.checkP2P(ctx, transaction)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	transaction, err = s.createDebit(ctx, transaction, fromProduct)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	if !transaction.ReviewID.Valid {
+		s.reactivateDormant(ctx, to)
+	}
+
+	return transaction, nil
+}
+
+// checkP2P checks both accounts of the P2P can take part in it, converting the amount credited when
+// their currencies differ.
+func (s service) checkP2P(
+	ctx context.Context,
+	transaction Transaction,
+) (Transaction, products.Product, accounts.Account, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if transaction.From == transaction.To {
+		zapctx.L(ctx).Error(
+			"transaction_service_from_acccount_to_account_equal_error",
+			zap.Error(ErrFromAccountToAccountShouldBeDifferent),
+			zap.String("from", transaction.From.String()),
+			zap.String("to", transaction.To.String()),
+		)
+		span.RecordError(ErrFromAccountToAccountShouldBeDifferent)
+		return Transaction{}, products.Product{}, accounts.Account{}, ErrFromAccountToAccountShouldBeDifferent
+	}
+
+	from, fromProduct, err := s.checkExternalAccount(
+		ctx,
+		transaction.From,
+		debitableStatuses,
+		accounts.DebitsRestriction,
+		accounts.P2POutRestriction,
+	)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, products.Product{}, accounts.Account{}, err
+	}
+
+	transaction.Currency, err = s.checkCurrency(ctx, transaction, from)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, products.Product{}, accounts.Account{}, err
+	}
+
+	err = s.checkDebitRole(ctx, transaction)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, products.Product{}, accounts.Account{}, err
+	}
+
+	to, toProduct, err := s.checkExternalAccount(ctx, transaction.To, creditableStatuses, accounts.CreditsRestriction)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, products.Product{}, accounts.Account{}, err
+	}
+
+	for _, product := range []products.Product{fromProduct, toProduct} {
+		if !product.P2PAllowed {
+			zapctx.L(ctx).Error(
+				"transaction_service_p2p_not_allowed_error",
+				zap.Error(ErrP2PNotAllowed),
+				zap.String("product", string(product.Type)),
+			)
+			span.RecordError(ErrP2PNotAllowed)
+			return Transaction{}, products.Product{}, accounts.Account{}, ErrP2PNotAllowed
+		}
+	}
+
+	transaction, err = s.convert(ctx, transaction, to.Currency)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, products.Product{}, accounts.Account{}, err
+	}
+
+	err = s.checkMaxBalance(ctx, transaction.To, toProduct, transaction.CreditedAmount())
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, products.Product{}, accounts.Account{}, err
+	}
+
+	return transaction, fromProduct, to, nil
+}
+
+// CreateTED debits a transfer to another bank from the From account into the clearing account in To,
+// where the funds wait for the transfer to be confirmed or reversed. It follows the rules of debits,
+// while the clearing account, a system account, is not checked.
+func (s service) CreateTED(ctx context.Context, transaction Transaction) (Transaction, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	transaction.Type = TEDTransaction
+
+	if transaction.From == transaction.To {
+		span.RecordError(ErrFromAccountToAccountShouldBeDifferent)
+		return Transaction{}, ErrFromAccountToAccountShouldBeDifferent
+	}
+
+	from, fromProduct, err := s.checkExternalAccount(
+		ctx,
+		transaction.From,
+		debitableStatuses,
+		accounts.DebitsRestriction,
+	)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	transaction.Currency, err = s.checkCurrency(ctx, transaction, from)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	err = s.checkDebitRole(ctx, transaction)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	return s.createDebit(ctx, transaction, fromProduct)
+}
+
+// ReverseTED returns the funds of a rejected TED from the clearing account in From to the account in
+// To. Reversals must carry an idempotency key, so the funds are returned once, and are made whatever
+// the status and restrictions of the account, since the funds were its own.
+func (s service) ReverseTED(ctx context.Context, transaction Transaction) (Transaction, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	transaction.Type = TEDReversalTransaction
+
+	if transaction.IdempotencyKey == "" {
+		span.RecordError(ErrMissingIdempotencyKey)
+		return Transaction{}, ErrMissingIdempotencyKey
+	}
+
+	model, err := s.store(ctx, transaction, Transaction{})
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	transaction.ID = model.ID
+
+	return transaction, nil
+}
+
+// CreateInternal moves funds between an account and one of its pockets, or between two pockets of
+// the same account. These moves do not count towards the daily debit limit.
+func (s service) CreateInternal(ctx context.Context, transaction Transaction) (Transaction, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	transaction.Type = InternalTransaction
+
+	if transaction.From == transaction.To {
+		zapctx.L(ctx).Error(
+			"transaction_service_from_acccount_to_account_equal_error",
+			zap.Error(ErrFromAccountToAccountShouldBeDifferent),
+			zap.String("from", transaction.From.String()),
+			zap.String("to", transaction.To.String()),
+		)
+		span.RecordError(ErrFromAccountToAccountShouldBeDifferent)
+		return Transaction{}, ErrFromAccountToAccountShouldBeDifferent
+	}
+
+	from, _, err := s.checkAccount(ctx, transaction.From, debitableStatuses, accounts.DebitsRestriction)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	to, _, err := s.checkAccount(ctx, transaction.To, creditableStatuses, accounts.CreditsRestriction)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	if !related(from, to) {
+		zapctx.L(ctx).Error(
+			"transaction_service_accounts_not_related_error",
+			zap.Error(ErrAccountsNotRelated),
+			zap.String("from", transaction.From.String()),
+			zap.String("to", transaction.To.String()),
+		)
+		span.RecordError(ErrAccountsNotRelated)
+		return Transaction{}, ErrAccountsNotRelated
+	}
+
+	err = s.checkDebitRole(ctx, transaction)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	// pockets hold the currency of their account, so internal moves are never converted.
+	transaction.Currency = from.Currency
+
+	transaction, err = s.createFunded(ctx, transaction, Transaction{})
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	s.reactivateDormant(ctx, to)
+
+	return transaction, nil
+}
+
+// checkAccount ensures the account exists, is in one of the given statuses and is not under any
+// of the given restrictions, returning it along with the product rules that apply to it.
+func (s service) checkAccount(
+	ctx context.Context,
+	accountID uuid.UUID,
+	statuses []accounts.Status,
+	restrictions ...accounts.Restriction,
+) (accounts.Account, products.Product, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	acc, err := s.accountsSvs.GetByID(ctx, accountID)
+	if err != nil {
+		span.RecordError(err)
+
+		if !errors.Is(err, accounts.ErrAccountNotFound) {
+			zapctx.L(ctx).Error(
+				"transaction_service_acccount_check_error",
+				zap.Error(err),
+				zap.String("account_id", accountID.String()),
+			)
+		}
+		return accounts.Account{}, products.Product{}, ErrAccountNotfound
+	}
+
+	if !hasStatus(statuses, acc.Status) {
+		zapctx.L(ctx).Error(
+			"transaction_service_acccount_inactive_error",
+			zap.Error(ErrAccountInactive),
+			zap.String("account_id", accountID.String()),
+		)
+		span.RecordError(ErrAccountInactive)
+		return accounts.Account{}, products.Product{}, ErrAccountInactive
+	}
+
+	for _, restriction := range restrictions {
+		if acc.Restricted(restriction) {
+			err := restrictionErrors[restriction]
+			zapctx.L(ctx).Error(
+				"transaction_service_acccount_restricted_error",
+				zap.Error(err),
+				zap.String("account_id", accountID.String()),
+				zap.String("restriction", string(restriction)),
+			)
+			span.RecordError(err)
+			return accounts.Account{}, products.Product{}, err
+		}
+	}
+
+	product, err := s.productsSvs.GetByType(ctx, acc.Type)
+	if err != nil {
+		zapctx.L(ctx).Error(
+			"transaction_service_account_product_error",
+			zap.Error(err),
+			zap.String("account_id", accountID.String()),
+			zap.String("type", string(acc.Type)),
+		)
+		span.RecordError(err)
+		return accounts.Account{}, products.Product{}, err
+	}
+
+	return acc, product, nil
+}
+
+// checkExternalAccount works like checkAccount but rejects pockets, which only move funds with their
+// account through internal transactions.
+func (s service) checkExternalAccount(
+	ctx context.Context,
+	accountID uuid.UUID,
+	statuses []accounts.Status,
+	restrictions ...accounts.Restriction,
+) (accounts.Account, products.Product, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	acc, product, err := s.checkAccount(ctx, accountID, statuses, restrictions...)
+	if err != nil {
+		span.RecordError(err)
+		return accounts.Account{}, products.Product{}, err
+	}
+
+	if acc.IsPocket() {
+		zapctx.L(ctx).Error(
+			"transaction_service_pocket_external_transaction_error",
+			zap.Error(ErrPocketExternalTransaction),
+			zap.String("account_id", accountID.String()),
+		)
+		span.RecordError(ErrPocketExternalTransaction)
+		return accounts.Account{}, products.Product{}, ErrPocketExternalTransaction
+	}
+
+	return acc, product, nil
+}
+
+// checkDebitRole ensures the principal requesting the transaction is linked to a holder of the From account
+// with a role allowed to debit it.
+func (s service) checkDebitRole(ctx context.Context, transaction Transaction) error {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if strings.TrimSpace(transaction.RequestedBy) == "" {
+		span.RecordError(ErrRequesterRequired)
+		return ErrRequesterRequired
+	}
+
+	err := s.policiesSvs.AuthorizeDebit(ctx, transaction.RequestedBy, transaction.From)
+	if errors.Is(err, policies.ErrForbidden) {
+		zapctx.L(ctx).Error(
+			"transaction_service_holder_not_allowed_to_debit_error",
+			zap.Error(ErrHolderNotAllowedToDebit),
+			zap.String("account_id", transaction.From.String()),
+		)
+		span.RecordError(ErrHolderNotAllowedToDebit)
+		return ErrHolderNotAllowedToDebit
+	} else if err != nil {
+		zapctx.L(ctx).Error(
+			"transaction_service_holder_role_error",
+			zap.Error(err),
+			zap.String("account_id", transaction.From.String()),
+		)
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+// checkCurrency returns the currency of the account, which the transaction must be in when it
+// informs one.
+func (s service) checkCurrency(
+	ctx context.Context,
+	transaction Transaction,
+	account accounts.Account,
+) (exchange.Currency, error) {
+	if transaction.Currency != "" && transaction.Currency != account.Currency {
+		zapctx.L(ctx).Error(
+			"transaction_service_currency_mismatch_error",
+			zap.Error(ErrCurrencyMismatch),
+			zap.String("account_id", account.ID.String()),
+			zap.String("currency", string(transaction.Currency)),
+			zap.String("account_currency", string(account.Currency)),
+		)
+		return "", ErrCurrencyMismatch
+	}
+
+	return account.Currency, nil
+}
+
+// convert prices the amount credited to the To account in its currency when it differs from the
+// transaction currency, keeping the applied rate along with the converted amount.
+func (s service) convert(ctx context.Context, transaction Transaction, currency exchange.Currency) (Transaction, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if transaction.Currency == currency {
+		return transaction, nil
+	}
+
+	conversion, err := s.exchangeSvs.Convert(ctx, transaction.Amount, transaction.Currency, currency)
+	if err != nil {
+		zapctx.L(ctx).Error(
+			"transaction_service_convert_error",
+			zap.Error(err),
+			zap.String("from_currency", string(transaction.Currency)),
+			zap.String("to_currency", string(currency)),
+		)
+		span.RecordError(err)
+		if errors.Is(err, exchange.ErrRateNotFound) {
+			return Transaction{}, ErrExchangeRateNotFound
+		}
+		return Transaction{}, err
+	}
+
+	transaction.ToAmount = conversion.ConvertedAmount
+	transaction.ToCurrency = conversion.To
+	transaction.FxRate = conversion.Rate
+
+	return transaction, nil
+}
+
+func hasStatus(statuses []accounts.Status, status accounts.Status) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// related reports whether two accounts are an account and one of its pockets, or pockets of the same account.
+func related(a, b accounts.Account) bool {
+	switch {
+	case a.ParentID.Valid && b.ParentID.Valid:
+		return a.ParentID.UUID == b.ParentID.UUID
+	case a.ParentID.Valid:
+		return a.ParentID.UUID == b.ID
+	case b.ParentID.Valid:
+		return b.ParentID.UUID == a.ID
+	}
+
+	return false
+}
+
+// reactivateDormant moves a dormant account back to ACTIVE after it received a credit. The credit is
+// already stored at this point, so a failure is only logged and the account stays dormant.
+func (s service) reactivateDormant(ctx context.Context, account accounts.Account) {
+	if account.Status != accounts.DormantStatus {
+		return
+	}
+
+	_, err := s.accountsSvs.ReactivateByID(ctx, account.ID, accounts.StatusChange{Reason: "credit received"})
+	if err != nil && !errors.Is(err, accounts.ErrAccountNotDormant) {
+		zapctx.L(ctx).Warn(
+			"transaction_service_account_not_reactivated",
+			zap.Error(err),
+			zap.String("account_id", account.ID.String()),
+		)
+	}
+}
+
+func (s service) checkMaxBalance(
+	ctx context.Context,
+	accountID uuid.UUID,
+	product products.Product,
+	amount float64,
+) error {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if !product.HasMaxBalance() {
+		return nil
+	}
+
+	accountBalance, err := s.balancesSvs.GetByAccountID(ctx, accountID)
+	if err != nil {
+		zapctx.L(ctx).Error("transaction_service_get_balance_error", zap.Error(err))
+		span.RecordError(err)
+		return ErrGetAccountBalance
+	}
+
+	if (accountBalance.CurrentBalance + amount) > product.MaxBalance {
+		zapctx.L(ctx).Error(
+			"transaction_service_max_balance_exceeded_error",
+			zap.Error(ErrMaxBalanceExceeded),
+			zap.String("account_id", accountID.String()),
+			zap.Float64("max_balance", product.MaxBalance),
+		)
+		span.RecordError(ErrMaxBalanceExceeded)
+		return ErrMaxBalanceExceeded
+	}
+
+	return nil
+}
+
+// createDebit refuses the debits above the approval threshold not approved yet, then evaluates the
+// debit against the risk rules before making it, holding the account lock so that concurrent debits
+// are evaluated one after the other. A Reviewable P2P the rules send to a manual review is parked
+// instead.
+func (s service) createDebit(
+	ctx context.Context,
+	transaction Transaction,
+	product products.Product,
+) (Transaction, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if err := s.checkApproval(ctx, transaction, product); err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	transactionAccountLockerKey := fmt.Sprintf("transaction-account-from-%s", transaction.From.String())
+	defer s.locker.Release(ctx, transactionAccountLockerKey)
+
+	if !s.locker.Acquire(ctx, transactionAccountLockerKey, debitLockTTL, 3) {
+		span.RecordError(ErrFailLockAccount)
+		return Transaction{}, ErrFailLockAccount
+	}
+
+	evaluation, err := s.checkRisk(ctx, transaction)
+	if errors.Is(err, ErrTransactionUnderReview) && transaction.Reviewable && transaction.Type == P2PTransaction {
+		return s.park(ctx, transaction, evaluation.ID, product)
+	}
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	return s.debit(ctx, transaction, product)
+}
+
+// debit makes the debit within the daily limit of the product, charging its fee. It must be called
+// holding the account lock.
+func (s service) debit(
+	ctx context.Context,
+	transaction Transaction,
+	product products.Product,
+) (Transaction, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	err := s.checkDebitLimit(ctx, transaction.From, transaction.Amount, product.DailyDebitLimit)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	fee, err := s.feesSvs.Quote(ctx, fees.Quote{
+		AccountID:       transaction.From,
+		TransactionType: string(transaction.Type),
+		ProductType:     product.Type,
+		Amount:          transaction.Amount,
+	})
+	if err != nil {
+		zapctx.L(ctx).Error("transaction_service_fee_quote_error", zap.Error(err))
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	var feeTransaction Transaction
+	if fee.Amount > 0 {
+		feeTransaction, err = s.feeTransaction(ctx, fee, transaction.Currency)
+		if err != nil {
+			span.RecordError(err)
+			return Transaction{}, err
+		}
+	}
+
+	transaction, err = s.fund(ctx, transaction, feeTransaction)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	err = s.updateDebitLimit(ctx, transaction.From, transaction.Amount)
+	if err != nil {
+		zapctx.L(ctx).Warn(
+			"transaction_service_transaction_not_considered_in_limit",
+			zap.Error(err),
+			zap.String("account_id", transaction.From.String()),
+			zap.Float64("amount", transaction.Amount),
+		)
+	}
+
+	return transaction, nil
+}
+
+// checkApproval refuses the debits from business accounts above the approval threshold, unless a
+// second principal already approved them. Zero disables the threshold.
+func (s service) checkApproval(ctx context.Context, transaction Transaction, product products.Product) error {
+	if transaction.Approved ||
+		s.approvalThreshold <= 0 ||
+		product.Type != products.BusinessType ||
+		transaction.Amount <= s.approvalThreshold {
+		return nil
+	}
+
+	zapctx.L(ctx).Warn(
+		"transaction_service_approval_required",
+		zap.String("account_id", transaction.From.String()),
+		zap.String("type", string(transaction.Type)),
+		zap.Float64("amount", transaction.Amount),
+	)
+
+	return ErrApprovalRequired
+}
+
+// checkRisk evaluates the debit against the risk rules, refusing it when a rule denies it or asks
+// for a review.
+func (s service) checkRisk(ctx context.Context, transaction Transaction) (risk.Evaluation, error) {
+	operation := risk.Operation{
+		AccountID:       transaction.From,
+		TransactionType: string(transaction.Type),
+		Amount:          transaction.Amount,
+	}
+	if transaction.Type == P2PTransaction {
+		operation.CounterpartyID = uuid.NullUUID{UUID: transaction.To, Valid: true}
+	}
+
+	evaluation, err := s.riskSvs.Evaluate(ctx, operation)
+	if err != nil {
+		zapctx.L(ctx).Error("transaction_service_risk_evaluation_error", zap.Error(err))
+		return risk.Evaluation{}, err
+	}
+
+	switch evaluation.Outcome {
+	case risk.DenyOutcome:
+		zapctx.L(ctx).Warn(
+			"transaction_service_risk_denied",
+			zap.String("evaluation_id", evaluation.ID.String()),
+			zap.String("account_id", transaction.From.String()),
+		)
+		return evaluation, ErrTransactionDenied
+	case risk.ReviewOutcome:
+		zapctx.L(ctx).Warn(
+			"transaction_service_risk_review",
+			zap.String("evaluation_id", evaluation.ID.String()),
+			zap.String("account_id", transaction.From.String()),
+		)
+		return evaluation, ErrTransactionUnderReview
+	}
+
+	return evaluation, nil
+}
+
+// park holds the funds of the P2P in a pending review, once the balance and the daily limit cover
+// it. The fee is charged when the review is approved and the P2P made. It must be called holding the
+// account lock.
+func (s service) park(
+	ctx context.Context,
+	transaction Transaction,
+	evaluationID uuid.UUID,
+	product products.Product,
+) (Transaction, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	err := s.checkDebitLimit(ctx, transaction.From, transaction.Amount, product.DailyDebitLimit)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	available, err := s.available(ctx, transaction.From)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	if available-transaction.Amount < 0 {
+		span.RecordError(ErrBalanceInsufficientFunds)
+		return Transaction{}, ErrBalanceInsufficientFunds
+	}
+
+	model, err := s.repository.CreateReview(
+		ctx,
+		newReviewModel(transaction, evaluationID, time.Now().UTC().Add(s.reviewSLA)),
+	)
+	if err != nil {
+		zapctx.L(ctx).Error("transaction_service_create_review_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	zapctx.L(ctx).Info(
+		"transaction_parked_for_review",
+		zap.String("review_id", model.ID.String()),
+		zap.String("evaluation_id", evaluationID.String()),
+		zap.String("account_id", transaction.From.String()),
+		zap.Float64("amount", transaction.Amount),
+		zap.Time("expires_at", model.ExpiresAt),
+	)
+
+	transaction.ReviewID = uuid.NullUUID{UUID: model.ID, Valid: true}
+
+	return transaction, nil
+}
+
+// createFunded stores a transaction that takes funds from the From account, holding the account lock
+// while checking the balance available after legal holds and pending reviews. A fee transaction with
+// an amount greater than zero is stored along with it, linked to it, and must also be covered by the
+// balance.
+func (s service) createFunded(ctx context.Context, transaction Transaction, fee Transaction) (Transaction, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	transactionAccountLockerKey := fmt.Sprintf("transaction-account-from-%s", transaction.From.String())
+	defer s.locker.Release(ctx, transactionAccountLockerKey)
+
+	if s.locker.Acquire(
+		ctx,
+		transactionAccountLockerKey,
+		50*time.Millisecond,
+		3,
+	) {
+		return s.fund(ctx, transaction, fee)
+	}
+
+	return Transaction{}, ErrFailLockAccount
+}
+
+// fund stores the transaction and its fee once the available balance covers both. It must be called
+// holding the account lock.
+func (s service) fund(ctx context.Context, transaction Transaction, fee Transaction) (Transaction, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	available, err := s.available(ctx, transaction.From)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	if (available - transaction.Amount - fee.Amount) < 0 {
+		return Transaction{}, ErrBalanceInsufficientFunds
+	}
+
+	model, err := s.store(ctx, transaction, fee)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	transaction.ID = model.ID
+	transaction.Fee = fee.Amount
+
+	return transaction, nil
+}
+
+// available is the balance of the account that may be moved. Legally held amounts and the P2Ps
+// pending a review must stay in the account, so only the remaining balance is available. It must be
+// called holding the account lock.
+func (s service) available(ctx context.Context, accountID uuid.UUID) (float64, error) {
+	accountBalance, err := s.balancesSvs.GetByAccountID(ctx, accountID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		zapctx.L(ctx).Error("transaction_service_get_balance_error", zap.Error(err))
+		return 0, ErrGetAccountBalance
+	}
+
+	heldAmount, err := s.accountsSvs.GetHeldAmount(ctx, accountID)
+	if err != nil {
+		zapctx.L(ctx).Error("transaction_service_get_held_amount_error", zap.Error(err))
+		return 0, ErrGetAccountHeldAmount
+	}
+
+	reviewedAmount, err := s.repository.SumPendingReviews(ctx, accountID)
+	if err != nil {
+		zapctx.L(ctx).Error("transaction_service_sum_pending_reviews_repository_error", zap.Error(err))
+		return 0, ErrGetAccountHeldAmount
+	}
+
+	return accountBalance.CurrentBalance - heldAmount - reviewedAmount, nil
+}
+
+// store inserts the transaction, along with its fee when there is one.
+func (s service) store(ctx context.Context, transaction Transaction, fee Transaction) (transactionModel, error) {
+	if fee.Amount <= 0 {
+		model, err := s.repository.Create(ctx, newTransactionModel(transaction))
+		if err != nil {
+			if errors.Is(err, errDuplicatedIdempotencyKey) {
+				return transactionModel{}, alreadyMade(transaction)
+			}
+			zapctx.L(ctx).Error("transaction_service_create_repository_error", zap.Error(err))
+			return transactionModel{}, err
+		}
+
+		return model, nil
+	}
+
+	model, _, err := s.repository.CreateWithFee(ctx, newTransactionModel(transaction), newTransactionModel(fee))
+	if err != nil {
+		if errors.Is(err, errDuplicatedIdempotencyKey) {
+			return transactionModel{}, alreadyMade(transaction)
+		}
+		zapctx.L(ctx).Error("transaction_service_create_with_fee_repository_error", zap.Error(err))
+		return transactionModel{}, err
+	}
+
+	return model, nil
+}
+
+// alreadyMade is the error of a transaction whose idempotency key was already used.
+func alreadyMade(transaction Transaction) error {
+	if transaction.Type == FeeTransaction {
+		return ErrFeeAlreadyCharged
+	}
+
+	return ErrTransactionAlreadyMade
+}
+
+// ChargeFee charges a fee not tied to a transaction, such as the monthly maintenance fee. Fees with an
+// idempotency key are charged only once.
+func (s service) ChargeFee(ctx context.Context, fee fees.Fee) (Transaction, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if fee.Amount <= 0 {
+		span.RecordError(ErrInvalidFeeAmount)
+		return Transaction{}, ErrInvalidFeeAmount
+	}
+
+	from, err := s.accountsSvs.GetByID(ctx, fee.AccountID)
+	if err != nil {
+		zapctx.L(ctx).Error(
+			"transaction_service_fee_account_error",
+			zap.Error(err),
+			zap.String("account_id", fee.AccountID.String()),
+		)
+		span.RecordError(err)
+		return Transaction{}, ErrAccountNotfound
+	}
+
+	transaction, err := s.feeTransaction(ctx, fee, from.Currency)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
+	}
 
-	err := s.checkAccount(ctx, transaction.To)
+	transaction, err = s.createFunded(ctx, transaction, Transaction{})
 	if err != nil {
 		span.RecordError(err)
 		return Transaction{}, err
 	}
 
-	return s.createCredit(ctx, transaction)
+	return transaction, nil
 }
 
-func (s service) CreateDebit(ctx context.Context, transaction Transaction) (Transaction, error) {
+// CloseAccount closes the account holding its lock, so no debit is made along with the closure. The
+// account must have no P2P pending a review nor anything else keeping it open, checked before its
+// balance moves through a P2P to the destination of the closure, which must take it like the To
+// account of any P2P.
+func (s service) CloseAccount(
+	ctx context.Context,
+	id uuid.UUID,
+	closure accounts.Closure,
+) (accounts.Account, error) {
 	ctx, span := s.tracer.Span(ctx)
 	defer span.End()
 
-	transaction.Type = DebitTransaction
+	if strings.TrimSpace(closure.Reason) == "" {
+		span.RecordError(accounts.ErrStatusReasonRequired)
+		return accounts.Account{}, accounts.ErrStatusReasonRequired
+	}
+
+	transactionAccountLockerKey := fmt.Sprintf("transaction-account-from-%s", id.String())
+	defer s.locker.Release(ctx, transactionAccountLockerKey)
+
+	if !s.locker.Acquire(ctx, transactionAccountLockerKey, 50*time.Millisecond, 3) {
+		span.RecordError(ErrFailLockAccount)
+		return accounts.Account{}, ErrFailLockAccount
+	}
 
-	err := s.checkAccount(ctx, transaction.From)
+	account, err := s.accountsSvs.GetByID(ctx, id)
 	if err != nil {
 		span.RecordError(err)
-		return Transaction{}, err
+		return accounts.Account{}, err
 	}
 
-	return s.createDebit(ctx, transaction)
-}
+	if account.Status == accounts.ClosedStatus {
+		return account, nil
+	}
 
-func (s service) CreateP2P(ctx context.Context, transaction Transaction) (Transaction, error) {
-	ctx, span := s.tracer.Span(ctx)
-	defer span.End()
+	err = s.accountsSvs.CheckClosable(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return accounts.Account{}, err
+	}
 
-	transaction.Type = P2PTransaction
+	reviewedAmount, err := s.repository.SumPendingReviews(ctx, id)
+	if err != nil {
+		zapctx.L(ctx).Error("transaction_service_sum_pending_reviews_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return accounts.Account{}, err
+	}
 
-	if transaction.From == transaction.To {
-		zapctx.L(ctx).Error(
-			"transaction_service_from_acccount_to_account_equal_error",
-			zap.Error(ErrFromAccountToAccountShouldBeDifferent),
-			zap.String("from", transaction.From.String()),
-			zap.String("to", transaction.To.String()),
-		)
-		span.RecordError(ErrFromAccountToAccountShouldBeDifferent)
-		return Transaction{}, ErrFromAccountToAccountShouldBeDifferent
+	if reviewedAmount > 0 {
+		span.RecordError(ErrAccountHasPendingReviews)
+		return accounts.Account{}, ErrAccountHasPendingReviews
 	}
 
-	err := s.checkAccount(ctx, transaction.From)
-	if err != nil {
+	accountBalance, err := s.balancesSvs.GetByAccountID(ctx, id)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		zapctx.L(ctx).Error("transaction_service_get_balance_error", zap.Error(err))
 		span.RecordError(err)
-		return Transaction{}, err
+		return accounts.Account{}, ErrGetAccountBalance
+	}
+
+	if accountBalance.CurrentBalance > 0 {
+		err := s.sweep(ctx, account, closure, accountBalance.CurrentBalance)
+		if err != nil {
+			span.RecordError(err)
+			return accounts.Account{}, err
+		}
 	}
 
-	err = s.checkAccount(ctx, transaction.To)
+	account, err = s.accountsSvs.CloseByID(ctx, id, closure)
 	if err != nil {
 		span.RecordError(err)
-		return Transaction{}, err
+		return accounts.Account{}, err
 	}
 
-	return s.createDebit(ctx, transaction)
+	return account, nil
 }
 
-func (s service) checkAccount(ctx context.Context, accountID uuid.UUID) error {
+// sweep moves the balance of the closing account to the destination of the closure. It must be
+// called holding the account lock.
+func (s service) sweep(ctx context.Context, account accounts.Account, closure accounts.Closure, amount float64) error {
 	ctx, span := s.tracer.Span(ctx)
 	defer span.End()
 
-	acc, err := s.accountsSvs.GetByID(ctx, accountID)
+	if !closure.DestinationID.Valid {
+		span.RecordError(accounts.ErrAccountBalanceNotZero)
+		return accounts.ErrAccountBalanceNotZero
+	}
+
+	if closure.DestinationID.UUID == account.ID {
+		span.RecordError(accounts.ErrInvalidClosureDestination)
+		return accounts.ErrInvalidClosureDestination
+	}
+
+	destination, destinationProduct, err := s.checkExternalAccount(
+		ctx,
+		closure.DestinationID.UUID,
+		closureDestinationStatuses,
+		accounts.CreditsRestriction,
+	)
+	if errors.Is(err, ErrAccountNotfound) || errors.Is(err, ErrAccountInactive) {
+		span.RecordError(accounts.ErrInvalidClosureDestination)
+		return accounts.ErrInvalidClosureDestination
+	}
 	if err != nil {
 		span.RecordError(err)
-
-		if !errors.Is(err, accounts.ErrAccountNotFound) {
-			zapctx.L(ctx).Error(
-				"transaction_service_acccount_check_error",
-				zap.Error(err),
-				zap.String("account_id", accountID.String()),
-			)
-		}
-		return ErrAccountNotfound
+		return err
 	}
 
-	if acc.Status != accounts.ActiveStatus {
+	if destination.Currency != account.Currency {
 		zapctx.L(ctx).Error(
-			"transaction_service_acccount_inactive_error",
-			zap.Error(ErrAccountInactive),
-			zap.String("account_id", accountID.String()),
+			"transaction_service_closure_currency_mismatch_error",
+			zap.Error(accounts.ErrClosureCurrencyMismatch),
+			zap.String("account_id", account.ID.String()),
+			zap.String("destination_id", destination.ID.String()),
 		)
-		span.RecordError(ErrAccountInactive)
-		return ErrAccountInactive
+		span.RecordError(accounts.ErrClosureCurrencyMismatch)
+		return accounts.ErrClosureCurrencyMismatch
+	}
+
+	err = s.checkMaxBalance(ctx, destination.ID, destinationProduct, amount)
+	if err != nil {
+		span.RecordError(err)
+		return err
 	}
 
+	model, err := s.store(ctx, Transaction{
+		From:        account.ID,
+		To:          destination.ID,
+		Type:        P2PTransaction,
+		Amount:      amount,
+		Currency:    account.Currency,
+		Description: "account closure: " + closure.Reason,
+	}, Transaction{})
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	zapctx.L(ctx).Info(
+		"transaction_closure_balance_swept",
+		zap.String("transaction_id", model.ID.String()),
+		zap.String("account_id", account.ID.String()),
+		zap.String("destination_id", destination.ID.String()),
+		zap.Float64("amount", amount),
+	)
+
 	return nil
 }
 
-func (s service) createDebit(ctx context.Context, transaction Transaction) (Transaction, error) {
+// feeTransaction builds the FEE transaction charging the fee, in the currency of the paying account,
+// converted when the revenue account holds another currency.
+func (s service) feeTransaction(ctx context.Context, fee fees.Fee, currency exchange.Currency) (Transaction, error) {
 	ctx, span := s.tracer.Span(ctx)
 	defer span.End()
 
-	err := s.checkDebitLimit(ctx, transaction.From, transaction.Amount)
+	revenue, err := s.accountsSvs.GetByID(ctx, fee.RevenueAccountID)
 	if err != nil {
+		zapctx.L(ctx).Error(
+			"transaction_service_fee_revenue_account_error",
+			zap.Error(err),
+			zap.String("account_id", fee.RevenueAccountID.String()),
+		)
 		span.RecordError(err)
-		return Transaction{}, err
+		return Transaction{}, ErrAccountNotfound
 	}
 
-	transactionAccountLockerKey := fmt.Sprintf("transaction-account-from-%s", transaction.From.String())
-	defer s.locker.Release(ctx, transactionAccountLockerKey)
-
-	if s.locker.Acquire(
-		ctx,
-		transactionAccountLockerKey,
-		50*time.Millisecond,
-		3,
-	) {
-		accountBalance, err := s.balancesSvs.GetByAccountID(ctx, transaction.From)
-		if err != nil && !errors.Is(err, sql.ErrNoRows) {
-			zapctx.L(ctx).Error("transaction_service_get_balance_error", zap.Error(err))
-			span.RecordError(err)
-			return Transaction{}, ErrGetAccountBalance
-		}
-
-		if (accountBalance.CurrentBalance - transaction.Amount) >= 0 {
-			model, err := s.repository.Create(ctx, newTransactionModel(transaction))
-			if err != nil {
-				zapctx.L(ctx).Error("transaction_service_create_repository_error", zap.Error(err))
-				span.RecordError(err)
-				return Transaction{}, err
-			}
-
-			transaction.ID = model.ID
-
-			err = s.updateDebitLimit(ctx, transaction.From, transaction.Amount)
-			if err != nil {
-				zapctx.L(ctx).Warn(
-					"transaction_service_transaction_not_considered_in_limit",
-					zap.Error(err),
-					zap.String("account_id", transaction.From.String()),
-					zap.Float64("amount", transaction.Amount),
-				)
-			}
-
-			return transaction, nil
-		}
-
-		return Transaction{}, ErrBalanceInsufficientFunds
+	transaction, err := s.convert(ctx, Transaction{
+		From:           fee.AccountID,
+		To:             fee.RevenueAccountID,
+		Type:           FeeTransaction,
+		Amount:         fee.Amount,
+		Currency:       currency,
+		Description:    fee.Description,
+		IdempotencyKey: fee.IdempotencyKey,
+	}, revenue.Currency)
+	if err != nil {
+		span.RecordError(err)
+		return Transaction{}, err
 	}
 
-	return Transaction{}, ErrFailLockAccount
+	return transaction, nil
 }
 
 func (s service) createCredit(ctx context.Context, transaction Transaction) (Transaction, error) {
@@ -219,8 +1292,11 @@ func (s service) createCredit(ctx context.Context, transaction Transaction) (Tra
 
 	model, err := s.repository.Create(ctx, newTransactionModel(transaction))
 	if err != nil {
-		zapctx.L(ctx).Error("transaction_service_create_repository_error", zap.Error(err))
 		span.RecordError(err)
+		if errors.Is(err, errDuplicatedIdempotencyKey) {
+			return Transaction{}, alreadyMade(transaction)
+		}
+		zapctx.L(ctx).Error("transaction_service_create_repository_error", zap.Error(err))
 		return Transaction{}, err
 	}
 
@@ -229,7 +1305,7 @@ func (s service) createCredit(ctx context.Context, transaction Transaction) (Tra
 	return transaction, nil
 }
 
-func (s service) checkDebitLimit(ctx context.Context, accountID uuid.UUID, amount float64) error {
+func (s service) checkDebitLimit(ctx context.Context, accountID uuid.UUID, amount, dailyLimit float64) error {
 	ctx, span := s.tracer.Span(ctx)
 	defer span.End()
 
@@ -239,7 +1315,7 @@ func (s service) checkDebitLimit(ctx context.Context, accountID uuid.UUID, amoun
 		return err
 	}
 
-	if (value + amount) > 2000 {
+	if (value + amount) > dailyLimit {
 		span.RecordError(ErrInsufficientDailyLimit)
 		return ErrInsufficientDailyLimit
 	}
@@ -334,3 +1410,322 @@ func (s service) GetByID(ctx context.Context, id uuid.UUID) (Transaction, error)
 
 	return newTransaction(models[0]), nil
 }
+
+// ListReviews lists a page of the reviews along with what analysts decide on: the rules each P2P hit,
+// the latest transactions of its account and the changes of the account status.
+func (s service) ListReviews(ctx context.Context, filter ReviewFilter) (int, []Review, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	total, models, err := s.repository.ListReviews(ctx, filter)
+	if err != nil {
+		zapctx.L(ctx).Error("transaction_service_list_reviews_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return 0, nil, err
+	}
+
+	if len(models) == 0 {
+		return total, []Review{}, nil
+	}
+
+	evaluationIDs := make([]uuid.UUID, len(models))
+	for i, model := range models {
+		evaluationIDs[i] = model.EvaluationID
+	}
+
+	_, evaluations, err := s.riskSvs.ListEvaluations(ctx, risk.EvaluationFilter{
+		IDs:  evaluationIDs,
+		Page: 1,
+		Size: len(evaluationIDs),
+	})
+	if err != nil {
+		zapctx.L(ctx).Error("transaction_service_list_reviews_evaluations_error", zap.Error(err))
+		span.RecordError(err)
+		return 0, nil, err
+	}
+
+	hits := make(map[uuid.UUID][]risk.Hit, len(evaluations))
+	for _, evaluation := range evaluations {
+		hits[evaluation.ID] = evaluation.Hits
+	}
+
+	histories := make(map[uuid.UUID][]Transaction)
+	statusHistories := make(map[uuid.UUID][]accounts.StatusEvent)
+	reviews := make([]Review, len(models))
+	for i, model := range models {
+		review := newReview(model)
+		review.Hits = hits[model.EvaluationID]
+
+		accountID := model.FromAccountID
+		if _, ok := histories[accountID]; !ok {
+			latest, err := s.repository.ListLatestByAccountID(ctx, accountID, reviewHistorySize)
+			if err != nil {
+				zapctx.L(ctx).Error("transaction_service_list_reviews_history_repository_error", zap.Error(err))
+				span.RecordError(err)
+				return 0, nil, err
+			}
+
+			histories[accountID] = make([]Transaction, len(latest))
+			for j, transaction := range latest {
+				histories[accountID][j] = newTransaction(transaction)
+			}
+
+			statusHistories[accountID], err = s.accountsSvs.ListStatusHistory(ctx, accountID)
+			if err != nil {
+				zapctx.L(ctx).Error("transaction_service_list_reviews_status_history_error", zap.Error(err))
+				span.RecordError(err)
+				return 0, nil, err
+			}
+		}
+
+		review.History = histories[accountID]
+		review.StatusHistory = statusHistories[accountID]
+		reviews[i] = review
+	}
+
+	return total, reviews, nil
+}
+
+// ApproveReview makes the P2P of a pending review, running again every check of P2Ps but the risk
+// rules. The P2P is made once, with the idempotency key of the review, and an approval that stopped
+// before making it may be retried. When the P2P cannot be made the review is pending again.
+func (s service) ApproveReview(ctx context.Context, id uuid.UUID, decision ReviewDecision) (Review, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if strings.TrimSpace(decision.Reason) == "" {
+		span.RecordError(ErrReviewReasonRequired)
+		return Review{}, ErrReviewReasonRequired
+	}
+
+	model, err := s.getReview(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return Review{}, err
+	}
+
+	switch {
+	case model.Status == PendingReview && !time.Now().Before(model.ExpiresAt):
+		s.expire(ctx, model)
+		span.RecordError(ErrReviewExpired)
+		return Review{}, ErrReviewExpired
+	case model.Status == PendingReview:
+	case model.Status == ApprovedReview && !model.TransactionID.Valid:
+		zapctx.L(ctx).Warn("transaction_service_approve_review_retried", zap.String("review_id", id.String()))
+	default:
+		span.RecordError(ErrReviewNotPending)
+		return Review{}, ErrReviewNotPending
+	}
+
+	_, err = s.makeReviewed(ctx, model, decision)
+	if err != nil && !errors.Is(err, ErrTransactionAlreadyMade) {
+		zapctx.L(ctx).Warn(
+			"transaction_service_approve_review_p2p_error",
+			zap.String("review_id", id.String()),
+			zap.Error(err),
+		)
+		span.RecordError(err)
+		return Review{}, err
+	}
+
+	model, err = s.getReview(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return Review{}, err
+	}
+
+	zapctx.L(ctx).Info(
+		"transaction_review_approved",
+		zap.String("review_id", id.String()),
+		zap.String("transaction_id", model.TransactionID.UUID.String()),
+		zap.String("decided_by", decision.Actor),
+		zap.String("reason", decision.Reason),
+	)
+
+	return newReview(model), nil
+}
+
+// makeReviewed makes the P2P of the review being approved. A pending review is approved holding the
+// account lock, right before the debit, so its funds are held by the pending reviews until the P2P is
+// made. The review is pending again when the P2P cannot be made.
+func (s service) makeReviewed(ctx context.Context, model reviewModel, decision ReviewDecision) (Transaction, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	transaction := newReview(model).Transaction
+	transaction.IdempotencyKey = reviewKey(model.ID)
+
+	transaction, fromProduct, to, err := s.checkP2P(ctx, transaction)
+	if err != nil {
+		s.reopenReview(ctx, model)
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	transactionAccountLockerKey := fmt.Sprintf("transaction-account-from-%s", transaction.From.String())
+	defer s.locker.Release(ctx, transactionAccountLockerKey)
+
+	if !s.locker.Acquire(ctx, transactionAccountLockerKey, debitLockTTL, 3) {
+		s.reopenReview(ctx, model)
+		span.RecordError(ErrFailLockAccount)
+		return Transaction{}, ErrFailLockAccount
+	}
+
+	if model.Status == PendingReview {
+		_, err = s.repository.DecideReview(ctx, reviewModel{
+			ID:             model.ID,
+			Status:         ApprovedReview,
+			DecidedBy:      decision.Actor,
+			DecisionReason: decision.Reason,
+		}, PendingReview)
+		if err != nil {
+			span.RecordError(err)
+			if errors.Is(err, errReviewStatusChanged) {
+				return Transaction{}, ErrReviewNotPending
+			}
+			zapctx.L(ctx).Error("transaction_service_approve_review_repository_error", zap.Error(err))
+			return Transaction{}, err
+		}
+		model.Status = ApprovedReview
+	}
+
+	transaction, err = s.debit(ctx, transaction, fromProduct)
+	if err != nil {
+		if !errors.Is(err, ErrTransactionAlreadyMade) {
+			s.reopenReview(ctx, model)
+		}
+		span.RecordError(err)
+		return Transaction{}, err
+	}
+
+	s.reactivateDormant(ctx, to)
+
+	return transaction, nil
+}
+
+// reopenReview makes the approved review pending again, its P2P could not be made.
+func (s service) reopenReview(ctx context.Context, model reviewModel) {
+	if model.Status != ApprovedReview {
+		return
+	}
+
+	_, err := s.repository.DecideReview(ctx, reviewModel{ID: model.ID, Status: PendingReview}, ApprovedReview)
+	if err != nil {
+		zapctx.L(ctx).Error("transaction_service_reopen_review_repository_error", zap.Error(err))
+	}
+}
+
+// RejectReview rejects the P2P of a pending review, releasing its funds.
+func (s service) RejectReview(ctx context.Context, id uuid.UUID, decision ReviewDecision) (Review, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	if strings.TrimSpace(decision.Reason) == "" {
+		span.RecordError(ErrReviewReasonRequired)
+		return Review{}, ErrReviewReasonRequired
+	}
+
+	model, err := s.getReview(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return Review{}, err
+	}
+
+	if model.Status != PendingReview {
+		span.RecordError(ErrReviewNotPending)
+		return Review{}, ErrReviewNotPending
+	}
+
+	model, err = s.repository.DecideReview(ctx, reviewModel{
+		ID:             id,
+		Status:         RejectedReview,
+		DecidedBy:      decision.Actor,
+		DecisionReason: decision.Reason,
+	}, PendingReview)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, errReviewStatusChanged) {
+			return Review{}, ErrReviewNotPending
+		}
+		zapctx.L(ctx).Error("transaction_service_reject_review_repository_error", zap.Error(err))
+		return Review{}, err
+	}
+
+	zapctx.L(ctx).Info(
+		"transaction_review_rejected",
+		zap.String("review_id", id.String()),
+		zap.String("decided_by", decision.Actor),
+		zap.String("reason", decision.Reason),
+	)
+
+	return newReview(model), nil
+}
+
+// ExpireReviews rejects the pending reviews not decided within the SLA, releasing their funds. It
+// returns how many reviews expired.
+func (s service) ExpireReviews(ctx context.Context) (int, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	models, err := s.repository.ListExpiredReviews(ctx, time.Now().UTC())
+	if err != nil {
+		zapctx.L(ctx).Error("transaction_service_list_expired_reviews_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return 0, err
+	}
+
+	var expired int
+	for _, model := range models {
+		if s.expire(ctx, model) {
+			expired++
+		}
+	}
+
+	return expired, nil
+}
+
+// expire rejects the pending review on behalf of the SLA, telling whether it did.
+func (s service) expire(ctx context.Context, model reviewModel) bool {
+	_, err := s.repository.DecideReview(ctx, reviewModel{
+		ID:             model.ID,
+		Status:         ExpiredReview,
+		DecidedBy:      reviewSLAActor,
+		DecisionReason: "the review was not decided within the SLA",
+	}, PendingReview)
+	if err != nil {
+		if !errors.Is(err, errReviewStatusChanged) {
+			zapctx.L(ctx).Error(
+				"transaction_service_expire_review_repository_error",
+				zap.String("review_id", model.ID.String()),
+				zap.Error(err),
+			)
+		}
+		return false
+	}
+
+	zapctx.L(ctx).Info(
+		"transaction_review_expired",
+		zap.String("review_id", model.ID.String()),
+		zap.String("account_id", model.FromAccountID.String()),
+		zap.Time("expires_at", model.ExpiresAt),
+	)
+
+	return true
+}
+
+func (s service) getReview(ctx context.Context, id uuid.UUID) (reviewModel, error) {
+	model, err := s.repository.GetReview(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return reviewModel{}, ErrReviewNotFound
+		}
+		zapctx.L(ctx).Error("transaction_service_get_review_repository_error", zap.Error(err))
+		return reviewModel{}, err
+	}
+
+	return model, nil
+}
+
+func reviewKey(id uuid.UUID) string {
+	return "review:" + id.String()
+}
@@ -0,0 +1,249 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/transactions/service.go
+
+// Package transactions is a generated GoMock package.
+package transactions
+
+import (
+	context "context"
+	reflect "reflect"
+
+	accounts "github.com/dalmarcogd/dock-test/internal/accounts"
+	fees "github.com/dalmarcogd/dock-test/internal/fees"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// ApproveReview mocks base method.
+func (m *MockService) ApproveReview(ctx context.Context, id uuid.UUID, decision ReviewDecision) (Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveReview", ctx, id, decision)
+	ret0, _ := ret[0].(Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveReview indicates an expected call of ApproveReview.
+func (mr *MockServiceMockRecorder) ApproveReview(ctx, id, decision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveReview", reflect.TypeOf((*MockService)(nil).ApproveReview), ctx, id, decision)
+}
+
+// ChargeFee mocks base method.
+func (m *MockService) ChargeFee(ctx context.Context, fee fees.Fee) (Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChargeFee", ctx, fee)
+	ret0, _ := ret[0].(Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChargeFee indicates an expected call of ChargeFee.
+func (mr *MockServiceMockRecorder) ChargeFee(ctx, fee interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargeFee", reflect.TypeOf((*MockService)(nil).ChargeFee), ctx, fee)
+}
+
+// CloseAccount mocks base method.
+func (m *MockService) CloseAccount(ctx context.Context, id uuid.UUID, closure accounts.Closure) (accounts.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseAccount", ctx, id, closure)
+	ret0, _ := ret[0].(accounts.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseAccount indicates an expected call of CloseAccount.
+func (mr *MockServiceMockRecorder) CloseAccount(ctx, id, closure interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAccount", reflect.TypeOf((*MockService)(nil).CloseAccount), ctx, id, closure)
+}
+
+// CreateCredit mocks base method.
+func (m *MockService) CreateCredit(ctx context.Context, transaction Transaction) (Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCredit", ctx, transaction)
+	ret0, _ := ret[0].(Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCredit indicates an expected call of CreateCredit.
+func (mr *MockServiceMockRecorder) CreateCredit(ctx, transaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCredit", reflect.TypeOf((*MockService)(nil).CreateCredit), ctx, transaction)
+}
+
+// CreateDebit mocks base method.
+func (m *MockService) CreateDebit(ctx context.Context, transaction Transaction) (Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDebit", ctx, transaction)
+	ret0, _ := ret[0].(Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDebit indicates an expected call of CreateDebit.
+func (mr *MockServiceMockRecorder) CreateDebit(ctx, transaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDebit", reflect.TypeOf((*MockService)(nil).CreateDebit), ctx, transaction)
+}
+
+// CreateInternal mocks base method.
+func (m *MockService) CreateInternal(ctx context.Context, transaction Transaction) (Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInternal", ctx, transaction)
+	ret0, _ := ret[0].(Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInternal indicates an expected call of CreateInternal.
+func (mr *MockServiceMockRecorder) CreateInternal(ctx, transaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInternal", reflect.TypeOf((*MockService)(nil).CreateInternal), ctx, transaction)
+}
+
+// CreateP2P mocks base method.
+func (m *MockService) CreateP2P(ctx context.Context, transaction Transaction) (Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateP2P", ctx, transaction)
+	ret0, _ := ret[0].(Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateP2P indicates an expected call of CreateP2P.
+func (mr *MockServiceMockRecorder) CreateP2P(ctx, transaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateP2P", reflect.TypeOf((*MockService)(nil).CreateP2P), ctx, transaction)
+}
+
+// CreateTED mocks base method.
+func (m *MockService) CreateTED(ctx context.Context, transaction Transaction) (Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTED", ctx, transaction)
+	ret0, _ := ret[0].(Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTED indicates an expected call of CreateTED.
+func (mr *MockServiceMockRecorder) CreateTED(ctx, transaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTED", reflect.TypeOf((*MockService)(nil).CreateTED), ctx, transaction)
+}
+
+// CreditInterest mocks base method.
+func (m *MockService) CreditInterest(ctx context.Context, transaction Transaction) (Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreditInterest", ctx, transaction)
+	ret0, _ := ret[0].(Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreditInterest indicates an expected call of CreditInterest.
+func (mr *MockServiceMockRecorder) CreditInterest(ctx, transaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreditInterest", reflect.TypeOf((*MockService)(nil).CreditInterest), ctx, transaction)
+}
+
+// ExpireReviews mocks base method.
+func (m *MockService) ExpireReviews(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireReviews", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireReviews indicates an expected call of ExpireReviews.
+func (mr *MockServiceMockRecorder) ExpireReviews(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireReviews", reflect.TypeOf((*MockService)(nil).ExpireReviews), ctx)
+}
+
+// GetByID mocks base method.
+func (m *MockService) GetByID(ctx context.Context, id uuid.UUID) (Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockServiceMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockService)(nil).GetByID), ctx, id)
+}
+
+// ListReviews mocks base method.
+func (m *MockService) ListReviews(ctx context.Context, filter ReviewFilter) (int, []Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReviews", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].([]Review)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListReviews indicates an expected call of ListReviews.
+func (mr *MockServiceMockRecorder) ListReviews(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviews", reflect.TypeOf((*MockService)(nil).ListReviews), ctx, filter)
+}
+
+// RejectReview mocks base method.
+func (m *MockService) RejectReview(ctx context.Context, id uuid.UUID, decision ReviewDecision) (Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectReview", ctx, id, decision)
+	ret0, _ := ret[0].(Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectReview indicates an expected call of RejectReview.
+func (mr *MockServiceMockRecorder) RejectReview(ctx, id, decision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectReview", reflect.TypeOf((*MockService)(nil).RejectReview), ctx, id, decision)
+}
+
+// ReverseTED mocks base method.
+func (m *MockService) ReverseTED(ctx context.Context, transaction Transaction) (Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReverseTED", ctx, transaction)
+	ret0, _ := ret[0].(Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReverseTED indicates an expected call of ReverseTED.
+func (mr *MockServiceMockRecorder) ReverseTED(ctx, transaction interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReverseTED", reflect.TypeOf((*MockService)(nil).ReverseTED), ctx, transaction)
+}
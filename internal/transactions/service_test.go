@@ -4,6 +4,8 @@ package transactions
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -11,6 +13,11 @@ import (
 	"github.com/brianvoe/gofakeit/v6"
 	"github.com/dalmarcogd/dock-test/internal/accounts"
 	"github.com/dalmarcogd/dock-test/internal/balances"
+	"github.com/dalmarcogd/dock-test/internal/exchange"
+	"github.com/dalmarcogd/dock-test/internal/fees"
+	"github.com/dalmarcogd/dock-test/internal/policies"
+	"github.com/dalmarcogd/dock-test/internal/products"
+	"github.com/dalmarcogd/dock-test/internal/risk"
 	"github.com/dalmarcogd/dock-test/pkg/distlock"
 	"github.com/dalmarcogd/dock-test/pkg/gomockeq"
 	"github.com/dalmarcogd/dock-test/pkg/redis"
@@ -21,6 +28,48 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+var checkingProduct = products.Product{
+	Type:            products.CheckingType,
+	DailyDebitLimit: 2000,
+	P2PAllowed:      true,
+}
+
+// noFees quotes every transaction free of charge.
+func noFees(ctrl *gomock.Controller) *fees.MockService {
+	feeSvcMock := fees.NewMockService(ctrl)
+	feeSvcMock.EXPECT().
+		Quote(gomock.Any(), gomock.Any()).
+		Return(fees.Fee{}, nil).
+		AnyTimes()
+
+	return feeSvcMock
+}
+
+// requester is the principal linked to the accounts the tests debit.
+const requester = "principal-id"
+
+// allowDebits allows the requester to debit every account.
+func allowDebits(ctrl *gomock.Controller) *policies.MockService {
+	policiesSvcMock := policies.NewMockService(ctrl)
+	policiesSvcMock.EXPECT().
+		AuthorizeDebit(gomock.Any(), requester, gomock.Any()).
+		Return(nil).
+		AnyTimes()
+
+	return policiesSvcMock
+}
+
+// allowRisk allows every transaction evaluated against the risk rules.
+func allowRisk(ctrl *gomock.Controller) *risk.MockService {
+	riskSvcMock := risk.NewMockService(ctrl)
+	riskSvcMock.EXPECT().
+		Evaluate(gomock.Any(), gomock.Any()).
+		Return(risk.Evaluation{Outcome: risk.AllowOutcome}, nil).
+		AnyTimes()
+
+	return riskSvcMock
+}
+
 func TestService_CreateCredit(t *testing.T) {
 	ctx := context.Background()
 	ctrl := gomock.NewController(t)
@@ -29,6 +78,7 @@ func TestService_CreateCredit(t *testing.T) {
 	repoMock := NewMockRepository(ctrl)
 	accSvcMock := accounts.NewMockService(ctrl)
 	blcSvcMock := balances.NewMockService(ctrl)
+	prdSvcMock := products.NewMockService(ctrl)
 	redisMock := redis.NewMockClient(ctrl)
 
 	svc := NewService(
@@ -37,9 +87,21 @@ func TestService_CreateCredit(t *testing.T) {
 		distlock.NewDistlockNoop(),
 		accSvcMock,
 		blcSvcMock,
+		prdSvcMock,
+		noFees(ctrl),
+		exchange.NewMockService(ctrl),
+		allowRisk(ctrl),
+		allowDebits(ctrl),
 		redisMock,
+		time.Hour,
+		0,
 	)
 
+	prdSvcMock.EXPECT().
+		GetByType(ctx, gomock.Any()).
+		Return(checkingProduct, nil).
+		AnyTimes()
+
 	accountID := uuid.New()
 
 	t.Run("fail transaction, account not found", func(t *testing.T) {
@@ -113,6 +175,123 @@ func TestService_CreateCredit(t *testing.T) {
 		assert.NoError(t, err, "the account related to the transaction must be active")
 		assert.NotEmpty(t, credit)
 	})
+
+	t.Run("fail transaction, idempotency key already used", func(t *testing.T) {
+		trx := Transaction{
+			To:             accountID,
+			Amount:         10,
+			Description:    gofakeit.BeerName(),
+			IdempotencyKey: "boleto",
+		}
+
+		accSvcMock.EXPECT().
+			GetByID(ctx, accountID).
+			Return(accounts.Account{Status: accounts.ActiveStatus}, nil)
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any()).
+			Return(transactionModel{}, errDuplicatedIdempotencyKey)
+
+		credit, err := svc.CreateCredit(ctx, trx)
+		assert.ErrorIs(t, err, ErrTransactionAlreadyMade)
+		assert.Empty(t, credit)
+	})
+}
+
+func TestService_CreditInterest(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	accSvcMock := accounts.NewMockService(ctrl)
+	prdSvcMock := products.NewMockService(ctrl)
+
+	svc := NewService(
+		tracer.NewNoop(),
+		repoMock,
+		distlock.NewDistlockNoop(),
+		accSvcMock,
+		balances.NewMockService(ctrl),
+		prdSvcMock,
+		noFees(ctrl),
+		exchange.NewMockService(ctrl),
+		allowRisk(ctrl),
+		allowDebits(ctrl),
+		redis.NewMockClient(ctrl),
+		time.Hour,
+		0,
+	)
+
+	prdSvcMock.EXPECT().
+		GetByType(ctx, gomock.Any()).
+		Return(checkingProduct, nil).
+		AnyTimes()
+
+	interestAccountID := uuid.New()
+	accountID := uuid.New()
+	trx := Transaction{
+		From:           interestAccountID,
+		To:             accountID,
+		Amount:         0.22,
+		Description:    "savings interest",
+		IdempotencyKey: "interest",
+	}
+
+	t.Run("fail credit, account closed", func(t *testing.T) {
+		accSvcMock.EXPECT().
+			GetByID(ctx, accountID).
+			Return(accounts.Account{ID: accountID, Status: accounts.ClosedStatus}, nil)
+
+		credit, err := svc.CreditInterest(ctx, trx)
+		assert.ErrorIs(t, err, ErrAccountInactive)
+		assert.Empty(t, credit)
+	})
+
+	t.Run("fail credit, account restricted from credits", func(t *testing.T) {
+		accSvcMock.EXPECT().
+			GetByID(ctx, accountID).
+			Return(accounts.Account{
+				ID:           accountID,
+				Status:       accounts.ActiveStatus,
+				Restrictions: []accounts.Restriction{accounts.CreditsRestriction},
+			}, nil)
+
+		credit, err := svc.CreditInterest(ctx, trx)
+		assert.ErrorIs(t, err, ErrAccountCreditsBlocked)
+		assert.Empty(t, credit)
+	})
+
+	t.Run("success credit, dormant pocket not reactivated", func(t *testing.T) {
+		accSvcMock.EXPECT().
+			GetByID(ctx, accountID).
+			Return(accounts.Account{
+				ID:       accountID,
+				ParentID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
+				Status:   accounts.DormantStatus,
+				Currency: exchange.DefaultCurrency,
+			}, nil)
+		repoMock.EXPECT().
+			Create(
+				ctx,
+				gomockeq.Eq(
+					transactionModel{
+						FromAccountID:  interestAccountID,
+						ToAccountID:    accountID,
+						Type:           CreditTransaction,
+						Amount:         trx.Amount,
+						Currency:       exchange.DefaultCurrency,
+						Description:    trx.Description,
+						IdempotencyKey: trx.IdempotencyKey,
+					},
+					gomockeq.IgnoreFields("ID", "CreatedAt"),
+				),
+			).
+			Return(transactionModel{ID: uuid.New()}, nil)
+
+		credit, err := svc.CreditInterest(ctx, trx)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, credit.ID)
+	})
 }
 
 func TestService_CreateDebit(t *testing.T) {
@@ -123,6 +302,7 @@ func TestService_CreateDebit(t *testing.T) {
 	repoMock := NewMockRepository(ctrl)
 	accSvcMock := accounts.NewMockService(ctrl)
 	blcSvcMock := balances.NewMockService(ctrl)
+	prdSvcMock := products.NewMockService(ctrl)
 	redisMock := redis.NewMockClient(ctrl)
 
 	svc := NewService(
@@ -131,13 +311,26 @@ func TestService_CreateDebit(t *testing.T) {
 		distlock.NewDistlockNoop(),
 		accSvcMock,
 		blcSvcMock,
+		prdSvcMock,
+		noFees(ctrl),
+		exchange.NewMockService(ctrl),
+		allowRisk(ctrl),
+		allowDebits(ctrl),
 		redisMock,
+		time.Hour,
+		0,
 	)
 
+	prdSvcMock.EXPECT().
+		GetByType(ctx, gomock.Any()).
+		Return(checkingProduct, nil).
+		AnyTimes()
+
 	accountID := uuid.New()
 
 	t.Run("fail transaction, account not found", func(t *testing.T) {
 		trx := Transaction{
+			RequestedBy: requester,
 			From:        accountID,
 			Amount:      10,
 			Description: gofakeit.BeerName(),
@@ -154,6 +347,7 @@ func TestService_CreateDebit(t *testing.T) {
 
 	t.Run("fail transaction, account inactive", func(t *testing.T) {
 		trx := Transaction{
+			RequestedBy: requester,
 			From:        accountID,
 			Amount:      10,
 			Description: gofakeit.BeerName(),
@@ -175,6 +369,7 @@ func TestService_CreateDebit(t *testing.T) {
 
 	t.Run("success transaction", func(t *testing.T) {
 		trx := Transaction{
+			RequestedBy: requester,
 			From:        accountID,
 			Amount:      10,
 			Description: gofakeit.BeerName(),
@@ -217,6 +412,8 @@ func TestService_CreateDebit(t *testing.T) {
 		).Return(redis2.NewStatusResult("10", nil))
 
 		blcSvcMock.EXPECT().GetByAccountID(ctx, accountID).Return(balances.AccountBalance{CurrentBalance: 1000}, nil)
+		accSvcMock.EXPECT().GetHeldAmount(ctx, accountID).Return(float64(0), nil)
+		repoMock.EXPECT().SumPendingReviews(ctx, accountID).Return(float64(0), nil)
 
 		repoMock.EXPECT().
 			Create(
@@ -238,6 +435,382 @@ func TestService_CreateDebit(t *testing.T) {
 	})
 }
 
+func TestService_Risk(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accSvcMock := accounts.NewMockService(ctrl)
+	prdSvcMock := products.NewMockService(ctrl)
+	riskSvcMock := risk.NewMockService(ctrl)
+
+	svc := NewService(
+		tracer.NewNoop(),
+		NewMockRepository(ctrl),
+		distlock.NewDistlockNoop(),
+		accSvcMock,
+		balances.NewMockService(ctrl),
+		prdSvcMock,
+		noFees(ctrl),
+		exchange.NewMockService(ctrl),
+		riskSvcMock,
+		allowDebits(ctrl),
+		redis.NewMockClient(ctrl),
+		time.Hour,
+		0,
+	)
+
+	prdSvcMock.EXPECT().
+		GetByType(ctx, gomock.Any()).
+		Return(checkingProduct, nil).
+		AnyTimes()
+
+	accountID := uuid.New()
+	accSvcMock.EXPECT().
+		GetByID(ctx, accountID).
+		Return(accounts.Account{ID: accountID, Status: accounts.ActiveStatus}, nil).
+		AnyTimes()
+
+	trx := Transaction{
+		RequestedBy: requester,
+		From:        accountID,
+		Amount:      5000,
+		Description: gofakeit.BeerName(),
+	}
+
+	for _, tt := range []struct {
+		name    string
+		outcome risk.Outcome
+		err     error
+		wantErr error
+	}{
+		{"fail transaction, denied by a rule", risk.DenyOutcome, nil, ErrTransactionDenied},
+		{"fail transaction, review asked by a rule", risk.ReviewOutcome, nil, ErrTransactionUnderReview},
+		{"fail transaction, rules not evaluated", "", errors.New("connection refused"), nil},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			riskSvcMock.EXPECT().
+				Evaluate(ctx, risk.Operation{
+					AccountID:       accountID,
+					TransactionType: string(DebitTransaction),
+					Amount:          trx.Amount,
+				}).
+				Return(risk.Evaluation{Outcome: tt.outcome}, tt.err)
+
+			debit, err := svc.CreateDebit(ctx, trx)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.ErrorIs(t, err, tt.err)
+			}
+			assert.Empty(t, debit)
+		})
+	}
+}
+
+func TestService_ApprovalThreshold(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accSvcMock := accounts.NewMockService(ctrl)
+	prdSvcMock := products.NewMockService(ctrl)
+	riskSvcMock := risk.NewMockService(ctrl)
+
+	svc := NewService(
+		tracer.NewNoop(),
+		NewMockRepository(ctrl),
+		distlock.NewDistlockNoop(),
+		accSvcMock,
+		balances.NewMockService(ctrl),
+		prdSvcMock,
+		noFees(ctrl),
+		exchange.NewMockService(ctrl),
+		riskSvcMock,
+		allowDebits(ctrl),
+		redis.NewMockClient(ctrl),
+		time.Hour,
+		1000,
+	)
+
+	businessID := uuid.New()
+	checkingID := uuid.New()
+	for _, account := range []accounts.Account{
+		{ID: businessID, Type: products.BusinessType, Status: accounts.ActiveStatus},
+		{ID: checkingID, Type: products.CheckingType, Status: accounts.ActiveStatus},
+	} {
+		accSvcMock.EXPECT().GetByID(ctx, account.ID).Return(account, nil).AnyTimes()
+		prdSvcMock.EXPECT().
+			GetByType(ctx, account.Type).
+			Return(products.Product{Type: account.Type, DailyDebitLimit: 100000}, nil).
+			AnyTimes()
+	}
+
+	t.Run("fail debit, business account above the threshold", func(t *testing.T) {
+		debit, err := svc.CreateDebit(ctx, Transaction{RequestedBy: requester, From: businessID, Amount: 1000.01})
+		assert.ErrorIs(t, err, ErrApprovalRequired)
+		assert.Empty(t, debit)
+	})
+
+	// the debits let through the threshold are then refused by the risk rules.
+	for _, tt := range []struct {
+		name        string
+		transaction Transaction
+	}{
+		{
+			"business account at the threshold",
+			Transaction{RequestedBy: requester, From: businessID, Amount: 1000},
+		},
+		{
+			"business account above the threshold, approved",
+			Transaction{RequestedBy: requester, From: businessID, Amount: 5000, Approved: true},
+		},
+		{
+			"other accounts above the threshold",
+			Transaction{RequestedBy: requester, From: checkingID, Amount: 5000},
+		},
+	} {
+		tt := tt
+		t.Run("debit not gated, "+tt.name, func(t *testing.T) {
+			riskSvcMock.EXPECT().
+				Evaluate(ctx, gomock.Any()).
+				Return(risk.Evaluation{Outcome: risk.DenyOutcome}, nil)
+
+			debit, err := svc.CreateDebit(ctx, tt.transaction)
+			assert.ErrorIs(t, err, ErrTransactionDenied)
+			assert.Empty(t, debit)
+		})
+	}
+}
+
+func TestService_RiskUnderLock(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	accSvcMock := accounts.NewMockService(ctrl)
+	prdSvcMock := products.NewMockService(ctrl)
+	riskSvcMock := risk.NewMockService(ctrl)
+	lockMock := distlock.NewMockDistLock(ctrl)
+
+	svc := NewService(
+		tracer.NewNoop(),
+		NewMockRepository(ctrl),
+		lockMock,
+		accSvcMock,
+		balances.NewMockService(ctrl),
+		prdSvcMock,
+		noFees(ctrl),
+		exchange.NewMockService(ctrl),
+		riskSvcMock,
+		allowDebits(ctrl),
+		redis.NewMockClient(ctrl),
+		time.Hour,
+		0,
+	)
+
+	prdSvcMock.EXPECT().
+		GetByType(ctx, gomock.Any()).
+		Return(checkingProduct, nil).
+		AnyTimes()
+
+	accountID := uuid.New()
+	accSvcMock.EXPECT().
+		GetByID(ctx, accountID).
+		Return(accounts.Account{ID: accountID, Status: accounts.ActiveStatus}, nil).
+		AnyTimes()
+
+	lockKey := fmt.Sprintf("transaction-account-from-%s", accountID.String())
+	trx := Transaction{
+		RequestedBy: requester,
+		From:        accountID,
+		Amount:      500,
+		Description: gofakeit.BeerName(),
+	}
+
+	t.Run("fail transaction, rules evaluated holding the account lock", func(t *testing.T) {
+		gomock.InOrder(
+			lockMock.EXPECT().Acquire(gomock.Any(), lockKey, debitLockTTL, gomock.Any()).Return(true),
+			riskSvcMock.EXPECT().
+				Evaluate(gomock.Any(), gomock.Any()).
+				Return(risk.Evaluation{Outcome: risk.DenyOutcome}, nil),
+			lockMock.EXPECT().Release(gomock.Any(), lockKey).Return(true),
+		)
+
+		debit, err := svc.CreateDebit(ctx, trx)
+		assert.ErrorIs(t, err, ErrTransactionDenied)
+		assert.Empty(t, debit)
+	})
+
+	t.Run("fail transaction, rules not evaluated without the account lock", func(t *testing.T) {
+		lockMock.EXPECT().Acquire(gomock.Any(), lockKey, gomock.Any(), gomock.Any()).Return(false)
+		lockMock.EXPECT().Release(gomock.Any(), lockKey).Return(false)
+
+		debit, err := svc.CreateDebit(ctx, trx)
+		assert.ErrorIs(t, err, ErrFailLockAccount)
+		assert.Empty(t, debit)
+	})
+}
+
+func TestService_TED(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	accSvcMock := accounts.NewMockService(ctrl)
+	blcSvcMock := balances.NewMockService(ctrl)
+	prdSvcMock := products.NewMockService(ctrl)
+	redisMock := redis.NewMockClient(ctrl)
+
+	svc := NewService(
+		tracer.NewNoop(),
+		repoMock,
+		distlock.NewDistlockNoop(),
+		accSvcMock,
+		blcSvcMock,
+		prdSvcMock,
+		noFees(ctrl),
+		exchange.NewMockService(ctrl),
+		allowRisk(ctrl),
+		allowDebits(ctrl),
+		redisMock,
+		time.Hour,
+		0,
+	)
+
+	prdSvcMock.EXPECT().
+		GetByType(ctx, gomock.Any()).
+		Return(checkingProduct, nil).
+		AnyTimes()
+
+	accountID := uuid.New()
+	clearingID := uuid.New()
+
+	t.Run("fail ted, account restricted from debits", func(t *testing.T) {
+		accSvcMock.EXPECT().
+			GetByID(ctx, accountID).
+			Return(
+				accounts.Account{
+					Status:       accounts.ActiveStatus,
+					Restrictions: []accounts.Restriction{accounts.DebitsRestriction},
+				},
+				nil,
+			)
+
+		trx, err := svc.CreateTED(ctx, Transaction{From: accountID, To: clearingID, Amount: 10, RequestedBy: requester})
+		assert.ErrorIs(t, err, ErrAccountDebitsBlocked)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("fail ted, insufficient funds", func(t *testing.T) {
+		accSvcMock.EXPECT().GetByID(ctx, accountID).Return(accounts.Account{Status: accounts.ActiveStatus}, nil)
+
+		redReturn := redis2.NewStringCmd(ctx)
+		redReturn.SetErr(redis2.Nil)
+		redisMock.EXPECT().
+			Get(ctx, fmt.Sprintf("transactions-debit-%s", accountID.String())).
+			Return(redReturn)
+
+		blcSvcMock.EXPECT().GetByAccountID(ctx, accountID).Return(balances.AccountBalance{CurrentBalance: 5}, nil)
+		accSvcMock.EXPECT().GetHeldAmount(ctx, accountID).Return(float64(0), nil)
+		repoMock.EXPECT().SumPendingReviews(ctx, accountID).Return(float64(0), nil)
+
+		trx, err := svc.CreateTED(ctx, Transaction{From: accountID, To: clearingID, Amount: 10, RequestedBy: requester})
+		assert.ErrorIs(t, err, ErrBalanceInsufficientFunds)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("success ted", func(t *testing.T) {
+		accSvcMock.EXPECT().GetByID(ctx, accountID).Return(accounts.Account{Status: accounts.ActiveStatus}, nil)
+
+		redReturn := redis2.NewStringCmd(ctx)
+		redReturn.SetErr(redis2.Nil)
+		redisMock.EXPECT().
+			Get(ctx, fmt.Sprintf("transactions-debit-%s", accountID.String())).
+			Return(redReturn).
+			Times(2)
+		redisMock.EXPECT().
+			SetArgs(ctx, fmt.Sprintf("transactions-debit-%s", accountID.String()), float64(10), gomock.Any()).
+			Return(redis2.NewStatusResult("10", nil))
+
+		blcSvcMock.EXPECT().GetByAccountID(ctx, accountID).Return(balances.AccountBalance{CurrentBalance: 100}, nil)
+		accSvcMock.EXPECT().GetHeldAmount(ctx, accountID).Return(float64(0), nil)
+		repoMock.EXPECT().SumPendingReviews(ctx, accountID).Return(float64(0), nil)
+
+		transactionID := uuid.New()
+		repoMock.EXPECT().
+			Create(
+				ctx,
+				gomockeq.Eq(
+					transactionModel{
+						FromAccountID:  accountID,
+						ToAccountID:    clearingID,
+						Type:           TEDTransaction,
+						Amount:         10,
+						IdempotencyKey: "transfer",
+					},
+					gomockeq.IgnoreFields("ID", "CreatedAt"),
+				),
+			).
+			Return(transactionModel{ID: transactionID}, nil)
+
+		trx, err := svc.CreateTED(ctx, Transaction{
+			From:           accountID,
+			To:             clearingID,
+			Amount:         10,
+			IdempotencyKey: "transfer",
+			RequestedBy:    requester,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, transactionID, trx.ID)
+	})
+
+	t.Run("fail reversal, missing idempotency key", func(t *testing.T) {
+		trx, err := svc.ReverseTED(ctx, Transaction{From: clearingID, To: accountID, Amount: 10})
+		assert.ErrorIs(t, err, ErrMissingIdempotencyKey)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("fail reversal, already reversed", func(t *testing.T) {
+		repoMock.EXPECT().Create(ctx, gomock.Any()).Return(transactionModel{}, errDuplicatedIdempotencyKey)
+
+		trx, err := svc.ReverseTED(
+			ctx,
+			Transaction{From: clearingID, To: accountID, Amount: 10, IdempotencyKey: "transfer-reversal"},
+		)
+		assert.ErrorIs(t, err, ErrTransactionAlreadyMade)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("success reversal", func(t *testing.T) {
+		transactionID := uuid.New()
+		repoMock.EXPECT().
+			Create(
+				ctx,
+				gomockeq.Eq(
+					transactionModel{
+						FromAccountID:  clearingID,
+						ToAccountID:    accountID,
+						Type:           TEDReversalTransaction,
+						Amount:         10,
+						IdempotencyKey: "transfer-reversal",
+					},
+					gomockeq.IgnoreFields("ID", "CreatedAt"),
+				),
+			).
+			Return(transactionModel{ID: transactionID}, nil)
+
+		trx, err := svc.ReverseTED(
+			ctx,
+			Transaction{From: clearingID, To: accountID, Amount: 10, IdempotencyKey: "transfer-reversal"},
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, transactionID, trx.ID)
+	})
+}
+
 func TestService_CreateP2P(t *testing.T) {
 	ctx := context.Background()
 	ctrl := gomock.NewController(t)
@@ -246,6 +819,7 @@ func TestService_CreateP2P(t *testing.T) {
 	repoMock := NewMockRepository(ctrl)
 	accSvcMock := accounts.NewMockService(ctrl)
 	blcSvcMock := balances.NewMockService(ctrl)
+	prdSvcMock := products.NewMockService(ctrl)
 	redisMock := redis.NewMockClient(ctrl)
 
 	svc := NewService(
@@ -254,14 +828,27 @@ func TestService_CreateP2P(t *testing.T) {
 		distlock.NewDistlockNoop(),
 		accSvcMock,
 		blcSvcMock,
+		prdSvcMock,
+		noFees(ctrl),
+		exchange.NewMockService(ctrl),
+		allowRisk(ctrl),
+		allowDebits(ctrl),
 		redisMock,
+		time.Hour,
+		0,
 	)
 
+	prdSvcMock.EXPECT().
+		GetByType(ctx, gomock.Any()).
+		Return(checkingProduct, nil).
+		AnyTimes()
+
 	accountID1 := uuid.New()
 	accountID2 := uuid.New()
 
 	t.Run("fail transaction, account inactive", func(t *testing.T) {
 		trx := Transaction{
+			RequestedBy: requester,
 			From:        accountID1,
 			To:          accountID2,
 			Amount:      10,
@@ -293,6 +880,7 @@ func TestService_CreateP2P(t *testing.T) {
 
 	t.Run("success transaction", func(t *testing.T) {
 		trx := Transaction{
+			RequestedBy: requester,
 			From:        accountID1,
 			To:          accountID2,
 			Amount:      10,
@@ -344,6 +932,8 @@ func TestService_CreateP2P(t *testing.T) {
 		).Return(redis2.NewStatusResult("10", nil))
 
 		blcSvcMock.EXPECT().GetByAccountID(ctx, accountID1).Return(balances.AccountBalance{CurrentBalance: 1000}, nil)
+		accSvcMock.EXPECT().GetHeldAmount(ctx, accountID1).Return(float64(0), nil)
+		repoMock.EXPECT().SumPendingReviews(ctx, accountID1).Return(float64(0), nil)
 
 		repoMock.EXPECT().
 			Create(
@@ -365,3 +955,1379 @@ func TestService_CreateP2P(t *testing.T) {
 		assert.NotEmpty(t, credit)
 	})
 }
+
+func TestService_ProductRules(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	accSvcMock := accounts.NewMockService(ctrl)
+	blcSvcMock := balances.NewMockService(ctrl)
+	prdSvcMock := products.NewMockService(ctrl)
+	redisMock := redis.NewMockClient(ctrl)
+
+	svc := NewService(
+		tracer.NewNoop(),
+		repoMock,
+		distlock.NewDistlockNoop(),
+		accSvcMock,
+		blcSvcMock,
+		prdSvcMock,
+		noFees(ctrl),
+		exchange.NewMockService(ctrl),
+		allowRisk(ctrl),
+		allowDebits(ctrl),
+		redisMock,
+		time.Hour,
+		0,
+	)
+
+	savingsProduct := products.Product{
+		Type:            products.SavingsType,
+		DailyDebitLimit: 1000,
+		P2PAllowed:      false,
+	}
+	paymentProduct := products.Product{
+		Type:            products.PaymentType,
+		DailyDebitLimit: 1000,
+		P2PAllowed:      true,
+		MaxBalance:      5000,
+	}
+
+	prdSvcMock.EXPECT().GetByType(ctx, products.CheckingType).Return(checkingProduct, nil).AnyTimes()
+	prdSvcMock.EXPECT().GetByType(ctx, products.SavingsType).Return(savingsProduct, nil).AnyTimes()
+	prdSvcMock.EXPECT().GetByType(ctx, products.PaymentType).Return(paymentProduct, nil).AnyTimes()
+
+	checkingID := uuid.New()
+	savingsID := uuid.New()
+	paymentID := uuid.New()
+
+	accSvcMock.EXPECT().
+		GetByID(ctx, checkingID).
+		Return(accounts.Account{ID: checkingID, Type: products.CheckingType, Status: accounts.ActiveStatus}, nil).
+		AnyTimes()
+	accSvcMock.EXPECT().
+		GetByID(ctx, savingsID).
+		Return(accounts.Account{ID: savingsID, Type: products.SavingsType, Status: accounts.ActiveStatus}, nil).
+		AnyTimes()
+	accSvcMock.EXPECT().
+		GetByID(ctx, paymentID).
+		Return(accounts.Account{ID: paymentID, Type: products.PaymentType, Status: accounts.ActiveStatus}, nil).
+		AnyTimes()
+
+	t.Run("fail p2p, product does not allow p2p", func(t *testing.T) {
+		trx, err := svc.CreateP2P(ctx, Transaction{From: savingsID, To: checkingID, Amount: 10, RequestedBy: requester})
+		assert.ErrorIs(t, err, ErrP2PNotAllowed)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("fail credit, product max balance exceeded", func(t *testing.T) {
+		blcSvcMock.EXPECT().
+			GetByAccountID(ctx, paymentID).
+			Return(balances.AccountBalance{AccountID: paymentID, CurrentBalance: 4990}, nil)
+
+		trx, err := svc.CreateCredit(ctx, Transaction{To: paymentID, Amount: 20})
+		assert.ErrorIs(t, err, ErrMaxBalanceExceeded)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("fail debit, product daily limit exceeded", func(t *testing.T) {
+		redReturn := redis2.NewStringCmd(ctx)
+		redReturn.SetVal("900")
+		redisMock.EXPECT().
+			Get(ctx, fmt.Sprintf("transactions-debit-%s", savingsID.String())).
+			Return(redReturn)
+
+		trx, err := svc.CreateDebit(ctx, Transaction{From: savingsID, Amount: 200, RequestedBy: requester})
+		assert.ErrorIs(t, err, ErrInsufficientDailyLimit)
+		assert.Empty(t, trx)
+	})
+}
+
+func TestService_Restrictions(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	accSvcMock := accounts.NewMockService(ctrl)
+	blcSvcMock := balances.NewMockService(ctrl)
+	prdSvcMock := products.NewMockService(ctrl)
+	redisMock := redis.NewMockClient(ctrl)
+
+	svc := NewService(
+		tracer.NewNoop(),
+		repoMock,
+		distlock.NewDistlockNoop(),
+		accSvcMock,
+		blcSvcMock,
+		prdSvcMock,
+		noFees(ctrl),
+		exchange.NewMockService(ctrl),
+		allowRisk(ctrl),
+		allowDebits(ctrl),
+		redisMock,
+		time.Hour,
+		0,
+	)
+
+	prdSvcMock.EXPECT().
+		GetByType(ctx, gomock.Any()).
+		Return(checkingProduct, nil).
+		AnyTimes()
+
+	debitsBlockedID := uuid.New()
+	creditsBlockedID := uuid.New()
+	p2pOutBlockedID := uuid.New()
+	heldID := uuid.New()
+
+	accSvcMock.EXPECT().
+		GetByID(ctx, debitsBlockedID).
+		Return(accounts.Account{
+			ID:           debitsBlockedID,
+			Status:       accounts.ActiveStatus,
+			Restrictions: []accounts.Restriction{accounts.DebitsRestriction},
+		}, nil).
+		AnyTimes()
+	accSvcMock.EXPECT().
+		GetByID(ctx, creditsBlockedID).
+		Return(accounts.Account{
+			ID:           creditsBlockedID,
+			Status:       accounts.ActiveStatus,
+			Restrictions: []accounts.Restriction{accounts.CreditsRestriction},
+		}, nil).
+		AnyTimes()
+	accSvcMock.EXPECT().
+		GetByID(ctx, p2pOutBlockedID).
+		Return(accounts.Account{
+			ID:           p2pOutBlockedID,
+			Status:       accounts.ActiveStatus,
+			Restrictions: []accounts.Restriction{accounts.P2POutRestriction},
+		}, nil).
+		AnyTimes()
+	accSvcMock.EXPECT().
+		GetByID(ctx, heldID).
+		Return(accounts.Account{ID: heldID, Status: accounts.ActiveStatus}, nil).
+		AnyTimes()
+
+	t.Run("fail debit, debits blocked", func(t *testing.T) {
+		trx, err := svc.CreateDebit(ctx, Transaction{From: debitsBlockedID, Amount: 10, RequestedBy: requester})
+		assert.ErrorIs(t, err, ErrAccountDebitsBlocked)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("success credit, debits blocked", func(t *testing.T) {
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any()).
+			Return(transactionModel{ID: uuid.New()}, nil)
+
+		trx, err := svc.CreateCredit(ctx, Transaction{To: debitsBlockedID, Amount: 10})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, trx)
+	})
+
+	t.Run("fail credit, credits blocked", func(t *testing.T) {
+		trx, err := svc.CreateCredit(ctx, Transaction{To: creditsBlockedID, Amount: 10})
+		assert.ErrorIs(t, err, ErrAccountCreditsBlocked)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("fail p2p, p2p out blocked", func(t *testing.T) {
+		trx, err := svc.CreateP2P(ctx, Transaction{From: p2pOutBlockedID, To: heldID, Amount: 10, RequestedBy: requester})
+		assert.ErrorIs(t, err, ErrAccountP2POutBlocked)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("fail p2p, destination credits blocked", func(t *testing.T) {
+		trx, err := svc.CreateP2P(ctx, Transaction{From: heldID, To: creditsBlockedID, Amount: 10, RequestedBy: requester})
+		assert.ErrorIs(t, err, ErrAccountCreditsBlocked)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("fail debit, balance under legal hold", func(t *testing.T) {
+		redReturn := redis2.NewStringCmd(ctx)
+		redReturn.SetErr(redis2.Nil)
+		redisMock.EXPECT().
+			Get(ctx, fmt.Sprintf("transactions-debit-%s", heldID.String())).
+			Return(redReturn)
+		blcSvcMock.EXPECT().GetByAccountID(ctx, heldID).Return(balances.AccountBalance{CurrentBalance: 100}, nil)
+		accSvcMock.EXPECT().GetHeldAmount(ctx, heldID).Return(float64(80), nil)
+		repoMock.EXPECT().SumPendingReviews(ctx, heldID).Return(float64(0), nil)
+
+		trx, err := svc.CreateDebit(ctx, Transaction{From: heldID, Amount: 30, RequestedBy: requester})
+		assert.ErrorIs(t, err, ErrBalanceInsufficientFunds)
+		assert.Empty(t, trx)
+	})
+}
+
+func TestService_Dormancy(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	accSvcMock := accounts.NewMockService(ctrl)
+	blcSvcMock := balances.NewMockService(ctrl)
+	prdSvcMock := products.NewMockService(ctrl)
+	redisMock := redis.NewMockClient(ctrl)
+
+	svc := NewService(
+		tracer.NewNoop(),
+		repoMock,
+		distlock.NewDistlockNoop(),
+		accSvcMock,
+		blcSvcMock,
+		prdSvcMock,
+		noFees(ctrl),
+		exchange.NewMockService(ctrl),
+		allowRisk(ctrl),
+		allowDebits(ctrl),
+		redisMock,
+		time.Hour,
+		0,
+	)
+
+	prdSvcMock.EXPECT().
+		GetByType(ctx, gomock.Any()).
+		Return(checkingProduct, nil).
+		AnyTimes()
+
+	dormantID := uuid.New()
+
+	accSvcMock.EXPECT().
+		GetByID(ctx, dormantID).
+		Return(accounts.Account{ID: dormantID, Status: accounts.DormantStatus}, nil).
+		AnyTimes()
+
+	t.Run("fail debit, account dormant", func(t *testing.T) {
+		trx, err := svc.CreateDebit(ctx, Transaction{From: dormantID, Amount: 10, RequestedBy: requester})
+		assert.ErrorIs(t, err, ErrAccountInactive)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("success credit, reactivating dormant account", func(t *testing.T) {
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any()).
+			Return(transactionModel{ID: uuid.New()}, nil)
+		accSvcMock.EXPECT().
+			ReactivateByID(ctx, dormantID, accounts.StatusChange{Reason: "credit received"}).
+			Return(accounts.Account{ID: dormantID, Status: accounts.ActiveStatus}, nil)
+
+		trx, err := svc.CreateCredit(ctx, Transaction{To: dormantID, Amount: 10})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, trx)
+	})
+
+	t.Run("success credit, keeping the credit when reactivation fails", func(t *testing.T) {
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any()).
+			Return(transactionModel{ID: uuid.New()}, nil)
+		accSvcMock.EXPECT().
+			ReactivateByID(ctx, dormantID, accounts.StatusChange{Reason: "credit received"}).
+			Return(accounts.Account{}, errors.New("connection refused"))
+
+		trx, err := svc.CreateCredit(ctx, Transaction{To: dormantID, Amount: 10})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, trx)
+	})
+}
+
+func TestService_DebitRoles(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	accSvcMock := accounts.NewMockService(ctrl)
+	blcSvcMock := balances.NewMockService(ctrl)
+	prdSvcMock := products.NewMockService(ctrl)
+	plcSvcMock := policies.NewMockService(ctrl)
+	redisMock := redis.NewMockClient(ctrl)
+
+	svc := NewService(
+		tracer.NewNoop(),
+		repoMock,
+		distlock.NewDistlockNoop(),
+		accSvcMock,
+		blcSvcMock,
+		prdSvcMock,
+		noFees(ctrl),
+		exchange.NewMockService(ctrl),
+		allowRisk(ctrl),
+		plcSvcMock,
+		redisMock,
+		time.Hour,
+		0,
+	)
+
+	prdSvcMock.EXPECT().
+		GetByType(ctx, gomock.Any()).
+		Return(checkingProduct, nil).
+		AnyTimes()
+
+	accountID := uuid.New()
+	toID := uuid.New()
+
+	accSvcMock.EXPECT().
+		GetByID(ctx, accountID).
+		Return(accounts.Account{ID: accountID, Status: accounts.ActiveStatus}, nil).
+		AnyTimes()
+	accSvcMock.EXPECT().
+		GetByID(ctx, toID).
+		Return(accounts.Account{ID: toID, Status: accounts.ActiveStatus}, nil).
+		AnyTimes()
+
+	t.Run("fail debit, requester required", func(t *testing.T) {
+		trx, err := svc.CreateDebit(ctx, Transaction{From: accountID, Amount: 10})
+		assert.ErrorIs(t, err, ErrRequesterRequired)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("fail debit, principal not allowed", func(t *testing.T) {
+		plcSvcMock.EXPECT().
+			AuthorizeDebit(ctx, "operator", accountID).
+			Return(policies.ErrForbidden)
+
+		trx, err := svc.CreateDebit(ctx, Transaction{From: accountID, Amount: 10, RequestedBy: "operator"})
+		assert.ErrorIs(t, err, ErrHolderNotAllowedToDebit)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("fail p2p, policies error", func(t *testing.T) {
+		plcSvcMock.EXPECT().
+			AuthorizeDebit(ctx, requester, accountID).
+			Return(sql.ErrConnDone)
+
+		trx, err := svc.CreateP2P(ctx, Transaction{From: accountID, To: toID, Amount: 10, RequestedBy: requester})
+		assert.ErrorIs(t, err, sql.ErrConnDone)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("success debit, co-owner", func(t *testing.T) {
+		plcSvcMock.EXPECT().
+			AuthorizeDebit(ctx, requester, accountID).
+			Return(nil)
+
+		redReturn := redis2.NewStringCmd(ctx)
+		redReturn.SetErr(redis2.Nil)
+		redisMock.EXPECT().
+			Get(ctx, fmt.Sprintf("transactions-debit-%s", accountID.String())).
+			Return(redReturn).
+			Times(2)
+		redisMock.EXPECT().
+			SetArgs(ctx, fmt.Sprintf("transactions-debit-%s", accountID.String()), float64(10), gomock.Any()).
+			Return(redis2.NewStatusCmd(ctx))
+		blcSvcMock.EXPECT().GetByAccountID(ctx, accountID).Return(balances.AccountBalance{CurrentBalance: 100}, nil)
+		accSvcMock.EXPECT().GetHeldAmount(ctx, accountID).Return(float64(0), nil)
+		repoMock.EXPECT().SumPendingReviews(ctx, accountID).Return(float64(0), nil)
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any()).
+			Return(transactionModel{ID: uuid.New()}, nil)
+
+		trx, err := svc.CreateDebit(ctx, Transaction{From: accountID, Amount: 10, RequestedBy: requester})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, trx)
+	})
+}
+
+func TestService_Pockets(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	accSvcMock := accounts.NewMockService(ctrl)
+	blcSvcMock := balances.NewMockService(ctrl)
+	prdSvcMock := products.NewMockService(ctrl)
+	redisMock := redis.NewMockClient(ctrl)
+
+	svc := NewService(
+		tracer.NewNoop(),
+		repoMock,
+		distlock.NewDistlockNoop(),
+		accSvcMock,
+		blcSvcMock,
+		prdSvcMock,
+		noFees(ctrl),
+		exchange.NewMockService(ctrl),
+		allowRisk(ctrl),
+		allowDebits(ctrl),
+		redisMock,
+		time.Hour,
+		0,
+	)
+
+	prdSvcMock.EXPECT().
+		GetByType(ctx, gomock.Any()).
+		Return(checkingProduct, nil).
+		AnyTimes()
+
+	accountID := uuid.New()
+	pocketID := uuid.New()
+	siblingID := uuid.New()
+	otherID := uuid.New()
+	parent := uuid.NullUUID{UUID: accountID, Valid: true}
+
+	accSvcMock.EXPECT().
+		GetByID(ctx, accountID).
+		Return(accounts.Account{ID: accountID, Status: accounts.ActiveStatus}, nil).
+		AnyTimes()
+	accSvcMock.EXPECT().
+		GetByID(ctx, pocketID).
+		Return(accounts.Account{ID: pocketID, Status: accounts.ActiveStatus, ParentID: parent}, nil).
+		AnyTimes()
+	accSvcMock.EXPECT().
+		GetByID(ctx, siblingID).
+		Return(accounts.Account{ID: siblingID, Status: accounts.ActiveStatus, ParentID: parent}, nil).
+		AnyTimes()
+	accSvcMock.EXPECT().
+		GetByID(ctx, otherID).
+		Return(accounts.Account{ID: otherID, Status: accounts.ActiveStatus}, nil).
+		AnyTimes()
+
+	t.Run("fail credit into pocket", func(t *testing.T) {
+		trx, err := svc.CreateCredit(ctx, Transaction{To: pocketID, Amount: 10})
+		assert.ErrorIs(t, err, ErrPocketExternalTransaction)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("fail p2p from pocket", func(t *testing.T) {
+		trx, err := svc.CreateP2P(ctx, Transaction{From: pocketID, To: otherID, Amount: 10, RequestedBy: requester})
+		assert.ErrorIs(t, err, ErrPocketExternalTransaction)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("fail internal between unrelated accounts", func(t *testing.T) {
+		trx, err := svc.CreateInternal(ctx, Transaction{From: accountID, To: otherID, Amount: 10, RequestedBy: requester})
+		assert.ErrorIs(t, err, ErrAccountsNotRelated)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("fail internal with insufficient funds", func(t *testing.T) {
+		blcSvcMock.EXPECT().GetByAccountID(ctx, pocketID).Return(balances.AccountBalance{CurrentBalance: 5}, nil)
+		accSvcMock.EXPECT().GetHeldAmount(ctx, pocketID).Return(float64(0), nil)
+		repoMock.EXPECT().SumPendingReviews(ctx, pocketID).Return(float64(0), nil)
+
+		trx, err := svc.CreateInternal(ctx, Transaction{From: pocketID, To: accountID, Amount: 10, RequestedBy: requester})
+		assert.ErrorIs(t, err, ErrBalanceInsufficientFunds)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("success internal to pocket without touching the debit limit", func(t *testing.T) {
+		blcSvcMock.EXPECT().GetByAccountID(ctx, accountID).Return(balances.AccountBalance{CurrentBalance: 100}, nil)
+		accSvcMock.EXPECT().GetHeldAmount(ctx, accountID).Return(float64(0), nil)
+		repoMock.EXPECT().SumPendingReviews(ctx, accountID).Return(float64(0), nil)
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any()).
+			Return(transactionModel{ID: uuid.New()}, nil)
+
+		trx, err := svc.CreateInternal(ctx, Transaction{From: accountID, To: pocketID, Amount: 10, RequestedBy: requester})
+		assert.NoError(t, err)
+		assert.Equal(t, InternalTransaction, trx.Type)
+	})
+
+	t.Run("success internal between pockets", func(t *testing.T) {
+		blcSvcMock.EXPECT().GetByAccountID(ctx, pocketID).Return(balances.AccountBalance{CurrentBalance: 10}, nil)
+		accSvcMock.EXPECT().GetHeldAmount(ctx, pocketID).Return(float64(0), nil)
+		repoMock.EXPECT().SumPendingReviews(ctx, pocketID).Return(float64(0), nil)
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any()).
+			Return(transactionModel{ID: uuid.New()}, nil)
+
+		trx, err := svc.CreateInternal(ctx, Transaction{From: pocketID, To: siblingID, Amount: 10, RequestedBy: requester})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, trx)
+	})
+}
+
+func TestService_Fees(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	accSvcMock := accounts.NewMockService(ctrl)
+	blcSvcMock := balances.NewMockService(ctrl)
+	prdSvcMock := products.NewMockService(ctrl)
+	feeSvcMock := fees.NewMockService(ctrl)
+	redisMock := redis.NewMockClient(ctrl)
+
+	svc := NewService(
+		tracer.NewNoop(),
+		repoMock,
+		distlock.NewDistlockNoop(),
+		accSvcMock,
+		blcSvcMock,
+		prdSvcMock,
+		feeSvcMock,
+		exchange.NewMockService(ctrl),
+		allowRisk(ctrl),
+		allowDebits(ctrl),
+		redisMock,
+		time.Hour,
+		0,
+	)
+
+	prdSvcMock.EXPECT().
+		GetByType(ctx, gomock.Any()).
+		Return(checkingProduct, nil).
+		AnyTimes()
+
+	fromID := uuid.New()
+	toID := uuid.New()
+	revenueID := uuid.New()
+
+	accSvcMock.EXPECT().
+		GetByID(ctx, fromID).
+		Return(accounts.Account{ID: fromID, Status: accounts.ActiveStatus}, nil).
+		AnyTimes()
+	accSvcMock.EXPECT().
+		GetByID(ctx, toID).
+		Return(accounts.Account{ID: toID, Status: accounts.ActiveStatus}, nil).
+		AnyTimes()
+	accSvcMock.EXPECT().
+		GetByID(ctx, revenueID).
+		Return(accounts.Account{ID: revenueID, Status: accounts.ActiveStatus}, nil).
+		AnyTimes()
+
+	fee := fees.Fee{AccountID: fromID, RevenueAccountID: revenueID, Amount: 1.5, Description: "P2P fee"}
+
+	expectDebit := func(balance float64) {
+		redReturn := redis2.NewStringCmd(ctx)
+		redReturn.SetErr(redis2.Nil)
+		redisMock.EXPECT().
+			Get(ctx, fmt.Sprintf("transactions-debit-%s", fromID.String())).
+			Return(redReturn).
+			AnyTimes()
+		feeSvcMock.EXPECT().
+			Quote(ctx, fees.Quote{
+				AccountID:       fromID,
+				TransactionType: string(P2PTransaction),
+				ProductType:     products.CheckingType,
+				Amount:          10,
+			}).
+			Return(fee, nil)
+		blcSvcMock.EXPECT().GetByAccountID(ctx, fromID).Return(balances.AccountBalance{CurrentBalance: balance}, nil)
+		accSvcMock.EXPECT().GetHeldAmount(ctx, fromID).Return(float64(0), nil)
+		repoMock.EXPECT().SumPendingReviews(ctx, fromID).Return(float64(0), nil)
+	}
+
+	t.Run("fail p2p, balance does not cover the fee", func(t *testing.T) {
+		expectDebit(11)
+
+		trx, err := svc.CreateP2P(ctx, Transaction{From: fromID, To: toID, Amount: 10, RequestedBy: requester})
+		assert.ErrorIs(t, err, ErrBalanceInsufficientFunds)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("success p2p, fee posted with the transaction", func(t *testing.T) {
+		expectDebit(11.5)
+		redisMock.EXPECT().
+			SetArgs(ctx, fmt.Sprintf("transactions-debit-%s", fromID.String()), float64(10), gomock.Any()).
+			Return(redis2.NewStatusCmd(ctx))
+
+		transactionID := uuid.New()
+		repoMock.EXPECT().
+			CreateWithFee(
+				ctx,
+				gomockeq.Eq(
+					transactionModel{FromAccountID: fromID, ToAccountID: toID, Type: P2PTransaction, Amount: 10},
+					gomockeq.IgnoreFields("ID", "CreatedAt"),
+				),
+				gomockeq.Eq(
+					transactionModel{
+						FromAccountID: fromID,
+						ToAccountID:   revenueID,
+						Type:          FeeTransaction,
+						Amount:        1.5,
+						Description:   "P2P fee",
+					},
+					gomockeq.IgnoreFields("ID", "CreatedAt"),
+				),
+			).
+			Return(transactionModel{ID: transactionID}, transactionModel{ID: uuid.New()}, nil)
+
+		trx, err := svc.CreateP2P(ctx, Transaction{From: fromID, To: toID, Amount: 10, RequestedBy: requester})
+		assert.NoError(t, err)
+		assert.Equal(t, transactionID, trx.ID)
+		assert.Equal(t, 1.5, trx.Fee)
+	})
+
+	t.Run("fail p2p, idempotency key already used", func(t *testing.T) {
+		expectDebit(11.5)
+		repoMock.EXPECT().
+			CreateWithFee(ctx, gomock.Any(), gomock.Any()).
+			Return(transactionModel{}, transactionModel{}, errDuplicatedIdempotencyKey)
+
+		trx, err := svc.CreateP2P(ctx, Transaction{
+			From:           fromID,
+			To:             toID,
+			Amount:         10,
+			IdempotencyKey: "charge",
+			RequestedBy:    requester,
+		})
+		assert.ErrorIs(t, err, ErrTransactionAlreadyMade)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("fail charge fee, invalid amount", func(t *testing.T) {
+		trx, err := svc.ChargeFee(ctx, fees.Fee{AccountID: fromID, RevenueAccountID: revenueID})
+		assert.ErrorIs(t, err, ErrInvalidFeeAmount)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("fail charge fee, already charged", func(t *testing.T) {
+		blcSvcMock.EXPECT().GetByAccountID(ctx, fromID).Return(balances.AccountBalance{CurrentBalance: 100}, nil)
+		accSvcMock.EXPECT().GetHeldAmount(ctx, fromID).Return(float64(0), nil)
+		repoMock.EXPECT().SumPendingReviews(ctx, fromID).Return(float64(0), nil)
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any()).
+			Return(transactionModel{}, errDuplicatedIdempotencyKey)
+
+		trx, err := svc.ChargeFee(ctx, fees.Fee{
+			AccountID:        fromID,
+			RevenueAccountID: revenueID,
+			Amount:           29.9,
+			IdempotencyKey:   "maintenance-fee",
+		})
+		assert.ErrorIs(t, err, ErrFeeAlreadyCharged)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("success charge fee", func(t *testing.T) {
+		blcSvcMock.EXPECT().GetByAccountID(ctx, fromID).Return(balances.AccountBalance{CurrentBalance: 100}, nil)
+		accSvcMock.EXPECT().GetHeldAmount(ctx, fromID).Return(float64(0), nil)
+		repoMock.EXPECT().SumPendingReviews(ctx, fromID).Return(float64(0), nil)
+		repoMock.EXPECT().
+			Create(ctx, gomockeq.Eq(
+				transactionModel{
+					FromAccountID:  fromID,
+					ToAccountID:    revenueID,
+					Type:           FeeTransaction,
+					Amount:         29.9,
+					Description:    "monthly maintenance fee 2026-10",
+					IdempotencyKey: "maintenance-fee",
+				},
+				gomockeq.IgnoreFields("ID", "CreatedAt"),
+			)).
+			Return(transactionModel{ID: uuid.New()}, nil)
+
+		trx, err := svc.ChargeFee(ctx, fees.Fee{
+			AccountID:        fromID,
+			RevenueAccountID: revenueID,
+			Amount:           29.9,
+			Description:      "monthly maintenance fee 2026-10",
+			IdempotencyKey:   "maintenance-fee",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, FeeTransaction, trx.Type)
+	})
+}
+
+func TestService_Currencies(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	accSvcMock := accounts.NewMockService(ctrl)
+	blcSvcMock := balances.NewMockService(ctrl)
+	prdSvcMock := products.NewMockService(ctrl)
+	excSvcMock := exchange.NewMockService(ctrl)
+	redisMock := redis.NewMockClient(ctrl)
+
+	svc := NewService(
+		tracer.NewNoop(),
+		repoMock,
+		distlock.NewDistlockNoop(),
+		accSvcMock,
+		blcSvcMock,
+		prdSvcMock,
+		noFees(ctrl),
+		excSvcMock,
+		allowRisk(ctrl),
+		allowDebits(ctrl),
+		redisMock,
+		time.Hour,
+		0,
+	)
+
+	prdSvcMock.EXPECT().
+		GetByType(ctx, gomock.Any()).
+		Return(checkingProduct, nil).
+		AnyTimes()
+
+	usdID := uuid.New()
+	brlID := uuid.New()
+
+	accSvcMock.EXPECT().
+		GetByID(ctx, usdID).
+		Return(accounts.Account{ID: usdID, Status: accounts.ActiveStatus, Currency: "USD"}, nil).
+		AnyTimes()
+	accSvcMock.EXPECT().
+		GetByID(ctx, brlID).
+		Return(accounts.Account{ID: brlID, Status: accounts.ActiveStatus, Currency: "BRL"}, nil).
+		AnyTimes()
+
+	redReturn := redis2.NewStringCmd(ctx)
+	redReturn.SetErr(redis2.Nil)
+	redisMock.EXPECT().
+		Get(ctx, fmt.Sprintf("transactions-debit-%s", usdID.String())).
+		Return(redReturn).
+		AnyTimes()
+
+	t.Run("fail debit, currency other than the account one", func(t *testing.T) {
+		trx, err := svc.CreateDebit(ctx, Transaction{From: usdID, Amount: 10, Currency: "BRL", RequestedBy: requester})
+		assert.ErrorIs(t, err, ErrCurrencyMismatch)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("fail p2p, no exchange rate", func(t *testing.T) {
+		excSvcMock.EXPECT().
+			Convert(ctx, float64(10), exchange.Currency("USD"), exchange.Currency("BRL")).
+			Return(exchange.Conversion{}, exchange.ErrRateNotFound)
+
+		trx, err := svc.CreateP2P(ctx, Transaction{From: usdID, To: brlID, Amount: 10, RequestedBy: requester})
+		assert.ErrorIs(t, err, ErrExchangeRateNotFound)
+		assert.Empty(t, trx)
+	})
+
+	t.Run("success p2p, converted into the to account currency", func(t *testing.T) {
+		excSvcMock.EXPECT().
+			Convert(ctx, float64(10), exchange.Currency("USD"), exchange.Currency("BRL")).
+			Return(exchange.Conversion{From: "USD", To: "BRL", Rate: 5.1, Amount: 10, ConvertedAmount: 51}, nil)
+		redisMock.EXPECT().
+			SetArgs(ctx, fmt.Sprintf("transactions-debit-%s", usdID.String()), float64(10), gomock.Any()).
+			Return(redis2.NewStatusCmd(ctx))
+		blcSvcMock.EXPECT().GetByAccountID(ctx, usdID).Return(balances.AccountBalance{CurrentBalance: 10}, nil)
+		accSvcMock.EXPECT().GetHeldAmount(ctx, usdID).Return(float64(0), nil)
+		repoMock.EXPECT().SumPendingReviews(ctx, usdID).Return(float64(0), nil)
+		repoMock.EXPECT().
+			Create(ctx, gomockeq.Eq(
+				transactionModel{
+					FromAccountID: usdID,
+					ToAccountID:   brlID,
+					Type:          P2PTransaction,
+					Amount:        10,
+					Currency:      "USD",
+					ToAmount:      51,
+					ToCurrency:    "BRL",
+					FxRate:        5.1,
+				},
+				gomockeq.IgnoreFields("ID", "CreatedAt"),
+			)).
+			Return(transactionModel{ID: uuid.New()}, nil)
+
+		trx, err := svc.CreateP2P(ctx, Transaction{From: usdID, To: brlID, Amount: 10, RequestedBy: requester})
+		assert.NoError(t, err)
+		assert.Equal(t, exchange.Currency("USD"), trx.Currency)
+		assert.Equal(t, float64(51), trx.CreditedAmount())
+		assert.Equal(t, 5.1, trx.FxRate)
+	})
+
+	t.Run("success credit, in the account currency", func(t *testing.T) {
+		repoMock.EXPECT().
+			Create(ctx, gomockeq.Eq(
+				transactionModel{ToAccountID: usdID, Type: CreditTransaction, Amount: 20, Currency: "USD"},
+				gomockeq.IgnoreFields("ID", "CreatedAt"),
+			)).
+			Return(transactionModel{ID: uuid.New()}, nil)
+
+		trx, err := svc.CreateCredit(ctx, Transaction{To: usdID, Amount: 20})
+		assert.NoError(t, err)
+		assert.Equal(t, exchange.Currency("USD"), trx.Currency)
+		assert.Empty(t, trx.ToCurrency)
+	})
+}
+
+func TestService_Reviews(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	accSvcMock := accounts.NewMockService(ctrl)
+	blcSvcMock := balances.NewMockService(ctrl)
+	prdSvcMock := products.NewMockService(ctrl)
+	riskSvcMock := risk.NewMockService(ctrl)
+	redisMock := redis.NewMockClient(ctrl)
+
+	svc := NewService(
+		tracer.NewNoop(),
+		repoMock,
+		distlock.NewDistlockNoop(),
+		accSvcMock,
+		blcSvcMock,
+		prdSvcMock,
+		noFees(ctrl),
+		exchange.NewMockService(ctrl),
+		riskSvcMock,
+		allowDebits(ctrl),
+		redisMock,
+		time.Hour,
+		0,
+	)
+
+	prdSvcMock.EXPECT().
+		GetByType(ctx, gomock.Any()).
+		Return(checkingProduct, nil).
+		AnyTimes()
+
+	fromID := uuid.New()
+	toID := uuid.New()
+	for _, id := range []uuid.UUID{fromID, toID} {
+		accSvcMock.EXPECT().
+			GetByID(ctx, id).
+			Return(accounts.Account{ID: id, Status: accounts.ActiveStatus}, nil).
+			AnyTimes()
+	}
+
+	redReturn := redis2.NewStringCmd(ctx)
+	redReturn.SetErr(redis2.Nil)
+	redisMock.EXPECT().Get(ctx, gomock.Any()).Return(redReturn).AnyTimes()
+	redisMock.EXPECT().SetArgs(ctx, gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(redis2.NewStatusResult("", nil)).
+		AnyTimes()
+
+	blcSvcMock.EXPECT().
+		GetByAccountID(ctx, fromID).
+		Return(balances.AccountBalance{CurrentBalance: 1000}, nil).
+		AnyTimes()
+	accSvcMock.EXPECT().GetHeldAmount(ctx, fromID).Return(float64(0), nil).AnyTimes()
+
+	evaluationID := uuid.New()
+	trx := Transaction{
+		RequestedBy: requester,
+		From:        fromID,
+		To:          toID,
+		Amount:      500,
+		Description: gofakeit.BeerName(),
+		Reviewable:  true,
+	}
+
+	reviewRisk := func() {
+		riskSvcMock.EXPECT().
+			Evaluate(ctx, risk.Operation{
+				AccountID:       fromID,
+				CounterpartyID:  uuid.NullUUID{UUID: toID, Valid: true},
+				TransactionType: string(P2PTransaction),
+				Amount:          trx.Amount,
+			}).
+			Return(risk.Evaluation{ID: evaluationID, Outcome: risk.ReviewOutcome}, nil)
+	}
+
+	pending := func() reviewModel {
+		return reviewModel{
+			ID:            uuid.New(),
+			EvaluationID:  evaluationID,
+			FromAccountID: fromID,
+			ToAccountID:   toID,
+			Amount:        trx.Amount,
+			Description:   trx.Description,
+			RequestedBy:   requester,
+			Status:        PendingReview,
+			ExpiresAt:     time.Now().UTC().Add(time.Hour),
+			CreatedAt:     time.Now().UTC(),
+		}
+	}
+
+	decision := ReviewDecision{Reason: "customer confirmed the payment", Actor: "analyst@dock"}
+
+	t.Run("park p2p, review asked by a rule", func(t *testing.T) {
+		reviewRisk()
+		repoMock.EXPECT().SumPendingReviews(ctx, fromID).Return(float64(400), nil)
+
+		model := pending()
+		repoMock.EXPECT().
+			CreateReview(
+				ctx,
+				gomockeq.Eq(
+					reviewModel{
+						EvaluationID:  evaluationID,
+						FromAccountID: fromID,
+						ToAccountID:   toID,
+						Amount:        trx.Amount,
+						Description:   trx.Description,
+						RequestedBy:   requester,
+						Status:        PendingReview,
+					},
+					gomockeq.IgnoreFields("ID", "ExpiresAt", "CreatedAt"),
+				),
+			).
+			Return(model, nil)
+
+		parked, err := svc.CreateP2P(ctx, trx)
+		assert.NoError(t, err)
+		assert.Equal(t, uuid.Nil, parked.ID)
+		assert.Equal(t, uuid.NullUUID{UUID: model.ID, Valid: true}, parked.ReviewID)
+	})
+
+	t.Run("fail park, funds held by pending reviews", func(t *testing.T) {
+		reviewRisk()
+		repoMock.EXPECT().SumPendingReviews(ctx, fromID).Return(float64(600), nil)
+
+		parked, err := svc.CreateP2P(ctx, trx)
+		assert.ErrorIs(t, err, ErrBalanceInsufficientFunds)
+		assert.Empty(t, parked)
+	})
+
+	t.Run("fail p2p, review asked for a p2p not reviewable", func(t *testing.T) {
+		reviewRisk()
+
+		notReviewable := trx
+		notReviewable.Reviewable = false
+
+		made, err := svc.CreateP2P(ctx, notReviewable)
+		assert.ErrorIs(t, err, ErrTransactionUnderReview)
+		assert.Empty(t, made)
+	})
+
+	t.Run("approve review, p2p made with the review key", func(t *testing.T) {
+		model := pending()
+		transactionID := uuid.New()
+		approved := model
+		approved.Status = ApprovedReview
+		approved.DecidedBy = decision.Actor
+		approved.DecisionReason = decision.Reason
+		approved.TransactionID = uuid.NullUUID{UUID: transactionID, Valid: true}
+
+		gomock.InOrder(
+			repoMock.EXPECT().GetReview(ctx, model.ID).Return(model, nil),
+			repoMock.EXPECT().
+				DecideReview(ctx, reviewModel{
+					ID:             model.ID,
+					Status:         ApprovedReview,
+					DecidedBy:      decision.Actor,
+					DecisionReason: decision.Reason,
+				}, PendingReview).
+				Return(approved, nil),
+			repoMock.EXPECT().SumPendingReviews(ctx, fromID).Return(float64(0), nil),
+			repoMock.EXPECT().
+				Create(
+					ctx,
+					gomockeq.Eq(
+						transactionModel{
+							FromAccountID:  fromID,
+							ToAccountID:    toID,
+							Type:           P2PTransaction,
+							Amount:         trx.Amount,
+							Description:    trx.Description,
+							IdempotencyKey: "review:" + model.ID.String(),
+						},
+						gomockeq.IgnoreFields("ID", "CreatedAt"),
+					),
+				).
+				Return(transactionModel{ID: transactionID}, nil),
+			repoMock.EXPECT().GetReview(ctx, model.ID).Return(approved, nil),
+		)
+
+		review, err := svc.ApproveReview(ctx, model.ID, decision)
+		assert.NoError(t, err)
+		assert.Equal(t, ApprovedReview, review.Status)
+		assert.Equal(t, approved.TransactionID, review.TransactionID)
+	})
+
+	t.Run("fail approve, p2p not made reopens the review", func(t *testing.T) {
+		model := pending()
+
+		gomock.InOrder(
+			repoMock.EXPECT().GetReview(ctx, model.ID).Return(model, nil),
+			repoMock.EXPECT().
+				DecideReview(ctx, gomock.Any(), PendingReview).
+				Return(reviewModel{}, nil),
+			repoMock.EXPECT().SumPendingReviews(ctx, fromID).Return(float64(800), nil),
+			repoMock.EXPECT().
+				DecideReview(ctx, reviewModel{ID: model.ID, Status: PendingReview}, ApprovedReview).
+				Return(model, nil),
+		)
+
+		review, err := svc.ApproveReview(ctx, model.ID, decision)
+		assert.ErrorIs(t, err, ErrBalanceInsufficientFunds)
+		assert.Empty(t, review)
+	})
+
+	t.Run("fail approve, review expired", func(t *testing.T) {
+		model := pending()
+		model.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+
+		repoMock.EXPECT().GetReview(ctx, model.ID).Return(model, nil)
+		repoMock.EXPECT().
+			DecideReview(
+				ctx,
+				gomockeq.Eq(
+					reviewModel{ID: model.ID, Status: ExpiredReview, DecidedBy: reviewSLAActor},
+					gomockeq.IgnoreFields("DecisionReason"),
+				),
+				PendingReview,
+			).
+			Return(reviewModel{}, nil)
+
+		review, err := svc.ApproveReview(ctx, model.ID, decision)
+		assert.ErrorIs(t, err, ErrReviewExpired)
+		assert.Empty(t, review)
+	})
+
+	t.Run("fail approve, review already decided", func(t *testing.T) {
+		model := pending()
+		model.Status = RejectedReview
+
+		repoMock.EXPECT().GetReview(ctx, model.ID).Return(model, nil)
+
+		review, err := svc.ApproveReview(ctx, model.ID, decision)
+		assert.ErrorIs(t, err, ErrReviewNotPending)
+		assert.Empty(t, review)
+	})
+
+	t.Run("fail approve, review not found", func(t *testing.T) {
+		id := uuid.New()
+		repoMock.EXPECT().GetReview(ctx, id).Return(reviewModel{}, sql.ErrNoRows)
+
+		review, err := svc.ApproveReview(ctx, id, decision)
+		assert.ErrorIs(t, err, ErrReviewNotFound)
+		assert.Empty(t, review)
+	})
+
+	t.Run("fail approve and reject, reason required", func(t *testing.T) {
+		_, err := svc.ApproveReview(ctx, uuid.New(), ReviewDecision{Reason: " ", Actor: decision.Actor})
+		assert.ErrorIs(t, err, ErrReviewReasonRequired)
+
+		_, err = svc.RejectReview(ctx, uuid.New(), ReviewDecision{Actor: decision.Actor})
+		assert.ErrorIs(t, err, ErrReviewReasonRequired)
+	})
+
+	t.Run("reject review", func(t *testing.T) {
+		model := pending()
+		rejected := model
+		rejected.Status = RejectedReview
+		rejected.DecidedBy = decision.Actor
+		rejected.DecisionReason = decision.Reason
+
+		repoMock.EXPECT().GetReview(ctx, model.ID).Return(model, nil)
+		repoMock.EXPECT().
+			DecideReview(ctx, reviewModel{
+				ID:             model.ID,
+				Status:         RejectedReview,
+				DecidedBy:      decision.Actor,
+				DecisionReason: decision.Reason,
+			}, PendingReview).
+			Return(rejected, nil)
+
+		review, err := svc.RejectReview(ctx, model.ID, decision)
+		assert.NoError(t, err)
+		assert.Equal(t, RejectedReview, review.Status)
+		assert.Equal(t, decision.Actor, review.DecidedBy)
+	})
+
+	t.Run("fail reject, review decided meanwhile", func(t *testing.T) {
+		model := pending()
+
+		repoMock.EXPECT().GetReview(ctx, model.ID).Return(model, nil)
+		repoMock.EXPECT().
+			DecideReview(ctx, gomock.Any(), PendingReview).
+			Return(reviewModel{}, errReviewStatusChanged)
+
+		review, err := svc.RejectReview(ctx, model.ID, decision)
+		assert.ErrorIs(t, err, ErrReviewNotPending)
+		assert.Empty(t, review)
+	})
+
+	t.Run("expire reviews out of the sla", func(t *testing.T) {
+		expired, decided := pending(), pending()
+
+		gomock.InOrder(
+			repoMock.EXPECT().ListExpiredReviews(ctx, gomock.Any()).Return([]reviewModel{expired, decided}, nil),
+			repoMock.EXPECT().DecideReview(ctx, gomock.Any(), PendingReview).Return(reviewModel{}, nil),
+			repoMock.EXPECT().DecideReview(ctx, gomock.Any(), PendingReview).Return(reviewModel{}, errReviewStatusChanged),
+		)
+
+		count, err := svc.ExpireReviews(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("list reviews with their context", func(t *testing.T) {
+		first, second := pending(), pending()
+		filter := ReviewFilter{Status: PendingReview, Page: 1, Size: 10}
+		hits := []risk.Hit{{RuleID: uuid.New(), RuleType: risk.NewCounterpartyRule, Outcome: risk.ReviewOutcome}}
+		history := []transactionModel{{ID: uuid.New(), ToAccountID: fromID, Type: CreditTransaction, Amount: 1000}}
+		events := []accounts.StatusEvent{
+			{AccountID: fromID, FromStatus: accounts.BlockedStatus, ToStatus: accounts.ActiveStatus},
+		}
+
+		repoMock.EXPECT().ListReviews(ctx, filter).Return(2, []reviewModel{first, second}, nil)
+		riskSvcMock.EXPECT().
+			ListEvaluations(ctx, risk.EvaluationFilter{IDs: []uuid.UUID{evaluationID, evaluationID}, Page: 1, Size: 2}).
+			Return(1, []risk.Evaluation{{ID: evaluationID, Hits: hits}}, nil)
+		repoMock.EXPECT().ListLatestByAccountID(ctx, fromID, reviewHistorySize).Return(history, nil)
+		accSvcMock.EXPECT().ListStatusHistory(ctx, fromID).Return(events, nil)
+
+		total, reviews, err := svc.ListReviews(ctx, filter)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Len(t, reviews, 2)
+		for _, review := range reviews {
+			assert.Equal(t, hits, review.Hits)
+			assert.Equal(t, []Transaction{newTransaction(history[0])}, review.History)
+			assert.Equal(t, events, review.StatusHistory)
+			assert.Equal(t, uuid.NullUUID{UUID: review.ID, Valid: true}, review.Transaction.ReviewID)
+		}
+	})
+}
+
+func TestService_ReviewApprovedUnderLock(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	accSvcMock := accounts.NewMockService(ctrl)
+	blcSvcMock := balances.NewMockService(ctrl)
+	prdSvcMock := products.NewMockService(ctrl)
+	redisMock := redis.NewMockClient(ctrl)
+	lockMock := distlock.NewMockDistLock(ctrl)
+
+	svc := NewService(
+		tracer.NewNoop(),
+		repoMock,
+		lockMock,
+		accSvcMock,
+		blcSvcMock,
+		prdSvcMock,
+		noFees(ctrl),
+		exchange.NewMockService(ctrl),
+		risk.NewMockService(ctrl),
+		allowDebits(ctrl),
+		redisMock,
+		time.Hour,
+		0,
+	)
+
+	prdSvcMock.EXPECT().
+		GetByType(ctx, gomock.Any()).
+		Return(checkingProduct, nil).
+		AnyTimes()
+
+	fromID := uuid.New()
+	toID := uuid.New()
+	for _, id := range []uuid.UUID{fromID, toID} {
+		accSvcMock.EXPECT().
+			GetByID(ctx, id).
+			Return(accounts.Account{ID: id, Status: accounts.ActiveStatus}, nil).
+			AnyTimes()
+	}
+
+	redReturn := redis2.NewStringCmd(ctx)
+	redReturn.SetErr(redis2.Nil)
+	redisMock.EXPECT().Get(ctx, gomock.Any()).Return(redReturn).AnyTimes()
+	blcSvcMock.EXPECT().
+		GetByAccountID(ctx, fromID).
+		Return(balances.AccountBalance{CurrentBalance: 1000}, nil).
+		AnyTimes()
+	accSvcMock.EXPECT().GetHeldAmount(ctx, fromID).Return(float64(0), nil).AnyTimes()
+
+	lockKey := fmt.Sprintf("transaction-account-from-%s", fromID.String())
+	model := reviewModel{
+		ID:            uuid.New(),
+		FromAccountID: fromID,
+		ToAccountID:   toID,
+		Amount:        500,
+		Description:   gofakeit.BeerName(),
+		RequestedBy:   requester,
+		Status:        PendingReview,
+		ExpiresAt:     time.Now().UTC().Add(time.Hour),
+	}
+	decision := ReviewDecision{Reason: "customer confirmed the payment", Actor: "analyst@dock"}
+
+	t.Run("fail approve, review approved and reopened holding the account lock", func(t *testing.T) {
+		gomock.InOrder(
+			repoMock.EXPECT().GetReview(ctx, model.ID).Return(model, nil),
+			lockMock.EXPECT().Acquire(ctx, lockKey, debitLockTTL, gomock.Any()).Return(true),
+			repoMock.EXPECT().
+				DecideReview(ctx, gomock.Any(), PendingReview).
+				Return(reviewModel{}, nil),
+			repoMock.EXPECT().SumPendingReviews(ctx, fromID).Return(float64(800), nil),
+			repoMock.EXPECT().
+				DecideReview(ctx, reviewModel{ID: model.ID, Status: PendingReview}, ApprovedReview).
+				Return(model, nil),
+			lockMock.EXPECT().Release(ctx, lockKey).Return(true),
+		)
+
+		review, err := svc.ApproveReview(ctx, model.ID, decision)
+		assert.ErrorIs(t, err, ErrBalanceInsufficientFunds)
+		assert.Empty(t, review)
+	})
+
+	t.Run("fail approve, review not approved without the account lock", func(t *testing.T) {
+		gomock.InOrder(
+			repoMock.EXPECT().GetReview(ctx, model.ID).Return(model, nil),
+			lockMock.EXPECT().Acquire(ctx, lockKey, debitLockTTL, gomock.Any()).Return(false),
+			lockMock.EXPECT().Release(ctx, lockKey).Return(false),
+		)
+
+		review, err := svc.ApproveReview(ctx, model.ID, decision)
+		assert.ErrorIs(t, err, ErrFailLockAccount)
+		assert.Empty(t, review)
+	})
+}
+
+func TestService_CloseAccount(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	accSvcMock := accounts.NewMockService(ctrl)
+	blcSvcMock := balances.NewMockService(ctrl)
+	prdSvcMock := products.NewMockService(ctrl)
+
+	svc := NewService(
+		tracer.NewNoop(),
+		repoMock,
+		distlock.NewDistlockNoop(),
+		accSvcMock,
+		blcSvcMock,
+		prdSvcMock,
+		noFees(ctrl),
+		exchange.NewMockService(ctrl),
+		allowRisk(ctrl),
+		allowDebits(ctrl),
+		redis.NewMockClient(ctrl),
+		time.Hour,
+		0,
+	)
+
+	prdSvcMock.EXPECT().GetByType(gomock.Any(), products.CheckingType).Return(checkingProduct, nil).AnyTimes()
+
+	account := accounts.Account{
+		ID:       uuid.New(),
+		Type:     products.CheckingType,
+		Status:   accounts.ActiveStatus,
+		Currency: "BRL",
+	}
+	destination := accounts.Account{
+		ID:       uuid.New(),
+		Type:     products.CheckingType,
+		Status:   accounts.ActiveStatus,
+		Currency: "BRL",
+	}
+	closure := accounts.Closure{
+		Reason:        "requested by the holder",
+		Actor:         "maker",
+		DestinationID: uuid.NullUUID{UUID: destination.ID, Valid: true},
+	}
+
+	t.Run("fail close, reason required", func(t *testing.T) {
+		closed, err := svc.CloseAccount(ctx, account.ID, accounts.Closure{})
+		assert.ErrorIs(t, err, accounts.ErrStatusReasonRequired)
+		assert.Empty(t, closed)
+	})
+
+	t.Run("fail close, pending transfers", func(t *testing.T) {
+		accSvcMock.EXPECT().GetByID(ctx, account.ID).Return(account, nil)
+		accSvcMock.EXPECT().CheckClosable(ctx, account.ID).Return(accounts.ErrAccountHasPendingTransfers)
+
+		closed, err := svc.CloseAccount(ctx, account.ID, closure)
+		assert.ErrorIs(t, err, accounts.ErrAccountHasPendingTransfers)
+		assert.Empty(t, closed)
+	})
+
+	t.Run("fail close, pending reviews", func(t *testing.T) {
+		accSvcMock.EXPECT().GetByID(ctx, account.ID).Return(account, nil)
+		accSvcMock.EXPECT().CheckClosable(ctx, account.ID).Return(nil)
+		repoMock.EXPECT().SumPendingReviews(ctx, account.ID).Return(50.0, nil)
+
+		closed, err := svc.CloseAccount(ctx, account.ID, closure)
+		assert.ErrorIs(t, err, ErrAccountHasPendingReviews)
+		assert.Empty(t, closed)
+	})
+
+	t.Run("fail close, balance without destination", func(t *testing.T) {
+		accSvcMock.EXPECT().GetByID(ctx, account.ID).Return(account, nil)
+		accSvcMock.EXPECT().CheckClosable(ctx, account.ID).Return(nil)
+		repoMock.EXPECT().SumPendingReviews(ctx, account.ID).Return(0.0, nil)
+		blcSvcMock.EXPECT().GetByAccountID(ctx, account.ID).Return(balances.AccountBalance{CurrentBalance: 150}, nil)
+
+		closed, err := svc.CloseAccount(ctx, account.ID, accounts.Closure{Reason: closure.Reason})
+		assert.ErrorIs(t, err, accounts.ErrAccountBalanceNotZero)
+		assert.Empty(t, closed)
+	})
+
+	for _, tt := range []struct {
+		name        string
+		destination accounts.Account
+		wantErr     error
+	}{
+		{
+			"fail close, destination inactive",
+			accounts.Account{ID: destination.ID, Type: products.CheckingType, Status: accounts.DormantStatus},
+			accounts.ErrInvalidClosureDestination,
+		},
+		{
+			"fail close, destination restricted from credits",
+			accounts.Account{
+				ID:           destination.ID,
+				Type:         products.CheckingType,
+				Status:       accounts.ActiveStatus,
+				Restrictions: []accounts.Restriction{accounts.CreditsRestriction},
+			},
+			ErrAccountCreditsBlocked,
+		},
+		{
+			"fail close, destination in another currency",
+			accounts.Account{ID: destination.ID, Type: products.CheckingType, Status: accounts.ActiveStatus, Currency: "USD"},
+			accounts.ErrClosureCurrencyMismatch,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			accSvcMock.EXPECT().GetByID(ctx, account.ID).Return(account, nil)
+			accSvcMock.EXPECT().CheckClosable(ctx, account.ID).Return(nil)
+			repoMock.EXPECT().SumPendingReviews(ctx, account.ID).Return(0.0, nil)
+			blcSvcMock.EXPECT().GetByAccountID(ctx, account.ID).Return(balances.AccountBalance{CurrentBalance: 150}, nil)
+			accSvcMock.EXPECT().GetByID(gomock.Any(), destination.ID).Return(tt.destination, nil)
+
+			closed, err := svc.CloseAccount(ctx, account.ID, closure)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Empty(t, closed)
+		})
+	}
+
+	t.Run("success close, sweeping the balance to the destination", func(t *testing.T) {
+		gomock.InOrder(
+			accSvcMock.EXPECT().GetByID(ctx, account.ID).Return(account, nil),
+			accSvcMock.EXPECT().CheckClosable(ctx, account.ID).Return(nil),
+			repoMock.EXPECT().SumPendingReviews(ctx, account.ID).Return(0.0, nil),
+			blcSvcMock.EXPECT().
+				GetByAccountID(ctx, account.ID).
+				Return(balances.AccountBalance{CurrentBalance: 150}, nil),
+			accSvcMock.EXPECT().GetByID(gomock.Any(), destination.ID).Return(destination, nil),
+			repoMock.EXPECT().
+				Create(gomock.Any(), gomockeq.Eq(transactionModel{
+					FromAccountID: account.ID,
+					ToAccountID:   destination.ID,
+					Type:          P2PTransaction,
+					Amount:        150,
+					Currency:      "BRL",
+					Description:   "account closure: " + closure.Reason,
+				}, gomockeq.IgnoreFields("ID", "CreatedAt"))).
+				Return(transactionModel{ID: uuid.New()}, nil),
+			accSvcMock.EXPECT().
+				CloseByID(ctx, account.ID, closure).
+				Return(accounts.Account{ID: account.ID, Status: accounts.ClosedStatus}, nil),
+		)
+
+		closed, err := svc.CloseAccount(ctx, account.ID, closure)
+		assert.NoError(t, err)
+		assert.Equal(t, accounts.ClosedStatus, closed.Status)
+	})
+
+	t.Run("success close, already closed", func(t *testing.T) {
+		accSvcMock.EXPECT().
+			GetByID(ctx, account.ID).
+			Return(accounts.Account{ID: account.ID, Status: accounts.ClosedStatus}, nil)
+
+		closed, err := svc.CloseAccount(ctx, account.ID, closure)
+		assert.NoError(t, err)
+		assert.Equal(t, accounts.ClosedStatus, closed.Status)
+	})
+}
@@ -1,6 +1,11 @@
 package transactions
 
 import (
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/exchange"
+	"github.com/dalmarcogd/dock-test/internal/risk"
 	"github.com/google/uuid"
 )
 
@@ -10,24 +15,146 @@ var (
 	CreditTransaction TransactionType = "CREDIT"
 	DebitTransaction  TransactionType = "DEBIT"
 	P2PTransaction    TransactionType = "P2P"
+	// InternalTransaction moves funds between an account and its pockets.
+	InternalTransaction TransactionType = "INTERNAL"
+	// FeeTransaction charges a fee, moving it from the account to the fee revenue account.
+	FeeTransaction TransactionType = "FEE"
+	// TEDTransaction moves funds sent to another bank from the account to the clearing account.
+	TEDTransaction TransactionType = "TED"
+	// TEDReversalTransaction returns the funds of a TED the other bank rejected to the account.
+	TEDReversalTransaction TransactionType = "TED_REVERSAL"
 )
 
 type Transaction struct {
-	ID          uuid.UUID
-	From        uuid.UUID
-	To          uuid.UUID
-	Type        TransactionType
-	Amount      float64
+	ID     uuid.UUID
+	From   uuid.UUID
+	To     uuid.UUID
+	Type   TransactionType
+	Amount float64
+	// Currency is the currency of Amount, the one of the From account or, for credits, of the To account.
+	Currency    exchange.Currency
 	Description string
+	// ToAmount is the amount credited to the To account in ToCurrency when the accounts currencies
+	// differ, converted at FxRate. Both are empty for transactions within a single currency.
+	ToAmount   float64
+	ToCurrency exchange.Currency
+	FxRate     float64
+	// RequestedBy is the id of the principal moving funds out of the From account, which must be linked
+	// to a holder whose role allows debits. It is empty for operations not requested by a holder.
+	RequestedBy string
+	// RelatedTransactionID links a fee to the transaction it was charged for.
+	RelatedTransactionID uuid.NullUUID
+	// IdempotencyKey is set for operations that must happen only once, such as monthly fees.
+	IdempotencyKey string
+	// Fee is the amount charged in a separate FEE transaction along with this one.
+	Fee float64
+	// Reviewable parks a P2P the risk rules send to a manual review, holding its funds until an
+	// analyst decides, instead of refusing it. Callers that track the outcome of the P2P themselves
+	// leave it unset.
+	Reviewable bool
+	// Approved debits were approved by a second principal, so they are made above the approval
+	// threshold. Only the approvals made by the approvals service set it.
+	Approved bool
+	// ReviewID is set, and ID left empty, when the P2P was parked for a manual review.
+	ReviewID uuid.NullUUID
 }
 
 func newTransaction(model transactionModel) Transaction {
 	return Transaction{
-		ID:          model.ID,
-		From:        model.FromAccountID,
-		To:          model.ToAccountID,
-		Type:        model.Type,
-		Amount:      model.Amount,
-		Description: model.Description,
+		ID:                   model.ID,
+		From:                 model.FromAccountID,
+		To:                   model.ToAccountID,
+		Type:                 model.Type,
+		Amount:               model.Amount,
+		Currency:             model.Currency,
+		Description:          model.Description,
+		ToAmount:             model.ToAmount,
+		ToCurrency:           model.ToCurrency,
+		FxRate:               model.FxRate,
+		RelatedTransactionID: model.RelatedTransactionID,
+		IdempotencyKey:       model.IdempotencyKey,
+	}
+}
+
+// CreditedAmount is the amount the To account receives, in its own currency.
+func (t Transaction) CreditedAmount() float64 {
+	if t.ToCurrency != "" {
+		return t.ToAmount
+	}
+
+	return t.Amount
+}
+
+type ReviewStatus string
+
+const (
+	// PendingReview holds the funds of the P2P until an analyst decides or the review expires.
+	PendingReview  ReviewStatus = "PENDING"
+	ApprovedReview ReviewStatus = "APPROVED"
+	RejectedReview ReviewStatus = "REJECTED"
+	// ExpiredReview was not decided within the review SLA, so the P2P was rejected.
+	ExpiredReview ReviewStatus = "EXPIRED"
+)
+
+var ReviewStatuses = []ReviewStatus{PendingReview, ApprovedReview, RejectedReview, ExpiredReview}
+
+// Review is a P2P parked by the risk rules for an analyst to approve, making it, or to reject,
+// releasing its funds.
+type Review struct {
+	ID           uuid.UUID
+	EvaluationID uuid.UUID
+	// Transaction is the P2P as requested, before fees and conversion, which are applied when it is made.
+	Transaction Transaction
+	Status      ReviewStatus
+	// TransactionID is the P2P made once the review was approved.
+	TransactionID  uuid.NullUUID
+	ExpiresAt      time.Time
+	DecidedAt      time.Time
+	DecidedBy      string
+	DecisionReason string
+	CreatedAt      time.Time
+	// Hits, History and StatusHistory are what analysts decide on: the rules the P2P hit, the latest
+	// transactions of the From account and the changes of its status.
+	Hits          []risk.Hit
+	History       []Transaction
+	StatusHistory []accounts.StatusEvent
+}
+
+func newReview(model reviewModel) Review {
+	return Review{
+		ID:           model.ID,
+		EvaluationID: model.EvaluationID,
+		Transaction: Transaction{
+			From:        model.FromAccountID,
+			To:          model.ToAccountID,
+			Type:        P2PTransaction,
+			Amount:      model.Amount,
+			Currency:    model.Currency,
+			Description: model.Description,
+			RequestedBy: model.RequestedBy,
+			ReviewID:    uuid.NullUUID{UUID: model.ID, Valid: true},
+		},
+		Status:         model.Status,
+		TransactionID:  model.TransactionID,
+		ExpiresAt:      model.ExpiresAt,
+		DecidedAt:      model.DecidedAt,
+		DecidedBy:      model.DecidedBy,
+		DecisionReason: model.DecisionReason,
+		CreatedAt:      model.CreatedAt,
 	}
 }
+
+// ReviewDecision is the analyst deciding a review and why.
+type ReviewDecision struct {
+	Reason string
+	// Actor is the id of the principal of the analyst.
+	Actor string
+}
+
+// ReviewFilter lists the reviews with a status, optionally of a single account, oldest first.
+type ReviewFilter struct {
+	Status    ReviewStatus
+	AccountID uuid.NullUUID
+	Page      int
+	Size      int
+}
@@ -0,0 +1,249 @@
+package transfers
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/pkg/document"
+	"github.com/dalmarcogd/dock-test/pkg/stringer"
+)
+
+const (
+	lineSize = 240
+	// lineBreak ends each line of the remittance files, as banks expect.
+	lineBreak = "\r\n"
+
+	fileLayoutVersion  = "089"
+	batchLayoutVersion = "046"
+	// paymentService is the supplier payment service, and tedEntry the TED to another holder.
+	paymentService = "20"
+	tedEntry       = "41"
+	// tedClearing is the clearing house of TEDs, and tedPurpose the credit to a checking account.
+	tedClearing = "018"
+	tedPurpose  = "00010"
+)
+
+// Occurrence codes of the payment return files.
+const (
+	// paidOccurrence is a credit made to the beneficiary.
+	paidOccurrence = "00"
+	// scheduledOccurrence is a transfer accepted by the bank, still to be made.
+	scheduledOccurrence = "BD"
+)
+
+var ErrInvalidReturnFile = errors.New("the file is not a CNAB 240 payment return file")
+
+// line builds a fixed width record, with positions counted from 1 as the layouts do.
+type line []byte
+
+func newLine() line {
+	return line(strings.Repeat(" ", lineSize))
+}
+
+// text left aligns the value in the field, filling it with spaces.
+func (l line) text(from, to int, value string) line {
+	size := to - from + 1
+	value = stringer.Plain(value, size)
+	copy(l[from-1:to], value+strings.Repeat(" ", size-len(value)))
+	return l
+}
+
+// number right aligns the digits in the field, filling it with zeros.
+func (l line) number(from, to int, value string) line {
+	size := to - from + 1
+	if len(value) > size {
+		value = value[len(value)-size:]
+	}
+	copy(l[from-1:to], strings.Repeat("0", size-len(value))+value)
+	return l
+}
+
+func (l line) amount(from, to int, value float64) line {
+	return l.number(from, to, fmt.Sprint(int64(math.Round(value*100))))
+}
+
+// encodeRemittance writes the FEBRABAN CNAB 240 payment file of the remittance, with one batch of
+// TEDs debited from the clearing account, each in an A segment followed by a B segment.
+func encodeRemittance(
+	originator Originator,
+	clearing accounts.Account,
+	remittance Remittance,
+	transfers []Transfer,
+) string {
+	clearingNumber, clearingDigit := Beneficiary{AccountNumber: clearing.Number}.number()
+	date := remittance.CreatedAt.Format("02012006")
+	inscription := inscriptionType(originator.DocumentNumber)
+
+	lines := make([]line, 0, len(transfers)*2+4)
+	lines = append(lines, newLine().
+		number(1, 3, originator.BankCode).
+		number(4, 7, "0000").
+		number(8, 8, "0").
+		number(18, 18, inscription).
+		number(19, 32, originator.DocumentNumber).
+		number(53, 57, clearing.Agency).
+		number(59, 70, clearingNumber).
+		text(71, 71, clearingDigit).
+		text(73, 102, originator.Name).
+		number(143, 143, "1").
+		number(144, 151, date).
+		number(152, 157, remittance.CreatedAt.Format("150405")).
+		number(158, 163, fmt.Sprint(remittance.Sequence)).
+		number(164, 166, fileLayoutVersion).
+		number(167, 171, "0"),
+	)
+	lines = append(lines, newLine().
+		number(1, 3, originator.BankCode).
+		number(4, 7, "1").
+		number(8, 8, "1").
+		text(9, 9, "C").
+		number(10, 11, paymentService).
+		number(12, 13, tedEntry).
+		number(14, 16, batchLayoutVersion).
+		number(18, 18, inscription).
+		number(19, 32, originator.DocumentNumber).
+		number(53, 57, clearing.Agency).
+		number(59, 70, clearingNumber).
+		text(71, 71, clearingDigit).
+		text(73, 102, originator.Name),
+	)
+
+	var total float64
+	for i, transfer := range transfers {
+		number, digit := transfer.Beneficiary.number()
+		lines = append(lines, newLine().
+			number(1, 3, originator.BankCode).
+			number(4, 7, "1").
+			number(8, 8, "3").
+			number(9, 13, fmt.Sprint(i*2+1)).
+			text(14, 14, "A").
+			number(15, 15, "0").
+			number(16, 17, "00").
+			number(18, 20, tedClearing).
+			number(21, 23, transfer.Beneficiary.BankCode).
+			number(24, 28, transfer.Beneficiary.Agency).
+			number(30, 41, number).
+			text(42, 42, digit).
+			text(44, 73, transfer.Beneficiary.Name).
+			text(74, 93, transfer.Reference).
+			number(94, 101, date).
+			text(102, 104, "BRL").
+			number(105, 119, "0").
+			amount(120, 134, transfer.Amount).
+			number(155, 162, "0").
+			number(163, 177, "0").
+			number(220, 224, tedPurpose).
+			number(230, 230, "0"),
+		)
+		lines = append(lines, newLine().
+			number(1, 3, originator.BankCode).
+			number(4, 7, "1").
+			number(8, 8, "3").
+			number(9, 13, fmt.Sprint(i*2+2)).
+			text(14, 14, "B").
+			number(18, 18, inscriptionType(transfer.Beneficiary.DocumentNumber)).
+			number(19, 32, transfer.Beneficiary.DocumentNumber),
+		)
+		total += transfer.Amount
+	}
+
+	lines = append(lines, newLine().
+		number(1, 3, originator.BankCode).
+		number(4, 7, "1").
+		number(8, 8, "5").
+		number(18, 23, fmt.Sprint(len(transfers)*2+2)).
+		amount(24, 41, total).
+		number(42, 59, "0").
+		number(60, 65, "0"),
+	)
+	lines = append(lines, newLine().
+		number(1, 3, originator.BankCode).
+		number(4, 7, "9999").
+		number(8, 8, "9").
+		number(18, 23, "1").
+		number(24, 29, fmt.Sprint(len(lines)+1)).
+		number(30, 35, "0"),
+	)
+
+	var sb strings.Builder
+	for _, l := range lines {
+		sb.Write(l)
+		sb.WriteString(lineBreak)
+	}
+
+	return sb.String()
+}
+
+// inscriptionType is 1 for CPFs and 2 for CNPJs.
+func inscriptionType(documentNumber string) string {
+	if document.IsCNPJ(documentNumber) {
+		return "2"
+	}
+	return "1"
+}
+
+// returnRecord is the outcome of a transfer reported in a return file.
+type returnRecord struct {
+	// Line is the line of the A segment of the transfer, counting from 1.
+	Line      int
+	Reference string
+	// Occurrences are up to five codes of two characters.
+	Occurrences string
+}
+
+func (r returnRecord) paid() bool {
+	return strings.HasPrefix(r.Occurrences, paidOccurrence)
+}
+
+func (r returnRecord) scheduled() bool {
+	return strings.HasPrefix(r.Occurrences, scheduledOccurrence)
+}
+
+// parseReturnFile reads the A segments of a CNAB 240 payment return file.
+func parseReturnFile(r io.Reader) ([]returnRecord, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if text := strings.TrimRight(scanner.Text(), "\r"); strings.TrimSpace(text) != "" {
+			lines = append(lines, text)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 0 {
+		return nil, ErrInvalidReturnFile
+	}
+
+	for i, l := range lines {
+		if len(l) != lineSize {
+			return nil, fmt.Errorf("%w: line %d has %d characters", ErrInvalidReturnFile, i+1, len(l))
+		}
+	}
+
+	// The file header has the record type 0 at position 8 and the return code 2 at position 143.
+	if lines[0][7] != '0' || lines[0][142] != '2' {
+		return nil, fmt.Errorf("%w: missing the return header", ErrInvalidReturnFile)
+	}
+
+	var records []returnRecord
+	for i, l := range lines {
+		if l[7] != '3' || l[13] != 'A' {
+			continue
+		}
+
+		records = append(records, returnRecord{
+			Line:        i + 1,
+			Reference:   strings.TrimSpace(l[73:93]),
+			Occurrences: strings.TrimSpace(l[230:240]),
+		})
+	}
+
+	return records, nil
+}
@@ -0,0 +1,125 @@
+//go:build unit
+
+package transfers
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeRemittance(t *testing.T) {
+	originator := Originator{BankCode: "999", DocumentNumber: "11222333000181", Name: "Dock Test"}
+	clearing := accounts.Account{Agency: "0001", Number: "000003-5"}
+	remittance := Remittance{
+		Sequence:  7,
+		Date:      time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC),
+		CreatedAt: time.Date(2025, time.March, 15, 1, 2, 3, 0, time.UTC),
+	}
+	transfers := []Transfer{
+		{
+			Amount:    150,
+			Reference: "0A1B2C3D4E5F60718293",
+			Beneficiary: Beneficiary{
+				BankCode:       "237",
+				Agency:         "1234",
+				AccountNumber:  "12345-6",
+				DocumentNumber: "52998224725",
+				Name:           "João da Silva",
+			},
+		},
+		{
+			Amount:    1234.56,
+			Reference: "1B2C3D4E5F6071829304",
+			Beneficiary: Beneficiary{
+				BankCode:       "341",
+				Agency:         "567",
+				AccountNumber:  "987654321-X",
+				DocumentNumber: "11222333000181",
+				Name:           "Padaria Pão Quente Ltda",
+			},
+		},
+	}
+
+	file := encodeRemittance(originator, clearing, remittance, transfers)
+	assert.True(t, strings.HasSuffix(file, "\r\n"))
+
+	lines := strings.Split(strings.TrimSuffix(file, "\r\n"), "\r\n")
+	assert.Len(t, lines, 8)
+	for _, l := range lines {
+		assert.Len(t, l, lineSize)
+	}
+
+	header := lines[0]
+	assert.Equal(t, "99900000", header[0:8])
+	assert.Equal(t, "211222333000181", header[17:32])
+	assert.Equal(t, "00001 0000000000035", header[52:71])
+	assert.Equal(t, "DOCK TEST", strings.TrimSpace(header[72:102]))
+	assert.Equal(t, "1150320250102030000070890", header[142:167])
+
+	assert.Equal(t, "99900011C2041046", lines[1][0:16])
+
+	first := lines[2]
+	assert.Equal(t, "9990001300001A00001823701234 0000000123456", first[0:42])
+	assert.Equal(t, "JOAO DA SILVA", strings.TrimSpace(first[43:73]))
+	assert.Equal(t, "0A1B2C3D4E5F6071829315032025BRL", first[73:104])
+	assert.Equal(t, "000000000015000", first[119:134])
+	assert.Equal(t, "00010", first[219:224])
+
+	assert.Equal(t, "9990001300002B", lines[3][0:14])
+	assert.Equal(t, "100052998224725", lines[3][17:32])
+
+	second := lines[4]
+	assert.Equal(t, "34100567 000987654321X", second[20:42])
+	assert.Equal(t, "PADARIA PAO QUENTE LTDA", strings.TrimSpace(second[43:73]))
+	assert.Equal(t, "000000000123456", second[119:134])
+	assert.Equal(t, "211222333000181", lines[5][17:32])
+
+	assert.Equal(t, "99900015", lines[6][0:8])
+	assert.Equal(t, "000006000000000000138456", lines[6][17:41])
+
+	assert.Equal(t, "99999999", lines[7][0:8])
+	assert.Equal(t, "000001000008", lines[7][17:29])
+}
+
+func TestParseReturnFile(t *testing.T) {
+	t.Run("cnab 240", func(t *testing.T) {
+		file, err := os.Open("testdata/return_cnab240.ret")
+		assert.NoError(t, err)
+		defer file.Close()
+
+		records, err := parseReturnFile(file)
+		assert.NoError(t, err)
+		assert.Equal(t, []returnRecord{
+			{Line: 3, Reference: "0A1B2C3D4E5F60718293", Occurrences: "00"},
+			{Line: 5, Reference: "1B2C3D4E5F6071829304", Occurrences: "BD"},
+			{Line: 7, Reference: "2C3D4E5F607182930415", Occurrences: "AP"},
+			{Line: 9, Reference: "FFFFFFFFFFFFFFFFFFFF", Occurrences: "00"},
+		}, records)
+		assert.True(t, records[0].paid())
+		assert.True(t, records[1].scheduled())
+		assert.False(t, records[2].paid())
+		assert.False(t, records[2].scheduled())
+	})
+
+	t.Run("remittance file", func(t *testing.T) {
+		file := encodeRemittance(Originator{BankCode: "999"}, accounts.Account{}, Remittance{}, nil)
+
+		_, err := parseReturnFile(strings.NewReader(file))
+		assert.ErrorIs(t, err, ErrInvalidReturnFile)
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		_, err := parseReturnFile(strings.NewReader("\n\n"))
+		assert.ErrorIs(t, err, ErrInvalidReturnFile)
+	})
+
+	t.Run("lines of different sizes", func(t *testing.T) {
+		_, err := parseReturnFile(strings.NewReader(strings.Repeat("0", 240) + "\n" + strings.Repeat("0", 239)))
+		assert.ErrorIs(t, err, ErrInvalidReturnFile)
+	})
+}
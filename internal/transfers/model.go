@@ -0,0 +1,59 @@
+package transfers
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type transferModel struct {
+	bun.BaseModel `bun:"table:transfers,alias:transfer"`
+
+	ID             uuid.UUID `bun:"id,pk"`
+	AccountID      uuid.UUID `bun:"account_id"`
+	Amount         float64   `bun:"amount"`
+	BankCode       string    `bun:"bank_code"`
+	Agency         string    `bun:"agency"`
+	AccountNumber  string    `bun:"account_number"`
+	DocumentNumber string    `bun:"document_number"`
+	Name           string    `bun:"name"`
+	Description    string    `bun:"description"`
+	Reference      string    `bun:"reference"`
+	Status         Status    `bun:"status"`
+	// DebitTransactionID is the TED made with the idempotency key of the transfer.
+	DebitTransactionID    uuid.NullUUID `bun:"debit_transaction_id,scanonly"`
+	ReversalTransactionID uuid.NullUUID `bun:"reversal_transaction_id"`
+	RemittanceID          uuid.NullUUID `bun:"remittance_id"`
+	Occurrences           string        `bun:"occurrences"`
+	CreatedAt             time.Time     `bun:"created_at,notnull"`
+	SettledAt             time.Time     `bun:"settled_at,nullzero"`
+}
+
+func newTransferModel(transfer Transfer) transferModel {
+	return transferModel{
+		ID:             transfer.ID,
+		AccountID:      transfer.AccountID,
+		Amount:         transfer.Amount,
+		BankCode:       transfer.Beneficiary.BankCode,
+		Agency:         transfer.Beneficiary.Agency,
+		AccountNumber:  transfer.Beneficiary.AccountNumber,
+		DocumentNumber: transfer.Beneficiary.DocumentNumber,
+		Name:           transfer.Beneficiary.Name,
+		Description:    transfer.Description,
+		Reference:      transfer.Reference,
+		Status:         transfer.Status,
+		CreatedAt:      transfer.CreatedAt,
+	}
+}
+
+type remittanceModel struct {
+	bun.BaseModel `bun:"table:transfer_remittances,alias:remittance"`
+
+	ID            uuid.UUID `bun:"id,pk"`
+	Sequence      int64     `bun:"sequence,autoincrement"`
+	Date          time.Time `bun:"date"`
+	TransferCount int       `bun:"transfer_count"`
+	TotalAmount   float64   `bun:"total_amount"`
+	CreatedAt     time.Time `bun:"created_at,notnull"`
+}
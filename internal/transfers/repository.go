@@ -0,0 +1,347 @@
+package transfers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+const (
+	transferRemittancesDateConstraint = "transfer_remittances_date"
+	// debitTransactionIDExpr resolves the TED made with the idempotency key of the transfer, so a
+	// transfer is known to be debited even when the debit was made right before a failure.
+	debitTransactionIDExpr = "(SELECT t.id FROM transactions AS t WHERE t.idempotency_key = 'transfer:' || transfer.id)"
+)
+
+var (
+	errTransferNotSent           = errors.New("the transfer is no longer waiting for the bank")
+	errRemittanceAlreadyExists   = errors.New("a remittance was already generated for this date")
+	errNoPendingTransfersToRemit = errors.New("no pending transfers to remit")
+)
+
+type Repository interface {
+	Create(ctx context.Context, model transferModel) (transferModel, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetByID(ctx context.Context, id uuid.UUID) (transferModel, error)
+	GetByReference(ctx context.Context, reference string) (transferModel, error)
+	MarkConfirmed(ctx context.Context, id uuid.UUID, occurrences string, settledAt time.Time) error
+	MarkReversed(
+		ctx context.Context,
+		id uuid.UUID,
+		occurrences string,
+		settledAt time.Time,
+		reversalTransactionID uuid.UUID,
+	) error
+	GetTransactionID(ctx context.Context, idempotencyKey string) (uuid.UUID, error)
+	CreateRemittance(ctx context.Context, date time.Time) (remittanceModel, error)
+	GetRemittanceByID(ctx context.Context, id uuid.UUID) (remittanceModel, error)
+	ListByRemittanceID(ctx context.Context, remittanceID uuid.UUID) ([]transferModel, error)
+}
+
+type repository struct {
+	tracer tracer.Tracer
+	db     database.Database
+}
+
+func NewRepository(t tracer.Tracer, db database.Database) Repository {
+	return repository{
+		tracer: t,
+		db:     db,
+	}
+}
+
+func (r repository) Create(ctx context.Context, model transferModel) (transferModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	if model.CreatedAt.IsZero() {
+		model.CreatedAt = time.Now().UTC()
+	}
+
+	_, err := r.db.Master().
+		NewInsert().
+		Model(&model).
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return transferModel{}, err
+	}
+
+	return model, nil
+}
+
+// Delete removes a transfer whose debit failed, before it is ever sent.
+func (r repository) Delete(ctx context.Context, id uuid.UUID) error {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	_, err := r.db.Master().
+		NewDelete().
+		Model((*transferModel)(nil)).
+		Where("id = ?", id).
+		Where("status = ?", PendingStatus).
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	return nil
+}
+
+func (r repository) GetByID(ctx context.Context, id uuid.UUID) (transferModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var model transferModel
+	err := r.db.Master().
+		NewSelect().
+		Model(&model).
+		ColumnExpr("transfer.*").
+		ColumnExpr(debitTransactionIDExpr+" AS debit_transaction_id").
+		Where("transfer.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return transferModel{}, err
+	}
+
+	return model, nil
+}
+
+func (r repository) GetByReference(ctx context.Context, reference string) (transferModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var model transferModel
+	err := r.db.Master().
+		NewSelect().
+		Model(&model).
+		ColumnExpr("transfer.*").
+		ColumnExpr(debitTransactionIDExpr+" AS debit_transaction_id").
+		Where("transfer.reference = ?", reference).
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return transferModel{}, err
+	}
+
+	return model, nil
+}
+
+// MarkConfirmed records the bank credited the beneficiary, failing with errTransferNotSent when the
+// transfer was already confirmed or reversed.
+func (r repository) MarkConfirmed(ctx context.Context, id uuid.UUID, occurrences string, settledAt time.Time) error {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	res, err := r.db.Master().
+		NewUpdate().
+		Model((*transferModel)(nil)).
+		Set("status = ?", ConfirmedStatus).
+		Set("occurrences = ?", occurrences).
+		Set("settled_at = ?", settledAt).
+		Where("id = ?", id).
+		Where("status = ?", SentStatus).
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	if rows == 0 {
+		span.RecordError(errTransferNotSent)
+		return errTransferNotSent
+	}
+
+	return nil
+}
+
+// MarkReversed records the bank rejected the transfer and the transaction that returned its funds,
+// failing with errTransferNotSent when the transfer was already confirmed or reversed.
+func (r repository) MarkReversed(
+	ctx context.Context,
+	id uuid.UUID,
+	occurrences string,
+	settledAt time.Time,
+	reversalTransactionID uuid.UUID,
+) error {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	res, err := r.db.Master().
+		NewUpdate().
+		Model((*transferModel)(nil)).
+		Set("status = ?", ReversedStatus).
+		Set("occurrences = ?", occurrences).
+		Set("settled_at = ?", settledAt).
+		Set("reversal_transaction_id = ?", reversalTransactionID).
+		Where("id = ?", id).
+		Where("status = ?", SentStatus).
+		Exec(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	if rows == 0 {
+		span.RecordError(errTransferNotSent)
+		return errTransferNotSent
+	}
+
+	return nil
+}
+
+// GetTransactionID returns the transaction made with the idempotency key, or sql.ErrNoRows when none
+// was made.
+func (r repository) GetTransactionID(ctx context.Context, idempotencyKey string) (uuid.UUID, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var id uuid.UUID
+	err := r.db.Master().
+		NewSelect().
+		TableExpr("transactions").
+		Column("id").
+		Where("idempotency_key = ?", idempotencyKey).
+		Scan(ctx, &id)
+	if err != nil {
+		span.RecordError(err)
+		return uuid.Nil, err
+	}
+
+	return id, nil
+}
+
+// CreateRemittance numbers the remittance of the date and sends in it the debited transfers still
+// pending that were created up to the end of the date. It fails with errRemittanceAlreadyExists when
+// the date already has a remittance, and with errNoPendingTransfersToRemit when there is nothing to
+// send, creating nothing.
+func (r repository) CreateRemittance(ctx context.Context, date time.Time) (remittanceModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	model := remittanceModel{
+		ID:        uuid.New(),
+		Date:      date,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	err := r.db.Master().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewInsert().Model(&model).Returning("*").Exec(ctx)
+		if err != nil {
+			if isConstraintViolation(err, transferRemittancesDateConstraint) {
+				return errRemittanceAlreadyExists
+			}
+			return err
+		}
+
+		_, err = tx.NewUpdate().
+			Model((*transferModel)(nil)).
+			Set("status = ?", SentStatus).
+			Set("remittance_id = ?", model.ID).
+			Where("status = ?", PendingStatus).
+			Where("remittance_id IS NULL").
+			Where("created_at < ?", date.AddDate(0, 0, 1)).
+			Where(debitTransactionIDExpr + " IS NOT NULL").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = tx.NewSelect().
+			Model((*transferModel)(nil)).
+			ColumnExpr("COUNT(*)").
+			ColumnExpr("COALESCE(SUM(transfer.amount), 0)").
+			Where("transfer.remittance_id = ?", model.ID).
+			Scan(ctx, &model.TransferCount, &model.TotalAmount)
+		if err != nil {
+			return err
+		}
+
+		if model.TransferCount == 0 {
+			return errNoPendingTransfersToRemit
+		}
+
+		_, err = tx.NewUpdate().
+			Model(&model).
+			Column("transfer_count", "total_amount").
+			WherePK().
+			Exec(ctx)
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		return remittanceModel{}, err
+	}
+
+	return model, nil
+}
+
+func (r repository) GetRemittanceByID(ctx context.Context, id uuid.UUID) (remittanceModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var model remittanceModel
+	err := r.db.Master().
+		NewSelect().
+		Model(&model).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return remittanceModel{}, err
+	}
+
+	return model, nil
+}
+
+// ListByRemittanceID returns the transfers of the remittance in the order they are written to its file.
+func (r repository) ListByRemittanceID(ctx context.Context, remittanceID uuid.UUID) ([]transferModel, error) {
+	ctx, span := r.tracer.Span(ctx)
+	defer span.End()
+
+	var models []transferModel
+	err := r.db.Master().
+		NewSelect().
+		Model(&models).
+		ColumnExpr("transfer.*").
+		ColumnExpr(debitTransactionIDExpr+" AS debit_transaction_id").
+		Where("transfer.remittance_id = ?", remittanceID).
+		Order("transfer.created_at ASC", "transfer.id ASC").
+		Scan(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return models, nil
+}
+
+func isConstraintViolation(err error, constraint string) bool {
+	var pgErr pgdriver.Error
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.IntegrityViolation() && pgErr.Field('n') == constraint
+}
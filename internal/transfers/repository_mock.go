@@ -0,0 +1,184 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/transfers/repository.go
+
+// Package transfers is a generated GoMock package.
+package transfers
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRepository) Create(ctx context.Context, model transferModel) (transferModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, model)
+	ret0, _ := ret[0].(transferModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRepositoryMockRecorder) Create(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, model)
+}
+
+// CreateRemittance mocks base method.
+func (m *MockRepository) CreateRemittance(ctx context.Context, date time.Time) (remittanceModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRemittance", ctx, date)
+	ret0, _ := ret[0].(remittanceModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRemittance indicates an expected call of CreateRemittance.
+func (mr *MockRepositoryMockRecorder) CreateRemittance(ctx, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRemittance", reflect.TypeOf((*MockRepository)(nil).CreateRemittance), ctx, date)
+}
+
+// Delete mocks base method.
+func (m *MockRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockRepository) GetByID(ctx context.Context, id uuid.UUID) (transferModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(transferModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByReference mocks base method.
+func (m *MockRepository) GetByReference(ctx context.Context, reference string) (transferModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByReference", ctx, reference)
+	ret0, _ := ret[0].(transferModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByReference indicates an expected call of GetByReference.
+func (mr *MockRepositoryMockRecorder) GetByReference(ctx, reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByReference", reflect.TypeOf((*MockRepository)(nil).GetByReference), ctx, reference)
+}
+
+// GetRemittanceByID mocks base method.
+func (m *MockRepository) GetRemittanceByID(ctx context.Context, id uuid.UUID) (remittanceModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRemittanceByID", ctx, id)
+	ret0, _ := ret[0].(remittanceModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRemittanceByID indicates an expected call of GetRemittanceByID.
+func (mr *MockRepositoryMockRecorder) GetRemittanceByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemittanceByID", reflect.TypeOf((*MockRepository)(nil).GetRemittanceByID), ctx, id)
+}
+
+// GetTransactionID mocks base method.
+func (m *MockRepository) GetTransactionID(ctx context.Context, idempotencyKey string) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionID", ctx, idempotencyKey)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionID indicates an expected call of GetTransactionID.
+func (mr *MockRepositoryMockRecorder) GetTransactionID(ctx, idempotencyKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionID", reflect.TypeOf((*MockRepository)(nil).GetTransactionID), ctx, idempotencyKey)
+}
+
+// ListByRemittanceID mocks base method.
+func (m *MockRepository) ListByRemittanceID(ctx context.Context, remittanceID uuid.UUID) ([]transferModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByRemittanceID", ctx, remittanceID)
+	ret0, _ := ret[0].([]transferModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByRemittanceID indicates an expected call of ListByRemittanceID.
+func (mr *MockRepositoryMockRecorder) ListByRemittanceID(ctx, remittanceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByRemittanceID", reflect.TypeOf((*MockRepository)(nil).ListByRemittanceID), ctx, remittanceID)
+}
+
+// MarkConfirmed mocks base method.
+func (m *MockRepository) MarkConfirmed(ctx context.Context, id uuid.UUID, occurrences string, settledAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkConfirmed", ctx, id, occurrences, settledAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkConfirmed indicates an expected call of MarkConfirmed.
+func (mr *MockRepositoryMockRecorder) MarkConfirmed(ctx, id, occurrences, settledAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkConfirmed", reflect.TypeOf((*MockRepository)(nil).MarkConfirmed), ctx, id, occurrences, settledAt)
+}
+
+// MarkReversed mocks base method.
+func (m *MockRepository) MarkReversed(ctx context.Context, id uuid.UUID, occurrences string, settledAt time.Time, reversalTransactionID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkReversed", ctx, id, occurrences, settledAt, reversalTransactionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkReversed indicates an expected call of MarkReversed.
+func (mr *MockRepositoryMockRecorder) MarkReversed(ctx, id, occurrences, settledAt, reversalTransactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkReversed", reflect.TypeOf((*MockRepository)(nil).MarkReversed), ctx, id, occurrences, settledAt, reversalTransactionID)
+}
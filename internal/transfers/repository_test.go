@@ -0,0 +1,148 @@
+//go:build integration
+
+package transfers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/holders"
+	"github.com/dalmarcogd/dock-test/internal/products"
+	"github.com/dalmarcogd/dock-test/pkg/database"
+	"github.com/dalmarcogd/dock-test/pkg/testingcontainers"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository(t *testing.T) {
+	ctx := context.Background()
+
+	url, closeFunc, err := testingcontainers.NewPostgresContainer()
+	assert.NoError(t, err)
+	defer closeFunc(ctx) //nolint:errcheck
+
+	_, callerPath, _, _ := runtime.Caller(0) //nolint:dogsled
+	err = testingcontainers.RunMigrateDatabase(
+		url,
+		fmt.Sprintf("file://%s/../../migrations/", filepath.Dir(callerPath)),
+	)
+	assert.NoError(t, err)
+
+	db, err := database.New(tracer.NewNoop(), url, url)
+	assert.NoError(t, err)
+
+	holdersRepo := holders.NewRepository(tracer.NewNoop(), db)
+	holderModel, err := holdersRepo.Create(ctx, holders.HolderModel{
+		ID:             uuid.New(),
+		Name:           gofakeit.Name(),
+		DocumentNumber: gofakeit.SSN(),
+	})
+	assert.NoError(t, err)
+
+	accSvc := accounts.NewService(tracer.NewNoop(), accounts.NewRepository(tracer.NewNoop(), db), holdersRepo, false, 0)
+	account, err := accSvc.Create(ctx, accounts.Account{
+		Name:           gofakeit.Name(),
+		DocumentNumber: holderModel.DocumentNumber,
+		Type:           products.CheckingType,
+	})
+	assert.NoError(t, err)
+
+	repo := NewRepository(tracer.NewNoop(), db)
+	date := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC)
+
+	create := func(t *testing.T, reference string, debited bool) transferModel {
+		model, err := repo.Create(ctx, transferModel{
+			ID:             uuid.New(),
+			AccountID:      account.ID,
+			Amount:         150,
+			BankCode:       "237",
+			Agency:         "1234",
+			AccountNumber:  "12345-6",
+			DocumentNumber: "52998224725",
+			Name:           gofakeit.Name(),
+			Reference:      reference,
+			Status:         PendingStatus,
+			CreatedAt:      date.Add(10 * time.Hour),
+		})
+		assert.NoError(t, err)
+
+		if debited {
+			_, err = db.Master().ExecContext(
+				ctx,
+				"INSERT INTO transactions (id, from_account_id, to_account_id, type, amount, description, idempotency_key, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)", //nolint:lll
+				uuid.New(),
+				account.ID,
+				"00000000-0000-0000-0000-000000000004",
+				"TED",
+				150,
+				"ted "+reference,
+				debitKey(model.ID),
+				time.Now().UTC(),
+			)
+			assert.NoError(t, err)
+		}
+
+		return model
+	}
+
+	t.Run("no transfers to remit", func(t *testing.T) {
+		_, err := repo.CreateRemittance(ctx, date.AddDate(0, 0, -10))
+		assert.ErrorIs(t, err, errNoPendingTransfersToRemit)
+	})
+
+	t.Run("transfer sent once and confirmed once", func(t *testing.T) {
+		debited := create(t, "AAAAAAAAAAAAAAAAAAAA", true)
+		notDebited := create(t, "BBBBBBBBBBBBBBBBBBBB", false)
+
+		found, err := repo.GetByReference(ctx, debited.Reference)
+		assert.NoError(t, err)
+		assert.True(t, found.DebitTransactionID.Valid)
+
+		found, err = repo.GetByID(ctx, notDebited.ID)
+		assert.NoError(t, err)
+		assert.False(t, found.DebitTransactionID.Valid)
+
+		remittance, err := repo.CreateRemittance(ctx, date)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, remittance.TransferCount)
+		assert.Equal(t, 150.0, remittance.TotalAmount)
+		assert.NotZero(t, remittance.Sequence)
+
+		_, err = repo.CreateRemittance(ctx, date)
+		assert.ErrorIs(t, err, errRemittanceAlreadyExists)
+
+		sent, err := repo.ListByRemittanceID(ctx, remittance.ID)
+		assert.NoError(t, err)
+		assert.Len(t, sent, 1)
+		assert.Equal(t, debited.ID, sent[0].ID)
+		assert.Equal(t, SentStatus, sent[0].Status)
+
+		err = repo.MarkConfirmed(ctx, debited.ID, "00", time.Now().UTC())
+		assert.NoError(t, err)
+
+		err = repo.MarkReversed(ctx, debited.ID, "AP", time.Now().UTC(), uuid.New())
+		assert.ErrorIs(t, err, errTransferNotSent)
+
+		err = repo.Delete(ctx, notDebited.ID)
+		assert.NoError(t, err)
+
+		_, err = repo.GetByID(ctx, notDebited.ID)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	t.Run("transfer not found", func(t *testing.T) {
+		_, err := repo.GetByReference(ctx, "CCCCCCCCCCCCCCCCCCCC")
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+
+		_, err = repo.GetRemittanceByID(ctx, uuid.New())
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
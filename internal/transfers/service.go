@@ -0,0 +1,439 @@
+package transfers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/exchange"
+	"github.com/dalmarcogd/dock-test/internal/transactions"
+	"github.com/dalmarcogd/dock-test/pkg/document"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// currency is the only currency TEDs are made in.
+const currency exchange.Currency = "BRL"
+
+var (
+	bankCodeRegexp      = regexp.MustCompile(`^[0-9]{3}$`)
+	agencyRegexp        = regexp.MustCompile(`^[0-9]{1,5}$`)
+	accountNumberRegexp = regexp.MustCompile(`^[0-9]{1,12}-[0-9xX]$`)
+)
+
+var (
+	ErrTransferNotFound              = errors.New("no transfer found with this id")
+	ErrInvalidTransferAmount         = errors.New("the transfer amount must be greater than zero")
+	ErrInvalidBeneficiary            = errors.New("the beneficiary must have a bank code, agency, account number, name and a valid CPF or CNPJ") //nolint:lll
+	ErrRemittanceNotFound            = errors.New("no remittance found with this id")
+	ErrRemittanceAlreadyGenerated    = errors.New("the remittance of this date was already generated")
+	ErrNoTransfersToRemit            = errors.New("there are no pending transfers to remit up to this date")
+	errTransferSettlementUnavailable = errors.New("the transfer can not be settled now")
+)
+
+type Service interface {
+	Create(ctx context.Context, transfer Transfer) (Transfer, error)
+	GetByID(ctx context.Context, id uuid.UUID) (Transfer, error)
+	GenerateRemittance(ctx context.Context, date time.Time) (Remittance, error)
+	GetRemittanceByID(ctx context.Context, id uuid.UUID) (Remittance, error)
+	GetRemittanceFile(ctx context.Context, id uuid.UUID) (string, error)
+	ImportReturnFile(ctx context.Context, file io.Reader) (ImportResult, error)
+}
+
+type service struct {
+	tracer            tracer.Tracer
+	repository        Repository
+	accountsSvc       accounts.Service
+	transactionsSvc   transactions.Service
+	clearingAccountID uuid.UUID
+	originator        Originator
+}
+
+func NewService(
+	t tracer.Tracer,
+	r Repository,
+	as accounts.Service,
+	ts transactions.Service,
+	clearingAccountID uuid.UUID,
+	originator Originator,
+) Service {
+	return service{
+		tracer:            t,
+		repository:        r,
+		accountsSvc:       as,
+		transactionsSvc:   ts,
+		clearingAccountID: clearingAccountID,
+		originator:        originator,
+	}
+}
+
+// Create debits the transfer from the account into the clearing account, where the funds wait for the
+// next remittance file. The transfer is recorded first and removed when the debit fails, so every
+// debit made has its transfer.
+func (s service) Create(ctx context.Context, transfer Transfer) (Transfer, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	transfer.Beneficiary.DocumentNumber = document.Normalize(transfer.Beneficiary.DocumentNumber)
+	transfer.Beneficiary.AccountNumber = strings.ToUpper(transfer.Beneficiary.AccountNumber)
+
+	err := validate(transfer)
+	if err != nil {
+		span.RecordError(err)
+		return Transfer{}, err
+	}
+
+	transfer.ID = uuid.New()
+	transfer.Reference = reference(transfer.ID)
+	transfer.Status = PendingStatus
+	transfer.CreatedAt = time.Now().UTC()
+
+	model, err := s.repository.Create(ctx, newTransferModel(transfer))
+	if err != nil {
+		zapctx.L(ctx).Error("transfer_service_create_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return Transfer{}, err
+	}
+
+	debit, err := s.transactionsSvc.CreateTED(ctx, transactions.Transaction{
+		From:           transfer.AccountID,
+		To:             s.clearingAccountID,
+		Amount:         transfer.Amount,
+		Currency:       currency,
+		Description:    description(transfer),
+		RequestedBy:    transfer.RequestedBy,
+		IdempotencyKey: debitKey(transfer.ID),
+		Approved:       transfer.Approved,
+	})
+	if err != nil {
+		span.RecordError(err)
+		if errDelete := s.repository.Delete(ctx, transfer.ID); errDelete != nil {
+			zapctx.L(ctx).Error(
+				"transfer_service_delete_repository_error",
+				zap.String("transfer_id", transfer.ID.String()),
+				zap.Error(errDelete),
+			)
+		}
+		return Transfer{}, err
+	}
+
+	created := newTransfer(model)
+	created.DebitTransactionID = uuid.NullUUID{UUID: debit.ID, Valid: true}
+	created.RequestedBy = transfer.RequestedBy
+
+	zapctx.L(ctx).Info(
+		"transfer_created",
+		zap.String("transfer_id", created.ID.String()),
+		zap.String("account_id", created.AccountID.String()),
+		zap.String("transaction_id", debit.ID.String()),
+		zap.Float64("amount", created.Amount),
+	)
+
+	return created, nil
+}
+
+func validate(transfer Transfer) error {
+	if transfer.Amount <= 0 {
+		return ErrInvalidTransferAmount
+	}
+
+	beneficiary := transfer.Beneficiary
+	if !bankCodeRegexp.MatchString(beneficiary.BankCode) ||
+		!agencyRegexp.MatchString(beneficiary.Agency) ||
+		!accountNumberRegexp.MatchString(beneficiary.AccountNumber) ||
+		strings.TrimSpace(beneficiary.Name) == "" ||
+		!document.IsValid(beneficiary.DocumentNumber) {
+		return ErrInvalidBeneficiary
+	}
+
+	return nil
+}
+
+func (s service) GetByID(ctx context.Context, id uuid.UUID) (Transfer, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	model, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return Transfer{}, ErrTransferNotFound
+		}
+		zapctx.L(ctx).Error("transfer_service_get_repository_error", zap.Error(err))
+		return Transfer{}, err
+	}
+
+	return newTransfer(model), nil
+}
+
+// GenerateRemittance sends in the remittance of the date every pending transfer created up to its end.
+// Each date has a single remittance, so transfers created after it are sent the next day.
+func (s service) GenerateRemittance(ctx context.Context, date time.Time) (Remittance, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	model, err := s.repository.CreateRemittance(ctx, date)
+	if err != nil {
+		span.RecordError(err)
+		switch {
+		case errors.Is(err, errRemittanceAlreadyExists):
+			return Remittance{}, ErrRemittanceAlreadyGenerated
+		case errors.Is(err, errNoPendingTransfersToRemit):
+			return Remittance{}, ErrNoTransfersToRemit
+		}
+		zapctx.L(ctx).Error("transfer_service_create_remittance_repository_error", zap.Error(err))
+		return Remittance{}, err
+	}
+
+	zapctx.L(ctx).Info(
+		"transfer_remittance_generated",
+		zap.String("remittance_id", model.ID.String()),
+		zap.Int64("sequence", model.Sequence),
+		zap.Time("date", model.Date),
+		zap.Int("transfers", model.TransferCount),
+		zap.Float64("total_amount", model.TotalAmount),
+	)
+
+	return newRemittance(model), nil
+}
+
+func (s service) GetRemittanceByID(ctx context.Context, id uuid.UUID) (Remittance, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	model, err := s.repository.GetRemittanceByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return Remittance{}, ErrRemittanceNotFound
+		}
+		zapctx.L(ctx).Error("transfer_service_get_remittance_repository_error", zap.Error(err))
+		return Remittance{}, err
+	}
+
+	return newRemittance(model), nil
+}
+
+// GetRemittanceFile writes the CNAB 240 file of the remittance. The file only depends on the
+// remittance and its transfers, so downloading it again gives the same file.
+func (s service) GetRemittanceFile(ctx context.Context, id uuid.UUID) (string, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	remittance, err := s.GetRemittanceByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		return "", err
+	}
+
+	models, err := s.repository.ListByRemittanceID(ctx, id)
+	if err != nil {
+		zapctx.L(ctx).Error("transfer_service_list_by_remittance_repository_error", zap.Error(err))
+		span.RecordError(err)
+		return "", err
+	}
+
+	clearing, err := s.accountsSvc.GetByID(ctx, s.clearingAccountID)
+	if err != nil {
+		zapctx.L(ctx).Error("transfer_service_get_clearing_account_error", zap.Error(err))
+		span.RecordError(err)
+		return "", err
+	}
+
+	transfers := make([]Transfer, 0, len(models))
+	for _, model := range models {
+		transfers = append(transfers, newTransfer(model))
+	}
+
+	return encodeRemittance(s.originator, clearing, remittance, transfers), nil
+}
+
+// ImportReturnFile confirms the transfers the bank credited and reverses the ones it rejected,
+// returning their funds from the clearing account. Each transfer is reversed once, so the same file
+// can be imported again to retry the records that failed.
+func (s service) ImportReturnFile(ctx context.Context, file io.Reader) (ImportResult, error) {
+	ctx, span := s.tracer.Span(ctx)
+	defer span.End()
+
+	records, err := parseReturnFile(file)
+	if err != nil {
+		zapctx.L(ctx).Error("transfer_service_parse_return_file_error", zap.Error(err))
+		span.RecordError(err)
+		return ImportResult{}, err
+	}
+
+	result := ImportResult{Records: make([]RecordResult, 0, len(records))}
+	for _, record := range records {
+		result.Records = append(result.Records, s.settle(ctx, record))
+	}
+
+	zapctx.L(ctx).Info(
+		"transfer_return_file_imported",
+		zap.Int("records", len(result.Records)),
+		zap.Int("confirmed", result.Count(ConfirmedOutcome)),
+		zap.Int("reversed", result.Count(ReversedOutcome)),
+		zap.Int("accepted", result.Count(AcceptedOutcome)),
+		zap.Int("already_processed", result.Count(AlreadyProcessedOutcome)),
+		zap.Int("not_found", result.Count(NotFoundOutcome)),
+		zap.Int("failed", result.Count(FailedOutcome)),
+	)
+
+	return result, nil
+}
+
+func (s service) settle(ctx context.Context, record returnRecord) RecordResult {
+	result := RecordResult{
+		Line:        record.Line,
+		Reference:   record.Reference,
+		Occurrences: record.Occurrences,
+	}
+
+	model, err := s.repository.GetByReference(ctx, record.Reference)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			zapctx.L(ctx).Warn("transfer_service_settle_not_found", zap.String("reference", record.Reference))
+			result.Outcome = NotFoundOutcome
+			return result
+		}
+		zapctx.L(ctx).Error("transfer_service_settle_get_repository_error", zap.Error(err))
+		return failed(result, errTransferSettlementUnavailable)
+	}
+
+	transfer := newTransfer(model)
+	result.TransferID = uuid.NullUUID{UUID: transfer.ID, Valid: true}
+
+	switch transfer.Status {
+	case ConfirmedStatus:
+		result.Outcome = AlreadyProcessedOutcome
+		result.TransactionID = transfer.DebitTransactionID
+		return result
+	case ReversedStatus:
+		result.Outcome = AlreadyProcessedOutcome
+		result.TransactionID = transfer.ReversalTransactionID
+		return result
+	case PendingStatus:
+		zapctx.L(ctx).Warn("transfer_service_settle_not_sent", zap.String("transfer_id", transfer.ID.String()))
+		result.Outcome = NotFoundOutcome
+		return result
+	}
+
+	switch {
+	case record.scheduled():
+		result.Outcome = AcceptedOutcome
+		result.TransactionID = transfer.DebitTransactionID
+		return result
+	case record.paid():
+		return s.confirm(ctx, transfer, record, result)
+	default:
+		return s.reverse(ctx, transfer, record, result)
+	}
+}
+
+func (s service) confirm(ctx context.Context, transfer Transfer, record returnRecord, result RecordResult) RecordResult {
+	result.TransactionID = transfer.DebitTransactionID
+
+	err := s.repository.MarkConfirmed(ctx, transfer.ID, record.Occurrences, time.Now().UTC())
+	if errors.Is(err, errTransferNotSent) {
+		result.Outcome = AlreadyProcessedOutcome
+		return result
+	} else if err != nil {
+		zapctx.L(ctx).Error(
+			"transfer_service_mark_confirmed_repository_error",
+			zap.String("transfer_id", transfer.ID.String()),
+			zap.Error(err),
+		)
+		return failed(result, errTransferSettlementUnavailable)
+	}
+
+	result.Outcome = ConfirmedOutcome
+	zapctx.L(ctx).Info("transfer_confirmed", zap.String("transfer_id", transfer.ID.String()))
+
+	return result
+}
+
+// reverse returns the funds of the rejected transfer to its account. The reversal carries an
+// idempotency key of the transfer, so a rejection reported twice returns the funds once. The fee
+// charged for the transfer is kept.
+func (s service) reverse(ctx context.Context, transfer Transfer, record returnRecord, result RecordResult) RecordResult {
+	reversal, err := s.transactionsSvc.ReverseTED(ctx, transactions.Transaction{
+		From:           s.clearingAccountID,
+		To:             transfer.AccountID,
+		Amount:         transfer.Amount,
+		Currency:       currency,
+		Description:    fmt.Sprintf("reversal of %s", description(transfer)),
+		IdempotencyKey: reversalKey(transfer.ID),
+	})
+	if errors.Is(err, transactions.ErrTransactionAlreadyMade) {
+		reversal.ID, err = s.repository.GetTransactionID(ctx, reversalKey(transfer.ID))
+		if err != nil {
+			zapctx.L(ctx).Error("transfer_service_get_reversal_transaction_error", zap.Error(err))
+			return failed(result, errTransferSettlementUnavailable)
+		}
+	} else if err != nil {
+		zapctx.L(ctx).Error(
+			"transfer_service_reverse_error",
+			zap.String("transfer_id", transfer.ID.String()),
+			zap.Error(err),
+		)
+		return failed(result, err)
+	}
+	result.TransactionID = uuid.NullUUID{UUID: reversal.ID, Valid: true}
+
+	err = s.repository.MarkReversed(ctx, transfer.ID, record.Occurrences, time.Now().UTC(), reversal.ID)
+	if errors.Is(err, errTransferNotSent) {
+		result.Outcome = AlreadyProcessedOutcome
+		return result
+	} else if err != nil {
+		zapctx.L(ctx).Error(
+			"transfer_service_mark_reversed_repository_error",
+			zap.String("transfer_id", transfer.ID.String()),
+			zap.String("transaction_id", reversal.ID.String()),
+			zap.Error(err),
+		)
+		return failed(result, errTransferSettlementUnavailable)
+	}
+
+	result.Outcome = ReversedOutcome
+	zapctx.L(ctx).Info(
+		"transfer_reversed",
+		zap.String("transfer_id", transfer.ID.String()),
+		zap.String("transaction_id", reversal.ID.String()),
+		zap.String("occurrences", record.Occurrences),
+	)
+
+	return result
+}
+
+func failed(result RecordResult, err error) RecordResult {
+	result.Outcome = FailedOutcome
+	result.Reason = err.Error()
+	return result
+}
+
+// reference identifies the transfer in the files, which have 20 characters for it.
+func reference(id uuid.UUID) string {
+	return strings.ToUpper(strings.ReplaceAll(id.String(), "-", "")[:20])
+}
+
+func description(transfer Transfer) string {
+	return fmt.Sprintf("ted %s to %s %s %s", transfer.Reference, transfer.Beneficiary.BankCode,
+		transfer.Beneficiary.Agency, transfer.Beneficiary.AccountNumber)
+}
+
+func debitKey(id uuid.UUID) string {
+	return "transfer:" + id.String()
+}
+
+func reversalKey(id uuid.UUID) string {
+	return "transfer-reversal:" + id.String()
+}
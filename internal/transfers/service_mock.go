@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/transfers/service.go
+
+// Package transfers is a generated GoMock package.
+package transfers
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockService is a mock of Service interface.
+type MockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceMockRecorder
+}
+
+// MockServiceMockRecorder is the mock recorder for MockService.
+type MockServiceMockRecorder struct {
+	mock *MockService
+}
+
+// NewMockService creates a new mock instance.
+func NewMockService(ctrl *gomock.Controller) *MockService {
+	mock := &MockService{ctrl: ctrl}
+	mock.recorder = &MockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockService) EXPECT() *MockServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockService) Create(ctx context.Context, transfer Transfer) (Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, transfer)
+	ret0, _ := ret[0].(Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockServiceMockRecorder) Create(ctx, transfer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockService)(nil).Create), ctx, transfer)
+}
+
+// GenerateRemittance mocks base method.
+func (m *MockService) GenerateRemittance(ctx context.Context, date time.Time) (Remittance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateRemittance", ctx, date)
+	ret0, _ := ret[0].(Remittance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateRemittance indicates an expected call of GenerateRemittance.
+func (mr *MockServiceMockRecorder) GenerateRemittance(ctx, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateRemittance", reflect.TypeOf((*MockService)(nil).GenerateRemittance), ctx, date)
+}
+
+// GetByID mocks base method.
+func (m *MockService) GetByID(ctx context.Context, id uuid.UUID) (Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockServiceMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockService)(nil).GetByID), ctx, id)
+}
+
+// GetRemittanceByID mocks base method.
+func (m *MockService) GetRemittanceByID(ctx context.Context, id uuid.UUID) (Remittance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRemittanceByID", ctx, id)
+	ret0, _ := ret[0].(Remittance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRemittanceByID indicates an expected call of GetRemittanceByID.
+func (mr *MockServiceMockRecorder) GetRemittanceByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemittanceByID", reflect.TypeOf((*MockService)(nil).GetRemittanceByID), ctx, id)
+}
+
+// GetRemittanceFile mocks base method.
+func (m *MockService) GetRemittanceFile(ctx context.Context, id uuid.UUID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRemittanceFile", ctx, id)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRemittanceFile indicates an expected call of GetRemittanceFile.
+func (mr *MockServiceMockRecorder) GetRemittanceFile(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemittanceFile", reflect.TypeOf((*MockService)(nil).GetRemittanceFile), ctx, id)
+}
+
+// ImportReturnFile mocks base method.
+func (m *MockService) ImportReturnFile(ctx context.Context, file io.Reader) (ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportReturnFile", ctx, file)
+	ret0, _ := ret[0].(ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportReturnFile indicates an expected call of ImportReturnFile.
+func (mr *MockServiceMockRecorder) ImportReturnFile(ctx, file interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportReturnFile", reflect.TypeOf((*MockService)(nil).ImportReturnFile), ctx, file)
+}
@@ -0,0 +1,343 @@
+//go:build unit
+
+package transfers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dalmarcogd/dock-test/internal/accounts"
+	"github.com/dalmarcogd/dock-test/internal/transactions"
+	"github.com/dalmarcogd/dock-test/pkg/tracer"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var originator = Originator{BankCode: "999", DocumentNumber: "11222333000181", Name: "Dock Test"}
+
+func TestService_Create(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	transactionsMock := transactions.NewMockService(ctrl)
+	clearingAccountID := uuid.New()
+	svc := NewService(
+		tracer.NewNoop(),
+		repoMock,
+		accounts.NewMockService(ctrl),
+		transactionsMock,
+		clearingAccountID,
+		originator,
+	)
+
+	transfer := Transfer{
+		AccountID: uuid.New(),
+		Amount:    150,
+		Beneficiary: Beneficiary{
+			BankCode:       "237",
+			Agency:         "1234",
+			AccountNumber:  "12345-6",
+			DocumentNumber: "529.982.247-25",
+			Name:           "João da Silva",
+		},
+		RequestedBy: "52998224725",
+	}
+
+	t.Run("fail create, invalid amount", func(t *testing.T) {
+		invalid := transfer
+		invalid.Amount = 0
+
+		created, err := svc.Create(ctx, invalid)
+		assert.ErrorIs(t, err, ErrInvalidTransferAmount)
+		assert.Empty(t, created)
+	})
+
+	t.Run("fail create, invalid beneficiary", func(t *testing.T) {
+		for _, beneficiary := range []Beneficiary{
+			{BankCode: "23", Agency: "1234", AccountNumber: "12345-6", DocumentNumber: "52998224725", Name: "João"},
+			{BankCode: "237", Agency: "123456", AccountNumber: "12345-6", DocumentNumber: "52998224725", Name: "João"},
+			{BankCode: "237", Agency: "1234", AccountNumber: "123456", DocumentNumber: "52998224725", Name: "João"},
+			{BankCode: "237", Agency: "1234", AccountNumber: "12345-6", DocumentNumber: "52998224726", Name: "João"},
+			{BankCode: "237", Agency: "1234", AccountNumber: "12345-6", DocumentNumber: "52998224725", Name: " "},
+		} {
+			invalid := transfer
+			invalid.Beneficiary = beneficiary
+
+			created, err := svc.Create(ctx, invalid)
+			assert.ErrorIs(t, err, ErrInvalidBeneficiary)
+			assert.Empty(t, created)
+		}
+	})
+
+	t.Run("fail create, debit refused", func(t *testing.T) {
+		var model transferModel
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, m transferModel) (transferModel, error) {
+				model = m
+				return m, nil
+			})
+		transactionsMock.EXPECT().
+			CreateTED(ctx, gomock.Any()).
+			Return(transactions.Transaction{}, transactions.ErrBalanceInsufficientFunds)
+		repoMock.EXPECT().
+			Delete(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, id uuid.UUID) error {
+				assert.Equal(t, model.ID, id)
+				return nil
+			})
+
+		created, err := svc.Create(ctx, transfer)
+		assert.ErrorIs(t, err, transactions.ErrBalanceInsufficientFunds)
+		assert.Empty(t, created)
+	})
+
+	t.Run("success create", func(t *testing.T) {
+		debitID := uuid.New()
+		repoMock.EXPECT().
+			Create(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, m transferModel) (transferModel, error) {
+				assert.Equal(t, PendingStatus, m.Status)
+				assert.Equal(t, "52998224725", m.DocumentNumber)
+				assert.Equal(t, strings.ToUpper(strings.ReplaceAll(m.ID.String(), "-", ""))[:20], m.Reference)
+				return m, nil
+			})
+		transactionsMock.EXPECT().
+			CreateTED(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, tx transactions.Transaction) (transactions.Transaction, error) {
+				assert.Equal(t, transfer.AccountID, tx.From)
+				assert.Equal(t, clearingAccountID, tx.To)
+				assert.Equal(t, 150.0, tx.Amount)
+				assert.Equal(t, "52998224725", tx.RequestedBy)
+				assert.True(t, strings.HasPrefix(tx.IdempotencyKey, "transfer:"))
+				tx.ID = debitID
+				return tx, nil
+			})
+
+		created, err := svc.Create(ctx, transfer)
+		assert.NoError(t, err)
+		assert.Equal(t, PendingStatus, created.Status)
+		assert.Equal(t, debitID, created.DebitTransactionID.UUID)
+		assert.Len(t, created.Reference, 20)
+	})
+}
+
+func TestService_GenerateRemittance(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	accountsMock := accounts.NewMockService(ctrl)
+	clearingAccountID := uuid.New()
+	svc := NewService(
+		tracer.NewNoop(),
+		repoMock,
+		accountsMock,
+		transactions.NewMockService(ctrl),
+		clearingAccountID,
+		originator,
+	)
+
+	date := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC)
+
+	t.Run("fail generate, already generated", func(t *testing.T) {
+		repoMock.EXPECT().CreateRemittance(ctx, date).Return(remittanceModel{}, errRemittanceAlreadyExists)
+
+		remittance, err := svc.GenerateRemittance(ctx, date.Add(15*time.Hour))
+		assert.ErrorIs(t, err, ErrRemittanceAlreadyGenerated)
+		assert.Empty(t, remittance)
+	})
+
+	t.Run("fail generate, nothing to remit", func(t *testing.T) {
+		repoMock.EXPECT().CreateRemittance(ctx, date).Return(remittanceModel{}, errNoPendingTransfersToRemit)
+
+		remittance, err := svc.GenerateRemittance(ctx, date)
+		assert.ErrorIs(t, err, ErrNoTransfersToRemit)
+		assert.Empty(t, remittance)
+	})
+
+	t.Run("success generate and download", func(t *testing.T) {
+		model := remittanceModel{
+			ID:            uuid.New(),
+			Sequence:      3,
+			Date:          date,
+			TransferCount: 1,
+			TotalAmount:   150,
+			CreatedAt:     date.Add(25 * time.Hour),
+		}
+		repoMock.EXPECT().CreateRemittance(ctx, date).Return(model, nil)
+
+		remittance, err := svc.GenerateRemittance(ctx, date)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), remittance.Sequence)
+		assert.Equal(t, 1, remittance.TransferCount)
+
+		repoMock.EXPECT().GetRemittanceByID(ctx, model.ID).Return(model, nil)
+		repoMock.EXPECT().ListByRemittanceID(ctx, model.ID).Return([]transferModel{{
+			ID:             uuid.New(),
+			Amount:         150,
+			BankCode:       "237",
+			Agency:         "1234",
+			AccountNumber:  "12345-6",
+			DocumentNumber: "52998224725",
+			Name:           "João da Silva",
+			Reference:      "0A1B2C3D4E5F60718293",
+			Status:         SentStatus,
+		}}, nil)
+		accountsMock.EXPECT().
+			GetByID(ctx, clearingAccountID).
+			Return(accounts.Account{ID: clearingAccountID, Agency: "0001", Number: "000003-5"}, nil)
+
+		file, err := svc.GetRemittanceFile(ctx, model.ID)
+		assert.NoError(t, err)
+		assert.Contains(t, file, "0A1B2C3D4E5F60718293")
+		assert.Len(t, strings.Split(strings.TrimSuffix(file, "\r\n"), "\r\n"), 6)
+	})
+
+	t.Run("fail download, remittance not found", func(t *testing.T) {
+		id := uuid.New()
+		repoMock.EXPECT().GetRemittanceByID(ctx, id).Return(remittanceModel{}, sql.ErrNoRows)
+
+		file, err := svc.GetRemittanceFile(ctx, id)
+		assert.ErrorIs(t, err, ErrRemittanceNotFound)
+		assert.Empty(t, file)
+	})
+}
+
+func TestService_ImportReturnFile(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repoMock := NewMockRepository(ctrl)
+	transactionsMock := transactions.NewMockService(ctrl)
+	clearingAccountID := uuid.New()
+	svc := NewService(
+		tracer.NewNoop(),
+		repoMock,
+		accounts.NewMockService(ctrl),
+		transactionsMock,
+		clearingAccountID,
+		originator,
+	)
+
+	sent := func(reference string) transferModel {
+		return transferModel{
+			ID:                 uuid.New(),
+			AccountID:          uuid.New(),
+			Amount:             150,
+			BankCode:           "237",
+			Agency:             "1234",
+			AccountNumber:      "12345-6",
+			Reference:          reference,
+			Status:             SentStatus,
+			DebitTransactionID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		}
+	}
+	confirmed := sent("0A1B2C3D4E5F60718293")
+	scheduled := sent("1B2C3D4E5F6071829304")
+	rejected := sent("2C3D4E5F607182930415")
+
+	t.Run("fail import, invalid file", func(t *testing.T) {
+		result, err := svc.ImportReturnFile(ctx, strings.NewReader(strings.Repeat("0", 240)))
+		assert.ErrorIs(t, err, ErrInvalidReturnFile)
+		assert.Empty(t, result)
+	})
+
+	t.Run("success import", func(t *testing.T) {
+		file, err := os.Open("testdata/return_cnab240.ret")
+		assert.NoError(t, err)
+		defer file.Close()
+
+		reversalID := uuid.New()
+
+		repoMock.EXPECT().GetByReference(ctx, confirmed.Reference).Return(confirmed, nil)
+		repoMock.EXPECT().MarkConfirmed(ctx, confirmed.ID, "00", gomock.Any()).Return(nil)
+
+		repoMock.EXPECT().GetByReference(ctx, scheduled.Reference).Return(scheduled, nil)
+
+		repoMock.EXPECT().GetByReference(ctx, rejected.Reference).Return(rejected, nil)
+		transactionsMock.EXPECT().
+			ReverseTED(ctx, transactions.Transaction{
+				From:           clearingAccountID,
+				To:             rejected.AccountID,
+				Amount:         150,
+				Currency:       "BRL",
+				Description:    "reversal of ted 2C3D4E5F607182930415 to 237 1234 12345-6",
+				IdempotencyKey: "transfer-reversal:" + rejected.ID.String(),
+			}).
+			Return(transactions.Transaction{ID: reversalID}, nil)
+		repoMock.EXPECT().MarkReversed(ctx, rejected.ID, "AP", gomock.Any(), reversalID).Return(nil)
+
+		repoMock.EXPECT().GetByReference(ctx, "FFFFFFFFFFFFFFFFFFFF").Return(transferModel{}, sql.ErrNoRows)
+
+		result, err := svc.ImportReturnFile(ctx, file)
+		assert.NoError(t, err)
+		assert.Len(t, result.Records, 4)
+		assert.Equal(t, ConfirmedOutcome, result.Records[0].Outcome)
+		assert.Equal(t, confirmed.DebitTransactionID, result.Records[0].TransactionID)
+		assert.Equal(t, AcceptedOutcome, result.Records[1].Outcome)
+		assert.Equal(t, ReversedOutcome, result.Records[2].Outcome)
+		assert.Equal(t, reversalID, result.Records[2].TransactionID.UUID)
+		assert.Equal(t, NotFoundOutcome, result.Records[3].Outcome)
+	})
+
+	t.Run("success import, imported again", func(t *testing.T) {
+		file, err := os.Open("testdata/return_cnab240.ret")
+		assert.NoError(t, err)
+		defer file.Close()
+
+		done := confirmed
+		done.Status = ConfirmedStatus
+		reversalID := uuid.New()
+
+		repoMock.EXPECT().GetByReference(ctx, confirmed.Reference).Return(done, nil)
+		repoMock.EXPECT().GetByReference(ctx, scheduled.Reference).Return(scheduled, nil)
+		repoMock.EXPECT().GetByReference(ctx, rejected.Reference).Return(rejected, nil)
+		transactionsMock.EXPECT().
+			ReverseTED(ctx, gomock.Any()).
+			Return(transactions.Transaction{}, transactions.ErrTransactionAlreadyMade)
+		repoMock.EXPECT().
+			GetTransactionID(ctx, "transfer-reversal:"+rejected.ID.String()).
+			Return(reversalID, nil)
+		repoMock.EXPECT().MarkReversed(ctx, rejected.ID, "AP", gomock.Any(), reversalID).Return(errTransferNotSent)
+		repoMock.EXPECT().GetByReference(ctx, "FFFFFFFFFFFFFFFFFFFF").Return(transferModel{}, sql.ErrNoRows)
+
+		result, err := svc.ImportReturnFile(ctx, file)
+		assert.NoError(t, err)
+		assert.Equal(t, AlreadyProcessedOutcome, result.Records[0].Outcome)
+		assert.Equal(t, AcceptedOutcome, result.Records[1].Outcome)
+		assert.Equal(t, AlreadyProcessedOutcome, result.Records[2].Outcome)
+		assert.Equal(t, reversalID, result.Records[2].TransactionID.UUID)
+	})
+
+	t.Run("success import, failed records are reported", func(t *testing.T) {
+		file, err := os.Open("testdata/return_cnab240.ret")
+		assert.NoError(t, err)
+		defer file.Close()
+
+		repoMock.EXPECT().GetByReference(ctx, confirmed.Reference).Return(transferModel{}, errors.New("timeout"))
+		repoMock.EXPECT().GetByReference(ctx, scheduled.Reference).Return(scheduled, nil)
+		repoMock.EXPECT().GetByReference(ctx, rejected.Reference).Return(rejected, nil)
+		transactionsMock.EXPECT().
+			ReverseTED(ctx, gomock.Any()).
+			Return(transactions.Transaction{}, errors.New("timeout"))
+		repoMock.EXPECT().GetByReference(ctx, "FFFFFFFFFFFFFFFFFFFF").Return(transferModel{}, sql.ErrNoRows)
+
+		result, err := svc.ImportReturnFile(ctx, file)
+		assert.NoError(t, err)
+		assert.Equal(t, FailedOutcome, result.Records[0].Outcome)
+		assert.Equal(t, errTransferSettlementUnavailable.Error(), result.Records[0].Reason)
+		assert.Equal(t, FailedOutcome, result.Records[2].Outcome)
+		assert.Equal(t, 2, result.Count(FailedOutcome))
+	})
+}
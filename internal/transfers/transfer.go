@@ -0,0 +1,161 @@
+package transfers
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Status string
+
+const (
+	// PendingStatus is a transfer debited from the account, waiting for the next remittance file.
+	PendingStatus Status = "PENDING"
+	// SentStatus is a transfer sent in a remittance file, waiting for the return file of the bank.
+	SentStatus Status = "SENT"
+	// ConfirmedStatus is a transfer the bank credited to the beneficiary.
+	ConfirmedStatus Status = "CONFIRMED"
+	// ReversedStatus is a transfer the bank rejected, whose funds returned to the account.
+	ReversedStatus Status = "REVERSED"
+)
+
+// Beneficiary is the account in another bank credited by the transfer.
+type Beneficiary struct {
+	BankCode string
+	Agency   string
+	// AccountNumber ends with the check digit after a dash, as in 12345-6.
+	AccountNumber  string
+	DocumentNumber string
+	Name           string
+}
+
+// number splits the account number from its check digit.
+func (b Beneficiary) number() (string, string) {
+	i := strings.LastIndex(b.AccountNumber, "-")
+	if i < 0 {
+		return b.AccountNumber, ""
+	}
+	return b.AccountNumber[:i], b.AccountNumber[i+1:]
+}
+
+// Transfer is a TED to an account in another bank, debited into the clearing account when created
+// and confirmed or reversed by the bank after being sent in a remittance file.
+type Transfer struct {
+	ID          uuid.UUID
+	AccountID   uuid.UUID
+	Amount      float64
+	Beneficiary Beneficiary
+	Description string
+	// RequestedBy is the id of the principal sending the transfer.
+	RequestedBy string
+	// Approved transfers were approved by a second principal, so they are sent above the approval
+	// threshold.
+	Approved bool
+	// Reference identifies the transfer in remittance and return files.
+	Reference             string
+	Status                Status
+	DebitTransactionID    uuid.NullUUID
+	ReversalTransactionID uuid.NullUUID
+	RemittanceID          uuid.NullUUID
+	// Occurrences are the codes the bank returned for the transfer.
+	Occurrences string
+	CreatedAt   time.Time
+	SettledAt   time.Time
+}
+
+func newTransfer(model transferModel) Transfer {
+	return Transfer{
+		ID:        model.ID,
+		AccountID: model.AccountID,
+		Amount:    model.Amount,
+		Beneficiary: Beneficiary{
+			BankCode:       model.BankCode,
+			Agency:         model.Agency,
+			AccountNumber:  model.AccountNumber,
+			DocumentNumber: model.DocumentNumber,
+			Name:           model.Name,
+		},
+		Description:           model.Description,
+		Reference:             model.Reference,
+		Status:                model.Status,
+		DebitTransactionID:    model.DebitTransactionID,
+		ReversalTransactionID: model.ReversalTransactionID,
+		RemittanceID:          model.RemittanceID,
+		Occurrences:           model.Occurrences,
+		CreatedAt:             model.CreatedAt,
+		SettledAt:             model.SettledAt,
+	}
+}
+
+// Remittance is the daily CNAB 240 file sending the pending transfers to the bank.
+type Remittance struct {
+	ID uuid.UUID
+	// Sequence is the file number the bank expects to grow by one at each file.
+	Sequence      int64
+	Date          time.Time
+	TransferCount int
+	TotalAmount   float64
+	CreatedAt     time.Time
+}
+
+func newRemittance(model remittanceModel) Remittance {
+	return Remittance{
+		ID:            model.ID,
+		Sequence:      model.Sequence,
+		Date:          model.Date,
+		TransferCount: model.TransferCount,
+		TotalAmount:   model.TotalAmount,
+		CreatedAt:     model.CreatedAt,
+	}
+}
+
+// Originator is the company sending the transfers, identified in the headers of remittance files.
+type Originator struct {
+	BankCode       string
+	DocumentNumber string
+	Name           string
+}
+
+// ImportResult is the outcome of each transfer of an imported return file.
+type ImportResult struct {
+	Records []RecordResult
+}
+
+// Count is how many records had the given outcome.
+func (r ImportResult) Count(outcome Outcome) int {
+	var count int
+	for _, record := range r.Records {
+		if record.Outcome == outcome {
+			count++
+		}
+	}
+	return count
+}
+
+type Outcome string
+
+const (
+	// ConfirmedOutcome is a transfer the bank credited to the beneficiary.
+	ConfirmedOutcome Outcome = "CONFIRMED"
+	// ReversedOutcome is a transfer the bank rejected, returned to the account.
+	ReversedOutcome Outcome = "REVERSED"
+	// AcceptedOutcome is a transfer the bank scheduled, still waiting to be confirmed or rejected.
+	AcceptedOutcome Outcome = "ACCEPTED"
+	// AlreadyProcessedOutcome is a transfer already confirmed or reversed, from a file imported twice.
+	AlreadyProcessedOutcome Outcome = "ALREADY_PROCESSED"
+	// NotFoundOutcome is a record whose reference does not match any sent transfer.
+	NotFoundOutcome Outcome = "NOT_FOUND"
+	// FailedOutcome is a record that could not be processed, importing the file again retries it.
+	FailedOutcome Outcome = "FAILED"
+)
+
+type RecordResult struct {
+	Line          int
+	Reference     string
+	Occurrences   string
+	Outcome       Outcome
+	TransferID    uuid.NullUUID
+	TransactionID uuid.NullUUID
+	Reason        string
+}
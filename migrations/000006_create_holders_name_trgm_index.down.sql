@@ -0,0 +1 @@
+DROP INDEX IF EXISTS holders_name_trgm;
@@ -0,0 +1,7 @@
+--
+-- Enable pg_trgm extension
+--
+-- Use to prefix and fuzzy search by holder name
+CREATE EXTENSION IF NOT EXISTS "pg_trgm";
+
+CREATE INDEX IF NOT EXISTS holders_name_trgm ON holders USING GIN (name gin_trgm_ops);
@@ -0,0 +1,2 @@
+ALTER TABLE holders
+    DROP COLUMN IF EXISTS type;
@@ -0,0 +1,29 @@
+ALTER TABLE holders
+    ADD COLUMN IF NOT EXISTS type VARCHAR(20) NULL;
+
+--
+-- Normalize document numbers to digits only
+--
+-- When more than one row ends up with the same digits (e.g. 123.456.789-09 and 12345678909), only the oldest
+-- is normalized, the others are kept as they are to be merged manually instead of breaking holders_document_number.
+WITH normalized AS (SELECT id,
+                           regexp_replace(document_number, '[^0-9]', '', 'g') AS document_number,
+                           row_number() OVER (
+                               PARTITION BY regexp_replace(document_number, '[^0-9]', '', 'g')
+                               ORDER BY document_number = regexp_replace(document_number, '[^0-9]', '', 'g') DESC,
+                                   created_at
+                               )                                              AS position
+                    FROM holders)
+UPDATE holders h
+SET document_number = n.document_number
+FROM normalized n
+WHERE h.id = n.id
+  AND n.position = 1
+  AND h.document_number <> n.document_number;
+
+UPDATE holders
+SET type = CASE length(document_number) WHEN 14 THEN 'COMPANY' ELSE 'INDIVIDUAL' END
+WHERE type IS NULL;
+
+ALTER TABLE holders
+    ALTER COLUMN type SET NOT NULL;
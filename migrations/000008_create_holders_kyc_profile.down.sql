@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS holder_kyc_reviews;
+DROP TABLE IF EXISTS holder_addresses;
+
+ALTER TABLE holders
+    DROP COLUMN IF EXISTS email,
+    DROP COLUMN IF EXISTS phone,
+    DROP COLUMN IF EXISTS birth_date,
+    DROP COLUMN IF EXISTS kyc_status;
@@ -0,0 +1,40 @@
+--
+-- Holder KYC profile
+--
+-- birth_date keeps the incorporation date when the holder is a company
+ALTER TABLE holders
+    ADD COLUMN IF NOT EXISTS email      VARCHAR(254) NULL,
+    ADD COLUMN IF NOT EXISTS phone      VARCHAR(20)  NULL,
+    ADD COLUMN IF NOT EXISTS birth_date DATE         NULL,
+    ADD COLUMN IF NOT EXISTS kyc_status VARCHAR(20)  NOT NULL DEFAULT 'PENDING';
+
+CREATE TABLE IF NOT EXISTS holder_addresses
+(
+    id           VARCHAR(36) PRIMARY KEY,
+    holder_id    VARCHAR(36)  NOT NULL,
+    street       VARCHAR(200) NOT NULL,
+    number       VARCHAR(20)  NOT NULL,
+    complement   VARCHAR(100) NULL,
+    neighborhood VARCHAR(100) NULL,
+    city         VARCHAR(100) NOT NULL,
+    state        VARCHAR(2)   NOT NULL,
+    zip_code     VARCHAR(8)   NOT NULL,
+    country      VARCHAR(2)   NOT NULL DEFAULT 'BR',
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (holder_id) REFERENCES holders (id)
+);
+
+CREATE INDEX holder_addresses_holder_id_index ON holder_addresses (holder_id);
+
+CREATE TABLE IF NOT EXISTS holder_kyc_reviews
+(
+    id         VARCHAR(36) PRIMARY KEY,
+    holder_id  VARCHAR(36)  NOT NULL,
+    status     VARCHAR(20)  NOT NULL,
+    reason     VARCHAR(200) NOT NULL,
+    reviewer   VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (holder_id) REFERENCES holders (id)
+);
+
+CREATE INDEX holder_kyc_reviews_holder_id_index ON holder_kyc_reviews (holder_id);
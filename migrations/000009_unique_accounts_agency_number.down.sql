@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS accounts_agency_number;
+
+-- Restores the numbers the accounts had before the first change.
+UPDATE accounts AS a
+SET number = c.old_number
+FROM (SELECT DISTINCT ON (account_id) account_id, old_number
+      FROM account_number_changes
+      ORDER BY account_id, id) AS c
+WHERE c.account_id = a.id;
+
+DROP TABLE IF EXISTS account_number_changes;
+
+CREATE UNIQUE INDEX accounts_holder_number ON accounts (number, holder_id);
@@ -0,0 +1,87 @@
+CREATE OR REPLACE FUNCTION account_number_check_digit(digits TEXT) RETURNS TEXT AS
+$$
+DECLARE
+    total  INT := 0;
+    weight INT := 2;
+    dv     INT;
+BEGIN
+    FOR i IN REVERSE length(digits)..1
+        LOOP
+            total := total + substr(digits, i, 1)::INT * weight;
+            weight := CASE WHEN weight = 9 THEN 2 ELSE weight + 1 END;
+        END LOOP;
+
+    dv := 11 - (total % 11);
+    IF dv >= 10 THEN
+        dv := 0;
+    END IF;
+
+    RETURN dv::TEXT;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+--
+-- Account number changes
+--
+-- keeps the numbers the accounts had before being changed by this migration, so they can still be found by them.
+CREATE TABLE IF NOT EXISTS account_number_changes
+(
+    id         BIGSERIAL PRIMARY KEY,
+    account_id VARCHAR(36) NOT NULL REFERENCES accounts (id),
+    agency     VARCHAR(4)  NOT NULL,
+    old_number VARCHAR(50) NOT NULL,
+    new_number VARCHAR(50) NOT NULL,
+    reason     VARCHAR(20) NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX account_number_changes_agency_old_number ON account_number_changes (agency, old_number);
+
+-- Appends the check digit to the numbers generated before it existed.
+INSERT INTO account_number_changes (account_id, agency, old_number, new_number, reason)
+SELECT id, agency, number, number || '-' || account_number_check_digit(number), 'CHECK_DIGIT'
+FROM accounts
+WHERE number ~ '^[0-9]{6}$';
+
+UPDATE accounts
+SET number = number || '-' || account_number_check_digit(number)
+WHERE number ~ '^[0-9]{6}$';
+
+-- Renumbers every account but the oldest one sharing the same agency and number.
+DO
+$$
+    DECLARE
+        acc        RECORD;
+        new_number TEXT;
+    BEGIN
+        FOR acc IN
+            SELECT id
+            FROM (SELECT id,
+                         row_number() OVER (PARTITION BY agency, number ORDER BY created_at, id) AS rn
+                  FROM accounts) AS ranked
+            WHERE rn > 1
+            LOOP
+                LOOP
+                    new_number := lpad(floor(random() * 1000000)::INT::TEXT, 6, '0');
+                    new_number := new_number || '-' || account_number_check_digit(new_number);
+                    EXIT WHEN NOT EXISTS(SELECT 1
+                                         FROM accounts AS a
+                                         WHERE a.number = new_number
+                                           AND a.agency = (SELECT agency FROM accounts WHERE id = acc.id));
+                END LOOP;
+
+                INSERT INTO account_number_changes (account_id, agency, old_number, new_number, reason)
+                SELECT id, agency, number, new_number, 'DUPLICATED'
+                FROM accounts
+                WHERE id = acc.id;
+
+                UPDATE accounts SET number = new_number, updated_at = NOW() WHERE id = acc.id;
+            END LOOP;
+    END
+$$;
+
+DROP FUNCTION account_number_check_digit(TEXT);
+
+DROP INDEX IF EXISTS accounts_holder_number;
+
+CREATE UNIQUE INDEX accounts_agency_number ON accounts (agency, number);
@@ -0,0 +1,4 @@
+ALTER TABLE accounts
+    DROP COLUMN IF EXISTS type;
+
+DROP TABLE IF EXISTS products;
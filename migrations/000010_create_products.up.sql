@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS products
+(
+    type              VARCHAR(20) PRIMARY KEY,
+    name              VARCHAR(100)   NOT NULL,
+    daily_debit_limit NUMERIC(15, 2) NOT NULL,
+    p2p_allowed       BOOLEAN        NOT NULL,
+    max_balance       NUMERIC(15, 2) NOT NULL DEFAULT 0,
+    monthly_fee       NUMERIC(15, 2) NOT NULL DEFAULT 0,
+    created_at        TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ    NULL
+);
+
+COMMENT ON COLUMN products.max_balance IS 'zero means the balance is unlimited';
+
+INSERT INTO products (type, name, daily_debit_limit, p2p_allowed, max_balance, monthly_fee)
+VALUES ('CHECKING', 'Checking account', 2000, TRUE, 0, 0),
+       ('SAVINGS', 'Savings account', 1000, FALSE, 0, 0),
+       ('PAYMENT', 'Payment account', 1000, TRUE, 5000, 0),
+       ('BUSINESS', 'Business account', 50000, TRUE, 0, 29.90)
+ON CONFLICT (type) DO NOTHING;
+
+ALTER TABLE accounts
+    ADD COLUMN IF NOT EXISTS type VARCHAR(20) NOT NULL DEFAULT 'CHECKING' REFERENCES products (type);
@@ -0,0 +1,3 @@
+ALTER TABLE accounts
+    DROP COLUMN IF EXISTS closure_reason,
+    DROP COLUMN IF EXISTS closed_at;
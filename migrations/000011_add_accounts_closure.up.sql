@@ -0,0 +1,3 @@
+ALTER TABLE accounts
+    ADD COLUMN IF NOT EXISTS closure_reason VARCHAR(255) NULL,
+    ADD COLUMN IF NOT EXISTS closed_at      TIMESTAMPTZ  NULL;
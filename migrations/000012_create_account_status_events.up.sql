@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS account_status_events
+(
+    id          VARCHAR(36) PRIMARY KEY,
+    account_id  VARCHAR(36)  NOT NULL,
+    from_status VARCHAR(100) NOT NULL,
+    to_status   VARCHAR(100) NOT NULL,
+    reason      VARCHAR(255) NOT NULL,
+    actor       VARCHAR(100) NULL,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (account_id) REFERENCES accounts (id)
+);
+
+CREATE INDEX account_status_events_account_id_index ON account_status_events (account_id, created_at);
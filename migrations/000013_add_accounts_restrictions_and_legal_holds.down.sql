@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS account_legal_holds;
+
+ALTER TABLE account_status_events
+    DROP COLUMN IF EXISTS restrictions;
+
+ALTER TABLE accounts
+    DROP COLUMN IF EXISTS restrictions;
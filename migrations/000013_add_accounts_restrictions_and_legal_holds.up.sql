@@ -0,0 +1,23 @@
+ALTER TABLE accounts
+    ADD COLUMN IF NOT EXISTS restrictions TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE account_status_events
+    ADD COLUMN IF NOT EXISTS restrictions TEXT[] NULL;
+
+CREATE TABLE IF NOT EXISTS account_legal_holds
+(
+    id             VARCHAR(36) PRIMARY KEY,
+    account_id     VARCHAR(36)    NOT NULL,
+    amount         NUMERIC(15, 2) NOT NULL,
+    court_order    VARCHAR(100)   NOT NULL,
+    reason         VARCHAR(255)   NOT NULL,
+    actor          VARCHAR(100)   NULL,
+    status         VARCHAR(20)    NOT NULL,
+    created_at     TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    released_at    TIMESTAMPTZ    NULL,
+    release_reason VARCHAR(255)   NULL,
+    released_by    VARCHAR(100)   NULL,
+    FOREIGN KEY (account_id) REFERENCES accounts (id)
+);
+
+CREATE INDEX account_legal_holds_account_id_status_index ON account_legal_holds (account_id, status);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS account_holders
+(
+    account_id VARCHAR(36) NOT NULL,
+    holder_id  VARCHAR(36) NOT NULL,
+    role       VARCHAR(30) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (account_id, holder_id),
+    FOREIGN KEY (account_id) REFERENCES accounts (id),
+    FOREIGN KEY (holder_id) REFERENCES holders (id)
+);
+
+CREATE INDEX account_holders_holder_id_index ON account_holders (holder_id);
+
+-- every existing account keeps its holder as the owner.
+INSERT INTO account_holders (account_id, holder_id, role, created_at)
+SELECT id, holder_id, 'OWNER', created_at
+FROM accounts
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS accounts_parent_name;
+DROP INDEX IF EXISTS accounts_parent_id_index;
+
+ALTER TABLE accounts
+    DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,7 @@
+ALTER TABLE accounts
+    ADD COLUMN IF NOT EXISTS parent_id VARCHAR(36) NULL REFERENCES accounts (id);
+
+CREATE INDEX accounts_parent_id_index ON accounts (parent_id);
+
+-- pocket names are unique among the open pockets of an account.
+CREATE UNIQUE INDEX accounts_parent_name ON accounts (parent_id, name) WHERE parent_id IS NOT NULL AND status <> 'CLOSED';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS interest_accruals;
+DROP TABLE IF EXISTS interest_payouts;
+DROP TABLE IF EXISTS interest_rates;
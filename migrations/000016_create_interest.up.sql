@@ -0,0 +1,57 @@
+--
+-- Savings interest
+--
+-- the interest account funds every capitalization, so its balance goes negative as interest is paid.
+INSERT INTO holders (id, name, document_number, type, kyc_status)
+VALUES ('00000000-0000-0000-0000-000000000001', 'Interest expenses', '00000001000136', 'COMPANY', 'APPROVED')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO accounts (id, name, agency, number, holder_id, type, status)
+VALUES ('00000000-0000-0000-0000-000000000002', 'Interest expenses', '0001', '000001-9',
+        '00000000-0000-0000-0000-000000000001', 'CHECKING', 'ACTIVE')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO account_holders (account_id, holder_id, role)
+VALUES ('00000000-0000-0000-0000-000000000002', '00000000-0000-0000-0000-000000000001', 'OWNER')
+ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS interest_rates
+(
+    effective_from DATE PRIMARY KEY,
+    annual_rate    NUMERIC(9, 6) NOT NULL,
+    created_at     TIMESTAMPTZ   NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON COLUMN interest_rates.annual_rate IS 'savings annual rate as a fraction, 0.065 means 6.5% a year';
+
+CREATE TABLE IF NOT EXISTS interest_payouts
+(
+    id             VARCHAR(36) PRIMARY KEY,
+    account_id     VARCHAR(36)    NOT NULL,
+    month          DATE           NOT NULL,
+    gross_amount   NUMERIC(15, 2) NOT NULL,
+    tax_amount     NUMERIC(15, 2) NOT NULL,
+    net_amount     NUMERIC(15, 2) NOT NULL,
+    transaction_id VARCHAR(36)    NOT NULL,
+    created_at     TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (account_id) REFERENCES accounts (id),
+    FOREIGN KEY (transaction_id) REFERENCES transactions (id)
+);
+
+CREATE UNIQUE INDEX interest_payouts_account_month ON interest_payouts (account_id, month);
+
+CREATE TABLE IF NOT EXISTS interest_accruals
+(
+    account_id  VARCHAR(36)    NOT NULL,
+    accrued_on  DATE           NOT NULL,
+    balance     NUMERIC(15, 2) NOT NULL,
+    annual_rate NUMERIC(9, 6)  NOT NULL,
+    amount      NUMERIC(20, 8) NOT NULL,
+    payout_id   VARCHAR(36)    NULL,
+    created_at  TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (account_id, accrued_on),
+    FOREIGN KEY (account_id) REFERENCES accounts (id),
+    FOREIGN KEY (payout_id) REFERENCES interest_payouts (id)
+);
+
+CREATE INDEX interest_accruals_pending_index ON interest_accruals (account_id) WHERE payout_id IS NULL;
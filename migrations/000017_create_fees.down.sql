@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS fee_schedules;
+
+DROP INDEX IF EXISTS transactions_related_transaction_id_index;
+DROP INDEX IF EXISTS transactions_idempotency_key;
+
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS idempotency_key,
+    DROP COLUMN IF EXISTS related_transaction_id;
+
+UPDATE holders
+SET name = 'Interest expenses'
+WHERE id = '00000000-0000-0000-0000-000000000001';
@@ -0,0 +1,44 @@
+--
+-- Fees
+--
+-- the system holder now owns both the interest and the fee revenue accounts.
+UPDATE holders
+SET name = 'System accounts'
+WHERE id = '00000000-0000-0000-0000-000000000001';
+
+INSERT INTO accounts (id, name, agency, number, holder_id, type, status)
+VALUES ('00000000-0000-0000-0000-000000000003', 'Fee revenue', '0001', '000002-7',
+        '00000000-0000-0000-0000-000000000001', 'CHECKING', 'ACTIVE')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO account_holders (account_id, holder_id, role)
+VALUES ('00000000-0000-0000-0000-000000000003', '00000000-0000-0000-0000-000000000001', 'OWNER')
+ON CONFLICT DO NOTHING;
+
+-- fee transactions point to the transaction they were charged for, and charges made once per
+-- period carry a key that cannot repeat.
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS related_transaction_id VARCHAR(36)  NULL REFERENCES transactions (id),
+    ADD COLUMN IF NOT EXISTS idempotency_key        VARCHAR(100) NULL;
+
+CREATE UNIQUE INDEX transactions_idempotency_key ON transactions (idempotency_key) WHERE idempotency_key IS NOT NULL;
+CREATE INDEX transactions_related_transaction_id_index ON transactions (related_transaction_id);
+
+-- a schedule with a higher min_amount replaces the lower tiers of the same transaction type and
+-- product for transactions of at least that amount.
+CREATE TABLE IF NOT EXISTS fee_schedules
+(
+    id               VARCHAR(36) PRIMARY KEY,
+    transaction_type VARCHAR(36)    NOT NULL,
+    product_type     VARCHAR(20)    NOT NULL REFERENCES products (type),
+    min_amount       NUMERIC(15, 2) NOT NULL DEFAULT 0,
+    fixed_amount     NUMERIC(15, 2) NOT NULL DEFAULT 0,
+    percentage       NUMERIC(9, 6)  NOT NULL DEFAULT 0,
+    free_per_month   INTEGER        NOT NULL DEFAULT 0,
+    created_at       TIMESTAMPTZ    NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX fee_schedules_tier ON fee_schedules (transaction_type, product_type, min_amount);
+
+COMMENT ON COLUMN fee_schedules.percentage IS 'fraction of the transaction amount, 0.01 means 1%';
+COMMENT ON COLUMN fee_schedules.free_per_month IS 'transactions of the type free of charge each month';
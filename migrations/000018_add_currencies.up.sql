@@ -0,0 +1,54 @@
+--
+-- Currencies
+--
+-- every account and movement before this migration is in BRL.
+ALTER TABLE accounts
+    ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'BRL';
+
+-- amount is in currency, the one of the from account or, for credits, of the to account. Transfers
+-- between accounts of different currencies credit to_amount in to_currency, converted at fx_rate.
+ALTER TABLE transactions
+    ADD COLUMN IF NOT EXISTS currency    CHAR(3)        NOT NULL DEFAULT 'BRL',
+    ADD COLUMN IF NOT EXISTS to_currency CHAR(3)        NULL,
+    ADD COLUMN IF NOT EXISTS to_amount   NUMERIC(15, 2) NULL,
+    ADD COLUMN IF NOT EXISTS fx_rate     NUMERIC(20, 10) NULL;
+
+COMMENT ON COLUMN transactions.to_amount IS 'amount credited to the to account when converted, otherwise amount';
+
+-- local stand-in for a market rate provider, each pair is quoted in one direction only.
+CREATE TABLE IF NOT EXISTS fx_rates
+(
+    base_currency  CHAR(3)         NOT NULL,
+    quote_currency CHAR(3)         NOT NULL,
+    rate           NUMERIC(20, 10) NOT NULL CHECK (rate > 0),
+    updated_at     TIMESTAMPTZ     NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (base_currency, quote_currency)
+);
+
+INSERT INTO fx_rates (base_currency, quote_currency, rate)
+VALUES ('USD', 'BRL', 5.0),
+       ('EUR', 'BRL', 5.5),
+       ('EUR', 'USD', 1.1),
+       ('GBP', 'BRL', 6.4)
+ON CONFLICT DO NOTHING;
+
+CREATE OR REPLACE VIEW transactions_balances AS
+SELECT trxb.account_id AS account_id,
+       Sum(CASE trxb.type
+               WHEN 'credit' THEN trxb.amount
+               WHEN 'debit' THEN trxb.amount * -1
+           END)        AS balance,
+       acc.currency    AS currency
+FROM (SELECT tr.from_account_id AS account_id,
+             'debit'            AS type,
+             Sum(tr.amount)     AS amount
+      FROM transactions tr
+      GROUP BY tr.from_account_id
+      UNION ALL
+      SELECT tr.to_account_id                      AS account_id,
+             'credit'                              AS type,
+             Sum(COALESCE(tr.to_amount, tr.amount)) AS amount
+      FROM transactions tr
+      GROUP BY tr.to_account_id) AS trxb
+         JOIN accounts acc ON acc.id = trxb.account_id
+GROUP BY trxb.account_id, acc.currency;
@@ -0,0 +1,25 @@
+--
+-- Account keys
+--
+-- aliases holders register to receive payments without informing the account. A value can be
+-- pending in several accounts while the owners verify it, but active in only one.
+CREATE TABLE IF NOT EXISTS account_keys
+(
+    id                      VARCHAR(36) PRIMARY KEY,
+    account_id              VARCHAR(36)  NOT NULL REFERENCES accounts (id),
+    holder_id               VARCHAR(36)  NOT NULL REFERENCES holders (id),
+    type                    VARCHAR(20)  NOT NULL,
+    value                   VARCHAR(100) NOT NULL,
+    status                  VARCHAR(20)  NOT NULL,
+    verification_code_hash  VARCHAR(64)  NULL,
+    verification_expires_at TIMESTAMPTZ  NULL,
+    verification_attempts   INTEGER      NOT NULL DEFAULT 0,
+    created_at              TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    verified_at             TIMESTAMPTZ  NULL
+);
+
+CREATE UNIQUE INDEX account_keys_active_value ON account_keys (value) WHERE status = 'ACTIVE';
+CREATE UNIQUE INDEX account_keys_account_value ON account_keys (account_id, value);
+
+COMMENT ON COLUMN account_keys.holder_id IS 'holder who registered the key';
+COMMENT ON COLUMN account_keys.verification_code_hash IS 'sha256 of the code sent to email and phone keys';
@@ -0,0 +1,25 @@
+--
+-- Charges
+--
+-- payment requests issued by merchants, paid once through a P2P transaction whose idempotency key
+-- is 'charge:' || id.
+CREATE TABLE IF NOT EXISTS charges
+(
+    id               VARCHAR(36) PRIMARY KEY,
+    account_id       VARCHAR(36)    NOT NULL REFERENCES accounts (id),
+    amount           NUMERIC(15, 2) NOT NULL,
+    currency         CHAR(3)        NOT NULL,
+    description      VARCHAR(100)   NOT NULL DEFAULT '',
+    expires_at       TIMESTAMPTZ    NOT NULL,
+    status           VARCHAR(20)    NOT NULL,
+    payload          TEXT           NOT NULL,
+    payer_account_id VARCHAR(36)    NULL REFERENCES accounts (id),
+    transaction_id   VARCHAR(36)    NULL REFERENCES transactions (id),
+    paid_at          TIMESTAMPTZ    NULL,
+    created_at       TIMESTAMPTZ    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX charges_account_id_index ON charges (account_id);
+CREATE UNIQUE INDEX charges_transaction_id ON charges (transaction_id) WHERE transaction_id IS NOT NULL;
+
+COMMENT ON COLUMN charges.payload IS 'EMV QR code payload of the charge';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS boletos;
+DROP SEQUENCE IF EXISTS boletos_our_number_seq;
@@ -0,0 +1,35 @@
+--
+-- Boletos
+--
+-- bank slips issued by accounts, settled by a credit whose idempotency key is 'boleto:' || id when
+-- a return file reports their payment.
+CREATE SEQUENCE IF NOT EXISTS boletos_our_number_seq;
+
+CREATE TABLE IF NOT EXISTS boletos
+(
+    id                    VARCHAR(36) PRIMARY KEY,
+    account_id            VARCHAR(36)    NOT NULL REFERENCES accounts (id),
+    our_number            CHAR(11)       NOT NULL,
+    amount                NUMERIC(15, 2) NOT NULL,
+    due_date              DATE           NOT NULL,
+    fine_rate             NUMERIC(7, 6)  NOT NULL DEFAULT 0,
+    monthly_interest_rate NUMERIC(7, 6)  NOT NULL DEFAULT 0,
+    payer_name            VARCHAR(255)   NOT NULL,
+    payer_document_number VARCHAR(14)    NOT NULL,
+    description           VARCHAR(100)   NOT NULL DEFAULT '',
+    barcode               CHAR(44)       NOT NULL,
+    digitable_line        VARCHAR(54)    NOT NULL,
+    status                VARCHAR(20)    NOT NULL,
+    paid_amount           NUMERIC(15, 2) NULL,
+    paid_at               DATE           NULL,
+    transaction_id        VARCHAR(36)    NULL REFERENCES transactions (id),
+    created_at            TIMESTAMPTZ    NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX boletos_our_number ON boletos (our_number);
+CREATE INDEX boletos_account_id_index ON boletos (account_id);
+CREATE UNIQUE INDEX boletos_transaction_id ON boletos (transaction_id) WHERE transaction_id IS NOT NULL;
+
+COMMENT ON COLUMN boletos.our_number IS 'nosso número identifying the boleto in barcodes and return files';
+COMMENT ON COLUMN boletos.fine_rate IS 'fraction of the amount charged once on payments after the due date';
+COMMENT ON COLUMN boletos.monthly_interest_rate IS 'fraction of the amount accrued per month late, pro rata per day';
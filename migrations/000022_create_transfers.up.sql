@@ -0,0 +1,55 @@
+--
+-- Transfers
+--
+-- the clearing account holds the funds of TEDs to other banks until the bank confirms them.
+INSERT INTO accounts (id, name, agency, number, holder_id, type, status)
+VALUES ('00000000-0000-0000-0000-000000000004', 'TED clearing', '0001', '000003-5',
+        '00000000-0000-0000-0000-000000000001', 'CHECKING', 'ACTIVE')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO account_holders (account_id, holder_id, role)
+VALUES ('00000000-0000-0000-0000-000000000004', '00000000-0000-0000-0000-000000000001', 'OWNER')
+ON CONFLICT DO NOTHING;
+
+-- daily CNAB 240 remittance files, numbered by sequence as the bank expects.
+CREATE TABLE IF NOT EXISTS transfer_remittances
+(
+    id             VARCHAR(36) PRIMARY KEY,
+    sequence       BIGSERIAL      NOT NULL,
+    date           DATE           NOT NULL,
+    transfer_count INTEGER        NOT NULL DEFAULT 0,
+    total_amount   NUMERIC(15, 2) NOT NULL DEFAULT 0,
+    created_at     TIMESTAMPTZ    NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX transfer_remittances_date ON transfer_remittances (date);
+
+-- TEDs to accounts in other banks, debited by a transaction whose idempotency key is
+-- 'transfer:' || id and, when the bank rejects them, reversed by reversal_transaction_id.
+CREATE TABLE IF NOT EXISTS transfers
+(
+    id                      VARCHAR(36) PRIMARY KEY,
+    account_id              VARCHAR(36)    NOT NULL REFERENCES accounts (id),
+    amount                  NUMERIC(15, 2) NOT NULL,
+    bank_code               CHAR(3)        NOT NULL,
+    agency                  VARCHAR(5)     NOT NULL,
+    account_number          VARCHAR(14)    NOT NULL,
+    document_number         VARCHAR(14)    NOT NULL,
+    name                    VARCHAR(255)   NOT NULL,
+    description             VARCHAR(100)   NOT NULL DEFAULT '',
+    reference               CHAR(20)       NOT NULL,
+    status                  VARCHAR(20)    NOT NULL,
+    reversal_transaction_id VARCHAR(36)    NULL REFERENCES transactions (id),
+    remittance_id           VARCHAR(36)    NULL REFERENCES transfer_remittances (id),
+    occurrences             VARCHAR(10)    NOT NULL DEFAULT '',
+    created_at              TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    settled_at              TIMESTAMPTZ    NULL
+);
+
+CREATE UNIQUE INDEX transfers_reference ON transfers (reference);
+CREATE INDEX transfers_account_id_index ON transfers (account_id);
+CREATE INDEX transfers_remittance_id_index ON transfers (remittance_id);
+CREATE INDEX transfers_pending_index ON transfers (created_at) WHERE status = 'PENDING';
+
+COMMENT ON COLUMN transfers.reference IS 'seu número identifying the transfer in remittance and return files';
+COMMENT ON COLUMN transfers.occurrences IS 'codes the bank returned for the transfer, 00 when credited';
@@ -0,0 +1,41 @@
+--
+-- Payment batches
+--
+-- ISO 20022 pain.001 messages, each submitted once by its message id by the principal submitting it.
+-- A batch without status is still creating the transfers of its payments.
+CREATE TABLE IF NOT EXISTS payment_batches
+(
+    id                VARCHAR(36) PRIMARY KEY,
+    message_id        VARCHAR(35)    NOT NULL,
+    submitted_by      VARCHAR(100)   NOT NULL,
+    initiating_party  VARCHAR(140)   NOT NULL DEFAULT '',
+    transaction_count INTEGER        NOT NULL,
+    control_sum       NUMERIC(18, 2) NOT NULL DEFAULT 0,
+    status            VARCHAR(4)     NULL,
+    reason_code       VARCHAR(4)     NOT NULL DEFAULT '',
+    created_at        TIMESTAMPTZ    NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX payment_batches_submitted_by_message_id ON payment_batches (submitted_by, message_id);
+
+-- the status reported in pain.002 for each payment of a batch, in the order of the message, with
+-- the transfer it created. A payment without status is still creating its transfer.
+CREATE TABLE IF NOT EXISTS payment_batch_items
+(
+    id                     VARCHAR(36) PRIMARY KEY,
+    batch_id               VARCHAR(36)    NOT NULL REFERENCES payment_batches (id),
+    position               INTEGER        NOT NULL,
+    payment_information_id VARCHAR(35)    NOT NULL DEFAULT '',
+    instruction_id         VARCHAR(35)    NOT NULL DEFAULT '',
+    end_to_end_id          VARCHAR(35)    NOT NULL DEFAULT '',
+    account_id             VARCHAR(36)    NULL,
+    amount                 NUMERIC(15, 2) NOT NULL DEFAULT 0,
+    currency               VARCHAR(3)     NOT NULL DEFAULT '',
+    status                 VARCHAR(4)     NULL,
+    reason_code            VARCHAR(4)     NOT NULL DEFAULT '',
+    reason                 VARCHAR(255)   NOT NULL DEFAULT '',
+    transfer_id            VARCHAR(36)    NULL REFERENCES transfers (id),
+    created_at             TIMESTAMPTZ    NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX payment_batch_items_batch_id_position ON payment_batch_items (batch_id, position);
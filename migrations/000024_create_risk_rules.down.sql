@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS risk_rule_hits;
+DROP TABLE IF EXISTS risk_evaluations;
+DROP TABLE IF EXISTS risk_rules;
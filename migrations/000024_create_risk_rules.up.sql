@@ -0,0 +1,46 @@
+--
+-- Risk rules
+--
+-- checks debits go through before being made, changed at runtime. Each type of rule reads only its
+-- own parameters.
+CREATE TABLE IF NOT EXISTS risk_rules
+(
+    id             VARCHAR(36) PRIMARY KEY,
+    type           VARCHAR(30)    NOT NULL,
+    outcome        VARCHAR(10)    NOT NULL,
+    enabled        BOOLEAN        NOT NULL DEFAULT TRUE,
+    count          INTEGER        NOT NULL DEFAULT 0,
+    window_minutes INTEGER        NOT NULL DEFAULT 0,
+    multiplier     NUMERIC(9, 2)  NOT NULL DEFAULT 0,
+    min_amount     NUMERIC(15, 2) NOT NULL DEFAULT 0,
+    created_at     TIMESTAMPTZ    NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ    NOT NULL DEFAULT NOW()
+);
+
+-- every debit evaluated against the rules, whatever its outcome, for analysts.
+CREATE TABLE IF NOT EXISTS risk_evaluations
+(
+    id               VARCHAR(36) PRIMARY KEY,
+    account_id       VARCHAR(36)    NOT NULL REFERENCES accounts (id),
+    counterparty_id  VARCHAR(36)    NULL,
+    transaction_type VARCHAR(36)    NOT NULL,
+    amount           NUMERIC(15, 2) NOT NULL,
+    outcome          VARCHAR(10)    NOT NULL,
+    created_at       TIMESTAMPTZ    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX risk_evaluations_account_id_index ON risk_evaluations (account_id, created_at);
+CREATE INDEX risk_evaluations_outcome_index ON risk_evaluations (outcome, created_at);
+
+-- the rules an evaluation hit. rule_id is kept without a reference, so hits outlive deleted rules.
+CREATE TABLE IF NOT EXISTS risk_rule_hits
+(
+    id            VARCHAR(36) PRIMARY KEY,
+    evaluation_id VARCHAR(36)  NOT NULL REFERENCES risk_evaluations (id),
+    rule_id       VARCHAR(36)  NOT NULL,
+    rule_type     VARCHAR(30)  NOT NULL,
+    outcome       VARCHAR(10)  NOT NULL,
+    reason        VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX risk_rule_hits_evaluation_id_index ON risk_rule_hits (evaluation_id);
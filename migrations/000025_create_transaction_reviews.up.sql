@@ -0,0 +1,26 @@
+--
+-- Transaction reviews
+--
+-- P2Ps the risk rules parked for a manual review. Pending reviews hold their amount in the account
+-- until an analyst approves them, making the P2P with the idempotency key 'review:' || id, or
+-- rejects them. Reviews not decided by expires_at are rejected as EXPIRED.
+CREATE TABLE IF NOT EXISTS transaction_reviews
+(
+    id              VARCHAR(36) PRIMARY KEY,
+    evaluation_id   VARCHAR(36)    NOT NULL REFERENCES risk_evaluations (id),
+    from_account_id VARCHAR(36)    NOT NULL REFERENCES accounts (id),
+    to_account_id   VARCHAR(36)    NOT NULL REFERENCES accounts (id),
+    amount          NUMERIC(15, 2) NOT NULL,
+    currency        CHAR(3)        NOT NULL,
+    description     VARCHAR(200)   NOT NULL,
+    requested_by    VARCHAR(100)   NULL,
+    status          VARCHAR(10)    NOT NULL,
+    expires_at      TIMESTAMPTZ    NOT NULL,
+    decided_at      TIMESTAMPTZ    NULL,
+    decided_by      VARCHAR(100)   NULL,
+    decision_reason VARCHAR(255)   NULL,
+    created_at      TIMESTAMPTZ    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX transaction_reviews_status_index ON transaction_reviews (status, created_at);
+CREATE INDEX transaction_reviews_from_account_id_index ON transaction_reviews (from_account_id, status);
@@ -0,0 +1,36 @@
+--
+-- Approvals
+--
+-- operations under dual control: submitted by a principal and made, with the payload stored here,
+-- only after another principal approves them. result_id is what the operation made.
+CREATE TABLE IF NOT EXISTS approvals
+(
+    id           VARCHAR(36) PRIMARY KEY,
+    operation    VARCHAR(30)  NOT NULL,
+    account_id   VARCHAR(36)  NOT NULL REFERENCES accounts (id),
+    payload      JSONB        NOT NULL,
+    status       VARCHAR(10)  NOT NULL,
+    submitted_by VARCHAR(100) NOT NULL,
+    expires_at   TIMESTAMPTZ  NOT NULL,
+    decided_by   VARCHAR(100) NULL,
+    decided_at   TIMESTAMPTZ  NULL,
+    result_id    VARCHAR(36)  NULL,
+    error        TEXT         NULL,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX approvals_status_index ON approvals (status, expires_at);
+CREATE INDEX approvals_account_id_index ON approvals (account_id, created_at);
+
+-- the audit trail of every approval: who submitted, decided or made it, when and why.
+CREATE TABLE IF NOT EXISTS approval_events
+(
+    id          VARCHAR(36) PRIMARY KEY,
+    approval_id VARCHAR(36)  NOT NULL REFERENCES approvals (id),
+    action      VARCHAR(10)  NOT NULL,
+    actor       VARCHAR(100) NOT NULL,
+    reason      TEXT         NULL,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX approval_events_approval_id_index ON approval_events (approval_id, created_at);
@@ -0,0 +1,18 @@
+--
+-- API keys
+--
+-- keys authenticating the clients of the API. Only the sha256 hash of a key is stored, prefix keeps its first
+-- characters in clear to tell the keys apart.
+CREATE TABLE IF NOT EXISTS api_keys
+(
+    id         VARCHAR(36) PRIMARY KEY,
+    name       VARCHAR(100) NOT NULL,
+    prefix     VARCHAR(20)  NOT NULL,
+    key_hash   VARCHAR(64)  NOT NULL,
+    scopes     TEXT[]       NOT NULL DEFAULT '{}',
+    created_by VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMPTZ  NULL
+);
+
+CREATE UNIQUE INDEX api_keys_key_hash ON api_keys (key_hash);
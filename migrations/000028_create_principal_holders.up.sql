@@ -0,0 +1,14 @@
+--
+-- Principal holders
+--
+-- links the principals, API key ids or JWT subjects, to the holders whose accounts they access.
+CREATE TABLE IF NOT EXISTS principal_holders
+(
+    principal_id VARCHAR(100) NOT NULL,
+    holder_id    VARCHAR(36)  NOT NULL REFERENCES holders (id),
+    created_by   VARCHAR(100) NOT NULL,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (principal_id, holder_id)
+);
+
+CREATE INDEX principal_holders_holder_id_index ON principal_holders (holder_id);
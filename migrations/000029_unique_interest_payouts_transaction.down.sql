@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS interest_payouts_account_id_index;
+DROP INDEX IF EXISTS interest_payouts_transaction;
+
+-- payouts made at closures may repeat the month of a monthly payout, so the index is no longer unique.
+CREATE INDEX interest_payouts_account_month ON interest_payouts (account_id, month);
@@ -0,0 +1,10 @@
+--
+-- Savings interest payouts
+--
+-- interest is paid monthly and at the closure of the account, so a month may have more than one payout.
+-- each payout is the credit of a single transaction.
+DROP INDEX IF EXISTS interest_payouts_account_month;
+
+CREATE UNIQUE INDEX interest_payouts_transaction ON interest_payouts (transaction_id);
+
+CREATE INDEX interest_payouts_account_id_index ON interest_payouts (account_id);
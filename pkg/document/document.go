@@ -0,0 +1,120 @@
+package document
+
+import "strings"
+
+const (
+	cpfSize  = 11
+	cnpjSize = 14
+)
+
+var (
+	cpfFirstWeights   = []int{10, 9, 8, 7, 6, 5, 4, 3, 2}
+	cpfSecondWeights  = []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2}
+	cnpjFirstWeights  = []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	cnpjSecondWeights = []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+)
+
+// Normalize removes the formatting characters (dots, dashes, slashes and spaces) from the document number, so
+// 123.456.789-09 and 12345678909 are represented the same way. Any other character is kept, so a number with
+// letters stays invalid instead of having them dropped.
+func Normalize(number string) string {
+	var sb strings.Builder
+	for _, r := range number {
+		if !isFormatting(r) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+func isFormatting(r rune) bool {
+	return r == '.' || r == '-' || r == '/' || r == ' '
+}
+
+// IsCPF reports whether the number, formatted or not, is a CPF with valid check digits.
+func IsCPF(number string) bool {
+	number = Normalize(number)
+	if !onlyDigits(number) {
+		return false
+	}
+
+	digits := toDigits(number)
+	if len(digits) != cpfSize || repeated(digits) {
+		return false
+	}
+
+	return digits[9] == cpfCheckDigit(digits[:9], cpfFirstWeights) &&
+		digits[10] == cpfCheckDigit(digits[:10], cpfSecondWeights)
+}
+
+// IsCNPJ reports whether the number, formatted or not, is a CNPJ with valid check digits.
+func IsCNPJ(number string) bool {
+	number = Normalize(number)
+	if !onlyDigits(number) {
+		return false
+	}
+
+	digits := toDigits(number)
+	if len(digits) != cnpjSize || repeated(digits) {
+		return false
+	}
+
+	return digits[12] == cnpjCheckDigit(digits[:12], cnpjFirstWeights) &&
+		digits[13] == cnpjCheckDigit(digits[:13], cnpjSecondWeights)
+}
+
+// IsValid reports whether the number is a valid CPF or CNPJ.
+func IsValid(number string) bool {
+	return IsCPF(number) || IsCNPJ(number)
+}
+
+func cpfCheckDigit(digits, weights []int) int {
+	rest := (weightedSum(digits, weights) * 10) % 11
+	if rest == 10 {
+		return 0
+	}
+	return rest
+}
+
+func cnpjCheckDigit(digits, weights []int) int {
+	rest := weightedSum(digits, weights) % 11
+	if rest < 2 {
+		return 0
+	}
+	return 11 - rest
+}
+
+func weightedSum(digits, weights []int) int {
+	var sum int
+	for i, w := range weights {
+		sum += digits[i] * w
+	}
+	return sum
+}
+
+func onlyDigits(number string) bool {
+	for _, r := range number {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func toDigits(number string) []int {
+	digits := make([]int, len(number))
+	for i, r := range number {
+		digits[i] = int(r - '0')
+	}
+	return digits
+}
+
+// repeated rejects sequences like 111.111.111-11, that satisfy the check digits but are not issued.
+func repeated(digits []int) bool {
+	for _, d := range digits[1:] {
+		if d != digits[0] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+//go:build unit
+
+package document
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "52998224725", Normalize("529.982.247-25"))
+	assert.Equal(t, "11222333000181", Normalize("11.222.333/0001-81"))
+	assert.Equal(t, "52998224725", Normalize(" 52998224725 "))
+	assert.Equal(t, "52998a224725", Normalize("529.98a2.247-25"))
+	assert.Equal(t, "abc", Normalize("abc"))
+}
+
+func TestIsCPF(t *testing.T) {
+	cases := []struct {
+		number string
+		valid  bool
+	}{
+		{number: "529.982.247-25", valid: true},
+		{number: "52998224725", valid: true},
+		{number: "123.456.789-09", valid: true},
+		{number: "529.982.247-26", valid: false},
+		{number: "111.111.111-11", valid: false},
+		{number: "529.98a2.247-25", valid: false},
+		{number: "529.982.247-2a", valid: false},
+		{number: "5299822472", valid: false},
+		{number: "11.222.333/0001-81", valid: false},
+		{number: "", valid: false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.valid, IsCPF(c.number), c.number)
+	}
+}
+
+func TestIsCNPJ(t *testing.T) {
+	cases := []struct {
+		number string
+		valid  bool
+	}{
+		{number: "11.222.333/0001-81", valid: true},
+		{number: "11222333000181", valid: true},
+		{number: "11.222.333/0001-82", valid: false},
+		{number: "00.000.000/0000-00", valid: false},
+		{number: "11.222.333/0001-8x", valid: false},
+		{number: "529.982.247-25", valid: false},
+		{number: "", valid: false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.valid, IsCNPJ(c.number), c.number)
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	assert.True(t, IsValid("529.982.247-25"))
+	assert.True(t, IsValid("11.222.333/0001-81"))
+	assert.False(t, IsValid("12345678900"))
+}
@@ -0,0 +1,160 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/dalmarcogd/dock-test/pkg/zapctx"
+	"go.uber.org/zap"
+)
+
+const (
+	APIKeyHeader        = "X-API-Key"
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+)
+
+var (
+	ErrMissingCredentials = errors.New("the request has no API key nor bearer token")
+	ErrInvalidCredentials = errors.New("the credentials are not valid")
+)
+
+// PrincipalType is how a principal authenticated.
+type PrincipalType string
+
+const (
+	APIKeyPrincipal PrincipalType = "API_KEY"
+	JWTPrincipal    PrincipalType = "JWT"
+)
+
+// Principal is who made a request: the id of the API key or the subject of the JWT, with the scopes it was
+// granted.
+type Principal struct {
+	ID     string
+	Type   PrincipalType
+	Scopes []string
+}
+
+// HasScope tells whether the principal was granted the scope.
+func (p Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Authenticator finds the principal of a credential, returning ErrInvalidCredentials when it is not valid.
+type Authenticator interface {
+	Authenticate(ctx context.Context, credential string) (Principal, error)
+}
+
+// AuthenticatorFunc adapts a function to an Authenticator.
+type AuthenticatorFunc func(ctx context.Context, credential string) (Principal, error)
+
+func (f AuthenticatorFunc) Authenticate(ctx context.Context, credential string) (Principal, error) {
+	return f(ctx, credential)
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of the Go context carrying the principal.
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal authenticated for the request of the Go context.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// NewAuthenticationHTTPMiddleware returns a middleware that authenticates the API key of the X-API-Key header
+// with apiKeys or the bearer token of the Authorization header with tokens, answering 401 when there is none or
+// it is not valid. The principal is put in the request context and logged by zapctx. A nil authenticator
+// rejects its credentials.
+func NewAuthenticationHTTPMiddleware(apiKeys, tokens Authenticator, ignorePaths ...string) Middleware {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			ctx := request.Context()
+			for _, ignorePath := range ignorePaths {
+				if request.URL.Path == ignorePath {
+					handler.ServeHTTP(writer, request)
+					return
+				}
+			}
+
+			principal, err := authenticate(request, apiKeys, tokens)
+			if err != nil {
+				if errors.Is(err, ErrMissingCredentials) || errors.Is(err, ErrInvalidCredentials) {
+					zapctx.L(ctx).Warn("authentication_failed", zap.Error(err))
+					writer.Header().Set("WWW-Authenticate", "Bearer")
+					writer.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				zapctx.L(ctx).Error("authentication_error", zap.Error(err))
+				writer.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			ctx = WithPrincipal(ctx, principal)
+			ctx = zapctx.WithFields(
+				ctx,
+				zap.String("principal_id", principal.ID),
+				zap.String("principal_type", string(principal.Type)),
+			)
+
+			handler.ServeHTTP(writer, request.WithContext(ctx))
+		})
+	}
+}
+
+func authenticate(request *http.Request, apiKeys, tokens Authenticator) (Principal, error) {
+	if key := request.Header.Get(APIKeyHeader); key != "" {
+		if apiKeys == nil {
+			return Principal{}, ErrInvalidCredentials
+		}
+		return apiKeys.Authenticate(request.Context(), key)
+	}
+
+	authorization := request.Header.Get(authorizationHeader)
+	if authorization == "" {
+		return Principal{}, ErrMissingCredentials
+	}
+
+	if len(authorization) <= len(bearerPrefix) || !strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	if tokens == nil {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	return tokens.Authenticate(request.Context(), strings.TrimSpace(authorization[len(bearerPrefix):]))
+}
+
+// NewScopeHTTPMiddleware returns a middleware that answers 403 to the principals granted none of the scopes, so
+// it must run after the authentication.
+func NewScopeHTTPMiddleware(scopes ...string) Middleware {
+	return func(handler http.Handler) http.Handler {
+		return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			ctx := request.Context()
+
+			if principal, ok := PrincipalFromContext(ctx); ok {
+				for _, scope := range scopes {
+					if principal.HasScope(scope) {
+						handler.ServeHTTP(writer, request)
+						return
+					}
+				}
+			}
+
+			zapctx.L(ctx).Warn("authorization_missing_scope", zap.Strings("scopes", scopes))
+			writer.WriteHeader(http.StatusForbidden)
+		})
+	}
+}
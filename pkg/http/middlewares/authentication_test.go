@@ -0,0 +1,126 @@
+//go:build unit
+
+package middlewares
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+//nolint:funlen
+func Test_authenticationHTTPMiddleware(t *testing.T) {
+	apiKeys := AuthenticatorFunc(func(_ context.Context, credential string) (Principal, error) {
+		switch credential {
+		case "valid-key":
+			return Principal{ID: "key-id", Type: APIKeyPrincipal, Scopes: []string{"admin"}}, nil
+		case "broken-key":
+			return Principal{}, sql.ErrConnDone
+		default:
+			return Principal{}, ErrInvalidCredentials
+		}
+	})
+	tokens := AuthenticatorFunc(func(_ context.Context, credential string) (Principal, error) {
+		if credential == "valid-token" {
+			return Principal{ID: "subject", Type: JWTPrincipal}, nil
+		}
+		return Principal{}, ErrInvalidCredentials
+	})
+
+	var principal Principal
+	var authenticated bool
+	h := func(w http.ResponseWriter, r *http.Request) {
+		principal, authenticated = PrincipalFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}
+	chain := Chain(_handleHTTPTest{h}, NewAuthenticationHTTPMiddleware(apiKeys, tokens, "/liveness"))
+
+	serve := func(path string, headers map[string]string) int {
+		principal, authenticated = Principal{}, false
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		for k, v := range headers {
+			request.Header.Set(k, v)
+		}
+		response := httptest.NewRecorder()
+		chain.ServeHTTP(response, request)
+		return response.Code
+	}
+
+	t.Run("Handle ignored path without credentials", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve("/liveness", nil))
+		assert.False(t, authenticated)
+	})
+
+	t.Run("Handle missing credentials", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, serve("/v1/accounts", nil))
+	})
+
+	t.Run("Handle valid API key", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve("/v1/accounts", map[string]string{APIKeyHeader: "valid-key"}))
+		assert.True(t, authenticated)
+		assert.Equal(t, "key-id", principal.ID)
+		assert.True(t, principal.HasScope("admin"))
+	})
+
+	t.Run("Handle invalid API key", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, serve("/v1/accounts", map[string]string{APIKeyHeader: "other-key"}))
+	})
+
+	t.Run("Handle API key authentication error", func(t *testing.T) {
+		assert.Equal(t, http.StatusInternalServerError, serve("/v1/accounts", map[string]string{APIKeyHeader: "broken-key"}))
+	})
+
+	t.Run("Handle valid bearer token", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, serve("/v1/accounts", map[string]string{"Authorization": "Bearer valid-token"}))
+		assert.Equal(t, JWTPrincipal, principal.Type)
+		assert.Equal(t, "subject", principal.ID)
+	})
+
+	t.Run("Handle invalid authorization", func(t *testing.T) {
+		for _, authorization := range []string{"Bearer other-token", "Basic dXNlcjpwYXNz", "Bearer "} {
+			assert.Equal(t, http.StatusUnauthorized, serve("/v1/accounts", map[string]string{"Authorization": authorization}))
+		}
+	})
+
+	t.Run("Handle bearer token without authenticator", func(t *testing.T) {
+		chain := Chain(_handleHTTPTest{h}, NewAuthenticationHTTPMiddleware(apiKeys, nil))
+
+		request := httptest.NewRequest(http.MethodGet, "/v1/accounts", nil)
+		request.Header.Set("Authorization", "Bearer valid-token")
+		response := httptest.NewRecorder()
+		chain.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	})
+}
+
+func Test_scopeHTTPMiddleware(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+	chain := Chain(_handleHTTPTest{h}, NewScopeHTTPMiddleware("back-office", "admin"))
+
+	for _, tt := range []struct {
+		name      string
+		principal *Principal
+		want      int
+	}{
+		{"Handle without principal", nil, http.StatusForbidden},
+		{"Handle principal without the scope", &Principal{ID: "subject", Scopes: []string{"read"}}, http.StatusForbidden},
+		{"Handle principal with the scope", &Principal{ID: "subject", Scopes: []string{"read", "admin"}}, http.StatusOK},
+		{"Handle principal with another scope", &Principal{ID: "subject", Scopes: []string{"back-office"}}, http.StatusOK},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "/v1/api-keys", nil)
+			if tt.principal != nil {
+				request = request.WithContext(WithPrincipal(request.Context(), *tt.principal))
+			}
+			response := httptest.NewRecorder()
+			chain.ServeHTTP(response, request)
+			assert.Equal(t, tt.want, response.Code)
+		})
+	}
+}
@@ -3,7 +3,10 @@ package middlewares
 import (
 	"bytes"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"mime"
 	"net/http"
@@ -14,7 +17,7 @@ import (
 	"go.uber.org/zap"
 )
 
-var defaultContentTypes = []string{"application/json", "text/plain"}
+var defaultContentTypes = []string{"application/json", "text/plain", "application/xml"}
 
 // NewDefaultContentTypeValidator returns a middleware that validates if the content-type header is one of the default
 // content types pre-defined and if the request body is according to the content-type header.
@@ -109,6 +112,8 @@ func validContentBody(request *http.Request, mimeType string) bool {
 	case "application/json":
 		var jm json.RawMessage
 		result = json.Unmarshal(rawBody, &jm) == nil
+	case "application/xml":
+		result = validXML(rawBody)
 	default:
 		result = false
 	}
@@ -117,3 +122,22 @@ func validContentBody(request *http.Request, mimeType string) bool {
 
 	return result
 }
+
+// validXML reads every token of the body, so it is well-formed XML with at least one element.
+func validXML(rawBody []byte) bool {
+	decoder := xml.NewDecoder(bytes.NewReader(rawBody))
+
+	var elements int
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return elements > 0
+		}
+		if err != nil {
+			return false
+		}
+		if _, ok := token.(xml.StartElement); ok {
+			elements++
+		}
+	}
+}
@@ -155,6 +155,36 @@ func TestNewDefaultContentTypeValidator(t *testing.T) {
 		handlerFunc.ServeHTTP(mockResponseWriter, request)
 	})
 
+	t.Run("Handle request with valid body when content-type is application/xml", func(t *testing.T) {
+		request := &http.Request{
+			Header: map[string][]string{},
+			Body:   ioutil.NopCloser(bytes.NewBuffer([]byte(`<?xml version="1.0"?><Document><Id>1</Id></Document>`))),
+		}
+		request.Header.Set("Content-Type", "application/xml")
+
+		mockHandler.
+			EXPECT().
+			ServeHTTP(mockResponseWriter, request).
+			Times(1)
+
+		handlerFunc.ServeHTTP(mockResponseWriter, request)
+	})
+
+	t.Run("Respond bad request due invalid body when content-type is application/xml", func(t *testing.T) {
+		request := &http.Request{
+			Header: map[string][]string{},
+			Body:   ioutil.NopCloser(bytes.NewBuffer([]byte(`<Document><Id>1</Document>`))),
+		}
+		request.Header.Set("Content-Type", "application/xml")
+
+		mockResponseWriter.
+			EXPECT().
+			WriteHeader(http.StatusBadRequest).
+			Times(1)
+
+		handlerFunc.ServeHTTP(mockResponseWriter, request)
+	})
+
 	t.Run("Respond bad request due invalid body with unsupported mime type", func(t *testing.T) {
 		handlerFunc := validateContentType([]string{"text/css"})(mockHandler)
 
@@ -0,0 +1,269 @@
+package middlewares
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	hs256 = "HS256"
+	rs256 = "RS256"
+	// jwtLeeway is the clock skew tolerated between the issuer of the tokens and this server.
+	jwtLeeway = time.Minute
+)
+
+// JWK is a key of a JSON Web Key Set verifying the tokens: an oct key with its secret in K for HS256 or an RSA
+// public key with its modulus in N and exponent in E for RS256, all base64url encoded.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	K   string `json:"k"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type jwks struct {
+	Keys []JWK `json:"keys"`
+}
+
+type verificationKey struct {
+	kid    string
+	alg    string
+	secret []byte
+	public *rsa.PublicKey
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type jwtClaims struct {
+	Subject   string          `json:"sub"`
+	Issuer    string          `json:"iss"`
+	Audience  json.RawMessage `json:"aud"`
+	ExpiresAt *int64          `json:"exp"`
+	NotBefore *int64          `json:"nbf"`
+	// Scope is the space separated list of the scopes granted to the subject.
+	Scope string `json:"scope"`
+}
+
+type jwtAuthenticator struct {
+	keys     []verificationKey
+	issuer   string
+	audience string
+	now      func() time.Time
+}
+
+// NewJWTAuthenticatorFromFile authenticates JWT bearer tokens signed by the keys of a local JWKS file. See
+// NewJWTAuthenticator.
+func NewJWTAuthenticatorFromFile(path, issuer, audience string) (Authenticator, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var set jwks
+	if err := json.Unmarshal(content, &set); err != nil {
+		return nil, fmt.Errorf("invalid JWKS file %s: %w", path, err)
+	}
+
+	return NewJWTAuthenticator(set.Keys, issuer, audience)
+}
+
+// NewJWTAuthenticator authenticates JWT bearer tokens signed with HS256 or RS256 by one of the keys, not expired
+// and, when issuer and audience are not empty, issued by issuer to audience. The subject is the principal and
+// the scope claim its scopes.
+func NewJWTAuthenticator(keys []JWK, issuer, audience string) (Authenticator, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no key to verify the tokens")
+	}
+
+	verificationKeys := make([]verificationKey, len(keys))
+	for i, key := range keys {
+		vk, err := newVerificationKey(key)
+		if err != nil {
+			return nil, err
+		}
+		verificationKeys[i] = vk
+	}
+
+	return jwtAuthenticator{
+		keys:     verificationKeys,
+		issuer:   issuer,
+		audience: audience,
+		now:      time.Now,
+	}, nil
+}
+
+func newVerificationKey(key JWK) (verificationKey, error) {
+	switch key.Kty {
+	case "oct":
+		if key.Alg != "" && key.Alg != hs256 {
+			return verificationKey{}, fmt.Errorf("unsupported algorithm %s for the oct key %s", key.Alg, key.Kid)
+		}
+
+		secret, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(key.K, "="))
+		if err != nil || len(secret) == 0 {
+			return verificationKey{}, fmt.Errorf("invalid secret of the oct key %s", key.Kid)
+		}
+
+		return verificationKey{kid: key.Kid, alg: hs256, secret: secret}, nil
+	case "RSA":
+		if key.Alg != "" && key.Alg != rs256 {
+			return verificationKey{}, fmt.Errorf("unsupported algorithm %s for the RSA key %s", key.Alg, key.Kid)
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(key.N, "="))
+		if err != nil || len(n) == 0 {
+			return verificationKey{}, fmt.Errorf("invalid modulus of the RSA key %s", key.Kid)
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(key.E, "="))
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return verificationKey{}, fmt.Errorf("invalid exponent of the RSA key %s", key.Kid)
+		}
+
+		return verificationKey{
+			kid: key.Kid,
+			alg: rs256,
+			public: &rsa.PublicKey{
+				N: new(big.Int).SetBytes(n),
+				E: int(new(big.Int).SetBytes(e).Int64()),
+			},
+		}, nil
+	default:
+		return verificationKey{}, fmt.Errorf("unsupported key type %s of the key %s", key.Kty, key.Kid)
+	}
+}
+
+func (a jwtAuthenticator) Authenticate(_ context.Context, token string) (Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	if !a.verify(header, []byte(parts[0]+"."+parts[1]), signature) {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	if !a.validClaims(claims) {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	return Principal{
+		ID:     claims.Subject,
+		Type:   JWTPrincipal,
+		Scopes: strings.Fields(claims.Scope),
+	}, nil
+}
+
+// verify checks the signature with the keys of the algorithm of the header, only the one with its kid when the
+// header has one. The algorithm of the key is never taken from the token, so an RSA public key is never used
+// as an HMAC secret.
+func (a jwtAuthenticator) verify(header jwtHeader, signed, signature []byte) bool {
+	for _, key := range a.keys {
+		if key.alg != header.Alg || (header.Kid != "" && key.kid != header.Kid) {
+			continue
+		}
+
+		switch key.alg {
+		case hs256:
+			mac := hmac.New(sha256.New, key.secret)
+			_, _ = mac.Write(signed)
+			if hmac.Equal(mac.Sum(nil), signature) {
+				return true
+			}
+		case rs256:
+			digest := sha256.Sum256(signed)
+			if rsa.VerifyPKCS1v15(key.public, crypto.SHA256, digest[:], signature) == nil {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (a jwtAuthenticator) validClaims(claims jwtClaims) bool {
+	now := a.now()
+
+	if claims.Subject == "" || claims.ExpiresAt == nil {
+		return false
+	}
+
+	if now.After(time.Unix(*claims.ExpiresAt, 0).Add(jwtLeeway)) {
+		return false
+	}
+
+	if claims.NotBefore != nil && now.Before(time.Unix(*claims.NotBefore, 0).Add(-jwtLeeway)) {
+		return false
+	}
+
+	if a.issuer != "" && claims.Issuer != a.issuer {
+		return false
+	}
+
+	if a.audience != "" && !hasAudience(claims.Audience, a.audience) {
+		return false
+	}
+
+	return true
+}
+
+// hasAudience tells whether the aud claim, a string or an array of strings, has the audience.
+func hasAudience(raw json.RawMessage, audience string) bool {
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		return single == audience
+	}
+
+	var many []string
+	if err := json.Unmarshal(raw, &many); err != nil {
+		return false
+	}
+
+	for _, aud := range many {
+		if aud == audience {
+			return true
+		}
+	}
+
+	return false
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	content, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(content, v)
+}
@@ -0,0 +1,158 @@
+//go:build unit
+
+package middlewares
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func signJWT(t *testing.T, header, claims map[string]interface{}, sign func([]byte) []byte) string {
+	h, err := json.Marshal(header)
+	assert.NoError(t, err)
+	c, err := json.Marshal(claims)
+	assert.NoError(t, err)
+
+	signed := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(signed)))
+}
+
+//nolint:funlen
+func TestJWTAuthenticator(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	secret := []byte("a-secret-shared-with-the-issuer")
+	hmacSign := func(signed []byte) []byte {
+		mac := hmac.New(sha256.New, secret)
+		_, _ = mac.Write(signed)
+		return mac.Sum(nil)
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	rsaSign := func(signed []byte) []byte {
+		digest := sha256.Sum256(signed)
+		signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest[:])
+		assert.NoError(t, err)
+		return signature
+	}
+
+	keys := []JWK{
+		{Kty: "oct", Kid: "hmac", Alg: "HS256", K: base64.RawURLEncoding.EncodeToString(secret)},
+		{
+			Kty: "RSA",
+			Kid: "rsa",
+			Alg: "RS256",
+			N:   base64.RawURLEncoding.EncodeToString(privateKey.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(privateKey.E)).Bytes()),
+		},
+	}
+
+	content, err := json.Marshal(map[string]interface{}{"keys": keys})
+	assert.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	assert.NoError(t, os.WriteFile(path, content, 0o600))
+
+	authenticator, err := NewJWTAuthenticatorFromFile(path, "https://issuer.dock.tech", "dock-test-api")
+	assert.NoError(t, err)
+
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{
+			"sub":   "back-office-user",
+			"iss":   "https://issuer.dock.tech",
+			"aud":   []string{"other-api", "dock-test-api"},
+			"exp":   now.Add(time.Hour).Unix(),
+			"nbf":   now.Add(-time.Minute).Unix(),
+			"scope": "admin accounts:read",
+		}
+		for k, v := range overrides {
+			c[k] = v
+		}
+		return c
+	}
+
+	rs256Token := func(overrides map[string]interface{}) string {
+		return signJWT(t, map[string]interface{}{"alg": "RS256"}, claims(overrides), rsaSign)
+	}
+	hs256Token := func(kid string, sign func([]byte) []byte) string {
+		return signJWT(t, map[string]interface{}{"alg": "HS256", "kid": kid}, claims(nil), sign)
+	}
+
+	t.Run("success HS256", func(t *testing.T) {
+		principal, err := authenticator.Authenticate(ctx, hs256Token("hmac", hmacSign))
+		assert.NoError(t, err)
+		assert.Equal(t, Principal{
+			ID:     "back-office-user",
+			Type:   JWTPrincipal,
+			Scopes: []string{"admin", "accounts:read"},
+		}, principal)
+	})
+
+	t.Run("success RS256 without kid", func(t *testing.T) {
+		principal, err := authenticator.Authenticate(ctx, rs256Token(map[string]interface{}{"aud": "dock-test-api"}))
+		assert.NoError(t, err)
+		assert.Equal(t, "back-office-user", principal.ID)
+	})
+
+	t.Run("fail, invalid tokens", func(t *testing.T) {
+		for name, token := range map[string]string{
+			"malformed":     "not-a-token",
+			"unsigned":      signJWT(t, map[string]interface{}{"alg": "none"}, claims(nil), func([]byte) []byte { return nil }),
+			"wrong kid":     hs256Token("rsa", hmacSign),
+			"wrong secret":  hs256Token("", func([]byte) []byte { return []byte("forged") }),
+			"expired":       rs256Token(map[string]interface{}{"exp": now.Add(-time.Hour).Unix()}),
+			"not yet valid": rs256Token(map[string]interface{}{"nbf": now.Add(time.Hour).Unix()}),
+			"no expiration": rs256Token(map[string]interface{}{"exp": nil}),
+			"no subject":    rs256Token(map[string]interface{}{"sub": ""}),
+			"other issuer":  rs256Token(map[string]interface{}{"iss": "https://evil"}),
+			"other aud":     rs256Token(map[string]interface{}{"aud": "other-api"}),
+		} {
+			principal, err := authenticator.Authenticate(ctx, token)
+			assert.ErrorIs(t, err, ErrInvalidCredentials, name)
+			assert.Empty(t, principal, name)
+		}
+	})
+
+	t.Run("fail, RSA public key used as HMAC secret", func(t *testing.T) {
+		token := hs256Token("rsa", func(signed []byte) []byte {
+			mac := hmac.New(sha256.New, []byte(keys[1].N))
+			_, _ = mac.Write(signed)
+			return mac.Sum(nil)
+		})
+
+		_, err := authenticator.Authenticate(ctx, token)
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	t.Run("fail, invalid keys", func(t *testing.T) {
+		for _, key := range []JWK{
+			{Kty: "EC", Kid: "ec"},
+			{Kty: "oct", Kid: "empty"},
+			{Kty: "oct", Kid: "hs512", Alg: "HS512", K: "c2VjcmV0"},
+			{Kty: "RSA", Kid: "no-exponent", N: keys[1].N},
+		} {
+			_, err := NewJWTAuthenticator([]JWK{key}, "", "")
+			assert.Error(t, err, key.Kid)
+		}
+
+		_, err := NewJWTAuthenticator(nil, "", "")
+		assert.Error(t, err)
+
+		_, err = NewJWTAuthenticatorFromFile(filepath.Join(t.TempDir(), "missing.json"), "", "")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,27 @@
+package stringer
+
+import "strings"
+
+var accents = strings.NewReplacer(
+	"Á", "A", "À", "A", "Â", "A", "Ã", "A", "Ä", "A",
+	"É", "E", "È", "E", "Ê", "E", "Ë", "E",
+	"Í", "I", "Ì", "I", "Î", "I", "Ï", "I",
+	"Ó", "O", "Ò", "O", "Ô", "O", "Õ", "O", "Ö", "O",
+	"Ú", "U", "Ù", "U", "Û", "U", "Ü", "U",
+	"Ç", "C", "Ñ", "N",
+)
+
+// Plain fits the value to the plain text accepted by payment formats such as EMV and CNAB:
+// uppercase, no accents, printable ASCII only and at most size long.
+func Plain(value string, size int) string {
+	value = accents.Replace(strings.ToUpper(strings.TrimSpace(value)))
+
+	var sb strings.Builder
+	for _, r := range value {
+		if r >= ' ' && r <= '~' && sb.Len() < size {
+			sb.WriteRune(r)
+		}
+	}
+
+	return strings.TrimSpace(sb.String())
+}
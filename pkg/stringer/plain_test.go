@@ -0,0 +1,16 @@
+//go:build unit
+
+package stringer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlain(t *testing.T) {
+	assert.Equal(t, "JOAO DA SILVA", Plain(" João da Silva ", 25))
+	assert.Equal(t, "SAO PAULO", Plain("São Paulo", 15))
+	assert.Equal(t, "FLORIANOPOLIS S", Plain("Florianópolis SC", 15))
+	assert.Equal(t, "ACAI", Plain("Açaí ☕", 25))
+}
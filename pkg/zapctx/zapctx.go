@@ -8,10 +8,28 @@ import (
 	"go.uber.org/zap/zapcore"
 )
 
+type fieldsKey struct{}
+
+// WithFields returns a copy of the Go context whose logger, returned by L, logs the fields along with the ones
+// already in the context.
+func WithFields(ctx context.Context, fields ...zap.Field) context.Context {
+	current, _ := ctx.Value(fieldsKey{}).([]zap.Field)
+
+	merged := make([]zap.Field, 0, len(current)+len(fields))
+	merged = append(merged, current...)
+	merged = append(merged, fields...)
+
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
 // L returns the global logger with considering the Go context.
 func L(ctx context.Context) *zap.Logger {
 	logger := zap.L()
 
+	if fields, ok := ctx.Value(fieldsKey{}).([]zap.Field); ok {
+		logger = logger.With(fields...)
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if !span.IsRecording() {
 		return logger